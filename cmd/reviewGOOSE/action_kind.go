@@ -0,0 +1,105 @@
+package main
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// ActionKind is the next action expected on a PR: one of Turn's action kinds, or one
+// the goose derives itself when Turn reports none. PRs keep the raw kind Turn sent, so
+// menus and links still show kinds this build doesn't know about; anything that behaves
+// differently per kind switches on parseActionKind instead.
+type ActionKind string
+
+const (
+	actionNone    ActionKind = ""        // No action expected
+	actionUnknown ActionKind = "unknown" // A kind this build has no dedicated handling for
+
+	actionResolveComments  = ActionKind(turn.ActionResolveComments)
+	actionPublishDraft     = ActionKind(turn.ActionPublishDraft)
+	actionRequestReviewers = ActionKind(turn.ActionRequestReviewers)
+	actionReview           = ActionKind(turn.ActionReview)
+	actionReReview         = ActionKind(turn.ActionReReview)
+	actionReviewDiscussion = ActionKind(turn.ActionReviewDiscussion)
+	actionApprove          = ActionKind(turn.ActionApprove)
+	actionFixTests         = ActionKind(turn.ActionFixTests)
+	actionTestsPending     = ActionKind(turn.ActionTestsPending)
+	actionRerunTests       = ActionKind(turn.ActionRerunTests)
+	actionRespond          = ActionKind(turn.ActionRespond)
+	actionFixConflict      = ActionKind(turn.ActionFixConflict)
+	actionMerge            = ActionKind(turn.ActionMerge)
+
+	// actionApproveWorkflows is for PRs whose GitHub Actions runs are waiting for a
+	// maintainer to approve them (typical for forks and first-time contributors).
+	actionApproveWorkflows ActionKind = "approve_workflows"
+	// actionInvestigateCI is for my PRs whose tests have been running far longer than
+	// healthy CI would (a hung runner, or workflows awaiting approval).
+	actionInvestigateCI ActionKind = "investigate_ci"
+	// actionSubmitReview is for incoming PRs where my review is drafted but unsubmitted.
+	actionSubmitReview ActionKind = "submit_review"
+)
+
+// knownActionKinds are the kinds with dedicated handling. Every per-kind switch must
+// cover all of them; action_kind_test.go checks each consumer against this list.
+var knownActionKinds = []ActionKind{
+	actionResolveComments,
+	actionPublishDraft,
+	actionRequestReviewers,
+	actionReview,
+	actionReReview,
+	actionReviewDiscussion,
+	actionApprove,
+	actionFixTests,
+	actionTestsPending,
+	actionRerunTests,
+	actionRespond,
+	actionFixConflict,
+	actionMerge,
+	actionApproveWorkflows,
+	actionInvestigateCI,
+	actionSubmitReview,
+}
+
+// parseActionKind maps a raw kind to its constant, or to actionUnknown when this build
+// has no dedicated handling for it.
+func parseActionKind[S ~string](raw S) ActionKind {
+	k := ActionKind(raw)
+	if k == actionNone || slices.Contains(knownActionKinds, k) {
+		return k
+	}
+	return actionUnknown
+}
+
+// label is the kind as shown in menus and explanations, e.g. "fix tests".
+func (k ActionKind) label() string {
+	return strings.ReplaceAll(string(k), "_", " ")
+}
+
+// gooseParam is the goose query parameter value for links opened for this kind.
+func (k ActionKind) gooseParam() string {
+	if k == actionNone {
+		return "next_action"
+	}
+	return string(k)
+}
+
+// outgoingIcon is the tray icon when this is the only kind blocking my PRs: the
+// cockroach when it's failing tests, the party popper otherwise.
+func (k ActionKind) outgoingIcon() IconType {
+	switch parseActionKind(k) {
+	case actionFixTests:
+		return IconCockroach
+	case actionNone, actionUnknown,
+		actionResolveComments, actionPublishDraft, actionRequestReviewers,
+		actionReview, actionReReview, actionReviewDiscussion, actionApprove,
+		actionTestsPending, actionRerunTests, actionRespond, actionFixConflict, actionMerge,
+		actionApproveWorkflows, actionInvestigateCI, actionSubmitReview:
+		return IconPopper
+	}
+	// Only reachable when a kind is added to knownActionKinds without a case above
+	slog.Warn("[ACTION] No tray icon for action kind", "kind", k)
+	return IconWarning
+}
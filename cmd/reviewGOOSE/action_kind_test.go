@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseActionKind(t *testing.T) {
+	seen := make(map[ActionKind]bool, len(knownActionKinds))
+	for _, k := range knownActionKinds {
+		if seen[k] {
+			t.Errorf("%q listed twice in knownActionKinds", k)
+		}
+		seen[k] = true
+		if got := parseActionKind(string(k)); got != k {
+			t.Errorf("parseActionKind(%q) = %q, want itself", k, got)
+		}
+	}
+	if got := parseActionKind(""); got != actionNone {
+		t.Errorf("parseActionKind(\"\") = %q, want none", got)
+	}
+	if got := parseActionKind("summon_goose"); got != actionUnknown {
+		t.Errorf("parseActionKind(\"summon_goose\") = %q, want unknown", got)
+	}
+}
+
+// Every kind, including ones Turn adds later, must render as something: a kind missing
+// from a consumer's switch shows up here rather than as a wrong icon in the tray.
+func TestActionKindConsumersCoverEveryKind(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	kinds := append([]ActionKind{"summon_goose"}, knownActionKinds...)
+	for _, k := range kinds {
+		t.Run(string(k), func(t *testing.T) {
+			if k.label() == "" {
+				t.Error("label() is empty")
+			}
+			if k.gooseParam() == "" {
+				t.Error("gooseParam() is empty")
+			}
+			if icon := k.outgoingIcon(); icon != IconPopper && icon != IconCockroach {
+				t.Errorf("outgoingIcon() = %v, want popper or cockroach", icon)
+			}
+			if text, ok := builtinNotifyTemplate(k); ok {
+				if err := validateNotifyTemplate(text); err != nil {
+					t.Errorf("built-in template %q: %v", text, err)
+				}
+			}
+			pr := PR{Repository: "acme/widgets", Number: 7, Title: "Add retries", IsBlocked: true, ActionKind: k}
+			if prNextAction(pr) == "" {
+				t.Error("prNextAction() is empty")
+			}
+			if app.notificationBody(&pr) == "" {
+				t.Error("notificationBody() is empty")
+			}
+		})
+	}
+}
+
+func TestActionKindOutgoingIcon(t *testing.T) {
+	if got := actionFixTests.outgoingIcon(); got != IconCockroach {
+		t.Errorf("fix_tests icon = %v, want cockroach", got)
+	}
+	if got := actionMerge.outgoingIcon(); got != IconPopper {
+		t.Errorf("merge icon = %v, want popper", got)
+	}
+	if got := actionNone.gooseParam(); got != "next_action" {
+		t.Errorf("gooseParam() without a kind = %q, want next_action", got)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// A fixed interval polls just as often overnight as during review ping-pong. With the
+// adaptive interval setting on, cycles that find nothing changed stretch the interval
+// toward a ceiling, and any sign of activity snaps it back to the configured one.
+
+const (
+	// adaptiveIntervalCeiling is the longest the adaptive interval stretches to.
+	adaptiveIntervalCeiling = 5 * time.Minute
+	// adaptiveQuietCycles is how many unchanged cycles in a row stretch the interval.
+	adaptiveQuietCycles = 3
+)
+
+// adaptiveInterval owns the update loop's interval in adaptive mode: the base interval
+// while PRs are changing, stretched by half again for each quiet cycle past
+// adaptiveQuietCycles, up to the ceiling.
+type adaptiveInterval struct {
+	snapped chan struct{} // Signals the update loop to reset a stretched ticker
+	base    time.Duration
+	ceiling time.Duration
+	current time.Duration
+	quiet   int  // Consecutive cycles that found nothing changed
+	active  bool // Activity was seen since the last cycle
+	mu      sync.Mutex
+}
+
+func newAdaptiveInterval(base time.Duration) *adaptiveInterval {
+	return &adaptiveInterval{
+		snapped: make(chan struct{}, 1),
+		base:    base,
+		ceiling: max(base, adaptiveIntervalCeiling),
+		current: base,
+	}
+}
+
+// next finishes a cycle and returns the interval until the next one. Without adaptive
+// mode it always returns the base interval.
+func (a *adaptiveInterval) next(enabled bool) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case !enabled, a.active:
+		a.active = false
+		a.quiet = 0
+		a.current = a.base
+	default:
+		a.quiet++
+		if a.quiet >= adaptiveQuietCycles {
+			a.current = min(a.current+a.current/2, a.ceiling)
+		}
+	}
+	return a.current
+}
+
+// activity snaps the interval back to the base, and reports whether it was stretched.
+func (a *adaptiveInterval) activity() bool {
+	a.mu.Lock()
+	stretched := a.current > a.base
+	a.active = true
+	a.quiet = 0
+	a.current = a.base
+	a.mu.Unlock()
+
+	if stretched {
+		select {
+		case a.snapped <- struct{}{}:
+		default:
+		}
+	}
+	return stretched
+}
+
+// interval returns the interval the update loop is currently using.
+func (a *adaptiveInterval) interval() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.current
+}
+
+// nextUpdateInterval returns how long the update loop waits before the next cycle.
+func (app *App) nextUpdateInterval() time.Duration {
+	if app.intervals == nil {
+		return app.updateInterval
+	}
+	next := app.intervals.next(app.readSetting(&app.adaptiveInterval))
+	if next != app.updateInterval {
+		slog.Debug("[UPDATE] Adaptive interval stretched", "interval", next, "base", app.updateInterval)
+	}
+	return next
+}
+
+// updateActivity snaps a stretched update interval back to the base, e.g. after a
+// sprinkler event or a forced refresh.
+func (app *App) updateActivity(reason string) {
+	if app.intervals != nil && app.intervals.activity() {
+		slog.Info("[UPDATE] Activity, restoring the base update interval", "reason", reason, "interval", app.updateInterval)
+	}
+}
+
+// intervalSnapped returns the channel signaled when a stretched interval snaps back,
+// or nil, which never fires, without an adaptive interval.
+func (app *App) intervalSnapped() <-chan struct{} {
+	if app.intervals == nil {
+		return nil
+	}
+	return app.intervals.snapped
+}
+
+// updateIntervalTitle returns the Debug submenu line for the effective update interval
+// in adaptive mode, e.g. "Update interval: 2m15s (base 1m0s)", or "" when it's off.
+func (app *App) updateIntervalTitle() string {
+	if app.intervals == nil || !app.readSetting(&app.adaptiveInterval) {
+		return ""
+	}
+	return msg("debug.update_interval", app.intervals.interval(), app.updateInterval)
+}
+
+// prStatesChanged reports whether a cycle's PR lists differ from the previous ones in
+// anything that would change what the menu shows.
+func prStatesChanged(before, after []PR) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	now := indexPRs(after)
+	for i := range before {
+		b := &before[i]
+		a := now.get(b.URL)
+		if a == nil || !a.UpdatedAt.Equal(b.UpdatedAt) || turnStateChanged(b, a) {
+			return true
+		}
+	}
+	return false
+}
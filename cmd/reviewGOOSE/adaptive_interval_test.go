@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveIntervalStretchesWhileQuiet(t *testing.T) {
+	a := newAdaptiveInterval(time.Minute)
+	var got []string
+	for range 8 {
+		got = append(got, a.next(true).String())
+	}
+	want := "[1m0s 1m0s 1m30s 2m15s 3m22.5s 5m0s 5m0s 5m0s]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("quiet cycles = %v, want %s", got, want)
+	}
+}
+
+func TestAdaptiveIntervalSnapsBack(t *testing.T) {
+	a := newAdaptiveInterval(time.Minute)
+	for range 5 {
+		a.next(true)
+	}
+	if a.interval() <= time.Minute {
+		t.Fatalf("interval after quiet cycles = %v, want stretched", a.interval())
+	}
+	if !a.activity() {
+		t.Error("activity() on a stretched interval = false, want true")
+	}
+	select {
+	case <-a.snapped:
+	default:
+		t.Error("snapping back didn't signal the update loop")
+	}
+	if got := a.interval(); got != time.Minute {
+		t.Errorf("interval after activity = %v, want base", got)
+	}
+	// The cycle that saw the activity doesn't count as quiet
+	if got := a.next(true); got != time.Minute {
+		t.Errorf("next() after activity = %v, want base", got)
+	}
+	if got := a.next(true); got != time.Minute {
+		t.Errorf("second quiet cycle = %v, want base until %d quiet cycles", got, adaptiveQuietCycles)
+	}
+
+	// Activity at the base doesn't wake the update loop
+	if a.activity() {
+		t.Error("activity() at the base interval = true, want false")
+	}
+	select {
+	case <-a.snapped:
+		t.Error("activity at the base interval signaled the update loop")
+	default:
+	}
+}
+
+func TestAdaptiveIntervalBusyNeverStretches(t *testing.T) {
+	a := newAdaptiveInterval(time.Minute)
+	for i := range 20 {
+		if i%2 == 0 {
+			a.activity()
+		}
+		if got := a.next(true); got != time.Minute {
+			t.Fatalf("cycle %d = %v, want base while busy", i, got)
+		}
+	}
+}
+
+func TestAdaptiveIntervalFloorAndCeiling(t *testing.T) {
+	a := newAdaptiveInterval(time.Minute)
+	for range 4 {
+		a.next(true)
+	}
+	if got := a.next(false); got != time.Minute {
+		t.Errorf("next() with adaptive mode off = %v, want base", got)
+	}
+
+	// A base above the ceiling is never stretched, or shrunk
+	slow := newAdaptiveInterval(10 * time.Minute)
+	for range 10 {
+		if got := slow.next(true); got != 10*time.Minute {
+			t.Fatalf("next() with a 10m base = %v, want 10m", got)
+		}
+	}
+}
+
+func TestPRStatesChanged(t *testing.T) {
+	now := time.Now()
+	before := []PR{
+		{URL: "https://github.com/acme/widgets/pull/1", UpdatedAt: now},
+		{URL: "https://github.com/acme/widgets/pull/2", UpdatedAt: now, IsBlocked: true, ActionKind: actionReview},
+	}
+	same := []PR{before[1], before[0]}
+	if prStatesChanged(before, same) {
+		t.Error("reordered lists reported as changed")
+	}
+	unblocked := []PR{before[0], before[1]}
+	unblocked[1].IsBlocked = false
+	if !prStatesChanged(before, unblocked) {
+		t.Error("unblocked PR not reported as changed")
+	}
+	if !prStatesChanged(before, before[:1]) {
+		t.Error("closed PR not reported as changed")
+	}
+	pushed := []PR{before[0], before[1]}
+	pushed[0].UpdatedAt = now.Add(time.Minute)
+	if !prStatesChanged(before, pushed) {
+		t.Error("updated PR not reported as changed")
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const refreshFrameInterval = 1 * time.Second // Alternate refresh frames at 1Hz
+
+//go:embed icons/refresh-1.png
+var iconRefresh1 []byte
+
+//go:embed icons/refresh-2.png
+var iconRefresh2 []byte
+
+// refreshFrames returns the icon frames shown while a forced refresh is running.
+func refreshFrames() [][]byte {
+	return [][]byte{iconRefresh1, iconRefresh2}
+}
+
+// refreshAnimationDefault reports whether the refresh animation is enabled by default.
+// StatusNotifier hosts on Linux/BSD re-register the icon on every update, which
+// flickers badly, so the animation is opt-in there.
+func refreshAnimationDefault() bool {
+	return runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+}
+
+// Animator cycles the tray icon through a set of frames until stopped.
+// It never outlives the context passed to Start, and Stop waits for the
+// animation goroutine to exit so callers can safely restore the icon afterwards.
+type Animator struct {
+	tray     SystrayInterface
+	goFn     goFunc // Starts the animation goroutine; nil starts an untracked one
+	cancel   context.CancelFunc
+	done     chan struct{}
+	frames   [][]byte
+	interval time.Duration
+	mu       sync.Mutex
+}
+
+// NewAnimator creates an animator for the given tray and frames.
+func NewAnimator(tray SystrayInterface, frames [][]byte, interval time.Duration) *Animator {
+	return &Animator{
+		tray:     tray,
+		frames:   frames,
+		interval: interval,
+	}
+}
+
+// Start begins the animation. It is a no-op if the animation is already running
+// or there are no frames. The animation stops when ctx is cancelled or Stop is called.
+func (a *Animator) Start(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.done != nil || len(a.frames) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	a.cancel = cancel
+	a.done = done
+
+	started := a.goFn.start("refresh animation", func() {
+		defer close(done)
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		frame := 0
+		a.tray.SetIcon(a.frames[frame])
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				frame = (frame + 1) % len(a.frames)
+				a.tray.SetIcon(a.frames[frame])
+			}
+		}
+	})
+	if !started {
+		// Shutting down; Stop has nothing to wait for
+		close(done)
+		return
+	}
+	slog.Debug("[ANIMATOR] Started", "frames", len(a.frames), "interval", a.interval)
+}
+
+// Stop ends the animation and waits for it to finish. It is safe to call
+// multiple times, and after the context passed to Start was cancelled.
+func (a *Animator) Stop() {
+	a.mu.Lock()
+	cancel, done := a.cancel, a.done
+	a.cancel, a.done = nil, nil
+	a.mu.Unlock()
+
+	if done == nil {
+		return
+	}
+	cancel()
+	<-done
+	slog.Debug("[ANIMATOR] Stopped")
+}
+
+// Running reports whether the animation goroutine is active.
+func (a *Animator) Running() bool {
+	a.mu.Lock()
+	done := a.done
+	a.mu.Unlock()
+
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// forceRefresh runs a user-triggered update that re-checks every PR with Turn,
+// animating the tray icon while it's in flight.
+func (app *App) forceRefresh(ctx context.Context) {
+	app.mu.Lock()
+	animate := app.enableRefreshAnimation
+	app.forceNextRefresh = true
+	app.mu.Unlock()
+	app.updateActivity("forced refresh")
+
+	if !animate {
+		app.updatePRs(ctx)
+		return
+	}
+
+	anim := NewAnimator(app.systrayInterface, refreshFrames(), refreshFrameInterval)
+	anim.goFn = app.goTracked
+	anim.Start(ctx)
+	app.updatePRs(ctx)
+	anim.Stop()
+
+	// Restore the icon that updatePRs chose: warning on failure, count-based otherwise
+	app.presentTrayState()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func (m *MockSystray) iconState() (last []byte, sets int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastIcon, m.iconSets
+}
+
+func TestAnimatorStartStop(t *testing.T) {
+	mock := &MockSystray{}
+	frames := [][]byte{[]byte("frame-a"), []byte("frame-b")}
+	anim := NewAnimator(mock, frames, 10*time.Millisecond)
+
+	anim.Start(context.Background())
+	if !anim.Running() {
+		t.Fatal("expected animator to be running after Start")
+	}
+
+	// Starting again while running is a no-op
+	anim.Start(context.Background())
+
+	time.Sleep(50 * time.Millisecond)
+	anim.Stop()
+	if anim.Running() {
+		t.Fatal("expected animator to be stopped")
+	}
+
+	_, sets := mock.iconState()
+	if sets < 2 {
+		t.Errorf("expected animator to alternate frames, got %d icon updates", sets)
+	}
+
+	// No further frames after Stop returns
+	time.Sleep(30 * time.Millisecond)
+	if _, after := mock.iconState(); after != sets {
+		t.Errorf("icon updated %d times after Stop", after-sets)
+	}
+
+	// Stop is idempotent
+	anim.Stop()
+}
+
+func TestAnimatorContextCancel(t *testing.T) {
+	mock := &MockSystray{}
+	anim := NewAnimator(mock, [][]byte{[]byte("a"), []byte("b")}, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	anim.Start(ctx)
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for anim.Running() {
+		if time.Now().After(deadline) {
+			t.Fatal("animator outlived its context")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Stop after cancellation must not block
+	done := make(chan struct{})
+	go func() {
+		anim.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocked after context cancellation")
+	}
+
+	// Can be restarted after stopping
+	anim.Start(context.Background())
+	if !anim.Running() {
+		t.Error("expected animator to restart")
+	}
+	anim.Stop()
+}
+
+func TestAnimatorNoFrames(t *testing.T) {
+	anim := NewAnimator(&MockSystray{}, nil, 10*time.Millisecond)
+	anim.Start(context.Background())
+	if anim.Running() {
+		t.Error("animator without frames should not run")
+	}
+	anim.Stop()
+}
+
+func TestAnimatorDuringShutdown(t *testing.T) {
+	l := newLifecycle(func() {})
+	l.Shutdown(shutdownTimeout, func() {})
+	app := &App{lifecycle: l}
+	anim := NewAnimator(&MockSystray{}, [][]byte{[]byte("a")}, 10*time.Millisecond)
+	anim.goFn = app.goTracked
+	// Nothing starts once shutdown has begun, and Stop doesn't wait for it
+	anim.Start(context.Background())
+	anim.Stop()
+	if anim.Running() {
+		t.Error("animator running after Stop")
+	}
+}
+
+func TestForceRefreshRestoresIcon(t *testing.T) {
+	mock := &MockSystray{}
+	app := &App{
+		mu:                     sync.RWMutex{},
+		systrayInterface:       mock,
+		stateManager:           NewPRStateManager(time.Now()),
+		hiddenOrgs:             make(map[string]bool),
+		seenOrgs:               make(map[string]orgActivity),
+		enableRefreshAnimation: true,
+		updateInterval:         time.Minute,
+	}
+
+	// No GitHub client, so the update fails and the warning icon must be restored
+	app.forceRefresh(context.Background())
+
+	last, _ := mock.iconState()
+	for _, frame := range refreshFrames() {
+		if bytes.Equal(last, frame) {
+			t.Fatal("refresh frame left on tray after update completed")
+		}
+	}
+	if want := getIcon(IconWarning, PRCounts{}); !bytes.Equal(last, want) {
+		t.Error("expected warning icon to be restored after a failed refresh")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// The GitHub and Turn API clients can be pointed at a local proxy, such as a caching
+// mitm on http://127.0.0.1:8443 that pools rate limits across tools. Plain http is
+// allowed only for loopback hosts, and only for these API clients: URLs opened in the
+// browser still go through safebrowse, which requires https.
+
+// parseAPIBaseURL validates an API base URL: https anywhere, or http on a loopback host.
+func parseAPIBaseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL %q: %w", raw, err)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return nil, fmt.Errorf("API URL %q has no host", raw)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("API URL %q may not carry user info, a query, or a fragment", raw)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !isLoopbackHost(u.Hostname()) {
+			return nil, fmt.Errorf("API URL %q uses http on a non-loopback host; use https, or http only with 127.0.0.1, ::1, or localhost", raw)
+		}
+	default:
+		return nil, fmt.Errorf("API URL %q must use https, or http on a loopback host", raw)
+	}
+	// go-github resolves request paths against the base URL, so it needs a trailing slash
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP address.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// turnServerURL returns the Turn base URL for TURNSERVER: a bare hostname gets https,
+// a full URL is validated like the GitHub API URL.
+func turnServerURL(server string) (string, error) {
+	if server == "" {
+		return "", errors.New("empty turn server")
+	}
+	if !strings.Contains(server, "://") {
+		return "https://" + server, nil
+	}
+	u, err := parseAPIBaseURL(server)
+	if err != nil {
+		return "", fmt.Errorf("TURNSERVER: %w", err)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// newGitHubClient creates a GitHub client on hc, talking to base when set and to
+// api.github.com otherwise.
+func newGitHubClient(hc *http.Client, base *url.URL) *github.Client {
+	client := github.NewClient(hc)
+	if base != nil {
+		client.BaseURL = base
+	}
+	return client
+}
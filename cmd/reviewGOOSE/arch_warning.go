@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/codeGROOVE-dev/goose/pkg/hostarch"
+)
+
+// The Intel build runs on Apple Silicon under Rosetta, and everything works except
+// sound playback and login-item registration, which fail silently. goose checks its
+// architecture at startup, logs a mismatch, and shows a menu line pointing at the right
+// build until it's dismissed for good.
+
+// releasesURL is where the builds for each architecture are published.
+const releasesURL = "https://github.com/codeGROOVE-dev/goose/releases/latest"
+
+// checkArch detects whether goose runs on a host of another architecture.
+func (app *App) checkArch(ctx context.Context, probes hostarch.Probes) {
+	arch := hostarch.Detect(ctx, probes)
+	app.mu.Lock()
+	app.arch = arch
+	app.mu.Unlock()
+	if !arch.Mismatch() {
+		return
+	}
+	slog.Warn("[ARCH] Running a build for another architecture; sounds and login items may fail",
+		"build", arch.BuildArch, "host", arch.HostArch, "rosetta", arch.Translated,
+		"download", releasesURL)
+}
+
+// archWarningTitle is the architecture warning menu line, or "" when the build matches
+// the host or the warning was dismissed.
+func (app *App) archWarningTitle() string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if !app.arch.Mismatch() || app.archWarningDismissed {
+		return ""
+	}
+	if app.arch.RosettaOnAppleSilicon() {
+		return msg("arch.rosetta")
+	}
+	return msg("arch.mismatch", app.arch.BuildArch, app.arch.HostArch)
+}
+
+// addArchWarning adds the architecture warning, with a download link and a way to
+// dismiss it for good.
+func (app *App) addArchWarning(ctx context.Context) {
+	title := app.archWarningTitle()
+	if title == "" {
+		return
+	}
+	item := app.systrayInterface.AddMenuItem(title, msg("arch.tooltip"))
+	item.AddSubMenuItem(msg("arch.download"), releasesURL).Click(func() {
+		if err := app.openBrowser(ctx, releasesURL, ""); err != nil {
+			slog.Error("failed to open releases page", "error", err)
+		}
+	})
+	item.AddSubMenuItem(msg("arch.dismiss"), "").Click(func() {
+		slog.Info("[ARCH] Architecture warning dismissed")
+		app.mu.Lock()
+		app.archWarningDismissed = true
+		app.mu.Unlock()
+		app.saveSettings()
+		app.rebuildMenu(ctx)
+	})
+	app.systrayInterface.AddSeparator()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/codeGROOVE-dev/goose/pkg/hostarch"
+)
+
+// rosettaProbes answer like the Intel build on Apple Silicon, or like a native build.
+func rosettaProbes(translated bool) hostarch.Probes {
+	return hostarch.Probes{
+		GOOS:   "darwin",
+		GOARCH: "amd64",
+		Sysctl: func(_ context.Context, name string) (string, error) {
+			switch {
+			case name == "sysctl.proc_translated" && translated:
+				return "1", nil
+			case name == "sysctl.proc_translated":
+				return "0", nil
+			case name == "hw.optional.arm64" && translated:
+				return "1", nil
+			default:
+				return "", errors.New("unknown oid")
+			}
+		},
+	}
+}
+
+func TestArchWarningUntilDismissed(t *testing.T) {
+	ctx := context.Background()
+	app, mock := newSettingsMenuTestApp(t)
+	app.checkArch(ctx, rosettaProbes(true))
+
+	want := msg("arch.rosetta")
+	if titles := app.generateMenuTitles(); !slices.Contains(titles, want) {
+		t.Fatalf("menu titles = %q, want the Rosetta warning", titles)
+	}
+	app.rebuildMenu(ctx)
+	var warning *MockMenuItem
+	for _, item := range mock.items {
+		if item.title == want {
+			warning = item
+		}
+	}
+	if warning == nil || len(warning.subItems) != 2 {
+		t.Fatalf("warning item = %+v, want download and dismiss entries", warning)
+	}
+	if download := warning.subItems[0].(*MockMenuItem); download.tooltip != releasesURL {
+		t.Errorf("download entry links to %q, want %q", download.tooltip, releasesURL)
+	}
+	warning.subItems[1].(*MockMenuItem).clickHandler()
+	if title := app.archWarningTitle(); title != "" {
+		t.Errorf("warning after dismissing = %q, want none", title)
+	}
+
+	// The dismissal is saved, so a restart doesn't bring the warning back
+	restarted := &App{mu: sync.RWMutex{}, systrayInterface: &MockSystray{}}
+	restarted.loadSettings()
+	restarted.checkArch(ctx, rosettaProbes(true))
+	if title := restarted.archWarningTitle(); title != "" {
+		t.Errorf("warning after restarting = %q, want none", title)
+	}
+}
+
+func TestArchWarningHiddenForNativeBuild(t *testing.T) {
+	app := newFocusTestApp(0)
+	app.checkArch(context.Background(), rosettaProbes(false))
+	if title := app.archWarningTitle(); title != "" {
+		t.Errorf("warning for a native build = %q, want none", title)
+	}
+}
+
+func TestArchWarningOtherMismatch(t *testing.T) {
+	app := newFocusTestApp(0)
+	app.checkArch(context.Background(), hostarch.Probes{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+		Uname:  func(context.Context) (string, error) { return "aarch64", nil },
+	})
+	if got, want := app.archWarningTitle(), msg("arch.mismatch", "amd64", "arm64"); got != want {
+		t.Errorf("warning = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// recordAutoOpenOutcomes tells the browser rate limiter which recently cleared PRs I
+// cleared myself, so it can tell auto-opens I acted on from ones I ignored.
+func (app *App) recordAutoOpenOutcomes() {
+	if app.browserRateLimiter == nil || app.stateManager == nil {
+		return
+	}
+	app.mu.RLock()
+	me := app.targetUser
+	app.mu.RUnlock()
+	for _, c := range app.stateManager.RecentlyCleared() {
+		app.browserRateLimiter.RecordCleared(c.PR.URL, c.attribution(me) == clearedByYou, c.UnblockReason.ClearedAt)
+	}
+}
+
+// autoOpenPausedTitle is the menu note shown while auto-open is paused after ignored
+// opens, or "" when it isn't.
+func (app *App) autoOpenPausedTitle() string {
+	if app.browserRateLimiter == nil || !app.readSetting(&app.enableAutoBrowser) {
+		return ""
+	}
+	if _, paused := app.browserRateLimiter.AutoOpenPaused(); !paused {
+		return ""
+	}
+	return msg("menu.auto_open_paused")
+}
+
+// addAutoOpenPausedNotice adds a disabled line while auto-open is paused, saying when
+// it resumes.
+func (app *App) addAutoOpenPausedNotice(_ context.Context) {
+	title := app.autoOpenPausedTitle()
+	if title == "" {
+		return
+	}
+	until, _ := app.browserRateLimiter.AutoOpenPaused()
+	app.systrayInterface.AddMenuItem(title, msg("menu.auto_open_paused.tooltip", until.Format(time.Kitchen))).Disable()
+	app.systrayInterface.AddSeparator()
+}
+
+// resumeAutoOpen ends an auto-open pause when I open a PR from the menu, since I'm
+// evidently paying attention again.
+func (app *App) resumeAutoOpen(ctx context.Context) {
+	if app.browserRateLimiter == nil || !app.browserRateLimiter.ResumeAutoOpen() {
+		return
+	}
+	slog.Info("[BROWSER] PR opened from the menu, resuming auto-open")
+	app.updateMenu(ctx)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Notifications go out around the clock, but a browser tab opened at 2am is one more
+// tab to close in the morning. auto_open_hours limits auto-open to weekday ranges like
+// "Mon-Fri 09:00-17:00", in auto_open_timezone or the local zone. Auto-opens outside
+// the schedule are skipped and counted, not saved for later: the notification and the
+// menu still point at the PR.
+
+// alwaysSchedule is how an empty auto_open_hours is shown and may be written.
+const alwaysSchedule = "always"
+
+// timeWindow is a daily span of wall-clock time on a range of weekdays.
+type timeWindow struct {
+	from, to   time.Weekday // The days the window starts on; to may wrap past Saturday
+	start, end int          // Minutes after midnight; the window runs past midnight when end <= start
+}
+
+// startsOn reports whether the window starts on day d.
+func (w timeWindow) startsOn(d time.Weekday) bool {
+	return (d-w.from+7)%7 <= (w.to-w.from+7)%7
+}
+
+// contains reports whether t's wall clock falls inside the window. The start minute is
+// inside and the end minute isn't; an overnight window belongs to the day it starts on,
+// so "Fri 22:00-02:00" covers the small hours of Saturday.
+func (w timeWindow) contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	d := t.Weekday()
+	if w.start < w.end {
+		return w.startsOn(d) && m >= w.start && m < w.end
+	}
+	return (w.startsOn(d) && m >= w.start) || (w.startsOn((d+6)%7) && m < w.end)
+}
+
+// String renders the window as it's written in settings, e.g. "Mon-Fri 09:00-17:00".
+func (w timeWindow) String() string {
+	clock := func(m int) string { return fmt.Sprintf("%02d:%02d", m/60, m%60) }
+	hours := clock(w.start) + "-" + clock(w.end)
+	switch {
+	case (w.to-w.from+7)%7 == 6:
+		return hours
+	case w.from == w.to:
+		return w.from.String()[:3] + " " + hours
+	default:
+		return w.from.String()[:3] + "-" + w.to.String()[:3] + " " + hours
+	}
+}
+
+// parseWeekday reads a day name, abbreviated or not, in any case.
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) || strings.EqualFold(s, d.String()[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", s)
+}
+
+// parseTimeWindow reads "[Day[-Day]] HH:MM-HH:MM"; without days the window is daily.
+func parseTimeWindow(s string) (timeWindow, error) {
+	w := timeWindow{from: time.Sunday, to: time.Saturday}
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+	case 2:
+		first, last, _ := strings.Cut(fields[0], "-")
+		var err error
+		if w.from, err = parseWeekday(first); err != nil {
+			return w, err
+		}
+		w.to = w.from
+		if last != "" {
+			if w.to, err = parseWeekday(last); err != nil {
+				return w, err
+			}
+		}
+	default:
+		return w, fmt.Errorf("want [days] HH:MM-HH:MM, got %q", s)
+	}
+	startClock, endClock, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return w, fmt.Errorf("want HH:MM-HH:MM, got %q", fields[len(fields)-1])
+	}
+	for _, c := range []struct {
+		clock string
+		m     *int
+	}{{startClock, &w.start}, {endClock, &w.end}} {
+		t, err := time.Parse(snoozeClockLayout, c.clock)
+		if err != nil {
+			return w, fmt.Errorf("want HH:MM, got %q", c.clock)
+		}
+		*c.m = t.Hour()*60 + t.Minute()
+	}
+	if w.start == w.end {
+		return w, fmt.Errorf("window %q is empty", s)
+	}
+	return w, nil
+}
+
+// weeklySchedule is a set of time windows in one time zone. An empty schedule always
+// allows.
+type weeklySchedule struct {
+	loc     *time.Location // nil: the local zone
+	windows []timeWindow
+}
+
+// parseWeeklySchedule reads comma-separated windows, like
+// "Mon-Fri 09:00-17:00, Sat 10:00-12:00", in the zone named tz ("" for local).
+func parseWeeklySchedule(spec, tz string) (weeklySchedule, error) {
+	var s weeklySchedule
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return s, fmt.Errorf("time zone: %w", err)
+		}
+		s.loc = loc
+	}
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.EqualFold(spec, alwaysSchedule) {
+		return s, nil
+	}
+	for part := range strings.SplitSeq(spec, ",") {
+		w, err := parseTimeWindow(part)
+		if err != nil {
+			return weeklySchedule{}, err
+		}
+		s.windows = append(s.windows, w)
+	}
+	if len(s.windows) == 0 {
+		return s, errors.New("no windows")
+	}
+	return s, nil
+}
+
+// always reports whether the schedule allows every hour.
+func (s weeklySchedule) always() bool {
+	return len(s.windows) == 0
+}
+
+// allows reports whether t falls in one of the windows, read in the schedule's zone.
+func (s weeklySchedule) allows(t time.Time) bool {
+	if s.always() {
+		return true
+	}
+	if s.loc != nil {
+		t = t.In(s.loc)
+	} else {
+		t = t.Local()
+	}
+	for _, w := range s.windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the windows as they're written in settings, or "" for always.
+func (s weeklySchedule) String() string {
+	parts := make([]string, len(s.windows))
+	for i, w := range s.windows {
+		parts[i] = w.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// zone is the settings name of the schedule's zone, or "" for local.
+func (s weeklySchedule) zone() string {
+	if s.loc == nil {
+		return ""
+	}
+	return s.loc.String()
+}
+
+// label describes the schedule for the menu, with its zone when it isn't local.
+func (s weeklySchedule) label() string {
+	if s.always() {
+		return msg("settings.auto_open.always")
+	}
+	if s.loc != nil {
+		return s.String() + " " + s.loc.String()
+	}
+	return s.String()
+}
+
+// autoOpenScheduled reports whether the auto-open schedule allows opening a PR at now,
+// logging and counting the opens it holds back.
+func (app *App) autoOpenScheduled(pr *PR, now time.Time) bool {
+	app.mu.RLock()
+	schedule := app.autoOpenSchedule
+	app.mu.RUnlock()
+	if schedule.allows(now) {
+		return true
+	}
+	slog.Info("[BROWSER] Outside auto-open hours, not opening",
+		"repo", pr.Repository, "number", pr.Number, "schedule", schedule.label())
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordOffHoursOpen()
+	}
+	return false
+}
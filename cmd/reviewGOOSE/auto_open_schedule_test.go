@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+)
+
+func mustSchedule(t *testing.T, spec, tz string) weeklySchedule {
+	t.Helper()
+	s, err := parseWeeklySchedule(spec, tz)
+	if err != nil {
+		t.Fatalf("parseWeeklySchedule(%q, %q): %v", spec, tz, err)
+	}
+	return s
+}
+
+func TestWeeklyScheduleAllows(t *testing.T) {
+	// 2026-10-16 is a Friday
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		spec string
+		at   time.Time
+		want bool
+	}{
+		{name: "always", spec: "", at: at(17, 3, 0), want: true},
+		{name: "start minute", spec: "Mon-Fri 09:00-17:00", at: at(16, 9, 0), want: true},
+		{name: "minute before start", spec: "Mon-Fri 09:00-17:00", at: at(16, 8, 59), want: false},
+		{name: "last minute", spec: "Mon-Fri 09:00-17:00", at: at(16, 16, 59), want: true},
+		{name: "end minute", spec: "Mon-Fri 09:00-17:00", at: at(16, 17, 0), want: false},
+		{name: "weekend excluded", spec: "Mon-Fri 09:00-17:00", at: at(17, 10, 0), want: false},
+		{name: "second window", spec: "Mon-Fri 09:00-17:00, Sat 10:00-12:00", at: at(17, 10, 0), want: true},
+		{name: "overnight before midnight", spec: "Fri 22:00-02:00", at: at(16, 23, 30), want: true},
+		{name: "overnight after midnight", spec: "Fri 22:00-02:00", at: at(17, 1, 59), want: true},
+		{name: "overnight end minute", spec: "Fri 22:00-02:00", at: at(17, 2, 0), want: false},
+		{name: "overnight from the day before", spec: "Fri 22:00-02:00", at: at(16, 1, 0), want: false},
+		{name: "range wrapping the week", spec: "Sat-Mon 09:00-17:00", at: at(18, 12, 0), want: true},
+		{name: "daily", spec: "09:00-17:00", at: at(18, 12, 0), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustSchedule(t, tt.spec, "UTC").allows(tt.at); got != tt.want {
+				t.Errorf("%q allows %s = %v, want %v", tt.spec, tt.at.Format(time.RFC1123), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeklyScheduleTimezone(t *testing.T) {
+	s, err := parseWeeklySchedule("Mon-Fri 09:00-17:00", "America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	// 09:30 in New York is 13:30 UTC, and Friday 20:00 there is already Saturday in UTC
+	if !s.allows(time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)) {
+		t.Error("09:30 in New York isn't allowed")
+	}
+	if s.allows(time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)) {
+		t.Error("05:30 in New York is allowed")
+	}
+	if got := s.label(); got != "Mon-Fri 09:00-17:00 America/New_York" {
+		t.Errorf("label() = %q", got)
+	}
+}
+
+func TestParseWeeklySchedule(t *testing.T) {
+	s := mustSchedule(t, " mon-friday 9:05-17:00 ,SAT 22:00-02:00", "")
+	if got, want := s.String(), "Mon-Fri 09:05-17:00, Sat 22:00-02:00"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if s := mustSchedule(t, "always", ""); !s.always() || s.String() != "" {
+		t.Errorf("%q isn't always", "always")
+	}
+	for _, spec := range []string{"Mon-Fri", "Mon-Fri 09:00", "Funday 09:00-17:00", "Mon-Fri 09:00-09:00", "Mon-Fri 25:00-26:00", "Mon-Fri 09:00-17:00,", "Mon Fri 09:00-17:00"} {
+		if _, err := parseWeeklySchedule(spec, ""); err == nil {
+			t.Errorf("parseWeeklySchedule(%q) succeeded, want an error", spec)
+		}
+	}
+	if _, err := parseWeeklySchedule("Mon-Fri 09:00-17:00", "Mars/Olympus_Mons"); err == nil {
+		t.Error("an unknown time zone was accepted")
+	}
+}
+
+func TestAutoOpenSkippedOutsideSchedule(t *testing.T) {
+	pr := &PR{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", NeedsReview: true}
+	now := time.Now().UTC()
+	minute := now.Hour()*60 + now.Minute()
+	tests := []struct {
+		name        string
+		window      timeWindow
+		wantOpened  int
+		wantSkipped int64
+	}{
+		{name: "inside", window: timeWindow{from: time.Sunday, to: time.Saturday, start: (minute + 1439) % 1440, end: (minute + 2) % 1440}, wantOpened: 1},
+		{name: "outside", window: timeWindow{from: time.Sunday, to: time.Saturday, start: (minute + 60) % 1440, end: (minute + 120) % 1440}, wantSkipped: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newGraceTestApp(time.Hour)
+			app.healthMonitor = newHealthMonitor()
+			app.healthMonitor.app = app
+			app.browserRateLimiter = ratelimit.NewBrowserRateLimiter(0, 5, defaultMaxBrowserOpensDay)
+			app.autoOpenSchedule = weeklySchedule{loc: time.UTC, windows: []timeWindow{tt.window}}
+			browser := &countingBrowser{}
+			app.browser = browser
+
+			app.tryAutoOpenPR(context.Background(), pr, true, app.startTime)
+			if got := browser.opened(); got != tt.wantOpened {
+				t.Errorf("opened %d PRs, want %d", got, tt.wantOpened)
+			}
+			if got := app.healthMonitor.metrics()["off_hours_opens"]; got != tt.wantSkipped {
+				t.Errorf("off_hours_opens = %v, want %d", got, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+)
+
+func TestAutoOpenOutcomesFromClears(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		wantSamples int
+	}{
+		{name: "cleared by me", actor: "bob", wantSamples: 1},
+		{name: "cleared by the author", actor: "alice", wantSamples: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newFocusTestApp(time.Hour)
+			app.targetUser = "bob"
+			app.enableAutoBrowser = true
+			app.browserRateLimiter = ratelimit.NewBrowserRateLimiter(0, 10, 100)
+			app.browserRateLimiter.RecordOpen("https://github.com/acme/widgets/pull/7")
+
+			clearBlockedPR(t, app.stateManager, tt.actor, "approve")
+			app.recordAutoOpenOutcomes()
+
+			ratio, samples := app.browserRateLimiter.AutoOpenEffectiveness()
+			if samples != tt.wantSamples || (samples > 0 && ratio != 1) {
+				t.Errorf("AutoOpenEffectiveness() = %v over %d samples, want %d acted on", ratio, samples, tt.wantSamples)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// nonDefaultBaseMarker follows the PR reference in the label when it targets a branch
+// other than the repository's default, such as a release or backport branch.
+const nonDefaultBaseMarker = " ⎇"
+
+// pullDetails holds what a PullRequests.Get tells us that the search API doesn't.
+type pullDetails struct {
+	baseBranch    string
+	defaultBranch string
+}
+
+// nonDefaultBase reports whether the PR targets a branch other than the repository's default.
+func (d pullDetails) nonDefaultBase() bool {
+	return d.baseBranch != "" && d.defaultBranch != "" && d.baseBranch != d.defaultBranch
+}
+
+// pullRequestDetails fetches a PR to learn its base branch. Lookups are cached per PR
+// revision, which retargeting the base branch moves; failures are cached too, so an
+// unreadable repo isn't retried every cycle.
+func (app *App) pullRequestDetails(ctx context.Context, repo string, number int, url string, updatedAt time.Time) (pullDetails, bool) {
+	if app.client == nil || app.pullDetails == nil {
+		return pullDetails{}, false
+	}
+	if details, found, ok := app.pullDetails.get(url, updatedAt); ok {
+		return details, found
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return pullDetails{}, false
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, turnAPITimeout)
+	defer cancel()
+	pr, _, err := app.client.PullRequests.Get(apiCtx, owner, name, number)
+	if err != nil {
+		if cacheableFailure(err) {
+			app.pullDetails.put(url, updatedAt, pullDetails{}, false)
+		}
+		if goneStatus(err) != 0 {
+			app.quarantinePR(url, err)
+		}
+		slog.Debug("[GITHUB] Pull request lookup failed", "url", url, "error", err)
+		return pullDetails{}, false
+	}
+
+	details := pullDetails{
+		baseBranch:    pr.GetBase().GetRef(),
+		defaultBranch: pr.GetBase().GetRepo().GetDefaultBranch(),
+	}
+	app.pullDetails.put(url, updatedAt, details, true)
+	if details.nonDefaultBase() {
+		slog.Debug("[GITHUB] PR targets a non-default branch", "url", url, "base", details.baseBranch, "default", details.defaultBranch)
+	}
+	return details, true
+}
+
+// blockedPullDetails is pullRequestDetails limited to PRs Turn says are waiting on me,
+// so unblocked PRs cost no extra API calls.
+func (app *App) blockedPullDetails(ctx context.Context, data *turn.CheckResponse, repo string, number int, url string, updatedAt time.Time, me string) pullDetails {
+	if data == nil {
+		return pullDetails{}
+	}
+	if _, waitingOnMe := data.Analysis.NextAction[me]; !waitingOnMe {
+		return pullDetails{}
+	}
+	details, _ := app.pullRequestDetails(ctx, repo, number, url, updatedAt)
+	return details
+}
+
+// baseMarker is the label marker for a PR targeting a non-default branch, or "".
+func baseMarker(pr PR) string {
+	if !pr.IsNonDefaultBase {
+		return ""
+	}
+	return nonDefaultBaseMarker
+}
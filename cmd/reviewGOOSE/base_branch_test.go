@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestPullRequestDetailsCachedPerRevision(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/acme/widgets/pulls/7" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"number":7,"base":{"ref":"release-1.8","repo":{"default_branch":"main"}}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	app := &App{mu: sync.RWMutex{}, client: newETagTestClient(t, server.URL), pullDetails: newPRRevisionCache[pullDetails]()}
+	url := "https://github.com/acme/widgets/pull/7"
+	updated := time.Now()
+	blocked := &turn.CheckResponse{}
+	blocked.Analysis.NextAction = map[string]turn.Action{"me": {Kind: "review"}}
+
+	for range 2 {
+		details := app.blockedPullDetails(ctx, blocked, "acme/widgets", 7, url, updated, "me")
+		if details.baseBranch != "release-1.8" || !details.nonDefaultBase() {
+			t.Fatalf("details = %+v, want a non-default release-1.8 base", details)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("PR fetched %d times for one revision, want 1", got)
+	}
+
+	app.blockedPullDetails(ctx, blocked, "acme/widgets", 7, url, updated.Add(time.Minute), "me")
+	if got := requests.Load(); got != 2 {
+		t.Errorf("a new UpdatedAt should refetch the PR (%d requests)", got)
+	}
+
+	if details := app.blockedPullDetails(ctx, &turn.CheckResponse{}, "acme/widgets", 8, "https://github.com/acme/widgets/pull/8", updated, "me"); details != (pullDetails{}) {
+		t.Errorf("looked up a PR not waiting on me: %+v", details)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("fetched a PR that isn't blocked (%d requests)", got)
+	}
+}
+
+func TestNonDefaultBaseMarker(t *testing.T) {
+	backport := PR{
+		Repository: "acme/widgets", Number: 7, Title: "Backport auth fix", ActionKind: "review",
+		BaseBranch: "release-1.8", IsNonDefaultBase: true,
+	}
+	mainline := backport
+	mainline.BaseBranch, mainline.IsNonDefaultBase = "main", false
+
+	tests := []struct {
+		mode DisplayMode
+		pr   PR
+		want string
+	}{
+		{DisplayRepoNumber, backport, "acme/widgets #7 ⎇ — review"},
+		{DisplayTitle, backport, "Backport auth fix ⎇ — review"},
+		{DisplayBoth, backport, "acme/widgets#7 ⎇: Backport auth fix"},
+		{DisplayRepoNumber, mainline, "acme/widgets #7 — review"},
+	}
+	for _, tt := range tests {
+		if got := formatMenuLabel(tt.pr, tt.mode, 0); got != tt.want {
+			t.Errorf("formatMenuLabel(%s, base %s) = %q, want %q", tt.mode, tt.pr.BaseBranch, got, tt.want)
+		}
+	}
+
+	if got, want := formatMenuTooltip(backport, DisplayRepoNumber, "2h"), "Backport auth fix (2h) → release-1.8"; got != want {
+		t.Errorf("tooltip = %q, want %q", got, want)
+	}
+	if got, want := formatMenuTooltip(mainline, DisplayRepoNumber, "2h"), "Backport auth fix (2h)"; got != want {
+		t.Errorf("tooltip for the default branch = %q, want %q", got, want)
+	}
+}
+
+func TestHideNonDefaultBaseCounts(t *testing.T) {
+	now := time.Now()
+	incoming := []PR{
+		{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: now, BaseBranch: "main"},
+		{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2", NeedsReview: true, UpdatedAt: now, BaseBranch: "release-1.8", IsNonDefaultBase: true},
+	}
+
+	for _, tt := range []struct {
+		hide         bool
+		wantBlocked  int
+		wantFiltered int
+	}{
+		{hide: false, wantBlocked: 2, wantFiltered: 0},
+		{hide: true, wantBlocked: 1, wantFiltered: 1},
+	} {
+		app := newFocusTestApp(time.Hour)
+		app.hideNonDefaultBase = tt.hide
+		in, out, filtered := app.splitFiltered(incoming, nil)
+		app.incoming, app.outgoing, app.filteredPRs = in, out, filtered
+
+		if got := app.countPRs().IncomingBlocked; got != tt.wantBlocked {
+			t.Errorf("hide=%t: %d incoming blocked, want %d", tt.hide, got, tt.wantBlocked)
+		}
+		if len(filtered) != tt.wantFiltered {
+			t.Errorf("hide=%t: %d filtered, want %d", tt.hide, len(filtered), tt.wantFiltered)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// batchOpenConfirmWindow is how long "Open all blocked" waits for the confirming second click.
+const batchOpenConfirmWindow = 5 * time.Second
+
+// batchOpenDelay spaces out the tabs "Open all blocked" opens, so browsers keep their order.
+var batchOpenDelay = 500 * time.Millisecond
+
+// manualOpenBudget is the part of the browser rate limiter that user-requested opens consume.
+type manualOpenBudget interface {
+	AllowManualOpen(prURL string) bool
+}
+
+// blockedIncomingToOpen returns the blocked incoming PRs as the menu lists them,
+// skipping those hidden by org, focus, or the stale filter.
+func (app *App) blockedIncomingToOpen() []PR {
+	var blocked []PR
+	for _, pr := range app.sortSectionPRs(app.snapshotPRs().incoming, "Incoming") {
+		if pr.NeedsReview || pr.IsBlocked {
+			blocked = append(blocked, pr)
+		}
+	}
+	return blocked
+}
+
+// batchOpenArmed reports whether "Open all blocked" is waiting for its confirming click.
+func (app *App) batchOpenArmed() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return !app.batchOpenArmedAt.IsZero() && time.Since(app.batchOpenArmedAt) < batchOpenConfirmWindow
+}
+
+// addOpenAllBlocked adds "Open all blocked (N)" under the Incoming header when more than
+// one PR is blocked on me. The first click arms it, relabelling the item for
+// batchOpenConfirmWindow; a second click within the window opens the tabs. It is only
+// ever started from this menu item, never by auto-open.
+func (app *App) addOpenAllBlocked(ctx context.Context) {
+	n := len(app.blockedIncomingToOpen())
+	if n < 2 {
+		return
+	}
+	label := msg("batch_open.title", n)
+	item := app.systrayInterface.AddMenuItem(label, msg("batch_open.tooltip"))
+	if app.batchOpenArmed() {
+		item.SetTitle(msg("batch_open.confirm", n))
+	}
+	item.Click(func() {
+		app.clickOpenAllBlocked(ctx, item, label)
+	})
+}
+
+// clickOpenAllBlocked advances the two-click confirmation for "Open all blocked".
+func (app *App) clickOpenAllBlocked(ctx context.Context, item MenuItem, label string) {
+	if !app.batchOpenArmed() {
+		prs := app.blockedIncomingToOpen()
+		now := time.Now()
+		app.mu.Lock()
+		app.batchOpenArmedAt = now
+		app.mu.Unlock()
+		slog.Info("[BROWSER] Open all blocked armed, waiting for confirmation", "count", len(prs))
+		item.SetTitle(msg("batch_open.confirm", len(prs)))
+
+		app.goTracked("batch open confirm", func() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(batchOpenConfirmWindow):
+			}
+			app.mu.Lock()
+			expired := app.batchOpenArmedAt.Equal(now)
+			if expired {
+				app.batchOpenArmedAt = time.Time{}
+			}
+			app.mu.Unlock()
+			if expired {
+				item.SetTitle(label)
+			}
+		})
+		return
+	}
+
+	app.mu.Lock()
+	app.batchOpenArmedAt = time.Time{}
+	app.mu.Unlock()
+	item.SetTitle(label)
+
+	if app.browserRateLimiter == nil {
+		return
+	}
+	prs := app.blockedIncomingToOpen()
+	app.goTracked("batch open", func() { app.openAll(ctx, prs, app.browserRateLimiter) })
+}
+
+// openAll opens each PR in the browser, pausing batchOpenDelay between tabs. It stops
+// early when budget runs out, saying how far it got, and returns how many it opened.
+func (app *App) openAll(ctx context.Context, prs []PR, budget manualOpenBudget) int {
+	slog.Info("[BROWSER] Opening all blocked PRs", "count", len(prs))
+	opened := 0
+	for i := range prs {
+		if ctx.Err() != nil {
+			return opened
+		}
+		pr := &prs[i]
+		if !budget.AllowManualOpen(pr.URL) {
+			slog.Warn("[BROWSER] Rate limit reached, stopping open all", "opened", opened, "total", len(prs))
+			if err := app.notify(msg("batch_open.rate_limited", opened, len(prs)), msg("batch_open.rate_limited.message")); err != nil {
+				slog.Error("[NOTIFY] Failed to send rate limit notification", "error", err)
+			}
+			return opened
+		}
+		if err := app.openBrowser(ctx, prLink(pr), ""); err != nil {
+			slog.Error("[BROWSER] Failed to open PR", "url", sanitizeForLog(pr.URL), "error", err)
+			continue
+		}
+		opened++
+		if i < len(prs)-1 {
+			select {
+			case <-ctx.Done():
+				return opened
+			case <-time.After(batchOpenDelay):
+			}
+		}
+	}
+	slog.Info("[BROWSER] Opened all blocked PRs", "opened", opened, "total", len(prs))
+	return opened
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+)
+
+// fakeOpenBudget allows the first n opens.
+type fakeOpenBudget struct {
+	urls []string
+	n    int
+}
+
+func (b *fakeOpenBudget) AllowManualOpen(prURL string) bool {
+	if len(b.urls) >= b.n {
+		return false
+	}
+	b.urls = append(b.urls, prURL)
+	return true
+}
+
+func newBatchOpenTestApp(t *testing.T, blocked int) (*App, *countingBrowser) {
+	t.Helper()
+	delay := batchOpenDelay
+	batchOpenDelay = 0
+	t.Cleanup(func() { batchOpenDelay = delay })
+
+	app := newFocusTestApp(time.Hour)
+	browser := &countingBrowser{}
+	app.browser = browser
+	app.browserRateLimiter = ratelimit.NewBrowserRateLimiter(time.Hour, 10, defaultMaxBrowserOpensDay)
+	now := time.Now()
+	for i := 1; i <= blocked; i++ {
+		app.incoming = append(app.incoming, PR{
+			Repository: "acme/widgets", Number: i, URL: fmt.Sprintf("https://github.com/acme/widgets/pull/%d", i),
+			NeedsReview: true, UpdatedAt: now,
+		})
+	}
+	// Neither a PR that isn't blocked nor one from a hidden org is opened
+	app.incoming = append(app.incoming,
+		PR{Repository: "acme/widgets", Number: 50, URL: "https://github.com/acme/widgets/pull/50", UpdatedAt: now},
+		PR{Repository: "hidden/repo", Number: 51, URL: "https://github.com/hidden/repo/pull/51", NeedsReview: true, UpdatedAt: now})
+	app.hiddenOrgs["hidden"] = true
+	return app, browser
+}
+
+func waitForOpens(b *countingBrowser, want int) int {
+	deadline := time.Now().Add(2 * time.Second)
+	for b.opened() < want && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	return b.opened()
+}
+
+func TestOpenAllBlockedConfirmation(t *testing.T) {
+	ctx := context.Background()
+	app, browser := newBatchOpenTestApp(t, 3)
+	item := &MockMenuItem{title: "Open all blocked (3)"}
+
+	app.clickOpenAllBlocked(ctx, item, "Open all blocked (3)")
+	if item.title != "Click again to open 3 tabs" {
+		t.Errorf("armed title = %q", item.title)
+	}
+	if got := waitForOpens(browser, 1); got != 0 {
+		t.Fatalf("the first click opened %d tabs, want none", got)
+	}
+
+	// A second click after the window lapses only arms it again
+	app.mu.Lock()
+	app.batchOpenArmedAt = time.Now().Add(-batchOpenConfirmWindow)
+	app.mu.Unlock()
+	app.clickOpenAllBlocked(ctx, item, "Open all blocked (3)")
+	if got := waitForOpens(browser, 1); got != 0 || !app.batchOpenArmed() {
+		t.Fatalf("a late click opened %d tabs (armed=%v), want it to re-arm", got, app.batchOpenArmed())
+	}
+
+	app.clickOpenAllBlocked(ctx, item, "Open all blocked (3)")
+	if item.title != "Open all blocked (3)" || app.batchOpenArmed() {
+		t.Errorf("after confirming: title=%q armed=%v, want the label restored", item.title, app.batchOpenArmed())
+	}
+	if got := waitForOpens(browser, 3); got != 3 {
+		t.Errorf("opened %d tabs, want 3", got)
+	}
+	browser.mu.Lock()
+	defer browser.mu.Unlock()
+	want := []string{"https://github.com/acme/widgets/pull/1", "https://github.com/acme/widgets/pull/2", "https://github.com/acme/widgets/pull/3"}
+	slices.Sort(browser.urls)
+	if !slices.Equal(browser.urls, want) {
+		t.Errorf("opened %q, want %q", browser.urls, want)
+	}
+}
+
+func TestOpenAllStopsAtRateLimit(t *testing.T) {
+	app, browser := newBatchOpenTestApp(t, 7)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	budget := &fakeOpenBudget{n: 5}
+
+	if opened := app.openAll(context.Background(), app.blockedIncomingToOpen(), budget); opened != 5 {
+		t.Errorf("openAll = %d, want 5", opened)
+	}
+	if browser.opened() != 5 {
+		t.Errorf("browser opened %d tabs, want 5", browser.opened())
+	}
+	want := []string{"Opened 5 of 7 — rate limit reached: Click again later to open the rest."}
+	if !slices.Equal(notifier.notes, want) {
+		t.Errorf("notifications = %q, want %q", notifier.notes, want)
+	}
+}
+
+func TestOpenAllBlockedNeedsSeveralPRs(t *testing.T) {
+	app, _ := newBatchOpenTestApp(t, 1)
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("test app should use MockSystray")
+	}
+	app.addOpenAllBlocked(context.Background())
+	if len(mock.menuItems) != 0 {
+		t.Errorf("added %q for a single blocked PR", mock.menuItems)
+	}
+
+	app, _ = newBatchOpenTestApp(t, 2)
+	mock, _ = app.systrayInterface.(*MockSystray)
+	app.addOpenAllBlocked(context.Background())
+	if !slices.Equal(mock.menuItems, []string{"Open all blocked (2)"}) {
+		t.Errorf("menu items = %q", mock.menuItems)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Release weeks bring a dependabot or renovate PR per dependency, across every repo.
+// With "Group bot PRs" on, a repository's bot PRs collapse into one menu entry whose
+// submenu lists them as usual. Grouping only changes how the sorted, filtered rows are
+// laid out; counts, notifications, and the tray never see it.
+
+// minBotGroup is how many bot PRs a repository needs before they are grouped.
+const minBotGroup = 2
+
+// menuRow is one entry in a PR section: a single PR, or a repository's bot PRs.
+type menuRow struct {
+	group []PR // Sorted, most urgent first; nil for a single PR
+	pr    PR   // The PR, or for a group its most urgent member
+}
+
+// blocked returns how many of the row's PRs count toward the section's blocked count.
+func (r *menuRow) blocked(sectionTitle string) int {
+	if r.group == nil {
+		if blockedInSection(&r.pr, sectionTitle) {
+			return 1
+		}
+		return 0
+	}
+	n := 0
+	for i := range r.group {
+		if blockedInSection(&r.group[i], sectionTitle) {
+			n++
+		}
+	}
+	return n
+}
+
+// groupBotPRs lays out sorted PRs as menu rows, collapsing each repository's bot PRs
+// into one row where its most urgent member would have been.
+func groupBotPRs(sorted []PR) []menuRow {
+	bots := make(map[string]int)
+	for i := range sorted {
+		if sorted[i].AuthorBot {
+			bots[sorted[i].Repository]++
+		}
+	}
+	rows := make([]menuRow, 0, len(sorted))
+	groupAt := make(map[string]int)
+	for i := range sorted {
+		pr := sorted[i]
+		if !pr.AuthorBot || bots[pr.Repository] < minBotGroup {
+			rows = append(rows, menuRow{pr: pr})
+			continue
+		}
+		if at, ok := groupAt[pr.Repository]; ok {
+			rows[at].group = append(rows[at].group, pr)
+			continue
+		}
+		groupAt[pr.Repository] = len(rows)
+		rows = append(rows, menuRow{pr: pr, group: []PR{pr}})
+	}
+	return rows
+}
+
+// sectionRows returns a section's PRs in menu order, grouped when "Group bot PRs" is on.
+func (app *App) sectionRows(prs []PR, sectionTitle string) []menuRow {
+	sorted := app.sortSectionPRs(prs, sectionTitle)
+	if app.readSetting(&app.groupBotPRs) {
+		return groupBotPRs(sorted)
+	}
+	rows := make([]menuRow, len(sorted))
+	for i := range sorted {
+		rows[i].pr = sorted[i]
+	}
+	return rows
+}
+
+// botGroupTitle is a group's menu label, e.g. "· acme/widgets — 6 bot PRs (2 blocked)",
+// marked like its most urgent member when any member is blocked.
+func (app *App) botGroupTitle(row *menuRow, sectionTitle string, highlight HighlightWindow) string {
+	blocked := row.blocked(sectionTitle)
+	if blocked == 0 {
+		return msg("botgroup.title.none", row.pr.Repository, len(row.group))
+	}
+	title := msg("botgroup.title", row.pr.Repository, len(row.group), blocked)
+	return fmt.Sprintf("%s %s", app.blockedPrefix(&row.pr, sectionTitle, highlight), title)
+}
+
+// addBotGroup adds a group's entry, with its PRs in a submenu, to the menu.
+func (app *App) addBotGroup(ctx context.Context, row *menuRow, sectionTitle string, displayMode DisplayMode, labelWidth int, highlight HighlightWindow) {
+	title := app.botGroupTitle(row, sectionTitle, highlight)
+	slog.Debug("[MENU] Adding bot PR group to menu",
+		"section", sectionTitle, "title", title, "repo", row.pr.Repository, "prs", len(row.group))
+	parent := app.systrayInterface.AddMenuItem(title, msg("botgroup.tooltip", row.pr.Repository))
+	for i := range row.group {
+		pr := &row.group[i]
+		item := parent.AddSubMenuItem(app.prRowTitle(pr, sectionTitle, displayMode, labelWidth, highlight),
+			formatMenuTooltip(*pr, displayMode, prAge(pr.UpdatedAt)))
+		app.setupPRItem(ctx, item, pr, sectionTitle)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// botGroupPRs is a release week: renovate PRs in two repos, one human PR, and a lone
+// dependabot PR that is too few to group.
+func botGroupPRs(now time.Time) []PR {
+	bot := func(repo string, n int, blocked bool, ago time.Duration) PR {
+		return PR{
+			Repository: repo, Number: n, URL: fmt.Sprintf("https://github.com/%s/pull/%d", repo, n),
+			Title: "Update dependency", Author: "renovate[bot]", AuthorBot: true, NeedsReview: blocked, UpdatedAt: now.Add(-ago),
+		}
+	}
+	return []PR{
+		bot("acme/widgets", 1, false, time.Hour),
+		bot("acme/widgets", 2, true, 2*time.Hour),
+		bot("acme/widgets", 3, true, 3*time.Hour),
+		bot("acme/gears", 4, false, 4*time.Hour),
+		bot("acme/gears", 5, false, 5*time.Hour),
+		bot("acme/lone", 6, false, 6*time.Hour),
+		{
+			Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7",
+			Title: "Add retries", Author: "carol", NeedsReview: true, UpdatedAt: now.Add(-30 * time.Minute),
+		},
+	}
+}
+
+func TestGroupBotPRs(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.groupBotPRs = true
+	rows := app.sectionRows(botGroupPRs(time.Now()), "Incoming")
+
+	// The human PR leads; the widgets group sorts where its blocked #2 would, ahead
+	// of everything unblocked
+	var got []string
+	for i := range rows {
+		r := &rows[i]
+		var members []int
+		for _, pr := range r.group {
+			members = append(members, pr.Number)
+		}
+		got = append(got, fmt.Sprintf("%s#%d%v blocked=%d", r.pr.Repository, r.pr.Number, members, r.blocked("Incoming")))
+	}
+	want := []string{
+		"acme/widgets#7[] blocked=1",
+		"acme/widgets#2[2 3 1] blocked=2",
+		"acme/gears#4[4 5] blocked=0",
+		"acme/lone#6[] blocked=0",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("rows =\n%q\nwant\n%q", got, want)
+	}
+
+	// Off, every PR is its own row
+	app.groupBotPRs = false
+	if rows := app.sectionRows(botGroupPRs(time.Now()), "Incoming"); len(rows) != 7 || rows[1].group != nil {
+		t.Errorf("ungrouped rows = %+v, want 7 single PRs", rows)
+	}
+}
+
+func TestBotGroupMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.groupBotPRs = true
+	browser := &countingBrowser{}
+	app.browser = browser
+	app.incoming = botGroupPRs(time.Now())
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("systray is not a mock")
+	}
+
+	view := app.snapshotPRs()
+	counts := view.counts()
+	if counts.IncomingBlocked != 3 {
+		t.Fatalf("IncomingBlocked = %d, want 3 whether or not bots are grouped", counts.IncomingBlocked)
+	}
+	// Panics, via assertMenuCounts, if the group's blocked members aren't counted
+	app.addPRSection(context.Background(), view.incoming, "Incoming", counts.IncomingBlocked, counts.IncomingBlockedRepos)
+
+	group := topMenuItem(mock, "· acme/widgets — 3 bot PRs (2 blocked)")
+	if group == nil {
+		t.Fatalf("no widgets group in menu %q", mock.menuItems)
+	}
+	var members []string
+	for _, sub := range group.subItems {
+		members = append(members, sub.(*MockMenuItem).title)
+	}
+	if len(members) != 3 || !strings.HasPrefix(members[0], "· ") || !strings.HasPrefix(members[2], "acme/widgets") {
+		t.Errorf("group submenu = %q, want blocked members marked first, then the rest", members)
+	}
+	if topMenuItem(mock, "acme/gears — 2 bot PRs") == nil {
+		t.Errorf("no unblocked gears group in menu %q", mock.menuItems)
+	}
+
+	// Members open like ungrouped PRs
+	group.subItems[0].(*MockMenuItem).clickHandler()
+	if len(browser.urls) != 1 || browser.urls[0] != "https://github.com/acme/widgets/pull/2" {
+		t.Errorf("clicking the first member opened %q, want PR #2", browser.urls)
+	}
+
+	// Change detection sees the same entries, in the same order
+	titles := app.generatePRSectionTitles(view.incoming, "Incoming")
+	if !slices.Contains(titles, group.title) || !slices.Contains(titles, members[0]) {
+		t.Errorf("generatePRSectionTitles() = %q, missing the group or its members", titles)
+	}
+	if again := app.generatePRSectionTitles(view.incoming, "Incoming"); !slices.Equal(titles, again) {
+		t.Errorf("titles changed between calls:\n%q\n%q", titles, again)
+	}
+}
+
+func TestBotGroupsSkipStalePRs(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.groupBotPRs = true
+	app.hideStaleIncoming = true
+	now := time.Now()
+	prs := botGroupPRs(now)
+	// Two of the three widgets bot PRs went stale: the last one is left alone
+	prs[1].UpdatedAt = now.Add(-100 * 24 * time.Hour)
+	prs[2].UpdatedAt = now.Add(-100 * 24 * time.Hour)
+	app.incoming = prs
+
+	view := app.snapshotPRs()
+	for _, row := range app.sectionRows(view.incoming, "Incoming") {
+		if row.group != nil && row.pr.Repository == "acme/widgets" {
+			t.Errorf("stale PRs still grouped: %+v", row.group)
+		}
+	}
+}
@@ -13,9 +13,39 @@ import (
 	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
 )
 
-// checkCache checks the cache for a PR and returns the cached data if valid.
-// Returns (data, hit, running) where running indicates incomplete tests.
-func (app *App) checkCache(cacheManager *prcache.Manager, path, url string, updatedAt time.Time) (data *turn.CheckResponse, hit, running bool) {
+// cacheDecision records how turnData used the cache for a PR, so slow cycles can be explained.
+type cacheDecision string
+
+const (
+	cacheHit               cacheDecision = "hit"
+	cacheHitMemory         cacheDecision = "hit-memory"          // Served by turnMemory without touching the disk
+	cacheMissExpired       cacheDecision = "miss-expired"        // Entry older than cacheTTL, or unreadable
+	cacheMissRunningTests  cacheDecision = "miss-running-tests"  // Entry had incomplete tests within runningTestsCacheBypass
+	cacheBypassNoCache     cacheDecision = "bypass-nocache"      // -no-cache
+	cacheBypassFreshUpdate cacheDecision = "bypass-fresh-update" // No entry for this UpdatedAt: the PR changed or was never cached
+	cacheMissMismatch      cacheDecision = "miss-mismatch"       // Entry's own updated_at disagreed with GitHub's
+)
+
+// turnUpdatedAtTolerance is how far a cached response's own updated_at may drift from
+// GitHub's UpdatedAt before the entry is taken to describe another version of the PR.
+const turnUpdatedAtTolerance = time.Minute
+
+// hit reports whether the response came from a cache rather than the Turn API.
+func (d cacheDecision) hit() bool {
+	return d == cacheHit || d == cacheHitMemory
+}
+
+// turnCacheManager returns the disk cache manager, reading through app.cacheFS if set.
+func (app *App) turnCacheManager() *prcache.Manager {
+	if app.cacheFS != nil {
+		return prcache.NewManagerFS(app.cacheDir, app.cacheFS)
+	}
+	return prcache.NewManager(app.cacheDir)
+}
+
+// checkCache checks the cache for a PR and returns the cached data if valid,
+// along with why the cache was or wasn't used.
+func (app *App) checkCache(cacheManager *prcache.Manager, path, url string, updatedAt time.Time) (*turn.CheckResponse, cacheDecision) {
 	// State check function for incomplete tests
 	stateCheck := func(d any) bool {
 		if m, ok := d.(map[string]any); ok {
@@ -39,57 +69,102 @@ func (app *App) checkCache(cacheManager *prcache.Manager, path, url string, upda
 	result, err := cacheManager.Get(path, updatedAt, ttl, bypassTTL, stateCheck)
 	if err != nil {
 		slog.Debug("[CACHE] Cache error", "url", url, "error", err)
-		return nil, false, false
+		return nil, cacheMissExpired
 	}
 
-	if !result.Hit {
-		return nil, false, result.ShouldBypass
+	switch {
+	case result.ShouldBypass:
+		return nil, cacheMissRunningTests
+	case result.Expired:
+		return nil, cacheMissExpired
+	case !result.Hit:
+		return nil, cacheBypassFreshUpdate
 	}
 
 	// Extract turn.CheckResponse from cached data
 	if result.Entry == nil || result.Entry.Data == nil {
-		return nil, false, false
+		return nil, cacheMissExpired
 	}
 
 	// Convert map back to CheckResponse
 	dataBytes, err := json.Marshal(result.Entry.Data)
 	if err != nil {
 		slog.Warn("Failed to marshal cached data", "url", url, "error", err)
-		return nil, false, false
+		return nil, cacheMissExpired
 	}
 
 	var response turn.CheckResponse
 	if err := json.Unmarshal(dataBytes, &response); err != nil {
 		slog.Warn("Failed to unmarshal cached data", "url", url, "error", err)
-		return nil, false, false
+		return nil, cacheMissExpired
+	}
+
+	if app.turnUpdatedAtMismatch(url, &response, updatedAt) {
+		return nil, cacheMissMismatch
 	}
 
 	slog.Debug("[CACHE] Cache hit",
 		"url", url,
 		"cached_at", result.Entry.CachedAt.Format(time.RFC3339),
 		"cache_age", time.Since(result.Entry.CachedAt).Round(time.Second),
-		"pr_updated_at", result.Entry.UpdatedAt.Format(time.RFC3339))
+		"pr_updated_at", result.Entry.UpdatedAt.Format(time.RFC3339),
+		"turn_updated_at", response.PullRequest.UpdatedAt.Format(time.RFC3339))
 
 	if app.healthMonitor != nil {
 		app.healthMonitor.recordCacheAccess(true)
 	}
+	if app.turnMemory != nil {
+		app.turnMemory.put(url, updatedAt, result.Entry.CachedAt, &response)
+	}
+
+	return &response, cacheHit
+}
+
+// turnUpdatedAtMismatch reports whether a cached response describes a different version
+// of the PR than GitHub's updatedAt, which happens when GitHub rewinds UpdatedAt (a
+// force-push can) and an old entry answers for the new key. Mismatches are logged and
+// counted. Responses without an updated_at of their own are trusted.
+func (app *App) turnUpdatedAtMismatch(url string, data *turn.CheckResponse, updatedAt time.Time) bool {
+	turnUpdatedAt := data.PullRequest.UpdatedAt
+	if turnUpdatedAt.IsZero() || updatedAt.IsZero() {
+		return false
+	}
+	drift := turnUpdatedAt.Sub(updatedAt)
+	if drift.Abs() <= turnUpdatedAtTolerance {
+		return false
+	}
+	slog.Warn("[CACHE] Cached Turn data is for another version of the PR, refetching",
+		"url", url,
+		"pr_updated_at", updatedAt.Format(time.RFC3339),
+		"turn_updated_at", turnUpdatedAt.Format(time.RFC3339),
+		"drift", drift.Round(time.Second))
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordCacheMismatch()
+	}
+	return true
+}
 
-	return &response, true, false
+// turnData fetches Turn API data with caching. The decision is empty when Turn is disabled
+// or the URL is rejected before the cache is consulted.
+func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (*turn.CheckResponse, cacheDecision, error) {
+	return app.turnDataAttempts(ctx, url, updatedAt, maxRetries)
 }
 
-// turnData fetches Turn API data with caching.
-func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (*turn.CheckResponse, bool, error) {
+// turnDataAttempts is turnData with a caller-chosen limit on Turn API attempts.
+func (app *App) turnDataAttempts(
+	ctx context.Context, url string, updatedAt time.Time, attempts uint,
+) (*turn.CheckResponse, cacheDecision, error) {
 	if app.turnClient == nil {
 		slog.Debug("[TURN] Turn API disabled, skipping", "url", url)
-		return nil, false, nil
+		return nil, "", nil
 	}
 
 	if err := safebrowse.ValidateURL(url); err != nil {
-		return nil, false, fmt.Errorf("invalid URL: %w", err)
+		return nil, "", fmt.Errorf("invalid URL: %w", err)
 	}
 
 	// Create cache manager and path
-	cacheManager := prcache.NewManager(app.cacheDir)
+	cacheManager := app.turnCacheManager()
 	cacheKey := prcache.CacheKey(url, updatedAt)
 	path := cacheManager.CachePath(cacheKey)
 
@@ -99,14 +174,44 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 		"cache_key", cacheKey)
 
 	// Check cache unless --no-cache flag is set
-	var running bool
+	decision := cacheBypassNoCache
 	if !app.noCache {
-		data, hit, r := app.checkCache(cacheManager, path, url, updatedAt)
-		if hit {
-			return data, true, nil
+		// Unchanged PRs are usually answered from memory without reading their cache file
+		if app.turnMemory != nil {
+			data, ok := app.turnMemory.get(url, updatedAt)
+			if ok && app.turnUpdatedAtMismatch(url, data, updatedAt) {
+				// The disk entry holds the same response, so skip it too
+				app.turnMemory.invalidate(url)
+				ok, decision = false, cacheMissMismatch
+			}
+			if ok {
+				slog.Debug("[CACHE] In-memory cache hit", "url", url)
+				if app.healthMonitor != nil {
+					app.healthMonitor.recordCacheAccess(true)
+				}
+				return data, cacheHitMemory, nil
+			}
+		}
+		// While the disk is unwritable, fresh responses only live in memory
+		if app.storage != nil && app.storage.memoryOnlyMode() {
+			if data, ok := app.storage.cacheGet(cacheKey); ok {
+				slog.Debug("[CACHE] Memory cache hit", "url", url)
+				if app.healthMonitor != nil {
+					app.healthMonitor.recordCacheAccess(true)
+				}
+				return data, cacheHit, nil
+			}
+		}
+		if decision != cacheMissMismatch {
+			var data *turn.CheckResponse
+			data, decision = app.checkCache(cacheManager, path, url, updatedAt)
+			if decision == cacheHit {
+				return data, cacheHit, nil
+			}
 		}
-		running = r
 	}
+	// Running tests and mismatched entries both mean Turn's server-side cache may be stale too
+	bypassTurnCache := decision == cacheMissRunningTests || decision == cacheMissMismatch
 
 	// Cache miss, fetch from API
 	if app.noCache {
@@ -114,6 +219,7 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 	} else {
 		slog.Info("[CACHE] Cache miss, fetching from Turn API",
 			"url", url,
+			"decision", decision,
 			"pr_updated_at", updatedAt.Format(time.RFC3339))
 		if app.healthMonitor != nil {
 			app.healthMonitor.recordCacheAccess(false)
@@ -126,11 +232,12 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 		tctx, cancel := context.WithTimeout(ctx, turnAPITimeout)
 		defer cancel()
 
-		// For PRs with running tests, send current time to bypass Turn server cache
+		// For PRs with running tests or a mismatched entry, send current time to bypass Turn server cache
 		ts := updatedAt
-		if running {
+		if bypassTurnCache {
 			ts = time.Now()
-			slog.Debug("[TURN] Using current timestamp for PR with running tests to bypass Turn server cache",
+			slog.Debug("[TURN] Using current timestamp to bypass Turn server cache",
+				"decision", decision,
 				"url", url,
 				"pr_updated_at", updatedAt.Format(time.RFC3339),
 				"timestamp_sent", ts.Format(time.RFC3339))
@@ -138,31 +245,35 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 
 		slog.Debug("[TURN] Making API call",
 			"url", url,
-			"user", app.currentUser.GetLogin(),
+			"user", app.login(),
 			"pr_updated_at", ts.Format(time.RFC3339))
 		var err error
-		data, err = app.turnClient.Check(tctx, url, app.currentUser.GetLogin(), ts)
+		data, err = app.turnClient.Check(tctx, url, app.login(), ts)
 		if err != nil {
+			if isPermanentPRError(err) {
+				// Lost access to this PR; retrying won't help
+				return retry.Unrecoverable(err)
+			}
 			slog.Warn("Turn API error (will retry)", "error", err)
 			return err
 		}
 		slog.Debug("[TURN] API call successful", "url", url)
 		return nil
 	},
-		retry.Attempts(maxRetries),
+		retry.Attempts(attempts),
 		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)),
 		retry.MaxDelay(maxRetryDelay),
 		retry.OnRetry(func(n uint, err error) {
-			slog.Warn("[TURN] API retry", "attempt", n+1, "maxRetries", maxRetries, "url", url, "error", err)
+			slog.Warn("[TURN] API retry", "attempt", n+1, "maxRetries", attempts, "url", url, "error", err)
 		}),
 		retry.Context(ctx),
 	)
 	if err != nil {
-		slog.Error("Turn API error after retries (will use PR without metadata)", "maxRetries", maxRetries, "error", err)
+		slog.Error("Turn API error after retries (will use PR without metadata)", "maxRetries", attempts, "error", err)
 		if app.healthMonitor != nil {
 			app.healthMonitor.recordAPICall(false)
 		}
-		return nil, false, err
+		return nil, decision, err
 	}
 
 	if app.healthMonitor != nil {
@@ -172,6 +283,8 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 	if data != nil {
 		slog.Info("[TURN] API response details",
 			"url", url,
+			"pr_updated_at", updatedAt.Format(time.RFC3339),
+			"turn_updated_at", data.PullRequest.UpdatedAt.Format(time.RFC3339),
 			"test_state", data.PullRequest.TestState,
 			"state", data.PullRequest.State,
 			"merged", data.PullRequest.Merged,
@@ -180,16 +293,45 @@ func (app *App) turnData(ctx context.Context, url string, updatedAt time.Time) (
 
 	// Save to cache (don't fail if caching fails)
 	if !app.noCache && data != nil {
+		app.saveToCache(cacheManager, path, cacheKey, url, data, updatedAt)
+		if app.turnMemory != nil {
+			app.turnMemory.put(url, updatedAt, time.Now(), data)
+		}
+	}
+
+	return data, decision, nil
+}
+
+// saveToCache writes a Turn response to the disk cache, recording the outcome in
+// storageHealth so a full or read-only disk degrades to a memory-only cache quietly.
+func (app *App) saveToCache(
+	cacheManager *prcache.Manager, path, cacheKey, url string, data *turn.CheckResponse, updatedAt time.Time,
+) {
+	if app.storage == nil {
 		if err := cacheManager.Put(path, data, updatedAt); err != nil {
 			slog.Error("Failed to save cache", "url", url, "error", err)
-		} else {
-			slog.Debug("[CACHE] Saved to cache",
-				"url", url,
-				"test_state", data.PullRequest.TestState)
 		}
+		return
 	}
 
-	return data, false, nil
+	if !app.storage.shouldWriteCache() {
+		app.storage.cachePut(cacheKey, data)
+		return
+	}
+
+	if err := cacheManager.Put(path, data, updatedAt); err != nil {
+		app.storage.recordFailure("cache", err)
+		app.storage.cachePut(cacheKey, data)
+		return
+	}
+
+	slog.Debug("[CACHE] Saved to cache",
+		"url", url,
+		"test_state", data.PullRequest.TestState)
+	if app.storage.recordSuccess("cache") {
+		slog.Info("[STORAGE] Flushing settings that failed to save earlier")
+		app.saveSettings()
+	}
 }
 
 // cleanupOldCache removes cache files older than the cleanup interval (15 days).
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ghref"
+)
+
+// "Why did goose honk at 14:32" used to mean lining up a handful of log lines. Every
+// update cycle now ends with one ChangeSet saying what the searches added and removed,
+// which PRs became blocked or unblocked, whose Turn state moved, and which notifications
+// went out. It's logged as a single record, and the last changeJournalSize are kept for
+// the diagnostic report. Changes made between cycles, by real-time events, backfills,
+// or the running tests sweep, are reported with the cycle that follows them.
+
+// changeJournalSize is how many cycles' change sets are kept.
+const changeJournalSize = 20
+
+// PRRef identifies a PR in a ChangeSet.
+type PRRef struct {
+	Repository string
+	URL        string
+	Number     int
+}
+
+// refOf returns pr's reference.
+func refOf(pr *PR) PRRef {
+	return PRRef{Repository: pr.Repository, URL: pr.URL, Number: pr.Number}
+}
+
+// refFromURL returns the reference for a PR known only by its URL.
+func refFromURL(url string) PRRef {
+	ref := PRRef{URL: url}
+	if parsed, err := ghref.ParsePRURL(url); err == nil {
+		ref.Repository, ref.Number = parsed.Repository(), parsed.Number
+	}
+	return ref
+}
+
+// String renders the reference compactly, e.g. "acme/widgets#42".
+func (r PRRef) String() string {
+	if r.Repository == "" {
+		return r.URL
+	}
+	return fmt.Sprintf("%s#%d", r.Repository, r.Number)
+}
+
+// ChangeSet is what changed over one update cycle.
+type ChangeSet struct {
+	At                   time.Time
+	Added                []PRRef // New to the incoming or outgoing lists
+	Removed              []PRRef // Closed: left the lists of a complete fetch without being filtered
+	BlockedTransitions   []PRRef // Became blocked on me
+	UnblockedTransitions []PRRef // Stopped being blocked on me, or closed while blocked
+	TurnDataUpdated      []PRRef // Turn's verdict (action, blocking, or tests) changed
+	NotificationsSent    []PRRef // PR notifications shown
+}
+
+// empty reports whether nothing changed.
+func (c *ChangeSet) empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.BlockedTransitions) == 0 &&
+		len(c.UnblockedTransitions) == 0 && len(c.TurnDataUpdated) == 0 && len(c.NotificationsSent) == 0
+}
+
+// merge appends o's changes to c's.
+func (c *ChangeSet) merge(o *ChangeSet) {
+	c.Added = append(c.Added, o.Added...)
+	c.Removed = append(c.Removed, o.Removed...)
+	c.BlockedTransitions = append(c.BlockedTransitions, o.BlockedTransitions...)
+	c.UnblockedTransitions = append(c.UnblockedTransitions, o.UnblockedTransitions...)
+	c.TurnDataUpdated = append(c.TurnDataUpdated, o.TurnDataUpdated...)
+	c.NotificationsSent = append(c.NotificationsSent, o.NotificationsSent...)
+}
+
+// removedURLs returns the URLs of the closed PRs.
+func (c *ChangeSet) removedURLs() []string {
+	urls := make([]string, len(c.Removed))
+	for i := range c.Removed {
+		urls[i] = c.Removed[i].URL
+	}
+	return urls
+}
+
+// turnStateChanged reports whether Turn's verdict on a PR differs between two fetches.
+func turnStateChanged(before, after *PR) bool {
+	return before.IsBlocked != after.IsBlocked || before.NeedsReview != after.NeedsReview ||
+		before.ActionKind != after.ActionKind || before.TestState != after.TestState
+}
+
+// diffPRLists compares one fetch's PRs with the previous fetch's. A PR missing from an
+// incomplete fetch may just be in the failed search, so Removed stays empty then.
+func diffPRLists(prevIncoming, prevOutgoing, incoming, outgoing, filtered []PR, complete bool) ChangeSet {
+	var c ChangeSet
+	prev, next, stillFiltered := indexPRs(prevIncoming, prevOutgoing), indexPRs(incoming, outgoing), indexPRs(filtered)
+	seen := make(map[string]bool)
+	for _, prs := range [][]PR{incoming, outgoing} {
+		for i := range prs {
+			pr := &prs[i]
+			if seen[pr.URL] {
+				continue
+			}
+			seen[pr.URL] = true
+			before := prev.get(pr.URL)
+			switch {
+			case before == nil:
+				c.Added = append(c.Added, refOf(pr))
+			case turnStateChanged(before, pr):
+				c.TurnDataUpdated = append(c.TurnDataUpdated, refOf(pr))
+			default:
+			}
+		}
+	}
+	if !complete {
+		return c
+	}
+	clear(seen)
+	for _, prs := range [][]PR{prevIncoming, prevOutgoing} {
+		for i := range prs {
+			pr := &prs[i]
+			if seen[pr.URL] {
+				continue
+			}
+			seen[pr.URL] = true
+			if next.get(pr.URL) == nil && stillFiltered.get(pr.URL) == nil {
+				c.Removed = append(c.Removed, refOf(pr))
+			}
+		}
+	}
+	return c
+}
+
+// changeJournal collects the changes of the cycle in progress and keeps the last
+// changeJournalSize cycles' sets, oldest first. Its zero value is ready to use.
+type changeJournal struct {
+	pending ChangeSet
+	sets    []ChangeSet
+	mu      sync.Mutex
+}
+
+// note adds changes to the cycle in progress.
+func (j *changeJournal) note(c *ChangeSet) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.pending.merge(c)
+}
+
+// commit ends the cycle in progress at at, keeps its set, and returns it.
+func (j *changeJournal) commit(at time.Time) ChangeSet {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	c := j.pending
+	c.At = at
+	j.pending = ChangeSet{}
+	j.sets = append(j.sets, c)
+	if len(j.sets) > changeJournalSize {
+		j.sets = j.sets[len(j.sets)-changeJournalSize:]
+	}
+	return c
+}
+
+// snapshot returns the kept sets, oldest first.
+func (j *changeJournal) snapshot() []ChangeSet {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	out := make([]ChangeSet, len(j.sets))
+	copy(out, j.sets)
+	return out
+}
+
+// noteNotified adds a shown notification to the cycle in progress.
+func (app *App) noteNotified(prURL string) {
+	if prURL == "" {
+		return
+	}
+	app.journal.note(&ChangeSet{NotificationsSent: []PRRef{refFromURL(prURL)}})
+}
+
+// commitChanges ends the update cycle's ChangeSet and logs it as one record.
+func (app *App) commitChanges(at time.Time) ChangeSet {
+	c := app.journal.commit(at)
+	level := slog.LevelInfo
+	if c.empty() {
+		level = slog.LevelDebug
+	}
+	slog.Log(context.Background(), level, "[CHANGES] Update cycle changes",
+		"added", refStrings(c.Added),
+		"removed", refStrings(c.Removed),
+		"blocked", refStrings(c.BlockedTransitions),
+		"unblocked", refStrings(c.UnblockedTransitions),
+		"turn_data_updated", refStrings(c.TurnDataUpdated),
+		"notifications_sent", refStrings(c.NotificationsSent))
+	return c
+}
+
+// refStrings renders references for a log record.
+func refStrings(refs []PRRef) []string {
+	out := make([]string, len(refs))
+	for i := range refs {
+		out[i] = refs[i].String()
+	}
+	return out
+}
+
+// writeChangeSets adds the kept change sets to the diagnostic report, newest first,
+// leaving out cycles that changed nothing.
+func writeChangeSets(b *strings.Builder, sets []ChangeSet) {
+	fmt.Fprintf(b, "\nupdate cycle changes (last %d cycles):\n", len(sets))
+	for i := len(sets) - 1; i >= 0; i-- {
+		c := &sets[i]
+		if c.empty() {
+			continue
+		}
+		fmt.Fprintf(b, "  %s", c.At.Format(time.RFC3339))
+		for _, part := range []struct {
+			name string
+			refs []PRRef
+		}{
+			{"added", c.Added},
+			{"removed", c.Removed},
+			{"blocked", c.BlockedTransitions},
+			{"unblocked", c.UnblockedTransitions},
+			{"turn", c.TurnDataUpdated},
+			{"notified", c.NotificationsSent},
+		} {
+			if len(part.refs) > 0 {
+				fmt.Fprintf(b, " %s=%s", part.name, strings.Join(refStrings(part.refs), ","))
+			}
+		}
+		b.WriteString("\n")
+	}
+}
+
+// patchTurnResults applies results to the shown PRs in place, like applyTurnResults, and
+// notes the PRs whose Turn verdict changed. Caller must hold app.mu.
+func (app *App) patchTurnResults(results []prResult, user string) int {
+	shown := indexPRs(app.incoming, app.outgoing)
+	var before []PR
+	for i := range results {
+		if pr := shown.get(results[i].url); pr != nil {
+			before = append(before, *pr)
+		}
+	}
+	patched := applyTurnResults(results, user, app.incoming, app.outgoing)
+	var c ChangeSet
+	for i := range before {
+		if pr := shown.get(before[i].URL); pr != nil && turnStateChanged(&before[i], pr) {
+			c.TurnDataUpdated = append(c.TurnDataUpdated, refOf(pr))
+		}
+	}
+	if !c.empty() {
+		app.journal.note(&c)
+	}
+	return patched
+}
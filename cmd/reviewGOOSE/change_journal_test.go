@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func journalRef(number int) PRRef {
+	return PRRef{Repository: "org/repo", URL: fmt.Sprintf("https://github.com/org/repo/pull/%d", number), Number: number}
+}
+
+func journalPR(number int) PR {
+	ref := journalRef(number)
+	return PR{Repository: ref.Repository, URL: ref.URL, Number: number}
+}
+
+func TestDiffPRLists(t *testing.T) {
+	kept, changed, closed, filtered := journalPR(1), journalPR(2), journalPR(3), journalPR(4)
+	mine := journalPR(5)
+	added := journalPR(6)
+	prev := []PR{kept, changed, closed, filtered}
+	changedNow := changed
+	changedNow.NeedsReview, changedNow.ActionKind = true, actionReview
+
+	got := diffPRLists(prev, []PR{mine}, []PR{kept, changedNow, added}, []PR{mine}, []PR{filtered}, true)
+	want := ChangeSet{
+		Added:           []PRRef{journalRef(6)},
+		Removed:         []PRRef{journalRef(3)},
+		TurnDataUpdated: []PRRef{journalRef(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffPRLists() = %+v, want %+v", got, want)
+	}
+
+	// A PR missing from a partial fetch hasn't closed
+	got = diffPRLists(prev, nil, []PR{kept, changed, filtered}, nil, nil, false)
+	if !got.empty() {
+		t.Errorf("diffPRLists() for a partial fetch = %+v, want no changes", got)
+	}
+}
+
+func TestChangeJournalKeepsLastCycles(t *testing.T) {
+	var j changeJournal
+	for i := range changeJournalSize + 5 {
+		j.note(&ChangeSet{Added: []PRRef{journalRef(i + 1)}})
+		j.commit(time.Unix(int64(i), 0))
+	}
+	sets := j.snapshot()
+	if len(sets) != changeJournalSize {
+		t.Fatalf("kept %d sets, want %d", len(sets), changeJournalSize)
+	}
+	if first := sets[0].Added[0].Number; first != 6 {
+		t.Errorf("oldest kept set added #%d, want #6", first)
+	}
+	if last := j.commit(time.Now()); !last.empty() {
+		t.Error("a cycle with nothing noted isn't empty")
+	}
+
+	var b strings.Builder
+	writeChangeSets(&b, j.snapshot()[changeJournalSize-2:])
+	if report := b.String(); !strings.Contains(report, "added=org/repo#25") || strings.Count(report, "\n  ") != 1 {
+		t.Errorf("report =\n%s\nwant only the cycle that changed something", report)
+	}
+}
+
+// journalServers serves the PRs in prs to the involves: search, and Turn verdicts that
+// block testuser on the PRs in blocking.
+type journalServers struct {
+	prs      map[int]time.Time // PR number -> updated_at
+	blocking map[int]bool
+	mu       sync.Mutex
+}
+
+func (s *journalServers) set(prs map[int]time.Time, blocking ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prs = prs
+	s.blocking = make(map[int]bool)
+	for _, n := range blocking {
+		s.blocking[n] = true
+	}
+}
+
+func newJournalTestApp(t *testing.T, s *journalServers) *App {
+	t.Helper()
+	search := newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		var result fakeSearch
+		s.mu.Lock()
+		if strings.Contains(r.URL.Query().Get("q"), "involves:") {
+			for number, updated := range s.prs {
+				result.prs = append(result.prs, fakePR{repo: "org/repo", number: number, updated: updated})
+			}
+		}
+		s.mu.Unlock()
+		return result
+	})
+	turnClient := newFakeTurn(t, func(_ context.Context, req turn.CheckRequest) fakeVerdict {
+		var number int
+		if _, err := fmt.Sscanf(req.URL, "https://github.com/org/repo/pull/%d", &number); err != nil {
+			t.Errorf("unexpected Turn request for %q", req.URL)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		next := map[string]any{}
+		if s.blocking[number] {
+			next["testuser"] = map[string]any{"kind": "review", "critical": true}
+		}
+		return fakeVerdict{next: next}
+	})
+	app := newFetchTestApp(t, search.URL, turnClient, "testuser")
+	app.noCache = true
+	app.soundPlayer = silentSoundPlayer{}
+	app.browser = silentBrowser{}
+	return app
+}
+
+// lastChanges returns the latest cycle's ChangeSet, without its time.
+func lastChanges(t *testing.T, app *App) ChangeSet {
+	t.Helper()
+	sets := app.journal.snapshot()
+	if len(sets) == 0 {
+		t.Fatal("no change sets recorded")
+	}
+	c := sets[len(sets)-1]
+	c.At = time.Time{}
+	for _, refs := range [][]PRRef{c.Added, c.Removed, c.BlockedTransitions, c.UnblockedTransitions, c.TurnDataUpdated, c.NotificationsSent} {
+		slices.SortFunc(refs, func(a, b PRRef) int { return a.Number - b.Number })
+	}
+	return c
+}
+
+func TestUpdateCycleChangeSets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Add(-time.Hour)
+	servers := &journalServers{}
+	app := newJournalTestApp(t, servers)
+	notifier, ok := app.notifier.(*messageNotifier)
+	if !ok {
+		t.Fatal("expected a messageNotifier")
+	}
+
+	// First load: #1 is already blocked, which is discovery rather than a transition
+	servers.set(map[int]time.Time{1: now, 2: now, 3: now}, 1)
+	app.updatePRs(ctx)
+	want := ChangeSet{Added: []PRRef{journalRef(1), journalRef(2), journalRef(3)}}
+	if got := lastChanges(t, app); !reflect.DeepEqual(got, want) {
+		t.Fatalf("first cycle = %+v, want %+v", got, want)
+	}
+
+	// #1 is reviewed, #2 now waits on me, #3 merges, and #4 is opened
+	later := now.Add(30 * time.Minute)
+	servers.set(map[int]time.Time{1: later, 2: later, 4: later}, 2)
+	app.updatePRs(ctx)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		notifier.mu.Lock()
+		sent := len(notifier.notes)
+		notifier.mu.Unlock()
+		if sent > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no notification for the newly blocked PR")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The notification for #2 is shown asynchronously, by the end of this cycle or the next
+	app.updatePRs(ctx)
+	sets := app.journal.snapshot()
+	if len(sets) != 3 {
+		t.Fatalf("recorded %d change sets, want 3", len(sets))
+	}
+	second, third := sets[1], sets[2]
+	if notified := slices.Concat(second.NotificationsSent, third.NotificationsSent); !slices.Equal(notified, []PRRef{journalRef(2)}) {
+		t.Errorf("notifications sent = %+v, want #2", notified)
+	}
+	second.NotificationsSent, third.NotificationsSent = nil, nil
+	app.journal.sets = []ChangeSet{second}
+	want = ChangeSet{
+		Added:                []PRRef{journalRef(4)},
+		Removed:              []PRRef{journalRef(3)},
+		BlockedTransitions:   []PRRef{journalRef(2)},
+		UnblockedTransitions: []PRRef{journalRef(1)},
+		TurnDataUpdated:      []PRRef{journalRef(1), journalRef(2)},
+	}
+	if got := lastChanges(t, app); !reflect.DeepEqual(got, want) {
+		t.Errorf("second cycle = %+v, want %+v", got, want)
+	}
+	if !third.empty() {
+		t.Errorf("third cycle = %+v, want no changes", third)
+	}
+	app.journal.sets = sets
+
+	// "Recently cleared" comes from the same transition
+	if cleared := app.stateManager.RecentlyCleared(); len(cleared) != 1 || cleared[0].PR.Number != 1 {
+		t.Errorf("RecentlyCleared() = %+v, want #1", cleared)
+	}
+	if report := app.diagnosticReport(time.Now()); !strings.Contains(report, "blocked=org/repo#2") {
+		t.Errorf("diagnostic report doesn't list the changes:\n%s", report)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// recentlyClearedWindow is how long a PR that left the blocked state stays in "Recently cleared".
+const recentlyClearedWindow = 10 * time.Minute
+
+// Who cleared a blocked incoming PR.
+const (
+	clearedByYou    = "you"
+	clearedByAuthor = "author"
+	clearedByOther  = "other"
+)
+
+// unblockReason is Turn's last activity at the moment a blocked incoming PR became unblocked.
+type unblockReason struct {
+	ClearedAt time.Time
+	Actor     string
+	Kind      string
+}
+
+// clearedPR is an incoming PR that recently left the blocked state.
+type clearedPR struct {
+	PR            PR
+	UnblockReason unblockReason
+}
+
+// attribution reports whether me, the PR author, or someone else cleared the PR.
+func (c clearedPR) attribution(me string) string {
+	actor := c.UnblockReason.Actor
+	switch {
+	case actor != "" && strings.EqualFold(actor, me):
+		return clearedByYou
+	case actor != "" && strings.EqualFold(actor, c.PR.Author):
+		return clearedByAuthor
+	default:
+		return clearedByOther
+	}
+}
+
+// description is the "cleared — you approved 4m ago" line shown for the PR.
+func (c clearedPR) description(me string, now time.Time) string {
+	ago := clearedAge(now.Sub(c.UnblockReason.ClearedAt))
+	verb := activityVerb(c.UnblockReason.Kind)
+	switch c.attribution(me) {
+	case clearedByYou:
+		return msg("cleared.you", verb, ago)
+	case clearedByAuthor:
+		return msg("cleared.author", verb, ago)
+	default:
+		if c.UnblockReason.Actor == "" {
+			return msg("cleared.unknown", ago)
+		}
+		return msg("cleared.other", c.UnblockReason.Actor, verb, ago)
+	}
+}
+
+// activityVerb phrases a Turn activity kind as a past-tense action.
+func activityVerb(kind string) string {
+	kind = strings.ToLower(kind)
+	switch {
+	case strings.Contains(kind, "approv"):
+		return msg("cleared.kind.approved")
+	case strings.Contains(kind, "push"), strings.Contains(kind, "commit"):
+		return msg("cleared.kind.pushed")
+	case strings.Contains(kind, "review"):
+		return msg("cleared.kind.reviewed")
+	case strings.Contains(kind, "comment"):
+		return msg("cleared.kind.commented")
+	case strings.Contains(kind, "merge"):
+		return msg("cleared.kind.merged")
+	default:
+		return msg("cleared.kind.updated")
+	}
+}
+
+// clearedAge formats how long ago a PR was cleared; the window keeps it under an hour.
+func clearedAge(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// recordCleared captures why a blocked incoming PR just unblocked. Caller must hold m.mu.
+func (m *PRStateManager) recordCleared(pr PR, now time.Time) {
+	if m.cleared == nil {
+		m.cleared = make(map[string]clearedPR)
+	}
+	c := clearedPR{
+		PR: pr,
+		UnblockReason: unblockReason{
+			ClearedAt: now,
+			Actor:     pr.LastActivityActor,
+			Kind:      pr.LastActivityKind,
+		},
+	}
+	m.cleared[pr.URL] = c
+	m.clearedLog = append(m.clearedLog, c)
+	slog.Info("[STATE] Recorded unblock reason",
+		"repo", pr.Repository, "number", pr.Number,
+		"actor", sanitizeForLog(pr.LastActivityActor), "kind", pr.LastActivityKind)
+}
+
+// pruneCleared drops entries older than recentlyClearedWindow, and history older than
+// clearedHistoryWindow. Caller must hold m.mu.
+func (m *PRStateManager) pruneCleared(now time.Time) {
+	for url, c := range m.cleared {
+		if now.Sub(c.UnblockReason.ClearedAt) >= recentlyClearedWindow {
+			delete(m.cleared, url)
+		}
+	}
+	m.clearedLog = slices.DeleteFunc(m.clearedLog, func(c clearedPR) bool {
+		return now.Sub(c.UnblockReason.ClearedAt) >= clearedHistoryWindow
+	})
+}
+
+// ClearedHistory returns every PR cleared within clearedHistoryWindow, oldest first. A
+// PR cleared twice is listed twice.
+func (m *PRStateManager) ClearedHistory() []clearedPR {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.clearedLog)
+}
+
+// RecentlyCleared returns incoming PRs cleared within recentlyClearedWindow, newest first.
+func (m *PRStateManager) RecentlyCleared() []clearedPR {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := m.now()
+	var result []clearedPR
+	for _, c := range m.cleared {
+		if now.Sub(c.UnblockReason.ClearedAt) < recentlyClearedWindow {
+			result = append(result, c)
+		}
+	}
+	slices.SortFunc(result, func(a, b clearedPR) int {
+		return b.UnblockReason.ClearedAt.Compare(a.UnblockReason.ClearedAt)
+	})
+	return result
+}
+
+// recentlyClearedTitles lists the "Recently cleared" entries for change detection.
+func (app *App) recentlyClearedTitles() []string {
+	if app.stateManager == nil {
+		return nil
+	}
+	cleared := app.stateManager.RecentlyCleared()
+	if len(cleared) == 0 {
+		return nil
+	}
+	titles := []string{msg("cleared.menu", len(cleared))}
+	for _, c := range cleared {
+		titles = append(titles, clearedTitle(c.PR))
+	}
+	return titles
+}
+
+// clearedTitle is the submenu label for a cleared PR.
+func clearedTitle(pr PR) string {
+	return fmt.Sprintf("%s #%d", pr.Repository, pr.Number)
+}
+
+// addRecentlyCleared adds a collapsed "Recently cleared" submenu; each entry's tooltip says who cleared it.
+func (app *App) addRecentlyCleared(ctx context.Context) {
+	if app.stateManager == nil {
+		return
+	}
+	cleared := app.stateManager.RecentlyCleared()
+	if len(cleared) == 0 {
+		return
+	}
+
+	app.mu.RLock()
+	me := app.targetUser
+	app.mu.RUnlock()
+
+	now := time.Now()
+	clearedMenu := app.systrayInterface.AddMenuItem(msg("cleared.menu", len(cleared)), msg("cleared.menu.tooltip"))
+	for _, c := range cleared {
+		item := clearedMenu.AddSubMenuItem(clearedTitle(c.PR), c.description(me, now))
+		url := prLink(&c.PR)
+		item.Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+				return
+			}
+			app.resumeAutoOpen(ctx)
+		})
+	}
+	app.systrayInterface.AddSeparator()
+}
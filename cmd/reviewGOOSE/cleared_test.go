@@ -0,0 +1,124 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// clearBlockedPR drives a PR through blocked -> unblocked with the given last activity.
+func clearBlockedPR(t *testing.T, m *PRStateManager, actor, kind string) {
+	t.Helper()
+	pr := PR{
+		Repository:     "acme/widgets",
+		Number:         7,
+		URL:            "https://github.com/acme/widgets/pull/7",
+		Author:         "alice",
+		NeedsReview:    true,
+		UpdatedAt:      m.now(),
+		LastActivityAt: m.now(),
+	}
+	m.UpdatePRs([]PR{pr}, nil, nil, true)
+
+	pr.NeedsReview = false
+	pr.LastActivityActor = actor
+	pr.LastActivityKind = kind
+	m.UpdatePRs([]PR{pr}, nil, nil, false)
+}
+
+func TestUnblockReasonAttribution(t *testing.T) {
+	tests := []struct {
+		name, actor, kind string
+		wantBy, wantDesc  string
+	}{
+		{name: "you approved", actor: "Bob", kind: "approve", wantBy: clearedByYou, wantDesc: "cleared — you approved 4m ago"},
+		{name: "author pushed", actor: "alice", kind: "push", wantBy: clearedByAuthor, wantDesc: "cleared — author pushed new commits 4m ago"},
+		{name: "someone else reviewed", actor: "carol", kind: "review", wantBy: clearedByOther, wantDesc: "cleared — @carol reviewed 4m ago"},
+		{name: "no actor", kind: "", wantBy: clearedByOther, wantDesc: "cleared 4m ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			m := NewPRStateManager(start.Add(-time.Hour))
+			m.now = func() time.Time { return start }
+
+			clearBlockedPR(t, m, tt.actor, tt.kind)
+
+			cleared := m.RecentlyCleared()
+			if len(cleared) != 1 {
+				t.Fatalf("RecentlyCleared() = %d entries, want 1", len(cleared))
+			}
+			c := cleared[0]
+			if c.UnblockReason.Actor != tt.actor || c.UnblockReason.Kind != tt.kind {
+				t.Errorf("UnblockReason = %+v, want actor %q kind %q", c.UnblockReason, tt.actor, tt.kind)
+			}
+			if got := c.attribution("bob"); got != tt.wantBy {
+				t.Errorf("attribution = %q, want %q", got, tt.wantBy)
+			}
+			if got := c.description("bob", start.Add(4*time.Minute)); got != tt.wantDesc {
+				t.Errorf("description = %q, want %q", got, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestRecentlyClearedWindow(t *testing.T) {
+	now := time.Now()
+	m := NewPRStateManager(now.Add(-time.Hour))
+	m.now = func() time.Time { return now }
+
+	clearBlockedPR(t, m, "bob", "approve")
+
+	now = now.Add(recentlyClearedWindow - time.Second)
+	if got := len(m.RecentlyCleared()); got != 1 {
+		t.Fatalf("just inside the window: %d entries, want 1", got)
+	}
+
+	now = now.Add(time.Second)
+	if got := len(m.RecentlyCleared()); got != 0 {
+		t.Fatalf("after the window: %d entries, want 0", got)
+	}
+
+	// The next update prunes the expired entry for good.
+	m.UpdatePRs(nil, nil, nil, false)
+	if len(m.cleared) != 0 {
+		t.Errorf("expired entries should be pruned, have %d", len(m.cleared))
+	}
+}
+
+func TestClearedOnlyTracksIncoming(t *testing.T) {
+	m := NewPRStateManager(time.Now().Add(-time.Hour))
+	pr := PR{Repository: "acme/widgets", Number: 8, URL: "https://github.com/acme/widgets/pull/8", IsBlocked: true, UpdatedAt: time.Now()}
+	m.UpdatePRs(nil, []PR{pr}, nil, true)
+	pr.IsBlocked = false
+	m.UpdatePRs(nil, []PR{pr}, nil, false)
+
+	if got := m.RecentlyCleared(); len(got) != 0 {
+		t.Errorf("outgoing PRs should not be tracked as cleared, got %+v", got)
+	}
+}
+
+func TestReblockedPRLeavesRecentlyCleared(t *testing.T) {
+	m := NewPRStateManager(time.Now().Add(-time.Hour))
+	clearBlockedPR(t, m, "bob", "approve")
+
+	pr := PR{Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7", NeedsReview: true, UpdatedAt: time.Now()}
+	m.UpdatePRs([]PR{pr}, nil, nil, false)
+
+	if got := m.RecentlyCleared(); len(got) != 0 {
+		t.Errorf("a re-blocked PR should leave Recently cleared, got %+v", got)
+	}
+}
+
+func TestRecentlyClearedMenuTitles(t *testing.T) {
+	app := &App{stateManager: NewPRStateManager(time.Now().Add(-time.Hour)), targetUser: "bob"}
+	if got := app.recentlyClearedTitles(); got != nil {
+		t.Fatalf("no cleared PRs should add no titles, got %v", got)
+	}
+
+	clearBlockedPR(t, app.stateManager, "bob", "approve")
+	want := []string{"✅ Recently cleared (1)", "acme/widgets #7"}
+	if got := app.recentlyClearedTitles(); !slices.Equal(got, want) {
+		t.Errorf("recentlyClearedTitles() = %v, want %v", got, want)
+	}
+}
@@ -16,7 +16,7 @@ func TestMenuClickRateLimit(t *testing.T) {
 		mu:                sync.RWMutex{},
 		stateManager:      NewPRStateManager(time.Now().Add(-35 * time.Second)),
 		hiddenOrgs:        make(map[string]bool),
-		seenOrgs:          make(map[string]bool),
+		seenOrgs:          make(map[string]orgActivity),
 		lastSearchAttempt: time.Now().Add(-15 * time.Second), // 15 seconds ago
 		systrayInterface:  &MockSystray{},                    // Use mock systray to avoid panics
 	}
@@ -68,7 +68,7 @@ func TestScheduledUpdateRateLimit(t *testing.T) {
 		mu:                sync.RWMutex{},
 		stateManager:      NewPRStateManager(time.Now().Add(-35 * time.Second)),
 		hiddenOrgs:        make(map[string]bool),
-		seenOrgs:          make(map[string]bool),
+		seenOrgs:          make(map[string]orgActivity),
 		lastSearchAttempt: time.Now().Add(-5 * time.Second), // 5 seconds ago
 		systrayInterface:  &MockSystray{},                   // Use mock systray to avoid panics
 	}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// errNoClipboard means no clipboard tool is available, e.g. on a headless Linux box.
+var errNoClipboard = errors.New("no clipboard available")
+
+// Clipboard copies text to the system clipboard.
+type Clipboard interface {
+	Copy(ctx context.Context, text string) error
+}
+
+// systemClipboard pipes text into the platform's clipboard command.
+type systemClipboard struct{}
+
+func (systemClipboard) Copy(ctx context.Context, text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		// Wayland first when it's running, then the common X11 tools
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if os.Getenv("DISPLAY") != "" {
+			candidates = append(candidates, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+		}
+	}
+
+	copyCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	for _, args := range candidates {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.CommandContext(copyCtx, path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return errNoClipboard
+}
+
+// copyToClipboard copies text, defaulting to the system clipboard. When copying fails
+// the text is shown in a notification instead so it can still be read and retyped.
+func (app *App) copyToClipboard(ctx context.Context, text string) {
+	var clipboard Clipboard = systemClipboard{}
+	if app.clipboard != nil {
+		clipboard = app.clipboard
+	}
+	err := clipboard.Copy(ctx, text)
+	if err == nil {
+		slog.Debug("[CLIPBOARD] Copied", "text", sanitizeForLog(text))
+		return
+	}
+	slog.Warn("[CLIPBOARD] Copy failed, showing the text in a notification instead", "error", err)
+	if err := app.notify(msg("clipboard.unavailable"), text); err != nil {
+		slog.Error("Failed to send clipboard fallback notification", "error", err)
+	}
+}
+
+// markdownEscaper escapes characters that would end or nest a Markdown link label.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+// markdownReference formats a PR as "[org/repo#123: Title](url)" for pasting into chat.
+func markdownReference(pr *PR) string {
+	return fmt.Sprintf("[%s#%d: %s](%s)", markdownEscaper.Replace(pr.Repository), pr.Number, markdownEscaper.Replace(pr.Title), pr.URL)
+}
+
+// addPRActions adds the per-PR submenu. A parent item with a submenu isn't clickable on
+// every platform, so the submenu always leads with an open action.
+func (app *App) addPRActions(ctx context.Context, item MenuItem, pr *PR, openURL string) {
+	if app.dashboard != nil && app.dashboard.custom {
+		app.addDashboardLinks(ctx, item, pr, openURL)
+	} else {
+		item.AddSubMenuItem(msg("dashboard.open_github"), "").Click(func() {
+			if err := app.openBrowser(ctx, openURL, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+			}
+		})
+	}
+
+	prURL := pr.URL
+	item.AddSubMenuItem(msg("pr.copy_url"), prURL).Click(func() {
+		app.copyToClipboard(ctx, prURL)
+	})
+	reference := markdownReference(pr)
+	item.AddSubMenuItem(msg("pr.copy_markdown"), reference).Click(func() {
+		app.copyToClipboard(ctx, reference)
+	})
+}
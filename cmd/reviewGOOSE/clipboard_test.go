@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// recordingClipboard records copied text, or fails every copy with err.
+type recordingClipboard struct {
+	err    error
+	copied []string
+}
+
+func (c *recordingClipboard) Copy(_ context.Context, text string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.copied = append(c.copied, text)
+	return nil
+}
+
+func TestMarkdownReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "plain", title: "Add retries", want: "[acme/widgets#7: Add retries](https://github.com/acme/widgets/pull/7)"},
+		{name: "brackets", title: "[WIP] fix [link]", want: `[acme/widgets#7: \[WIP\] fix \[link\]](https://github.com/acme/widgets/pull/7)`},
+		{name: "backslash", title: `path\to`, want: `[acme/widgets#7: path\\to](https://github.com/acme/widgets/pull/7)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PR{Repository: "acme/widgets", Number: 7, Title: tt.title, URL: "https://github.com/acme/widgets/pull/7"}
+			if got := markdownReference(pr); got != tt.want {
+				t.Errorf("markdownReference() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// prActionItem returns the sub item with the given title.
+func prActionItem(t *testing.T, item *MockMenuItem, title string) *MockMenuItem {
+	t.Helper()
+	for _, sub := range item.subItems {
+		if m, ok := sub.(*MockMenuItem); ok && m.title == title {
+			return m
+		}
+	}
+	t.Fatalf("no %q action under %q", title, item.title)
+	return nil
+}
+
+func TestPRActionsCopy(t *testing.T) {
+	app := newFocusTestApp(0)
+	clipboard := &recordingClipboard{}
+	app.clipboard = clipboard
+	pr := &PR{Repository: "acme/widgets", Number: 7, Title: "[RFC] New API", URL: "https://github.com/acme/widgets/pull/7"}
+
+	item := &MockMenuItem{title: "acme/widgets #7"}
+	app.addPRActions(context.Background(), item, pr, pr.URL)
+
+	prActionItem(t, item, "Copy URL").clickHandler()
+	prActionItem(t, item, "Copy as Markdown").clickHandler()
+	want := []string{
+		"https://github.com/acme/widgets/pull/7",
+		`[acme/widgets#7: \[RFC\] New API](https://github.com/acme/widgets/pull/7)`,
+	}
+	if !slices.Equal(clipboard.copied, want) {
+		t.Errorf("copied %q, want %q", clipboard.copied, want)
+	}
+}
+
+func TestPRActionsKeepOpen(t *testing.T) {
+	app := newFocusTestApp(0)
+	app.browser = silentBrowser{}
+	pr := &PR{Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7"}
+
+	item := &MockMenuItem{}
+	app.addPRActions(context.Background(), item, pr, pr.URL+"/checks")
+	if first, ok := item.subItems[0].(*MockMenuItem); !ok || first.title != "Open on GitHub" {
+		t.Fatalf("the submenu should lead with an open action, got %+v", item.subItems[0])
+	}
+
+	app.dashboard, _ = newDashboardConfig("https://menu.dash.example", "")
+	item = &MockMenuItem{}
+	app.addPRActions(context.Background(), item, pr, pr.URL)
+	if len(item.subItems) != 4 {
+		t.Errorf("custom dashboard: got %d sub items, want open, dashboard, and two copy actions", len(item.subItems))
+	}
+}
+
+func TestCopyFallsBackToNotification(t *testing.T) {
+	app := newFocusTestApp(0)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.clipboard = &recordingClipboard{err: errNoClipboard}
+
+	app.copyToClipboard(context.Background(), "https://github.com/acme/widgets/pull/7")
+	want := "Clipboard unavailable; copy this manually: https://github.com/acme/widgets/pull/7"
+	if len(notifier.notes) != 1 || notifier.notes[0] != want {
+		t.Errorf("notifications = %q, want %q", notifier.notes, want)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// colorSchemePollInterval is how often the color scheme is re-read when the desktop
+// can't announce changes.
+const colorSchemePollInterval = 30 * time.Second
+
+// colorScheme is the desktop's light/dark preference. Values match the
+// org.freedesktop.appearance color-scheme setting.
+type colorScheme uint32
+
+const (
+	schemeDefault colorScheme = iota // No preference
+	schemeDark
+	schemeLight
+)
+
+func (s colorScheme) String() string {
+	switch s {
+	case schemeDark:
+		return "dark"
+	case schemeLight:
+		return "light"
+	default:
+		return "default"
+	}
+}
+
+// colorSchemeSource reads the desktop color scheme.
+type colorSchemeSource interface {
+	// current returns the scheme in effect now.
+	current(ctx context.Context) (colorScheme, error)
+	// subscribe delivers changes until ctx is done, then closes the channel. It returns an
+	// error if the desktop can't announce changes; the watcher polls current instead.
+	subscribe(ctx context.Context) (<-chan colorScheme, error)
+}
+
+// colorSchemeWatcher reports color scheme changes until its context is done.
+type colorSchemeWatcher struct {
+	source   colorSchemeSource
+	onChange func(colorScheme)
+	interval time.Duration
+}
+
+func newColorSchemeWatcher(source colorSchemeSource, onChange func(colorScheme)) *colorSchemeWatcher {
+	return &colorSchemeWatcher{source: source, onChange: onChange, interval: colorSchemePollInterval}
+}
+
+// run calls onChange with the initial scheme, if it isn't the default, and on every change.
+// It returns when ctx is done, or right away if the scheme can't be read at all.
+func (w *colorSchemeWatcher) run(ctx context.Context) {
+	last := schemeDefault
+	report := func(s colorScheme) {
+		if s == last {
+			return
+		}
+		slog.Info("[THEME] Desktop color scheme changed", "from", last, "to", s)
+		last = s
+		w.onChange(s)
+	}
+
+	initial, readErr := w.source.current(ctx)
+	if readErr == nil {
+		report(initial)
+	}
+
+	changes, err := w.source.subscribe(ctx)
+	if err == nil {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case s, ok := <-changes:
+				if !ok {
+					return
+				}
+				report(s)
+			}
+		}
+	}
+	if readErr != nil {
+		slog.Debug("[THEME] Color scheme unavailable, not following it", "error", readErr, "subscribe_error", err)
+		return
+	}
+
+	slog.Debug("[THEME] Color scheme changes aren't announced, polling", "error", err, "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s, err := w.source.current(ctx)
+			if err != nil {
+				slog.Debug("[THEME] Failed to read color scheme", "error", err)
+				continue
+			}
+			report(s)
+		}
+	}
+}
+
+// setColorScheme redraws the tray icon for a new color scheme. Only the icon changes;
+// the menu is left alone.
+func (app *App) setColorScheme(scheme colorScheme) {
+	app.tray.mu.Lock()
+	defer app.tray.mu.Unlock()
+	if app.tray.scheme == scheme {
+		return
+	}
+	app.tray.scheme = scheme
+	if app.tray.shown {
+		app.showIconLocked()
+	}
+}
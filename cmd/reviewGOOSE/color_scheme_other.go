@@ -0,0 +1,8 @@
+//go:build !(linux || freebsd || openbsd || netbsd || dragonfly || solaris || illumos || aix)
+
+package main
+
+// newDesktopColorScheme returns nil: macOS and Windows tray icons don't follow the color scheme.
+func newDesktopColorScheme() colorSchemeSource {
+	return nil
+}
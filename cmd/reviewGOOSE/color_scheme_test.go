@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeColorScheme is a colorSchemeSource the test controls. A nil changes channel
+// means the desktop can't announce changes.
+type fakeColorScheme struct {
+	readErr error
+	changes chan colorScheme
+	scheme  colorScheme
+	mu      sync.Mutex
+}
+
+func (f *fakeColorScheme) set(s colorScheme) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scheme = s
+}
+
+func (f *fakeColorScheme) current(context.Context) (colorScheme, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.scheme, f.readErr
+}
+
+func (f *fakeColorScheme) subscribe(context.Context) (<-chan colorScheme, error) {
+	if f.changes == nil {
+		return nil, errors.New("no portal")
+	}
+	return f.changes, nil
+}
+
+// schemeRecorder collects the schemes a watcher reports.
+type schemeRecorder struct {
+	got []colorScheme
+	mu  sync.Mutex
+}
+
+func (r *schemeRecorder) record(s colorScheme) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, s)
+}
+
+func (r *schemeRecorder) waitFor(t *testing.T, want ...colorScheme) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		r.mu.Lock()
+		got := slices.Clone(r.got)
+		r.mu.Unlock()
+		if slices.Equal(got, want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("reported schemes = %v, want %v", got, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func startWatcher(t *testing.T, w *colorSchemeWatcher) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(ctx)
+	}()
+	return func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("watcher didn't stop with its context")
+		}
+	}
+}
+
+func TestColorSchemeWatcherFollowsPortal(t *testing.T) {
+	source := &fakeColorScheme{scheme: schemeDark, changes: make(chan colorScheme)}
+	rec := &schemeRecorder{}
+	stop := startWatcher(t, newColorSchemeWatcher(source, rec.record))
+
+	rec.waitFor(t, schemeDark)
+	source.changes <- schemeDark // Repeats aren't reported
+	source.changes <- schemeLight
+	source.changes <- schemeDefault
+	rec.waitFor(t, schemeDark, schemeLight, schemeDefault)
+	stop()
+}
+
+func TestColorSchemeWatcherPollsWithoutPortal(t *testing.T) {
+	source := &fakeColorScheme{}
+	rec := &schemeRecorder{}
+	w := newColorSchemeWatcher(source, rec.record)
+	w.interval = 5 * time.Millisecond
+	stop := startWatcher(t, w)
+
+	time.Sleep(20 * time.Millisecond)
+	rec.waitFor(t) // Starting on the default scheme reports nothing
+	source.set(schemeLight)
+	rec.waitFor(t, schemeLight)
+	source.set(schemeDark)
+	rec.waitFor(t, schemeLight, schemeDark)
+	stop()
+}
+
+func TestColorSchemeWatcherGivesUpWithoutSource(t *testing.T) {
+	source := &fakeColorScheme{readErr: errors.New("no gsettings")}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		newColorSchemeWatcher(source, func(colorScheme) { t.Error("nothing should be reported") }).run(context.Background())
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher kept polling a scheme it can't read")
+	}
+}
+
+func TestSetColorSchemeRedrawsIconOnly(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("test app should use MockSystray")
+	}
+	counts := PRCounts{IncomingBlocked: 2}
+
+	app.setColorScheme(schemeDark) // Nothing on screen yet
+	if _, sets := mock.iconState(); sets != 0 {
+		t.Fatalf("a scheme change drew %d icons before the first one was set", sets)
+	}
+
+	app.setTrayIcon(IconGoose, counts)
+	if last, _ := mock.iconState(); !bytes.Equal(last, themedIcon(IconGoose, counts, schemeDark)) {
+		t.Error("the icon wasn't drawn for the dark scheme")
+	}
+	menu := slices.Clone(mock.menuItems)
+
+	app.setColorScheme(schemeLight)
+	last, sets := mock.iconState()
+	if sets != 2 || !bytes.Equal(last, themedIcon(IconGoose, counts, schemeLight)) {
+		t.Errorf("after switching to light: %d icon updates, want the light badge redrawn", sets)
+	}
+	app.setColorScheme(schemeLight)
+	if _, again := mock.iconState(); again != sets {
+		t.Error("an unchanged scheme redrew the icon")
+	}
+	if !slices.Equal(mock.menuItems, menu) {
+		t.Errorf("a scheme change touched the menu: %q", mock.menuItems)
+	}
+}
@@ -0,0 +1,188 @@
+//go:build linux || freebsd || openbsd || netbsd || dragonfly || solaris || illumos || aix
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	portalDestination   = "org.freedesktop.portal.Desktop"
+	portalPath          = "/org/freedesktop/portal/desktop"
+	portalSettings      = "org.freedesktop.portal.Settings"
+	appearanceNamespace = "org.freedesktop.appearance"
+	colorSchemeKey      = "color-scheme"
+)
+
+// desktopColorScheme reads the color scheme from the XDG settings portal, falling back to
+// GNOME's gsettings when no portal is running.
+type desktopColorScheme struct {
+	conn     *dbus.Conn
+	mu       sync.Mutex
+	noPortal bool
+}
+
+func newDesktopColorScheme() colorSchemeSource {
+	return &desktopColorScheme{}
+}
+
+func (d *desktopColorScheme) current(ctx context.Context) (colorScheme, error) {
+	if conn := d.portal(); conn != nil {
+		scheme, err := readPortalScheme(ctx, conn)
+		if err == nil {
+			return scheme, nil
+		}
+		slog.Debug("[THEME] Settings portal read failed, trying gsettings", "error", err)
+	}
+	out, err := exec.CommandContext(ctx, "gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output()
+	if err != nil {
+		return schemeDefault, fmt.Errorf("gsettings: %w", err)
+	}
+	return parseGSettingsScheme(string(out)), nil
+}
+
+func (d *desktopColorScheme) subscribe(ctx context.Context) (<-chan colorScheme, error) {
+	conn := d.portal()
+	if conn == nil {
+		return nil, errors.New("no D-Bus session bus")
+	}
+	if _, err := readPortalScheme(ctx, conn); err != nil {
+		d.closePortal()
+		return nil, fmt.Errorf("settings portal: %w", err)
+	}
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(portalPath),
+		dbus.WithMatchInterface(portalSettings),
+		dbus.WithMatchMember("SettingChanged"),
+	); err != nil {
+		d.closePortal()
+		return nil, fmt.Errorf("watch settings portal: %w", err)
+	}
+
+	signals := make(chan *dbus.Signal, 8)
+	conn.Signal(signals)
+	changes := make(chan colorScheme)
+	go func() {
+		defer close(changes)
+		defer d.closePortal()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig, ok := <-signals:
+				if !ok {
+					return
+				}
+				scheme, ok := portalSchemeChange(sig)
+				if !ok {
+					continue
+				}
+				select {
+				case changes <- scheme:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// portal returns the session bus connection, or nil once the portal is known to be missing.
+func (d *desktopColorScheme) portal() *dbus.Conn {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.noPortal {
+		return nil
+	}
+	if d.conn == nil {
+		conn, err := dbus.ConnectSessionBus()
+		if err != nil {
+			slog.Debug("[THEME] Failed to connect to D-Bus session bus", "error", err)
+			d.noPortal = true
+			return nil
+		}
+		d.conn = conn
+	}
+	return d.conn
+}
+
+// closePortal drops the connection; later reads go straight to gsettings.
+func (d *desktopColorScheme) closePortal() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.noPortal = true
+	if d.conn == nil {
+		return
+	}
+	if err := d.conn.Close(); err != nil {
+		slog.Debug("[THEME] Failed to close D-Bus connection", "error", err)
+	}
+	d.conn = nil
+}
+
+// readPortalScheme reads color-scheme with ReadOne, or with the deprecated Read on older portals.
+func readPortalScheme(ctx context.Context, conn *dbus.Conn) (colorScheme, error) {
+	obj := conn.Object(portalDestination, portalPath)
+	var value dbus.Variant
+	err := obj.CallWithContext(ctx, portalSettings+".ReadOne", 0, appearanceNamespace, colorSchemeKey).Store(&value)
+	if err != nil {
+		if legacyErr := obj.CallWithContext(ctx, portalSettings+".Read", 0, appearanceNamespace, colorSchemeKey).Store(&value); legacyErr != nil {
+			return schemeDefault, err
+		}
+	}
+	return portalScheme(value)
+}
+
+// portalSchemeChange extracts the new scheme from a SettingChanged signal.
+func portalSchemeChange(sig *dbus.Signal) (colorScheme, bool) {
+	if sig == nil || len(sig.Body) < 3 {
+		return schemeDefault, false
+	}
+	namespace, _ := sig.Body[0].(string)
+	key, _ := sig.Body[1].(string)
+	value, ok := sig.Body[2].(dbus.Variant)
+	if namespace != appearanceNamespace || key != colorSchemeKey || !ok {
+		return schemeDefault, false
+	}
+	scheme, err := portalScheme(value)
+	return scheme, err == nil
+}
+
+// portalScheme decodes a color-scheme value. Read wraps it in a second variant.
+func portalScheme(value dbus.Variant) (colorScheme, error) {
+	v := value.Value()
+	if inner, ok := v.(dbus.Variant); ok {
+		v = inner.Value()
+	}
+	n, ok := v.(uint32)
+	if !ok {
+		return schemeDefault, fmt.Errorf("unexpected color-scheme value %v", value)
+	}
+	switch s := colorScheme(n); s {
+	case schemeDark, schemeLight:
+		return s, nil
+	default:
+		return schemeDefault, nil
+	}
+}
+
+// parseGSettingsScheme maps GNOME's color-scheme setting ('prefer-dark', 'prefer-light', 'default').
+func parseGSettingsScheme(out string) colorScheme {
+	switch strings.Trim(strings.TrimSpace(out), "'") {
+	case "prefer-dark":
+		return schemeDark
+	case "prefer-light":
+		return schemeLight
+	default:
+		return schemeDefault
+	}
+}
@@ -0,0 +1,38 @@
+//go:build linux || freebsd || openbsd || netbsd || dragonfly || solaris || illumos || aix
+
+package main
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestDesktopColorSchemeValues(t *testing.T) {
+	for out, want := range map[string]colorScheme{
+		"'prefer-dark'\n":  schemeDark,
+		"'prefer-light'\n": schemeLight,
+		"'default'\n":      schemeDefault,
+		"":                 schemeDefault,
+	} {
+		if got := parseGSettingsScheme(out); got != want {
+			t.Errorf("parseGSettingsScheme(%q) = %v, want %v", out, got, want)
+		}
+	}
+
+	sig := &dbus.Signal{Body: []any{appearanceNamespace, colorSchemeKey, dbus.MakeVariant(uint32(1))}}
+	if got, ok := portalSchemeChange(sig); !ok || got != schemeDark {
+		t.Errorf("SettingChanged to 1 = %v, %v; want dark", got, ok)
+	}
+	other := &dbus.Signal{Body: []any{"org.gnome.desktop.interface", "gtk-theme", dbus.MakeVariant("Adwaita")}}
+	if _, ok := portalSchemeChange(other); ok {
+		t.Error("an unrelated setting was taken as a color scheme change")
+	}
+	// The deprecated Read call nests the value in a second variant
+	if got, err := portalScheme(dbus.MakeVariant(dbus.MakeVariant(uint32(2)))); err != nil || got != schemeLight {
+		t.Errorf("nested light value = %v, %v", got, err)
+	}
+	if _, err := portalScheme(dbus.MakeVariant("dark")); err == nil {
+		t.Error("a non-integer value should be rejected")
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// A burst of new comments on a PR I've reviewed or written usually means I'm needed
+// again, even before Turn hands me a next action. Each PR's comment count from the
+// search results is compared against a baseline taken when I last opened the PR from
+// goose or was notified about it. Once enough new comments pile up on a PR that isn't
+// blocked on me, its label gets a "💬 +7" suffix, and, if enabled, a quiet notification
+// goes out.
+
+// defaultCommentBurstThreshold is how many new comments make a burst, unless
+// comment_burst_threshold says otherwise.
+const defaultCommentBurstThreshold = 5
+
+// commentCount is a PR's comment count at the baseline and at the latest update.
+type commentCount struct {
+	baseline int // When I last opened or was notified about the PR
+	latest   int
+}
+
+// TrackComments records the comment counts from a search. A PR seen for the first time
+// starts with no new comments. After a complete search, PRs it didn't list are dropped.
+func (m *PRStateManager) TrackComments(incoming, outgoing []PR, complete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	listed := make(map[string]bool, len(incoming)+len(outgoing))
+	for _, list := range [][]PR{incoming, outgoing} {
+		for i := range list {
+			key := responseKey(list[i].URL)
+			if key == "" {
+				continue
+			}
+			listed[key] = true
+			c, ok := m.comments[key]
+			if !ok {
+				m.comments[key] = commentCount{baseline: list[i].Comments, latest: list[i].Comments}
+				continue
+			}
+			c.latest = list[i].Comments
+			// Deleted comments lower the baseline, so they don't hide the next burst
+			c.baseline = min(c.baseline, c.latest)
+			m.comments[key] = c
+		}
+	}
+	if !complete {
+		return
+	}
+	for key := range m.comments {
+		if !listed[key] {
+			delete(m.comments, key)
+		}
+	}
+}
+
+// NewComments returns how many comments a PR gained since its baseline.
+func (m *PRStateManager) NewComments(url string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := m.comments[responseKey(url)]
+	return c.latest - c.baseline
+}
+
+// SettleComments moves a PR's baseline to its latest comment count, after I opened it
+// or was notified about it.
+func (m *PRStateManager) SettleComments(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := responseKey(url)
+	if c, ok := m.comments[key]; ok && c.baseline != c.latest {
+		c.baseline = c.latest
+		m.comments[key] = c
+	}
+}
+
+// settleComments resets a PR's new comment count, if there's a state manager.
+func (app *App) settleComments(url string) {
+	if app.stateManager == nil || url == "" {
+		return
+	}
+	app.stateManager.SettleComments(url)
+}
+
+// commentBurstThreshold returns how many new comments make a burst.
+func (app *App) commentBurstThreshold() int {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.commentBurstMin > 0 {
+		return app.commentBurstMin
+	}
+	return defaultCommentBurstThreshold
+}
+
+// commentBurst returns how many new comments pr gained, if they make a burst on a PR I'm
+// involved in but not blocked on, or 0. In a section's context, Outgoing PRs are mine;
+// an incoming PR counts once I've reviewed it.
+func (app *App) commentBurst(pr *PR, sectionTitle string) int {
+	if app.stateManager == nil || pr.NeedsReview || pr.IsBlocked {
+		return 0
+	}
+	if sectionTitle != "Outgoing" && pr.MyReviewState == "" {
+		return 0
+	}
+	if n := app.stateManager.NewComments(pr.URL); n >= app.commentBurstThreshold() {
+		return n
+	}
+	return 0
+}
+
+// commentBurstSuffix is the label suffix for a burst of n new comments, or "".
+func commentBurstSuffix(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return " " + msg("comments.burst", n)
+}
+
+// notifyCommentBursts sends a quiet notification, with no sound or auto-open, for each
+// shown PR with a burst of new comments, when that's enabled. Being notified settles the
+// burst.
+func (app *App) notifyCommentBursts(view *prView) {
+	if !app.readSetting(&app.commentBurstNotify) || app.teamMode() {
+		return
+	}
+	for _, section := range []struct {
+		title string
+		prs   []PR
+	}{{"Incoming", view.incoming}, {"Outgoing", view.outgoing}} {
+		for i := range section.prs {
+			pr := section.prs[i]
+			n := app.commentBurst(&pr, section.title)
+			if n == 0 || app.prPolicy(pr.Repository) != orgPolicyFull {
+				continue
+			}
+			slog.Info("[NOTIFY] Discussion heating up", "repo", pr.Repository, "number", pr.Number, "new_comments", n)
+			app.settleComments(pr.URL)
+			e := notificationEvent{
+				kind:    notifyKindComments,
+				prURL:   pr.URL,
+				title:   msg("notify.comment_burst"),
+				message: msg("notify.comment_burst.body", n, strings.TrimSpace(prRef(pr)+" "+pr.Title)),
+			}
+			app.goTracked("notification", func() {
+				if err := app.notifyEvent(e); err != nil {
+					slog.Error("[NOTIFY] Failed to send comment burst notification", "url", e.prURL, "error", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// discussedPR is an incoming PR I've reviewed, with comments comments.
+func discussedPR(n, comments int) PR {
+	pr := pinnablePR(n, false)
+	pr.MyReviewState = reviewApproved
+	pr.Comments = comments
+	return pr
+}
+
+func TestCommentDeltaAccumulates(t *testing.T) {
+	m := NewPRStateManager(time.Now())
+	pr := discussedPR(1, 10)
+
+	m.TrackComments([]PR{pr}, nil, true)
+	if n := m.NewComments(pr.URL); n != 0 {
+		t.Fatalf("new comments on first sight = %d, want 0", n)
+	}
+	for _, comments := range []int{12, 14, 17} {
+		pr.Comments = comments
+		m.TrackComments([]PR{pr}, nil, true)
+	}
+	if n := m.NewComments(pr.URL); n != 7 {
+		t.Errorf("new comments after three cycles = %d, want 7", n)
+	}
+
+	// Deleted comments lower the baseline
+	m.SettleComments(pr.URL)
+	pr.Comments = 15
+	m.TrackComments([]PR{pr}, nil, true)
+	pr.Comments = 16
+	m.TrackComments([]PR{pr}, nil, true)
+	if n := m.NewComments(pr.URL); n != 1 {
+		t.Errorf("new comments after deletions = %d, want 1", n)
+	}
+
+	// A partial search keeps PRs it missed; a complete one drops them
+	m.TrackComments(nil, nil, false)
+	if n := m.NewComments(pr.URL); n != 1 {
+		t.Errorf("new comments after a partial search = %d, want 1", n)
+	}
+	m.TrackComments(nil, nil, true)
+	if _, ok := m.comments[responseKey(pr.URL)]; ok {
+		t.Error("a PR missing from a complete search is still tracked")
+	}
+}
+
+func TestCommentDeltaResetsOnOpen(t *testing.T) {
+	for _, link := range []string{"", "/checks"} {
+		app := newFocusTestApp(time.Hour)
+		app.browser = &countingBrowser{}
+		pr := discussedPR(1, 2)
+		app.stateManager.TrackComments([]PR{pr}, nil, true)
+		pr.Comments = 9
+		app.stateManager.TrackComments([]PR{pr}, nil, true)
+
+		if err := app.openBrowser(context.Background(), pr.URL+link, ""); err != nil {
+			t.Fatal(err)
+		}
+		if n := app.stateManager.NewComments(pr.URL); n != 0 {
+			t.Errorf("new comments after opening %s = %d, want 0", pr.URL+link, n)
+		}
+	}
+}
+
+func TestCommentBurstThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		pr      PR
+		section string
+		min     int
+		added   int
+		want    int
+	}{
+		{name: "below threshold", pr: discussedPR(1, 0), section: "Incoming", added: 4, want: 0},
+		{name: "at threshold", pr: discussedPR(1, 0), section: "Incoming", added: 5, want: 5},
+		{name: "custom threshold", pr: discussedPR(1, 0), section: "Incoming", min: 10, added: 7, want: 0},
+		{name: "not reviewed", pr: pinnablePR(1, false), section: "Incoming", added: 9, want: 0},
+		{name: "blocked on me", pr: func() PR { pr := discussedPR(1, 0); pr.NeedsReview = true; return pr }(), section: "Incoming", added: 9, want: 0},
+		{name: "my own PR", pr: pinnablePR(1, false), section: "Outgoing", added: 7, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newFocusTestApp(time.Hour)
+			app.commentBurstMin = tt.min
+			pr := tt.pr
+			app.stateManager.TrackComments([]PR{pr}, nil, true)
+			pr.Comments += tt.added
+			app.stateManager.TrackComments([]PR{pr}, nil, true)
+			if got := app.commentBurst(&pr, tt.section); got != tt.want {
+				t.Errorf("commentBurst() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentBurstLabel(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	pr := discussedPR(1, 3)
+	app.stateManager.TrackComments([]PR{pr}, nil, true)
+	pr.Comments = 10
+	app.stateManager.TrackComments([]PR{pr}, nil, true)
+
+	if title := app.prRowTitle(&pr, "Incoming", DisplayRepoNumber, 0, Highlight1m); !strings.HasSuffix(title, " 💬 +7") {
+		t.Errorf("label = %q, want a 💬 +7 suffix", title)
+	}
+}
+
+func TestCommentBurstNotification(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		app, notifier := newGraceTestApp(2 * time.Minute)
+		app.commentBurstNotify = enabled
+		pr := discussedPR(1, 0)
+		app.incoming = []PR{pr}
+		app.stateManager.TrackComments(app.incoming, nil, true)
+		pr.Comments = 6
+		app.incoming = []PR{pr}
+		app.stateManager.TrackComments(app.incoming, nil, true)
+
+		app.notifyCommentBursts(app.snapshotPRs())
+		app.notifyCommentBursts(app.snapshotPRs())
+		want := 0
+		if enabled {
+			want = 1
+		}
+		if notes := waitForNotes(notifier, want); len(notes) != want {
+			t.Errorf("enabled %v: notifications = %q, want %d", enabled, notes, want)
+		}
+		if settled := app.stateManager.NewComments(pr.URL) == 0; settled != enabled {
+			t.Errorf("enabled %v: burst settled = %v", enabled, settled)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/goose/pkg/safebrowse"
+)
+
+const (
+	// defaultDashboardURL is the public dashboard opened by the "Web Dashboard" menu item.
+	defaultDashboardURL = "https://my.reviewGOOSE.dev/"
+	// defaultDashboardPRTemplate builds a dashboard PR detail URL; placeholders are path-escaped.
+	defaultDashboardPRTemplate = "{base}/pr/{org}/{repo}/{number}"
+)
+
+// dashboardConfig describes where the dashboard lives and how to link to a PR in it.
+type dashboardConfig struct {
+	baseURL    string // Opened by the "Web Dashboard" item
+	prTemplate string
+	custom     bool // Set via DASHBOARD_URL or settings; enables per-PR dashboard links
+}
+
+// newDashboardConfig validates a dashboard URL and PR template, allowlisting the dashboard's host.
+// An empty rawURL selects the public dashboard; an empty template selects defaultDashboardPRTemplate.
+func newDashboardConfig(rawURL, prTemplate string) (*dashboardConfig, error) {
+	d := &dashboardConfig{baseURL: defaultDashboardURL, prTemplate: defaultDashboardPRTemplate}
+	if rawURL != "" {
+		if err := safebrowse.ValidateURL(rawURL); err != nil {
+			return nil, fmt.Errorf("invalid dashboard URL: %w", err)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse dashboard URL: %w", err)
+		}
+		if err := safebrowse.AllowHost(u.Host); err != nil {
+			return nil, fmt.Errorf("allow dashboard host: %w", err)
+		}
+		d.baseURL = rawURL
+		d.custom = true
+	}
+	if prTemplate != "" {
+		d.prTemplate = prTemplate
+	}
+
+	// Expand against a sample PR so a bad template fails at startup rather than on click
+	if _, err := d.prURL("org/repo", 1); err != nil {
+		return nil, fmt.Errorf("invalid dashboard PR template: %w", err)
+	}
+	return d, nil
+}
+
+// prURL expands the PR template for a repository ("org/repo") and PR number.
+// The result must point at an allowlisted host.
+func (d *dashboardConfig) prURL(repository string, number int) (string, error) {
+	org, repo, ok := strings.Cut(repository, "/")
+	if !ok || org == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", fmt.Errorf("invalid repository %q", repository)
+	}
+	if number <= 0 {
+		return "", errors.New("invalid PR number")
+	}
+
+	// Single-pass replacement, so placeholders smuggled into org or repo are not expanded again
+	expanded := strings.NewReplacer(
+		"{base}", strings.TrimSuffix(d.baseURL, "/"),
+		"{org}", url.PathEscape(org),
+		"{repo}", url.PathEscape(repo),
+		"{number}", strconv.Itoa(number),
+	).Replace(d.prTemplate)
+
+	if err := safebrowse.ValidateAllowedHost(expanded); err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+// configureDashboard resolves the dashboard from DASHBOARD_URL / DASHBOARD_PR_TEMPLATE,
+// falling back to settings and then the public dashboard.
+func (app *App) configureDashboard() {
+	rawURL := os.Getenv("DASHBOARD_URL")
+	if rawURL == "" {
+		rawURL = app.dashboardURLSetting
+	}
+	prTemplate := os.Getenv("DASHBOARD_PR_TEMPLATE")
+	if prTemplate == "" {
+		prTemplate = app.dashboardPRTemplateSetting
+	}
+
+	d, err := newDashboardConfig(rawURL, prTemplate)
+	if err != nil {
+		slog.Error("Ignoring dashboard configuration", "url", rawURL, "pr_template", prTemplate, "error", err)
+		d = &dashboardConfig{baseURL: defaultDashboardURL, prTemplate: defaultDashboardPRTemplate}
+	}
+	if d.custom {
+		slog.Info("Using custom dashboard", "url", d.baseURL, "pr_template", d.prTemplate)
+	}
+	app.dashboard = d
+}
+
+// dashboardURL returns the URL opened by the "Web Dashboard" menu item.
+func (app *App) dashboardURL() string {
+	if app.dashboard == nil {
+		return defaultDashboardURL
+	}
+	return app.dashboard.baseURL
+}
+
+// addDashboardLinks adds "Open on GitHub" and "Open in dashboard" under a PR's menu item.
+// Only used for a configured dashboard; otherwise addPRActions adds just "Open on GitHub".
+func (app *App) addDashboardLinks(ctx context.Context, item MenuItem, pr *PR, githubURL string) {
+	dashURL, err := app.dashboard.prURL(pr.Repository, pr.Number)
+	if err != nil {
+		slog.Warn("[MENU] Cannot build dashboard link", "repo", pr.Repository, "number", pr.Number, "error", err)
+		return
+	}
+	item.AddSubMenuItem(msg("dashboard.open_github"), "").Click(func() {
+		if err := app.openBrowser(ctx, githubURL, ""); err != nil {
+			slog.Error("failed to open url", "error", err)
+		}
+	})
+	item.AddSubMenuItem(msg("dashboard.open_pr"), dashURL).Click(func() {
+		if err := app.openBrowser(ctx, dashURL, ""); err != nil {
+			slog.Error("failed to open dashboard", "error", err)
+			return
+		}
+		app.recordPROpened(ctx, pr.URL)
+	})
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDashboardDefaults(t *testing.T) {
+	d, err := newDashboardConfig("", "")
+	if err != nil {
+		t.Fatalf("newDashboardConfig() error = %v", err)
+	}
+	if d.custom || d.baseURL != defaultDashboardURL {
+		t.Errorf("default dashboard = %+v", d)
+	}
+	if got := (&App{}).dashboardURL(); got != defaultDashboardURL {
+		t.Errorf("dashboardURL() without config = %q", got)
+	}
+}
+
+func TestDashboardPRURL(t *testing.T) {
+	d, err := newDashboardConfig("https://dash.corp.example/", "")
+	if err != nil {
+		t.Fatalf("newDashboardConfig() error = %v", err)
+	}
+	if !d.custom {
+		t.Error("configured dashboard not marked custom")
+	}
+
+	got, err := d.prURL("my-org/my.repo", 42)
+	if err != nil {
+		t.Fatalf("prURL() error = %v", err)
+	}
+	if want := "https://dash.corp.example/pr/my-org/my.repo/42"; got != want {
+		t.Errorf("prURL() = %q, want %q", got, want)
+	}
+
+	// Values needing escaping produce percent-encoding, which safebrowse refuses to open
+	for _, repo := range []string{"org/re po", "org/{number}", "org/..", "org/a?b", "org/a#b", "noslash", "org/a/b", "/repo"} {
+		if got, err := d.prURL(repo, 1); err == nil {
+			t.Errorf("prURL(%q) = %q, want error", repo, got)
+		}
+	}
+	if _, err := d.prURL("org/repo", 0); err == nil {
+		t.Error("prURL() accepted PR number 0")
+	}
+}
+
+func TestDashboardTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{name: "custom path", base: "https://review.tmpl.example", template: "{base}/{org}/{repo}/pulls/{number}",
+			want: "https://review.tmpl.example/acme/widgets/pulls/7"},
+		{name: "github is allowlisted", base: "https://review2.tmpl.example", template: "https://github.com/{org}/{repo}/pull/{number}",
+			want: "https://github.com/acme/widgets/pull/7"},
+		{name: "non-allowlisted host", base: "https://review3.tmpl.example", template: "https://evil.example/{org}/{repo}/{number}", wantErr: true},
+		{name: "host from placeholder position", base: "https://review4.tmpl.example", template: "https://{org}.example/{repo}/{number}", wantErr: true},
+		{name: "http scheme", base: "https://review5.tmpl.example", template: "http://review5.tmpl.example/{number}", wantErr: true},
+		{name: "query injection", base: "https://review6.tmpl.example", template: "{base}/pr?id={number}", wantErr: true},
+		{name: "bad base URL", base: "http://insecure.tmpl.example", wantErr: true},
+		{name: "base with port", base: "https://ported.tmpl.example:8443", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := newDashboardConfig(tt.base, tt.template)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("newDashboardConfig(%q, %q) = %+v, want error", tt.base, tt.template, d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newDashboardConfig() error = %v", err)
+			}
+			if tt.want == "" {
+				return
+			}
+			got, err := d.prURL("acme/widgets", 7)
+			if err != nil {
+				t.Fatalf("prURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("prURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigureDashboardPrecedence(t *testing.T) {
+	app := &App{dashboardURLSetting: "https://settings.dash.example"}
+	t.Setenv("DASHBOARD_URL", "")
+	t.Setenv("DASHBOARD_PR_TEMPLATE", "")
+	app.configureDashboard()
+	if got := app.dashboardURL(); got != "https://settings.dash.example" {
+		t.Errorf("dashboardURL() from settings = %q", got)
+	}
+
+	t.Setenv("DASHBOARD_URL", "https://env.dash.example")
+	app.configureDashboard()
+	if got := app.dashboardURL(); got != "https://env.dash.example" {
+		t.Errorf("DASHBOARD_URL should override settings, got %q", got)
+	}
+
+	// Invalid configuration falls back to the public dashboard
+	t.Setenv("DASHBOARD_PR_TEMPLATE", "https://elsewhere.example/{number}")
+	app.configureDashboard()
+	if got := app.dashboardURL(); got != defaultDashboardURL || app.dashboard.custom {
+		t.Errorf("invalid template should fall back to default, got %q", got)
+	}
+}
+
+func TestDashboardMenuLinks(t *testing.T) {
+	app := newFocusTestApp(0)
+	pr := &PR{Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7"}
+
+	item := &MockMenuItem{}
+	app.dashboard, _ = newDashboardConfig("https://menu.dash.example", "")
+	app.addDashboardLinks(context.Background(), item, pr, pr.URL)
+	if len(item.subItems) != 2 {
+		t.Fatalf("got %d sub items, want 2", len(item.subItems))
+	}
+	dash, ok := item.subItems[1].(*MockMenuItem)
+	if !ok || dash.title != "Open in dashboard" || dash.tooltip != "https://menu.dash.example/pr/acme/widgets/7" {
+		t.Errorf("unexpected dashboard sub item: %+v", item.subItems[1])
+	}
+}
@@ -14,7 +14,7 @@ func TestConcurrentMenuOperations(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{},
@@ -101,7 +101,7 @@ func TestMenuClickDeadlockScenario(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{},
@@ -142,7 +142,7 @@ func TestRapidMenuClicks(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{},
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/safebrowse"
+)
+
+const (
+	// githubStatusURL is offered when goose itself keeps failing; its host is allowlisted in safebrowse.
+	githubStatusURL = "https://www.githubstatus.com/"
+	// connectivityTestTimeout bounds the manual "Test GitHub connectivity" check.
+	connectivityTestTimeout = 10 * time.Second
+	// maxRecentErrors is how many fetch errors the diagnostic report includes.
+	maxRecentErrors = 10
+)
+
+// recordedError is a fetch failure kept for the diagnostic report.
+type recordedError struct {
+	at  time.Time
+	msg string
+}
+
+// appendRecentError adds err to the history, keeping only the newest maxRecentErrors.
+func appendRecentError(history []recordedError, at time.Time, err error) []recordedError {
+	history = append(history, recordedError{at: at, msg: err.Error()})
+	if len(history) > maxRecentErrors {
+		history = history[len(history)-maxRecentErrors:]
+	}
+	return history
+}
+
+// addEscalationItems offers next steps once goose has been failing persistently.
+// Handlers run in their own goroutines and don't take updateMutex, so they work while updates fail.
+func (app *App) addEscalationItems(ctx context.Context) {
+	reportItem := app.systrayInterface.AddMenuItem(msg("diag.report"), msg("diag.report.tooltip"))
+	reportItem.Click(func() {
+		app.goTracked("diagnostic report", app.handleDiagnosticReport)
+	})
+
+	testItem := app.systrayInterface.AddMenuItem(msg("diag.connectivity"), msg("diag.connectivity.tooltip"))
+	testItem.Click(func() {
+		app.goTracked("connectivity test", func() { app.reportGitHubConnectivity(ctx) })
+	})
+
+	statusItem := app.systrayInterface.AddMenuItem(msg("diag.status_page"), githubStatusURL)
+	statusItem.Click(func() {
+		if err := safebrowse.ValidateAllowedHost(githubStatusURL); err != nil {
+			slog.Error("refusing to open status page", "error", err)
+			return
+		}
+		if err := app.openBrowser(ctx, githubStatusURL, ""); err != nil {
+			slog.Error("failed to open status page", "error", err)
+		}
+	})
+}
+
+// diagnosticReport summarizes goose's health for bug reports. It records whether
+// credentials are present, never their values.
+func (app *App) diagnosticReport(now time.Time) string {
+	app.mu.RLock()
+	failures := app.consecutiveFailures
+	lastSuccess := app.lastSuccessfulFetch
+	authError := app.authError
+	lastFetchError := app.lastFetchError
+	loginOverride := app.loginOverride
+	hasClient := app.client != nil
+	hasTurn := app.turnClient != nil
+	errs := make([]recordedError, len(app.recentErrors))
+	copy(errs, app.recentErrors)
+	slowTurn := slices.Clone(app.slowTurnCalls)
+	app.mu.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "reviewGOOSE diagnostic report (%s)\n\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "version: %s\ncommit: %s\nbuilt: %s\ngo: %s\nplatform: %s/%s\n",
+		appVersion(), commit, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if app.profileName != "" {
+		fmt.Fprintf(&b, "profile: %s\n", app.profileName)
+	}
+
+	lastSuccessText := "never"
+	if !lastSuccess.IsZero() {
+		lastSuccessText = lastSuccess.Format(time.RFC3339)
+	}
+	fmt.Fprintf(&b, "\nconsecutive failures: %d\nlast success: %s\n", failures, lastSuccessText)
+	if authError != "" {
+		fmt.Fprintf(&b, "auth error: %s\n", authError)
+	}
+	if lastFetchError != "" {
+		fmt.Fprintf(&b, "last fetch error: %s\n", lastFetchError)
+	}
+
+	fmt.Fprintf(&b, "\nGITHUB_TOKEN set: %t\nGitHub client: %t\nTurn client: %t\n",
+		os.Getenv("GITHUB_TOKEN") != "", hasClient, hasTurn)
+	if loginOverride != "" {
+		fmt.Fprintf(&b, "login override: %s (/user refused)\n", loginOverride)
+	}
+	for _, cb := range app.circuits() {
+		st := cb.status()
+		fmt.Fprintf(&b, "circuit %s: %s (failures %d/%d)\n", st.name, st.state, st.failures, st.threshold)
+	}
+	if app.sprinklerMonitor != nil {
+		fmt.Fprintf(&b, "sprinkler connected: %t\n", app.sprinklerActivity().connected)
+	}
+	if app.intervals != nil {
+		fmt.Fprintf(&b, "update interval: %s (base %s, adaptive %t)\n",
+			app.intervals.interval(), app.updateInterval, app.readSetting(&app.adaptiveInterval))
+	}
+
+	fmt.Fprintf(&b, "\nslowest Turn calls last cycle (%d):\n", len(slowTurn))
+	for _, t := range slowTurn {
+		fmt.Fprintf(&b, "  %s\n", t)
+	}
+
+	writeTrayChanges(&b, app.trayHistory.snapshot())
+	writeChangeSets(&b, app.journal.snapshot())
+
+	fmt.Fprintf(&b, "\nrecent errors (%d):\n", len(errs))
+	for i := len(errs) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "  %s  %s\n", errs[i].at.Format(time.RFC3339), errs[i].msg)
+	}
+	return b.String()
+}
+
+// writeDiagnosticReport saves the report next to the cache and returns its path.
+func (app *App) writeDiagnosticReport(now time.Time) (string, error) {
+	dir := app.cacheDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	path := filepath.Join(dir, "diagnostics-"+now.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(app.diagnosticReport(now)), 0o600); err != nil {
+		return "", fmt.Errorf("write diagnostic report: %w", err)
+	}
+	return path, nil
+}
+
+// handleDiagnosticReport writes the report and tells the user where it is.
+func (app *App) handleDiagnosticReport() {
+	path, err := app.writeDiagnosticReport(time.Now())
+	if err != nil {
+		slog.Error("[DIAGNOSTICS] Failed to write report", "error", err)
+		if nerr := app.notify(msg("diag.report.failed"), err.Error()); nerr != nil {
+			slog.Error("Failed to send notification", "error", nerr)
+		}
+		return
+	}
+	slog.Info("[DIAGNOSTICS] Wrote report", "path", path)
+	if err := app.notify(msg("diag.report.saved"), path); err != nil {
+		slog.Error("Failed to send notification", "error", err)
+	}
+}
+
+// testGitHubConnectivity makes one authenticated API call, bypassing the circuit breaker
+// so the result reflects GitHub right now. It returns the authenticated login.
+func (app *App) testGitHubConnectivity(ctx context.Context) (string, error) {
+	if app.client == nil {
+		return "", errors.New("no GitHub client available")
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectivityTestTimeout)
+	defer cancel()
+
+	user, _, err := app.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("get authenticated user: %w", err)
+	}
+	return user.GetLogin(), nil
+}
+
+// reportGitHubConnectivity runs the connectivity test and notifies with the outcome.
+func (app *App) reportGitHubConnectivity(ctx context.Context) {
+	start := time.Now()
+	login, err := app.testGitHubConnectivity(ctx)
+	title, message := msg("diag.connectivity.ok"), msg("diag.connectivity.ok.message", login, time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		title, message = msg("diag.connectivity.failed"), err.Error()
+		slog.Warn("[DIAGNOSTICS] Connectivity test failed", "error", err)
+	} else {
+		slog.Info("[DIAGNOSTICS] Connectivity test succeeded", "login", login)
+	}
+	if err := app.notify(title, message); err != nil {
+		slog.Error("Failed to send notification", "error", err)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// messageNotifier records notification titles and messages.
+type messageNotifier struct {
+	notes []string
+	mu    sync.Mutex
+}
+
+func (n *messageNotifier) Notify(title, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notes = append(n.notes, title+": "+message)
+	return nil
+}
+
+// newGitHubTestClient returns a client whose API calls go to handler.
+func newGitHubTestClient(t *testing.T, handler http.HandlerFunc) *github.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestAppendRecentError(t *testing.T) {
+	var history []recordedError
+	start := time.Now()
+	for i := range maxRecentErrors + 3 {
+		history = appendRecentError(history, start.Add(time.Duration(i)*time.Second), fmt.Errorf("failure %d", i))
+	}
+	if len(history) != maxRecentErrors {
+		t.Fatalf("kept %d errors, want %d", len(history), maxRecentErrors)
+	}
+	if history[0].msg != "failure 3" || history[len(history)-1].msg != fmt.Sprintf("failure %d", maxRecentErrors+2) {
+		t.Errorf("history should keep the newest errors: first=%q last=%q", history[0].msg, history[len(history)-1].msg)
+	}
+}
+
+func TestDiagnosticReport(t *testing.T) {
+	const secret = "ghp_doNotLeakThisToken"
+	t.Setenv("GITHUB_TOKEN", secret)
+
+	app := &App{
+		mu:                  sync.RWMutex{},
+		cacheDir:            t.TempDir(),
+		consecutiveFailures: 12,
+		githubCircuit:       newCircuitBreaker("github", 5, 2*time.Minute),
+		recentErrors: []recordedError{
+			{at: time.Now().Add(-time.Minute), msg: "older failure"},
+			{at: time.Now(), msg: "search failed: 502 Bad Gateway"},
+		},
+	}
+	report := app.diagnosticReport(time.Now())
+	if strings.Contains(report, secret) {
+		t.Fatal("diagnostic report contains the token")
+	}
+	for _, want := range []string{"GITHUB_TOKEN set: true", "GitHub client: false", "consecutive failures: 12",
+		"circuit github: closed", "search failed: 502 Bad Gateway", "version: "} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Index(report, "502 Bad Gateway") > strings.Index(report, "older failure") {
+		t.Error("recent errors should be listed newest first")
+	}
+
+	path, err := app.writeDiagnosticReport(time.Now())
+	if err != nil {
+		t.Fatalf("writeDiagnosticReport() error = %v", err)
+	}
+	if filepath.Dir(path) != app.cacheDir {
+		t.Errorf("report written to %s, want cache dir %s", path, app.cacheDir)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 && os.PathSeparator == '/' {
+		t.Errorf("report permissions = %v, want 0600", perm)
+	}
+}
+
+func TestGitHubConnectivityFailure(t *testing.T) {
+	client := newGitHubTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message":"Service Unavailable"}`, http.StatusServiceUnavailable)
+	})
+	notifier := &messageNotifier{}
+	app := &App{mu: sync.RWMutex{}, client: client, notifier: notifier}
+
+	if _, err := app.testGitHubConnectivity(context.Background()); err == nil {
+		t.Fatal("testGitHubConnectivity() = nil error for a failing GitHub")
+	}
+	app.reportGitHubConnectivity(context.Background())
+	if len(notifier.notes) != 1 || !strings.HasPrefix(notifier.notes[0], "GitHub connectivity failed: ") ||
+		!strings.Contains(notifier.notes[0], "503") {
+		t.Errorf("notifications = %q, want one failure naming the 503", notifier.notes)
+	}
+
+	// A missing client is reported rather than panicking
+	if _, err := (&App{}).testGitHubConnectivity(context.Background()); err == nil {
+		t.Error("testGitHubConnectivity() without a client = nil error")
+	}
+}
+
+func TestGitHubConnectivitySuccess(t *testing.T) {
+	client := newGitHubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"login":"octocat"}`)
+	})
+	notifier := &messageNotifier{}
+	app := &App{mu: sync.RWMutex{}, client: client, notifier: notifier}
+
+	app.reportGitHubConnectivity(context.Background())
+	if len(notifier.notes) != 1 || !strings.HasPrefix(notifier.notes[0], "GitHub connectivity OK: Authenticated as @octocat") {
+		t.Errorf("notifications = %q", notifier.notes)
+	}
+}
+
+func TestConnectivityTestTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	client := newGitHubTestClient(t, func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer close(release)
+	app := &App{mu: sync.RWMutex{}, client: client}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := app.testGitHubConnectivity(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("testGitHubConnectivity() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestEscalationMenuItems(t *testing.T) {
+	mock := &MockSystray{}
+	app := &App{
+		mu:                  sync.RWMutex{},
+		stateManager:        NewPRStateManager(time.Now()),
+		systrayInterface:    mock,
+		hiddenOrgs:          make(map[string]bool),
+		seenOrgs:            make(map[string]orgActivity),
+		consecutiveFailures: majorFailureThreshold - 1,
+		lastFetchError:      "search failed: connection refused",
+	}
+	escalation := []string{"Copy diagnostic report", "Test GitHub connectivity", "Open GitHub status page"}
+
+	app.rebuildMenu(context.Background())
+	for _, title := range escalation {
+		if slices.Contains(mock.menuItems, title) {
+			t.Errorf("%q shown before failures became persistent", title)
+		}
+	}
+
+	app.consecutiveFailures = majorFailureThreshold
+	app.rebuildMenu(context.Background())
+	for _, title := range escalation {
+		if !slices.Contains(mock.menuItems, title) {
+			t.Errorf("%q missing after %d failures: %v", title, majorFailureThreshold, mock.menuItems)
+		}
+	}
+}
+
+func TestFetchErrorDetails(t *testing.T) {
+	const fetchErr = `search failed: GET https://api.github.com/search/issues?q=is%3Aopen+involves%3Atestuser: 422 Validation Failed [{Resource:Search Field:q Code:invalid Message:The listed users cannot be searched}] (request 0C1A:2B3C:4D5E)`
+	mock := &MockSystray{}
+	clipboard := &recordingClipboard{}
+	app := &App{
+		mu:                  sync.RWMutex{},
+		stateManager:        NewPRStateManager(time.Now()),
+		systrayInterface:    mock,
+		clipboard:           clipboard,
+		hiddenOrgs:          make(map[string]bool),
+		seenOrgs:            make(map[string]orgActivity),
+		consecutiveFailures: 1,
+		lastFetchError:      fetchErr,
+	}
+	app.rebuildMenu(context.Background())
+
+	var details []string
+	var copyItem *MockMenuItem
+	for _, item := range mock.items {
+		switch {
+		case item.title == msg("error.copy"):
+			copyItem = item
+		case item.tooltip == fetchErr:
+			details = append(details, item.title)
+			if !item.disabled {
+				t.Errorf("detail line %q is clickable", item.title)
+			}
+		default:
+		}
+	}
+	if want := wrapRunes(msg("error.details", fetchErr), errorDetailWidth, errorDetailLines); !slices.Equal(details, want) {
+		t.Errorf("detail lines = %q, want %q", details, want)
+	}
+	if len(details) != errorDetailLines || !strings.HasSuffix(details[len(details)-1], "…") {
+		t.Errorf("detail lines = %q, want %d lines ending in an ellipsis", details, errorDetailLines)
+	}
+	if copyItem == nil {
+		t.Fatal("no copy item")
+	}
+	copyItem.clickHandler()
+	if !slices.Equal(clipboard.copied, []string{fetchErr}) {
+		t.Errorf("copied %q, want the full error", clipboard.copied)
+	}
+	if report := app.diagnosticReport(time.Now()); !strings.Contains(report, "last fetch error: "+fetchErr) {
+		t.Errorf("report doesn't include the full error:\n%s", report)
+	}
+}
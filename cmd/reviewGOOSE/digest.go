@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// The daily digest is one notification at a set time of day listing everything blocked
+// on me, like GitHub's scheduled reminders but local. It's built from what the menu
+// shows, so filters, dismissals, and snoozes apply. If the machine sleeps through the
+// digest time, the digest goes out on wake as long as it's still the same day.
+
+// defaultDigestClock is when the digest goes out unless settings say otherwise.
+const defaultDigestClock = "08:45"
+
+// digestCheckInterval is how often the scheduler reads the wall clock. Timers don't
+// reliably count time asleep, so the scheduler polls rather than sleeping until the
+// digest time.
+const digestCheckInterval = time.Minute
+
+// digestMaxListed caps the PRs listed in the digest body.
+const digestMaxListed = 5
+
+// digestDayLayout formats the day a digest went out.
+const digestDayLayout = "2006-01-02"
+
+// digestScheduler sends the digest once a day.
+type digestScheduler struct {
+	app   *App
+	now   func() time.Time
+	done  string // Day the digest last went out or was skipped, as digestDayLayout
+	armed bool   // The digest was enabled at the last check
+}
+
+func newDigestScheduler(app *App) *digestScheduler {
+	return &digestScheduler{app: app, now: time.Now}
+}
+
+// digestAt is when the digest goes out on now's day, in now's location.
+func digestAt(now time.Time, clock string) time.Time {
+	t, err := time.Parse(snoozeClockLayout, clock)
+	if err != nil {
+		t, _ = time.Parse(snoozeClockLayout, defaultDigestClock)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+}
+
+// weekend reports whether t falls on a Saturday or Sunday.
+func weekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// run checks for a due digest until ctx is cancelled.
+func (s *digestScheduler) run(ctx context.Context) {
+	s.check()
+	ticker := time.NewTicker(digestCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check()
+		}
+	}
+}
+
+// check sends the digest if it's due, reporting whether it did. Enabling the digest,
+// including by starting up with it enabled, after the day's digest time skips that
+// day, so it never goes out at a surprising time.
+func (s *digestScheduler) check() bool {
+	app := s.app
+	app.mu.RLock()
+	enabled, clock, weekdaysOnly := app.digestEnabled, app.digestClock, app.digestWeekdaysOnly
+	fetched := app.lastSuccessfulFetch
+	app.mu.RUnlock()
+
+	if !enabled {
+		s.armed = false
+		return false
+	}
+	now := s.now()
+	day := now.Format(digestDayLayout)
+	at := digestAt(now, clock)
+	if !s.armed {
+		s.armed = true
+		if !now.Before(at) {
+			s.done = day
+		}
+		return false
+	}
+	if s.done == day || now.Before(at) {
+		return false
+	}
+	if weekdaysOnly && weekend(now) {
+		slog.Debug("[DIGEST] Weekend, skipping the daily digest", "day", day)
+		s.done = day
+		return false
+	}
+	// After a wake the PR lists predate the digest time; wait for an update
+	if fetched.Before(at) {
+		return false
+	}
+	s.done = day
+	title, message, ok := app.digest(app.snapshotPRs())
+	if !ok {
+		slog.Info("[DIGEST] Nothing blocked on me, skipping the daily digest")
+		return false
+	}
+	slog.Info("[DIGEST] Sending the daily digest", "title", title)
+	if err := app.notify(title, message); err != nil {
+		slog.Error("[DIGEST] Failed to send the daily digest", "error", err)
+	}
+	return true
+}
+
+// digest builds the digest notification from the view. ok is false when nothing is
+// blocked on me. Team mode never notifies, and PRs from orgs that don't notify are left
+// out.
+func (app *App) digest(view *prView) (title, message string, ok bool) {
+	if app.teamMode() {
+		return "", "", false
+	}
+	var listed []PR
+	reviews, merges, others := 0, 0, 0
+	for _, section := range []struct {
+		prs   []PR
+		title string
+	}{{view.incoming, "Incoming"}, {view.outgoing, "Outgoing"}} {
+		for i := range section.prs {
+			pr := &section.prs[i]
+			if !blockedInSection(pr, section.title) || app.prPolicy(pr.Repository) != orgPolicyFull {
+				continue
+			}
+			switch {
+			case section.title == "Incoming":
+				reviews++
+			case pr.ActionKind == actionMerge:
+				merges++
+			default:
+				others++
+			}
+			listed = append(listed, *pr)
+		}
+	}
+	if len(listed) == 0 {
+		return "", "", false
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		one, many func(int) string
+		n         int
+	}{
+		{func(int) string { return msg("digest.reviews.one") }, func(n int) string { return msg("digest.reviews", n) }, reviews},
+		{func(int) string { return msg("digest.merge.one") }, func(n int) string { return msg("digest.merge", n) }, merges},
+		{func(int) string { return msg("digest.outgoing.one") }, func(n int) string { return msg("digest.outgoing", n) }, others},
+	} {
+		switch part.n {
+		case 0:
+		case 1:
+			parts = append(parts, part.one(part.n))
+		default:
+			parts = append(parts, part.many(part.n))
+		}
+	}
+
+	lines := make([]string, 0, digestMaxListed+1)
+	for i := range listed {
+		if i == digestMaxListed {
+			lines = append(lines, msg("digest.more", len(listed)-digestMaxListed))
+			break
+		}
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("%s #%d %s", listed[i].Repository, listed[i].Number, listed[i].Title)))
+	}
+	return msg("digest.title", strings.Join(parts, ", ")), strings.Join(lines, "\n"), true
+}
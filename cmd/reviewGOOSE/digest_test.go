@@ -0,0 +1,169 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// newDigestTestApp returns an app with the digest enabled at 08:45 and one incoming PR
+// waiting on my review, and a scheduler whose clock the test sets.
+func newDigestTestApp(weekdaysOnly bool) (*App, *digestScheduler, *messageNotifier, *time.Time) {
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.digestEnabled = true
+	app.digestClock = "08:45"
+	app.digestWeekdaysOnly = weekdaysOnly
+	app.incoming = []PR{{
+		Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1",
+		Title: "Fix the flux capacitor", NeedsReview: true, UpdatedAt: time.Now(),
+	}}
+	now := new(time.Time)
+	s := newDigestScheduler(app)
+	s.now = func() time.Time { return *now }
+	return app, s, notifier, now
+}
+
+// advanceDigestClock moves the test clock to t, with an update just finished.
+func advanceDigestClock(app *App, now *time.Time, t time.Time) {
+	*now = t
+	app.lastSuccessfulFetch = t
+}
+
+func TestDigestFiresOnceADay(t *testing.T) {
+	app, s, notifier, now := newDigestTestApp(false)
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+
+	advanceDigestClock(app, now, monday.Add(8*time.Hour))
+	if s.check() {
+		t.Fatal("the first check only arms the scheduler")
+	}
+	advanceDigestClock(app, now, monday.Add(8*time.Hour+44*time.Minute))
+	if s.check() {
+		t.Fatal("digest sent before 08:45")
+	}
+	advanceDigestClock(app, now, monday.Add(8*time.Hour+45*time.Minute))
+	if !s.check() {
+		t.Fatal("digest not sent at 08:45")
+	}
+	advanceDigestClock(app, now, monday.Add(17*time.Hour))
+	if s.check() {
+		t.Fatal("digest sent twice in one day")
+	}
+	advanceDigestClock(app, now, monday.AddDate(0, 0, 1).Add(8*time.Hour+46*time.Minute))
+	if !s.check() {
+		t.Fatal("digest not sent the next day")
+	}
+
+	if len(notifier.notes) != 2 {
+		t.Fatalf("notifications = %q, want two digests", notifier.notes)
+	}
+	if want := "Goose digest: 1 review waiting: acme/widgets #1 Fix the flux capacitor"; notifier.notes[0] != want {
+		t.Errorf("digest = %q, want %q", notifier.notes[0], want)
+	}
+}
+
+func TestDigestSkipsWeekends(t *testing.T) {
+	saturday := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.Local)
+
+	app, s, _, now := newDigestTestApp(true)
+	advanceDigestClock(app, now, saturday.Add(-time.Hour))
+	s.check()
+	advanceDigestClock(app, now, saturday)
+	if s.check() {
+		t.Error("weekdays-only digest sent on a Saturday")
+	}
+	advanceDigestClock(app, now, saturday.AddDate(0, 0, 2))
+	if !s.check() {
+		t.Error("weekdays-only digest not sent on Monday")
+	}
+
+	app, s, _, now = newDigestTestApp(false)
+	advanceDigestClock(app, now, saturday.Add(-time.Hour))
+	s.check()
+	advanceDigestClock(app, now, saturday)
+	if !s.check() {
+		t.Error("digest not sent on a Saturday without weekdays only")
+	}
+}
+
+func TestDigestCatchesUpOnWake(t *testing.T) {
+	app, s, notifier, now := newDigestTestApp(false)
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+	advanceDigestClock(app, now, monday.Add(8*time.Hour))
+	s.check()
+
+	// Asleep from 08:30 until 13:00: the first check after waking sees lists from before
+	// the digest time, so it waits for the next update
+	asleep := monday.Add(8*time.Hour + 30*time.Minute)
+	app.lastSuccessfulFetch = asleep
+	*now = monday.Add(13 * time.Hour)
+	if s.check() {
+		t.Fatal("digest sent from PR lists fetched before the digest time")
+	}
+	advanceDigestClock(app, now, monday.Add(13*time.Hour+time.Minute))
+	if !s.check() {
+		t.Fatal("digest not sent after waking the same day")
+	}
+	advanceDigestClock(app, now, monday.Add(13*time.Hour+2*time.Minute))
+	if s.check() || len(notifier.notes) != 1 {
+		t.Errorf("notifications = %q, want one digest after waking", notifier.notes)
+	}
+}
+
+func TestDigestNotSentWhenEnabledLate(t *testing.T) {
+	app, s, notifier, now := newDigestTestApp(false)
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+
+	// Starting, or enabling the digest, after 08:45 waits for tomorrow
+	advanceDigestClock(app, now, monday.Add(10*time.Hour))
+	s.check()
+	advanceDigestClock(app, now, monday.Add(10*time.Hour+time.Minute))
+	if s.check() {
+		t.Error("digest sent right after starting past the digest time")
+	}
+	app.digestEnabled = false
+	s.check()
+	app.digestEnabled = true
+	advanceDigestClock(app, now, monday.AddDate(0, 0, 1).Add(9*time.Hour))
+	s.check()
+	advanceDigestClock(app, now, monday.AddDate(0, 0, 1).Add(9*time.Hour+time.Minute))
+	if s.check() {
+		t.Error("digest sent right after enabling past the digest time")
+	}
+	if len(notifier.notes) != 0 {
+		t.Errorf("notifications = %q, want none", notifier.notes)
+	}
+}
+
+func TestDigestContents(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	app.incoming = []PR{
+		{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: now},
+		{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2", NeedsReview: true, UpdatedAt: now},
+		{Repository: "acme/widgets", Number: 3, URL: "https://github.com/acme/widgets/pull/3", NeedsReview: true, UpdatedAt: now},
+		{Repository: "acme/widgets", Number: 4, URL: "https://github.com/acme/widgets/pull/4", UpdatedAt: now},
+	}
+	app.outgoing = []PR{
+		{Repository: "acme/gears", Number: 5, URL: "https://github.com/acme/gears/pull/5", IsBlocked: true, ActionKind: "merge", UpdatedAt: now},
+	}
+	// Snoozed PRs are left out
+	app.snooze = &incomingSnooze{until: now.Add(time.Hour), urls: map[string]bool{app.incoming[2].URL: true}}
+
+	title, message, ok := app.digest(app.snapshotPRs())
+	if !ok {
+		t.Fatal("expected a digest")
+	}
+	if want := "Goose digest: 2 reviews waiting, 1 PR ready to merge"; title != want {
+		t.Errorf("title = %q, want %q", title, want)
+	}
+	if want := "acme/widgets #1\nacme/widgets #2\nacme/gears #5"; message != want {
+		t.Errorf("message = %q, want %q", message, want)
+	}
+
+	app.incoming, app.outgoing = app.incoming[3:], nil
+	if _, _, ok := app.digest(app.snapshotPRs()); ok {
+		t.Error("no digest expected with nothing blocked")
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Turn sometimes insists a PR waits on me when it doesn't (e.g. a CODEOWNERS path I no
+// longer own). "Not my review" records a local override: the PR stops counting as
+// blocked and stops notifying until its action kind changes or it closes.
+
+// dismissedFileName persists "Not my review" overrides in the cache directory.
+const dismissedFileName = "dismissed_prs.json"
+
+// dismissal is a PR I marked "Not my review", and the Turn action I dismissed.
+type dismissal struct {
+	Repository string     `json:"repository"`
+	ActionKind ActionKind `json:"action_kind"`
+	Number     int        `json:"number"`
+}
+
+// dismissedPR is a current dismissal with its PR URL, for the menu.
+type dismissedPR struct {
+	dismissal
+
+	URL string
+}
+
+// LoadDismissals restores the dismissals saved at path and saves later changes there.
+// A missing or unreadable file starts empty.
+func (m *PRStateManager) LoadDismissals(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dismissedPath = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("[STATE] Failed to read dismissed PRs", "path", path, "error", err)
+		}
+		return
+	}
+	var dismissed map[string]dismissal
+	if err := json.Unmarshal(data, &dismissed); err != nil {
+		slog.Warn("[STATE] Ignoring unreadable dismissed PRs", "path", path, "error", err)
+		return
+	}
+	maps.Copy(m.dismissed, dismissed)
+	if len(m.dismissed) > 0 {
+		slog.Info("[STATE] Restored dismissed PRs", "count", len(m.dismissed))
+	}
+}
+
+// Dismiss stops attributing pr's current action to me.
+func (m *PRStateManager) Dismiss(pr *PR) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dismissed[pr.URL] = dismissal{Repository: pr.Repository, Number: pr.Number, ActionKind: pr.ActionKind}
+	slog.Info("[STATE] PR dismissed as not my review", "repo", pr.Repository, "number", pr.Number, "action", pr.ActionKind)
+	m.saveDismissalsLocked()
+}
+
+// Undismiss removes the dismissal on a PR, if any.
+func (m *PRStateManager) Undismiss(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.dismissed[url]; !ok {
+		return
+	}
+	delete(m.dismissed, url)
+	slog.Info("[STATE] PR dismissal undone", "url", url)
+	m.saveDismissalsLocked()
+}
+
+// Dismissals returns the current dismissals, ordered by repository and number.
+func (m *PRStateManager) Dismissals() []dismissedPR {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]dismissedPR, 0, len(m.dismissed))
+	for url, d := range m.dismissed {
+		result = append(result, dismissedPR{dismissal: d, URL: url})
+	}
+	slices.SortFunc(result, func(a, b dismissedPR) int {
+		return cmp.Or(strings.Compare(a.Repository, b.Repository), cmp.Compare(a.Number, b.Number))
+	})
+	return result
+}
+
+// ReconcileDismissals drops dismissals whose PR's action kind changed, and those on PRs
+// missing from a complete fetch, which have closed. PRs without Turn data this cycle
+// keep their dismissal.
+func (m *PRStateManager) ReconcileDismissals(incoming, outgoing []PR, complete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.dismissed) == 0 {
+		return
+	}
+	prs := make(map[string]*PR, len(incoming)+len(outgoing))
+	for _, list := range [][]PR{incoming, outgoing} {
+		for i := range list {
+			prs[list[i].URL] = &list[i]
+		}
+	}
+
+	before := len(m.dismissed)
+	for url, d := range maps.Clone(m.dismissed) {
+		pr, ok := prs[url]
+		switch {
+		case !ok && complete:
+			slog.Info("[STATE] Dismissed PR closed, dropping dismissal", "repo", d.Repository, "number", d.Number)
+		case !ok, pr.TurnDataAppliedAt.IsZero(), pr.ActionKind == d.ActionKind:
+			continue
+		default:
+			slog.Info("[STATE] Dismissed PR's action changed, dropping dismissal",
+				"repo", d.Repository, "number", d.Number, "dismissed_action", d.ActionKind, "action", pr.ActionKind)
+		}
+		delete(m.dismissed, url)
+	}
+	if len(m.dismissed) != before {
+		m.saveDismissalsLocked()
+	}
+}
+
+// applyDismissals returns prs with the dismissed ones demoted to non-blocking and marked
+// Dismissed. Like applyDraftPolicy, the input is never modified.
+func (m *PRStateManager) applyDismissals(prs []PR) []PR {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.dismissed) == 0 {
+		return prs
+	}
+	var out []PR
+	for i := range prs {
+		d, ok := m.dismissed[prs[i].URL]
+		if !ok || d.ActionKind != prs[i].ActionKind {
+			continue
+		}
+		if out == nil {
+			out = make([]PR, len(prs))
+			copy(out, prs)
+		}
+		out[i].NeedsReview = false
+		out[i].IsBlocked = false
+		out[i].Dismissed = true
+	}
+	if out == nil {
+		return prs
+	}
+	return out
+}
+
+// saveDismissalsLocked persists the dismissals, if LoadDismissals set a path. The caller holds m.mu.
+func (m *PRStateManager) saveDismissalsLocked() {
+	if m.dismissedPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(m.dismissed, "", "  ")
+	if err != nil {
+		slog.Warn("[STATE] Failed to marshal dismissed PRs", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(m.dismissedPath), 0o700); err != nil {
+		slog.Warn("[STATE] Failed to create dismissed PR directory", "error", err)
+		return
+	}
+	if err := os.WriteFile(m.dismissedPath, data, 0o600); err != nil {
+		slog.Warn("[STATE] Failed to save dismissed PRs", "path", m.dismissedPath, "error", err)
+	}
+}
+
+// FlushDismissals writes the dismissals to disk, for shutdown.
+func (m *PRStateManager) FlushDismissals() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saveDismissalsLocked()
+}
+
+// withDismissals applies my "Not my review" overrides to prs.
+func (app *App) withDismissals(prs []PR) []PR {
+	if app.stateManager == nil {
+		return prs
+	}
+	return app.stateManager.applyDismissals(prs)
+}
+
+// addDismissAction adds "Not my review" to a blocked PR's submenu.
+func (app *App) addDismissAction(ctx context.Context, item MenuItem, pr *PR) {
+	if app.stateManager == nil || (!pr.NeedsReview && !pr.IsBlocked) || pr.ActionKind == "" {
+		return
+	}
+	p := *pr
+	item.AddSubMenuItem(msg("pr.dismiss"), msg("pr.dismiss.tooltip")).Click(func() {
+		app.stateManager.Dismiss(&p)
+		app.presentTrayState()
+		app.rebuildMenu(ctx)
+	})
+}
+
+// dismissedTitle is the submenu label for a dismissed PR.
+func dismissedTitle(d dismissedPR) string {
+	return fmt.Sprintf("%s #%d", d.Repository, d.Number)
+}
+
+// dismissedTitles lists the "Dismissed PRs" entries for change detection.
+func (app *App) dismissedTitles() []string {
+	if app.stateManager == nil {
+		return nil
+	}
+	dismissed := app.stateManager.Dismissals()
+	if len(dismissed) == 0 {
+		return nil
+	}
+	titles := []string{msg("dismissed.menu", len(dismissed))}
+	for _, d := range dismissed {
+		titles = append(titles, dismissedTitle(d))
+	}
+	return titles
+}
+
+// addDismissedPRs adds a collapsed "Dismissed PRs" submenu; each entry can be opened or
+// undismissed.
+func (app *App) addDismissedPRs(ctx context.Context) {
+	if app.stateManager == nil {
+		return
+	}
+	dismissed := app.stateManager.Dismissals()
+	if len(dismissed) == 0 {
+		return
+	}
+
+	dismissedMenu := app.systrayInterface.AddMenuItem(msg("dismissed.menu", len(dismissed)), msg("dismissed.menu.tooltip"))
+	for _, d := range dismissed {
+		item := dismissedMenu.AddSubMenuItem(dismissedTitle(d), "")
+		url := d.URL
+		item.AddSubMenuItem(msg("dashboard.open_github"), "").Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+			}
+		})
+		item.AddSubMenuItem(msg("dismissed.undo"), "").Click(func() {
+			app.stateManager.Undismiss(url)
+			app.presentTrayState()
+			app.rebuildMenu(ctx)
+		})
+	}
+	app.systrayInterface.AddSeparator()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dismissablePR(kind ActionKind) PR {
+	return PR{
+		Repository: "acme/widgets", Number: 42, URL: "https://github.com/acme/widgets/pull/42",
+		Title: "Touch a path I no longer own", ActionKind: kind, NeedsReview: true,
+		UpdatedAt: time.Now(), TurnDataAppliedAt: time.Now(),
+	}
+}
+
+func dismissedURLs(m *PRStateManager) []string {
+	var urls []string
+	for _, d := range m.Dismissals() {
+		urls = append(urls, d.URL)
+	}
+	return urls
+}
+
+func TestDismissalPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), dismissedFileName)
+	m := NewPRStateManager(time.Now())
+	m.LoadDismissals(path)
+	pr := dismissablePR("review")
+	m.Dismiss(&pr)
+
+	restarted := NewPRStateManager(time.Now())
+	restarted.LoadDismissals(path)
+	if got := dismissedURLs(restarted); !slices.Equal(got, []string{pr.URL}) {
+		t.Fatalf("dismissals after restart = %v, want %v", got, []string{pr.URL})
+	}
+
+	restarted.Undismiss(pr.URL)
+	again := NewPRStateManager(time.Now())
+	again.LoadDismissals(path)
+	if got := dismissedURLs(again); len(got) != 0 {
+		t.Errorf("undone dismissal came back after restart: %v", got)
+	}
+}
+
+func TestReconcileDismissals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prs      []PR
+		complete bool
+		wantKept bool
+	}{
+		{name: "same action", prs: []PR{dismissablePR("review")}, complete: true, wantKept: true},
+		{name: "action changed", prs: []PR{dismissablePR("approve")}, complete: true},
+		{name: "no action anymore", prs: []PR{dismissablePR("")}, complete: true},
+		{name: "closed", complete: true},
+		{name: "missing from a partial fetch", wantKept: true},
+		{
+			name: "no Turn data this cycle",
+			prs: func() []PR {
+				pr := dismissablePR("")
+				pr.TurnDataAppliedAt = time.Time{}
+				return []PR{pr}
+			}(),
+			complete: true,
+			wantKept: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewPRStateManager(time.Now())
+			pr := dismissablePR("review")
+			m.Dismiss(&pr)
+
+			m.ReconcileDismissals(tt.prs, nil, tt.complete)
+			if kept := len(m.Dismissals()) == 1; kept != tt.wantKept {
+				t.Errorf("dismissal kept = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestDismissedPRDoesNotCount(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.hasPerformedInitialDiscovery = true
+	pr := dismissablePR("review")
+	app.incoming = []PR{pr}
+
+	item := &MockMenuItem{}
+	app.addDismissAction(ctx, item, &pr)
+	prActionItem(t, item, "Not my review").clickHandler()
+
+	if counts := app.countPRs(); counts.IncomingTotal != 1 || counts.IncomingBlocked != 0 {
+		t.Errorf("counts = %+v, want the dismissed PR listed but not blocked", counts)
+	}
+	app.processNotifications(ctx)
+	if _, blocked := app.stateManager.PRState(pr.URL); blocked {
+		t.Error("state manager tracks the dismissed PR as blocked")
+	}
+	notifier.mu.Lock()
+	notes := slices.Clone(notifier.notes)
+	notifier.mu.Unlock()
+	if len(notes) != 0 {
+		t.Errorf("dismissed PR notified: %q", notes)
+	}
+
+	titles := app.generateMenuTitles()
+	if !slices.ContainsFunc(titles, func(title string) bool { return strings.HasPrefix(title, "– ") && strings.Contains(title, "widgets") }) {
+		t.Errorf("menu titles %q lack the dismissed PR with a – prefix", titles)
+	}
+	if !slices.Contains(titles, "– Dismissed PRs (1)") {
+		t.Errorf("menu titles %q lack the Dismissed PRs submenu", titles)
+	}
+	dismissed := app.withDismissals(app.incoming)
+	if tooltip := formatMenuTooltip(dismissed[0], DisplayTitle, "1h"); !strings.Contains(tooltip, "dismissed by you") {
+		t.Errorf("tooltip = %q, want it to say the PR was dismissed", tooltip)
+	}
+
+	// Once Turn wants something else from me, the PR blocks again
+	changed := dismissablePR("approve")
+	app.incoming = []PR{changed}
+	app.processNotifications(ctx)
+	if counts := app.countPRs(); counts.IncomingBlocked != 1 {
+		t.Errorf("counts after the action changed = %+v, want the PR blocked again", counts)
+	}
+	if got := dismissedURLs(app.stateManager); len(got) != 0 {
+		t.Errorf("dismissals after the action changed = %v, want none", got)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// DisplayMode controls how PRs are labelled in the menu.
+type DisplayMode string
+
+const (
+	// DisplayRepoNumber labels PRs as "org/repo #123 — action".
+	DisplayRepoNumber DisplayMode = "repo_number"
+	// DisplayTitle labels PRs as "Fix flaky auth test — action".
+	DisplayTitle DisplayMode = "title"
+	// DisplayBoth labels PRs as "org/repo#123: Fix flaky auth test".
+	DisplayBoth DisplayMode = "both"
+)
+
+// defaultMenuLabelWidth is the maximum label length in runes, used when no width is configured.
+const defaultMenuLabelWidth = 60
+
+// A fetch error's details are wrapped onto at most errorDetailLines lines this wide.
+const (
+	errorDetailWidth = 60
+	errorDetailLines = 4
+)
+
+// displayModes lists the display modes in menu order.
+var displayModes = []DisplayMode{DisplayRepoNumber, DisplayTitle, DisplayBoth}
+
+// label returns the human-readable name shown in the menu.
+func (m DisplayMode) label() string {
+	switch m {
+	case DisplayTitle:
+		return msg("display.mode.title")
+	case DisplayBoth:
+		return msg("display.mode.both")
+	default:
+		return msg("display.mode.repo_number")
+	}
+}
+
+// valid reports whether m is a known display mode.
+func (m DisplayMode) valid() bool {
+	switch m {
+	case DisplayRepoNumber, DisplayTitle, DisplayBoth:
+		return true
+	default:
+		return false
+	}
+}
+
+// truncateRunes shortens s to at most width runes, ending in an ellipsis when cut.
+// A width of zero or less disables truncation.
+func truncateRunes(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return strings.TrimRight(string(runes[:width-1]), " ") + "…"
+}
+
+// wrapRunes breaks s into lines of at most width runes for menu items, which can't
+// wrap on their own. Lines break between words; a word longer than a line, like a
+// URL, is split across lines. Past maxLines the last line ends in an ellipsis. A width
+// of zero or less disables wrapping, and a maxLines of zero or less allows any number.
+func wrapRunes(s string, width, maxLines int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) <= width {
+			line = append(append(line, ' '), w...)
+			continue
+		}
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+		for len(w) > width {
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+		line = w
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		last := []rune(lines[maxLines-1])
+		if len(last) >= width {
+			last = last[:width-1]
+		}
+		lines = append(lines[:maxLines-1], strings.TrimRight(string(last), " ")+"…")
+	}
+	return lines
+}
+
+// prAction returns the action shown next to a PR, marking drafts.
+func prAction(pr PR) string {
+	return draftAction(pr, prNextAction(pr))
+}
+
+// prNextAction returns the PR's next action, or test state as a fallback.
+func prNextAction(pr PR) string {
+	if pr.TestsStuckFor > 0 && (pr.ActionKind == actionNone || pr.ActionKind == actionInvestigateCI) {
+		return fmt.Sprintf("tests stuck (%s)", stuckDuration(pr.TestsStuckFor))
+	}
+	if pr.ActionKind != actionNone {
+		return pr.ActionKind.label()
+	}
+	if pr.TestState == "running" {
+		// Show "tests running" as a fallback when no specific action is available
+		return "tests running..."
+	}
+	if !pr.IsBlocked && !pr.NeedsReview {
+		return waitingOnLabel(pr)
+	}
+	return ""
+}
+
+// prRef returns the "org/repo #123" reference for a PR.
+func prRef(pr PR) string {
+	return fmt.Sprintf("%s #%d", pr.Repository, pr.Number)
+}
+
+// formatMenuLabel returns the menu label for a PR, without status prefixes.
+// Only the PR title is truncated, so the repository and action stay readable.
+// PRs without a title fall back to the repo-and-number label.
+func formatMenuLabel(pr PR, mode DisplayMode, width int) string {
+	title := strings.TrimSpace(pr.Title)
+	if title == "" {
+		mode = DisplayRepoNumber
+	}
+
+	marker := baseMarker(pr)
+	switch mode {
+	case DisplayTitle:
+		action := prAction(pr)
+		if action == "" {
+			return truncateRunes(title, titleWidth(width, marker)) + marker
+		}
+		suffix := marker + " — " + action
+		return truncateRunes(title, titleWidth(width, suffix)) + suffix
+	case DisplayBoth:
+		prefix := fmt.Sprintf("%s#%d%s: ", pr.Repository, pr.Number, marker)
+		return prefix + truncateRunes(title, titleWidth(width, prefix))
+	default:
+		label := prRef(pr) + marker
+		if action := prAction(pr); action != "" {
+			label = fmt.Sprintf("%s — %s", label, action)
+		}
+		return label
+	}
+}
+
+// titleWidth returns the runes left for a title once fixed text is accounted for,
+// keeping room for at least a few characters of the title.
+func titleWidth(width int, fixed string) int {
+	const minTitleRunes = 10
+	if width <= 0 {
+		return 0
+	}
+	return max(width-len([]rune(fixed)), minTitleRunes)
+}
+
+// formatMenuTooltip returns the tooltip for a PR, carrying whatever the label leaves out.
+func formatMenuTooltip(pr PR, mode DisplayMode, age string) string {
+	if strings.TrimSpace(pr.Title) == "" {
+		mode = DisplayRepoNumber
+	}
+
+	var detail string
+	switch mode {
+	case DisplayTitle:
+		detail = prRef(pr)
+	case DisplayBoth:
+		detail = prAction(pr)
+	default:
+		detail = pr.Title
+	}
+
+	tooltip := fmt.Sprintf("(%s)", age)
+	if detail != "" {
+		tooltip = fmt.Sprintf("%s (%s)", detail, age)
+	}
+	// Add action reason for blocked PRs, drafts (informational), and PRs I've approved that wait on others
+	if (pr.NeedsReview || pr.IsBlocked || pr.IsDraft || pr.MyReviewState == reviewApproved) && pr.ActionReason != "" {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, pr.ActionReason)
+	}
+	if pr.Dismissed {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, msg("pr.dismissed"))
+	}
+	if pr.Snoozed {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, msg("pr.snoozed"))
+	}
+	if waiting := waitingOnDetail(pr, time.Now()); waiting != "" {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, waiting)
+	}
+	if requested := reviewRequestDetail(pr, time.Now()); requested != "" {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, requested)
+	}
+	if question := questionDetail(pr); question != "" {
+		tooltip = fmt.Sprintf("%s - %s", tooltip, question)
+	}
+	if pr.IsNonDefaultBase {
+		tooltip = fmt.Sprintf("%s → %s", tooltip, pr.BaseBranch)
+	}
+	return tooltip
+}
+
+// menuLabelSettings returns the current display mode and label width.
+func (app *App) menuLabelSettings() (mode DisplayMode, width int) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	mode, width = app.displayMode, app.menuLabelWidth
+	if !mode.valid() {
+		mode = DisplayRepoNumber
+	}
+	if width == 0 {
+		width = defaultMenuLabelWidth
+	}
+	return mode, width
+}
+
+// addDisplayModeMenu adds the "PR labels" submenu for choosing the display mode.
+func (app *App) addDisplayModeMenu(ctx context.Context) {
+	displayMenu := app.systrayInterface.AddMenuItem(msg("display.menu"), msg("display.menu.tooltip"))
+
+	current, _ := app.menuLabelSettings()
+	for _, m := range displayModes {
+		mode := m // Capture for closure
+		text := mode.label()
+		if mode == current {
+			text = "✓ " + text
+		}
+		item := displayMenu.AddSubMenuItem(text, "")
+		item.Click(func() {
+			app.mu.Lock()
+			app.displayMode = mode
+			app.mu.Unlock()
+
+			slog.Info("[SETTINGS] PR label display mode changed", "mode", mode)
+
+			// Save settings to disk
+			app.saveSettings()
+
+			// Rebuild menu to update labels and checkmarks
+			app.rebuildMenu(ctx)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFormatMenuLabel(t *testing.T) {
+	pr := PR{Repository: "org/repo", Number: 123, Title: "Fix flaky auth test", ActionKind: "review"}
+	longTitle := "修复在高负载下偶尔失败的身份验证测试以及相关的重试逻辑问题并添加更多日志记录"
+
+	tests := []struct {
+		name  string
+		pr    PR
+		mode  DisplayMode
+		want  string
+		width int
+	}{
+		{name: "repo and number", pr: pr, mode: DisplayRepoNumber, width: 60, want: "org/repo #123 — review"},
+		{name: "title", pr: pr, mode: DisplayTitle, width: 60, want: "Fix flaky auth test — review"},
+		{name: "both", pr: pr, mode: DisplayBoth, width: 60, want: "org/repo#123: Fix flaky auth test"},
+		{name: "unknown mode falls back to repo and number", pr: pr, mode: "bogus", width: 60, want: "org/repo #123 — review"},
+		{
+			name:  "action underscores become spaces",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: "Broken", ActionKind: "fix_tests"},
+			mode:  DisplayTitle,
+			width: 60,
+			want:  "Broken — fix tests",
+		},
+		{
+			name:  "running tests fallback",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: "Slow", TestState: "running"},
+			mode:  DisplayRepoNumber,
+			width: 60,
+			want:  "org/repo #1 — tests running...",
+		},
+		{
+			name:  "title without action",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: "Docs"},
+			mode:  DisplayTitle,
+			width: 60,
+			want:  "Docs",
+		},
+		{
+			name:  "missing title falls back to repo and number",
+			pr:    PR{Repository: "org/repo", Number: 7, ActionKind: "merge"},
+			mode:  DisplayTitle,
+			width: 60,
+			want:  "org/repo #7 — merge",
+		},
+		{
+			name:  "blank title in both mode",
+			pr:    PR{Repository: "org/repo", Number: 7, Title: "   "},
+			mode:  DisplayBoth,
+			width: 60,
+			want:  "org/repo #7",
+		},
+		{
+			name:  "long title truncated before action",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: "Refactor the entire authentication layer to use tokens", ActionKind: "review"},
+			mode:  DisplayTitle,
+			width: 30,
+			want:  "Refactor the entire… — review",
+		},
+		{
+			name:  "long unicode title truncated on rune boundary",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: longTitle},
+			mode:  DisplayBoth,
+			width: 25,
+			want:  "org/repo#1: 修复在高负载下偶尔失败的…",
+		},
+		{
+			name:  "emoji title",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: "🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿"},
+			mode:  DisplayTitle,
+			width: 12,
+			want:  "🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿🪿…",
+		},
+		{
+			name:  "negative width disables truncation",
+			pr:    PR{Repository: "org/repo", Number: 1, Title: longTitle},
+			mode:  DisplayTitle,
+			width: -1,
+			want:  longTitle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatMenuLabel(tt.pr, tt.mode, tt.width)
+			if got != tt.want {
+				t.Errorf("formatMenuLabel() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("formatMenuLabel() returned invalid UTF-8: %q", got)
+			}
+		})
+	}
+}
+
+func TestFormatMenuTooltip(t *testing.T) {
+	pr := PR{
+		Repository:   "org/repo",
+		Number:       123,
+		Title:        "Fix flaky auth test",
+		ActionKind:   "review",
+		ActionReason: "requested by alice",
+		NeedsReview:  true,
+	}
+	tests := []struct {
+		mode DisplayMode
+		want string
+	}{
+		{mode: DisplayRepoNumber, want: "Fix flaky auth test (2h) - requested by alice"},
+		{mode: DisplayTitle, want: "org/repo #123 (2h) - requested by alice"},
+		{mode: DisplayBoth, want: "review (2h) - requested by alice"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			if got := formatMenuTooltip(pr, tt.mode, "2h"); got != tt.want {
+				t.Errorf("formatMenuTooltip() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Without a title, the label shows the repo and number, so the tooltip has nothing extra
+	if got := formatMenuTooltip(PR{Repository: "org/repo", Number: 1}, DisplayTitle, "5m"); got != "(5m)" {
+		t.Errorf("formatMenuTooltip() without title = %q, want %q", got, "(5m)")
+	}
+}
+
+func TestWrapRunes(t *testing.T) {
+	url := "https://api.github.com/search/issues?q=is%3Aopen+is%3Apr+involves%3Atestuser"
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		maxLines int
+		want     []string
+	}{
+		{name: "short", s: "no such host", width: 20, want: []string{"no such host"}},
+		{name: "word boundaries", s: "dial tcp: lookup api.github.com: no such host", width: 20,
+			want: []string{"dial tcp: lookup", "api.github.com: no", "such host"}},
+		{name: "unbroken token", s: "GET " + url, width: 30,
+			want: []string{"GET", url[:30], url[30:60], url[60:]}},
+		{name: "multibyte", s: "接続 できません サーバー が 応答 しません", width: 8,
+			want: []string{"接続 できません", "サーバー が", "応答 しません"}},
+		{name: "line cap", s: "one two three four five six", width: 9, maxLines: 2,
+			want: []string{"one two", "three…"}},
+		{name: "no width", s: "one two", width: 0, want: []string{"one two"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapRunes(tt.s, tt.width, tt.maxLines)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("wrapRunes() = %q, want %q", got, tt.want)
+			}
+			for _, line := range got {
+				if tt.width > 0 && utf8.RuneCountInString(line) > tt.width {
+					t.Errorf("line %q is longer than %d runes", line, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestDisplayModeAppliedToMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.incoming = []PR{{
+		Repository: "org/repo",
+		Number:     9,
+		Title:      "Add display modes",
+		URL:        "https://github.com/org/repo/pull/9",
+		UpdatedAt:  time.Now(),
+	}}
+
+	app.displayMode = DisplayBoth
+	titles := app.generateMenuTitles()
+	if !slices.Contains(titles, "org/repo#9: Add display modes") {
+		t.Errorf("expected title-based label in menu titles, got %v", titles)
+	}
+
+	mock := &MockSystray{}
+	app.systrayInterface = mock
+	app.rebuildMenu(t.Context())
+	if !slices.ContainsFunc(mock.menuItems, func(s string) bool { return strings.HasSuffix(s, "org/repo#9: Add display modes") }) {
+		t.Errorf("expected rendered menu to use the same label, got %v", mock.menuItems)
+	}
+}
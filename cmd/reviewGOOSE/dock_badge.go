@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// dockBadger sets the badge label on the app's Dock icon, for people who hide the menu
+// bar. Only macOS has one.
+type dockBadger interface {
+	// Available reports whether the badge can be shown: AppKit only badges a .app bundle.
+	Available() bool
+	// SetBadge sets the badge label; "" clears it.
+	SetBadge(label string)
+}
+
+// dockBadgeLabel is the Dock badge for counts: the incoming blocked count, empty at zero.
+func dockBadgeLabel(counts PRCounts) string {
+	if counts.IncomingBlocked == 0 {
+		return ""
+	}
+	return strconv.Itoa(counts.IncomingBlocked)
+}
+
+// updateDockBadge keeps the Dock badge in step with the tray title, clearing it when
+// the setting is off.
+func (app *App) updateDockBadge(counts PRCounts) {
+	if app.dockBadge == nil || !app.dockBadge.Available() {
+		return
+	}
+	app.mu.Lock()
+	label := ""
+	if app.showDockBadge {
+		label = dockBadgeLabel(counts)
+	}
+	if label == app.dockBadgeShown {
+		app.mu.Unlock()
+		return
+	}
+	app.dockBadgeShown = label
+	app.mu.Unlock()
+
+	slog.Debug("[TRAY] Setting Dock badge", "label", label)
+	app.dockBadge.SetBadge(label)
+}
+
+// dockBadgeSetting is the "Show count on Dock icon" toggle, grayed out when the badge
+// is unavailable. There is none on platforms without a Dock.
+func (app *App) dockBadgeSetting() (SettingItem, bool) {
+	if app.dockBadge == nil {
+		return SettingItem{}, false
+	}
+	item := SettingItem{
+		ID:      "dock_badge",
+		Label:   msg("settings.dock_badge"),
+		Tooltip: msg("settings.dock_badge.tooltip"),
+		Checked: func() bool { return app.readSetting(&app.showDockBadge) },
+		OnToggle: func() {
+			app.mu.Lock()
+			app.showDockBadge = !app.showDockBadge
+			app.mu.Unlock()
+			app.presentTrayState()
+		},
+	}
+	if !app.dockBadge.Available() {
+		item.Disabled = true
+		item.Tooltip = msg("settings.dock_badge.unavailable")
+	}
+	return item, true
+}
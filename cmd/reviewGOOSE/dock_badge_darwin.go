@@ -0,0 +1,50 @@
+//go:build darwin
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#include <stdlib.h>
+#import <Cocoa/Cocoa.h>
+
+// setDockBadge sets the Dock tile's badge label on the main thread; an empty label clears it.
+static void setDockBadge(const char *label) {
+	@autoreleasepool {
+		NSString *text = label[0] ? [NSString stringWithUTF8String:label] : nil;
+		dispatch_async(dispatch_get_main_queue(), ^{
+			[[NSApp dockTile] setBadgeLabel:text];
+		});
+	}
+}
+*/
+import "C"
+
+import (
+	"log/slog"
+	"unsafe"
+)
+
+// appKitDockBadge badges the Dock icon through NSDockTile.
+type appKitDockBadge struct {
+	bundled bool
+}
+
+// newDockBadger returns the AppKit badger. A plain binary has no Dock tile to badge.
+func newDockBadger() dockBadger {
+	_, err := bundlePath()
+	if err != nil {
+		slog.Debug("[TRAY] Dock badge unavailable", "error", err)
+	}
+	return &appKitDockBadge{bundled: err == nil}
+}
+
+func (d *appKitDockBadge) Available() bool {
+	return d.bundled
+}
+
+func (*appKitDockBadge) SetBadge(label string) {
+	cs := C.CString(label)
+	defer C.free(unsafe.Pointer(cs))
+	C.setDockBadge(cs)
+}
@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package main
+
+// newDockBadger returns nil: only macOS has a Dock.
+func newDockBadger() dockBadger {
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDockBadge records the badge labels set.
+type fakeDockBadge struct {
+	labels    []string
+	mu        sync.Mutex
+	available bool
+}
+
+func (f *fakeDockBadge) Available() bool { return f.available }
+
+func (f *fakeDockBadge) SetBadge(label string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.labels = append(f.labels, label)
+}
+
+func TestDockBadgeFollowsIncomingBlockedCount(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	badge := &fakeDockBadge{available: true}
+	app.dockBadge = badge
+	app.showDockBadge = true
+	now := time.Now()
+	blocked := func(n int) PR {
+		return PR{Repository: "org/repo", Number: n, URL: "https://github.com/org/repo/pull/" + strconv.Itoa(n), NeedsReview: true, UpdatedAt: now}
+	}
+
+	app.presentTrayState() // Nothing blocked: the badge is already clear
+	app.incoming = []PR{blocked(1), blocked(2)}
+	app.outgoing = []PR{{Repository: "org/repo", Number: 9, URL: "https://github.com/org/repo/pull/9", IsBlocked: true, UpdatedAt: now}}
+	app.presentTrayState()
+	app.presentTrayState() // Unchanged count: no AppKit call
+	app.incoming = app.incoming[:1]
+	app.presentTrayState()
+	app.incoming = nil
+	app.presentTrayState()
+	app.incoming = []PR{blocked(3)}
+	app.presentTrayState()
+	app.showDockBadge = false
+	app.presentTrayState()
+
+	want := []string{"2", "1", "", "1", ""}
+	if !slices.Equal(badge.labels, want) {
+		t.Errorf("badge labels = %q, want %q", badge.labels, want)
+	}
+}
+
+func TestDockBadgeSettingUnavailable(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	app.rebuildMenu(context.Background())
+	if _, ok := mock.settingItems["dock_badge"]; ok {
+		t.Fatal("Dock badge setting shown without a Dock")
+	}
+
+	badge := &fakeDockBadge{}
+	app.dockBadge = badge
+	app.showDockBadge = true
+	app.rebuildMenu(context.Background())
+	item, ok := mock.settingItems["dock_badge"]
+	if !ok {
+		t.Fatal("Dock badge setting missing")
+	}
+	if !item.disabled || item.tooltip != "Needs reviewGOOSE to run from its .app bundle" {
+		t.Errorf("unavailable Dock badge setting: disabled=%v tooltip=%q, want it grayed out with an explanation", item.disabled, item.tooltip)
+	}
+	if len(badge.labels) != 0 {
+		t.Errorf("set badge %q on a binary without a bundle", badge.labels)
+	}
+
+	badge.available = true
+	app.rebuildMenu(context.Background())
+	if item := mock.settingItems["dock_badge"]; item.disabled {
+		t.Error("Dock badge setting grayed out with a bundle")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+// Drafts can't be merged or formally reviewed, yet Turn sometimes assigns them a next
+// action (e.g. fix_tests). By default those actions are informational: a draft never
+// counts as blocked, notifies, or auto-opens. The "Treat draft actions as blocking"
+// setting restores the old behavior.
+
+// draftLabel marks draft PRs in the menu.
+const draftLabel = "draft"
+
+// applyDraftPolicy returns prs with drafts demoted to non-blocking unless draftsBlock is
+// set. The input is never modified; it is returned as-is when there is nothing to change.
+func applyDraftPolicy(prs []PR, draftsBlock bool) []PR {
+	if draftsBlock {
+		return prs
+	}
+	var out []PR
+	for i := range prs {
+		if !prs[i].IsDraft || (!prs[i].NeedsReview && !prs[i].IsBlocked) {
+			continue
+		}
+		if out == nil {
+			out = make([]PR, len(prs))
+			copy(out, prs)
+		}
+		out[i].NeedsReview = false
+		out[i].IsBlocked = false
+	}
+	if out == nil {
+		return prs
+	}
+	return out
+}
+
+// withDraftPolicy applies the current draft setting to prs.
+func (app *App) withDraftPolicy(prs []PR) []PR {
+	return applyDraftPolicy(prs, app.readSetting(&app.draftsBlock))
+}
+
+// draftAction prefixes a draft PR's action with the draft marker.
+func draftAction(pr PR, action string) string {
+	if !pr.IsDraft {
+		return action
+	}
+	if action == "" {
+		return draftLabel
+	}
+	return draftLabel + ": " + action
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func draftTestPRs(now time.Time) (incoming, outgoing []PR) {
+	incoming = []PR{
+		{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", NeedsReview: true, UpdatedAt: now},
+		{
+			Repository: "org/repo", Number: 2, URL: "https://github.com/org/repo/pull/2", Title: "WIP: rework auth",
+			IsDraft: true, NeedsReview: true, IsBlocked: true, ActionKind: "review", UpdatedAt: now,
+		},
+	}
+	outgoing = []PR{
+		{
+			Repository: "org/repo", Number: 3, URL: "https://github.com/org/repo/pull/3",
+			IsDraft: true, IsBlocked: true, NeedsReview: true, ActionKind: "fix_tests", UpdatedAt: now,
+		},
+	}
+	return incoming, outgoing
+}
+
+func TestDraftActionsDoNotCountAsBlocked(t *testing.T) {
+	tests := []struct {
+		name            string
+		draftsBlock     bool
+		incomingBlocked int
+		outgoingBlocked int
+	}{
+		{name: "default: drafts are informational", incomingBlocked: 1, outgoingBlocked: 0},
+		{name: "drafts block when opted in", draftsBlock: true, incomingBlocked: 2, outgoingBlocked: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newFocusTestApp(time.Hour)
+			app.draftsBlock = tt.draftsBlock
+			app.incoming, app.outgoing = draftTestPRs(time.Now())
+
+			counts := app.countPRs()
+			if counts.IncomingTotal != 2 || counts.OutgoingTotal != 1 {
+				t.Errorf("totals = %+v, drafts should still be listed", counts)
+			}
+			if counts.IncomingBlocked != tt.incomingBlocked || counts.OutgoingBlocked != tt.outgoingBlocked {
+				t.Errorf("blocked = %d incoming, %d outgoing; want %d, %d",
+					counts.IncomingBlocked, counts.OutgoingBlocked, tt.incomingBlocked, tt.outgoingBlocked)
+			}
+		})
+	}
+}
+
+func TestDraftActionsDoNotNotify(t *testing.T) {
+	for _, draftsBlock := range []bool{false, true} {
+		app := newFocusTestApp(time.Hour) // Well past the grace period
+		app.draftsBlock = draftsBlock
+		app.hasPerformedInitialDiscovery = true
+		app.incoming, app.outgoing = draftTestPRs(time.Now())
+		app.processNotifications(context.Background())
+
+		for _, url := range []string{app.incoming[1].URL, app.outgoing[0].URL} {
+			state, tracked := app.stateManager.PRState(url)
+			notified := tracked && state.HasNotified
+			if notified != draftsBlock {
+				t.Errorf("draftsBlock=%v: draft %s notified = %v", draftsBlock, url, notified)
+			}
+		}
+		if state, ok := app.stateManager.PRState(app.incoming[0].URL); !ok || !state.HasNotified {
+			t.Errorf("draftsBlock=%v: the non-draft blocked PR should still notify", draftsBlock)
+		}
+	}
+}
+
+func TestDraftMarkerInMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.incoming, _ = draftTestPRs(time.Now())
+
+	titles := app.generatePRSectionTitles(app.snapshotPRs().incoming, "Incoming")
+	if len(titles) != 2 {
+		t.Fatalf("titles = %v, want both PRs", titles)
+	}
+	// The blocked non-draft sorts first; the draft shows its action informationally
+	if !strings.Contains(titles[0], "#1") || !strings.Contains(titles[1], "#2 — draft: review") {
+		t.Errorf("titles = %v, want the non-draft first and the draft marked", titles)
+	}
+	if strings.HasPrefix(titles[1], "■") {
+		t.Errorf("draft title %q should not carry the blocked icon", titles[1])
+	}
+
+	if got := prAction(PR{IsDraft: true}); got != "draft" {
+		t.Errorf("prAction(draft without action) = %q, want %q", got, "draft")
+	}
+}
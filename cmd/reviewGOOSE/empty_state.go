@@ -0,0 +1,62 @@
+package main
+
+import (
+	"log/slog"
+)
+
+// A new account often has no open PRs or review requests at all. Then the update skips
+// Turn lookups and notification processing, and the menu explains what will show up
+// instead of the bare "No pull requests".
+
+// idle reports whether the state manager has nothing an update could change: no
+// blocked, recently cleared, dismissed, watched, or pinned PRs.
+func (m *PRStateManager) idle() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.states) == 0 && len(m.cleared) == 0 && len(m.dismissed) == 0 &&
+		len(m.testWatches) == 0 && len(m.pinned) == 0
+}
+
+// nothingToNotify reports whether the view has no PRs and no earlier update left
+// anything behind to clear, so notification processing can be skipped.
+func (app *App) nothingToNotify(view *prView) bool {
+	if len(view.allIncoming) > 0 || len(view.allOutgoing) > 0 {
+		return false
+	}
+	app.mu.RLock()
+	wasBlocked := len(app.previousBlockedPRs)
+	app.mu.RUnlock()
+	return wasBlocked == 0 && app.stateManager.idle()
+}
+
+// emptyStateTitles returns the menu lines shown when the last complete fetch found no
+// PRs at all, or nil. Repo and team mode keep the plain "No pull requests", as the
+// lines talk about my own PRs.
+func (app *App) emptyStateTitles(view *prView) []string {
+	if len(view.allIncoming) > 0 || len(view.allOutgoing) > 0 {
+		return nil
+	}
+	if shared, _ := app.sharedQueue(); shared {
+		return nil
+	}
+	app.mu.RLock()
+	complete := app.partialFetch == nil && len(app.filteredPRs) == 0
+	app.mu.RUnlock()
+	if !complete {
+		return nil
+	}
+	return []string{msg("menu.empty"), msg("menu.empty.hint")}
+}
+
+// addEmptyState adds the empty state lines, reporting whether it did.
+func (app *App) addEmptyState(view *prView) bool {
+	titles := app.emptyStateTitles(view)
+	if titles == nil {
+		return false
+	}
+	for _, title := range titles {
+		app.systrayInterface.AddMenuItem(title, "").Disable()
+	}
+	slog.Debug("[MENU] No PRs yet, showing the empty state")
+	return true
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestZeroPRsSkipsTurnAndShowsEmptyState(t *testing.T) {
+	searches := newFakeGitHub(t, func(*http.Request) fakeSearch { return fakeSearch{} })
+	var turnCalls atomic.Int32
+	turnClient := newFakeTurn(t, func(context.Context, turn.CheckRequest) fakeVerdict {
+		turnCalls.Add(1)
+		return fakeVerdict{status: http.StatusInternalServerError}
+	})
+	app := newFetchTestApp(t, searches.URL, turnClient, "testuser")
+
+	// Twice: the first load and a regular update take different Turn paths
+	app.updatePRs(context.Background())
+	app.updatePRs(context.Background())
+
+	if got := turnCalls.Load(); got != 0 {
+		t.Errorf("Turn server got %d requests, want none without PRs", got)
+	}
+	if app.consecutiveFailures != 0 {
+		t.Errorf("consecutiveFailures = %d, an empty account isn't a failure", app.consecutiveFailures)
+	}
+	if !app.hasPerformedInitialDiscovery {
+		t.Error("initial discovery should complete so the first PR notifies")
+	}
+	titles := app.generateMenuTitles()
+	for _, want := range []string{msg("menu.empty"), msg("menu.empty.hint"), msg("menu.web_dashboard")} {
+		if !slices.Contains(titles, want) {
+			t.Errorf("menu titles %v missing %q", titles, want)
+		}
+	}
+	if slices.Contains(titles, msg("menu.no_prs")) {
+		t.Error("the empty state replaces the plain no PRs line")
+	}
+}
+
+func TestEmptyStateOnlyWithoutAnyPRs(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	if app.emptyStateTitles(app.snapshotPRs()) == nil {
+		t.Fatal("expected the empty state for an account without PRs")
+	}
+
+	// PRs hidden by an org policy still exist, so it's plain "No pull requests"
+	app.incoming = []PR{{Repository: "hidden/repo", Number: 1, URL: "https://github.com/hidden/repo/pull/1", UpdatedAt: time.Now()}}
+	app.hiddenOrgs["hidden"] = true
+	if titles := app.generateMenuTitles(); !slices.Contains(titles, msg("menu.no_prs")) || slices.Contains(titles, msg("menu.empty")) {
+		t.Errorf("menu titles = %v, want the plain no PRs line", titles)
+	}
+
+	app.incoming = nil
+	app.partialFetch = &PartialError{Queries: []string{"q"}, Total: 2}
+	if app.emptyStateTitles(app.snapshotPRs()) != nil {
+		t.Error("a partial fetch may have missed PRs, so no empty state")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// eventPRMaxMisses is how many polls may miss a PR inserted from a sprinkler event
+// before it's dropped. The search index can lag the event by a cycle.
+const eventPRMaxMisses = 2
+
+// pendingEventPR is a PR the sprinkler added to the menu before any poll found it.
+type pendingEventPR struct {
+	pr       PR
+	outgoing bool
+	misses   int // Polls since insertion that didn't return it
+}
+
+// prFromEvent builds a menu entry for a PR first seen in a sprinkler event from its
+// Turn response, and reports whether it's one of mine.
+func prFromEvent(data *turn.CheckResponse, url, repo string, n int, user string, act *turn.Action) (pr PR, outgoing bool) {
+	outgoing = data.PullRequest.Author == user
+	pr = PR{
+		Title:             data.PullRequest.Title,
+		URL:               url,
+		Repository:        repo,
+		Number:            n,
+		Author:            data.PullRequest.Author,
+		CreatedAt:         data.PullRequest.CreatedAt,
+		UpdatedAt:         data.PullRequest.UpdatedAt,
+		Labels:            data.PullRequest.Labels,
+		IsDraft:           data.PullRequest.Draft,
+		AuthorBot:         data.PullRequest.AuthorBot,
+		TestState:         data.PullRequest.TestState,
+		WorkflowState:     data.Analysis.WorkflowState,
+		Size:              data.Analysis.Size,
+		FailingCheck:      firstFailingCheck(data),
+		LastActivityAt:    data.Analysis.LastActivity.Timestamp,
+		LastActivityKind:  data.Analysis.LastActivity.Kind,
+		LastActivityActor: data.Analysis.LastActivity.Actor,
+		NeedsReview:       true,
+		IsBlocked:         act.Critical,
+		ActionKind:        ActionKind(act.Kind),
+		ActionReason:      act.Reason,
+		ActionSince:       act.Since,
+		TurnDataAppliedAt: time.Now(),
+	}
+	if outgoing {
+		waiting := pendingUsers(data.Analysis.NextAction, user)
+		pr.WaitingOnCount = len(waiting)
+		if len(waiting) > 0 {
+			pr.WaitingOn, pr.WaitingOnKind, pr.WaitingSince = waiting[0].login, waiting[0].kind, waiting[0].since
+		}
+	} else {
+		pr.MyReviewState = myReviewState(data, user)
+	}
+	return pr, outgoing
+}
+
+// insertEventPR adds pr to the incoming or outgoing list until a poll confirms it,
+// returning false if a filter rule hides it. A PR a poll added in the meantime is
+// left as the poll found it.
+func (app *App) insertEventPR(pr PR, outgoing bool) bool {
+	in, out := []PR{pr}, []PR(nil)
+	if outgoing {
+		in, out = nil, in
+	}
+	if _, _, filtered := app.splitFiltered(in, out); len(filtered) > 0 {
+		return false
+	}
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if findPR(pr.URL, app.incoming, app.outgoing) != nil {
+		return true
+	}
+	if outgoing {
+		app.outgoing = append(app.outgoing, pr)
+	} else {
+		app.incoming = append(app.incoming, pr)
+	}
+	if app.eventPRs == nil {
+		app.eventPRs = make(map[string]*pendingEventPR)
+	}
+	app.eventPRs[pr.URL] = &pendingEventPR{pr: pr, outgoing: outgoing}
+	return true
+}
+
+// reconcileEventPRs carries the PRs inserted from events over a poll that didn't return
+// them, dropping those it confirmed and those missed eventPRMaxMisses times. Misses only
+// count when every search succeeded.
+func (app *App) reconcileEventPRs(incoming, outgoing []PR, complete bool) (keptIncoming, keptOutgoing []PR) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if len(app.eventPRs) == 0 {
+		return incoming, outgoing
+	}
+	polled := indexPRs(incoming, outgoing)
+	for url, p := range app.eventPRs {
+		if polled.get(url) != nil {
+			slog.Debug("[SPRINKLER] Poll confirmed event-discovered PR", "repo", p.pr.Repository, "number", p.pr.Number)
+			delete(app.eventPRs, url)
+			continue
+		}
+		if complete {
+			p.misses++
+		}
+		if p.misses >= eventPRMaxMisses {
+			slog.Info("[SPRINKLER] Dropping event-discovered PR no poll confirmed",
+				"repo", p.pr.Repository, "number", p.pr.Number, "polls", p.misses)
+			delete(app.eventPRs, url)
+			continue
+		}
+		if p.outgoing {
+			outgoing = append(outgoing, p.pr)
+		} else {
+			incoming = append(incoming, p.pr)
+		}
+	}
+	return incoming, outgoing
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const eventTestURL = "https://github.com/org/repo/pull/7"
+
+// newEventPR delivers a sprinkler event for a PR no poll has seen yet.
+func newEventPR(t *testing.T, app *App) {
+	t.Helper()
+	act := turn.Action{Kind: "review", Reason: "needs review", Critical: true, Since: time.Now()}
+	data := &turn.CheckResponse{
+		PullRequest: prx.PullRequest{Title: "Add the thing", Author: "someone", UpdatedAt: time.Now(), CreatedAt: time.Now()},
+		Analysis:    turn.Analysis{NextAction: map[string]turn.Action{"me": act}},
+	}
+	sm := &sprinklerMonitor{app: app}
+	if !sm.handleNewPR(context.Background(), data, eventTestURL, "org/repo", 7, "me", &act) {
+		t.Fatal("handleNewPR() = false for a PR not in the lists")
+	}
+}
+
+func hasPR(prs []PR, url string) bool {
+	return slices.ContainsFunc(prs, func(pr PR) bool { return pr.URL == url })
+}
+
+func TestEventPRConfirmedByPoll(t *testing.T) {
+	app, notifier := newGraceTestApp(time.Hour)
+	app.hasPerformedInitialDiscovery = true
+	newEventPR(t, app)
+
+	if !hasPR(app.incoming, eventTestURL) {
+		t.Fatalf("incoming = %+v, want the event's PR before any poll", app.incoming)
+	}
+	if titles := app.generateMenuTitles(); !slices.ContainsFunc(titles, func(title string) bool { return strings.Contains(title, "#7") }) {
+		t.Errorf("menu titles %q lack the event's PR", titles)
+	}
+	if notes := waitForNotes(notifier, 1); len(notes) != 1 {
+		t.Fatalf("got %d notifications for the event, want 1", len(notes))
+	}
+
+	// The poll returns the PR itself: it replaces the inserted copy and doesn't notify again
+	polled := PR{URL: eventTestURL, Repository: "org/repo", Number: 7, Title: "Add the thing", NeedsReview: true, IsBlocked: true, ActionKind: "review", UpdatedAt: time.Now()}
+	incoming, outgoing := app.reconcileEventPRs([]PR{polled}, nil, true)
+	if len(incoming) != 1 || len(outgoing) != 0 {
+		t.Fatalf("after the poll incoming=%d outgoing=%d, want the PR once", len(incoming), len(outgoing))
+	}
+	app.mu.Lock()
+	app.incoming = incoming
+	app.mu.Unlock()
+	app.processNotifications(context.Background())
+	if notes := waitForNotes(notifier, 2); len(notes) != 1 {
+		t.Errorf("got %d notifications after the poll, want the event's 1: %q", len(notes), notes)
+	}
+	if len(app.eventPRs) != 0 {
+		t.Errorf("confirmed PR still pending: %v", app.eventPRs)
+	}
+}
+
+func TestEventPRExpiresUnconfirmed(t *testing.T) {
+	app, _ := newGraceTestApp(time.Hour)
+	newEventPR(t, app)
+
+	// A failed search doesn't count against it
+	incoming, _ := app.reconcileEventPRs(nil, nil, false)
+	if !hasPR(incoming, eventTestURL) {
+		t.Fatal("event PR dropped by a partial poll")
+	}
+	incoming, _ = app.reconcileEventPRs(nil, nil, true)
+	if !hasPR(incoming, eventTestURL) {
+		t.Fatal("event PR dropped after one poll missed it, want it kept for two")
+	}
+	incoming, _ = app.reconcileEventPRs(nil, nil, true)
+	if hasPR(incoming, eventTestURL) {
+		t.Error("event PR kept after two polls missed it")
+	}
+	if incoming, _ = app.reconcileEventPRs(nil, nil, true); hasPR(incoming, eventTestURL) {
+		t.Error("expired event PR came back")
+	}
+}
+
+func TestEventPROutgoingAndFiltered(t *testing.T) {
+	act := &turn.Action{Kind: "merge", Critical: true}
+	data := &turn.CheckResponse{PullRequest: prx.PullRequest{Author: "me", Labels: []string{"wip"}}}
+	pr, outgoing := prFromEvent(data, eventTestURL, "org/repo", 7, "me", act)
+	if !outgoing || !pr.IsBlocked || pr.ActionKind != "merge" {
+		t.Errorf("prFromEvent() = %+v outgoing=%v, want my blocked PR", pr, outgoing)
+	}
+
+	app := newFocusTestApp(time.Hour)
+	app.filters = []FilterRule{{Label: "wip"}}
+	if app.insertEventPR(pr, outgoing) || hasPR(app.outgoing, eventTestURL) {
+		t.Error("insertEventPR() added a PR a filter rule hides")
+	}
+}
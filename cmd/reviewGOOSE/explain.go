@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+)
+
+// Turn's ActionReason is terse ("PR is ready for review"), so a blocked PR also gets a
+// short explanation built from everything goose knows about it: why it's blocked, CI,
+// who else it waits on, and the latest activity. It shows as a "Why?" submenu, and the
+// first lines go into the notification. Facts missing their data are left out.
+
+// explainNotifyLines is how many explanation lines a notification includes.
+const explainNotifyLines = 2
+
+// explain lists the facts about why pr is blocked, most important first.
+func explain(pr PR, now time.Time) []string {
+	var facts []string
+	for _, fact := range []string{
+		blockedBecause(pr, now),
+		ciFact(pr),
+		myReviewFact(pr),
+		waitingOnDetail(pr, now),
+		lastActivityFact(pr, now),
+	} {
+		if fact != "" {
+			facts = append(facts, capitalize(fact))
+		}
+	}
+	if pr.IsNonDefaultBase {
+		facts = append(facts, msg("explain.base", pr.BaseBranch))
+	}
+	return facts
+}
+
+// capitalize upper-cases a fact's first letter, since facts such as "waiting on @bob"
+// come from tooltip fragments.
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// blockedBecause says why pr is blocked: who requested my review and when, or else
+// Turn's reason or action.
+func blockedBecause(pr PR, now time.Time) string {
+	switch {
+	case pr.RequestedAuto:
+		return msg("explain.blocked", msg("explain.requested_auto"))
+	case pr.RequestedBy != "":
+		return msg("explain.blocked", reviewRequestDetail(pr, now))
+	case pr.ActionReason != "" && !pr.ActionSince.IsZero():
+		return msg("explain.blocked.since", pr.ActionReason, stuckDuration(now.Sub(pr.ActionSince)))
+	case pr.ActionReason != "":
+		return msg("explain.blocked", pr.ActionReason)
+	case pr.ActionKind != actionNone:
+		return msg("explain.blocked", pr.ActionKind.label())
+	default:
+		return ""
+	}
+}
+
+// ciFact describes pr's tests, or "" if Turn didn't report them.
+func ciFact(pr PR) string {
+	switch {
+	case pr.TestState == "failing" && pr.FailingCheck != "":
+		return msg("explain.ci.failing.check", pr.FailingCheck)
+	case pr.TestState == "failing":
+		return msg("explain.ci.failing")
+	case pr.TestsStuckFor > 0:
+		return msg("explain.ci.stuck", stuckDuration(pr.TestsStuckFor))
+	case testsInProgress(pr.TestState):
+		return msg("explain.ci.running")
+	case pr.TestState == "passing":
+		return msg("explain.ci.passing")
+	default:
+		return ""
+	}
+}
+
+// myReviewFact describes my review still covering the head commit, or "" without one.
+func myReviewFact(pr PR) string {
+	switch pr.MyReviewState {
+	case reviewApproved:
+		return msg("explain.review.approved")
+	case string(prx.ReviewStateChangesRequested):
+		return msg("explain.review.changes_requested")
+	case string(prx.ReviewStateCommented):
+		return msg("explain.review.commented")
+	default:
+		return ""
+	}
+}
+
+// lastActivityFact describes pr's latest activity, like "Last activity: author pushed
+// 3h ago", or "" if Turn didn't report when it was.
+func lastActivityFact(pr PR, now time.Time) string {
+	if pr.LastActivityAt.IsZero() {
+		return ""
+	}
+	age := stuckDuration(now.Sub(pr.LastActivityAt))
+	if pr.LastActivityKind == "" {
+		return msg("explain.activity", age)
+	}
+	verb := activityVerb(pr.LastActivityKind)
+	switch {
+	case pr.LastActivityActor == "":
+		return msg("explain.activity.kind", verb, age)
+	case pr.LastActivityActor == pr.Author:
+		return msg("explain.activity.by", msg("explain.author"), verb, age)
+	default:
+		return msg("explain.activity.by", "@"+pr.LastActivityActor, verb, age)
+	}
+}
+
+// explainedBody is the notification body for pr followed by the first explanation
+// lines that don't repeat it.
+func (app *App) explainedBody(pr *PR) string {
+	body := app.notificationBody(pr)
+	lines := []string{body}
+	for _, fact := range explain(*pr, time.Now()) {
+		if len(lines) > explainNotifyLines {
+			break
+		}
+		if !strings.Contains(body, fact) {
+			lines = append(lines, fact)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// addExplainAction adds the read-only "Why?" submenu to a blocked PR's item.
+func addExplainAction(item MenuItem, pr *PR) {
+	if !pr.NeedsReview && !pr.IsBlocked {
+		return
+	}
+	facts := explain(*pr, time.Now())
+	if len(facts) == 0 {
+		return
+	}
+	why := item.AddSubMenuItem(msg("explain.menu"), "")
+	for _, fact := range facts {
+		why.AddSubMenuItem(fact, "").Disable()
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		pr   PR
+		want []string
+	}{
+		{name: "no data", pr: PR{NeedsReview: true}, want: nil},
+		{
+			name: "reason only",
+			pr:   PR{ActionReason: "PR is ready for review"},
+			want: []string{"Blocked because: PR is ready for review"},
+		},
+		{
+			name: "reason with age",
+			pr:   PR{ActionReason: "PR is ready for review", ActionSince: now.Add(-3 * time.Hour)},
+			want: []string{"Blocked because: PR is ready for review (3h)"},
+		},
+		{
+			name: "action kind without reason",
+			pr:   PR{ActionKind: "fix_tests"},
+			want: []string{"Blocked because: fix tests"},
+		},
+		{
+			name: "requester beats reason",
+			pr:   PR{ActionReason: "PR is ready for review", RequestedBy: "carol", RequestedAt: now.Add(-48 * time.Hour)},
+			want: []string{"Blocked because: review requested by @carol, 2d ago"},
+		},
+		{
+			name: "requester without time",
+			pr:   PR{RequestedBy: "carol"},
+			want: []string{"Blocked because: review requested by @carol"},
+		},
+		{
+			name: "auto-assigned",
+			pr:   PR{RequestedAuto: true, RequestedBy: "carol"},
+			want: []string{"Blocked because: review auto-assigned"},
+		},
+		{name: "failing check", pr: PR{TestState: "failing", FailingCheck: "ci/test"}, want: []string{"CI: checks failing (ci/test)"}},
+		{name: "failing unnamed", pr: PR{TestState: "failing"}, want: []string{"CI: checks failing"}},
+		{name: "stuck", pr: PR{TestState: "running", TestsStuckFor: 3 * time.Hour}, want: []string{"CI: tests running for 3h"}},
+		{name: "queued", pr: PR{TestState: "queued"}, want: []string{"CI: tests running"}},
+		{name: "passing", pr: PR{TestState: "passing"}, want: []string{"CI: passing"}},
+		{name: "unknown test state", pr: PR{TestState: "skipped"}, want: nil},
+		{name: "changes requested", pr: PR{MyReviewState: "changes_requested"}, want: []string{"Your review: changes requested"}},
+		{name: "others pending", pr: PR{WaitingOnCount: 2}, want: []string{"Waiting on 2 reviewers"}},
+		{
+			name: "one pending with age",
+			pr:   PR{WaitingOn: "dave", WaitingOnKind: "review", WaitingSince: now.Add(-2 * time.Hour)},
+			want: []string{"Waiting on @dave to review, 2h"},
+		},
+		{
+			name: "author pushed",
+			pr:   PR{Author: "erin", LastActivityKind: "push", LastActivityActor: "erin", LastActivityAt: now.Add(-3 * time.Hour)},
+			want: []string{"Last activity: author pushed new commits 3h ago"},
+		},
+		{
+			name: "someone else commented",
+			pr:   PR{Author: "erin", LastActivityKind: "comment", LastActivityActor: "frank", LastActivityAt: now.Add(-10 * time.Minute)},
+			want: []string{"Last activity: @frank commented 10m ago"},
+		},
+		{
+			name: "activity without actor",
+			pr:   PR{LastActivityKind: "review", LastActivityAt: now.Add(-time.Hour)},
+			want: []string{"Last activity: reviewed 1h ago"},
+		},
+		{name: "activity without kind", pr: PR{LastActivityAt: now.Add(-time.Hour)}, want: []string{"Last activity: 1h ago"}},
+		{name: "activity without time", pr: PR{LastActivityKind: "push", LastActivityActor: "erin"}, want: nil},
+		{name: "release branch", pr: PR{IsNonDefaultBase: true, BaseBranch: "release-1.2"}, want: []string{"Targets release-1.2"}},
+		{
+			name: "everything, in order",
+			pr: PR{
+				Author: "erin", RequestedBy: "carol", RequestedAt: now.Add(-48 * time.Hour),
+				TestState: "failing", FailingCheck: "ci/test", WaitingOnCount: 2,
+				LastActivityKind: "push", LastActivityActor: "erin", LastActivityAt: now.Add(-3 * time.Hour),
+				IsNonDefaultBase: true, BaseBranch: "release-1.2",
+			},
+			want: []string{
+				"Blocked because: review requested by @carol, 2d ago",
+				"CI: checks failing (ci/test)",
+				"Waiting on 2 reviewers",
+				"Last activity: author pushed new commits 3h ago",
+				"Targets release-1.2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := explain(tt.pr, now); !slices.Equal(got, tt.want) {
+				t.Errorf("explain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainedBody(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	pr := PR{
+		Repository: "acme/widgets", Number: 7, Author: "erin", ActionKind: "review", NeedsReview: true,
+		ActionReason: "PR is ready for review", TestState: "failing", FailingCheck: "ci/test", WaitingOnCount: 2,
+	}
+	want := "Review requested: acme/widgets#7 by @erin\nBlocked because: PR is ready for review\nCI: checks failing (ci/test)"
+	if got := app.explainedBody(&pr); got != want {
+		t.Errorf("explainedBody() = %q, want %q", got, want)
+	}
+
+	// Nothing to explain leaves the body alone
+	bare := PR{Repository: "acme/widgets", Number: 7}
+	if got, body := app.explainedBody(&bare), app.notificationBody(&bare); got != body {
+		t.Errorf("explainedBody() = %q, want the plain body %q", got, body)
+	}
+}
+
+func TestExplainSubmenuOnlyForBlockedPRs(t *testing.T) {
+	blocked := &MockMenuItem{}
+	addExplainAction(blocked, &PR{NeedsReview: true, TestState: "passing", ActionReason: "PR is ready for review"})
+	if len(blocked.subItems) != 1 {
+		t.Fatalf("blocked PR has %d submenus, want the Why? submenu", len(blocked.subItems))
+	}
+	why, ok := blocked.subItems[0].(*MockMenuItem)
+	if !ok || why.title != "Why?" || len(why.subItems) != 2 {
+		t.Fatalf("Why? submenu = %+v", blocked.subItems[0])
+	}
+	for _, fact := range why.subItems {
+		if item, ok := fact.(*MockMenuItem); !ok || !item.disabled {
+			t.Errorf("fact %+v is clickable, want read-only", fact)
+		}
+	}
+
+	for _, pr := range []*PR{{TestState: "passing"}, {NeedsReview: true}} {
+		item := &MockMenuItem{}
+		addExplainAction(item, pr)
+		if len(item.subItems) != 0 {
+			t.Errorf("PR %+v got a Why? submenu", pr)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// fakePR is one PR in a fake search result.
+type fakePR struct {
+	updated time.Time // Created an hour earlier
+	repo    string
+	title   string // "PR <number>" unless set
+	author  string // "author" unless set
+	number  int
+}
+
+// fakeSearch is the fake GitHub API's answer to one request. A non-zero status fails
+// the request with it, and message as the error body when set.
+type fakeSearch struct {
+	etag       string
+	message    string
+	prs        []fakePR
+	total      int // len(prs) unless set
+	status     int
+	incomplete bool
+}
+
+// newFakeGitHub serves a GitHub API that answers every request with handle(r) as a
+// search result. It's closed when the test ends.
+func newFakeGitHub(t *testing.T, handle func(r *http.Request) fakeSearch) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := handle(r)
+		w.Header().Set("Content-Type", "application/json")
+		if result.etag != "" {
+			w.Header().Set("ETag", result.etag)
+		}
+		if result.status != 0 {
+			w.WriteHeader(result.status)
+			if result.message != "" {
+				if err := json.NewEncoder(w).Encode(map[string]any{"message": result.message}); err != nil {
+					t.Errorf("Failed to encode error response: %v", err)
+				}
+			}
+			return
+		}
+		items := make([]map[string]any, 0, len(result.prs))
+		for _, pr := range result.prs {
+			title, author := pr.title, pr.author
+			if title == "" {
+				title = fmt.Sprintf("PR %d", pr.number)
+			}
+			if author == "" {
+				author = "author"
+			}
+			items = append(items, map[string]any{
+				"number":         pr.number,
+				"title":          title,
+				"html_url":       fmt.Sprintf("https://github.com/%s/pull/%d", pr.repo, pr.number),
+				"repository_url": "https://api.github.com/repos/" + pr.repo,
+				"user":           map[string]any{"login": author},
+				"pull_request":   map[string]any{"url": fmt.Sprintf("https://api.github.com/repos/%s/pulls/%d", pr.repo, pr.number)},
+				"created_at":     pr.updated.Add(-time.Hour).Format(time.RFC3339),
+				"updated_at":     pr.updated.Format(time.RFC3339),
+			})
+		}
+		total := result.total
+		if total == 0 {
+			total = len(items)
+		}
+		resp := map[string]any{"total_count": total, "incomplete_results": result.incomplete, "items": items}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Failed to encode search response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fakeVerdict is the fake Turn API's answer to one lookup: an open PR with passing
+// tests, waiting for review on the users in next. A non-zero status fails the lookup.
+type fakeVerdict struct {
+	next   map[string]any // next_action by user
+	status int
+}
+
+// reviewAction is a critical review next_action.
+func reviewAction() map[string]any {
+	return map[string]any{"kind": "review", "reason": "needs review", "critical": true}
+}
+
+// newFakeTurn serves a Turn API that answers each lookup with verdict, and returns a
+// client for it. verdict may block on ctx, which ends when the client gives up; the
+// lookup then gets no answer. The server is closed when the test ends.
+func newFakeTurn(t *testing.T, verdict func(ctx context.Context, req turn.CheckRequest) fakeVerdict) *turn.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req turn.CheckRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode Turn request: %v", err)
+		}
+		v := verdict(r.Context(), req)
+		if r.Context().Err() != nil {
+			return
+		}
+		if v.status != 0 {
+			http.Error(w, http.StatusText(v.status), v.status)
+			return
+		}
+		resp := map[string]any{
+			"timestamp":    time.Now().Format(time.RFC3339),
+			"pull_request": map[string]any{"state": "open", "test_state": "passing", "check_summary": map[string]any{}},
+			"analysis":     map[string]any{"workflow_state": "WAITING_FOR_REVIEW", "next_action": v.next},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Failed to encode Turn response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	turnClient, err := turn.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create turn client: %v", err)
+	}
+	turnClient.SetAuthToken("test-token")
+	return turnClient
+}
+
+// newETagTestClient returns a GitHub client for the API at serverURL.
+func newETagTestClient(t *testing.T, serverURL string) *github.Client {
+	t.Helper()
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(serverURL + "/")
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	client.BaseURL = baseURL
+	return client
+}
+
+// newFetchTestApp returns an app that fetches PRs for login from the GitHub API at
+// githubURL, enriched by turnClient.
+func newFetchTestApp(t *testing.T, githubURL string, turnClient *turn.Client, login string) *App {
+	t.Helper()
+	app := newFocusTestApp(time.Hour)
+	app.turnClient = turnClient
+	app.currentUser = &github.User{Login: &login}
+	app.cacheDir = t.TempDir()
+	app.updateInterval = time.Minute
+	app.searchCache = newSearchCache()
+	app.notifier = &messageNotifier{}
+	app.client = newETagTestClient(t, githubURL)
+	return app
+}
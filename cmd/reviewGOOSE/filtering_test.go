@@ -149,6 +149,11 @@ func TestExtractOrgFromRepo(t *testing.T) {
 			repo: "org/repo/subpath",
 			want: "org",
 		},
+		{
+			name: "repo with dots and .git suffix",
+			repo: "acme/widgets.io.git",
+			want: "acme",
+		},
 	}
 
 	for _, tt := range tests {
@@ -239,7 +244,7 @@ func TestBotPRsSortedAfterHumans(t *testing.T) {
 		systrayInterface: &MockSystray{},
 	}
 
-	titles := app.generatePRSectionTitles(app.incoming, "Incoming", map[string]bool{}, false)
+	titles := app.generatePRSectionTitles(app.incoming, "Incoming")
 
 	if len(titles) != 4 {
 		t.Fatalf("Expected 4 titles, got %d", len(titles))
@@ -285,7 +290,7 @@ func TestBotPRsGetSmallerIcon(t *testing.T) {
 		systrayInterface: &MockSystray{},
 	}
 
-	titles := app.generatePRSectionTitles(app.incoming, "Incoming", map[string]bool{}, false)
+	titles := app.generatePRSectionTitles(app.incoming, "Incoming")
 
 	if len(titles) != 2 {
 		t.Fatalf("Expected 2 titles, got %d", len(titles))
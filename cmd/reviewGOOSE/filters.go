@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// FilterRule hides PRs such as automated releases and backports. Title matches a
+// substring of the PR title, or the whole title when it contains * or ?. Label
+// matches a label name, with the same wildcards. A rule with both fields needs both
+// to match; matching is case-insensitive. Rules are edited in settings.json.
+type FilterRule struct {
+	Title string `json:"title,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+// String describes the rule for the read-only "Filters" submenu.
+func (r FilterRule) String() string {
+	switch {
+	case r.Title != "" && r.Label != "":
+		return msg("filters.rule.both", r.Title, r.Label)
+	case r.Label != "":
+		return msg("filters.rule.label", r.Label)
+	default:
+		return msg("filters.rule.title", r.Title)
+	}
+}
+
+// matches reports whether the rule applies to pr. An empty rule matches nothing.
+func (r FilterRule) matches(pr *PR) bool {
+	if r.Title == "" && r.Label == "" {
+		return false
+	}
+	if r.Title != "" && !matchTitle(r.Title, pr.Title) {
+		return false
+	}
+	if r.Label != "" && !slices.ContainsFunc(pr.Labels, func(label string) bool {
+		return matchWildcard(strings.ToLower(r.Label), strings.ToLower(label))
+	}) {
+		return false
+	}
+	return true
+}
+
+// matchTitle matches a title pattern: a glob when it has wildcards, otherwise a substring.
+func matchTitle(pattern, title string) bool {
+	pattern, title = strings.ToLower(pattern), strings.ToLower(title)
+	if !strings.ContainsAny(pattern, "*?") {
+		return strings.Contains(title, pattern)
+	}
+	return matchWildcard(pattern, title)
+}
+
+// matchWildcard matches s against a pattern where * is any run of characters and
+// ? is any single character. Unlike path.Match, * also matches "/", which release
+// titles like "chore(deps): bump foo/bar" need.
+func matchWildcard(pattern, s string) bool {
+	p, r := []rune(pattern), []rune(s)
+	pi, si := 0, 0
+	star, mark := -1, 0
+	for si < len(r) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == r[si]):
+			pi++
+			si++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, si
+			pi++
+		case star >= 0:
+			// Let the last * absorb one more character and retry
+			mark++
+			pi, si = star+1, mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// matchingFilter returns the first rule that applies to pr.
+func matchingFilter(rules []FilterRule, pr *PR) (FilterRule, bool) {
+	for _, rule := range rules {
+		if rule.matches(pr) {
+			return rule, true
+		}
+	}
+	return FilterRule{}, false
+}
+
+// splitFiltered removes PRs matching the filter rules, and those targeting a non-default
+// branch when that's hidden, from incoming and outgoing, returning them separately so they stay out of counts, notifications, and auto-open.
+func (app *App) splitFiltered(incoming, outgoing []PR) (keptIncoming, keptOutgoing, filtered []PR) {
+	app.mu.RLock()
+	rules := app.filters
+	hideNonDefaultBase := app.hideNonDefaultBase
+	app.mu.RUnlock()
+	if len(rules) == 0 && !hideNonDefaultBase {
+		return incoming, outgoing, nil
+	}
+
+	split := func(prs []PR) []PR {
+		kept := make([]PR, 0, len(prs))
+		for i := range prs {
+			if hideNonDefaultBase && prs[i].IsNonDefaultBase {
+				slog.Debug("[FILTER] PR targets a non-default branch",
+					"repo", prs[i].Repository, "number", prs[i].Number, "base", prs[i].BaseBranch)
+				filtered = append(filtered, prs[i])
+				continue
+			}
+			if rule, ok := matchingFilter(rules, &prs[i]); ok {
+				slog.Debug("[FILTER] PR matched a filter rule",
+					"repo", prs[i].Repository, "number", prs[i].Number, "rule", rule.String())
+				filtered = append(filtered, prs[i])
+				continue
+			}
+			kept = append(kept, prs[i])
+		}
+		return kept
+	}
+	keptIncoming = split(incoming)
+	keptOutgoing = split(outgoing)
+	if len(filtered) > 0 {
+		slog.Info("[FILTER] Filtered PRs", "count", len(filtered), "rules", len(rules))
+	}
+	return keptIncoming, keptOutgoing, filtered
+}
+
+// isFilteredPR reports whether url is a PR the filter rules moved out of the sections.
+// Caller must hold app.mu.
+func (app *App) isFilteredPR(url string) bool {
+	return slices.ContainsFunc(app.filteredPRs, func(pr PR) bool { return pr.URL == url })
+}
+
+// filteredTitle is the submenu label for a filtered PR.
+func filteredTitle(pr *PR) string {
+	return fmt.Sprintf("%s #%d%s — %s", pr.Repository, pr.Number, baseMarker(*pr), pr.Title)
+}
+
+// filteredTitles lists the "Filtered" and "Filters" entries for change detection.
+func (app *App) filteredTitles() []string {
+	app.mu.RLock()
+	filtered := slices.Clone(app.filteredPRs)
+	rules := slices.Clone(app.filters)
+	app.mu.RUnlock()
+
+	var titles []string
+	if len(filtered) > 0 {
+		titles = append(titles, msg("filtered.menu", len(filtered)))
+		for i := range filtered {
+			titles = append(titles, filteredTitle(&filtered[i]))
+		}
+	}
+	if len(rules) > 0 {
+		titles = append(titles, msg("filters.menu", len(rules)))
+		for _, rule := range rules {
+			titles = append(titles, rule.String())
+		}
+	}
+	return titles
+}
+
+// addFilteredPRs adds a collapsed "Filtered (N)" submenu so PRs hidden by the filter
+// rules can still be opened.
+func (app *App) addFilteredPRs(ctx context.Context) {
+	app.mu.RLock()
+	filtered := slices.Clone(app.filteredPRs)
+	app.mu.RUnlock()
+	if len(filtered) == 0 {
+		return
+	}
+
+	filteredMenu := app.systrayInterface.AddMenuItem(msg("filtered.menu", len(filtered)), msg("filtered.menu.tooltip"))
+	for i := range filtered {
+		pr := &filtered[i]
+		item := filteredMenu.AddSubMenuItem(filteredTitle(pr), "")
+		url := prLink(pr)
+		item.Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+			}
+		})
+	}
+	app.systrayInterface.AddSeparator()
+}
+
+// addFiltersMenu lists the filter rules read-only; they are edited in settings.json.
+func (app *App) addFiltersMenu() {
+	app.mu.RLock()
+	rules := slices.Clone(app.filters)
+	app.mu.RUnlock()
+	if len(rules) == 0 {
+		return
+	}
+	filtersMenu := app.systrayInterface.AddMenuItem(msg("filters.menu", len(rules)), msg("filters.menu.tooltip"))
+	for _, rule := range rules {
+		filtersMenu.AddSubMenuItem(rule.String(), "").Disable()
+	}
+}
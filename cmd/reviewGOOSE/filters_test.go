@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMatchingFilter(t *testing.T) {
+	release := PR{Title: "chore(main): release 1.4.0", Labels: []string{"autorelease: pending"}}
+	backport := PR{Title: "[Backport release-1.3] Fix crash on start", Labels: []string{"Backport", "size/S"}}
+	deps := PR{Title: "Bump golang.org/x/net from 0.1 to 0.2", Labels: []string{"dependencies"}}
+	feature := PR{Title: "Add dark mode", Labels: []string{"enhancement"}}
+
+	tests := []struct {
+		name  string
+		rules []FilterRule
+		pr    PR
+		want  bool
+	}{
+		{name: "title substring", rules: []FilterRule{{Title: "release 1."}}, pr: release, want: true},
+		{name: "title substring ignores case", rules: []FilterRule{{Title: "[BACKPORT"}}, pr: backport, want: true},
+		{name: "title glob", rules: []FilterRule{{Title: "chore(*): release *"}}, pr: release, want: true},
+		{name: "title glob anchors the whole title", rules: []FilterRule{{Title: "release *"}}, pr: release, want: false},
+		{name: "glob star spans slashes", rules: []FilterRule{{Title: "bump * from *"}}, pr: deps, want: true},
+		{name: "glob question mark", rules: []FilterRule{{Title: "add dark mod?"}}, pr: feature, want: true},
+		{name: "label exact ignores case", rules: []FilterRule{{Label: "backport"}}, pr: backport, want: true},
+		{name: "label is not a substring", rules: []FilterRule{{Label: "back"}}, pr: backport, want: false},
+		{name: "label glob", rules: []FilterRule{{Label: "autorelease:*"}}, pr: release, want: true},
+		{name: "title and label both match", rules: []FilterRule{{Title: "bump", Label: "dependencies"}}, pr: deps, want: true},
+		{name: "title and label need both", rules: []FilterRule{{Title: "bump", Label: "backport"}}, pr: deps, want: false},
+		{name: "any rule matches", rules: []FilterRule{{Label: "backport"}, {Title: "dark"}}, pr: feature, want: true},
+		{name: "empty rule matches nothing", rules: []FilterRule{{}}, pr: feature, want: false},
+		{name: "no rules", pr: release, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := matchingFilter(tt.rules, &tt.pr); got != tt.want {
+				t.Errorf("matchingFilter(%+v, %q %q) = %v, want %v", tt.rules, tt.pr.Title, tt.pr.Labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilteredPRsLeaveSections(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.filters = []FilterRule{{Label: "backport"}, {Title: "chore: release *"}}
+	now := time.Now()
+	incoming := []PR{
+		{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", Title: "Fix login", NeedsReview: true, UpdatedAt: now},
+		{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2", Title: "Fix login", Labels: []string{"Backport"}, NeedsReview: true, UpdatedAt: now},
+	}
+	outgoing := []PR{
+		{Repository: "acme/gears", Number: 3, URL: "https://github.com/acme/gears/pull/3", Title: "chore: release 2.0", IsBlocked: true, UpdatedAt: now},
+	}
+
+	in, out, filtered := app.splitFiltered(incoming, outgoing)
+	if len(in) != 1 || in[0].Number != 1 || len(out) != 0 {
+		t.Fatalf("kept incoming=%+v outgoing=%+v, want only #1", in, out)
+	}
+	if len(filtered) != 2 {
+		t.Fatalf("filtered %d PRs, want 2", len(filtered))
+	}
+	app.incoming, app.outgoing, app.filteredPRs = in, out, filtered
+
+	if counts := app.countPRs(); counts.IncomingBlocked != 1 || counts.OutgoingBlocked != 0 {
+		t.Errorf("counts = %+v, want the filtered PRs excluded", counts)
+	}
+
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("test app should use MockSystray")
+	}
+	app.addFilteredPRs(context.Background())
+	app.addFiltersMenu()
+	want := []string{"🔕 Filtered (2)", "---", "Filters (2)"}
+	if !slices.Equal(mock.menuItems, want) {
+		t.Errorf("menu items = %q, want %q", mock.menuItems, want)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// focusFilterOut reports whether a PR should be hidden because focus mode is
+// limited to a different repository. An empty focusRepo means no focus.
+func focusFilterOut(repo, focusRepo string) bool {
+	return focusRepo != "" && repo != focusRepo
+}
+
+// focusedRepo returns the repository currently in focus, if any.
+func (app *App) focusedRepo() string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.focusRepo
+}
+
+// inFocus reports whether a repository passes the focus filter.
+func (app *App) inFocus(repo string) bool {
+	return !focusFilterOut(repo, app.focusedRepo())
+}
+
+// setFocusRepo enters focus mode for a repository, or leaves it when repo is empty.
+// Focus is transient and is not persisted to settings.
+func (app *App) setFocusRepo(repo string) {
+	app.mu.Lock()
+	app.focusRepo = repo
+	app.mu.Unlock()
+
+	if repo == "" {
+		slog.Info("[FOCUS] Focus mode cleared")
+	} else {
+		slog.Info("[FOCUS] Focusing on repository", "repo", repo)
+	}
+}
+
+// focusCandidates returns the sorted, de-duplicated repositories in the current queue.
+func (app *App) focusCandidates() []string {
+	app.mu.RLock()
+	repoSet := make(map[string]bool)
+	for i := range app.incoming {
+		repoSet[app.incoming[i].Repository] = true
+	}
+	for i := range app.outgoing {
+		repoSet[app.outgoing[i].Repository] = true
+	}
+	app.mu.RUnlock()
+
+	repos := make([]string, 0, len(repoSet))
+	for repo := range repoSet {
+		if repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// focusBannerTitle returns the menu title shown at the top while focus mode is active.
+func focusBannerTitle(repo string) string {
+	return msg("focus.banner", repo)
+}
+
+// addFocusBanner adds the "Focused" item that exits focus mode when clicked.
+func (app *App) addFocusBanner(ctx context.Context, repo string) {
+	item := app.systrayInterface.AddMenuItem(focusBannerTitle(repo), msg("focus.banner.tooltip"))
+	item.Click(func() {
+		app.setFocusRepo("")
+		app.rebuildMenu(ctx)
+	})
+	app.systrayInterface.AddSeparator()
+}
+
+// addFocusMenu adds the "Focus on repo…" submenu listing repositories in the current queue.
+func (app *App) addFocusMenu(ctx context.Context) {
+	focusMenu := app.systrayInterface.AddMenuItem(msg("focus.menu"), msg("focus.menu.tooltip"))
+
+	repos := app.focusCandidates()
+	if len(repos) == 0 {
+		noRepos := focusMenu.AddSubMenuItem(msg("focus.none"), "")
+		noRepos.Disable()
+		return
+	}
+
+	current := app.focusedRepo()
+	for _, r := range repos {
+		repo := r // Capture for closure
+		text := repo
+		if repo == current {
+			text = "✓ " + repo
+		}
+		item := focusMenu.AddSubMenuItem(text, "")
+		item.Click(func() {
+			if app.focusedRepo() == repo {
+				app.setFocusRepo("")
+			} else {
+				app.setFocusRepo(repo)
+			}
+			app.rebuildMenu(ctx)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newFocusTestApp(startedAgo time.Duration) *App {
+	return &App{
+		mu:                 sync.RWMutex{},
+		stateManager:       NewPRStateManager(time.Now().Add(-startedAgo)),
+		hiddenOrgs:         make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
+		previousBlockedPRs: make(map[string]bool),
+		blockedPRTimes:     make(map[string]time.Time),
+		systrayInterface:   &MockSystray{},
+		menuInitialized:    true,
+		startTime:          time.Now().Add(-startedAgo),
+	}
+}
+
+func TestFocusRepoCountFiltering(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	app.incoming = []PR{
+		{Repository: "org/release", Number: 1, URL: "https://github.com/org/release/pull/1", NeedsReview: true, UpdatedAt: now},
+		{Repository: "org/other", Number: 2, URL: "https://github.com/org/other/pull/2", NeedsReview: true, UpdatedAt: now},
+		{Repository: "org/other", Number: 3, URL: "https://github.com/org/other/pull/3", UpdatedAt: now},
+	}
+	app.outgoing = []PR{
+		{Repository: "org/release", Number: 4, URL: "https://github.com/org/release/pull/4", UpdatedAt: now},
+		{Repository: "org/other", Number: 5, URL: "https://github.com/org/other/pull/5", IsBlocked: true, UpdatedAt: now},
+	}
+
+	counts := app.countPRs()
+	if counts.IncomingTotal != 3 || counts.OutgoingBlocked != 1 {
+		t.Fatalf("unfocused counts = %+v, want 3 incoming and 1 outgoing blocked", counts)
+	}
+
+	app.setFocusRepo("org/release")
+	counts = app.countPRs()
+	want := PRCounts{IncomingTotal: 1, IncomingBlocked: 1, IncomingBlockedRepos: 1, OutgoingTotal: 1, OutgoingBlocked: 0}
+	if counts != want {
+		t.Errorf("focused counts = %+v, want %+v", counts, want)
+	}
+
+	titles := app.generateMenuTitles()
+	if !slices.Contains(titles, focusBannerTitle("org/release")) {
+		t.Errorf("expected focus banner in menu titles, got %v", titles)
+	}
+	for _, title := range titles {
+		if strings.Contains(title, "org/other #") {
+			t.Errorf("unexpected out-of-focus PR in menu: %q", title)
+		}
+	}
+
+	if got := app.focusCandidates(); !slices.Equal(got, []string{"org/other", "org/release"}) {
+		t.Errorf("focusCandidates() = %v", got)
+	}
+
+	app.setFocusRepo("")
+	if counts := app.countPRs(); counts.IncomingTotal != 3 {
+		t.Errorf("after clearing focus, IncomingTotal = %d, want 3", counts.IncomingTotal)
+	}
+}
+
+func TestFocusRepoSuppressesNotificationsOutsideFocus(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.setFocusRepo("org/release")
+
+	toNotify := []PR{
+		{Repository: "org/release", Number: 1, URL: "https://github.com/org/release/pull/1"},
+		{Repository: "org/other", Number: 2, URL: "https://github.com/org/other/pull/2"},
+	}
+	alerts := app.notifiablePRs(toNotify)
+	if len(alerts) != 1 || alerts[0].Repository != "org/release" {
+		t.Errorf("notifiablePRs() = %+v, want only org/release", alerts)
+	}
+}
+
+func TestFocusRepoNoBurstOnExit(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour) // Well past the grace period
+	now := time.Now()
+	other := PR{Repository: "org/other", Number: 2, URL: "https://github.com/org/other/pull/2", UpdatedAt: now}
+
+	// Baseline while focused: nothing is blocked
+	app.setFocusRepo("org/release")
+	app.incoming = []PR{other}
+	app.processNotifications(ctx)
+
+	// A PR outside the focus repo becomes blocked while filtered
+	other.NeedsReview = true
+	other.LastActivityAt = now
+	app.incoming = []PR{other}
+	app.processNotifications(ctx)
+
+	state, ok := app.stateManager.PRState(other.URL)
+	if !ok {
+		t.Fatal("expected out-of-focus PR to still be tracked by the state manager")
+	}
+	if !state.HasNotified {
+		t.Error("expected out-of-focus PR to be marked notified while filtered")
+	}
+
+	// Leaving focus mode must not report it as newly blocked
+	app.setFocusRepo("")
+	toNotify := app.stateManager.UpdatePRs(app.incoming, app.outgoing, app.hiddenOrgs, false)
+	if len(toNotify) != 0 {
+		t.Errorf("expected no notifications after leaving focus mode, got %d", len(toNotify))
+	}
+}
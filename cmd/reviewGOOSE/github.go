@@ -9,10 +9,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/codeGROOVE-dev/goose/pkg/ghref"
 	"github.com/codeGROOVE-dev/retry"
 	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
 	"github.com/google/go-github/v57/github"
@@ -21,14 +23,7 @@ import (
 
 // extractOrgFromRepo extracts the organization name from a repository path like "org/repo".
 func extractOrgFromRepo(repo string) string {
-	idx := strings.Index(repo, "/")
-	if idx > 0 {
-		return repo[:idx]
-	}
-	if idx == 0 {
-		return "" // Invalid: starts with "/"
-	}
-	return repo // No slash: return as-is (single segment or empty)
+	return ghref.Org(repo)
 }
 
 // initClients initializes GitHub and Turn API clients.
@@ -42,7 +37,11 @@ func (app *App) initClients(ctx context.Context) error {
 		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
-	app.client = github.NewClient(tc)
+	app.countGitHubCalls(tc)
+	app.client = newGitHubClient(tc, app.githubAPIURL)
+	if app.githubAPIURL != nil {
+		slog.Info("Using custom GitHub API URL", "url", app.githubAPIURL.String())
+	}
 
 	// Check for custom turn server hostname (for self-hosting)
 	// Set TURNSERVER=disabled to run without Turn API
@@ -53,7 +52,12 @@ func (app *App) initClients(ctx context.Context) error {
 		var turnClient *turn.Client
 		if turnServer != "" {
 			slog.Info("Using custom turn server", "hostname", turnServer)
-			turnClient, err = turn.NewClient("https://" + turnServer)
+			var turnURL string
+			turnURL, err = turnServerURL(turnServer)
+			if err != nil {
+				return err
+			}
+			turnClient, err = turn.NewClient(turnURL)
 		} else {
 			turnClient, err = turn.NewDefaultClient()
 		}
@@ -90,22 +94,11 @@ func (app *App) initSprinklerOrgs(ctx context.Context) error {
 		slog.Debug("[SPRINKLER] Sprinkler disabled, skipping org initialization")
 		return nil
 	}
+	return app.syncSprinklerOrgs(ctx)
+}
 
-	// Get current user
-	user := ""
-	if app.currentUser != nil {
-		user = app.currentUser.GetLogin()
-	}
-	if app.targetUser != "" {
-		user = app.targetUser
-	}
-	if user == "" {
-		return errors.New("no user configured")
-	}
-
-	slog.Info("[SPRINKLER] Fetching user's organizations", "user", user)
-
-	// Fetch all orgs the user is a member of with retry
+// fetchUserOrgs lists every organization user is a member of, retrying each page.
+func (app *App) fetchUserOrgs(ctx context.Context, user string) ([]string, error) {
 	opts := &github.ListOptions{PerPage: 100}
 	var orgs []string
 
@@ -134,11 +127,7 @@ func (app *App) initSprinklerOrgs(ctx context.Context) error {
 			retry.Context(ctx),
 		)
 		if err != nil {
-			// Gracefully degrade - continue without sprinkler if org fetch fails
-			slog.Warn("[SPRINKLER] Failed to fetch organizations after retries, sprinkler will not start",
-				"error", err,
-				"maxRetries", maxRetries)
-			return nil // Return nil to avoid blocking startup
+			return nil, err
 		}
 
 		for _, o := range page {
@@ -152,21 +141,7 @@ func (app *App) initSprinklerOrgs(ctx context.Context) error {
 		}
 		opts.Page = resp.NextPage
 	}
-
-	slog.Info("[SPRINKLER] Discovered user organizations",
-		"user", user,
-		"orgs", orgs,
-		"count", len(orgs))
-
-	// Update sprinkler with all orgs at once
-	if len(orgs) > 0 {
-		app.sprinklerMonitor.updateOrgs(orgs)
-		if err := app.sprinklerMonitor.start(ctx); err != nil {
-			return fmt.Errorf("start sprinkler: %w", err)
-		}
-	}
-
-	return nil
+	return orgs, nil
 }
 
 // token retrieves the GitHub token from GITHUB_TOKEN env var or gh CLI.
@@ -292,24 +267,36 @@ func (*App) token(ctx context.Context) (string, error) {
 }
 
 // executeGitHubQuery executes a single GitHub search query with retry logic.
-func (app *App) executeGitHubQuery(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, error) {
-	var result *github.IssuesSearchResult
+// notModified reports that the cached result was revalidated with a 304.
+func (app *App) executeGitHubQuery(
+	ctx context.Context, query string, opts *github.SearchOptions,
+) (result *github.IssuesSearchResult, notModified bool, err error) {
 	var resp *github.Response
 
-	// Use circuit breaker if available
-	if app.githubCircuit != nil {
+	// Use circuit breaker if available. A GitHub-wide incident isn't a reason to trip it.
+	// Nor is a search GitHub refuses outright.
+	if app.githubCircuit != nil && app.activeGitHubIncident() == nil {
+		var unavailable error
 		err := app.githubCircuit.call(func() error {
-			return app.executeGitHubQueryInternal(ctx, query, opts, &result, &resp)
+			err := app.executeGitHubQueryInternal(ctx, query, opts, &result, &resp, &notModified)
+			if asSearchUnavailable(err) != nil {
+				unavailable = err
+				return nil
+			}
+			return err
 		})
+		if unavailable != nil {
+			return nil, false, unavailable
+		}
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		return result, nil
+		return result, notModified, nil
 	}
 
 	// Fallback to direct execution
-	err := app.executeGitHubQueryInternal(ctx, query, opts, &result, &resp)
-	return result, err
+	err = app.executeGitHubQueryInternal(ctx, query, opts, &result, &resp, &notModified)
+	return result, notModified, err
 }
 
 func (app *App) executeGitHubQueryInternal(
@@ -318,6 +305,7 @@ func (app *App) executeGitHubQueryInternal(
 	opts *github.SearchOptions,
 	result **github.IssuesSearchResult,
 	resp **github.Response,
+	notModified *bool,
 ) error {
 	return retry.Do(func() error {
 		// Create timeout context for GitHub API call
@@ -325,7 +313,7 @@ func (app *App) executeGitHubQueryInternal(
 		defer cancel()
 
 		var retryErr error
-		*result, *resp, retryErr = app.client.Search.Issues(githubCtx, query, opts)
+		*result, *resp, *notModified, retryErr = app.searchIssues(githubCtx, query, opts)
 		if retryErr != nil {
 			// Enhanced error handling with specific cases
 			if *resp != nil {
@@ -333,8 +321,13 @@ func (app *App) executeGitHubQueryInternal(
 					httpStatusUnauthorized  = 401
 					httpStatusForbidden     = 403
 					httpStatusUnprocessable = 422
+					httpStatusGone          = 410
+					httpStatusLegalBlock    = 451
 				)
-				switch (*resp).StatusCode {
+				switch code := (*resp).StatusCode; code {
+				case httpStatusGone, httpStatusLegalBlock:
+					slog.Error("GitHub API refuses search (not retrying)", "statusCode", code, "query", query)
+					return retry.Unrecoverable(&SearchUnavailableError{Query: query, StatusCode: code, Err: retryErr})
 				case httpStatusForbidden:
 					if (*resp).Header.Get("X-Ratelimit-Remaining") == "0" {
 						resetTime := (*resp).Header.Get("X-Ratelimit-Reset")
@@ -360,7 +353,7 @@ func (app *App) executeGitHubQueryInternal(
 		}
 		return nil
 	},
-		retry.Attempts(maxRetries),
+		retry.Attempts(app.githubRetryAttempts()),
 		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)), // Add jitter for better backoff distribution
 		retry.MaxDelay(maxRetryDelay),
 		retry.OnRetry(func(n uint, err error) {
@@ -372,11 +365,18 @@ func (app *App) executeGitHubQueryInternal(
 
 // prResult holds the result of a Turn API query for a PR.
 type prResult struct {
-	err          error
-	turnData     *turn.CheckResponse
-	url          string
-	isOwner      bool
-	wasFromCache bool
+	err              error
+	turnData         *turn.CheckResponse
+	url              string
+	decision         cacheDecision // How the Turn cache was used
+	elapsed          time.Duration // Wall time of the turnData call
+	isOwner          bool
+	actionUser       string          // Repo and team mode: the watched user whose next action counts; empty means the querying user
+	blockedOn        []string        // Repo and team mode: every watched user with a next action
+	requestedBy      reviewRequest   // Who requested my review, when found for a blocked incoming PR
+	pullDetails      pullDetails     // Base branch, when looked up for a blocked PR
+	question         pendingQuestion // The review comment to answer, when looked up for a blocked respond PR
+	awaitingApproval bool            // Workflow runs need maintainer approval and Turn reported no action
 }
 
 // fetchPRsInternal fetches PRs and Turn data synchronously for simplicity.
@@ -384,6 +384,10 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 	// Update search attempt time for rate limiting
 	app.mu.Lock()
 	app.lastSearchAttempt = time.Now()
+	app.updateGeneration++
+	// A forced refresh still revalidates with ETags but re-checks every PR with Turn
+	forced := app.forceNextRefresh
+	app.forceNextRefresh = false
 	app.mu.Unlock()
 
 	// Check if we have a client
@@ -392,10 +396,7 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 	}
 
 	// Use targetUser if specified, otherwise use authenticated user
-	user := ""
-	if app.currentUser != nil {
-		user = app.currentUser.GetLogin()
-	}
+	user := app.login()
 	if app.targetUser != "" {
 		user = app.targetUser
 	}
@@ -412,53 +413,60 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 
 	searchStart := time.Now()
 
-	// Run both queries in parallel
+	// Run all queries in parallel
 	type qResult struct {
-		err    error
-		query  string
-		issues []*github.Issue
+		err         error
+		query       string
+		issues      []*github.Issue
+		notModified bool
 	}
 
-	results := make(chan qResult, 2)
-
-	// Query 1: PRs involving the user
-	go func() {
-		q := fmt.Sprintf("is:open is:pr involves:%s archived:false", user)
-		slog.Debug("[GITHUB] Searching for PRs", "query", q)
-
-		res, err := app.executeGitHubQuery(ctx, q, opts)
-		if err != nil {
-			results <- qResult{err: err, query: q}
-		} else {
-			results <- qResult{issues: res.Issues, query: q}
-		}
-	}()
-
-	// Query 2: PRs in user-owned repos with no reviewers
-	go func() {
-		q := fmt.Sprintf("is:open is:pr user:%s review:none archived:false", user)
-		slog.Debug("[GITHUB] Searching for PRs", "query", q)
+	repos, _ := app.repoModeState()
+	queries := searchQueries(user, repos)
+	shared := len(repos) > 0
+	// In team mode each teammate's searches run; one teammate's failure doesn't fail the rest
+	var queryMember map[string]string
+	if app.teamMode() {
+		app.mu.RLock()
+		team := app.team
+		app.mu.RUnlock()
+		queries, queryMember = teamSearchQueries(team)
+		shared = true
+	}
+	results := make(chan qResult, len(queries))
+	for _, q := range queries {
+		go func() {
+			slog.Debug("[GITHUB] Searching for PRs", "query", q)
 
-		res, err := app.executeGitHubQuery(ctx, q, opts)
-		if err != nil {
-			results <- qResult{err: err, query: q}
-		} else {
-			results <- qResult{issues: res.Issues, query: q}
-		}
-	}()
+			res, notModified, err := app.executeBoundedQuery(ctx, q, opts)
+			if err != nil {
+				results <- qResult{err: err, query: q}
+			} else {
+				results <- qResult{issues: res.Issues, query: q, notModified: notModified}
+			}
+		}()
+	}
 
-	// Collect results from both queries
+	// Collect results from all queries
 	var issues []*github.Issue
 	seen := make(map[string]bool)
 	var errs []error
+	var failed []string
+	failedMembers := make(map[string]bool)
+	unchanged := true
 
-	for range 2 {
+	for range queries {
 		r := <-results
 		if r.err != nil {
 			slog.Error("[GITHUB] Query failed", "query", r.query, "error", r.err)
 			errs = append(errs, r.err)
+			failed = append(failed, r.query)
+			if login, ok := queryMember[r.query]; ok {
+				failedMembers[login] = true
+			}
 			continue
 		}
+		unchanged = unchanged && r.notModified
 		slog.Debug("[GITHUB] Query completed", "query", r.query, "prCount", len(r.issues))
 
 		// Deduplicate PRs based on URL
@@ -470,12 +478,27 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 			}
 		}
 	}
-	slog.Info("[GITHUB] Both searches completed", "duration", time.Since(searchStart), "uniquePRs", len(issues))
+	slog.Info("[GITHUB] Searches completed", "queries", len(queries), "duration", time.Since(searchStart), "uniquePRs", len(issues))
 
-	// If both queries failed, return an error
-	if len(errs) == 2 {
+	// If every query failed, return an error
+	if len(errs) == len(queries) {
 		return nil, nil, fmt.Errorf("all GitHub queries failed: %v", errs)
 	}
+	// If only some failed, return what the others found along with a PartialError
+	// In team mode they are marked on the teammates' lines instead
+	var partial error
+	if queryMember != nil {
+		app.setTeamFailures(failedMembers)
+	} else if len(errs) > 0 {
+		partial = &PartialError{Queries: failed, Errs: errs, Total: len(queries)}
+	}
+
+	// In repo and team mode the limit spans every query, keeping the most recently updated PRs
+	if shared {
+		slices.SortStableFunc(issues, func(a, b *github.Issue) int {
+			return b.GetUpdatedAt().Time.Compare(a.GetUpdatedAt().Time)
+		})
+	}
 
 	// Limit PRs for performance
 	if len(issues) > maxPRsToProcess {
@@ -483,6 +506,15 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 		issues = issues[:maxPRsToProcess]
 	}
 
+	// When every query came back 304, PRs whose UpdatedAt is unchanged keep their
+	// previous Turn data instead of being re-enriched
+	var previous map[string]PR
+	if unchanged && len(errs) == 0 && !forced {
+		previous = app.reusablePRs()
+	}
+	turnIssues := make([]*github.Issue, 0, len(issues))
+	reused := 0
+
 	// Process GitHub results immediately
 	for _, issue := range issues {
 		if !issue.IsPullRequest() {
@@ -494,10 +526,7 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 		org := extractOrgFromRepo(repo)
 		if org != "" {
 			app.mu.Lock()
-			if !app.seenOrgs[org] {
-				slog.Info("[ORG] Discovered new organization", "org", org)
-			}
-			app.seenOrgs[org] = true
+			app.noteOrgSeen(org, time.Now())
 			app.mu.Unlock()
 		}
 
@@ -510,11 +539,23 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 			CreatedAt:  issue.GetCreatedAt().Time,
 			UpdatedAt:  issue.GetUpdatedAt().Time,
 			IsDraft:    issue.GetDraft(),
+			Comments:   issue.GetComments(),
+		}
+		for _, label := range issue.Labels {
+			pr.Labels = append(pr.Labels, label.GetName())
+		}
+		if prev, found := previous[pr.URL]; canReuseTurnData(prev, found, pr) {
+			prev.Labels, prev.Comments = pr.Labels, pr.Comments
+			pr = prev
+			reused++
+		} else {
+			turnIssues = append(turnIssues, issue)
 		}
 
 		// Categorize as incoming or outgoing
-		// When viewing another user's PRs, we're looking at it from their perspective
-		if issue.GetUser().GetLogin() == user {
+		// When viewing another user's PRs, we're looking at it from their perspective.
+		// Repo and team mode have no "my PRs": everything is incoming.
+		if !shared && issue.GetUser().GetLogin() == user {
 			slog.Info("[GITHUB] Found outgoing PR", "repo", repo, "number", pr.Number, "author", pr.Author, "url", pr.URL)
 			outgoing = append(outgoing, pr)
 		} else {
@@ -523,20 +564,142 @@ func (app *App) fetchPRsInternal(ctx context.Context) (incoming []PR, outgoing [
 		}
 	}
 
+	// Nothing to enrich: skip the Turn lookups and their bookkeeping entirely
+	if len(incoming) == 0 && len(outgoing) == 0 {
+		slog.Info("[GITHUB] No open PRs or review requests, skipping Turn lookups", "queries", len(queries))
+		return nil, nil, partial
+	}
+
 	// Only log summary, not individual PRs
 	slog.Info("[GITHUB] GitHub PR summary", "incoming", len(incoming), "outgoing", len(outgoing))
+	if reused > 0 {
+		slog.Info("[GITHUB] Search results unchanged, reusing Turn data", "reused", reused, "rechecking", len(turnIssues))
+	}
+
+	// Don't start Turn lookups if the update cycle has already been abandoned
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 
 	// Fetch Turn API data
-	// Always synchronous now for simplicity - Turn API calls are fast with caching
-	app.fetchTurnDataSync(ctx, issues, user, &incoming, &outgoing)
+	// Always synchronous now for simplicity - Turn API calls are fast with caching,
+	// except on the first load where the cold tail is left to a second wave
+	app.mu.RLock()
+	firstLoad := !app.initialLoadComplete
+	app.mu.RUnlock()
+	if firstLoad {
+		app.fetchTurnDataInWaves(ctx, turnIssues, user, &incoming, &outgoing)
+	} else {
+		app.fetchTurnDataSync(ctx, turnIssues, user, &incoming, &outgoing)
+	}
+	app.markPendingReviews(ctx, incoming, user)
 
-	return incoming, outgoing, nil
+	// Drop PRs we've lost access to so they don't linger in menus, counts, or state
+	if app.quarantine != nil {
+		incoming = app.quarantine.filter(incoming)
+		outgoing = app.quarantine.filter(outgoing)
+	}
+
+	if app.stateManager != nil {
+		app.stateManager.TrackStuckTests(incoming, outgoing, app.stuckTestsThreshold)
+	}
+
+	return incoming, outgoing, partial
+}
+
+// applyTurnResult copies a successful Turn result onto pr. Every path that patches Turn
+// data into the lists goes through it, so they can't drift apart.
+func applyTurnResult(pr *PR, result *prResult, user string, appliedAt time.Time) {
+	// Check if user needs to review and get action reason
+	needsReview := false
+	isBlocked := false
+	actionReason := ""
+	actionKind := actionNone
+	var actionSince time.Time
+	actor := user
+	if result.actionUser != "" {
+		actor = result.actionUser
+	}
+	if action, exists := result.turnData.Analysis.NextAction[actor]; exists {
+		needsReview = true
+		isBlocked = action.Critical // Only critical actions are blocking
+		actionReason = action.Reason
+		actionKind = ActionKind(action.Kind)
+		actionSince = action.Since
+	} else if result.awaitingApproval {
+		needsReview = true
+		isBlocked = true
+		actionReason = workflowApprovalReason
+		actionKind = actionApproveWorkflows
+	}
+
+	// Once I've approved, an incoming PR is only waiting on other reviewers
+	myReview := ""
+	if !result.isOwner {
+		myReview = myReviewState(result.turnData, user)
+	}
+
+	// On my own PRs, note who else owes an action so I know whom to nudge
+	var waiting []pendingUser
+	if result.isOwner {
+		waiting = pendingUsers(result.turnData.Analysis.NextAction, user)
+	}
+	if myReview == reviewApproved {
+		if needsReview {
+			slog.Debug("[REVIEW] Already approved, demoting PR", "url", result.url, "action", actionKind)
+		}
+		needsReview = false
+		isBlocked = false
+		actionKind = actionNone
+		actionReason = waitingOnOthersReason
+	}
+
+	pr.NeedsReview = needsReview
+	pr.IsBlocked = isBlocked
+	pr.ActionReason = actionReason
+	pr.ActionKind = actionKind
+	pr.ActionSince = actionSince
+	pr.TestState = result.turnData.PullRequest.TestState
+	pr.WorkflowState = result.turnData.Analysis.WorkflowState
+	pr.Size = result.turnData.Analysis.Size
+	pr.FailingCheck = firstFailingCheck(result.turnData)
+	pr.MyReviewState = myReview
+	pr.AuthorBot = result.turnData.PullRequest.AuthorBot
+	pr.LastActivityAt = result.turnData.Analysis.LastActivity.Timestamp
+	pr.LastActivityKind = result.turnData.Analysis.LastActivity.Kind
+	pr.LastActivityActor = result.turnData.Analysis.LastActivity.Actor
+	pr.WaitingOn, pr.WaitingOnKind, pr.WaitingSince = "", "", time.Time{}
+	pr.WaitingOnCount = len(waiting)
+	if len(waiting) > 0 {
+		pr.WaitingOn = waiting[0].login
+		pr.WaitingOnKind = waiting[0].kind
+		pr.WaitingSince = waiting[0].since
+	}
+	pr.RequestedBy = result.requestedBy.login
+	pr.RequestedAt = result.requestedBy.at
+	pr.RequestedAuto = result.requestedBy.auto
+	pr.BaseBranch = result.pullDetails.baseBranch
+	pr.PendingQuestion, pr.QuestionBy, pr.QuestionURL = "", "", ""
+	if q := result.question; q.id != 0 {
+		pr.PendingQuestion, pr.QuestionBy, pr.QuestionURL = q.excerpt, q.author, questionAnchor(pr.URL, q.id)
+	}
+	pr.IsNonDefaultBase = result.pullDetails.nonDefaultBase()
+	pr.BlockedOn = result.blockedOn
+	pr.TurnDataAppliedAt = appliedAt
+	applyPendingReview(pr)
 }
 
 // fetchTurnDataSync fetches Turn API data synchronously and updates PRs directly.
 func (app *App) fetchTurnDataSync(ctx context.Context, issues []*github.Issue, user string, incoming *[]PR, outgoing *[]PR) {
 	turnStart := time.Now()
+	results, _ := collectTurnResults(app.startTurnLookups(ctx, issues, user), nil)
+	applyTurnResults(app.recordTurnResults(results, user, turnStart), user, *incoming, *outgoing)
+}
 
+// startTurnLookups looks up Turn data for issues in parallel. Results arrive on the
+// returned channel, which is closed once every lookup is done; it's buffered, so
+// lookups finish even if nobody is reading.
+func (app *App) startTurnLookups(ctx context.Context, issues []*github.Issue, user string) <-chan prResult {
 	// Create a channel for results
 	results := make(chan prResult, len(issues))
 
@@ -546,111 +709,167 @@ func (app *App) fetchTurnDataSync(ctx context.Context, issues []*github.Issue, u
 	// Create semaphore to limit concurrent Turn API calls
 	sem := make(chan struct{}, maxConcurrentTurnAPICalls)
 
+	// In repo and team mode nothing is mine, and blocked means blocked on a watched user
+	shared, actionUsers := app.sharedQueue()
+	owns := func(issue *github.Issue) bool {
+		return !shared && issue.GetUser().GetLogin() == user
+	}
+
 	// Process PRs in parallel with concurrency limit
 	for _, issue := range issues {
 		if !issue.IsPullRequest() {
 			continue
 		}
+		if app.quarantine != nil && app.quarantine.shouldSkip(issue.GetHTMLURL()) {
+			continue
+		}
 
 		wg.Go(func() {
-			// Acquire semaphore
-			sem <- struct{}{}
-			defer func() { <-sem }()
+			// Acquire semaphore, giving up if the update cycle is abandoned
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results <- prResult{
+					url:     issue.GetHTMLURL(),
+					err:     ctx.Err(),
+					isOwner: owns(issue),
+				}
+				return
+			}
 
 			url := issue.GetHTMLURL()
 			updatedAt := issue.GetUpdatedAt().Time
 
 			// Call turnData - it now has proper exponential backoff with jitter
-			turnData, wasFromCache, err := app.turnData(ctx, url, updatedAt)
+			callStart := time.Now()
+			turnData, decision, err := app.turnData(ctx, url, updatedAt)
+			elapsed := time.Since(callStart)
+			isOwner := owns(issue)
+			var actionUser string
+			var blocked []string
+			actor := user
+			if err == nil && turnData != nil {
+				if actionUser = repoModeActor(turnData.Analysis.NextAction, actionUsers); actionUser != "" {
+					actor = actionUser
+				}
+				blocked = blockedOn(turnData.Analysis.NextAction, actionUsers)
+			}
+
+			// Turn doesn't surface workflow runs awaiting approval, so check incoming PRs it has no action for
+			awaitingApproval := false
+			if err == nil && turnData != nil && !isOwner {
+				if _, hasAction := turnData.Analysis.NextAction[actor]; !hasAction {
+					repo := strings.TrimPrefix(issue.GetRepositoryURL(), "https://api.github.com/repos/")
+					awaitingApproval = app.workflowsAwaitingApproval(ctx, repo, url, turnData)
+				}
+			}
+			var requestedBy reviewRequest
+			var details pullDetails
+			var question pendingQuestion
+			if err == nil {
+				repo := strings.TrimPrefix(issue.GetRepositoryURL(), "https://api.github.com/repos/")
+				requestedBy, _ = app.blockedReviewRequester(ctx, turnData, isOwner, repo, issue.GetNumber(), url, updatedAt, actor)
+				details = app.blockedPullDetails(ctx, turnData, repo, issue.GetNumber(), url, updatedAt, actor)
+				question = app.blockedQuestion(ctx, turnData, isOwner, repo, issue.GetNumber(), url, updatedAt, actor)
+			}
 
 			results <- prResult{
-				url:          issue.GetHTMLURL(),
-				turnData:     turnData,
-				err:          err,
-				isOwner:      issue.GetUser().GetLogin() == user,
-				wasFromCache: wasFromCache,
+				url:              issue.GetHTMLURL(),
+				turnData:         turnData,
+				err:              err,
+				isOwner:          isOwner,
+				decision:         decision,
+				elapsed:          elapsed,
+				actionUser:       actionUser,
+				blockedOn:        blocked,
+				requestedBy:      requestedBy,
+				pullDetails:      details,
+				question:         question,
+				awaitingApproval: awaitingApproval,
 			}
 		})
 	}
 
-	// Close the results channel when all goroutines are done
-	go func() {
+	// Close the results channel when all goroutines are done. Shutdown waits for the
+	// closer, and so for the lookups; once it has begun they see the cancelled context
+	// and end promptly, so they're waited for here instead.
+	closeResults := func() {
 		wg.Wait()
 		close(results)
-	}()
+	}
+	if !app.goTracked("turn lookups", closeResults) {
+		closeResults()
+	}
+	return results
+}
 
-	// Collect results and update PRs directly
+// collectTurnResults reads Turn results until the lookups are done, or budget fires.
+// It reports whether every result was read; a nil budget waits for all of them.
+func collectTurnResults(results <-chan prResult, budget <-chan time.Time) ([]prResult, bool) {
+	var collected []prResult
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return collected, true
+			}
+			collected = append(collected, result)
+		case <-budget:
+			return collected, false
+		}
+	}
+}
+
+// recordTurnResults updates the quarantine, backfill, and timing bookkeeping for a
+// batch of Turn results, and returns the ones with data to apply.
+func (app *App) recordTurnResults(results []prResult, user string, turnStart time.Time) []prResult {
+	var usable []prResult
 	turnSuccesses := 0
 	turnFailures := 0
 	actualAPICalls := 0
 	cacheHits := 0
+	var timings []turnTiming
+
+	for _, result := range results {
+		if result.decision != "" || result.err != nil {
+			timings = append(timings, turnTiming{
+				url: result.url, decision: result.decision, elapsed: result.elapsed, failed: result.err != nil,
+			})
+		}
+		if app.quarantine != nil {
+			if isPermanentPRError(result.err) {
+				app.quarantinePR(result.url, result.err)
+			} else if result.err == nil {
+				app.quarantine.recordSuccess(result.url)
+			}
+		}
 
-	for result := range results {
 		if result.err == nil && result.turnData != nil && result.turnData.Analysis.NextAction != nil {
 			turnSuccesses++
-			if result.wasFromCache {
+			if result.decision.hit() {
 				cacheHits++
 			} else {
 				actualAPICalls++
-			}
-
-			// Check if user needs to review and get action reason
-			needsReview := false
-			isBlocked := false
-			actionReason := ""
-			actionKind := ""
-			testState := result.turnData.PullRequest.TestState
-			workflowState := result.turnData.Analysis.WorkflowState
-			if action, exists := result.turnData.Analysis.NextAction[user]; exists {
-				needsReview = true
-				isBlocked = action.Critical // Only critical actions are blocking
-				actionReason = action.Reason
-				actionKind = string(action.Kind)
 				// Only log fresh API calls
-				if !result.wasFromCache {
+				if action, exists := result.turnData.Analysis.NextAction[user]; exists {
 					slog.Debug("[TURN] NextAction", "url", result.url, "reason", action.Reason, "kind", action.Kind, "critical", action.Critical)
 				}
 			}
-
-			// Update the PR in the slices directly
-			authorBot := result.turnData.PullRequest.AuthorBot
-			lastActivityAt := result.turnData.Analysis.LastActivity.Timestamp
-			if result.isOwner {
-				for i := range *outgoing {
-					if (*outgoing)[i].URL != result.url {
-						continue
-					}
-					(*outgoing)[i].NeedsReview = needsReview
-					(*outgoing)[i].IsBlocked = isBlocked
-					(*outgoing)[i].ActionReason = actionReason
-					(*outgoing)[i].ActionKind = actionKind
-					(*outgoing)[i].TestState = testState
-					(*outgoing)[i].WorkflowState = workflowState
-					(*outgoing)[i].AuthorBot = authorBot
-					(*outgoing)[i].LastActivityAt = lastActivityAt
-					break
-				}
-			} else {
-				for i := range *incoming {
-					if (*incoming)[i].URL != result.url {
-						continue
-					}
-					(*incoming)[i].NeedsReview = needsReview
-					(*incoming)[i].IsBlocked = isBlocked
-					(*incoming)[i].ActionReason = actionReason
-					(*incoming)[i].ActionKind = actionKind
-					(*incoming)[i].TestState = testState
-					(*incoming)[i].WorkflowState = workflowState
-					(*incoming)[i].AuthorBot = authorBot
-					(*incoming)[i].LastActivityAt = lastActivityAt
-					break
-				}
+			if app.turnBackfill != nil {
+				app.turnBackfill.recordSuccess(result.url)
 			}
+			usable = append(usable, result)
 		} else if result.err != nil {
 			turnFailures++
+			if app.turnBackfill != nil && !isPermanentPRError(result.err) {
+				app.turnBackfill.recordFailure(result.url)
+			}
 		}
 	}
 
+	app.recordTurnTimings(timings)
+
 	// Only log if there were actual API calls or failures
 	if actualAPICalls > 0 || turnFailures > 0 {
 		slog.Info("[TURN] API queries completed",
@@ -664,4 +883,27 @@ func (app *App) fetchTurnDataSync(ctx context.Context, issues []*github.Issue, u
 			"cache_hits", cacheHits,
 			"duration", time.Since(turnStart))
 	}
+	return usable
+}
+
+// applyTurnResults copies Turn results onto the matching PRs and returns how many it found.
+// Each list is indexed once, so a cycle's patching is linear in the number of PRs.
+func applyTurnResults(results []prResult, user string, incoming, outgoing []PR) int {
+	if len(results) == 0 {
+		return 0
+	}
+	in, out := indexPRs(incoming), indexPRs(outgoing)
+	applied := 0
+	appliedAt := time.Now()
+	for i := range results {
+		ix := in
+		if results[i].isOwner {
+			ix = out
+		}
+		if pr := ix.get(results[i].url); pr != nil {
+			applyTurnResult(pr, &results[i], user, appliedAt)
+			applied++
+		}
+	}
+	return applied
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// githubStatusAPIURL reports GitHub-wide incidents. It needs no authentication.
+	githubStatusAPIURL   = "https://www.githubstatus.com/api/v2/status.json"
+	githubStatusTimeout  = 5 * time.Second
+	githubStatusCacheTTL = 5 * time.Minute
+	// outageRetryInterval spaces out fetches while GitHub reports an incident.
+	outageRetryInterval = 5 * time.Minute
+)
+
+// githubIncident is an active GitHub-wide incident from the status API.
+type githubIncident struct {
+	indicator   string // minor, major, or critical
+	description string // e.g. "Major Service Outage"
+}
+
+// githubStatusChecker queries the GitHub status API, caching the answer for githubStatusCacheTTL.
+// A failed check counts as "no incident" so goose falls back to its normal failure handling.
+type githubStatusChecker struct {
+	checkedAt time.Time
+	client    *http.Client
+	incident  *githubIncident
+	url       string
+	mu        sync.Mutex
+}
+
+func newGitHubStatusChecker(url string) *githubStatusChecker {
+	return &githubStatusChecker{url: url, client: &http.Client{Timeout: githubStatusTimeout}}
+}
+
+// activeIncident returns the current incident, or nil if GitHub reports none or can't be asked.
+func (c *githubStatusChecker) activeIncident(ctx context.Context, now time.Time) *githubIncident {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < githubStatusCacheTTL {
+		return c.incident
+	}
+	incident, err := c.fetch(ctx)
+	if err != nil {
+		slog.Debug("[STATUS] GitHub status check failed", "error", err)
+	}
+	c.checkedAt = now
+	c.incident = incident
+	return incident
+}
+
+func (c *githubStatusChecker) fetch(ctx context.Context) (*githubIncident, error) {
+	ctx, cancel := context.WithTimeout(ctx, githubStatusTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Response already read
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var body struct {
+		Status struct {
+			Indicator   string `json:"indicator"`
+			Description string `json:"description"`
+		} `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	switch body.Status.Indicator {
+	case "none":
+		return nil, nil //nolint:nilnil // No incident is not an error
+	case "":
+		return nil, errors.New("missing status indicator")
+	default:
+		return &githubIncident{indicator: body.Status.Indicator, description: body.Status.Description}, nil
+	}
+}
+
+// checkGitHubOutage consults the status API once failures cross the minor threshold,
+// recording whether an incident explains them. It returns the active incident, if any,
+// and whether that changed since the last check.
+func (app *App) checkGitHubOutage(ctx context.Context, failureCount int) (incident *githubIncident, changed bool) {
+	if app.githubStatus == nil || failureCount < minorFailureThreshold {
+		return nil, false
+	}
+	incident = app.githubStatus.activeIncident(ctx, time.Now())
+
+	app.mu.Lock()
+	previous := app.githubIncident
+	app.githubIncident = incident
+	app.mu.Unlock()
+
+	switch {
+	case incident != nil && previous == nil:
+		slog.Warn("[STATUS] GitHub reports an incident, suppressing failure escalation",
+			"indicator", incident.indicator, "description", incident.description, "failures", failureCount)
+	case incident == nil && previous != nil:
+		slog.Info("[STATUS] GitHub incident cleared, resuming normal failure handling")
+	default:
+	}
+	return incident, (incident == nil) != (previous == nil)
+}
+
+// activeGitHubIncident returns the incident recorded by the last status check, if any.
+func (app *App) activeGitHubIncident() *githubIncident {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.githubIncident
+}
+
+// waitingOutOutage reports whether this update should be skipped because GitHub has an
+// active incident and the last attempt was less than outageRetryInterval ago.
+func (app *App) waitingOutOutage(now time.Time) bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.githubIncident == nil || app.forceNextRefresh {
+		return false
+	}
+	return now.Sub(app.lastSearchAttempt) < outageRetryInterval
+}
+
+// githubRetryAttempts is how many times a search is tried per update. During an incident
+// each update makes a single attempt instead of escalating through retries.
+func (app *App) githubRetryAttempts() uint {
+	if app.activeGitHubIncident() != nil {
+		return 1
+	}
+	return maxRetries
+}
+
+// label names the incident for the menu and tooltip.
+func (i *githubIncident) label() string {
+	if i.description != "" {
+		return i.description
+	}
+	return i.indicator
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newStatusServer serves body from a fake status API with the given HTTP status code.
+func newStatusServer(t *testing.T, code int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(code)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Errorf("write: %v", err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+const (
+	majorOutageStatus = `{"status":{"indicator":"major","description":"Major Service Outage"}}`
+	operationalStatus = `{"status":{"indicator":"none","description":"All Systems Operational"}}`
+)
+
+func TestGitHubStatusChecker(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		body string
+		want string // Expected incident label; empty for none
+	}{
+		{name: "incident", code: http.StatusOK, body: majorOutageStatus, want: "Major Service Outage"},
+		{name: "no incident", code: http.StatusOK, body: operationalStatus},
+		{name: "unavailable", code: http.StatusServiceUnavailable, body: "upstream connect error"},
+		{name: "garbage", code: http.StatusOK, body: "<html>"},
+		{name: "missing indicator", code: http.StatusOK, body: `{"status":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newStatusServer(t, tt.code, tt.body)
+			incident := newGitHubStatusChecker(srv.URL).activeIncident(context.Background(), time.Now())
+			got := ""
+			if incident != nil {
+				got = incident.label()
+			}
+			if got != tt.want {
+				t.Errorf("activeIncident() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubStatusCheckerCaches(t *testing.T) {
+	srv, calls := newStatusServer(t, http.StatusOK, majorOutageStatus)
+	c := newGitHubStatusChecker(srv.URL)
+	now := time.Now()
+
+	c.activeIncident(context.Background(), now)
+	c.activeIncident(context.Background(), now.Add(githubStatusCacheTTL-time.Second))
+	if got := calls.Load(); got != 1 {
+		t.Errorf("status API called %d times within the cache TTL, want 1", got)
+	}
+	c.activeIncident(context.Background(), now.Add(githubStatusCacheTTL))
+	if got := calls.Load(); got != 2 {
+		t.Errorf("status API called %d times after the TTL, want 2", got)
+	}
+}
+
+func TestGitHubOutageSuppressesEscalation(t *testing.T) {
+	srv, calls := newStatusServer(t, http.StatusOK, majorOutageStatus)
+	app := newFocusTestApp(time.Hour)
+	app.githubStatus = newGitHubStatusChecker(srv.URL)
+
+	// Below the minor threshold the status API isn't consulted.
+	if incident, _ := app.checkGitHubOutage(context.Background(), minorFailureThreshold-1); incident != nil || calls.Load() != 0 {
+		t.Fatalf("status checked too early: incident=%v calls=%d", incident, calls.Load())
+	}
+
+	incident, changed := app.checkGitHubOutage(context.Background(), minorFailureThreshold)
+	if incident == nil || !changed {
+		t.Fatalf("checkGitHubOutage() = %v, %v; want a new incident", incident, changed)
+	}
+	if got := app.githubRetryAttempts(); got != 1 {
+		t.Errorf("githubRetryAttempts() = %d during an incident, want 1", got)
+	}
+
+	app.lastSearchAttempt = time.Now()
+	if !app.waitingOutOutage(time.Now().Add(time.Minute)) {
+		t.Error("updates should back off during an incident")
+	}
+	if app.waitingOutOutage(time.Now().Add(outageRetryInterval)) {
+		t.Error("an update should be attempted once the outage interval has passed")
+	}
+
+	app.consecutiveFailures = majorFailureThreshold
+	app.lastFetchError = "502 Bad Gateway"
+	app.rebuildMenu(context.Background())
+	mock, _ := app.systrayInterface.(*MockSystray)
+	if !slices.Contains(mock.menuItems, "GitHub is having problems (Major Service Outage) — waiting it out") {
+		t.Errorf("expected the outage message in the menu, got %v", mock.menuItems)
+	}
+	if slices.Contains(mock.menuItems, msg("diag.report")) {
+		t.Error("escalation items should be suppressed during a GitHub incident")
+	}
+}
+
+func TestGitHubOutageClears(t *testing.T) {
+	srv, _ := newStatusServer(t, http.StatusOK, operationalStatus)
+	app := newFocusTestApp(time.Hour)
+	app.githubStatus = newGitHubStatusChecker(srv.URL)
+	app.githubIncident = &githubIncident{indicator: "major"}
+
+	incident, changed := app.checkGitHubOutage(context.Background(), majorFailureThreshold)
+	if incident != nil || !changed {
+		t.Fatalf("checkGitHubOutage() = %v, %v; want the incident cleared", incident, changed)
+	}
+	if got := app.githubRetryAttempts(); got != maxRetries {
+		t.Errorf("githubRetryAttempts() = %d after the incident, want %d", got, maxRetries)
+	}
+	if app.waitingOutOutage(time.Now()) {
+		t.Error("no backoff once the incident has cleared")
+	}
+}
+
+func TestGitHubStatusFailureChangesNothing(t *testing.T) {
+	srv, _ := newStatusServer(t, http.StatusInternalServerError, "")
+	app := newFocusTestApp(time.Hour)
+	app.githubStatus = newGitHubStatusChecker(srv.URL)
+
+	if incident, changed := app.checkGitHubOutage(context.Background(), majorFailureThreshold); incident != nil || changed {
+		t.Errorf("checkGitHubOutage() = %v, %v; a failed status check must not change anything", incident, changed)
+	}
+	if got := app.githubRetryAttempts(); got != maxRetries {
+		t.Errorf("githubRetryAttempts() = %d, want %d", got, maxRetries)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// startupGrace returns how long after startup notifications, sounds, and auto-opens
+// are held back, defaulting to startupGracePeriod.
+func (app *App) startupGrace() time.Duration {
+	if app.gracePeriod <= 0 {
+		return startupGracePeriod
+	}
+	return app.gracePeriod
+}
+
+// inGracePeriod reports whether the app is still within its startup grace period.
+func (app *App) inGracePeriod() bool {
+	return time.Since(app.startTime) < app.startupGrace()
+}
+
+// logGracePeriodEnd logs once when the startup grace period ends.
+func (app *App) logGracePeriodEnd(ctx context.Context) {
+	remaining := app.startupGrace() - time.Since(app.startTime)
+	app.goTracked("grace period", func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(remaining):
+			slog.Info("[NOTIFY] Startup grace period ended, notifications enabled", "grace_period", app.startupGrace())
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// countingBrowser records opened URLs without launching anything.
+type countingBrowser struct {
+	urls []string
+	mu   sync.Mutex
+}
+
+func (b *countingBrowser) Open(_ context.Context, rawURL, _ string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.urls = append(b.urls, rawURL)
+	return nil
+}
+
+func (b *countingBrowser) opened() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.urls)
+}
+
+func newGraceTestApp(startedAgo time.Duration) (*App, *messageNotifier) {
+	app := newFocusTestApp(startedAgo)
+	app.gracePeriod = time.Minute
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	return app, notifier
+}
+
+func TestSprinklerNotificationsWaitOutGracePeriod(t *testing.T) {
+	act := &turn.Action{Kind: "review", Reason: "needs review", Critical: true}
+	const url = "https://github.com/org/repo/pull/1"
+
+	tests := []struct {
+		name       string
+		startedAgo time.Duration
+		want       int
+	}{
+		{name: "early event suppressed", startedAgo: 15 * time.Second, want: 0},
+		{name: "late event notifies", startedAgo: 2 * time.Minute, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, notifier := newGraceTestApp(tt.startedAgo)
+			sm := &sprinklerMonitor{app: app}
+			sm.sendNotifications(context.Background(), url, "org/repo", 1, act)
+			if notes := waitForNotes(notifier, tt.want); len(notes) != tt.want {
+				t.Errorf("got %d notifications %s after startup, want %d", len(notes), tt.startedAgo, tt.want)
+			}
+			if _, tracked := app.stateManager.PRState(url); tracked != (tt.want == 1) {
+				t.Errorf("tracked = %v; suppressed events should leave the block for the poll", tracked)
+			}
+		})
+	}
+}
+
+func TestSprinklerNewPRDuringGracePeriod(t *testing.T) {
+	app, _ := newGraceTestApp(15 * time.Second)
+	sm := &sprinklerMonitor{app: app}
+	// With no GitHub client a triggered refresh would fail and count a failure
+	if !sm.handleNewPR(context.Background(), &turn.CheckResponse{}, "https://github.com/org/repo/pull/9", "org/repo", 9, "me", &turn.Action{Kind: "review"}) {
+		t.Fatal("an unknown PR should be handled")
+	}
+	time.Sleep(50 * time.Millisecond)
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.consecutiveFailures != 0 {
+		t.Error("handleNewPR triggered a refresh during the grace period")
+	}
+}
+
+func TestAutoOpenComposesDelays(t *testing.T) {
+	pr := &PR{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", NeedsReview: true}
+	tests := []struct {
+		name         string
+		startedAgo   time.Duration
+		gracePeriod  time.Duration
+		browserDelay time.Duration
+		want         int
+	}{
+		{name: "both elapsed", startedAgo: 3 * time.Minute, gracePeriod: time.Minute, browserDelay: 2 * time.Minute, want: 1},
+		{name: "grace period longer", startedAgo: 3 * time.Minute, gracePeriod: 5 * time.Minute, browserDelay: time.Minute, want: 0},
+		{name: "browser delay longer", startedAgo: 3 * time.Minute, gracePeriod: time.Minute, browserDelay: 5 * time.Minute, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newGraceTestApp(tt.startedAgo)
+			app.gracePeriod = tt.gracePeriod
+			app.browserRateLimiter = ratelimit.NewBrowserRateLimiter(tt.browserDelay, 5, defaultMaxBrowserOpensDay)
+			browser := &countingBrowser{}
+			app.browser = browser
+
+			app.tryAutoOpenPR(context.Background(), pr, true, app.startTime)
+			if got := browser.opened(); got != tt.want {
+				t.Errorf("opened %d PRs, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Some PRs will never merge or close, like one on an abandoned fork that a bot touches
+// monthly, so even the stale filter never drops it. "Hide forever" drops a PR from the
+// menu, the counts, notifications, and auto-open, in the same view filter as hidden
+// orgs. The hide lapses once the PR is confirmed closed, or after it hasn't appeared in
+// an update for hiddenPRExpiry.
+
+// hiddenPRsFileName persists hidden PRs in the cache directory.
+const hiddenPRsFileName = "hidden_prs.json"
+
+// hiddenPRExpiry is how long a hidden PR may go missing from updates before its hide is
+// dropped.
+const hiddenPRExpiry = 180 * 24 * time.Hour
+
+// hiddenPRSeenResolution is how stale a hidden PR's last-seen time may get before an
+// update refreshes and saves it, so updates don't rewrite the file every few minutes.
+const hiddenPRSeenResolution = 24 * time.Hour
+
+// hiddenPR is a PR I hid forever.
+type hiddenPR struct {
+	HiddenAt   time.Time `json:"hidden_at"`
+	LastSeen   time.Time `json:"last_seen"` // When an update last listed the PR
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+}
+
+// hiddenPRRow is a hidden PR with its URL, for the menu.
+type hiddenPRRow struct {
+	hiddenPR
+
+	URL string
+}
+
+// LoadHiddenPRs restores the hidden PRs saved at path and saves later changes there. A
+// missing or unreadable file starts empty.
+func (m *PRStateManager) LoadHiddenPRs(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.hiddenPRsPath = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("[STATE] Failed to read hidden PRs", "path", path, "error", err)
+		}
+		return
+	}
+	var hidden map[string]hiddenPR
+	if err := json.Unmarshal(data, &hidden); err != nil {
+		slog.Warn("[STATE] Ignoring unreadable hidden PRs", "path", path, "error", err)
+		return
+	}
+	maps.Copy(m.hiddenPRs, hidden)
+	if len(m.hiddenPRs) > 0 {
+		slog.Info("[STATE] Restored hidden PRs", "count", len(m.hiddenPRs))
+	}
+}
+
+// HidePR hides pr until it closes or is unhidden.
+func (m *PRStateManager) HidePR(pr *PR) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hiddenPRs[pr.URL]; ok {
+		return
+	}
+	now := m.now()
+	m.hiddenPRs[pr.URL] = hiddenPR{Repository: pr.Repository, Number: pr.Number, HiddenAt: now, LastSeen: now}
+	slog.Info("[STATE] PR hidden forever", "repo", pr.Repository, "number", pr.Number)
+	m.saveHiddenPRsLocked()
+}
+
+// UnhidePR removes the hide on a PR, if any.
+func (m *PRStateManager) UnhidePR(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hiddenPRs[url]; !ok {
+		return
+	}
+	delete(m.hiddenPRs, url)
+	slog.Info("[STATE] PR unhidden", "url", url)
+	m.saveHiddenPRsLocked()
+}
+
+// HiddenPR reports whether a PR is hidden.
+func (m *PRStateManager) HiddenPR(url string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.hiddenPRs[url]
+	return ok
+}
+
+// HiddenPRURLs returns the set of hidden PR URLs, for a prView.
+func (m *PRStateManager) HiddenPRURLs() map[string]bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.hiddenPRs) == 0 {
+		return nil
+	}
+	urls := make(map[string]bool, len(m.hiddenPRs))
+	for url := range m.hiddenPRs {
+		urls[url] = true
+	}
+	return urls
+}
+
+// HiddenPRs returns the hidden PRs, ordered by repository and number.
+func (m *PRStateManager) HiddenPRs() []hiddenPRRow {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rows := make([]hiddenPRRow, 0, len(m.hiddenPRs))
+	for url, h := range m.hiddenPRs {
+		rows = append(rows, hiddenPRRow{hiddenPR: h, URL: url})
+	}
+	slices.SortFunc(rows, func(a, b hiddenPRRow) int {
+		return cmp.Or(strings.Compare(a.Repository, b.Repository), cmp.Compare(a.Number, b.Number))
+	})
+	return rows
+}
+
+// ReconcileHiddenPRs notes which hidden PRs an update listed, and, after a complete
+// update, drops hides on PRs missing for hiddenPRExpiry.
+func (m *PRStateManager) ReconcileHiddenPRs(incoming, outgoing []PR, complete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.hiddenPRs) == 0 {
+		return
+	}
+	now := m.now()
+	listed := make(map[string]bool, len(incoming)+len(outgoing))
+	for _, list := range [][]PR{incoming, outgoing} {
+		for i := range list {
+			listed[list[i].URL] = true
+		}
+	}
+	changed := false
+	for url, h := range m.hiddenPRs {
+		switch {
+		case listed[url]:
+			if now.Sub(h.LastSeen) >= hiddenPRSeenResolution {
+				h.LastSeen = now
+				m.hiddenPRs[url] = h
+				changed = true
+			}
+		case complete && now.Sub(h.LastSeen) >= hiddenPRExpiry:
+			slog.Info("[STATE] Hidden PR missing from updates, dropping its hide",
+				"repo", h.Repository, "number", h.Number, "last_seen", h.LastSeen.Format(time.RFC3339))
+			delete(m.hiddenPRs, url)
+			changed = true
+		default:
+		}
+	}
+	if changed {
+		m.saveHiddenPRsLocked()
+	}
+}
+
+// PruneClosedHiddenPRs drops hides on PRs confirmed closed.
+func (m *PRStateManager) PruneClosedHiddenPRs(urls []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before := len(m.hiddenPRs)
+	for _, url := range urls {
+		if h, ok := m.hiddenPRs[url]; ok {
+			slog.Info("[STATE] Hidden PR closed, dropping its hide", "repo", h.Repository, "number", h.Number)
+			delete(m.hiddenPRs, url)
+		}
+	}
+	if len(m.hiddenPRs) != before {
+		m.saveHiddenPRsLocked()
+	}
+}
+
+// saveHiddenPRsLocked persists the hidden PRs, if LoadHiddenPRs set a path. The caller
+// holds m.mu.
+func (m *PRStateManager) saveHiddenPRsLocked() {
+	if m.hiddenPRsPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(m.hiddenPRs, "", "  ")
+	if err != nil {
+		slog.Warn("[STATE] Failed to marshal hidden PRs", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(m.hiddenPRsPath), 0o700); err != nil {
+		slog.Warn("[STATE] Failed to create hidden PR directory", "error", err)
+		return
+	}
+	if err := os.WriteFile(m.hiddenPRsPath, data, 0o600); err != nil {
+		slog.Warn("[STATE] Failed to save hidden PRs", "path", m.hiddenPRsPath, "error", err)
+	}
+}
+
+// FlushHiddenPRs writes the hidden PRs to disk, for shutdown.
+func (m *PRStateManager) FlushHiddenPRs() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saveHiddenPRsLocked()
+}
+
+// hiddenPRURLs returns the hidden PR URLs, or nil without a state manager.
+func (app *App) hiddenPRURLs() map[string]bool {
+	if app.stateManager == nil {
+		return nil
+	}
+	return app.stateManager.HiddenPRURLs()
+}
+
+// pruneClosedHiddenPRs drops hides on PRs confirmed closed.
+func (app *App) pruneClosedHiddenPRs(urls []string) {
+	if app.stateManager == nil || len(urls) == 0 {
+		return
+	}
+	app.stateManager.PruneClosedHiddenPRs(urls)
+}
+
+// addHidePRAction adds "Hide forever" to a PR's submenu.
+func (app *App) addHidePRAction(ctx context.Context, item MenuItem, pr *PR) {
+	if app.stateManager == nil {
+		return
+	}
+	p := *pr
+	item.AddSubMenuItem(msg("pr.hide"), msg("pr.hide.tooltip")).Click(func() {
+		app.stateManager.HidePR(&p)
+		app.presentTrayState()
+		app.rebuildMenu(ctx)
+	})
+}
+
+// hiddenPRTitle is the submenu label for a hidden PR.
+func hiddenPRTitle(h hiddenPRRow) string {
+	return fmt.Sprintf("%s #%d", h.Repository, h.Number)
+}
+
+// hiddenPRsTitles lists the "Hidden PRs" entries for change detection.
+func (app *App) hiddenPRsTitles() []string {
+	if app.stateManager == nil {
+		return nil
+	}
+	hidden := app.stateManager.HiddenPRs()
+	if len(hidden) == 0 {
+		return nil
+	}
+	titles := []string{msg("hidden_prs.menu", len(hidden))}
+	for _, h := range hidden {
+		titles = append(titles, hiddenPRTitle(h))
+	}
+	return titles
+}
+
+// addHiddenPRsMenu adds the "Hidden PRs" settings submenu; each entry can be opened or
+// unhidden.
+func (app *App) addHiddenPRsMenu(ctx context.Context) {
+	if app.stateManager == nil {
+		return
+	}
+	hidden := app.stateManager.HiddenPRs()
+	if len(hidden) == 0 {
+		return
+	}
+
+	hiddenMenu := app.systrayInterface.AddMenuItem(msg("hidden_prs.menu", len(hidden)), msg("hidden_prs.menu.tooltip"))
+	for _, h := range hidden {
+		item := hiddenMenu.AddSubMenuItem(hiddenPRTitle(h), "")
+		url := h.URL
+		item.AddSubMenuItem(msg("dashboard.open_github"), "").Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+			}
+		})
+		item.AddSubMenuItem(msg("hidden_prs.unhide"), "").Click(func() {
+			app.stateManager.UnhidePR(url)
+			app.presentTrayState()
+			app.rebuildMenu(ctx)
+		})
+	}
+}
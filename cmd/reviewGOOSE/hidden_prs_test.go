@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestHiddenPRPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), hiddenPRsFileName)
+	m := NewPRStateManager(time.Now())
+	m.LoadHiddenPRs(path)
+	first, second := pinnablePR(1, true), pinnablePR(2, true)
+	m.HidePR(&first)
+	m.HidePR(&second)
+
+	restarted := NewPRStateManager(time.Now())
+	restarted.LoadHiddenPRs(path)
+	if !restarted.HiddenPR(first.URL) || !restarted.HiddenPR(second.URL) {
+		t.Fatalf("hidden PRs after restart = %v, want both", restarted.HiddenPRURLs())
+	}
+
+	restarted.UnhidePR(first.URL)
+	again := NewPRStateManager(time.Now())
+	again.LoadHiddenPRs(path)
+	if again.HiddenPR(first.URL) || !again.HiddenPR(second.URL) {
+		t.Errorf("hidden PRs after unhiding and restarting = %v, want only %s", again.HiddenPRURLs(), second.URL)
+	}
+}
+
+func TestHiddenPRLeavesMenuAndCounts(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	hidden, shown := pinnablePR(1, true), pinnablePR(2, true)
+	outgoing := pinnablePR(3, false)
+	outgoing.IsBlocked = true
+	app.incoming = []PR{hidden, shown}
+	app.outgoing = []PR{outgoing}
+	app.stateManager.HidePR(&hidden)
+	app.stateManager.HidePR(&outgoing)
+
+	view := app.snapshotPRs()
+	if len(view.incoming) != 1 || view.incoming[0].URL != shown.URL {
+		t.Errorf("incoming = %v, want only %s", view.incoming, shown.URL)
+	}
+	if len(view.outgoing) != 0 {
+		t.Errorf("outgoing = %v, want none", view.outgoing)
+	}
+	if c := view.counts(); c.IncomingTotal != 1 || c.IncomingBlocked != 1 || c.OutgoingTotal != 0 || c.OutgoingBlocked != 0 {
+		t.Errorf("counts = %+v, want one blocked incoming PR", c)
+	}
+}
+
+func TestHiddenPRDoesNotNotify(t *testing.T) {
+	now := time.Now()
+	app, notifier := newGraceTestApp(2 * time.Minute)
+	pr := pipelinePR("acme/widgets", 1, true, now)
+	app.stateManager.HidePR(&pr)
+
+	for _, incoming := range [][]PR{{pipelinePR("acme/widgets", 1, false, now)}, {pr}} {
+		app.mu.Lock()
+		app.incoming = incoming
+		app.mu.Unlock()
+		app.processNotifications(context.Background())
+	}
+	if notes := waitForNotes(notifier, 0); len(notes) != 0 {
+		t.Errorf("poll notifications = %q, want none", notes)
+	}
+
+	act := &turn.Action{Kind: "review", Reason: "needs review", Critical: true}
+	sm := &sprinklerMonitor{app: app}
+	sm.sendNotifications(context.Background(), pr.URL, pr.Repository, pr.Number, act)
+	if notes := waitForNotes(notifier, 0); len(notes) != 0 {
+		t.Errorf("event notifications = %q, want none", notes)
+	}
+}
+
+func TestHiddenPRLapses(t *testing.T) {
+	now := time.Now()
+	m := NewPRStateManager(now)
+	m.now = func() time.Time { return now }
+	listed, missing := pinnablePR(1, true), pinnablePR(2, true)
+	m.HidePR(&listed)
+	m.HidePR(&missing)
+
+	// A day later, an update listing the PR refreshes its last-seen time
+	now = now.Add(hiddenPRSeenResolution)
+	m.ReconcileHiddenPRs([]PR{listed}, nil, true)
+	if got := m.hiddenPRs[listed.URL].LastSeen; !got.Equal(now) {
+		t.Errorf("last seen = %v, want %v", got, now)
+	}
+
+	now = now.Add(hiddenPRExpiry)
+	m.ReconcileHiddenPRs(nil, nil, false)
+	if !m.HiddenPR(missing.URL) {
+		t.Fatal("a partial update shouldn't drop a hide")
+	}
+	m.ReconcileHiddenPRs([]PR{listed}, nil, true)
+	if m.HiddenPR(missing.URL) {
+		t.Error("a PR missing for the expiry is still hidden")
+	}
+	if !m.HiddenPR(listed.URL) {
+		t.Error("a listed PR lost its hide")
+	}
+
+	m.PruneClosedHiddenPRs([]string{listed.URL})
+	if m.HiddenPR(listed.URL) {
+		t.Error("a closed PR is still hidden")
+	}
+}
+
+func TestHiddenPRLapsesOnCloseEvent(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	pr := pinnablePR(1, true)
+	app.incoming = []PR{pr}
+	app.stateManager.HidePR(&pr)
+
+	sm := &sprinklerMonitor{app: app}
+	sm.removeClosedPR(context.Background(), pr.URL, pr.Repository, pr.Number, "closed", false)
+	if app.stateManager.HiddenPR(pr.URL) {
+		t.Error("a PR closed by an event is still hidden")
+	}
+}
+
+func TestHiddenPRsMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	pr := pinnablePR(1, true)
+
+	item := &MockMenuItem{}
+	app.addHidePRAction(context.Background(), item, &pr)
+	if len(item.subItems) != 1 || item.subItems[0].(*MockMenuItem).title != msg("pr.hide") {
+		t.Fatalf("PR submenu = %+v, want Hide forever", item.subItems)
+	}
+
+	if titles := app.hiddenPRsTitles(); titles != nil {
+		t.Errorf("Hidden PRs titles with nothing hidden = %q", titles)
+	}
+	app.stateManager.HidePR(&pr)
+	titles := app.hiddenPRsTitles()
+	if len(titles) != 2 || titles[0] != msg("hidden_prs.menu", 1) || titles[1] != "acme/widgets #1" {
+		t.Errorf("Hidden PRs titles = %q", titles)
+	}
+
+	systray := &MockSystray{}
+	app.systrayInterface = systray
+	app.addHiddenPRsMenu(context.Background())
+	if len(systray.items) != 1 {
+		t.Fatalf("settings items = %d, want the Hidden PRs submenu", len(systray.items))
+	}
+}
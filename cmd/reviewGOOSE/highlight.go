@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// HighlightWindow controls how long a newly blocked PR keeps its emoji (🪿, 🎉, 🪳).
+type HighlightWindow string
+
+const (
+	// Highlight1m, Highlight5m, and Highlight15m clear the emoji after a fixed time.
+	Highlight1m  HighlightWindow = "1m"
+	Highlight5m  HighlightWindow = "5m"
+	Highlight15m HighlightWindow = "15m"
+	// HighlightUntilOpened keeps the emoji until the PR is opened from goose.
+	HighlightUntilOpened HighlightWindow = "until_opened"
+)
+
+// highlightWindows lists the highlight windows in menu order.
+var highlightWindows = []HighlightWindow{Highlight1m, Highlight5m, Highlight15m, HighlightUntilOpened}
+
+// duration returns the fixed window, or 0 for HighlightUntilOpened.
+func (h HighlightWindow) duration() time.Duration {
+	switch h {
+	case Highlight1m:
+		return time.Minute
+	case Highlight15m:
+		return 15 * time.Minute
+	case HighlightUntilOpened:
+		return 0
+	default:
+		return 5 * time.Minute
+	}
+}
+
+// label returns the human-readable name shown in the menu.
+func (h HighlightWindow) label() string {
+	if h == HighlightUntilOpened {
+		return msg("highlight.until_opened")
+	}
+	return msg("highlight.minutes", int(h.duration().Minutes()))
+}
+
+// valid reports whether h is a known highlight window.
+func (h HighlightWindow) valid() bool {
+	switch h {
+	case Highlight1m, Highlight5m, Highlight15m, HighlightUntilOpened:
+		return true
+	default:
+		return false
+	}
+}
+
+// FreshlyBlocked reports whether a PR's fresh-block emoji should show. Blocks seen at
+// startup never qualify. With a window of 0 the emoji lasts until openedAt passes the
+// time the PR was blocked; otherwise it lasts for window after the block.
+func (m *PRStateManager) FreshlyBlocked(url string, window time.Duration, openedAt time.Time) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, ok := m.states[url]
+	if !ok || state.FirstBlockedAt.IsZero() || state.IsInitialDiscovery {
+		return false
+	}
+	if window == 0 {
+		return !openedAt.After(state.FirstBlockedAt)
+	}
+	return m.now().Sub(state.FirstBlockedAt) < window
+}
+
+// highlightWindow returns the configured highlight window.
+func (app *App) highlightWindow() HighlightWindow {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if !app.highlight.valid() {
+		return Highlight5m
+	}
+	return app.highlight
+}
+
+// recordPROpened notes that the PR behind rawURL was opened. Under HighlightUntilOpened
+// that clears its emoji, so the menu is refreshed right away. URLs that aren't GitHub
+// PRs are ignored.
+func (app *App) recordPROpened(ctx context.Context, rawURL string) {
+	key := responseKey(rawURL)
+	if key == "" {
+		return
+	}
+	app.mu.Lock()
+	if app.prOpenedAt == nil {
+		app.prOpenedAt = make(map[string]time.Time)
+	}
+	app.prOpenedAt[key] = time.Now()
+	app.mu.Unlock()
+	app.settleComments(rawURL)
+
+	if app.highlightWindow() == HighlightUntilOpened && app.systrayInterface != nil {
+		app.updateMenu(ctx)
+	}
+}
+
+// prOpened returns when the PR was last opened from goose, or the zero time.
+func (app *App) prOpened(prURL string) time.Time {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.prOpenedAt[responseKey(prURL)]
+}
+
+// blockedPrefix returns the marker for a blocked PR: an emoji while the block is fresh,
+// otherwise a block icon (a smaller dot for bots).
+func (app *App) blockedPrefix(pr *PR, sectionTitle string, window HighlightWindow) string {
+	if app.stateManager.FreshlyBlocked(pr.URL, window.duration(), app.prOpened(pr.URL)) {
+		// Use cockroach for fix_tests, party popper for other outgoing PRs, goose for incoming PRs
+		emoji := "🪿"
+		if sectionTitle == "Outgoing" {
+			emoji = "🎉"
+			if pr.ActionKind.outgoingIcon() == IconCockroach {
+				emoji = "🪳"
+			}
+		}
+		slog.Debug("[MENU] Highlighting newly blocked PR",
+			"section", sectionTitle, "url", pr.URL, "emoji", emoji, "window", window)
+		return emoji
+	}
+	if pr.AuthorBot {
+		return "·"
+	}
+	return "■"
+}
+
+// addHighlightMenu adds the "Highlight new blocks for" submenu.
+func (app *App) addHighlightMenu(ctx context.Context) {
+	highlightMenu := app.systrayInterface.AddMenuItem(msg("highlight.menu"), msg("highlight.menu.tooltip"))
+
+	current := app.highlightWindow()
+	for _, w := range highlightWindows {
+		window := w // Capture for closure
+		text := window.label()
+		if window == current {
+			text = "✓ " + text
+		}
+		highlightMenu.AddSubMenuItem(text, "").Click(func() {
+			app.mu.Lock()
+			app.highlight = window
+			app.mu.Unlock()
+
+			slog.Info("[SETTINGS] Highlight window changed", "window", window)
+
+			app.saveSettings()
+			app.rebuildMenu(ctx)
+		})
+	}
+}
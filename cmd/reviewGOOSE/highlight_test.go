@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+const highlightPR = "https://github.com/org/repo/pull/1"
+
+// newHighlightStateManager returns a state manager tracking highlightPR as blocked
+// blockedAgo before now, discovered after startup.
+func newHighlightStateManager(now time.Time, blockedAgo time.Duration) *PRStateManager {
+	m := NewPRStateManager(now.Add(-time.Hour))
+	m.now = func() time.Time { return now }
+	m.states[highlightPR] = &PRState{FirstBlockedAt: now.Add(-blockedAgo)}
+	return m
+}
+
+func TestFreshlyBlocked(t *testing.T) {
+	now := time.Now()
+	blockedAt := 3 * time.Minute
+
+	tests := []struct {
+		openedAt time.Time
+		window   HighlightWindow
+		name     string
+		want     bool
+	}{
+		{name: "1m expired", window: Highlight1m, want: false},
+		{name: "5m still fresh", window: Highlight5m, want: true},
+		{name: "15m still fresh", window: Highlight15m, want: true},
+		{name: "until opened: never opened", window: HighlightUntilOpened, want: true},
+		{name: "until opened: opened before the block", window: HighlightUntilOpened, openedAt: now.Add(-time.Hour), want: true},
+		{name: "until opened: opened after the block", window: HighlightUntilOpened, openedAt: now.Add(-time.Minute), want: false},
+		{name: "fixed windows ignore opens", window: Highlight5m, openedAt: now.Add(-time.Minute), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newHighlightStateManager(now, blockedAt)
+			if got := m.FreshlyBlocked(highlightPR, tt.window.duration(), tt.openedAt); got != tt.want {
+				t.Errorf("FreshlyBlocked(%s) = %v, want %v", tt.window, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("initial discovery never highlights", func(t *testing.T) {
+		m := newHighlightStateManager(now, time.Second)
+		m.states[highlightPR].IsInitialDiscovery = true
+		if m.FreshlyBlocked(highlightPR, HighlightUntilOpened.duration(), time.Time{}) {
+			t.Error("PRs already blocked at startup should not get the emoji")
+		}
+	})
+	t.Run("unknown PR", func(t *testing.T) {
+		m := newHighlightStateManager(now, time.Second)
+		if m.FreshlyBlocked("https://github.com/org/repo/pull/2", Highlight15m.duration(), time.Time{}) {
+			t.Error("PRs without state should not get the emoji")
+		}
+	})
+}
+
+func TestOpeningClearsHighlight(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	app.browser = silentBrowser{}
+	now := time.Now()
+	app.stateManager = newHighlightStateManager(now, 30*time.Minute) // Past every fixed window
+	app.highlight = HighlightUntilOpened
+	incoming := []PR{{Repository: "org/repo", Number: 1, URL: highlightPR, NeedsReview: true, UpdatedAt: now}}
+
+	title := func() string {
+		return app.generatePRSectionTitles(incoming, "Incoming")[0]
+	}
+	if got := title(); !strings.HasPrefix(got, "🪿") {
+		t.Fatalf("title = %q, want the goose until the PR is opened", got)
+	}
+
+	// The checks tab still identifies the PR
+	if err := app.openBrowser(context.Background(), highlightPR+"/checks", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := title(); !strings.HasPrefix(got, "■") {
+		t.Errorf("title = %q, want the block icon once opened", got)
+	}
+
+	app.highlight = Highlight15m
+	if got := title(); !strings.HasPrefix(got, "■") {
+		t.Errorf("title = %q, want the 15m window to have expired", got)
+	}
+}
+
+func TestHighlightWindowPersists(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	if got := app.highlightWindow(); got != Highlight5m {
+		t.Errorf("default highlight window = %s, want 5m", got)
+	}
+	app.highlight = HighlightUntilOpened
+	app.saveSettings()
+	app.highlight = ""
+	app.loadSettings()
+	if got := app.highlightWindow(); got != HighlightUntilOpened {
+		t.Errorf("highlight window = %s after reload, want until_opened", got)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	hookTimeout      = 10 * time.Second // Per run; the process is killed after this
+	hookMaxPerMinute = 10               // Events beyond this are dropped, not queued
+	hookQueueSize    = 32               // Pending events; a full queue drops new events
+	maxHookOutput    = 512              // Runes of hook output kept for the failure log
+)
+
+// Hook event types passed in hookEvent.Type.
+const (
+	hookEventBlocked      = "blocked"
+	hookEventUnblocked    = "unblocked"
+	hookEventReadyToMerge = "ready_to_merge"
+)
+
+// hookEvent is the JSON document a notification hook receives on stdin.
+type hookEvent struct {
+	At   time.Time `json:"at"`
+	Type string    `json:"type"`
+	PR   hookPR    `json:"pr"`
+}
+
+// hookPR is the PR as described to a notification hook.
+type hookPR struct {
+	URL          string     `json:"url"`
+	Repository   string     `json:"repository"`
+	Title        string     `json:"title"`
+	Author       string     `json:"author"`
+	ActionKind   ActionKind `json:"action_kind,omitempty"`
+	ActionReason string     `json:"action_reason,omitempty"`
+	Number       int        `json:"number"`
+	Incoming     bool       `json:"incoming"`
+}
+
+// newHookEvent describes a PR event for the hook.
+func newHookEvent(eventType string, pr *PR, incoming bool, at time.Time) hookEvent {
+	return hookEvent{
+		At:   at,
+		Type: eventType,
+		PR: hookPR{
+			URL:          pr.URL,
+			Repository:   pr.Repository,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			ActionKind:   pr.ActionKind,
+			ActionReason: pr.ActionReason,
+			Number:       pr.Number,
+			Incoming:     incoming,
+		},
+	}
+}
+
+// validateHookPath checks that a configured hook is an absolute path to an executable
+// file that other users can't rewrite.
+func validateHookPath(path string) error {
+	if !filepath.IsAbs(path) {
+		return errors.New("hook path must be absolute")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat hook: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return errors.New("hook is not a regular file")
+	}
+	// Windows doesn't report Unix permission bits
+	if runtime.GOOS != "windows" {
+		if info.Mode().Perm()&0o002 != 0 {
+			return errors.New("hook is world-writable")
+		}
+		if info.Mode().Perm()&0o111 == 0 {
+			return errors.New("hook is not executable")
+		}
+	}
+	return nil
+}
+
+// notificationHook runs a user-provided executable for each notification event, off the
+// notification path. Hook failures are logged and counted, never acted on.
+type notificationHook struct {
+	health       *healthMonitor
+	events       chan hookEvent
+	path         string
+	runs         []time.Time // Start times within the last minute; only touched by the worker
+	timeout      time.Duration
+	maxPerMinute int
+}
+
+func newNotificationHook(path string, health *healthMonitor) *notificationHook {
+	return &notificationHook{
+		path:         path,
+		health:       health,
+		events:       make(chan hookEvent, hookQueueSize),
+		timeout:      hookTimeout,
+		maxPerMinute: hookMaxPerMinute,
+	}
+}
+
+// work runs queued events one at a time until ctx is done.
+func (h *notificationHook) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-h.events:
+			if !h.allow(time.Now()) {
+				slog.Warn("[HOOK] Rate limited, dropping event", "type", ev.Type, "url", ev.PR.URL, "max_per_minute", h.maxPerMinute)
+				h.record(false)
+				continue
+			}
+			err := h.run(ctx, ev)
+			if err != nil {
+				slog.Warn("[HOOK] Hook failed", "type", ev.Type, "url", ev.PR.URL, "error", err)
+			} else {
+				slog.Debug("[HOOK] Hook ran", "type", ev.Type, "url", ev.PR.URL)
+			}
+			h.record(err == nil)
+		}
+	}
+}
+
+// enqueue hands an event to the worker without ever blocking the caller.
+func (h *notificationHook) enqueue(ev hookEvent) {
+	select {
+	case h.events <- ev:
+	default:
+		slog.Warn("[HOOK] Queue full, dropping event", "type", ev.Type, "url", ev.PR.URL)
+		h.record(false)
+	}
+}
+
+// allow reports whether another run fits within maxPerMinute, recording it if so.
+func (h *notificationHook) allow(now time.Time) bool {
+	kept := h.runs[:0]
+	for _, t := range h.runs {
+		if now.Sub(t) < time.Minute {
+			kept = append(kept, t)
+		}
+	}
+	h.runs = kept
+	if len(h.runs) >= h.maxPerMinute {
+		return false
+	}
+	h.runs = append(h.runs, now)
+	return true
+}
+
+// run executes the hook directly (no shell) with the event as JSON on stdin.
+func (h *notificationHook) run(ctx context.Context, ev hookEvent) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	runCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, h.path)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.WaitDelay = time.Second // Don't wait on grandchildren holding the output pipe
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", h.timeout)
+		}
+		return fmt.Errorf("%w: %s", err, truncateRunes(strings.TrimSpace(string(out)), maxHookOutput))
+	}
+	return nil
+}
+
+func (h *notificationHook) record(success bool) {
+	if h.health != nil {
+		h.health.recordHookRun(success)
+	}
+}
+
+// configureNotificationHook validates the notification_hook setting and starts its worker.
+// An invalid hook is logged and left off.
+func (app *App) configureNotificationHook(ctx context.Context) {
+	path := app.notificationHookSetting
+	if path == "" {
+		return
+	}
+	if err := validateHookPath(path); err != nil {
+		slog.Error("[HOOK] Ignoring notification hook", "path", path, "error", err)
+		return
+	}
+	app.hook = newNotificationHook(path, app.healthMonitor)
+	hook := app.hook
+	app.goTracked("notification hook", func() { hook.work(ctx) })
+	slog.Info("[HOOK] Notification hook enabled", "path", path)
+}
+
+// emitHookEvent queues an event for the notification hook, if one is configured.
+// Silent mode suppresses hooks along with every other side effect.
+func (app *App) emitHookEvent(eventType string, pr *PR, incoming bool) {
+	if app.hook == nil || app.silentMode {
+		return
+	}
+	app.hook.enqueue(newHookEvent(eventType, pr, incoming, time.Now()))
+}
+
+// emitUnblockedHookEvents reports PRs that were blocked before this cycle and no longer are.
+// PRs that left the lists entirely (merged or closed) are described as last seen blocked.
+func (app *App) emitUnblockedHookEvents(unblocked []stateTransition) {
+	if app.hook == nil {
+		return
+	}
+	for i := range unblocked {
+		pr := unblocked[i].PR
+		if !app.inFocus(pr.Repository) || app.prPolicy(pr.Repository) != orgPolicyFull {
+			continue
+		}
+		app.emitHookEvent(hookEventUnblocked, &pr, unblocked[i].incoming)
+	}
+}
+
+// blockedHookEvent is the hook event type for a newly blocked PR.
+func blockedHookEvent(pr *PR) string {
+	if pr.ActionKind == actionMerge {
+		return hookEventReadyToMerge
+	}
+	return hookEventBlocked
+}
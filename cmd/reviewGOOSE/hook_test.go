@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// writeHookScript writes an executable shell script and returns its path.
+func writeHookScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook fixtures are shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHookReceivesEventOnStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	h := newNotificationHook(writeHookScript(t, "cat > "+out), newHealthMonitor())
+	pr := &PR{
+		URL:        "https://github.com/acme/widgets/pull/7",
+		Repository: "acme/widgets",
+		Number:     7,
+		Title:      "Add retries",
+		Author:     "alice",
+		ActionKind: "merge",
+	}
+	if err := h.run(context.Background(), newHookEvent(blockedHookEvent(pr), pr, false, time.Now())); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Type string         `json:"type"`
+		PR   map[string]any `json:"pr"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("hook stdin is not JSON: %v\n%s", err, data)
+	}
+	if got.Type != hookEventReadyToMerge {
+		t.Errorf("type = %q, want %q", got.Type, hookEventReadyToMerge)
+	}
+	for key, want := range map[string]any{
+		"url":         pr.URL,
+		"repository":  "acme/widgets",
+		"number":      float64(7),
+		"title":       "Add retries",
+		"action_kind": "merge",
+		"incoming":    false,
+	} {
+		if got.PR[key] != want {
+			t.Errorf("pr.%s = %v, want %v", key, got.PR[key], want)
+		}
+	}
+}
+
+func TestHookFailuresAreReported(t *testing.T) {
+	h := newNotificationHook(writeHookScript(t, "echo boom >&2; exit 3"), newHealthMonitor())
+	err := h.run(context.Background(), hookEvent{Type: hookEventBlocked})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("run() error = %v, want the exit status and output", err)
+	}
+}
+
+func TestHookOutputTruncatedOnRunes(t *testing.T) {
+	// Each ü is two bytes, so a byte cut at maxHookOutput could split one
+	h := newNotificationHook(writeHookScript(t, "printf 'x'; printf 'ü%.0s' $(seq 600) >&2; exit 1"), newHealthMonitor())
+	err := h.run(context.Background(), hookEvent{Type: hookEventBlocked})
+	if err == nil {
+		t.Fatal("run() succeeded, want a failure")
+	}
+	if !utf8.ValidString(err.Error()) || !strings.HasSuffix(err.Error(), "…") {
+		t.Errorf("run() error = %q, want valid UTF-8 cut with an ellipsis", err)
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	h := newNotificationHook(writeHookScript(t, "sleep 30"), newHealthMonitor())
+	h.timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	err := h.run(context.Background(), hookEvent{Type: hookEventBlocked})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("run() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("hook ran for %s despite the %s timeout", elapsed, h.timeout)
+	}
+}
+
+func TestHookRateLimit(t *testing.T) {
+	h := newNotificationHook("/unused", nil)
+	h.maxPerMinute = 3
+	now := time.Now()
+	for i := range 3 {
+		if !h.allow(now.Add(time.Duration(i) * time.Second)) {
+			t.Fatalf("run %d should be allowed", i+1)
+		}
+	}
+	if h.allow(now.Add(10 * time.Second)) {
+		t.Error("a fourth run within a minute should be dropped")
+	}
+	if !h.allow(now.Add(time.Minute)) {
+		t.Error("runs should be allowed again once the first has aged out")
+	}
+}
+
+func TestHookWorkerCountsRunsAndDrops(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "runs")
+	hm := newHealthMonitor()
+	h := newNotificationHook(writeHookScript(t, "echo run >> "+out), hm)
+	h.maxPerMinute = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go h.work(ctx)
+
+	for range 3 {
+		h.enqueue(hookEvent{Type: hookEventBlocked})
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		m := hm.metrics()
+		if m["hook_runs"].(int64) == 2 && m["hook_failures"].(int64) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("hook metrics = runs %v, failures %v; want 2 runs, 1 dropped", m["hook_runs"], m["hook_failures"])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if data, _ := os.ReadFile(out); strings.Count(string(data), "run") != 2 {
+		t.Errorf("hook ran %d times, want 2", strings.Count(string(data), "run"))
+	}
+}
+
+func TestValidateHookPath(t *testing.T) {
+	good := writeHookScript(t, "true")
+	if err := validateHookPath(good); err != nil {
+		t.Errorf("validateHookPath(%q) = %v, want nil", good, err)
+	}
+
+	worldWritable := writeHookScript(t, "true")
+	if err := os.Chmod(worldWritable, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	notExecutable := writeHookScript(t, "true")
+	if err := os.Chmod(notExecutable, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, path := range map[string]string{
+		"relative":       "hooks/notify.sh",
+		"missing":        filepath.Join(t.TempDir(), "missing.sh"),
+		"directory":      t.TempDir(),
+		"world-writable": worldWritable,
+		"not executable": notExecutable,
+	} {
+		if err := validateHookPath(path); err == nil {
+			t.Errorf("%s: validateHookPath(%q) = nil, want an error", name, path)
+		}
+	}
+}
+
+func TestConfigureNotificationHookRejectsInvalidPath(t *testing.T) {
+	app := newFocusTestApp(0)
+	app.notificationHookSetting = "relative/hook.sh"
+	app.configureNotificationHook(context.Background())
+	if app.hook != nil {
+		t.Error("an invalid hook path should leave the hook off")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log/slog"
+	"sync"
 )
 
 // Icon implementations are in platform-specific files:
@@ -21,20 +22,76 @@ const (
 	IconLock                      // Authentication error
 )
 
+// String returns the icon's short name for logs and diagnostics.
+func (i IconType) String() string {
+	switch i {
+	case IconSmiling:
+		return "smile"
+	case IconGoose:
+		return "goose"
+	case IconPopper:
+		return "popper"
+	case IconCockroach:
+		return "cockroach"
+	case IconBoth:
+		return "both"
+	case IconWarning:
+		return "warn"
+	case IconLock:
+		return "lock"
+	default:
+		return "unknown"
+	}
+}
+
 // getIcon returns icon bytes for the given type and counts.
 // Implementation is platform-specific:
 //   - macOS: returns static icons (counts displayed in title bar)
 //   - Linux/Windows: generates dynamic badges with embedded counts.
-// Implemented in icons_darwin.go and icons_badge.go.
+// Implemented in icons_darwin.go and icons_badge.go, along with themedIcon, which
+// adapts the icon to the desktop's light or dark color scheme.
 
 // setTrayIcon updates the system tray icon.
 func (app *App) setTrayIcon(iconType IconType, counts PRCounts) {
-	iconBytes := getIcon(iconType, counts)
+	app.setTrayIconFrame(iconType, counts, false)
+}
+
+// setTrayIconFrame is setTrayIcon for a tray flash, drawing the goose in place of the
+// icon on goose frames. The icon is remembered either way, for the frames between.
+func (app *App) setTrayIconFrame(iconType IconType, counts PRCounts, goose bool) {
+	if app.stateFile != nil {
+		app.stateFile.update(iconType, counts)
+	}
+	app.tray.mu.Lock()
+	defer app.tray.mu.Unlock()
+	app.tray.icon, app.tray.counts, app.tray.shown, app.tray.goose = iconType, counts, true, goose
+	app.showIconLocked()
+}
+
+// trayIconState remembers the icon on screen so it can be redrawn for a new color scheme.
+// Its zero value is ready to use.
+type trayIconState struct {
+	counts PRCounts
+	icon   IconType
+	scheme colorScheme
+	mu     sync.Mutex
+	shown  bool
+	goose  bool // A tray flash frame: the goose is drawn instead of icon
+}
+
+// showIconLocked draws the remembered icon for the current color scheme. The caller holds app.tray.mu.
+func (app *App) showIconLocked() {
+	if app.tray.goose {
+		app.systrayInterface.SetIcon(flashGooseIcon())
+		return
+	}
+	iconType, counts := app.tray.icon, app.tray.counts
+	iconBytes := themedIcon(iconType, counts, app.tray.scheme)
 	if len(iconBytes) == 0 {
 		slog.Warn("icon bytes empty, skipping update", "type", iconType)
 		return
 	}
 
 	app.systrayInterface.SetIcon(iconBytes)
-	slog.Debug("tray icon updated", "type", iconType, "incoming", counts.IncomingBlocked, "outgoing", counts.OutgoingBlocked)
+	slog.Debug("tray icon updated", "type", iconType, "scheme", app.tray.scheme, "incoming", counts.IncomingBlocked, "outgoing", counts.OutgoingBlocked)
 }
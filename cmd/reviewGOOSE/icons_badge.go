@@ -4,6 +4,7 @@ package main
 
 import (
 	_ "embed"
+	"image/color"
 	"log/slog"
 	"sync"
 
@@ -26,8 +27,25 @@ var (
 
 	smiling     []byte
 	smilingOnce sync.Once
+
+	themed   = make(map[themedIconKey][]byte)
+	themedMu sync.Mutex
 )
 
+// schemeEdges are the outline colors that keep icons legible on light and dark panels.
+var schemeEdges = map[colorScheme]color.RGBA{
+	schemeDark:  {235, 235, 235, 255},
+	schemeLight: {33, 37, 41, 255},
+}
+
+// themedIconKey identifies an icon variant; counts only matter for badges.
+type themedIconKey struct {
+	iconType IconType
+	scheme   colorScheme
+	incoming int
+	outgoing int
+}
+
 func getIcon(iconType IconType, counts PRCounts) []byte {
 	// Static icons for error states
 	if iconType == IconWarning {
@@ -69,3 +87,38 @@ func getIcon(iconType IconType, counts PRCounts) []byte {
 	cache.Put(incoming, outgoing, badge)
 	return badge
 }
+
+// themedIcon returns the icon outlined for a light or dark panel. The default scheme
+// gets the plain icon.
+func themedIcon(iconType IconType, counts PRCounts, scheme colorScheme) []byte {
+	plain := getIcon(iconType, counts)
+	edge, ok := schemeEdges[scheme]
+	if !ok || len(plain) == 0 {
+		return plain
+	}
+
+	key := themedIconKey{iconType: iconType, scheme: scheme}
+	switch iconType {
+	case IconWarning, IconLock:
+	default:
+		key.iconType = IconSmiling // Non-error icons differ only by counts
+		key.incoming, key.outgoing = counts.IncomingBlocked, counts.OutgoingBlocked
+	}
+
+	themedMu.Lock()
+	defer themedMu.Unlock()
+	if cached, ok := themed[key]; ok {
+		return cached
+	}
+	outlined, err := icon.Outline(plain, edge)
+	if err != nil {
+		slog.Error("failed to outline icon", "error", err, "type", iconType, "scheme", scheme)
+		return plain
+	}
+	// Same limit as the badge cache
+	if len(themed) > 100 {
+		clear(themed)
+	}
+	themed[key] = outlined
+	return outlined
+}
@@ -40,3 +40,8 @@ func getIcon(iconType IconType, _ PRCounts) []byte {
 		return iconSmiling
 	}
 }
+
+// themedIcon returns the plain icon: macOS adapts menu bar icons to its appearance itself.
+func themedIcon(iconType IconType, counts PRCounts, _ colorScheme) []byte {
+	return getIcon(iconType, counts)
+}
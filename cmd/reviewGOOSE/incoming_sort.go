@@ -0,0 +1,149 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// IncomingSort controls how blocked incoming PRs are ordered in the menu.
+type IncomingSort string
+
+const (
+	// IncomingSortRecent lists the most recently updated PRs first.
+	IncomingSortRecent IncomingSort = "recent"
+	// IncomingSortLongestWaiting lists the PRs that have been blocked on me longest first.
+	IncomingSortLongestWaiting IncomingSort = "longest_waiting"
+)
+
+// incomingSorts lists the incoming sort orders in menu order.
+var incomingSorts = []IncomingSort{IncomingSortRecent, IncomingSortLongestWaiting}
+
+// label returns the human-readable name shown in the menu.
+func (s IncomingSort) label() string {
+	if s == IncomingSortLongestWaiting {
+		return msg("sort.longest_waiting")
+	}
+	return msg("sort.recent")
+}
+
+// valid reports whether s is a known sort order.
+func (s IncomingSort) valid() bool {
+	return s == IncomingSortRecent || s == IncomingSortLongestWaiting
+}
+
+// sortablePR pairs a PR with when it started waiting on me.
+type sortablePR struct {
+	since time.Time
+	pr    PR
+}
+
+// blockedSince returns when a PR started waiting on me: when it was first seen blocked,
+// else when the Turn API says my action became due, else its last update.
+func blockedSince(pr *PR, state *PRState) time.Time {
+	switch {
+	case state != nil && !state.FirstBlockedAt.IsZero():
+		return state.FirstBlockedAt
+	case !pr.ActionSince.IsZero():
+		return pr.ActionSince
+	default:
+		return pr.UpdatedAt
+	}
+}
+
+// comparePRs orders blocked PRs first and humans before bots. Blocked PRs are then
+// ordered by order; everything else, and ties, most recently updated first.
+func comparePRs(a, b *sortablePR, order IncomingSort) int {
+	// First priority: blocked status
+	if a.pr.NeedsReview != b.pr.NeedsReview {
+		if a.pr.NeedsReview {
+			return -1
+		}
+		return 1
+	}
+	if a.pr.IsBlocked != b.pr.IsBlocked {
+		if a.pr.IsBlocked {
+			return -1
+		}
+		return 1
+	}
+	// Second priority: human PRs before bot PRs
+	if a.pr.AuthorBot != b.pr.AuthorBot {
+		if !a.pr.AuthorBot {
+			return -1
+		}
+		return 1
+	}
+	// Third priority: longest waiting, when asked for
+	blocked := a.pr.NeedsReview || a.pr.IsBlocked
+	if blocked && order == IncomingSortLongestWaiting {
+		if c := a.since.Compare(b.since); c != 0 {
+			return c
+		}
+	}
+	return b.pr.UpdatedAt.Compare(a.pr.UpdatedAt)
+}
+
+// sortSectionPRs returns a sorted copy of a menu section. The incoming sort setting only
+// applies to the Incoming section; outgoing PRs stay in recency order.
+func (app *App) sortSectionPRs(prs []PR, sectionTitle string) []PR {
+	order := IncomingSortRecent
+	if sectionTitle == "Incoming" {
+		order = app.incomingSortOrder()
+	}
+
+	entries := make([]sortablePR, len(prs))
+	for i := range prs {
+		entries[i].pr = prs[i]
+		if order != IncomingSortLongestWaiting {
+			continue
+		}
+		var state *PRState
+		if app.stateManager != nil {
+			state, _ = app.stateManager.PRState(prs[i].URL)
+		}
+		entries[i].since = blockedSince(&prs[i], state)
+	}
+	slices.SortStableFunc(entries, func(a, b sortablePR) int {
+		return comparePRs(&a, &b, order)
+	})
+
+	sorted := make([]PR, len(entries))
+	for i := range entries {
+		sorted[i] = entries[i].pr
+	}
+	return sorted
+}
+
+// incomingSortOrder returns the current incoming sort order.
+func (app *App) incomingSortOrder() IncomingSort {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return cmp.Or(app.incomingSort, IncomingSortRecent)
+}
+
+// addIncomingSortMenu adds the "Incoming sort" submenu for choosing the sort order.
+func (app *App) addIncomingSortMenu(ctx context.Context) {
+	sortMenu := app.systrayInterface.AddMenuItem(msg("sort.menu"), msg("sort.menu.tooltip"))
+
+	current := app.incomingSortOrder()
+	for _, s := range incomingSorts {
+		order := s // Capture for closure
+		text := order.label()
+		if order == current {
+			text = "✓ " + text
+		}
+		sortMenu.AddSubMenuItem(text, "").Click(func() {
+			app.mu.Lock()
+			app.incomingSort = order
+			app.mu.Unlock()
+
+			slog.Info("[SETTINGS] Incoming sort order changed", "order", order)
+
+			app.saveSettings()
+			app.rebuildMenu(ctx)
+		})
+	}
+}
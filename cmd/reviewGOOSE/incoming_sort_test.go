@@ -0,0 +1,155 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlockedSince(t *testing.T) {
+	now := time.Now()
+	firstBlocked := now.Add(-3 * time.Hour)
+	actionSince := now.Add(-2 * time.Hour)
+	updated := now.Add(-time.Hour)
+
+	tests := []struct {
+		state *PRState
+		want  time.Time
+		name  string
+		pr    PR
+	}{
+		{
+			name:  "state wins",
+			pr:    PR{ActionSince: actionSince, UpdatedAt: updated},
+			state: &PRState{FirstBlockedAt: firstBlocked},
+			want:  firstBlocked,
+		},
+		{name: "no state falls back to the action's due time", pr: PR{ActionSince: actionSince, UpdatedAt: updated}, want: actionSince},
+		{
+			name:  "state without a blocked time falls back too",
+			pr:    PR{ActionSince: actionSince, UpdatedAt: updated},
+			state: &PRState{},
+			want:  actionSince,
+		},
+		{name: "nothing known falls back to the last update", pr: PR{UpdatedAt: updated}, want: updated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockedSince(&tt.pr, tt.state); !got.Equal(tt.want) {
+				t.Errorf("blockedSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparePRs(t *testing.T) {
+	now := time.Now()
+	entry := func(blocked bool, waitingFor, updatedAgo time.Duration) sortablePR {
+		return sortablePR{
+			pr:    PR{NeedsReview: blocked, UpdatedAt: now.Add(-updatedAgo)},
+			since: now.Add(-waitingFor),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		a, b  sortablePR
+		order IncomingSort
+		want  int
+	}{
+		{
+			name:  "recent: newer update first",
+			a:     entry(true, 5*time.Hour, time.Minute),
+			b:     entry(true, time.Hour, time.Hour),
+			order: IncomingSortRecent,
+			want:  -1,
+		},
+		{
+			name:  "longest waiting: older block first",
+			a:     entry(true, 5*time.Hour, time.Minute),
+			b:     entry(true, time.Hour, time.Hour),
+			order: IncomingSortLongestWaiting,
+			want:  -1,
+		},
+		{
+			name:  "longest waiting: newer block after",
+			a:     entry(true, time.Hour, time.Minute),
+			b:     entry(true, 5*time.Hour, time.Hour),
+			order: IncomingSortLongestWaiting,
+			want:  1,
+		},
+		{
+			name:  "longest waiting: tie falls back to recency",
+			a:     entry(true, time.Hour, time.Hour),
+			b:     entry(true, time.Hour, time.Minute),
+			order: IncomingSortLongestWaiting,
+			want:  1,
+		},
+		{
+			name:  "longest waiting: full tie keeps input order",
+			a:     entry(true, time.Hour, time.Hour),
+			b:     entry(true, time.Hour, time.Hour),
+			order: IncomingSortLongestWaiting,
+			want:  0,
+		},
+		{
+			name:  "longest waiting: unblocked stay in recency order",
+			a:     entry(false, 5*time.Hour, time.Hour),
+			b:     entry(false, time.Hour, time.Minute),
+			order: IncomingSortLongestWaiting,
+			want:  1,
+		},
+		{
+			name:  "blocked before unblocked regardless of order",
+			a:     entry(false, 9*time.Hour, time.Minute),
+			b:     entry(true, time.Minute, 9*time.Hour),
+			order: IncomingSortLongestWaiting,
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := comparePRs(&tt.a, &tt.b, tt.order); got != tt.want {
+				t.Errorf("comparePRs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestWaitingOrdersMenuTitles(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	now := time.Now()
+	incoming := []PR{
+		{Repository: "org/fresh", Number: 1, URL: "https://github.com/org/fresh/pull/1", NeedsReview: true, UpdatedAt: now},
+		{Repository: "org/rotting", Number: 2, URL: "https://github.com/org/rotting/pull/2", NeedsReview: true,
+			ActionSince: now.Add(-48 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+		{Repository: "org/idle", Number: 3, URL: "https://github.com/org/idle/pull/3", UpdatedAt: now.Add(-time.Minute)},
+	}
+
+	order := func() string {
+		var repos []string
+		for _, title := range app.generatePRSectionTitles(incoming, "Incoming") {
+			for _, repo := range []string{"fresh", "rotting", "idle"} {
+				if strings.Contains(title, "org/"+repo) {
+					repos = append(repos, repo)
+				}
+			}
+		}
+		return strings.Join(repos, ",")
+	}
+
+	if got := order(); got != "fresh,rotting,idle" {
+		t.Errorf("recent order = %s, want fresh,rotting,idle", got)
+	}
+
+	app.incomingSort = IncomingSortLongestWaiting
+	app.saveSettings()
+	app.incomingSort = ""
+	app.loadSettings()
+	if app.incomingSort != IncomingSortLongestWaiting {
+		t.Fatalf("incoming sort = %q after reload, want it persisted", app.incomingSort)
+	}
+	if got := order(); got != "rotting,fresh,idle" {
+		t.Errorf("longest waiting order = %s, want rotting,fresh,idle", got)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Most per-PR state goes away when its PR stops being blocked or drops out of a
+// complete search, but a session that runs for weeks sees partial fetches, event-only
+// PRs, and skipped state updates that can leave entries behind. A janitor runs with the
+// health ticker: entries for PRs the lists haven't shown for longer than cacheTTL are
+// pruned, and when more PRs are tracked than max_tracked_prs allows, the ones gone from
+// the lists longest are evicted first. PRs in the lists are never pruned. The same pass
+// drops quarantine entries for PRs no search has listed within cacheTTL, and orgs
+// without PRs for orgForgetAfter.
+
+// defaultMaxTrackedPRs is how many PRs may have state in memory, unless max_tracked_prs
+// says otherwise.
+const defaultMaxTrackedPRs = 5000
+
+// stateJanitor remembers when each PR with long-running state was last listed.
+type stateJanitor struct {
+	lastListed map[string]time.Time // By URL
+	mu         sync.Mutex
+}
+
+// trackedSince is a PR with state, and the last time it's known to have been listed.
+type trackedSince struct {
+	at  time.Time
+	url string
+}
+
+// stale returns the tracked PRs to prune at now: those unlisted for longer than ttl,
+// then the longest unlisted ones while more than limit PRs are tracked. A PR tracked
+// before the janitor saw it starts its clock at hint, the last time its state saw it.
+func (j *stateJanitor) stale(now time.Time, listed map[string]bool, tracked map[string]time.Time, ttl time.Duration, limit int) []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.lastListed == nil {
+		j.lastListed = make(map[string]time.Time)
+	}
+
+	for url := range listed {
+		j.lastListed[url] = now
+	}
+	var gone []trackedSince
+	for url, hint := range tracked {
+		if listed[url] {
+			continue
+		}
+		at, ok := j.lastListed[url]
+		if !ok {
+			at = hint
+			if at.IsZero() || at.After(now) {
+				at = now
+			}
+			j.lastListed[url] = at
+		}
+		gone = append(gone, trackedSince{at: at, url: url})
+	}
+	for url := range j.lastListed {
+		if _, ok := tracked[url]; !ok && !listed[url] {
+			delete(j.lastListed, url)
+		}
+	}
+
+	slices.SortFunc(gone, func(a, b trackedSince) int {
+		return cmp.Or(a.at.Compare(b.at), cmp.Compare(a.url, b.url))
+	})
+	over := len(tracked) - limit
+	var urls []string
+	for i, g := range gone {
+		if i < over || now.Sub(g.at) > ttl {
+			urls = append(urls, g.url)
+			delete(j.lastListed, g.url)
+		}
+	}
+	return urls
+}
+
+// TrackedPRs returns the PRs with blocking, test, or clearing state, by URL, with the last
+// time that state saw each one, or the zero time when unknown.
+func (m *PRStateManager) TrackedPRs() map[string]time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tracked := make(map[string]time.Time, len(m.states)+len(m.runningSince)+len(m.cleared))
+	for url, state := range m.states {
+		tracked[url] = state.LastSeenBlocked
+	}
+	for url, since := range m.runningSince {
+		tracked[url] = later(tracked[url], since)
+	}
+	for url := range m.cleared {
+		if _, ok := tracked[url]; !ok {
+			tracked[url] = time.Time{}
+		}
+	}
+	return tracked
+}
+
+// ForgetPRs drops the blocking, test, clearing, and comment state of urls.
+func (m *PRStateManager) ForgetPRs(urls []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, url := range urls {
+		delete(m.states, url)
+		delete(m.runningSince, url)
+		delete(m.cleared, url)
+		delete(m.comments, responseKey(url))
+	}
+}
+
+// pruneTrackedState drops state kept for PRs that have been gone from the lists for
+// too long, or for too many PRs, and returns how many PRs it dropped.
+func (app *App) pruneTrackedState(now time.Time) int {
+	app.mu.RLock()
+	listed := make(map[string]bool, len(app.incoming)+len(app.outgoing)+len(app.filteredPRs)+len(app.eventPRs))
+	for _, prs := range [][]PR{app.incoming, app.outgoing, app.filteredPRs} {
+		for i := range prs {
+			listed[prs[i].URL] = true
+		}
+	}
+	for url := range app.eventPRs {
+		listed[url] = true
+	}
+	tracked := make(map[string]time.Time, len(app.blockedPRTimes))
+	for url := range app.previousBlockedPRs {
+		tracked[url] = time.Time{}
+	}
+	for url, at := range app.blockedPRTimes {
+		tracked[url] = at
+	}
+	listedKeys := make(map[string]bool, len(listed))
+	for url := range listed {
+		listedKeys[responseKey(url)] = true
+	}
+	var openedKeys []string
+	for key, at := range app.prOpenedAt {
+		if !listedKeys[key] && now.Sub(at) > cacheTTL {
+			openedKeys = append(openedKeys, key)
+		}
+	}
+	var staleOrgs []string
+	for org, a := range app.seenOrgs {
+		// Never seen: known only from a policy, which keeps it
+		if !a.LastSeenAt.IsZero() && now.Sub(a.LastSeenAt) > orgForgetAfter {
+			staleOrgs = append(staleOrgs, org)
+		}
+	}
+	limit := app.maxTrackedPRs
+	app.mu.RUnlock()
+	if limit <= 0 {
+		limit = defaultMaxTrackedPRs
+	}
+
+	if app.stateManager != nil {
+		for url, at := range app.stateManager.TrackedPRs() {
+			tracked[url] = later(tracked[url], at)
+		}
+	}
+	caches := app.trackedCaches()
+	for _, c := range caches {
+		for url, at := range c.tracked() {
+			tracked[url] = later(tracked[url], at)
+		}
+	}
+
+	stale := app.janitor.stale(now, listed, tracked, cacheTTL, limit)
+	quarantined := 0
+	if app.quarantine != nil {
+		quarantined = app.quarantine.prune(now, cacheTTL)
+	}
+	if len(stale) == 0 && len(openedKeys) == 0 && len(staleOrgs) == 0 && quarantined == 0 {
+		return 0
+	}
+
+	app.mu.Lock()
+	for _, url := range stale {
+		delete(app.previousBlockedPRs, url)
+		delete(app.blockedPRTimes, url)
+	}
+	for _, key := range openedKeys {
+		delete(app.prOpenedAt, key)
+	}
+	for _, org := range staleOrgs {
+		delete(app.seenOrgs, org)
+		app.orgActivityDirty = true
+	}
+	app.mu.Unlock()
+	if app.stateManager != nil {
+		app.stateManager.ForgetPRs(stale)
+	}
+	for _, c := range caches {
+		c.forget(stale)
+	}
+
+	slog.Info("[STATE] Pruned state for PRs gone from the lists",
+		"prs", len(stale), "opened_times", len(openedKeys), "quarantine", quarantined, "orgs", len(staleOrgs),
+		"tracked", len(tracked), "limit", limit)
+	return len(stale)
+}
+
+// later returns the later of a and b.
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// trackedCaches returns the per-PR lookup caches and records the janitor prunes.
+func (app *App) trackedCaches() []trackedCache {
+	var caches []trackedCache
+	if app.reviewRequests != nil {
+		caches = append(caches, app.reviewRequests)
+	}
+	if app.pullDetails != nil {
+		caches = append(caches, app.pullDetails)
+	}
+	if app.questions != nil {
+		caches = append(caches, app.questions)
+	}
+	if app.turnBackfill != nil {
+		caches = append(caches, app.turnBackfill)
+	}
+	return caches
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// trackedHistory fills app with state for n PRs that were last blocked at
+// start+i minutes, as if a long session had seen and forgotten them.
+func trackedHistory(app *App, n int, start time.Time) []string {
+	urls := make([]string, n)
+	for i := range n {
+		url := fmt.Sprintf("https://github.com/acme/history/pull/%d", i+1)
+		urls[i] = url
+		seen := start.Add(time.Duration(i) * time.Minute)
+		app.previousBlockedPRs[url] = true
+		app.blockedPRTimes[url] = seen
+		app.stateManager.states[url] = &PRState{FirstBlockedAt: seen, LastSeenBlocked: seen}
+		app.stateManager.runningSince[url] = seen
+	}
+	return urls
+}
+
+// assertTracked fails unless url's state is kept exactly when want is set.
+func assertTracked(t *testing.T, app *App, url string, want bool) {
+	t.Helper()
+	_, blocked := app.blockedPRTimes[url]
+	_, state := app.stateManager.states[url]
+	_, running := app.stateManager.runningSince[url]
+	if app.previousBlockedPRs[url] != want || blocked != want || state != want || running != want {
+		t.Errorf("%s: previous %v, blocked time %v, state %v, running %v; want all %v",
+			url, app.previousBlockedPRs[url], blocked, state, running, want)
+	}
+}
+
+func TestPruneTrackedStateAfterTTL(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	urls := trackedHistory(app, 2000, now.Add(-cacheTTL-48*time.Hour))
+	// The last 500 were seen within the TTL, and two early ones are listed again
+	recent := now.Add(-time.Hour)
+	for _, url := range urls[1500:] {
+		app.blockedPRTimes[url] = recent
+		app.stateManager.states[url].LastSeenBlocked = recent
+	}
+	app.incoming = []PR{{URL: urls[0]}}
+	app.filteredPRs = []PR{{URL: urls[1]}}
+
+	if n := app.pruneTrackedState(now); n != 1498 {
+		t.Errorf("pruned %d PRs, want 1498", n)
+	}
+	assertTracked(t, app, urls[0], true)
+	assertTracked(t, app, urls[1], true)
+	assertTracked(t, app, urls[2], false)
+	assertTracked(t, app, urls[1499], false)
+	assertTracked(t, app, urls[1500], true)
+
+	// The rest lapse once they've been gone for longer than the TTL
+	if n := app.pruneTrackedState(recent.Add(cacheTTL)); n != 0 {
+		t.Errorf("pruned %d PRs at the TTL, want 0", n)
+	}
+	if n := app.pruneTrackedState(recent.Add(cacheTTL + time.Minute)); n != 500 {
+		t.Errorf("pruned %d PRs after the TTL, want 500", n)
+	}
+	assertTracked(t, app, urls[0], true)
+	if got := len(app.stateManager.states); got != 2 {
+		t.Errorf("states left = %d, want the 2 listed PRs", got)
+	}
+	if got := len(app.janitor.lastListed); got != 2 {
+		t.Errorf("janitor clocks left = %d, want the 2 listed PRs", got)
+	}
+}
+
+func TestPruneTrackedStateCapEvictsOldestFirst(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.maxTrackedPRs = 100
+	now := time.Now()
+	urls := trackedHistory(app, 1000, now.Add(-24*time.Hour))
+	// 150 listed PRs exceed the cap on their own, yet stay
+	for _, url := range urls[:150] {
+		app.outgoing = append(app.outgoing, PR{URL: url})
+	}
+
+	if n := app.pruneTrackedState(now); n != 850 {
+		t.Errorf("pruned %d PRs, want 850", n)
+	}
+	for _, url := range urls[:150] {
+		assertTracked(t, app, url, true)
+	}
+	assertTracked(t, app, urls[150], false)
+	assertTracked(t, app, urls[999], false)
+
+	// With room to spare, the unlisted PRs gone longest go first; those that just left
+	// the lists stay
+	app.maxTrackedPRs = 200
+	app.outgoing = app.outgoing[:100]
+	trackedHistory(app, 1000, now.Add(-24*time.Hour))
+	if n := app.pruneTrackedState(now); n != 800 {
+		t.Errorf("pruned %d PRs, want 800", n)
+	}
+	assertTracked(t, app, urls[100], true)
+	assertTracked(t, app, urls[149], true)
+	assertTracked(t, app, urls[150], false)
+	assertTracked(t, app, urls[949], false)
+	assertTracked(t, app, urls[950], true)
+}
+
+func TestPruneTrackedStateOpenedTimes(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	listed := pinnablePR(1, false)
+	app.incoming = []PR{listed}
+	app.prOpenedAt = map[string]time.Time{
+		responseKey(listed.URL):               now.Add(-cacheTTL - time.Hour),
+		responseKey(pinnablePR(2, false).URL): now.Add(-cacheTTL - time.Hour),
+		responseKey(pinnablePR(3, false).URL): now.Add(-time.Hour),
+	}
+
+	app.pruneTrackedState(now)
+	if len(app.prOpenedAt) != 2 || app.prOpenedAt[responseKey(pinnablePR(2, false).URL)] != (time.Time{}) {
+		t.Errorf("opened times = %v, want the listed and recent PRs", app.prOpenedAt)
+	}
+}
+
+func TestPruneTrackedStateLookupCaches(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.pullDetails = newPRRevisionCache[pullDetails]()
+	app.questions = newPRRevisionCache[pendingQuestion]()
+	now := time.Now()
+	listed, gone := pinnablePR(1, false), pinnablePR(2, false)
+	app.incoming = []PR{listed}
+	for _, pr := range []PR{listed, gone} {
+		app.pullDetails.put(pr.URL, now.Add(-cacheTTL-time.Hour), pullDetails{baseBranch: "main"}, true)
+		app.questions.put(pr.URL, now.Add(-cacheTTL-time.Hour), pendingQuestion{}, false)
+	}
+
+	if n := app.pruneTrackedState(now); n != 1 {
+		t.Errorf("pruned %d PRs, want the unlisted one", n)
+	}
+	for _, pr := range []PR{listed, gone} {
+		_, _, cached := app.pullDetails.get(pr.URL, now.Add(-cacheTTL-time.Hour))
+		_, _, asked := app.questions.get(pr.URL, now.Add(-cacheTTL-time.Hour))
+		if want := pr.URL == listed.URL; cached != want || asked != want {
+			t.Errorf("%s: details cached %v, question cached %v, want %v", pr.URL, cached, asked, want)
+		}
+	}
+}
+
+func TestPruneTrackedStateQuarantine(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.quarantine = newPRQuarantine()
+	now := time.Now()
+	failedAt := now.Add(-cacheTTL - time.Hour)
+	app.quarantine.now = func() time.Time { return failedAt }
+	searched, gone, struck := pinnablePR(1, false), pinnablePR(2, false), pinnablePR(3, false)
+	notFound := errors.New("api request failed with status 404: not found")
+	for range quarantineStrikeThreshold {
+		app.quarantine.recordFailure(searched.URL, notFound)
+		app.quarantine.recordFailure(gone.URL, notFound)
+	}
+	// The search still lists one quarantined PR, and another just failed once
+	app.quarantine.now = func() time.Time { return now.Add(-time.Hour) }
+	app.quarantine.filter([]PR{searched})
+	app.quarantine.recordFailure(struck.URL, notFound)
+
+	app.pruneTrackedState(now)
+	if !app.quarantine.isQuarantined(searched.URL) {
+		t.Error("a quarantined PR the search still lists was pruned")
+	}
+	if _, ok := app.quarantine.entries[gone.URL]; ok {
+		t.Error("a quarantined PR no search has listed within the TTL was kept")
+	}
+	if _, ok := app.quarantine.entries[struck.URL]; !ok {
+		t.Error("a recent failure was pruned")
+	}
+}
+
+func TestPruneTrackedStateBackfillFailures(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.turnBackfill = newTurnBackfill()
+	now := time.Now()
+	listed, gone := pinnablePR(1, false), pinnablePR(2, false)
+	app.incoming = []PR{listed}
+	app.turnBackfill.recordFailure(listed.URL)
+	app.turnBackfill.recordFailure(gone.URL)
+
+	// The unlisted PR's clock starts at the first pass, and runs out after the TTL
+	if n := app.pruneTrackedState(now); n != 0 {
+		t.Errorf("pruned %d PRs on the first pass, want 0", n)
+	}
+	if n := app.pruneTrackedState(now.Add(cacheTTL + time.Minute)); n != 1 {
+		t.Errorf("pruned %d PRs after the TTL, want the unlisted one", n)
+	}
+	if app.turnBackfill.failureCount(listed.URL) != 1 || app.turnBackfill.failureCount(gone.URL) != 0 {
+		t.Errorf("failures = %v, want only the listed PR's", app.turnBackfill.failures)
+	}
+}
+
+func TestPruneTrackedStateSeenOrgs(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	app.seenOrgs = map[string]orgActivity{
+		"acme":    {LastSeenAt: now.Add(-time.Hour)},
+		"oldcorp": {LastSeenAt: now.Add(-orgForgetAfter - time.Hour)},
+		"policy":  {}, // Known only from a policy
+	}
+
+	app.pruneTrackedState(now)
+	if _, ok := app.seenOrgs["oldcorp"]; ok || len(app.seenOrgs) != 2 {
+		t.Errorf("orgs = %v, want the recent and policy-only ones", app.seenOrgs)
+	}
+	if !app.orgActivityDirty {
+		t.Error("dropping an org should mark the org activity for saving")
+	}
+}
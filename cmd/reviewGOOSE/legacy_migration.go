@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
+)
+
+const (
+	// legacyAppDirName is where the original ready-to-review binary kept its cache and settings.
+	legacyAppDirName = "ready-to-review"
+	// legacyMigrationMarker in the current cache dir records that the legacy directory was handled.
+	legacyMigrationMarker = ".legacy-migrated"
+)
+
+// Outcomes of migrating the legacy settings file, for the summary log line.
+const (
+	legacySettingsMigrated = "migrated"
+	legacySettingsKept     = "kept_existing" // The current profile already has settings.json
+	legacySettingsNone     = "none"
+	legacySettingsCorrupt  = "corrupt"
+)
+
+// legacyMigration moves settings out of the ready-to-review directories and prunes their stale cache.
+type legacyMigration struct {
+	legacyCacheDir  string
+	legacyConfigDir string
+	cacheDir        string // Current cache dir; holds the marker
+	settingsPath    string // Current settings.json
+	cacheTTL        time.Duration
+}
+
+// newLegacyMigration locates the legacy directories next to the current ones.
+func newLegacyMigration(cacheDir string) (*legacyMigration, error) {
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("get user cache dir: %w", err)
+	}
+	userConfig, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("get user config dir: %w", err)
+	}
+	settingsPath, err := appsettings.NewManager(defaultAppDirName).Path()
+	if err != nil {
+		return nil, err
+	}
+	return &legacyMigration{
+		legacyCacheDir:  filepath.Join(userCache, legacyAppDirName),
+		legacyConfigDir: filepath.Join(userConfig, legacyAppDirName),
+		cacheDir:        cacheDir,
+		settingsPath:    settingsPath,
+		cacheTTL:        cacheTTL,
+	}, nil
+}
+
+// pending reports whether a legacy directory exists and hasn't been migrated yet.
+func (lm *legacyMigration) pending() bool {
+	if _, err := os.Stat(filepath.Join(lm.cacheDir, legacyMigrationMarker)); err == nil {
+		return false
+	}
+	for _, dir := range []string{lm.legacyCacheDir, lm.legacyConfigDir} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateSettings copies the legacy settings.json into place unless the current profile
+// already has one. A corrupt legacy file is reported and left alone.
+func (lm *legacyMigration) migrateSettings() (string, error) {
+	if _, err := os.Stat(lm.settingsPath); err == nil {
+		return legacySettingsKept, nil
+	}
+
+	var data []byte
+	for _, dir := range []string{lm.legacyConfigDir, lm.legacyCacheDir} {
+		b, err := os.ReadFile(filepath.Join(dir, "settings.json"))
+		if err == nil {
+			data = b
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return legacySettingsCorrupt, fmt.Errorf("read legacy settings: %w", err)
+		}
+	}
+	if data == nil {
+		return legacySettingsNone, nil
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return legacySettingsCorrupt, fmt.Errorf("parse legacy settings: %w", err)
+	}
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return legacySettingsCorrupt, fmt.Errorf("marshal settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(lm.settingsPath), 0o700); err != nil {
+		return legacySettingsNone, fmt.Errorf("create settings directory: %w", err)
+	}
+	if err := appsettings.WriteAtomic(lm.settingsPath, out, 0o600); err != nil {
+		return legacySettingsNone, fmt.Errorf("write settings file: %w", err)
+	}
+	return legacySettingsMigrated, nil
+}
+
+// cleanupCache removes legacy cache entries older than the TTL, then the directory if it's empty.
+func (lm *legacyMigration) cleanupCache() (removed, errs int) {
+	if _, err := os.Stat(lm.legacyCacheDir); err != nil {
+		return 0, 0
+	}
+	removed, errs = prcache.NewManager(lm.legacyCacheDir).CleanupOldFiles(lm.cacheTTL)
+	if err := os.Remove(lm.legacyCacheDir); err == nil {
+		slog.Debug("[MIGRATE] Removed empty legacy cache directory", "dir", lm.legacyCacheDir)
+	}
+	return removed, errs
+}
+
+// finish writes the marker so the migration never runs again.
+func (lm *legacyMigration) finish() error {
+	if err := os.MkdirAll(lm.cacheDir, 0o700); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	path := filepath.Join(lm.cacheDir, legacyMigrationMarker)
+	if err := os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write migration marker: %w", err)
+	}
+	return nil
+}
+
+// migrateLegacyDirs brings settings over from ready-to-review before they're loaded, then prunes
+// the legacy cache in the background. Only the default profile ever used those directories.
+func (app *App) migrateLegacyDirs() {
+	if app.profileName != "" {
+		return
+	}
+	lm, err := newLegacyMigration(app.cacheDir)
+	if err != nil {
+		slog.Warn("[MIGRATE] Cannot locate legacy directories", "error", err)
+		return
+	}
+	if !lm.pending() {
+		return
+	}
+
+	status, err := lm.migrateSettings()
+	if err != nil {
+		slog.Warn("[MIGRATE] Legacy settings not migrated", "error", err)
+	}
+
+	app.goTracked("legacy cache cleanup", func() {
+		removed, errs := lm.cleanupCache()
+		if err := lm.finish(); err != nil {
+			slog.Warn("[MIGRATE] Failed to record legacy migration; it will retry next launch", "error", err)
+		}
+		slog.Info("[MIGRATE] Legacy ready-to-review directory handled",
+			"settings", status, "cache_removed", removed, "cache_errors", errs, "legacy_cache", lm.legacyCacheDir)
+	})
+}
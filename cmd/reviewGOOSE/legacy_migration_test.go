@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newLegacyFixture lays out a ready-to-review install under a temp dir.
+func newLegacyFixture(t *testing.T, legacySettings string) *legacyMigration {
+	t.Helper()
+	root := t.TempDir()
+	lm := &legacyMigration{
+		legacyCacheDir:  filepath.Join(root, "cache", legacyAppDirName),
+		legacyConfigDir: filepath.Join(root, "config", legacyAppDirName),
+		cacheDir:        filepath.Join(root, "cache", defaultAppDirName),
+		settingsPath:    filepath.Join(root, "config", defaultAppDirName, "settings.json"),
+		cacheTTL:        cacheTTL,
+	}
+	if err := os.MkdirAll(lm.legacyCacheDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if legacySettings != "" {
+		if err := os.MkdirAll(lm.legacyConfigDir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(lm.legacyConfigDir, "settings.json"), legacySettings)
+	}
+	return lm
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeCacheEntry writes a legacy Turn response last modified age ago.
+func writeCacheEntry(t *testing.T, dir, name string, age time.Duration) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	writeFile(t, path, `{}`)
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLegacyMigrationSettings(t *testing.T) {
+	lm := newLegacyFixture(t, `{"hidden_orgs":{"oldcorp":true},"enable_audio_cues":false,"hide_stale":true}`)
+	if !lm.pending() {
+		t.Fatal("legacy directory exists, migration should be pending")
+	}
+
+	status, err := lm.migrateSettings()
+	if err != nil || status != legacySettingsMigrated {
+		t.Fatalf("migrateSettings() = %q, %v; want %q", status, err, legacySettingsMigrated)
+	}
+
+	data, err := os.ReadFile(lm.settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Settings
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.HiddenOrgs["oldcorp"] || got.EnableAudioCues || !got.HideStale {
+		t.Errorf("migrated settings = %+v, want hidden oldcorp, honks off, hide stale on", got)
+	}
+}
+
+func TestLegacyMigrationKeepsExistingSettings(t *testing.T) {
+	lm := newLegacyFixture(t, `{"hide_stale":false}`)
+	if err := os.MkdirAll(filepath.Dir(lm.settingsPath), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, lm.settingsPath, `{"hide_stale":true}`)
+
+	status, err := lm.migrateSettings()
+	if err != nil || status != legacySettingsKept {
+		t.Fatalf("migrateSettings() = %q, %v; want %q", status, err, legacySettingsKept)
+	}
+	if data, _ := os.ReadFile(lm.settingsPath); string(data) != `{"hide_stale":true}` {
+		t.Errorf("existing settings were overwritten: %s", data)
+	}
+}
+
+func TestLegacyMigrationCorruptSettings(t *testing.T) {
+	for name, content := range map[string]string{
+		"truncated":  `{"hidden_orgs":{"oldcorp":tr`,
+		"wrong type": `{"hidden_orgs":["oldcorp"]}`,
+		"binary":     "\x00\x01\x02",
+	} {
+		t.Run(name, func(t *testing.T) {
+			lm := newLegacyFixture(t, content)
+			status, err := lm.migrateSettings()
+			if err == nil || status != legacySettingsCorrupt {
+				t.Fatalf("migrateSettings() = %q, %v; want %q with an error", status, err, legacySettingsCorrupt)
+			}
+			if _, err := os.Stat(lm.settingsPath); !os.IsNotExist(err) {
+				t.Errorf("corrupt legacy settings should not create settings.json: %v", err)
+			}
+			// A corrupt settings file must not block the rest of the migration.
+			writeCacheEntry(t, lm.legacyCacheDir, "old.json", 2*cacheTTL)
+			if removed, _ := lm.cleanupCache(); removed != 1 {
+				t.Errorf("cleanupCache() removed %d, want 1", removed)
+			}
+		})
+	}
+}
+
+func TestLegacyMigrationSettingsInCacheDir(t *testing.T) {
+	lm := newLegacyFixture(t, "")
+	writeFile(t, filepath.Join(lm.legacyCacheDir, "settings.json"), `{"enable_auto_browser":true}`)
+
+	status, err := lm.migrateSettings()
+	if err != nil || status != legacySettingsMigrated {
+		t.Fatalf("migrateSettings() = %q, %v; want %q", status, err, legacySettingsMigrated)
+	}
+}
+
+func TestLegacyMigrationCacheCleanup(t *testing.T) {
+	lm := newLegacyFixture(t, "")
+	writeCacheEntry(t, lm.legacyCacheDir, "stale-1.json", cacheTTL+time.Hour)
+	writeCacheEntry(t, lm.legacyCacheDir, "stale-2.json", 30*24*time.Hour)
+	writeCacheEntry(t, lm.legacyCacheDir, "fresh.json", time.Hour)
+
+	removed, errs := lm.cleanupCache()
+	if removed != 2 || errs != 0 {
+		t.Fatalf("cleanupCache() = %d removed, %d errors; want 2, 0", removed, errs)
+	}
+	if _, err := os.Stat(filepath.Join(lm.legacyCacheDir, "fresh.json")); err != nil {
+		t.Errorf("entries within the TTL should be kept: %v", err)
+	}
+
+	// Once everything has aged out the directory itself goes away.
+	writeCacheEntry(t, lm.legacyCacheDir, "fresh.json", 2*cacheTTL)
+	lm.cleanupCache()
+	if _, err := os.Stat(lm.legacyCacheDir); !os.IsNotExist(err) {
+		t.Errorf("empty legacy cache dir should be removed: %v", err)
+	}
+}
+
+func TestLegacyMigrationRunsOnce(t *testing.T) {
+	lm := newLegacyFixture(t, `{"hide_stale":true}`)
+	if _, err := lm.migrateSettings(); err != nil {
+		t.Fatal(err)
+	}
+	if err := lm.finish(); err != nil {
+		t.Fatal(err)
+	}
+	if lm.pending() {
+		t.Fatal("migration should not be pending after the marker is written")
+	}
+
+	// Even if the user deletes the new settings, the old ones don't come back.
+	if err := os.Remove(lm.settingsPath); err != nil {
+		t.Fatal(err)
+	}
+	if lm.pending() {
+		t.Error("marker should keep the migration from running again")
+	}
+}
+
+func TestLegacyMigrationNotPendingWithoutLegacyDir(t *testing.T) {
+	root := t.TempDir()
+	lm := &legacyMigration{
+		legacyCacheDir:  filepath.Join(root, "cache", legacyAppDirName),
+		legacyConfigDir: filepath.Join(root, "config", legacyAppDirName),
+		cacheDir:        filepath.Join(root, "cache", defaultAppDirName),
+		settingsPath:    filepath.Join(root, "config", defaultAppDirName, "settings.json"),
+	}
+	if lm.pending() {
+		t.Error("nothing to migrate without a legacy directory")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// shutdownTimeout bounds how long quitting waits for background goroutines.
+const shutdownTimeout = 5 * time.Second
+
+// lifecycle tracks the app's background goroutines so quitting can wait for them
+// to notice the cancelled context before state is flushed.
+type lifecycle struct {
+	cancel   context.CancelFunc
+	running  map[string]int // Live goroutines by component name
+	stopped  chan struct{}  // Closed when shutdown begins
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	stopping bool
+}
+
+// newLifecycle returns a lifecycle whose Shutdown calls cancel.
+func newLifecycle(cancel context.CancelFunc) *lifecycle {
+	return &lifecycle{cancel: cancel, running: make(map[string]int), stopped: make(chan struct{})}
+}
+
+// Go runs fn in a goroutine that Shutdown waits for. Once shutdown has begun it
+// starts nothing and returns false.
+func (l *lifecycle) Go(name string, fn func()) bool {
+	l.mu.Lock()
+	if l.stopping {
+		l.mu.Unlock()
+		slog.Debug("[LIFECYCLE] Not starting goroutine during shutdown", "component", name)
+		return false
+	}
+	l.running[name]++
+	l.wg.Add(1)
+	l.mu.Unlock()
+
+	go func() {
+		defer func() {
+			l.mu.Lock()
+			if l.running[name]--; l.running[name] == 0 {
+				delete(l.running, name)
+			}
+			l.mu.Unlock()
+			l.wg.Done()
+		}()
+		fn()
+	}()
+	return true
+}
+
+// Shutdown cancels the app context, waits up to timeout for tracked goroutines,
+// then runs flush whether or not they all stopped. It returns the components
+// still running at the deadline, which are logged and left behind.
+func (l *lifecycle) Shutdown(timeout time.Duration, flush func()) []string {
+	l.mu.Lock()
+	if !l.stopping {
+		close(l.stopped)
+	}
+	l.stopping = true
+	l.mu.Unlock()
+	l.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+
+	var stuck []string
+	select {
+	case <-done:
+		slog.Info("[LIFECYCLE] Background goroutines stopped")
+	case <-time.After(timeout):
+		l.mu.Lock()
+		stuck = slices.Sorted(maps.Keys(l.running))
+		l.mu.Unlock()
+		for _, name := range stuck {
+			slog.Warn("[LIFECYCLE] Component failed to stop before the shutdown deadline",
+				"component", name, "timeout", timeout)
+		}
+	}
+
+	flush()
+	return stuck
+}
+
+// goTracked runs fn in a goroutine the shutdown sequence waits for. It reports false,
+// starting nothing, once shutdown has begun.
+func (app *App) goTracked(name string, fn func()) bool {
+	if app.lifecycle == nil {
+		go fn()
+		return true
+	}
+	return app.lifecycle.Go(name, fn)
+}
+
+// shutdownStarted returns a channel closed once shutdown has begun, for tracked
+// goroutines that don't hold the app context. It never closes without a lifecycle.
+func (app *App) shutdownStarted() <-chan struct{} {
+	if app.lifecycle == nil {
+		return nil
+	}
+	return app.lifecycle.stopped
+}
+
+// goFunc starts a named goroutine, like App.goTracked, for components without an App.
+// The zero value starts an untracked one.
+type goFunc func(name string, fn func()) bool
+
+func (g goFunc) start(name string, fn func()) bool {
+	if g == nil {
+		go fn()
+		return true
+	}
+	return g(name, fn)
+}
+
+// flushState writes settings and persisted PR state synchronously before exit.
+func (app *App) flushState() {
+	app.saveSettings()
+	if app.trackingResponses() {
+		// A notification sent while shutting down may not have been saved yet
+		if err := app.responses.save(time.Now()); err != nil {
+			slog.Warn("[STATS] Failed to save stats", "error", err)
+		}
+	}
+	if app.stateManager != nil {
+		app.stateManager.FlushTestWatches()
+		app.stateManager.FlushDismissals()
+		app.stateManager.FlushPins()
+		app.stateManager.FlushHiddenPRs()
+	}
+	slog.Info("[LIFECYCLE] Flushed settings and PR state")
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShutdownHonorsDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := newLifecycle(cancel)
+
+	l.Go("update loop", func() { <-ctx.Done() })
+	release := make(chan struct{})
+	defer close(release)
+	l.Go("slow worker", func() { <-release }) // Ignores cancellation
+
+	flushed := false
+	start := time.Now()
+	stuck := l.Shutdown(100*time.Millisecond, func() { flushed = true })
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %v, want it bounded by the deadline", elapsed)
+	}
+	if !slices.Equal(stuck, []string{"slow worker"}) {
+		t.Errorf("stuck components = %q, want only the slow worker", stuck)
+	}
+	if !flushed {
+		t.Error("state wasn't flushed after the deadline passed")
+	}
+	if ctx.Err() == nil {
+		t.Error("Shutdown didn't cancel the app context")
+	}
+	if l.Go("late", func() { t.Error("a goroutine started after shutdown ran") }) {
+		t.Error("Go started a goroutine after shutdown began")
+	}
+}
+
+func TestShutdownWaitsForWorkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := newLifecycle(cancel)
+
+	stopped := make(chan struct{})
+	l.Go("sprinkler events", func() {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		close(stopped)
+	})
+
+	var flushedAfterStop bool
+	stuck := l.Shutdown(shutdownTimeout, func() {
+		select {
+		case <-stopped:
+			flushedAfterStop = true
+		default:
+		}
+	})
+	if len(stuck) != 0 {
+		t.Errorf("stuck components = %q, want none", stuck)
+	}
+	if !flushedAfterStop {
+		t.Error("flush ran before the worker stopped")
+	}
+}
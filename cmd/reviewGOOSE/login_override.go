@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// A fine-grained token scoped to one org can be refused /user while searches with an
+// explicit username work fine. Given a login, by GOOSE_LOGIN or -user, goose carries on
+// without currentUser: searches and Turn lookups use that login, org memberships aren't
+// looked up, so the sprinkler watches the orgs seen in fetched PRs, and the tooltip
+// says the login wasn't confirmed by GitHub.
+
+// loginOverrideEnv names the login to use when GitHub won't say who the token belongs to.
+const loginOverrideEnv = "GOOSE_LOGIN"
+
+// resolveLoginFallback returns GOOSE_LOGIN, or the -user flag when it isn't set.
+func resolveLoginFallback(targetUser string) (string, error) {
+	login := os.Getenv(loginOverrideEnv)
+	if login == "" {
+		return targetUser, nil
+	}
+	if err := validateGitHubUsername(login); err != nil {
+		return "", fmt.Errorf("%s: %w", loginOverrideEnv, err)
+	}
+	return login, nil
+}
+
+// permissionDenied reports whether GitHub refused a request with 403 Forbidden.
+func permissionDenied(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusForbidden
+}
+
+// useLoginOverride switches to the fallback login after /user failed with err. It reports
+// false, leaving the failure an auth error, unless GitHub refused the lookup with 403
+// and a fallback login was given.
+func (app *App) useLoginOverride(err error) bool {
+	if app.loginFallback == "" || !permissionDenied(err) {
+		return false
+	}
+	app.mu.Lock()
+	app.loginOverride = app.loginFallback
+	if app.targetUser == "" {
+		app.targetUser = app.loginFallback
+	}
+	app.mu.Unlock()
+	slog.Warn("[GITHUB] Token can't read /user, continuing with the login override",
+		"login", sanitizeForLog(app.loginFallback), "error", err)
+	return true
+}
+
+// login returns the authenticated user's login, or the login override when GitHub
+// wouldn't say, or "" before either is known.
+func (app *App) login() string {
+	if app.currentUser != nil {
+		return app.currentUser.GetLogin()
+	}
+	return app.loginOverride
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// newOrgTokenTestApp serves a GitHub API that refuses /user with 403, as it does for a
+// token scoped to one org, but answers searches, and a Turn API that blocks testuser.
+// It returns the app and the users Turn was asked about.
+func newOrgTokenTestApp(t *testing.T) (app *App, turnUsers func() []string) {
+	t.Helper()
+	now := time.Now()
+	api := newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		if r.URL.Path == "/user" {
+			return fakeSearch{status: http.StatusForbidden, message: "Resource not accessible by personal access token"}
+		}
+		if !strings.Contains(r.URL.Query().Get("q"), "involves:testuser") {
+			return fakeSearch{}
+		}
+		return fakeSearch{prs: []fakePR{{repo: "org/repo", number: 1, updated: now.Add(-time.Hour)}}}
+	})
+
+	var mu sync.Mutex
+	var users []string
+	turnClient := newFakeTurn(t, func(_ context.Context, req turn.CheckRequest) fakeVerdict {
+		mu.Lock()
+		users = append(users, req.User)
+		mu.Unlock()
+		return fakeVerdict{next: map[string]any{"testuser": map[string]any{"kind": "review", "critical": true}}}
+	})
+
+	app = newFetchTestApp(t, api.URL, turnClient, "testuser")
+	app.currentUser = nil
+	app.noCache = true
+	return app, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), users...)
+	}
+}
+
+func TestLoginOverrideWhenUserRefused(t *testing.T) {
+	ctx := context.Background()
+	app, turnUsers := newOrgTokenTestApp(t)
+	app.loginFallback = "testuser"
+
+	app.loadCurrentUser(ctx)
+	if app.authError != "" {
+		t.Fatalf("authError = %q, want the login override to be used", app.authError)
+	}
+	if app.currentUser != nil || app.login() != "testuser" || app.targetUser != "testuser" {
+		t.Fatalf("currentUser = %v, login() = %q, targetUser = %q, want only the override", app.currentUser, app.login(), app.targetUser)
+	}
+
+	app.updatePRs(ctx)
+	app.mu.RLock()
+	incoming, failures := app.incoming, app.consecutiveFailures
+	app.mu.RUnlock()
+	if failures != 0 || len(incoming) != 1 || !incoming[0].NeedsReview {
+		t.Fatalf("after a fetch: incoming = %+v, failures = %d, want #1 needing my review", incoming, failures)
+	}
+	users := turnUsers()
+	if len(users) == 0 {
+		t.Fatal("no Turn lookups")
+	}
+	for _, user := range users {
+		if user != "testuser" {
+			t.Errorf("Turn was asked about %q, want the override", user)
+		}
+	}
+	if got, want := app.baseTooltip(), msg("tray.tooltip.user", "testuser")+" "+msg("tray.tooltip.login_override"); got != want {
+		t.Errorf("baseTooltip() = %q, want %q", got, want)
+	}
+}
+
+func TestUserRefusedWithoutOverride(t *testing.T) {
+	app, _ := newOrgTokenTestApp(t)
+	// Without an override the 403 is retried; cut the backoff short
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	app.loadCurrentUser(ctx)
+	if app.authError == "" {
+		t.Fatal("no auth error without a login override")
+	}
+	if app.login() != "" {
+		t.Errorf("login() = %q, want none", app.login())
+	}
+}
+
+func TestResolveLoginFallback(t *testing.T) {
+	tests := []struct {
+		env, flag, want string
+		wantErr         bool
+	}{
+		{flag: "alice", want: "alice"},
+		{env: "bob", flag: "alice", want: "bob"},
+		{env: "not a login", wantErr: true},
+		{},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.env, tt.flag), func(t *testing.T) {
+			t.Setenv(loginOverrideEnv, tt.env)
+			got, err := resolveLoginFallback(tt.flag)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("resolveLoginFallback(%q) = %q, %v, want %q", tt.flag, got, err, tt.want)
+			}
+		})
+	}
+}
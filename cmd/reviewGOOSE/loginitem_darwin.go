@@ -175,11 +175,11 @@ func addLoginItemUI(ctx context.Context, app *App) {
 	}
 
 	// Use cached state for menu display (fast, non-blocking).
-	text := "Start at Login"
+	text := msg("settings.start_at_login")
 	if loginItemEnabled() {
 		text = "✓ Start at Login"
 	}
-	item := systray.AddMenuItem(text, "Automatically start when you log in")
+	item := systray.AddMenuItem(text, msg("settings.start_at_login.tooltip"))
 
 	item.Click(func() {
 		// Prevent concurrent toggle operations.
@@ -4,7 +4,7 @@ package main
 
 import "context"
 
-// addLoginItemUI is a no-op on non-macOS platforms.
-func addLoginItemUI(_ context.Context, _ *App) {
-	// Login items are only supported on macOS
+// addLoginItemUI adds the "Start at Login" toggle backed by the platform's startup store.
+func addLoginItemUI(ctx context.Context, app *App) {
+	app.addStartupItem(ctx)
 }
@@ -11,6 +11,7 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -20,7 +21,9 @@ import (
 	"time"
 
 	"github.com/codeGROOVE-dev/goose/cmd/reviewGOOSE/x11tray"
+	"github.com/codeGROOVE-dev/goose/pkg/hostarch"
 	"github.com/codeGROOVE-dev/goose/pkg/logging"
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
 	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
 	"github.com/codeGROOVE-dev/retry"
 	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
@@ -55,10 +58,11 @@ func appVersion() string {
 }
 
 // logDir returns the platform-appropriate directory for application logs.
+// appDir is reviewGOOSE for the default profile (see appDirName).
 // - macOS: ~/Library/Logs/reviewGOOSE.
 // - Linux: ~/.local/state/reviewGOOSE (or $XDG_STATE_HOME/reviewGOOSE if set).
 // - Windows: %LOCALAPPDATA%\reviewGOOSE\Logs.
-func logDir() (string, error) {
+func logDir(appDir string) (string, error) {
 	var dir string
 
 	switch runtime.GOOS {
@@ -68,7 +72,7 @@ func logDir() (string, error) {
 		if err != nil {
 			return "", err
 		}
-		dir = filepath.Join(home, "Library", "Logs", "reviewGOOSE")
+		dir = filepath.Join(home, "Library", "Logs", appDir)
 
 	case "windows":
 		// Windows: use %LOCALAPPDATA%\reviewGOOSE\Logs
@@ -76,7 +80,7 @@ func logDir() (string, error) {
 		if localAppData == "" {
 			return "", errors.New("LOCALAPPDATA environment variable not set")
 		}
-		dir = filepath.Join(localAppData, "reviewGOOSE", "Logs")
+		dir = filepath.Join(localAppData, appDir, "Logs")
 
 	default:
 		// Linux and other Unix: use XDG_STATE_HOME or ~/.local/state
@@ -88,7 +92,7 @@ func logDir() (string, error) {
 			}
 			stateHome = filepath.Join(home, ".local", "state")
 		}
-		dir = filepath.Join(stateHome, "reviewGOOSE")
+		dir = filepath.Join(stateHome, appDir)
 	}
 
 	return dir, nil
@@ -103,7 +107,6 @@ const (
 	maxPRsToProcess           = 200
 	minUpdateInterval         = 10 * time.Second
 	defaultUpdateInterval     = 2 * time.Minute
-	blockedPRIconDuration     = 5 * time.Minute
 	maxRetryDelay             = 2 * time.Minute
 	maxRetries                = 10
 	minorFailureThreshold     = 3
@@ -111,8 +114,9 @@ const (
 	panicFailureIncrement     = 10
 	turnAPITimeout            = 10 * time.Second
 	maxConcurrentTurnAPICalls = 20
+	updateCycleTimeoutFactor  = 3 // Abandon an update cycle after this many update intervals
 	defaultMaxBrowserOpensDay = 20
-	startupGracePeriod        = 1 * time.Minute // Don't play sounds or auto-open for first minute
+	startupGracePeriod        = 1 * time.Minute // Default for -grace-period: no notifications, sounds, or auto-opens
 	authRetryInterval         = 2 * time.Minute // Retry authentication periodically when in error state
 	ancientPRThreshold        = 24 * time.Hour  // Refuse to notify for PRs with no activity in this long (safety check)
 )
@@ -134,19 +138,44 @@ type PR struct {
 	TurnDataAppliedAt time.Time
 	FirstBlockedAt    time.Time // When this PR was first detected as blocked
 	LastActivityAt    time.Time // Most recent activity timestamp from Turn API (includes test completions)
+	WaitingSince      time.Time // When WaitingOn's action became due
+	ActionSince       time.Time // When my next action on this PR became due, per the Turn API
+	RequestedAt       time.Time // When RequestedBy asked for my review
+	PendingReviewAt   time.Time // When my unsubmitted review on this incoming PR was first seen
 	Title             string
 	URL               string
 	Repository        string
 	Author            string // GitHub username of the PR author
+	LastActivityKind  string // Kind of the most recent activity from Turn API: "review", "push", "comment", etc.
+	LastActivityActor string // Who performed the most recent activity
+	WaitingOn         string // On my own PRs: the first person other than me with a next action
+	WaitingOnKind     string // WaitingOn's action kind: "review", "approve", etc.
 	ActionReason      string
-	ActionKind        string // The kind of action expected (review, merge, fix_tests, etc.)
-	TestState         string // Test state from Turn API: "running", "passing", "failing", etc.
-	WorkflowState     string // Workflow state from Turn API: "running_tests", "waiting_for_review", etc.
+	ActionKind        ActionKind    // The kind of action expected (review, merge, fix_tests, etc.)
+	TestState         string        // Test state from Turn API: "running", "passing", "failing", etc.
+	WorkflowState     string        // Workflow state from Turn API: "running_tests", "waiting_for_review", etc.
+	MyReviewState     string        // My latest review still covering the head commit: "approved", "changes_requested", "commented", or ""
+	RequestedBy       string        // On incoming PRs: who requested my review, from the issue timeline
+	BaseBranch        string        // Branch the PR targets; only looked up for blocked PRs
+	PendingQuestion   string        // On my PRs awaiting my response: an excerpt of the review comment to answer
+	QuestionBy        string        // Who asked PendingQuestion
+	QuestionURL       string        // Link to PendingQuestion's comment
+	Size              string        // Size class from Turn API, e.g. "S" or "XL"
+	FailingCheck      string        // First failing check by name, from Turn API
+	Labels            []string      // Label names, for the filter rules
+	BlockedOn         []string      // In repo and team mode: the watched users with a next action
+	TestsStuckFor     time.Duration // How long tests have been running, once past the stuck threshold
 	Number            int
+	WaitingOnCount    int // People other than me with a next action on my PR, bots excluded
+	Comments          int // Comment count from the search results
 	IsDraft           bool
 	IsBlocked         bool
 	NeedsReview       bool
 	AuthorBot         bool // True if the author is a bot (dependabot, renovate, etc.)
+	RequestedAuto     bool // My review was requested by CODEOWNERS, a team, or automation
+	IsNonDefaultBase  bool // BaseBranch isn't the repository's default branch, e.g. a release branch
+	Dismissed         bool // I marked it "Not my review", so its action doesn't count as blocking
+	Snoozed           bool // Snoozed with the other incoming PRs blocked on me until tomorrow morning
 }
 
 // App holds the application state.
@@ -154,59 +183,190 @@ type App struct {
 	lastSearchAttempt            time.Time
 	lastSuccessfulFetch          time.Time
 	startTime                    time.Time
+	batchOpenArmedAt             time.Time // When "Open all blocked" was clicked once, awaiting confirmation
 	systrayInterface             SystrayInterface
+	notifier                     Notifier
+	soundPlayer                  SoundPlayer
+	browser                      BrowserOpener
+	clipboard                    Clipboard
 	browserRateLimiter           *ratelimit.BrowserRateLimiter
 	blockedPRTimes               map[string]time.Time
 	currentUser                  *github.User
 	stateManager                 *PRStateManager
 	client                       *github.Client
 	hiddenOrgs                   map[string]bool
-	seenOrgs                     map[string]bool
+	silentOrgs                   map[string]bool
+	seenOrgs                     map[string]orgActivity
+	orgActivitySaved             map[string]time.Time // Each org's LastSeenAt as last written to settings
+	prOpenedAt                   map[string]time.Time // By responseKey: when each PR was last opened from goose
 	turnClient                   *turn.Client
 	sprinklerMonitor             *sprinklerMonitor
 	previousBlockedPRs           map[string]bool
+	eventPRs                     map[string]*pendingEventPR // By URL: PRs added from sprinkler events that no poll has returned yet
 	githubCircuit                *circuitBreaker
+	githubAPIURL                 *url.URL // Set by -github-api-url; nil talks to api.github.com
+	githubStatus                 *githubStatusChecker
+	githubIncident               *githubIncident         // Set while failures coincide with a GitHub-wide incident
+	partialFetch                 *PartialError           // Set while one of the searches fails but the other succeeds
+	searchUnavailable            *SearchUnavailableError // Set while GitHub answers a search with 410 or 451
+	cappedSearches               map[string]bool         // Searches that hit the 1,000-result ceiling; narrowed for the session
 	healthMonitor                *healthMonitor
+	dockBadge                    dockBadger       // Nil without a Dock
+	startup                      *startupEntry    // "Start at Login" outside macOS; nil where unsupported
+	snooze                       *incomingSnooze  // Set by "Snooze incoming until tomorrow"; nil when nothing is snoozed
+	session                      *reviewSession   // The review session in progress, if any
+	sessionBudget                manualOpenBudget // Review sessions' own browser open allowance, made by the first session
+	storage                      *storageHealth
+	quarantine                   *prQuarantine
+	searchCache                  *searchCache
+	turnMemory                   *turnMemory // In-memory Turn responses consulted before the disk cache
+	cacheFS                      prcache.FS  // Disk cache filesystem; nil uses the os package
+	workflowApprovals            *workflowApprovalCache
+	reviewRequests               *prRevisionCache[reviewRequest]
+	pendingReviews               *pendingReviewCache               // My unsubmitted reviews on incoming PRs
+	pullDetails                  *prRevisionCache[pullDetails]     // Base branches of blocked PRs
+	questions                    *prRevisionCache[pendingQuestion] // Open review questions on my PRs awaiting my response
+	notifications                *notificationHistory              // What goose told me, for "Recent notifications"
+	notifyQueue                  *notificationQueue                // Notifications waiting for the notification service to come back
+	stateFile                    *stateFile                        // -state-file output for status bars
+	turnBackfill                 *turnBackfill
+	quietCycles                  *quietCycles
+	intervals                    *adaptiveInterval // The update loop's interval, stretched while quiet in adaptive mode
+	dashboard                    *dashboardConfig
+	hook                         *notificationHook
+	arch                         hostarch.Result // The build and host architectures, checked at startup
 	cacheDir                     string
 	lastFetchError               string
+	lastFetchErr                 error // The error behind lastFetchError, for the tray tooltip's hint
 	authError                    string
 	targetUser                   string
+	loginFallback                string            // GOOSE_LOGIN or -user: the login to carry on with when /user is refused
+	loginOverride                string            // loginFallback once /user was refused; currentUser stays nil
+	profileName                  string            // Set by -profile-name; empty for the default profile
+	focusRepo                    string            // Transient: when set, only this repository's PRs are shown and notified
+	dashboardURLSetting          string            // dashboard_url from settings; DASHBOARD_URL takes precedence
+	dashboardPRTemplateSetting   string            // dashboard_pr_template from settings; DASHBOARD_PR_TEMPLATE takes precedence
+	localeSetting                string            // Catalog locale from settings; empty auto-detects from LANG
+	notificationHookSetting      string            // notification_hook from settings; config file only
+	notifyTemplates              map[string]string // Valid notification_templates from settings, by action kind
+	settingsResetBackup          string            // Where a corrupt settings file was moved; shown with settingsReset
+	dockBadgeShown               string            // The Dock badge label last set
+	snoozeClock                  string            // snooze_until from settings: when "Snooze incoming" ends, as 15:04
+	digestClock                  string            // digest_at from settings: when the daily digest goes out, as 15:04
+	reviewSLA                    time.Duration     // review_sla from settings: how long a PR may wait on my review
+	displayMode                  DisplayMode
+	incomingSort                 IncomingSort
+	highlight                    HighlightWindow
+	openedURLParam               URLParam // opened_url_param from settings: what goose appends to opened links
 	lastMenuTitles               []string
+	lastSectionTitles            map[string][]string          // Per-section PR titles at the last menu change
+	sectionChangedAt             map[string]time.Time         // When each section's PRs last changed
+	sectionHeaders               map[string]sectionHeaderItem // Built section headers, refreshed as their suffix ages
+	responses                    *responseTracker
+	slowTurnCalls                []turnTiming    // Slowest Turn lookups of the last cycle, for the diagnostic report
+	recentErrors                 []recordedError // Newest last; capped at maxRecentErrors for the diagnostic report
 	outgoing                     []PR
 	incoming                     []PR
+	filteredPRs                  []PR            // PRs the filter rules moved out of incoming and outgoing
+	filters                      []FilterRule    // From settings.json; read-only in the menu
+	repos                        []string        // From settings.json: repo mode watches these instead of searching by user
+	repoModeUsers                []string        // From settings.json: in repo mode, whose next actions count as blocked
+	team                         []string        // From settings.json: team mode shows these teammates' blocked PRs
+	teamFailed                   map[string]bool // Team mode: teammates whose searches failed last cycle
+	lifecycle                    *lifecycle      // Background goroutines the shutdown sequence waits for
+	orgSync                      orgSyncState    // Org membership between sprinkler syncs
+	janitor                      stateJanitor    // When PRs with long-running state were last listed
+	tray                         trayIconState   // The icon on screen, redrawn when the color scheme changes
+	trayFlash                    trayFlashState  // A notification shown on the tray while no notification service runs
+	trayHistory                  trayHistory     // The latest tray icon changes, for the diagnostic report
+	journal                      changeJournal   // What the latest update cycles changed, for the log and the diagnostic report
+	autoOpenSchedule             weeklySchedule  // auto_open_hours and auto_open_timezone from settings: when auto-open may open tabs
+	turnWave                     *turnWave       // The first load's deferred Turn lookups, until they're applied
 	updateInterval               time.Duration
+	stuckTestsThreshold          time.Duration // Running tests older than this count as stuck; 0 uses the default
+	gracePeriod                  time.Duration // No notifications, sounds, or auto-opens this soon after startup; 0 uses the default
+	firstTurnWaveBudget          time.Duration // How long the first menu waits for likely-blocked PRs' Turn data; 0 uses the default
 	consecutiveFailures          int
+	updateGeneration             uint64 // Incremented when a full update cycle starts; stale backfills check it
+	menuLabelWidth               int    // 0: defaultMenuLabelWidth, negative: no truncation
+	sessionCap                   int    // review_session_cap from settings: PRs a review session queues; 0 uses the default
+	commentBurstMin              int    // comment_burst_threshold from settings: new comments that make a burst; 0 uses the default
+	maxTrackedPRs                int    // max_tracked_prs from settings: PRs whose state is kept in memory; 0 uses the default
+	settingsSchemaLoaded         int    // schema_version of the loaded settings file; saves never lower it
 	mu                           sync.RWMutex
 	updateMutex                  sync.Mutex
 	menuMutex                    sync.Mutex
+	trayPresent                  sync.Mutex // Serializes presentTrayState, so icon, title, and tooltip match
 	hideStaleIncoming            bool
+	hideIncoming                 bool // Incoming section is hidden from the menu, counts, and notifications
+	hideOutgoing                 bool // Outgoing section is hidden from the menu, counts, and notifications
+	digestEnabled                bool // daily_digest from settings: send one digest notification a day
+	digestWeekdaysOnly           bool // digest_weekdays_only from settings: no digest on Saturday or Sunday
+	commentBurstNotify           bool // comment_burst_notifications from settings: notify quietly about comment bursts
+	adaptiveInterval             bool // adaptive_interval from settings: stretch the update interval while nothing changes
 	hasPerformedInitialDiscovery bool
 	noCache                      bool
 	enableAudioCues              bool
 	initialLoadComplete          bool
 	menuInitialized              bool
 	enableAutoBrowser            bool
+	enableRefreshAnimation       bool
+	countRepos                   bool // Tray title counts repos with blocked PRs instead of PRs
+	groupBotPRs                  bool // Collapse each repository's bot PRs into one menu entry
+	showDockBadge                bool // macOS: badge the Dock icon with the incoming blocked count
+	trackResponseTimes           bool // Opt-in: record notification-to-open times in the local stats file
+	draftsBlock                  bool // Count Turn actions on draft PRs as blocking (off: informational only)
+	hideNonDefaultBase           bool // Filter out PRs targeting a branch other than the repository's default
+	forceNextRefresh             bool // Set by a user-triggered refresh; consumed by the next fetch
+	silentMode                   bool // No notifications, sounds, or browser opens (-silent or GOOSE_SILENT=1)
+	orgActivityDirty             bool // seenOrgs changed enough to be saved with the settings
+	settingsReset                bool // Corrupt settings were replaced by defaults; cleared once the notice is dismissed
+	archWarningDismissed         bool // The architecture mismatch warning was dismissed for good
+	crashed                      bool // The update loop panicked and the app is quitting
 }
 
 //nolint:maintidx // Main function complexity is acceptable for initialization logic
 func main() {
 	// Parse command line flags
 	var targetUser string
+	var profileName string
 	var noCache bool
 	var debugMode bool
 	var showVersion bool
+	var silent bool
 	var updateInterval time.Duration
+	var stuckTestsThreshold time.Duration
+	var quietSkipWindow time.Duration
 	var browserOpenDelay time.Duration
+	var gracePeriod time.Duration
 	var maxBrowserOpensMinute int
 	var maxBrowserOpensDay int
-	flag.StringVar(&targetUser, "user", "", "GitHub user to query PRs for (defaults to authenticated user)")
+	var metricsPort int
+	var stateFilePath string
+	var githubAPIURL string
+	var logMaxDays int
+	var logMaxMB int
+	flag.StringVar(&targetUser, "user", "", "GitHub user to query PRs for (defaults to authenticated user, or GOOSE_LOGIN if the token can't read it)")
+	flag.StringVar(&profileName, "profile-name", "", "Isolate cache, logs, and settings under this name (a-z, 0-9, -) to run instances side by side")
 	flag.BoolVar(&noCache, "no-cache", false, "Bypass cache for debugging")
 	flag.BoolVar(&debugMode, "debug", false, "Enable debug logging")
+	flag.BoolVar(&silent, "silent", false, "Disable notifications, sounds, and browser opens (also GOOSE_SILENT=1)")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit")
 	flag.DurationVar(&updateInterval, "interval", defaultUpdateInterval, "Update interval (e.g. 30s, 1m, 5m)")
+	flag.DurationVar(&stuckTestsThreshold, "stuck-tests-threshold", defaultStuckTestsThreshold,
+		"How long tests may run before your PR is flagged as stuck (e.g. 90m, 2h)")
+	flag.DurationVar(&quietSkipWindow, "quiet-skip-window", defaultQuietSkipWindow,
+		"Skip scheduled fetches for this long after the last one while the sprinkler reports no changes (0 disables)")
 	flag.DurationVar(&browserOpenDelay, "browser-delay", 1*time.Minute, "Minimum delay before opening PRs in browser after startup")
+	flag.DurationVar(&gracePeriod, "grace-period", startupGracePeriod,
+		"Hold back notifications, sounds, and auto-opens for this long after startup")
 	flag.IntVar(&maxBrowserOpensMinute, "browser-max-per-minute", 2, "Maximum browser windows to open per minute")
 	flag.IntVar(&maxBrowserOpensDay, "browser-max-per-day", defaultMaxBrowserOpensDay, "Maximum browser windows to open per day")
+	flag.IntVar(&metricsPort, "metrics-port", 0, "Serve Prometheus metrics on localhost at this port (0 disables)")
+	flag.StringVar(&githubAPIURL, "github-api-url", "", "GitHub API base URL, e.g. a local caching proxy; http is allowed only on loopback")
+	flag.StringVar(&stateFilePath, "state-file", "", "Write blocked counts and the tray icon as JSON to this path, for status bars like waybar")
+	flag.IntVar(&logMaxDays, "log-max-days", logging.DefaultMaxAge, "Days of log files to keep")
+	flag.IntVar(&logMaxMB, "log-max-mb", logging.DefaultMaxTotalSize>>20, "Total size of all log files to keep, in MB")
 	flag.Parse()
 
 	// Handle version flag
@@ -223,6 +383,27 @@ func main() {
 		}
 	}
 
+	fallbackLogin, err := resolveLoginFallback(targetUser)
+	if err != nil {
+		slog.Error("Invalid login override", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validateProfileName(profileName); err != nil {
+		slog.Error("Invalid profile name", "error", err)
+		os.Exit(1)
+	}
+	appDir := appDirName(profileName)
+
+	var githubAPIBase *url.URL
+	if githubAPIURL != "" {
+		var err error
+		if githubAPIBase, err = parseAPIBaseURL(githubAPIURL); err != nil {
+			slog.Error("Invalid GitHub API URL", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Validate update interval
 	if updateInterval < minUpdateInterval {
 		slog.Warn("Update interval too short, using minimum", "requested", updateInterval, "minimum", minUpdateInterval)
@@ -242,6 +423,10 @@ func main() {
 		slog.Warn("Invalid browser-delay, using default", "invalid", browserOpenDelay, "default", "1m")
 		browserOpenDelay = 1 * time.Minute
 	}
+	if gracePeriod < 0 {
+		slog.Warn("Invalid grace-period, using default", "invalid", gracePeriod, "default", startupGracePeriod)
+		gracePeriod = startupGracePeriod
+	}
 
 	// Set up structured logging with source location
 	logLevel := slog.LevelInfo
@@ -249,10 +434,11 @@ func main() {
 		logLevel = slog.LevelDebug
 	}
 	opts := &slog.HandlerOptions{AddSource: true, Level: logLevel, ReplaceAttr: simplifySource}
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, opts)))
+	slog.SetDefault(profileLogger(slog.NewTextHandler(os.Stderr, opts), profileName))
 	slog.Info("Starting Goose", "version", appVersion(), "commit", commit, "date", date)
 	slog.Info("Configuration", "update_interval", updateInterval, "max_retries", maxRetries, "max_delay", maxRetryDelay)
 	slog.Info("Browser auto-open configuration",
+		"grace_period", gracePeriod,
 		"startup_delay", browserOpenDelay,
 		"max_per_minute", maxBrowserOpensMinute,
 		"max_per_day", maxBrowserOpensDay)
@@ -264,65 +450,140 @@ func main() {
 		slog.Error("Failed to get cache directory", "error", err)
 		os.Exit(1)
 	}
-	cacheDir = filepath.Join(cacheDir, "reviewGOOSE")
+	cacheDir = filepath.Join(cacheDir, appDir)
 	const dirPerm = 0o700 // Only owner can access cache directory
+	storage := newStorageHealth()
 	if err := os.MkdirAll(cacheDir, dirPerm); err != nil {
-		slog.Error("Failed to create cache directory", "error", err)
-		os.Exit(1)
+		// Persistence is best-effort; cache writes will fail and fall back to memory.
+		storage.recordFailure("cache", err)
 	}
 
 	// Set up file-based logging in platform-appropriate location
-	logDirectory, err := logDir()
+	logDirectory, err := logDir(appDir)
 	if err != nil {
 		slog.Error("Failed to determine log directory", "error", err)
 		// Continue without file logging
 	} else if err := os.MkdirAll(logDirectory, dirPerm); err != nil {
-		slog.Error("Failed to create log directory", "error", err)
+		storage.recordFailure("log", err)
 		// Continue without file logging
 	} else {
-		// Create log file with daily rotation
-		logPath := filepath.Join(logDirectory, fmt.Sprintf("goose-%s.log", time.Now().Format("2006-01-02")))
-		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		// Daily log files, rolled over by size and pruned to the retention limits
+		logFile, err := logging.NewRotatingWriter(logDirectory, logging.RotateOptions{
+			MaxAge:       max(logMaxDays, 1),
+			MaxTotalSize: int64(max(logMaxMB, 1)) << 20,
+		})
 		if err != nil {
-			slog.Error("Failed to open log file", "error", err)
+			storage.recordFailure("log", err)
 		} else {
 			// Update logger to write to both stderr and file
 			multiHandler := logging.NewMultiHandler(
 				slog.NewTextHandler(os.Stderr, opts),
 				slog.NewTextHandler(logFile, opts),
 			)
-			slog.SetDefault(slog.New(multiHandler))
-			slog.Info("Logs are being written to", "path", logPath)
+			slog.SetDefault(profileLogger(multiHandler, profileName))
+			slog.Info("Logs are being written to", "path", logFile.Path(), "max_days", max(logMaxDays, 1), "max_mb", max(logMaxMB, 1))
 		}
 	}
 
+	// One instance per profile; differently named profiles lock separate cache dirs
+	releaseLock := func() {}
+	if release, err := acquireInstanceLock(cacheDir); err == nil {
+		releaseLock = release
+	} else if errors.Is(err, errInstanceRunning) {
+		// The PID may have been reused by an unrelated process, so this isn't fatal
+		slog.Warn("goose appears to be running already with this profile, continuing", "cache_dir", cacheDir, "error", err,
+			"help", "Quit the other instance, or use -profile-name to run a separate one")
+	} else {
+		slog.Warn("Failed to acquire instance lock, continuing without it", "error", err)
+	}
+
 	startTime := time.Now()
+	stateManager := NewPRStateManager(startTime)
+	stateManager.LoadTestWatches(filepath.Join(cacheDir, testWatchFileName))
+	stateManager.LoadDismissals(filepath.Join(cacheDir, dismissedFileName))
+	stateManager.LoadPins(filepath.Join(cacheDir, pinnedFileName))
+	stateManager.LoadHiddenPRs(filepath.Join(cacheDir, hiddenPRsFileName))
+	stateManager.gracePeriod = gracePeriod // Polled notifications wait out the same grace period
 	app := &App{
-		cacheDir:           cacheDir,
-		hideStaleIncoming:  true,
-		stateManager:       NewPRStateManager(startTime), // NEW: Simplified state tracking
-		targetUser:         targetUser,
-		noCache:            noCache,
-		updateInterval:     updateInterval,
-		enableAudioCues:    true,
-		enableAutoBrowser:  false, // Default to false for safety
-		browserRateLimiter: ratelimit.NewBrowserRateLimiter(browserOpenDelay, maxBrowserOpensMinute, maxBrowserOpensDay),
-		startTime:          startTime,
-		systrayInterface:   &RealSystray{}, // Use real systray implementation
-		seenOrgs:           make(map[string]bool),
-		hiddenOrgs:         make(map[string]bool),
+		cacheDir:               cacheDir,
+		profileName:            profileName,
+		hideStaleIncoming:      true,
+		stateManager:           stateManager,
+		gracePeriod:            gracePeriod,
+		targetUser:             targetUser,
+		loginFallback:          fallbackLogin,
+		githubAPIURL:           githubAPIBase,
+		noCache:                noCache,
+		updateInterval:         updateInterval,
+		stuckTestsThreshold:    stuckTestsThreshold,
+		enableAudioCues:        true,
+		enableAutoBrowser:      false, // Default to false for safety
+		enableRefreshAnimation: refreshAnimationDefault(),
+		browserRateLimiter:     ratelimit.NewBrowserRateLimiter(browserOpenDelay, maxBrowserOpensMinute, maxBrowserOpensDay),
+		startTime:              startTime,
+		systrayInterface:       &RealSystray{}, // Use real systray implementation
+		seenOrgs:               make(map[string]orgActivity),
+		hiddenOrgs:             make(map[string]bool),
+		silentOrgs:             make(map[string]bool),
 		// Deprecated fields for test compatibility
 		previousBlockedPRs: make(map[string]bool),
 		blockedPRTimes:     make(map[string]time.Time),
 		healthMonitor:      newHealthMonitor(),
+		dockBadge:          newDockBadger(),
 		githubCircuit:      newCircuitBreaker("github", 5, 2*time.Minute),
+		githubStatus:       newGitHubStatusChecker(githubStatusAPIURL),
+		storage:            storage,
+		quarantine:         newPRQuarantine(),
+		searchCache:        newSearchCache(),
+		turnMemory:         newTurnMemory(turnMemoryEntries),
+		workflowApprovals:  newWorkflowApprovalCache(),
+		reviewRequests:     newPRRevisionCache[reviewRequest](),
+		pendingReviews:     newPendingReviewCache(),
+		pullDetails:        newPRRevisionCache[pullDetails](),
+		questions:          newPRRevisionCache[pendingQuestion](),
+		notifications:      newNotificationHistory(),
+		turnBackfill:       newTurnBackfill(),
+		quietCycles:        newQuietCycles(quietSkipWindow),
+		responses:          newResponseTracker(filepath.Join(cacheDir, statsFileName)),
+	}
+
+	app.installSideEffects(silentModeRequested(silent))
+	if !app.silentMode {
+		app.notifyQueue = newNotificationQueue(app.deliverNotification)
 	}
+	app.startup = newStartupEntry(profileName)
+	app.logGracePeriodEnd(ctx)
 
 	// Set app reference in health monitor for sprinkler status
 	app.healthMonitor.app = app
 
+	// Bring settings over from ready-to-review before loading them
+	app.migrateLegacyDirs()
+
 	// Load saved settings
 	app.loadSettings()
+	app.applyLocale()
+	app.checkArch(ctx, hostarch.NativeProbes())
+	if app.teamMode() {
+		interval := teamUpdateInterval(app.updateInterval, len(app.team))
+		slog.Info("[SETTINGS] Team mode, stretching the update interval", "teammates", len(app.team), "interval", interval)
+		app.updateInterval = interval
+	}
+	app.intervals = newAdaptiveInterval(app.updateInterval)
+	app.configureDashboard()
+	app.configureNotificationHook(ctx)
+
+	if stateFilePath != "" {
+		slog.Info("[STATEFILE] Writing state file", "path", stateFilePath)
+		app.stateFile = newStateFile(stateFilePath)
+		app.stateFile.goFn = app.goTracked
+	}
+
+	if metricsPort > 0 {
+		if _, err := app.serveMetrics(ctx, metricsPort); err != nil {
+			slog.Error("[METRICS] Failed to start metrics server", "port", metricsPort, "error", err)
+		}
+	}
 
 	slog.Info("Initializing GitHub clients...")
 	err = app.initClients(ctx)
@@ -332,52 +593,7 @@ func main() {
 		// Continue running with auth error - will show error in UI
 	}
 
-	// Load current user if we have a client
-	slog.Info("Loading current user...")
-	if app.client != nil {
-		var user *github.User
-		err := retry.Do(func() error {
-			var err error
-			user, _, err = app.client.Users.Get(ctx, "")
-			if err != nil {
-				slog.Warn("GitHub Users.Get failed (will retry)", "error", err)
-				return err
-			}
-			return nil
-		},
-			retry.Attempts(maxRetries),
-			retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)), // Add jitter for better backoff distribution
-			retry.MaxDelay(maxRetryDelay),
-			retry.OnRetry(func(n uint, err error) {
-				slog.Warn("[GITHUB] Users.Get retry", "attempt", n+1, "maxRetries", maxRetries, "error", err)
-			}),
-			retry.Context(ctx),
-		)
-		switch {
-		case err != nil:
-			slog.Warn("Failed to load current user after retries", "maxRetries", maxRetries, "error", err)
-			if app.authError == "" {
-				app.authError = fmt.Sprintf("Failed to load user: %v", err)
-			}
-		case user != nil:
-			app.currentUser = user
-			// Log if we're using a different target user (sanitized)
-			if app.targetUser != "" && app.targetUser != user.GetLogin() {
-				slog.Info("Querying PRs for different user", "targetUser", sanitizeForLog(app.targetUser))
-			}
-
-			// Initialize sprinkler with user's organizations now that we have the user
-			go func() {
-				if err := app.initSprinklerOrgs(ctx); err != nil {
-					slog.Warn("[SPRINKLER] Failed to initialize organizations", "error", err)
-				}
-			}()
-		default:
-			slog.Warn("GitHub API returned nil user")
-		}
-	} else {
-		slog.Info("Skipping user load - no GitHub client available")
-	}
+	app.loadCurrentUser(ctx)
 
 	slog.Info("Checking system tray availability...")
 	trayProxy, err := x11tray.EnsureTray(ctx)
@@ -392,13 +608,18 @@ func main() {
 	// Create a cancellable context for the application
 	appCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	app.lifecycle = newLifecycle(cancel)
 
 	systray.Run(func() { app.onReady(appCtx) }, func() {
 		slog.Info("Shutting down application")
-		cancel() // Cancel the context to stop goroutines
 		if app.sprinklerMonitor != nil {
 			app.sprinklerMonitor.stop()
 		}
+		// Cancel the context, wait for goroutines, then flush what they left behind
+		app.lifecycle.Shutdown(shutdownTimeout, app.flushState)
+		if app.stateFile != nil {
+			app.stateFile.remove()
+		}
 		// Stop tray proxy if we started one
 		if trayProxy != nil {
 			slog.Info("Stopping system tray proxy")
@@ -407,9 +628,66 @@ func main() {
 			}
 		}
 		app.cleanupOldCache()
+		releaseLock()
 	})
 }
 
+// loadCurrentUser loads the authenticated user. When GitHub refuses the lookup, as it
+// can for a fine-grained token scoped to one org, goose carries on with the login
+// override if there is one; otherwise the failure is an auth error.
+func (app *App) loadCurrentUser(ctx context.Context) {
+	slog.Info("Loading current user...")
+	if app.client == nil {
+		slog.Info("Skipping user load - no GitHub client available")
+		return
+	}
+	var user *github.User
+	err := retry.Do(func() error {
+		var err error
+		user, _, err = app.client.Users.Get(ctx, "")
+		if err != nil {
+			if app.loginFallback != "" && permissionDenied(err) {
+				return retry.Unrecoverable(err)
+			}
+			slog.Warn("GitHub Users.Get failed (will retry)", "error", err)
+			return err
+		}
+		return nil
+	},
+		retry.Attempts(maxRetries),
+		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)), // Add jitter for better backoff distribution
+		retry.MaxDelay(maxRetryDelay),
+		retry.OnRetry(func(n uint, err error) {
+			slog.Warn("[GITHUB] Users.Get retry", "attempt", n+1, "maxRetries", maxRetries, "error", err)
+		}),
+		retry.Context(ctx),
+	)
+	switch {
+	case err != nil && app.useLoginOverride(err):
+		// Org membership needs /user too; the sprinkler follows the orgs seen in PRs instead
+	case err != nil:
+		slog.Warn("Failed to load current user after retries", "maxRetries", maxRetries, "error", err)
+		if app.authError == "" {
+			app.authError = fmt.Sprintf("Failed to load user: %v", err)
+		}
+	case user != nil:
+		app.currentUser = user
+		// Log if we're using a different target user (sanitized)
+		if app.targetUser != "" && app.targetUser != user.GetLogin() {
+			slog.Info("Querying PRs for different user", "targetUser", sanitizeForLog(app.targetUser))
+		}
+
+		// Initialize sprinkler with user's organizations now that we have the user
+		app.goTracked("sprinkler orgs", func() {
+			if err := app.initSprinklerOrgs(ctx); err != nil {
+				slog.Warn("[SPRINKLER] Failed to initialize organizations", "error", err)
+			}
+		})
+	default:
+		slog.Warn("GitHub API returned nil user")
+	}
+}
+
 // handleReauthentication attempts to re-authenticate when auth errors occur.
 func (app *App) handleReauthentication(ctx context.Context) {
 	// Try to reinitialize clients which will attempt to get token via gh auth token
@@ -454,23 +732,16 @@ func (app *App) handleReauthentication(ctx context.Context) {
 		}
 	}
 
-	// Update tooltip
-	tooltip := "reviewGOOSE"
-	if app.targetUser != "" {
-		tooltip = fmt.Sprintf("reviewGOOSE (@%s)", app.targetUser)
-	}
-	systray.SetTooltip(tooltip)
-
-	// Rebuild menu to remove error state
+	// Rebuild menu to remove error state; it presents the tray state too
 	app.rebuildMenu(ctx)
 
 	// Start update loop if not already running
 	if !app.menuInitialized {
 		app.menuInitialized = true
-		go app.updateLoop(ctx)
+		app.goTracked("update loop", func() { app.updateLoop(ctx) })
 	} else {
 		// Just do a single update to refresh data
-		go app.updatePRs(ctx)
+		app.goTracked("update", func() { app.updatePRs(ctx) })
 	}
 }
 
@@ -521,12 +792,12 @@ func (app *App) onReady(ctx context.Context) {
 	if runtime.GOOS == "linux" {
 		slog.Info("[LINUX] Building initial minimal menu")
 		app.systrayInterface.ResetMenu()
-		placeholderItem := app.systrayInterface.AddMenuItem("Loading...", "Goose is starting up")
+		placeholderItem := app.systrayInterface.AddMenuItem(msg("menu.loading"), msg("menu.loading.tooltip"))
 		if placeholderItem != nil {
 			placeholderItem.Disable()
 		}
 		app.systrayInterface.AddSeparator()
-		quitItem := app.systrayInterface.AddMenuItem("Quit", "Quit Goose")
+		quitItem := app.systrayInterface.AddMenuItem(msg("menu.quit"), msg("menu.quit.tooltip"))
 		if quitItem != nil {
 			quitItem.Click(func() {
 				slog.Info("Quit clicked")
@@ -546,7 +817,7 @@ func (app *App) onReady(ctx context.Context) {
 
 		if hasAuthError {
 			slog.Info("[CLICK] Auth error detected, attempting to re-authenticate")
-			go app.handleReauthentication(ctx)
+			app.goTracked("reauthentication", func() { app.handleReauthentication(ctx) })
 		} else {
 			// Normal operation - check if we can perform a forced refresh
 			app.mu.RLock()
@@ -555,9 +826,7 @@ func (app *App) onReady(ctx context.Context) {
 
 			if timeSinceLastSearch >= minUpdateInterval {
 				slog.Info("[CLICK] Forcing search refresh", "lastSearchAgo", timeSinceLastSearch)
-				go func() {
-					app.updatePRs(ctx)
-				}()
+				app.goTracked("forced refresh", func() { app.forceRefresh(ctx) })
 			} else {
 				remainingTime := minUpdateInterval - timeSinceLastSearch
 				slog.Debug("[CLICK] Rate limited", "lastSearchAgo", timeSinceLastSearch, "remaining", remainingTime)
@@ -586,35 +855,56 @@ func (app *App) onReady(ctx context.Context) {
 		// by snixembed when it detects the right-click
 	})
 
+	// Retry notifications the notification service rejected, off the hot path
+	if app.notifyQueue != nil {
+		app.goTracked("notification retries", func() { app.notifyQueue.run(ctx) })
+	}
+
+	// Redraw the icon when the desktop switches between light and dark (Linux/BSD only)
+	if source := newDesktopColorScheme(); source != nil {
+		watcher := newColorSchemeWatcher(source, app.setColorScheme)
+		app.goTracked("color scheme watcher", func() { watcher.run(ctx) })
+	}
+
+	// Re-register the icon when the tray host restarts (Linux only)
+	if source := newTrayHostSource(); source != nil {
+		watcher := newTrayHostWatcher(source, app.healthMonitor, app.restoreTray)
+		app.goTracked("tray host watcher", func() { watcher.run(ctx) })
+	}
+
+	// Keep the startup entry pointing at this binary (Windows and Linux)
+	if app.startup != nil {
+		app.goTracked("startup entry check", app.verifyStartup)
+	}
+
 	// Check if we have an auth error
 	if app.authError != "" {
-		systray.SetTitle("")
-		app.setTrayIcon(IconLock, PRCounts{})
-		systray.SetTooltip("Goose - Authentication Error")
-		// Create initial error menu
+		// Create initial error menu, which shows the lock icon
 		app.rebuildMenu(ctx)
 		// Clean old cache on startup
 		app.cleanupOldCache()
 		// Start background auth retry loop
-		go app.authRetryLoop(ctx)
+		app.goTracked("auth retry loop", func() { app.authRetryLoop(ctx) })
 		return
 	}
 
-	systray.SetTitle("")
-	app.setTrayIcon(IconSmiling, PRCounts{}) // Start with smiling icon while loading
-
-	// Set tooltip based on whether we're using a custom user
-	tooltip := "reviewGOOSE"
-	if app.targetUser != "" {
-		tooltip = fmt.Sprintf("reviewGOOSE (@%s)", app.targetUser)
-	}
-	systray.SetTooltip(tooltip)
+	// Start with smiling icon while loading
+	app.presentTrayState()
 
 	// Clean old cache on startup
 	app.cleanupOldCache()
 
 	// Start update loop - it will create the initial menu after loading data
-	go app.updateLoop(ctx)
+	app.goTracked("update loop", func() { app.updateLoop(ctx) })
+
+	// Pick up orgs I join or leave while running
+	app.goTracked("org sync loop", func() { app.orgSyncLoop(ctx) })
+
+	// Catch tests finishing between update cycles
+	app.goTracked("running tests sweep", func() { app.runningTestsSweepLoop(ctx) })
+
+	digest := newDigestScheduler(app)
+	app.goTracked("daily digest", func() { digest.run(ctx) })
 }
 
 func (app *App) updateLoop(ctx context.Context) {
@@ -623,16 +913,15 @@ func (app *App) updateLoop(ctx context.Context) {
 		if r := recover(); r != nil {
 			slog.Error("PANIC in update loop", "panic", r)
 
-			// Set error state in UI
-			systray.SetTitle("")
-			app.setTrayIcon(IconWarning, PRCounts{})
-			systray.SetTooltip("Goose - Critical error")
-
 			// Update failure count
 			app.mu.Lock()
 			app.consecutiveFailures += panicFailureIncrement // Treat panic as critical failure
+			app.crashed = true
 			app.mu.Unlock()
 
+			// Set error state in UI
+			app.presentTrayState()
+
 			// Signal app to quit after panic
 			slog.Error("Update loop panic - signaling quit")
 			systray.Quit()
@@ -658,6 +947,7 @@ func (app *App) updateLoop(ctx context.Context) {
 			if app.healthMonitor != nil {
 				app.healthMonitor.logMetrics()
 			}
+			app.pruneTrackedState(time.Now())
 		case <-ticker.C:
 			// Check if we should skip this scheduled update due to recent forced refresh
 			app.mu.RLock()
@@ -671,6 +961,9 @@ func (app *App) updateLoop(ctx context.Context) {
 				remainingTime := minUpdateInterval - timeSinceLastSearch
 				slog.Debug("Skipping scheduled update", "recentSearchAgo", timeSinceLastSearch, "remaining", remainingTime)
 			}
+			ticker.Reset(app.nextUpdateInterval())
+		case <-app.intervalSnapped():
+			ticker.Reset(app.updateInterval)
 		case <-ctx.Done():
 			slog.Info("Update loop stopping due to context cancellation")
 			return
@@ -678,6 +971,82 @@ func (app *App) updateLoop(ctx context.Context) {
 	}
 }
 
+// errUpdateTimedOut is returned when an update cycle exceeds its deadline and is abandoned.
+var errUpdateTimedOut = errors.New("update timed out")
+
+// updateCycleTimeout returns the overall deadline for a single update cycle.
+func (app *App) updateCycleTimeout() time.Duration {
+	interval := app.updateInterval
+	if interval <= 0 {
+		interval = defaultUpdateInterval
+	}
+	return updateCycleTimeoutFactor * interval
+}
+
+// fetchPRsWithDeadline runs fetchPRsInternal under a per-cycle deadline.
+// If the deadline fires, the cycle is abandoned so the caller can release updateMutex;
+// any straggling calls observe the cancelled context and their results are discarded.
+func (app *App) fetchPRsWithDeadline(ctx context.Context) (incoming []PR, outgoing []PR, _ error) {
+	timeout := app.updateCycleTimeout()
+	cycleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type fetchResult struct {
+		err      error
+		partial  *PartialError
+		incoming []PR
+		outgoing []PR
+	}
+	done := make(chan fetchResult, 1)
+	start := time.Now()
+
+	started := app.goTracked("fetch", func() {
+		var r fetchResult
+		r.err = safeExecute("fetchPRs", func() error {
+			var err error
+			r.incoming, r.outgoing, err = app.fetchPRsInternal(cycleCtx)
+			// Partial results are usable, so they aren't reported as a failed operation
+			if r.partial = asPartialFetch(err); r.partial != nil {
+				return nil
+			}
+			return err
+		})
+		done <- r
+	})
+	if !started {
+		// Shutdown has begun
+		return nil, nil, context.Canceled
+	}
+
+	// Watchdog: note cycles that run longer than the update interval
+	interval := app.updateInterval
+	if interval <= 0 {
+		interval = defaultUpdateInterval
+	}
+	watchdog := time.AfterFunc(interval, func() {
+		slog.Warn("[WATCHDOG] Update cycle exceeding update interval",
+			"elapsed", time.Since(start).Round(time.Second), "interval", interval, "timeout", timeout)
+	})
+	defer watchdog.Stop()
+
+	select {
+	case r := <-done:
+		if r.err != nil && errors.Is(cycleCtx.Err(), context.DeadlineExceeded) {
+			return nil, nil, fmt.Errorf("%w after %s: %w", errUpdateTimedOut, timeout, r.err)
+		}
+		if r.err == nil && r.partial != nil {
+			return r.incoming, r.outgoing, r.partial
+		}
+		return r.incoming, r.outgoing, r.err
+	case <-cycleCtx.Done():
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		slog.Error("[WATCHDOG] Abandoning update cycle", "elapsed", time.Since(start).Round(time.Second), "timeout", timeout)
+		return nil, nil, fmt.Errorf("%w after %s", errUpdateTimedOut, timeout)
+	}
+}
+
 func (app *App) updatePRs(ctx context.Context) {
 	// Prevent concurrent updates
 	if !app.updateMutex.TryLock() {
@@ -686,112 +1055,67 @@ func (app *App) updatePRs(ctx context.Context) {
 	}
 	defer app.updateMutex.Unlock()
 
-	var incoming, outgoing []PR
-	err := safeExecute("fetchPRs", func() error {
-		var err error
-		incoming, outgoing, err = app.fetchPRsInternal(ctx)
-		return err
-	})
+	if app.skipQuietCycle() {
+		return
+	}
+	if app.waitingOutOutage(time.Now()) {
+		slog.Debug("[STATUS] Waiting out GitHub incident, skipping update")
+		return
+	}
+
+	act := app.sprinklerActivity()
+	fetchStart := time.Now()
+	incoming, outgoing, err := app.fetchPRsWithDeadline(ctx)
+	app.setSearchUnavailable(asSearchUnavailable(err))
+	// One failed search still leaves usable results: treat it as a success and flag it
+	partial := asPartialFetch(err)
+	if partial != nil {
+		err = nil
+	}
 	if err != nil {
 		slog.Error("Error fetching PRs", "error", err)
-		app.mu.Lock()
-		app.consecutiveFailures++
-		failureCount := app.consecutiveFailures
-		app.lastFetchError = err.Error()
-		app.mu.Unlock()
-
-		// Progressive degradation based on failure count
-		var tooltip string
-		var iconType IconType
-		switch {
-		case failureCount <= minorFailureThreshold:
-			iconType = IconWarning
-			tooltip = fmt.Sprintf("Goose - %d consecutive failures", failureCount)
-		default:
-			iconType = IconWarning
-			tooltip = "Goose - Connection failures, check network/auth"
-		}
-
-		systray.SetTitle("")
-		app.setTrayIcon(iconType, PRCounts{})
-
-		// Include time since last success and user info
-		timeSinceSuccess := "never"
-		if !app.lastSuccessfulFetch.IsZero() {
-			timeSinceSuccess = time.Since(app.lastSuccessfulFetch).Round(time.Minute).String()
-		}
-
-		userInfo := ""
-		if app.targetUser != "" {
-			userInfo = fmt.Sprintf(" - @%s", app.targetUser)
+		if app.quietCycles != nil {
+			app.quietCycles.invalidate()
 		}
+		failureCount := app.recordFetchFailure(err)
+		_, incidentChanged := app.checkGitHubOutage(ctx, failureCount)
+		app.presentTrayState()
 
-		// Provide actionable error message based on error type
-		var errorHint string
-		errMsg := err.Error()
-		switch {
-		case strings.Contains(errMsg, "rate limited"):
-			errorHint = "\nRate limited - wait before retrying"
-		case strings.Contains(errMsg, "authentication"):
-			errorHint = "\nCheck GitHub token with 'gh auth status'"
-		case strings.Contains(errMsg, "network"):
-			errorHint = "\nCheck internet connection"
-		default:
-			// No specific hint for this error type
+		// Failures are now persistent: rebuild once so the menu offers diagnostics,
+		// or explains that GitHub itself is having problems
+		if (failureCount == majorFailureThreshold || incidentChanged) && app.menuInitialized {
+			app.rebuildMenu(ctx)
 		}
-
-		fullTooltip := fmt.Sprintf("%s%s\nLast success: %s ago%s", tooltip, userInfo, timeSinceSuccess, errorHint)
-		systray.SetTooltip(fullTooltip)
 		return
 	}
 
+	if app.quietCycles != nil {
+		app.quietCycles.recordFetch(fetchStart, act)
+	}
+
 	// Update health status on success
-	app.mu.Lock()
-	previousFailures := app.consecutiveFailures
-	app.lastSuccessfulFetch = time.Now()
-	app.consecutiveFailures = 0
-	app.lastFetchError = ""
-	app.mu.Unlock()
+	previousFailures := app.recordFetchSuccess()
+	app.setPartialFetch(partial)
 
 	// Restore normal tray icon after successful fetch
 	if previousFailures > 0 {
 		slog.Info("[RECOVERY] Network recovered, restoring tray icon",
 			"previousFailures", previousFailures)
 	}
-	app.setTrayTitle()
+	app.presentTrayState()
+
+	incoming, outgoing, filtered := app.splitFiltered(incoming, outgoing)
+	app.stateManager.TrackComments(incoming, outgoing, partial == nil)
+	incoming, outgoing = app.reconcileEventPRs(incoming, outgoing, partial == nil)
 
 	// Update state atomically
 	app.mu.Lock()
-	// Log PRs that were removed (likely merged/closed)
-	for i := range app.incoming {
-		found := false
-		for j := range incoming {
-			if app.incoming[i].URL == incoming[j].URL {
-				found = true
-				break
-			}
-		}
-		if !found {
-			slog.Info("[UPDATE] Incoming PR removed (likely merged/closed)",
-				"repo", app.incoming[i].Repository, "number", app.incoming[i].Number, "url", app.incoming[i].URL)
-		}
-	}
-	for i := range app.outgoing {
-		found := false
-		for j := range outgoing {
-			if app.outgoing[i].URL == outgoing[j].URL {
-				found = true
-				break
-			}
-		}
-		if !found {
-			slog.Info("[UPDATE] Outgoing PR removed (likely merged/closed)",
-				"repo", app.outgoing[i].Repository, "number", app.outgoing[i].Number, "url", app.outgoing[i].URL)
-		}
-	}
+	changes := diffPRLists(app.incoming, app.outgoing, incoming, outgoing, filtered, partial == nil)
+	changed := prStatesChanged(app.incoming, incoming) || prStatesChanged(app.outgoing, outgoing)
 
 	app.incoming = incoming
 	app.outgoing = outgoing
+	app.filteredPRs = filtered
 	slog.Info("[UPDATE] PR counts after update",
 		"incoming_count", len(incoming),
 		"outgoing_count", len(outgoing))
@@ -812,6 +1136,11 @@ func (app *App) updatePRs(ctx context.Context) {
 		app.initialLoadComplete = true
 	}
 	app.mu.Unlock()
+	app.journal.note(&changes)
+	app.pruneClosedHiddenPRs(changes.removedURLs())
+	if changed {
+		app.updateActivity("PRs changed")
+	}
 
 	app.updateMenu(ctx)
 
@@ -819,6 +1148,14 @@ func (app *App) updatePRs(ctx context.Context) {
 	slog.Debug("[DEBUG] Processing PR state updates and notifications")
 	app.processNotifications(ctx)
 	slog.Debug("[DEBUG] Completed PR state updates and notifications")
+	app.commitChanges(time.Now())
+
+	// Enrich the PRs the first load deferred, then retry PRs whose Turn lookups failed
+	app.startTurnWave(ctx)
+	app.scheduleTurnBackfill(ctx)
+
+	app.reconcileOrgs(ctx)
+	app.persistOrgActivity()
 }
 
 // updateMenu rebuilds the menu only if there are changes to improve UX.
@@ -835,6 +1172,7 @@ func (app *App) updateMenu(ctx context.Context) {
 	// Check if titles have changed
 	if slices.Equal(currentTitles, lastTitles) {
 		slog.Debug("[MENU] No changes detected, skipping rebuild", "itemCount", len(currentTitles))
+		app.refreshSectionHeaders()
 		return
 	}
 
@@ -855,6 +1193,7 @@ func (app *App) updateMenu(ctx context.Context) {
 		slog.Debug("[MENU] Title removed", "index", i, "title", lastTitles[i])
 	}
 
+	app.markSectionChanges()
 	app.rebuildMenu(ctx)
 
 	// Store new titles
@@ -872,34 +1211,25 @@ func (app *App) updatePRsWithWait(ctx context.Context) {
 	}
 	defer app.updateMutex.Unlock()
 
-	incoming, outgoing, err := app.fetchPRsInternal(ctx)
+	act := app.sprinklerActivity()
+	fetchStart := time.Now()
+	incoming, outgoing, err := app.fetchPRsWithDeadline(ctx)
+	app.setSearchUnavailable(asSearchUnavailable(err))
+	// One failed search still leaves usable results: treat it as a success and flag it
+	partial := asPartialFetch(err)
+	if partial != nil {
+		err = nil
+	}
 	if err != nil {
 		slog.Error("Error fetching PRs", "error", err)
-		app.mu.Lock()
-		app.consecutiveFailures++
-		failureCount := app.consecutiveFailures
-		app.lastFetchError = err.Error()
-		app.mu.Unlock()
-
-		// Progressive degradation based on failure count
-		var tooltip string
-		var iconType IconType
-		switch {
-		case failureCount <= minorFailureThreshold:
-			iconType = IconWarning
-			tooltip = fmt.Sprintf("Goose - %d consecutive failures", failureCount)
-		default:
-			iconType = IconWarning
-			tooltip = "Goose - Connection failures, check network/auth"
-		}
-
-		systray.SetTitle("")
-		app.setTrayIcon(iconType, PRCounts{})
-		systray.SetTooltip(tooltip)
+		failureCount := app.recordFetchFailure(err)
+		app.checkGitHubOutage(ctx, failureCount)
+		app.presentTrayState()
 
 		// Create or update menu to show error state
 		if !app.menuInitialized {
 			// Create initial menu despite error
+			app.markSectionChanges()
 			app.rebuildMenu(ctx)
 			app.menuInitialized = true
 			// Store initial menu titles to prevent unnecessary rebuild on first update
@@ -916,25 +1246,30 @@ func (app *App) updatePRsWithWait(ctx context.Context) {
 		return
 	}
 
+	if app.quietCycles != nil {
+		app.quietCycles.recordFetch(fetchStart, act)
+	}
+
 	// Update health status on success
-	app.mu.Lock()
-	previousFailures := app.consecutiveFailures
-	app.lastSuccessfulFetch = time.Now()
-	app.consecutiveFailures = 0
-	app.lastFetchError = ""
-	app.mu.Unlock()
+	previousFailures := app.recordFetchSuccess()
+	app.setPartialFetch(partial)
 
 	// Restore normal tray icon after successful fetch
 	if previousFailures > 0 {
 		slog.Info("[RECOVERY] Network recovered, restoring tray icon",
 			"previousFailures", previousFailures)
 	}
-	app.setTrayTitle()
+	app.presentTrayState()
+
+	incoming, outgoing, filtered := app.splitFiltered(incoming, outgoing)
+	app.stateManager.TrackComments(incoming, outgoing, partial == nil)
 
 	// Update state
 	app.mu.Lock()
+	changes := diffPRLists(app.incoming, app.outgoing, incoming, outgoing, filtered, partial == nil)
 	app.incoming = incoming
 	app.outgoing = outgoing
+	app.filteredPRs = filtered
 
 	// Debug logging to track PR states
 	blockedIncoming := 0
@@ -956,12 +1291,14 @@ func (app *App) updatePRsWithWait(ctx context.Context) {
 		"outgoing", len(outgoing), "blockedOutgoing", blockedOutgoing)
 
 	app.mu.Unlock()
+	app.journal.note(&changes)
 
 	// Create initial menu after first successful data load
 	if !app.menuInitialized {
 		// Create initial menu with Turn data
 		// Initialize menu structure
 		slog.Info("[FLOW] Creating initial menu (first time)")
+		app.markSectionChanges()
 		app.rebuildMenu(ctx)
 		app.menuInitialized = true
 		// Store initial menu titles to prevent unnecessary rebuild on first update
@@ -982,12 +1319,19 @@ func (app *App) updatePRsWithWait(ctx context.Context) {
 	slog.Info("[FLOW] About to process PR state updates and notifications")
 	app.processNotifications(ctx)
 	slog.Info("[FLOW] Completed PR state updates and notifications")
+	app.commitChanges(time.Now())
 	// Mark initial load as complete after first successful update
 	if !app.initialLoadComplete {
 		app.mu.Lock()
 		app.initialLoadComplete = true
 		app.mu.Unlock()
 	}
+
+	app.startTurnWave(ctx)
+	app.scheduleTurnBackfill(ctx)
+
+	app.reconcileOrgs(ctx)
+	app.persistOrgActivity()
 }
 
 // tryAutoOpenPR attempts to open a PR in the browser if enabled and rate limits allow.
@@ -1003,10 +1347,23 @@ func (app *App) tryAutoOpenPR(ctx context.Context, pr *PR, autoBrowserEnabled bo
 		return
 	}
 
+	// The rate limiter's own startup delay applies too, so the longer of the two wins
+	if app.inGracePeriod() {
+		slog.Debug("[BROWSER] In startup grace period, skipping auto-open",
+			"repo", pr.Repository, "number", pr.Number)
+		return
+	}
+
+	if policy := app.prPolicy(pr.Repository); policy != orgPolicyFull {
+		slog.Debug("[BROWSER] Skipping auto-open due to org policy",
+			"repo", pr.Repository, "number", pr.Number, "policy", policy)
+		return
+	}
+
 	// Determine queried user for draft check
 	queriedUser := app.targetUser
-	if queriedUser == "" && app.currentUser != nil {
-		queriedUser = app.currentUser.GetLogin()
+	if queriedUser == "" {
+		queriedUser = app.login()
 	}
 
 	// Skip draft PRs authored by the user we're querying for
@@ -1024,6 +1381,11 @@ func (app *App) tryAutoOpenPR(ctx context.Context, pr *PR, autoBrowserEnabled bo
 		return
 	}
 
+	// Held back by the schedule, not queued: the PR is in the menu when I'm back
+	if !app.autoOpenScheduled(pr, time.Now()) {
+		return
+	}
+
 	if app.browserRateLimiter.CanOpen(startTime, pr.URL) {
 		slog.Info("[BROWSER] Auto-opening newly blocked PR",
 			"repo", pr.Repository,
@@ -1035,14 +1397,10 @@ func (app *App) tryAutoOpenPR(ctx context.Context, pr *PR, autoBrowserEnabled bo
 			"age_since_creation", time.Since(pr.CreatedAt).Round(time.Second),
 			"age_since_update", time.Since(pr.UpdatedAt).Round(time.Second))
 		// Use strict GitHub PR validation for auto-opening
-		// Use ActionKind as the goose parameter value, or "next_action" if not set
-		gooseParam := pr.ActionKind
-		if gooseParam == "" {
-			gooseParam = "next_action"
-		}
+		gooseParam := pr.ActionKind.gooseParam()
 
 		// OpenWithParams will validate the URL and add the goose parameter
-		if err := openURL(ctx, pr.URL, gooseParam); err != nil {
+		if err := app.openBrowser(ctx, prLink(pr), gooseParam); err != nil {
 			slog.Error("[BROWSER] Failed to auto-open PR", "url", sanitizeForLog(pr.URL), "error", err)
 		} else {
 			app.browserRateLimiter.RecordOpen(pr.URL)
@@ -8,7 +8,6 @@ import (
 	"net/http/httptest"
 	"os"
 	"runtime"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -20,11 +19,25 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// A section header that disagrees with its rows fails the test that built it
+	assertMenuCounts = true
 	// Set test mode to prevent actual sound playback during tests
 	if err := os.Setenv("GOOSE_TEST_MODE", "1"); err != nil {
 		panic(err)
 	}
-	os.Exit(m.Run())
+	// Update cycles save settings (e.g. newly seen orgs); keep them out of the real config dir
+	home, err := os.MkdirTemp("", "goose-test-home")
+	if err != nil {
+		panic(err)
+	}
+	for _, key := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "HOME", "APPDATA"} {
+		if err := os.Setenv(key, home); err != nil {
+			panic(err)
+		}
+	}
+	code := m.Run()
+	os.RemoveAll(home) //nolint:errcheck,gosec // Best-effort cleanup of the temp dir
+	os.Exit(code)
 }
 
 func TestIsStale(t *testing.T) {
@@ -78,7 +91,7 @@ func TestMenuItemTitleTransition(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{}, // Use mock systray to avoid panics
@@ -123,7 +136,7 @@ func TestMenuItemTitleTransition(t *testing.T) {
 			// Add action code if present, or test state as fallback
 			if pr.ActionKind != "" {
 				// Replace underscores with spaces for better readability
-				actionDisplay := strings.ReplaceAll(pr.ActionKind, "_", " ")
+				actionDisplay := pr.ActionKind.label()
 				title = fmt.Sprintf("%s — %s", title, actionDisplay)
 			} else if pr.TestState == "running" {
 				// Show "tests running" as a fallback when no specific action is available
@@ -146,7 +159,7 @@ func TestMenuItemTitleTransition(t *testing.T) {
 			// Add action code if present, or test state as fallback
 			if pr.ActionKind != "" {
 				// Replace underscores with spaces for better readability
-				actionDisplay := strings.ReplaceAll(pr.ActionKind, "_", " ")
+				actionDisplay := pr.ActionKind.label()
 				title = fmt.Sprintf("%s — %s", title, actionDisplay)
 			} else if pr.TestState == "running" {
 				// Show "tests running" as a fallback when no specific action is available
@@ -290,7 +303,7 @@ func TestWorkflowStateNewlyPublished(t *testing.T) {
 
 				// Add action code if present, or test state as fallback
 				if pr.ActionKind != "" {
-					actionDisplay := strings.ReplaceAll(pr.ActionKind, "_", " ")
+					actionDisplay := pr.ActionKind.label()
 					title = fmt.Sprintf("%s — %s", title, actionDisplay)
 				} else if pr.TestState == "running" {
 					title = fmt.Sprintf("%s — tests running...", title)
@@ -328,7 +341,7 @@ func TestTrayIconRestoredAfterNetworkRecovery(t *testing.T) {
 		stateManager:       NewPRStateManager(time.Now().Add(-35 * time.Second)), // Past grace period
 		blockedPRTimes:     make(map[string]time.Time),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   mock,
 		menuInitialized:    true,
@@ -338,7 +351,7 @@ func TestTrayIconRestoredAfterNetworkRecovery(t *testing.T) {
 	app.incoming = []PR{
 		{Repository: "test/repo", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 	}
-	app.setTrayTitle()
+	app.presentTrayState()
 	initialTitle := mock.title
 
 	// Expected title varies by platform
@@ -354,15 +367,15 @@ func TestTrayIconRestoredAfterNetworkRecovery(t *testing.T) {
 	// Simulate network failure - updatePRs would set warning icon and return early
 	app.consecutiveFailures = 3
 	app.lastFetchError = "network timeout"
-	// In the old code, rebuildMenu would be called but return early, never calling setTrayTitle()
+	// In the old code, rebuildMenu would be called but return early, never calling presentTrayState()
 	app.rebuildMenu(ctx)
 	// The mock systray won't have the warning icon because rebuildMenu doesn't set it directly
 
 	// Simulate network recovery - this should restore the normal icon
 	app.consecutiveFailures = 0
 	app.lastFetchError = ""
-	// With our fix, setTrayTitle() is now called after successful fetch
-	app.setTrayTitle()
+	// With our fix, presentTrayState() is now called after successful fetch
+	app.presentTrayState()
 	recoveredTitle := mock.title
 	if recoveredTitle != expectedTitle {
 		t.Errorf("Expected tray title to be restored to %q after recovery, got %q", expectedTitle, recoveredTitle)
@@ -376,7 +389,7 @@ func TestTrayTitleUpdates(t *testing.T) {
 		stateManager:       NewPRStateManager(time.Now().Add(-35 * time.Second)), // Past grace period
 		blockedPRTimes:     make(map[string]time.Time),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{}, // Use mock systray to avoid panics
 	}
@@ -387,13 +400,17 @@ func TestTrayTitleUpdates(t *testing.T) {
 		outgoing          []PR
 		hiddenOrgs        map[string]bool
 		hideStaleIncoming bool
+		hideIncoming      bool
+		hideOutgoing      bool
 		expectedTitle     string
+		expectedRepoTitle string // With "Count repos instead of PRs" enabled
 	}{
 		{
-			name:          "no PRs",
-			incoming:      []PR{},
-			outgoing:      []PR{},
-			expectedTitle: "", // No count shown when no blocked PRs
+			name:              "no PRs",
+			incoming:          []PR{},
+			outgoing:          []PR{},
+			expectedTitle:     "", // No count shown when no blocked PRs
+			expectedRepoTitle: "",
 		},
 		{
 			name: "only incoming blocked",
@@ -401,8 +418,9 @@ func TestTrayTitleUpdates(t *testing.T) {
 				{Repository: "test/repo", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 				{Repository: "test/repo", Number: 2, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			outgoing:      []PR{},
-			expectedTitle: "2", // macOS format: just the count
+			outgoing:          []PR{},
+			expectedTitle:     "2", // macOS format: just the count
+			expectedRepoTitle: "1",
 		},
 		{
 			name:     "only outgoing blocked",
@@ -412,7 +430,8 @@ func TestTrayTitleUpdates(t *testing.T) {
 				{Repository: "test/repo", Number: 4, IsBlocked: true, UpdatedAt: time.Now()},
 				{Repository: "test/repo", Number: 5, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			expectedTitle: "3", // macOS format: just the count
+			expectedTitle:     "3", // macOS format: just the count
+			expectedRepoTitle: "1",
 		},
 		{
 			name: "both incoming and outgoing blocked",
@@ -422,7 +441,8 @@ func TestTrayTitleUpdates(t *testing.T) {
 			outgoing: []PR{
 				{Repository: "test/repo", Number: 2, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			expectedTitle: "1 / 1", // macOS format: "incoming / outgoing"
+			expectedTitle:     "1 / 1", // macOS format: "incoming / outgoing"
+			expectedRepoTitle: "1 / 1",
 		},
 		{
 			name: "mixed blocked and unblocked",
@@ -434,7 +454,8 @@ func TestTrayTitleUpdates(t *testing.T) {
 				{Repository: "test/repo", Number: 3, IsBlocked: false, UpdatedAt: time.Now()},
 				{Repository: "test/repo", Number: 4, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			expectedTitle: "1 / 1", // macOS format: "incoming / outgoing"
+			expectedTitle:     "1 / 1", // macOS format: "incoming / outgoing"
+			expectedRepoTitle: "1 / 1",
 		},
 		{
 			name: "hidden org filters out blocked PRs",
@@ -442,9 +463,10 @@ func TestTrayTitleUpdates(t *testing.T) {
 				{Repository: "hidden-org/repo", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 				{Repository: "visible-org/repo", Number: 2, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			outgoing:      []PR{},
-			hiddenOrgs:    map[string]bool{"hidden-org": true},
-			expectedTitle: "1", // macOS format: just the count
+			outgoing:          []PR{},
+			hiddenOrgs:        map[string]bool{"hidden-org": true},
+			expectedTitle:     "1", // macOS format: just the count
+			expectedRepoTitle: "1",
 		},
 		{
 			name: "stale PRs filtered when hideStaleIncoming is true",
@@ -455,189 +477,149 @@ func TestTrayTitleUpdates(t *testing.T) {
 			outgoing:          []PR{},
 			hideStaleIncoming: true,
 			expectedTitle:     "1", // macOS format: just the count
+			expectedRepoTitle: "1",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			app.incoming = tt.incoming
-			app.outgoing = tt.outgoing
-			app.hiddenOrgs = tt.hiddenOrgs
-			app.hideStaleIncoming = tt.hideStaleIncoming
-
-			// Call setTrayTitle to get the actual title
-			app.setTrayTitle()
-			mockSystray, ok := app.systrayInterface.(*MockSystray)
-			if !ok {
-				t.Fatal("Failed to cast systrayInterface to MockSystray")
-			}
-			actualTitle := mockSystray.title
-
-			// Adjust expected title based on platform
-			expectedTitle := tt.expectedTitle
-			if runtime.GOOS != "darwin" {
-				// Non-macOS platforms show icon only (no text)
-				expectedTitle = ""
-			}
-
-			if actualTitle != expectedTitle {
-				t.Errorf("Expected tray title %q, got %q", expectedTitle, actualTitle)
-			}
-		})
-	}
-}
-
-// TestSoundPlaybackDuringTransitions tests the logic for when sounds should be played during PR state transitions.
-func TestSoundPlaybackDuringTransitions(t *testing.T) {
-	// This test verifies the logic by checking state transitions
-	// Actual sound playback is tested through logging in integration tests
-	ctx := context.Background()
-
-	// Create app with initial state
-	app := &App{
-		mu:                  sync.RWMutex{},
-		stateManager:        NewPRStateManager(time.Now().Add(-35 * time.Second)), // Past grace period
-		blockedPRTimes:      make(map[string]time.Time),
-		hiddenOrgs:          make(map[string]bool),
-		seenOrgs:            make(map[string]bool),
-		previousBlockedPRs:  make(map[string]bool),
-		browserRateLimiter:  ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
-		enableAudioCues:     true,
-		initialLoadComplete: true, // Set to true to allow sound playback
-		menuInitialized:     true,
-		systrayInterface:    &MockSystray{}, // Use mock systray to avoid Windows-specific panics
-	}
-
-	tests := []struct {
-		name            string
-		initialIncoming []PR
-		initialOutgoing []PR
-		updatedIncoming []PR
-		updatedOutgoing []PR
-		expectedSounds  []string
-		description     string
-	}{
 		{
-			name: "incoming PR becomes blocked",
-			initialIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: false, UpdatedAt: time.Now()},
-			},
-			initialOutgoing: []PR{},
-			updatedIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
+			name: "dependency bot storm across two repos",
+			incoming: []PR{
+				{Repository: "org/api", Number: 1, NeedsReview: true, AuthorBot: true, UpdatedAt: time.Now()},
+				{Repository: "org/api", Number: 2, NeedsReview: true, AuthorBot: true, UpdatedAt: time.Now()},
+				{Repository: "org/api", Number: 3, NeedsReview: true, AuthorBot: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 4, NeedsReview: true, AuthorBot: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 5, NeedsReview: true, AuthorBot: true, UpdatedAt: time.Now()},
+				{Repository: "org/docs", Number: 6, NeedsReview: false, UpdatedAt: time.Now()}, // not blocked
 			},
-			updatedOutgoing: []PR{},
-			expectedSounds:  []string{"honk"},
-			description:     "Should play honk sound when incoming PR becomes blocked",
+			outgoing:          []PR{},
+			expectedTitle:     "5",
+			expectedRepoTitle: "2",
 		},
 		{
-			name:            "outgoing PR becomes blocked",
-			initialIncoming: []PR{},
-			initialOutgoing: []PR{
-				{Repository: "test/repo", Number: 2, URL: "https://github.com/test/repo/pull/2", IsBlocked: false, UpdatedAt: time.Now()},
+			name: "blocked PRs in several repos on both sides",
+			incoming: []PR{
+				{Repository: "org/api", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
+				{Repository: "org/api", Number: 2, NeedsReview: true, UpdatedAt: time.Now()},
+				{Repository: "other/lib", Number: 3, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			updatedIncoming: []PR{},
-			updatedOutgoing: []PR{
-				{Repository: "test/repo", Number: 2, URL: "https://github.com/test/repo/pull/2", IsBlocked: true, UpdatedAt: time.Now()},
+			outgoing: []PR{
+				{Repository: "org/api", Number: 4, IsBlocked: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 5, IsBlocked: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 6, IsBlocked: true, UpdatedAt: time.Now()},
+				{Repository: "org/cli", Number: 7, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			expectedSounds: []string{"rocket"},
-			description:    "Should play rocket sound when outgoing PR becomes blocked",
+			expectedTitle:     "3 / 4",
+			expectedRepoTitle: "2 / 3",
 		},
 		{
-			name: "multiple PRs become blocked",
-			initialIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: false, UpdatedAt: time.Now()},
-			},
-			initialOutgoing: []PR{
-				{Repository: "test/repo", Number: 2, URL: "https://github.com/test/repo/pull/2", IsBlocked: false, UpdatedAt: time.Now()},
+			name: "hidden org repos are not counted",
+			incoming: []PR{
+				{Repository: "hidden-org/a", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
+				{Repository: "hidden-org/b", Number: 2, NeedsReview: true, UpdatedAt: time.Now()},
+				{Repository: "visible-org/repo", Number: 3, NeedsReview: true, UpdatedAt: time.Now()},
+				{Repository: "visible-org/repo", Number: 4, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			updatedIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
+			outgoing:          []PR{},
+			hiddenOrgs:        map[string]bool{"hidden-org": true},
+			expectedTitle:     "2",
+			expectedRepoTitle: "1",
+		},
+		{
+			name: "outgoing section hidden",
+			incoming: []PR{
+				{Repository: "org/api", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			updatedOutgoing: []PR{
-				{Repository: "test/repo", Number: 2, URL: "https://github.com/test/repo/pull/2", IsBlocked: true, UpdatedAt: time.Now()},
+			outgoing: []PR{
+				{Repository: "org/api", Number: 2, IsBlocked: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 3, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			expectedSounds: []string{"honk", "rocket"},
-			description:    "Should play both sounds when both PR types become blocked",
+			hideOutgoing:      true,
+			expectedTitle:     "1",
+			expectedRepoTitle: "1",
 		},
 		{
-			name: "PR becomes unblocked - no sound",
-			initialIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
+			name: "incoming section hidden",
+			incoming: []PR{
+				{Repository: "org/api", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			initialOutgoing: []PR{},
-			updatedIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: false, UpdatedAt: time.Now()},
+			outgoing: []PR{
+				{Repository: "org/api", Number: 2, IsBlocked: true, UpdatedAt: time.Now()},
+				{Repository: "org/web", Number: 3, IsBlocked: true, UpdatedAt: time.Now()},
 			},
-			updatedOutgoing: []PR{},
-			expectedSounds:  []string{},
-			description:     "Should not play sound when PR becomes unblocked",
+			hideIncoming:      true,
+			expectedTitle:     "2",
+			expectedRepoTitle: "2",
 		},
 		{
-			name: "already blocked PR stays blocked - no sound",
-			initialIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
+			name: "only the hidden section is blocked",
+			incoming: []PR{
+				{Repository: "org/api", Number: 1, NeedsReview: true, UpdatedAt: time.Now()},
 			},
-			initialOutgoing: []PR{},
-			updatedIncoming: []PR{
-				{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
+			outgoing: []PR{
+				{Repository: "org/api", Number: 2, IsBlocked: false, UpdatedAt: time.Now()},
 			},
-			updatedOutgoing: []PR{},
-			expectedSounds:  []string{},
-			description:     "Should not play sound when PR stays blocked",
+			hideIncoming:      true,
+			expectedTitle:     "",
+			expectedRepoTitle: "",
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Reset app state for each test
-			app.previousBlockedPRs = make(map[string]bool)
-			app.blockedPRTimes = make(map[string]time.Time)
-
-			// Note: We can't directly capture sound calls in unit tests since playSound is a method.
-			// This test verifies the state transitions that would trigger sounds.
-			// Actual sound playback is verified through integration testing.
-
-			// Set initial state
-			app.mu.Lock()
-			app.incoming = tt.initialIncoming
-			app.outgoing = tt.initialOutgoing
-			app.mu.Unlock()
-
-			// Run first check to establish baseline
-			app.checkForNewlyBlockedPRs(ctx)
-
-			// Update to new state
-			app.mu.Lock()
-			app.incoming = tt.updatedIncoming
-			app.outgoing = tt.updatedOutgoing
-			app.mu.Unlock()
-
-			// Run check again to detect transitions
-			app.checkForNewlyBlockedPRs(ctx)
-
-			// Verify state transitions occurred correctly
-			// For newly blocked PRs after grace period, the previousBlockedPRs map should be updated
-			if len(tt.expectedSounds) > 0 {
-				// Check that blocked PRs are tracked in previousBlockedPRs
-				blocked := 0
-				app.mu.RLock()
-				for _, pr := range app.incoming {
-					if pr.NeedsReview && app.previousBlockedPRs[pr.URL] {
-						blocked++
-					}
+		for _, countRepos := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/count_repos=%v", tt.name, countRepos), func(t *testing.T) {
+				app.incoming = tt.incoming
+				app.outgoing = tt.outgoing
+				app.hiddenOrgs = tt.hiddenOrgs
+				app.hideStaleIncoming = tt.hideStaleIncoming
+				app.hideIncoming = tt.hideIncoming
+				app.hideOutgoing = tt.hideOutgoing
+				app.countRepos = countRepos
+
+				want := tt.expectedTitle
+				if countRepos {
+					want = tt.expectedRepoTitle
 				}
-				for _, pr := range app.outgoing {
-					if pr.IsBlocked && app.previousBlockedPRs[pr.URL] {
-						blocked++
-					}
+				if got := trayTitle(app.countPRs(), countRepos); got != want {
+					t.Errorf("trayTitle() = %q, want %q", got, want)
 				}
-				app.mu.RUnlock()
-				if blocked == 0 {
-					t.Errorf("%s: expected blocked PRs to be tracked in previousBlockedPRs", tt.description)
+
+				// Present the tray state to get the actual title
+				app.presentTrayState()
+				mockSystray, ok := app.systrayInterface.(*MockSystray)
+				if !ok {
+					t.Fatal("Failed to cast systrayInterface to MockSystray")
 				}
-			}
-		})
+				actualTitle := mockSystray.title
+
+				// Adjust expected title based on platform
+				expectedTitle := want
+				if runtime.GOOS != "darwin" {
+					// Non-macOS platforms show icon only (no text)
+					expectedTitle = ""
+				}
+
+				if actualTitle != expectedTitle {
+					t.Errorf("Expected tray title %q, got %q", expectedTitle, actualTitle)
+				}
+			})
+		}
+	}
+}
+
+func TestSectionHeader(t *testing.T) {
+	tests := []struct {
+		want       string
+		blocked    int
+		repos      int
+		countRepos bool
+	}{
+		{blocked: 3, repos: 2, countRepos: false, want: "Incoming — 3 blocked on you"},
+		{blocked: 3, repos: 2, countRepos: true, want: "Incoming — 3 blocked across 2 repos"},
+		{blocked: 14, repos: 1, countRepos: true, want: "Incoming — 14 blocked across 1 repo"},
+		{blocked: 0, repos: 0, countRepos: true, want: "Incoming — 0 blocked on you"},
+	}
+	for _, tt := range tests {
+		if got := sectionHeader("Incoming", tt.blocked, tt.repos, tt.countRepos); got != tt.want {
+			t.Errorf("sectionHeader(%d, %d, %v) = %q, want %q", tt.blocked, tt.repos, tt.countRepos, got, tt.want)
+		}
 	}
 }
 
@@ -650,7 +632,7 @@ func TestSoundDisabledNoPlayback(t *testing.T) {
 		stateManager:        NewPRStateManager(time.Now().Add(-35 * time.Second)), // Past grace period
 		blockedPRTimes:      make(map[string]time.Time),
 		hiddenOrgs:          make(map[string]bool),
-		seenOrgs:            make(map[string]bool),
+		seenOrgs:            make(map[string]orgActivity),
 		previousBlockedPRs:  make(map[string]bool),
 		browserRateLimiter:  ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		enableAudioCues:     false, // Audio disabled
@@ -680,238 +662,6 @@ func TestSoundDisabledNoPlayback(t *testing.T) {
 	}
 }
 
-// TestGracePeriodPreventsNotifications tests that no sounds/notifications occur during the grace period.
-func TestGracePeriodPreventsNotifications(t *testing.T) {
-	ctx := context.Background()
-
-	// Create app with a very recent start time (within grace period)
-	app := &App{
-		mu:                  sync.RWMutex{},
-		stateManager:        NewPRStateManager(time.Now()), // Within grace period
-		blockedPRTimes:      make(map[string]time.Time),
-		hiddenOrgs:          make(map[string]bool),
-		seenOrgs:            make(map[string]bool),
-		previousBlockedPRs:  make(map[string]bool),
-		browserRateLimiter:  ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
-		enableAudioCues:     true,
-		initialLoadComplete: true,
-		menuInitialized:     true,
-		startTime:           time.Now(),     // Just started
-		systrayInterface:    &MockSystray{}, // Use mock systray to avoid panics
-	}
-
-	// Track whether we're in grace period for verification
-	inGracePeriod := func() bool {
-		return time.Since(app.startTime) < startupGracePeriod
-	}
-
-	// Set initial state with no blocked PRs
-	app.incoming = []PR{
-		{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: false, UpdatedAt: time.Now()},
-	}
-	app.checkForNewlyBlockedPRs(ctx)
-
-	// PR becomes blocked during grace period
-	app.incoming = []PR{
-		{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
-	}
-	app.checkForNewlyBlockedPRs(ctx)
-
-	// Verify we're still in grace period
-	if !inGracePeriod() {
-		t.Errorf("Expected to still be in grace period")
-	}
-
-	// The PR should be tracked as blocked but not as "newly notified"
-	if !app.previousBlockedPRs["https://github.com/test/repo/pull/1"] {
-		t.Errorf("Expected PR to be tracked as blocked during grace period")
-	}
-
-	// Now simulate time passing beyond grace period (1 minute)
-	app.startTime = time.Now().Add(-61 * time.Second)
-
-	// New PR becomes blocked after grace period
-	app.incoming = []PR{
-		{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
-		{Repository: "test/repo", Number: 2, URL: "https://github.com/test/repo/pull/2", NeedsReview: true, UpdatedAt: time.Now()},
-	}
-	app.checkForNewlyBlockedPRs(ctx)
-
-	// Verify we're past grace period
-	if inGracePeriod() {
-		t.Errorf("Expected to be past grace period")
-	}
-
-	// Both PRs should now be tracked
-	if !app.previousBlockedPRs["https://github.com/test/repo/pull/2"] {
-		t.Errorf("Expected new PR to be tracked as blocked after grace period")
-	}
-}
-
-// TestNotificationScenarios comprehensively tests when notifications should and shouldn't fire.
-func TestNotificationScenarios(t *testing.T) {
-	tests := []struct {
-		name                string
-		timeSinceStart      time.Duration
-		initialLoadComplete bool
-		prWasBlocked        bool
-		prIsBlocked         bool
-		expectNotification  bool
-		description         string
-	}{
-		{
-			name:                "initial_load_already_blocked",
-			timeSinceStart:      1 * time.Second,
-			initialLoadComplete: false,
-			prWasBlocked:        false,
-			prIsBlocked:         true,
-			expectNotification:  false,
-			description:         "PRs already blocked on startup should not notify",
-		},
-		{
-			name:                "newly_blocked_during_grace_period",
-			timeSinceStart:      10 * time.Second,
-			initialLoadComplete: true,
-			prWasBlocked:        false,
-			prIsBlocked:         true,
-			expectNotification:  false,
-			description:         "Newly blocked PRs during grace period should not notify",
-		},
-		{
-			name:                "newly_blocked_after_grace_period",
-			timeSinceStart:      35 * time.Second,
-			initialLoadComplete: true,
-			prWasBlocked:        false,
-			prIsBlocked:         true,
-			expectNotification:  true,
-			description:         "Newly blocked PRs after grace period SHOULD notify",
-		},
-		{
-			name:                "stays_blocked",
-			timeSinceStart:      35 * time.Second,
-			initialLoadComplete: true,
-			prWasBlocked:        true,
-			prIsBlocked:         true,
-			expectNotification:  false,
-			description:         "PRs that stay blocked should not notify again",
-		},
-		{
-			name:                "becomes_unblocked",
-			timeSinceStart:      35 * time.Second,
-			initialLoadComplete: true,
-			prWasBlocked:        true,
-			prIsBlocked:         false,
-			expectNotification:  false,
-			description:         "PRs becoming unblocked should not notify",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-
-			app := &App{
-				mu:                  sync.RWMutex{},
-				stateManager:        NewPRStateManager(time.Now().Add(-tt.timeSinceStart)),
-				blockedPRTimes:      make(map[string]time.Time),
-				hiddenOrgs:          make(map[string]bool),
-				seenOrgs:            make(map[string]bool),
-				previousBlockedPRs:  make(map[string]bool),
-				browserRateLimiter:  ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
-				enableAudioCues:     true,
-				initialLoadComplete: tt.initialLoadComplete,
-				menuInitialized:     true,
-				startTime:           time.Now().Add(-tt.timeSinceStart),
-				systrayInterface:    &MockSystray{}, // Use mock systray to avoid panics
-			}
-
-			// Set up previous state
-			if tt.prWasBlocked {
-				app.previousBlockedPRs["https://github.com/test/repo/pull/1"] = true
-				app.blockedPRTimes["https://github.com/test/repo/pull/1"] = time.Now().Add(-5 * time.Minute)
-			}
-
-			// Set current state
-			app.incoming = []PR{
-				{
-					Repository:  "test/repo",
-					Number:      1,
-					URL:         "https://github.com/test/repo/pull/1",
-					NeedsReview: tt.prIsBlocked,
-					UpdatedAt:   time.Now(),
-				},
-			}
-
-			// Track if we would notify (by checking logs)
-			app.checkForNewlyBlockedPRs(ctx)
-
-			// Verify expectations
-			if tt.expectNotification {
-				// Should have updated previousBlockedPRs
-				if !app.previousBlockedPRs["https://github.com/test/repo/pull/1"] {
-					t.Errorf("%s: Expected PR to be tracked as blocked", tt.description)
-				}
-				// Should have set FirstBlockedAt in state manager
-				if state, exists := app.stateManager.PRState("https://github.com/test/repo/pull/1"); !exists || state.FirstBlockedAt.IsZero() {
-					t.Errorf("%s: Expected FirstBlockedAt to be set in state manager", tt.description)
-				}
-			}
-
-			// Check if PR is tracked correctly
-			if tt.prIsBlocked && !app.previousBlockedPRs["https://github.com/test/repo/pull/1"] {
-				t.Errorf("%s: Expected blocked PR to be tracked", tt.description)
-			}
-		})
-	}
-}
-
-// TestNewlyBlockedPRAfterGracePeriod verifies that a PR becoming blocked after grace period triggers notifications.
-func TestNewlyBlockedPRAfterGracePeriod(t *testing.T) {
-	ctx := context.Background()
-
-	// Create app that's been running for more than 30 seconds
-	app := &App{
-		mu:                  sync.RWMutex{},
-		stateManager:        NewPRStateManager(time.Now().Add(-35 * time.Second)), // Past grace period
-		blockedPRTimes:      make(map[string]time.Time),
-		hiddenOrgs:          make(map[string]bool),
-		seenOrgs:            make(map[string]bool),
-		previousBlockedPRs:  make(map[string]bool),
-		browserRateLimiter:  ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
-		enableAudioCues:     true,
-		initialLoadComplete: true, // Already past initial load
-		menuInitialized:     true,
-		startTime:           time.Now().Add(-35 * time.Second), // Started 35 seconds ago
-		systrayInterface:    &MockSystray{},                    // Use mock systray to avoid panics
-	}
-
-	// Start with no blocked PRs
-	app.incoming = []PR{
-		{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: false, UpdatedAt: time.Now()},
-	}
-
-	// Run initial check to establish baseline
-	app.checkForNewlyBlockedPRs(ctx)
-
-	// Now the PR becomes blocked (after grace period)
-	app.incoming = []PR{
-		{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()},
-	}
-
-	// This should trigger notifications since we're past grace period
-	app.checkForNewlyBlockedPRs(ctx)
-
-	// Verify the PR is tracked as blocked
-	if !app.previousBlockedPRs["https://github.com/test/repo/pull/1"] {
-		t.Error("Expected PR to be tracked as blocked after grace period")
-	}
-
-	// Verify FirstBlockedAt was set in state manager
-	if state, exists := app.stateManager.PRState("https://github.com/test/repo/pull/1"); !exists || state.FirstBlockedAt.IsZero() {
-		t.Error("Expected FirstBlockedAt to be set for newly blocked PR in state manager")
-	}
-}
-
 // TestAuthRetryLoopStopsOnSuccess tests that the auth retry loop stops when auth succeeds.
 func TestAuthRetryLoopStopsOnSuccess(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -1088,15 +838,15 @@ func TestTurnDataDisabled(t *testing.T) {
 	}
 
 	// turnData should return nil without error when disabled
-	data, cached, err := app.turnData(ctx, "https://github.com/test/repo/pull/1", time.Now())
+	data, decision, err := app.turnData(ctx, "https://github.com/test/repo/pull/1", time.Now())
 	if err != nil {
 		t.Errorf("Expected no error when Turn API disabled, got: %v", err)
 	}
 	if data != nil {
 		t.Error("Expected nil data when Turn API disabled")
 	}
-	if cached {
-		t.Error("Expected cached=false when Turn API disabled")
+	if decision != "" {
+		t.Errorf("Expected no cache decision when Turn API disabled, got %q", decision)
 	}
 }
 
@@ -17,7 +17,7 @@ func TestMenuChangeDetection(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{},
@@ -110,7 +110,7 @@ func TestFirstRunMenuRebuildBug(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		menuInitialized:    false,
@@ -169,7 +169,7 @@ func TestHiddenOrgChangesMenu(t *testing.T) {
 		mu:                 sync.RWMutex{},
 		stateManager:       NewPRStateManager(time.Now()),
 		hiddenOrgs:         make(map[string]bool),
-		seenOrgs:           make(map[string]bool),
+		seenOrgs:           make(map[string]orgActivity),
 		blockedPRTimes:     make(map[string]time.Time),
 		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
 		systrayInterface:   &MockSystray{},
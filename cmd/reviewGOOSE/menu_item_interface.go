@@ -1,6 +1,10 @@
 package main
 
-import "github.com/energye/systray"
+import (
+	"log/slog"
+
+	"github.com/energye/systray"
+)
 
 // MenuItem is an interface for menu items that can be implemented by both
 // real systray menu items and mock menu items for testing.
@@ -18,6 +22,9 @@ type MenuItem interface {
 // RealMenuItem wraps a real systray.MenuItem to implement our MenuItem interface.
 type RealMenuItem struct {
 	*systray.MenuItem
+	tray       *RealSystray // Nil for items not created through RealSystray
+	generation uint64       // The RealSystray menu generation the item belongs to
+	hasClick   bool
 }
 
 // Ensure RealMenuItem implements MenuItem interface.
@@ -53,15 +60,47 @@ func (r *RealMenuItem) SetTooltip(tooltip string) {
 	r.MenuItem.SetTooltip(tooltip)
 }
 
-// Click sets the click handler.
+// Click sets the click handler. Clicks on an item from an older menu generation are
+// ignored, so a handler never outlives the menu it was registered for.
 func (r *RealMenuItem) Click(handler func()) {
-	r.MenuItem.Click(handler)
+	if r.tray == nil {
+		r.MenuItem.Click(handler)
+		return
+	}
+	r.tray.mu.Lock()
+	r.hasClick = handler != nil
+	r.tray.mu.Unlock()
+	if handler == nil {
+		r.MenuItem.Click(nil)
+		return
+	}
+	tray, generation := r.tray, r.generation
+	r.MenuItem.Click(func() {
+		if !tray.current(generation) {
+			slog.Warn("[SYSTRAY] Ignoring click on an item from a previous menu", "title", r.String())
+			return
+		}
+		handler()
+	})
+}
+
+// release drops the item's click handler. The caller must not hold tray.mu.
+func (r *RealMenuItem) release() {
+	r.MenuItem.Click(nil)
+	if r.tray != nil {
+		r.tray.mu.Lock()
+		r.hasClick = false
+		r.tray.mu.Unlock()
+	}
 }
 
 // AddSubMenuItem adds a sub menu item and returns it wrapped in our interface.
 func (r *RealMenuItem) AddSubMenuItem(title, tooltip string) MenuItem {
 	subItem := r.MenuItem.AddSubMenuItem(title, tooltip)
-	return &RealMenuItem{MenuItem: subItem}
+	if r.tray == nil {
+		return &RealMenuItem{MenuItem: subItem}
+	}
+	return r.tray.track(subItem)
 }
 
 // MockMenuItem implements MenuItem for testing without calling systray functions.
@@ -112,6 +151,30 @@ func (m *MockMenuItem) Click(handler func()) {
 	m.clickHandler = handler
 }
 
+// release drops the handlers of the item and its sub items, as ResetMenu does.
+func (m *MockMenuItem) release() {
+	m.clickHandler = nil
+	for _, sub := range m.subItems {
+		if s, ok := sub.(*MockMenuItem); ok {
+			s.release()
+		}
+	}
+}
+
+// liveHandlers counts the click handlers on the item and its sub items.
+func (m *MockMenuItem) liveHandlers() int {
+	n := 0
+	if m.clickHandler != nil {
+		n++
+	}
+	for _, sub := range m.subItems {
+		if s, ok := sub.(*MockMenuItem); ok {
+			n += s.liveHandlers()
+		}
+	}
+	return n
+}
+
 // AddSubMenuItem adds a sub menu item (mock).
 func (m *MockMenuItem) AddSubMenuItem(title, tooltip string) MenuItem {
 	subItem := &MockMenuItem{
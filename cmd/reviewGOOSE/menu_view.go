@@ -0,0 +1,123 @@
+package main
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// assertMenuCounts makes a section header whose blocked count disagrees with its
+// blocked rows panic instead of only logging. Tests turn it on.
+var assertMenuCounts = false
+
+// prView is one consistent snapshot of the PR lists and the settings that filter them.
+// The tray count, the menu rows, their change detection, and notifications each work
+// from a single view, so an org hidden or a PR going stale mid-update can't make them
+// disagree.
+type prView struct {
+	at          time.Time // The one "now" the stale filter uses
+	hiddenOrgs  map[string]bool
+	hiddenPRs   map[string]bool // PRs I hid forever, by URL
+	focusRepo   string
+	hideStale   bool
+	snooze      *incomingSnooze
+	allIncoming []PR // Every incoming PR with the draft policy applied, before dismissals and filters
+	allOutgoing []PR
+	incoming    []PR // The incoming PRs the menu shows, with dismissals applied, unsorted
+	outgoing    []PR
+}
+
+// snapshotPRs takes a view of the current PR lists and filter settings.
+func (app *App) snapshotPRs() *prView {
+	app.mu.RLock()
+	v := &prView{
+		at:          time.Now(),
+		hiddenOrgs:  maps.Clone(app.hiddenOrgs),
+		focusRepo:   app.focusRepo,
+		hideStale:   app.hideStaleIncoming,
+		allIncoming: applyDraftPolicy(slices.Clone(app.incoming), app.draftsBlock),
+		allOutgoing: applyDraftPolicy(slices.Clone(app.outgoing), app.draftsBlock),
+	}
+	v.snooze = app.snooze
+	hideIncoming, hideOutgoing := app.hideIncoming, app.hideOutgoing
+	app.mu.RUnlock()
+	v.hiddenPRs = app.hiddenPRURLs()
+
+	v.incoming = v.filter(applySnooze(app.withDismissals(shownSection(v.allIncoming, hideIncoming)), v.snooze, v.at))
+	v.outgoing = v.filter(app.withDismissals(shownSection(v.allOutgoing, hideOutgoing)))
+	return v
+}
+
+// shows reports whether pr passes the hidden org and PR, focus, and stale filters.
+func (v *prView) shows(pr *PR) bool {
+	if org := extractOrgFromRepo(pr.Repository); org != "" && v.hiddenOrgs[org] {
+		return false
+	}
+	if v.hiddenPRs[pr.URL] {
+		return false
+	}
+	if focusFilterOut(pr.Repository, v.focusRepo) {
+		return false
+	}
+	return !v.hideStale || !pr.UpdatedAt.Before(v.at.Add(-stalePRThreshold))
+}
+
+// filter returns the PRs in prs that the view shows.
+func (v *prView) filter(prs []PR) []PR {
+	var shown []PR
+	for i := range prs {
+		if v.shows(&prs[i]) {
+			shown = append(shown, prs[i])
+		}
+	}
+	return shown
+}
+
+// blockedInSection reports whether pr counts toward its section's blocked count: incoming
+// PRs need me, outgoing PRs are blocked on me.
+func blockedInSection(pr *PR, sectionTitle string) bool {
+	if sectionTitle == "Outgoing" {
+		return pr.IsBlocked
+	}
+	return pr.NeedsReview
+}
+
+// counts tallies the shown PRs.
+func (v *prView) counts() PRCounts {
+	var c PRCounts
+	tally := func(prs []PR, section string, total, blocked, blockedRepos *int) {
+		repos := make(map[string]bool)
+		for i := range prs {
+			*total++
+			if blockedInSection(&prs[i], section) {
+				*blocked++
+				repos[prs[i].Repository] = true
+			}
+		}
+		*blockedRepos = len(repos)
+	}
+	tally(v.incoming, "Incoming", &c.IncomingTotal, &c.IncomingBlocked, &c.IncomingBlockedRepos)
+	tally(v.outgoing, "Outgoing", &c.OutgoingTotal, &c.OutgoingBlocked, &c.OutgoingBlockedRepos)
+	slog.Debug("[MENU] PR counts",
+		"incoming_before_filter", len(v.allIncoming),
+		"incoming", c.IncomingTotal,
+		"incoming_blocked", c.IncomingBlocked,
+		"outgoing_before_filter", len(v.allOutgoing),
+		"outgoing", c.OutgoingTotal,
+		"outgoing_blocked", c.OutgoingBlocked)
+	return c
+}
+
+// checkSectionCount reports a section header whose blocked count doesn't match the
+// blocked rows built under it.
+func checkSectionCount(sectionTitle string, headerBlocked, rowsBlocked int) {
+	if headerBlocked == rowsBlocked {
+		return
+	}
+	slog.Error("[MENU] Blocked count disagrees with the menu rows",
+		"section", sectionTitle, "count", headerBlocked, "rows", rowsBlocked)
+	if assertMenuCounts {
+		panic("menu: " + sectionTitle + " blocked count disagrees with its rows")
+	}
+}
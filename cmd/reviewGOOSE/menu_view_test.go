@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// discoveringSystray applies an org policy the moment the tray title is set, the way an
+// org discovered mid-update lands between the badge and the section rows.
+type discoveringSystray struct {
+	*MockSystray
+	discover func()
+	once     sync.Once
+}
+
+func (s *discoveringSystray) SetTitle(title string) {
+	s.MockSystray.SetTitle(title)
+	s.once.Do(s.discover)
+}
+
+func TestBadgeMatchesMenuWhenOrgHiddenMidUpdate(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.hideStaleIncoming = true
+	mock := &MockSystray{}
+	app.systrayInterface = &discoveringSystray{MockSystray: mock, discover: func() { app.setOrgPolicy("newco", orgPolicyHidden) }}
+	now := time.Now()
+	for i, repo := range []string{"acme/api", "acme/web", "newco/app"} {
+		app.incoming = append(app.incoming, PR{
+			Repository: repo, Number: i + 1, URL: fmt.Sprintf("https://github.com/%s/pull/%d", repo, i+1),
+			NeedsReview: true, UpdatedAt: now,
+		})
+	}
+
+	app.rebuildMenu(context.Background()) // assertMenuCounts panics on a header/row mismatch
+
+	trayBlocked := func() int {
+		app.tray.mu.Lock()
+		defer app.tray.mu.Unlock()
+		return app.tray.counts.IncomingBlocked
+	}
+	badge := trayBlocked()
+	if badge != 3 {
+		t.Errorf("badge = %d, want the 3 PRs visible when the update started", badge)
+	}
+	mock.mu.Lock()
+	items := mock.menuItems
+	mock.mu.Unlock()
+	rows := 0
+	header := false
+	for _, title := range items {
+		if strings.Contains(title, "/app") || strings.Contains(title, "/api") || strings.Contains(title, "/web") {
+			rows++
+		}
+		header = header || title == sectionHeader("Incoming", badge, 0, false)
+	}
+	if rows != badge || !header {
+		t.Errorf("badge %d, %d rows, header present %v, want them to agree: %q", badge, rows, header, items)
+	}
+
+	// The next update sees the hidden org everywhere at once
+	app.rebuildMenu(context.Background())
+	if got := trayBlocked(); got != 2 {
+		t.Errorf("badge after the policy applied = %d, want 2", got)
+	}
+}
+
+func TestCheckSectionCountPanicsInTests(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("checkSectionCount() didn't panic on a mismatch")
+		}
+	}()
+	checkSectionCount("Incoming", 2, 3)
+}
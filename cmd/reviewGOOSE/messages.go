@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"log/slog"
+	"os"
+	"sync/atomic"
+
+	"github.com/codeGROOVE-dev/goose/pkg/i18n"
+)
+
+// defaultLocale is used for message IDs a locale doesn't translate.
+const defaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalog holds every user-visible string; see locales/en.json for the message IDs.
+var catalog = mustLoadCatalog()
+
+// activeLocale is the locale msg renders in. The UI is process-wide, so this is too.
+var activeLocale atomic.Value
+
+func mustLoadCatalog() *i18n.Catalog {
+	c, err := i18n.Load(localeFiles, "locales", defaultLocale)
+	if err != nil {
+		panic("load message catalog: " + err.Error())
+	}
+	return c
+}
+
+// msg returns the message for id in the active locale, filling {0}, {1}, ... from args.
+func msg(id string, args ...any) string {
+	locale, ok := activeLocale.Load().(string)
+	if !ok {
+		locale = defaultLocale
+	}
+	return catalog.Translate(locale, id, args...)
+}
+
+// setLocale switches the locale msg renders in.
+func setLocale(locale string) {
+	activeLocale.Store(locale)
+}
+
+// systemLocale picks a catalog locale from the environment, in POSIX precedence order.
+func systemLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if locale := catalog.Match(v); locale != "" {
+				return locale
+			}
+			return defaultLocale
+		}
+	}
+	return defaultLocale
+}
+
+// applyLocale activates the locale setting, auto-detecting when it's empty.
+func (app *App) applyLocale() {
+	app.mu.RLock()
+	locale := app.localeSetting
+	app.mu.RUnlock()
+
+	if catalog.Messages(locale) == nil {
+		if locale != "" {
+			slog.Warn("Unknown locale setting, using system locale", "locale", locale)
+		}
+		locale = systemLocale()
+	}
+	slog.Info("Using locale", "locale", locale)
+	setLocale(locale)
+}
+
+// addLanguageMenu adds the "Language" submenu: automatic detection plus each catalog locale
+// listed by its own name.
+func (app *App) addLanguageMenu(ctx context.Context) {
+	languageMenu := app.systrayInterface.AddMenuItem(msg("language.menu"), msg("language.menu.tooltip"))
+
+	app.mu.RLock()
+	current := app.localeSetting
+	app.mu.RUnlock()
+
+	choices := append([]string{""}, catalog.Locales()...)
+	for _, l := range choices {
+		locale := l // Capture for closure
+		text := msg("language.auto")
+		if locale != "" {
+			text = catalog.Translate(locale, "language.name")
+		}
+		if locale == current {
+			text = "✓ " + text
+		}
+		item := languageMenu.AddSubMenuItem(text, "")
+		item.Click(func() {
+			app.mu.Lock()
+			app.localeSetting = locale
+			app.mu.Unlock()
+
+			slog.Info("[SETTINGS] Locale changed", "locale", locale)
+			app.applyLocale()
+			app.saveSettings()
+			app.presentTrayState()
+			app.rebuildMenu(ctx)
+		})
+	}
+}
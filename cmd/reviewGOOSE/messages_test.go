@@ -0,0 +1,183 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/codeGROOVE-dev/goose/pkg/i18n"
+)
+
+// useLocale switches msg to locale for the duration of the test.
+func useLocale(t *testing.T, locale string) {
+	t.Helper()
+	setLocale(locale)
+	t.Cleanup(func() { setLocale(defaultLocale) })
+}
+
+func TestMsgFallsBackToEnglishPerKey(t *testing.T) {
+	useLocale(t, "ja")
+
+	if got := msg("menu.quit"); got != "終了" {
+		t.Errorf("menu.quit = %q, want the Japanese translation", got)
+	}
+	// Every shipped catalog is complete, so a partial one stands in for a new translation
+	shipped := catalog
+	t.Cleanup(func() { catalog = shipped })
+	partial, err := i18n.Load(fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"menu.quit": "Quit", "notify.tests_finished.other": "Tests finished on {0} #{1} ({2})"}`)},
+		"locales/ja.json": {Data: []byte(`{"menu.quit": "終了"}`)},
+	}, "locales", defaultLocale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog = partial
+	if got, want := msg("notify.tests_finished.other", "acme/widgets", 7, "cancelled"), "Tests finished on acme/widgets #7 (cancelled)"; got != want {
+		t.Errorf("notify.tests_finished.other = %q, want %q", got, want)
+	}
+	if got := msg("no.such.id"); got != "no.such.id" {
+		t.Errorf("unknown id = %q, want the id itself", got)
+	}
+}
+
+func TestMsgPlaceholderOrder(t *testing.T) {
+	useLocale(t, "ja")
+
+	// The Japanese string reorders the arguments: repo count before PR count.
+	got := sectionHeader("Incoming", 5, 2, true)
+	if want := "受信 — 2 リポジトリで 5 件ブロック中"; got != want {
+		t.Errorf("sectionHeader = %q, want %q", got, want)
+	}
+}
+
+func TestSystemLocale(t *testing.T) {
+	tests := []struct {
+		name, lcAll, lang string
+		want              string
+	}{
+		{name: "lang", lang: "de_DE.UTF-8", want: "de"},
+		{name: "lc_all wins", lcAll: "ja_JP.UTF-8", lang: "de_DE.UTF-8", want: "ja"},
+		{name: "unknown", lang: "fr_FR.UTF-8", want: defaultLocale},
+		{name: "posix", lang: "C", want: defaultLocale},
+		{name: "unset", want: defaultLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.lang)
+			if got := systemLocale(); got != tt.want {
+				t.Errorf("systemLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	en := catalog.Messages(defaultLocale)
+	for _, locale := range catalog.Locales() {
+		messages := catalog.Messages(locale)
+		for id := range en {
+			if _, ok := messages[id]; !ok {
+				t.Errorf("%s: %q is missing from %s.json", locale, id, locale)
+			}
+		}
+		for id, text := range messages {
+			base, ok := en[id]
+			if !ok {
+				t.Errorf("%s: %q is not in %s.json", locale, id, defaultLocale)
+				continue
+			}
+			for _, n := range i18n.Placeholders(text) {
+				if !slices.Contains(i18n.Placeholders(base), n) {
+					t.Errorf("%s: %q uses {%d}, which the English text doesn't", locale, id, n)
+				}
+			}
+		}
+	}
+}
+
+// TestMessageIDsExist scans the package source so a typo'd message ID fails here
+// rather than showing up as a raw ID in the menu.
+func TestMessageIDsExist(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	en := catalog.Messages(defaultLocale)
+	fset := token.NewFileSet()
+	found := 0
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if fn, ok := call.Fun.(*ast.Ident); !ok || fn.Name != "msg" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				t.Errorf("%s: msg called with a non-literal ID", fset.Position(call.Pos()))
+				return true
+			}
+			id, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			found++
+			if _, ok := en[id]; !ok {
+				t.Errorf("%s: message ID %q is missing from %s.json", fset.Position(lit.Pos()), id, defaultLocale)
+			}
+			return true
+		})
+	}
+	if found == 0 {
+		t.Fatal("found no msg calls; is the scan looking in the right place?")
+	}
+}
+
+func TestLocaleSettingPersists(t *testing.T) {
+	t.Cleanup(func() { setLocale(defaultLocale) })
+	app, mock := newSettingsMenuTestApp(t)
+	app.localeSetting = "de"
+	app.applyLocale()
+	app.saveSettings()
+
+	app.localeSetting = ""
+	app.loadSettings()
+	if app.localeSetting != "de" {
+		t.Fatalf("localeSetting after reload = %q, want %q", app.localeSetting, "de")
+	}
+
+	app.rebuildMenu(t.Context())
+	if !slices.Contains(mock.menuItems, "Sprache") {
+		t.Errorf("expected the German language menu, got %v", mock.menuItems)
+	}
+}
+
+func TestApplyLocaleIgnoresUnknownSetting(t *testing.T) {
+	t.Cleanup(func() { setLocale(defaultLocale) })
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	app := &App{localeSetting: "xx"}
+	app.applyLocale()
+	if got := msg("menu.quit"); got != "Beenden" {
+		t.Errorf("menu.quit = %q, want the system (German) translation", got)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// metricsReadHeaderTimeout bounds how long a scraper may take to send request headers.
+const metricsReadHeaderTimeout = 5 * time.Second
+
+// Sprinkler event outcomes reported in sprinkler_events_total.
+const (
+	sprinklerEventProcessed = "processed"
+	sprinklerEventDropped   = "dropped"
+	sprinklerEventDeduped   = "deduped"
+	sprinklerEventInvalid   = "unparseable" // No usable PR URL
+)
+
+func (hm *healthMonitor) recordGitHubCall() {
+	hm.githubCalls.Add(1)
+}
+
+func (hm *healthMonitor) recordNotificationSent() {
+	hm.notificationsSent.Add(1)
+}
+
+func (hm *healthMonitor) recordSprinklerEvent(result string) {
+	switch result {
+	case sprinklerEventProcessed:
+		hm.sprinklerProcessed.Add(1)
+	case sprinklerEventDropped:
+		hm.sprinklerDropped.Add(1)
+	case sprinklerEventDeduped:
+		hm.sprinklerDeduped.Add(1)
+	case sprinklerEventInvalid:
+		hm.sprinklerInvalid.Add(1)
+	default:
+		slog.Warn("[METRICS] Unknown sprinkler event result", "result", result)
+	}
+}
+
+// recordBlocked keeps the blocked counts last shown in the tray.
+func (hm *healthMonitor) recordBlocked(incoming, outgoing int) {
+	hm.blockedIncoming.Store(int64(incoming))
+	hm.blockedOutgoing.Store(int64(outgoing))
+}
+
+// githubCallCounter counts every request made through the GitHub client.
+type githubCallCounter struct {
+	base   http.RoundTripper
+	health *healthMonitor
+}
+
+func (c *githubCallCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.health.recordGitHubCall()
+	return c.base.RoundTrip(req)
+}
+
+// countGitHubCalls wraps client's transport so its requests show up in github_api_calls_total.
+func (app *App) countGitHubCalls(client *http.Client) {
+	if app.healthMonitor == nil {
+		return
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &githubCallCounter{base: base, health: app.healthMonitor}
+}
+
+// writeMetrics writes the health monitor's counters and the app's gauges in the
+// Prometheus text exposition format.
+func (app *App) writeMetrics(w io.Writer, now time.Time) error {
+	hm := app.healthMonitor
+	hm.mu.RLock()
+	turnHits := hm.cacheHits
+	turnMisses := hm.apiCalls - hm.apiErrors // Cache misses answered by the Turn API
+	turnErrors := hm.apiErrors
+	hm.mu.RUnlock()
+
+	app.mu.RLock()
+	failures := app.consecutiveFailures
+	lastSuccess := app.lastSuccessfulFetch
+	app.mu.RUnlock()
+	sinceSuccess := float64(-1) // Never fetched successfully
+	if !lastSuccess.IsZero() {
+		sinceSuccess = now.Sub(lastSuccess).Seconds()
+	}
+
+	m := &metricsWriter{w: w}
+	m.family("github_api_calls_total", "counter", "Requests made to the GitHub API.")
+	m.sample("github_api_calls_total", "", hm.githubCalls.Load())
+	m.family("turn_api_calls_total", "counter", "Turn API lookups by result: cache hit, fetched on a miss, or failed.")
+	m.sample("turn_api_calls_total", `result="hit"`, turnHits)
+	m.sample("turn_api_calls_total", `result="miss"`, turnMisses)
+	m.sample("turn_api_calls_total", `result="error"`, turnErrors)
+	m.family("notifications_sent_total", "counter", "Desktop notifications sent.")
+	m.sample("notifications_sent_total", "", hm.notificationsSent.Load())
+	m.family("sprinkler_events_total", "counter", "Real-time PR events by outcome.")
+	m.sample("sprinkler_events_total", `result="processed"`, hm.sprinklerProcessed.Load())
+	m.sample("sprinkler_events_total", `result="dropped"`, hm.sprinklerDropped.Load())
+	m.sample("sprinkler_events_total", `result="deduped"`, hm.sprinklerDeduped.Load())
+	m.sample("sprinkler_events_total", `result="unparseable"`, hm.sprinklerInvalid.Load())
+	m.family("blocked_incoming", "gauge", "Incoming PRs blocked on you, as shown in the tray.")
+	m.sample("blocked_incoming", "", hm.blockedIncoming.Load())
+	m.family("blocked_outgoing", "gauge", "Your PRs that are blocked, as shown in the tray.")
+	m.sample("blocked_outgoing", "", hm.blockedOutgoing.Load())
+	m.family("consecutive_failures", "gauge", "Update cycles that failed in a row.")
+	m.sample("consecutive_failures", "", int64(failures))
+	m.family("seconds_since_last_successful_fetch", "gauge", "Seconds since PRs were last fetched successfully; -1 if never.")
+	m.sampleFloat("seconds_since_last_successful_fetch", "", sinceSuccess)
+	return m.err
+}
+
+// metricsWriter writes exposition lines, keeping the first error.
+type metricsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *metricsWriter) printf(format string, args ...any) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintf(m.w, format, args...)
+	}
+}
+
+func (m *metricsWriter) family(name, kind, help string) {
+	m.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (m *metricsWriter) sample(name, labels string, v int64) {
+	m.sampleValue(name, labels, strconv.FormatInt(v, 10))
+}
+
+func (m *metricsWriter) sampleFloat(name, labels string, v float64) {
+	m.sampleValue(name, labels, strconv.FormatFloat(v, 'f', 3, 64))
+}
+
+func (m *metricsWriter) sampleValue(name, labels, v string) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	m.printf("%s %s\n", name, v)
+}
+
+// metricsHandler serves writeMetrics.
+func (app *App) metricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := app.writeMetrics(w, time.Now()); err != nil {
+			slog.Debug("[METRICS] Failed to write metrics", "error", err)
+		}
+	})
+}
+
+// serveMetrics serves /metrics on localhost:port until ctx is done. It returns once the
+// port is bound, so a port already in use is reported immediately.
+func (app *App) serveMetrics(ctx context.Context, port int) (net.Addr, error) {
+	if app.healthMonitor == nil {
+		return nil, errors.New("health monitor not initialized")
+	}
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("listen on metrics port: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", app.metricsHandler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+	app.goTracked("metrics server shutdown", func() {
+		<-ctx.Done()
+		if err := srv.Close(); err != nil {
+			slog.Debug("[METRICS] Failed to close metrics server", "error", err)
+		}
+	})
+	app.goTracked("metrics server", func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("[METRICS] Metrics server stopped", "error", err)
+		}
+	})
+
+	slog.Info("[METRICS] Serving Prometheus metrics", "url", "http://"+ln.Addr().String()+"/metrics")
+	return ln.Addr(), nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// metricsSample matches one exposition line: name, optional labels, value.
+var metricsSample = regexp.MustCompile(`^[a-z_]+(\{result="[a-z]+"\})? -?[0-9]+(\.[0-9]+)?$`)
+
+func scrapeMetrics(t *testing.T, app *App) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	app.metricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestMetricsExpositionFormat(t *testing.T) {
+	hm := newHealthMonitor()
+	app := &App{mu: sync.RWMutex{}, healthMonitor: hm, consecutiveFailures: 2, lastSuccessfulFetch: time.Now().Add(-90 * time.Second)}
+	hm.recordCacheAccess(true)
+	hm.recordAPICall(true)
+	hm.recordAPICall(false)
+	hm.recordGitHubCall()
+	hm.recordSprinklerEvent(sprinklerEventDeduped)
+	hm.recordBlocked(3, 1)
+
+	body := scrapeMetrics(t, app)
+	types := map[string]string{}
+	for line := range strings.SplitSeq(strings.TrimSuffix(body, "\n"), "\n") {
+		if rest, ok := strings.CutPrefix(line, "# TYPE "); ok {
+			name, kind, _ := strings.Cut(rest, " ")
+			types[name] = kind
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP ") {
+			continue
+		}
+		if !metricsSample.MatchString(line) {
+			t.Errorf("malformed sample line %q", line)
+		}
+		if name, _, _ := strings.Cut(strings.Fields(line)[0], "{"); types[name] == "" {
+			t.Errorf("sample %q has no preceding TYPE line", line)
+		}
+	}
+
+	for _, want := range []string{
+		"github_api_calls_total 1\n",
+		`turn_api_calls_total{result="hit"} 1` + "\n",
+		`turn_api_calls_total{result="miss"} 1` + "\n",
+		`turn_api_calls_total{result="error"} 1` + "\n",
+		"notifications_sent_total 0\n",
+		`sprinkler_events_total{result="deduped"} 1` + "\n",
+		`sprinkler_events_total{result="dropped"} 0` + "\n",
+		`sprinkler_events_total{result="unparseable"} 0` + "\n",
+		"blocked_incoming 3\n",
+		"blocked_outgoing 1\n",
+		"consecutive_failures 2\n",
+		"# TYPE seconds_since_last_successful_fetch gauge\n",
+		"# TYPE github_api_calls_total counter\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+	if !regexp.MustCompile(`(?m)^seconds_since_last_successful_fetch 9[0-9]\.[0-9]{3}$`).MatchString(body) {
+		t.Errorf("seconds_since_last_successful_fetch should be about 90s\n%s", body)
+	}
+}
+
+func TestMetricsNeverFetched(t *testing.T) {
+	app := &App{mu: sync.RWMutex{}, healthMonitor: newHealthMonitor()}
+	if body := scrapeMetrics(t, app); !strings.Contains(body, "seconds_since_last_successful_fetch -1.000\n") {
+		t.Errorf("expected -1 before the first successful fetch\n%s", body)
+	}
+}
+
+func TestMetricsCountUpdateCycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	githubServer := newETagSearchServer(t, now)
+
+	var turnRequests atomic.Int32
+	turnClient := newFakeTurn(t, func(context.Context, turn.CheckRequest) fakeVerdict {
+		turnRequests.Add(1)
+		return fakeVerdict{next: map[string]any{"testuser": reviewAction()}}
+	})
+	app := newFetchTestApp(t, githubServer.URL, turnClient, "testuser")
+	app.healthMonitor = newHealthMonitor()
+	httpClient := &http.Client{}
+	app.countGitHubCalls(httpClient)
+	app.client = github.NewClient(httpClient)
+	app.client.BaseURL = newETagTestClient(t, githubServer.URL).BaseURL
+
+	app.updatePRs(ctx)
+	body := scrapeMetrics(t, app)
+	for _, want := range []string{
+		"github_api_calls_total 2\n", // Both search queries
+		`turn_api_calls_total{result="miss"} 1` + "\n",
+		"blocked_incoming 1\n",
+		"consecutive_failures 0\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("after one cycle, metrics missing %q\n%s", want, body)
+		}
+	}
+
+	// The second cycle revalidates both searches; the unchanged PR skips Turn
+	app.updatePRs(ctx)
+	body = scrapeMetrics(t, app)
+	if !strings.Contains(body, "github_api_calls_total 4\n") {
+		t.Errorf("after two cycles, expected 4 GitHub calls\n%s", body)
+	}
+	if turnRequests.Load() != 1 {
+		t.Errorf("Turn server saw %d requests, want 1", turnRequests.Load())
+	}
+}
+
+func TestServeMetricsBindsLocalhost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	app := &App{mu: sync.RWMutex{}, healthMonitor: newHealthMonitor()}
+
+	addr, err := app.serveMetrics(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil || host != "127.0.0.1" {
+		t.Fatalf("metrics bound to %v, want localhost only", addr)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr.String()+"/metrics", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "github_api_calls_total 0") {
+		t.Errorf("GET /metrics = %d\n%s", resp.StatusCode, body)
+	}
+}
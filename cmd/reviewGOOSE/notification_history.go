@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// notificationHistorySize is how many notifications "Recent notifications" remembers.
+	notificationHistorySize = 10
+	// notificationHistoryTTL is how long a notification stays in "Recent notifications".
+	notificationHistoryTTL = 24 * time.Hour
+	// notificationSummaryWidth caps the length of a "Recent notifications" entry.
+	notificationSummaryWidth = 60
+)
+
+// Kinds of notification events.
+const (
+	notifyKindBlocked  = "blocked"  // A poll found a PR newly blocked on me
+	notifyKindEvent    = "event"    // A real-time sprinkler event
+	notifyKindTests    = "tests"    // A watched PR's tests finished
+	notifyKindComments = "comments" // A burst of new comments on a PR I'm involved in
+	notifyKindReview   = "review"   // My review on a PR has stayed unsubmitted
+	notifyKindOther    = "other"    // Anything not about a single PR
+)
+
+// notificationEvent is one desktop notification goose showed.
+type notificationEvent struct {
+	at      time.Time
+	kind    string
+	prURL   string // Empty for notifications not about a PR
+	title   string
+	message string
+}
+
+// summary is the compact "15:04 Title: message" submenu label.
+func (e notificationEvent) summary() string {
+	text := e.title
+	if e.message != "" {
+		text = fmt.Sprintf("%s: %s", e.title, e.message)
+	}
+	return fmt.Sprintf("%s %s", e.at.Format("15:04"), truncateRunes(text, notificationSummaryWidth))
+}
+
+// notificationHistory is a ring of the last notificationHistorySize notifications.
+type notificationHistory struct {
+	now    func() time.Time
+	events []notificationEvent // Oldest first
+	mu     sync.Mutex
+}
+
+func newNotificationHistory() *notificationHistory {
+	return &notificationHistory{now: time.Now}
+}
+
+// record adds a notification, dropping the oldest once the ring is full.
+func (h *notificationHistory) record(e notificationEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if e.at.IsZero() {
+		e.at = h.now()
+	}
+	h.events = append(h.events, e)
+	if over := len(h.events) - notificationHistorySize; over > 0 {
+		h.events = slices.Delete(h.events, 0, over)
+	}
+}
+
+// recent returns the notifications from the last notificationHistoryTTL, newest first.
+func (h *notificationHistory) recent() []notificationEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cutoff := h.now().Add(-notificationHistoryTTL)
+	var result []notificationEvent
+	for i := len(h.events) - 1; i >= 0; i-- {
+		if h.events[i].at.After(cutoff) {
+			result = append(result, h.events[i])
+		}
+	}
+	return result
+}
+
+// notificationHistoryTitles lists the "Recent notifications" entries for change detection.
+// New entries show up at the next menu update.
+func (app *App) notificationHistoryTitles() []string {
+	if app.notifications == nil {
+		return nil
+	}
+	titles := []string{msg("history.menu")}
+	for _, e := range app.notifications.recent() {
+		titles = append(titles, e.summary())
+	}
+	return titles
+}
+
+// addNotificationHistory adds the "Recent notifications" submenu, newest first. Entries
+// about a PR open it; the rest are informational.
+func (app *App) addNotificationHistory(ctx context.Context) {
+	if app.notifications == nil {
+		return
+	}
+	historyMenu := app.systrayInterface.AddMenuItem(msg("history.menu"), msg("history.menu.tooltip"))
+	events := app.notifications.recent()
+	if len(events) == 0 {
+		historyMenu.AddSubMenuItem(msg("history.empty"), "").Disable()
+		return
+	}
+	for _, e := range events {
+		item := historyMenu.AddSubMenuItem(e.summary(), e.message)
+		if e.prURL == "" {
+			item.Disable()
+			continue
+		}
+		url := e.prURL
+		item.Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNotificationHistoryBounded(t *testing.T) {
+	h := newNotificationHistory()
+	start := time.Now()
+	for i := 1; i <= notificationHistorySize+5; i++ {
+		h.record(notificationEvent{at: start.Add(time.Duration(i) * time.Second), kind: notifyKindBlocked, title: fmt.Sprintf("n%d", i)})
+	}
+	h.now = func() time.Time { return start.Add(time.Minute) }
+
+	got := h.recent()
+	if len(got) != notificationHistorySize {
+		t.Fatalf("recent() returned %d events, want %d", len(got), notificationHistorySize)
+	}
+	if got[0].title != "n15" || got[len(got)-1].title != "n6" {
+		t.Errorf("recent() = %s..%s, want n15..n6 (newest first)", got[0].title, got[len(got)-1].title)
+	}
+}
+
+func TestNotificationHistoryExpires(t *testing.T) {
+	now := time.Now()
+	h := newNotificationHistory()
+	h.now = func() time.Time { return now }
+	h.record(notificationEvent{at: now.Add(-notificationHistoryTTL), title: "yesterday"})
+	h.record(notificationEvent{at: now.Add(-time.Hour), title: "this morning"})
+	h.record(notificationEvent{title: "now"}) // Stamped by record
+
+	var titles []string
+	for _, e := range h.recent() {
+		titles = append(titles, e.title)
+	}
+	if want := []string{"now", "this morning"}; !slices.Equal(titles, want) {
+		t.Errorf("recent() = %q, want %q", titles, want)
+	}
+}
+
+func TestNotificationHistoryMenu(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	app.notifier = &messageNotifier{}
+	browser := &countingBrowser{}
+	app.browser = browser
+	app.notifications = newNotificationHistory()
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("test app should use MockSystray")
+	}
+
+	app.addNotificationHistory(ctx)
+	empty := mock.items[0]
+	if len(empty.subItems) != 1 || empty.subItems[0].(*MockMenuItem).title != "No recent notifications" {
+		t.Fatalf("empty history submenu = %+v", empty.subItems)
+	}
+
+	prURL := "https://github.com/acme/widgets/pull/123"
+	if err := app.notifyEvent(notificationEvent{kind: notifyKindTests, prURL: prURL, title: "Tests Finished", message: "Tests passed on acme/widgets #123"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.notify("Diagnostic report saved", "/tmp/report.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ResetMenu()
+	app.addNotificationHistory(ctx)
+	history := mock.items[0]
+	if len(history.subItems) != 2 {
+		t.Fatalf("history has %d entries, want 2", len(history.subItems))
+	}
+	other, pr := history.subItems[0].(*MockMenuItem), history.subItems[1].(*MockMenuItem)
+	if !other.disabled || other.clickHandler != nil {
+		t.Error("a notification without a PR should be informational")
+	}
+	if want := app.notifications.recent()[1].at.Format("15:04") + " Tests Finished: Tests passed on acme/widgets #123"; pr.title != want {
+		t.Errorf("entry title = %q, want %q", pr.title, want)
+	}
+	pr.clickHandler()
+	browser.mu.Lock()
+	defer browser.mu.Unlock()
+	if !slices.Equal(browser.urls, []string{prURL}) {
+		t.Errorf("clicking opened %q, want %q", browser.urls, prURL)
+	}
+}
+
+func TestNotificationHistorySkipsSilentMode(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.installSideEffects(true)
+	app.notifications = newNotificationHistory()
+	if err := app.notifyEvent(notificationEvent{kind: notifyKindBlocked, prURL: "https://github.com/acme/widgets/pull/1", title: "Blocked"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.notifications.recent(); len(got) != 0 {
+		t.Errorf("silent mode recorded %d notifications it never showed", len(got))
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	notifyRetryInitial = 5 * time.Second  // First retry after a failed send
+	notifyRetryMax     = 2 * time.Minute  // Backoff cap between retries
+	notifyRetryWindow  = 10 * time.Minute // Retry individually this long, then fold into the summary
+	notifyQueueMaxAge  = 30 * time.Minute // Older notifications are stale and dropped
+	notifyQueueSize    = 50               // Bounds a long outage; the oldest are folded first
+)
+
+// queuedNotification is a notification that failed to send and is waiting for a retry.
+type queuedNotification struct {
+	firstFailed time.Time
+	nextAttempt time.Time
+	event       notificationEvent
+	backoff     time.Duration
+}
+
+// notificationQueue holds notifications the desktop notification service rejected,
+// for example while the Linux notification daemon restarts. Failed sends are retried
+// with backoff for retryWindow, then folded into one summary that goes out after the
+// next successful send. Anything older than maxAge is dropped. Each notification is
+// delivered at most once.
+type notificationQueue struct {
+	send           func(notificationEvent) error
+	now            func() time.Time
+	wake           chan struct{}
+	pending        []queuedNotification
+	folded         []notificationEvent // Gave up retrying; summarized once sends work again
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retryWindow    time.Duration
+	maxAge         time.Duration
+	mu             sync.Mutex
+	summaryDue     bool // A send just succeeded, so the service is back
+}
+
+func newNotificationQueue(send func(notificationEvent) error) *notificationQueue {
+	return &notificationQueue{
+		send:           send,
+		now:            time.Now,
+		wake:           make(chan struct{}, 1),
+		initialBackoff: notifyRetryInitial,
+		maxBackoff:     notifyRetryMax,
+		retryWindow:    notifyRetryWindow,
+		maxAge:         notifyQueueMaxAge,
+	}
+}
+
+// enqueue queues a notification that failed to send. It never blocks on the retry loop.
+func (q *notificationQueue) enqueue(e notificationEvent) {
+	q.mu.Lock()
+	now := q.now()
+	if e.at.IsZero() {
+		e.at = now
+	}
+	if len(q.pending) >= notifyQueueSize {
+		q.folded = append(q.folded, q.pending[0].event)
+		q.pending = q.pending[1:]
+	}
+	q.pending = append(q.pending, queuedNotification{
+		event:       e,
+		firstFailed: now,
+		nextAttempt: now.Add(q.initialBackoff),
+		backoff:     q.initialBackoff,
+	})
+	q.mu.Unlock()
+	q.poke()
+}
+
+// recovered is called after a successful send: queued notifications are retried right
+// away and the summary of folded ones goes out.
+func (q *notificationQueue) recovered() {
+	q.mu.Lock()
+	if len(q.pending) == 0 && len(q.folded) == 0 {
+		q.mu.Unlock()
+		return
+	}
+	now := q.now()
+	for i := range q.pending {
+		q.pending[i].nextAttempt = now
+	}
+	q.summaryDue = true
+	q.mu.Unlock()
+	q.poke()
+}
+
+func (q *notificationQueue) poke() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run retries queued notifications until ctx is done.
+func (q *notificationQueue) run(ctx context.Context) {
+	timer := time.NewTimer(q.maxBackoff)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		if wait, ok := q.process(); ok {
+			timer.Reset(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// process retries what's due and sends the summary once a send succeeds. It returns the
+// time until the next retry, and false when nothing is queued.
+func (q *notificationQueue) process() (time.Duration, bool) {
+	q.mu.Lock()
+	now := q.now()
+	var due []queuedNotification
+	kept := q.pending[:0]
+	for _, p := range q.pending {
+		switch {
+		case now.Sub(p.event.at) >= q.maxAge:
+			slog.Info("[NOTIFY] Dropping stale queued notification", "title", p.event.title)
+		case now.Sub(p.firstFailed) >= q.retryWindow:
+			slog.Info("[NOTIFY] Notification still failing, folding it into a summary", "title", p.event.title)
+			q.folded = append(q.folded, p.event)
+		case !p.nextAttempt.After(now):
+			due = append(due, p)
+		default:
+			kept = append(kept, p)
+		}
+	}
+	q.pending = kept
+	q.folded = q.dropStale(q.folded, now)
+	q.mu.Unlock()
+
+	delivered := false
+	var retry []queuedNotification
+	for _, p := range due {
+		if err := q.send(p.event); err != nil {
+			p.backoff = min(p.backoff*2, q.maxBackoff)
+			p.nextAttempt = now.Add(p.backoff)
+			retry = append(retry, p)
+			slog.Debug("[NOTIFY] Retry failed", "title", p.event.title, "next", p.backoff, "error", err)
+			continue
+		}
+		delivered = true
+		slog.Info("[NOTIFY] Delivered queued notification", "title", p.event.title, "delayed", now.Sub(p.firstFailed).Round(time.Second))
+	}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, retry...)
+	var folded []notificationEvent
+	if delivered || q.summaryDue {
+		folded, q.folded = q.folded, nil
+	}
+	q.summaryDue = false
+	q.mu.Unlock()
+
+	if len(folded) > 0 {
+		summary := foldedSummary(folded)
+		if err := q.send(summary); err != nil {
+			slog.Debug("[NOTIFY] Summary of missed notifications failed", "count", len(folded), "error", err)
+			q.mu.Lock()
+			q.folded = append(folded, q.folded...)
+			q.mu.Unlock()
+		} else {
+			slog.Info("[NOTIFY] Sent summary of missed notifications", "count", len(folded))
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		return 0, false
+	}
+	next := q.pending[0].nextAttempt
+	for _, p := range q.pending[1:] {
+		if p.nextAttempt.Before(next) {
+			next = p.nextAttempt
+		}
+	}
+	return max(0, next.Sub(q.now())), true
+}
+
+// dropStale removes folded notifications older than maxAge. The caller holds q.mu.
+func (q *notificationQueue) dropStale(events []notificationEvent, now time.Time) []notificationEvent {
+	kept := events[:0]
+	for _, e := range events {
+		if now.Sub(e.at) < q.maxAge {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// foldedSummary is the single notification standing in for ones that never got through,
+// e.g. "While notifications were down: 2 PRs became blocked".
+func foldedSummary(events []notificationEvent) notificationEvent {
+	counts := make(map[string]int)
+	for _, e := range events {
+		switch e.kind {
+		case notifyKindBlocked, notifyKindEvent, notifyKindTests:
+			counts[e.kind]++
+		default:
+			counts[notifyKindOther]++
+		}
+	}
+	var parts []string
+	for _, kind := range []struct {
+		kind      string
+		one, many func(int) string
+	}{
+		{notifyKindBlocked, func(n int) string { return msg("notify.missed.blocked.one", n) }, func(n int) string { return msg("notify.missed.blocked", n) }},
+		{notifyKindEvent, func(n int) string { return msg("notify.missed.event.one", n) }, func(n int) string { return msg("notify.missed.event", n) }},
+		{notifyKindTests, func(n int) string { return msg("notify.missed.tests.one", n) }, func(n int) string { return msg("notify.missed.tests", n) }},
+		{notifyKindOther, func(n int) string { return msg("notify.missed.other.one", n) }, func(n int) string { return msg("notify.missed.other", n) }},
+	} {
+		switch n := counts[kind.kind]; n {
+		case 0:
+		case 1:
+			parts = append(parts, kind.one(n))
+		default:
+			parts = append(parts, kind.many(n))
+		}
+	}
+	return notificationEvent{kind: notifyKindOther, title: msg("notify.missed.title"), message: strings.Join(parts, ", ")}
+}
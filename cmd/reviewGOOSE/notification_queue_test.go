@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyNotifier fails every send while down, like a restarting notification daemon.
+type flakyNotifier struct {
+	sent     []string
+	attempts int
+	mu       sync.Mutex
+	down     bool
+}
+
+func (f *flakyNotifier) Notify(title, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.down {
+		return errors.New("org.freedesktop.Notifications was not provided by any .service files")
+	}
+	f.sent = append(f.sent, title+": "+message)
+	return nil
+}
+
+func (f *flakyNotifier) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func (f *flakyNotifier) delivered() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.sent)
+}
+
+func newNotifyQueueTestApp() (*App, *flakyNotifier) {
+	app := newFocusTestApp(time.Hour)
+	notifier := &flakyNotifier{down: true}
+	app.notifier = notifier
+	app.notifications = newNotificationHistory()
+	app.notifyQueue = newNotificationQueue(app.deliverNotification)
+	return app, notifier
+}
+
+func waitForDelivered(t *testing.T, n *flakyNotifier, want []string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Equal(n.delivered(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %q, want %q", n.delivered(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNotificationQueueRetries(t *testing.T) {
+	app, notifier := newNotifyQueueTestApp()
+	app.notifyQueue.initialBackoff = 5 * time.Millisecond
+	app.notifyQueue.maxBackoff = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.notifyQueue.run(ctx)
+	}()
+
+	e := notificationEvent{kind: notifyKindBlocked, prURL: "https://github.com/acme/widgets/pull/1", title: "Review needed", message: "acme/widgets #1"}
+	if err := app.notifyEvent(e); err != nil {
+		t.Fatalf("a queued notification returned %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := notifier.delivered(); len(got) != 0 {
+		t.Fatalf("delivered %q while the service was down", got)
+	}
+
+	notifier.setDown(false)
+	want := []string{"Review needed: acme/widgets #1"}
+	waitForDelivered(t, notifier, want)
+
+	// Recovery doesn't deliver it again
+	if err := app.notifyEvent(notificationEvent{kind: notifyKindOther, title: "Diagnostic report saved", message: "/tmp/report.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := notifier.delivered(); !slices.Equal(got, append(want, "Diagnostic report saved: /tmp/report.txt")) {
+		t.Errorf("after recovering: delivered %q", got)
+	}
+	if got := app.notifications.recent(); len(got) != 2 {
+		t.Errorf("history has %d entries, want each notification once", len(got))
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("retry loop didn't stop on shutdown")
+	}
+}
+
+func TestNotificationQueueFoldsIntoSummary(t *testing.T) {
+	app, notifier := newNotifyQueueTestApp()
+	q := app.notifyQueue
+	now := time.Now()
+	q.now = func() time.Time { return now }
+
+	for _, pr := range []string{"1", "2"} {
+		if err := app.notifyEvent(notificationEvent{kind: notifyKindBlocked, title: "Review needed", message: "acme/widgets #" + pr}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Retried with backoff until the retry window runs out
+	for range 3 {
+		now = now.Add(q.maxBackoff)
+		q.process()
+	}
+	now = now.Add(q.retryWindow)
+	if _, queued := q.process(); queued {
+		t.Fatal("notifications past the retry window are still being retried")
+	}
+	if got := notifier.delivered(); len(got) != 0 {
+		t.Fatalf("delivered %q while the service was down", got)
+	}
+
+	// The next successful send brings the summary along, once
+	notifier.setDown(false)
+	if err := app.notifyEvent(notificationEvent{kind: notifyKindTests, title: "Tests Finished", message: "Tests passed on acme/gears #3"}); err != nil {
+		t.Fatal(err)
+	}
+	q.process()
+	q.process()
+	want := []string{"Tests Finished: Tests passed on acme/gears #3", "While notifications were down: 2 PRs became blocked"}
+	if got := notifier.delivered(); !slices.Equal(got, want) {
+		t.Errorf("delivered %q, want %q", got, want)
+	}
+}
+
+func TestNotificationQueueDropsStale(t *testing.T) {
+	app, notifier := newNotifyQueueTestApp()
+	q := app.notifyQueue
+	now := time.Now()
+	q.now = func() time.Time { return now }
+
+	if err := app.notifyEvent(notificationEvent{kind: notifyKindBlocked, title: "Review needed", message: "acme/widgets #1"}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(q.retryWindow)
+	q.process() // Folded
+	now = now.Add(q.maxAge)
+
+	notifier.setDown(false)
+	q.recovered()
+	q.process()
+	if got := notifier.delivered(); len(got) != 0 {
+		t.Errorf("delivered %q for a notification older than %v", got, q.maxAge)
+	}
+}
+
+func TestFoldedSummary(t *testing.T) {
+	got := foldedSummary([]notificationEvent{
+		{kind: notifyKindBlocked}, {kind: notifyKindTests}, {kind: notifyKindTests}, {},
+	})
+	if want := "1 PR became blocked, 2 test runs finished, 1 other notification"; got.message != want {
+		t.Errorf("summary = %q, want %q", got.message, want)
+	}
+}
@@ -2,12 +2,8 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
-	"maps"
 	"time"
-
-	"github.com/gen2brain/beeep"
 )
 
 // processNotifications handles notifications for newly blocked PRs using the state manager.
@@ -15,23 +11,43 @@ func (app *App) processNotifications(ctx context.Context) {
 	slog.Debug("[NOTIFY] Processing notifications...")
 
 	// Get the list of PRs that need notifications
+	// Drafts only notify and auto-open when their actions count as blocking
+	view := app.snapshotPRs()
+	app.advanceSession(ctx, view)
+	if app.nothingToNotify(view) {
+		if !app.hasPerformedInitialDiscovery && !app.turnWavePending() {
+			app.hasPerformedInitialDiscovery = true
+			slog.Info("[STATE] Initial discovery completed with no PRs")
+		}
+		slog.Debug("[NOTIFY] No PRs, skipping notifications")
+		return
+	}
+	hiddenOrgs := view.hiddenOrgs
+	incoming, outgoing := view.allIncoming, view.allOutgoing
+
+	// Dismissals whose action changed or whose PR closed lapse; the rest don't block,
+	// and neither do snoozed PRs
 	app.mu.RLock()
-	hiddenOrgs := make(map[string]bool)
-	maps.Copy(hiddenOrgs, app.hiddenOrgs)
-	incoming := make([]PR, len(app.incoming))
-	copy(incoming, app.incoming)
-	outgoing := make([]PR, len(app.outgoing))
-	copy(outgoing, app.outgoing)
+	complete := app.partialFetch == nil
 	app.mu.RUnlock()
+	app.stateManager.ReconcileDismissals(incoming, outgoing, complete)
+	app.stateManager.ReconcilePins(incoming, outgoing, complete)
+	app.stateManager.ReconcileHiddenPRs(incoming, outgoing, complete)
+	incoming = applySnooze(app.withDismissals(incoming), view.snooze, view.at)
+	outgoing = app.withDismissals(outgoing)
 
 	// Determine if this is the initial discovery
 	isInitialDiscovery := !app.hasPerformedInitialDiscovery
 
 	// Let the state manager figure out what needs notifications
-	toNotify := app.stateManager.UpdatePRs(incoming, outgoing, hiddenOrgs, isInitialDiscovery)
-
-	// Mark that we've performed initial discovery
-	if isInitialDiscovery {
+	changes := app.stateManager.Reconcile(incoming, outgoing, hiddenOrgs, isInitialDiscovery, complete)
+	app.journal.note(changes.journal())
+	toNotify := changes.notify
+	app.recordAutoOpenOutcomes()
+
+	// Mark that we've performed initial discovery. It lasts until the first load's second
+	// Turn wave is applied, so PRs already blocked at startup don't notify as newly blocked.
+	if isInitialDiscovery && !app.turnWavePending() {
 		app.hasPerformedInitialDiscovery = true
 		slog.Info("[STATE] Initial discovery completed", "incoming_count", len(incoming), "outgoing_count", len(outgoing))
 	}
@@ -47,47 +63,45 @@ func (app *App) processNotifications(ctx context.Context) {
 	}
 	app.mu.Unlock()
 
+	app.emitUnblockedHookEvents(changes.unblocked)
+	app.notifyFinishedTests(ctx, incoming, outgoing)
+	app.notifyCommentBursts(view)
+	app.remindPendingReviews(view)
+
 	if len(toNotify) == 0 {
 		slog.Debug("[NOTIFY] No PRs need notifications")
 		return
 	}
 
 	slog.Info("[NOTIFY] PRs need notifications", "count", len(toNotify))
+	alerts := app.notifiablePRs(toNotify)
+
+	plans := planAlerts(alerts, incoming, app.enableAutoBrowser)
 
 	// Process notifications in a goroutine to avoid blocking the UI thread
-	go func() {
-		// Send notifications for each PR
+	app.goTracked("notifications", func() {
 		playedHonk := false
-		playedRocket := false
-
-		for i := range toNotify {
-			pr := toNotify[i]
-			isIncoming := false
-			// Check if it's in the incoming list
-			for j := range incoming {
-				if incoming[j].URL == pr.URL {
-					isIncoming = true
-					break
-				}
-			}
-
-			// Send notification
-			if isIncoming {
-				app.sendPRNotification(ctx, &pr, "PR Blocked on You 🪿", "honk", &playedHonk)
-			} else {
-				// Add delay between different sound types in goroutine to avoid blocking
-				if playedHonk && !playedRocket {
-					time.Sleep(2 * time.Second)
+		for i := range plans {
+			plan := &plans[i]
+			app.emitHookEvent(blockedHookEvent(&plan.pr), &plan.pr, plan.incoming)
+
+			// Leave a gap between the two sounds, so they don't play over each other
+			if plan.playSound && plan.sound == "rocket" && playedHonk {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(2 * time.Second):
 				}
-				app.sendPRNotification(ctx, &pr, "Your PR is Blocked 🚀", "rocket", &playedRocket)
 			}
+			playedHonk = playedHonk || (plan.playSound && plan.sound == "honk")
+			skipSound := !plan.playSound
+			app.sendPRNotification(ctx, &plan.pr, plan.title, plan.sound, &skipSound)
 
-			// Auto-open if enabled
-			if app.enableAutoBrowser && time.Since(app.startTime) > startupGracePeriod {
-				app.tryAutoOpenPR(ctx, &pr, app.enableAutoBrowser, app.startTime)
+			if plan.autoOpen {
+				app.tryAutoOpenPR(ctx, &plan.pr, true, app.startTime)
 			}
 		}
-	}()
+	})
 
 	// Update menu immediately after sending notifications
 	// This needs to happen in the main thread to show the party popper emoji
@@ -98,16 +112,59 @@ func (app *App) processNotifications(ctx context.Context) {
 	}
 }
 
+// notifiablePRs filters newly blocked PRs down to those that should make noise.
+// Filtered PRs have already been marked notified by the state manager, so they
+// won't produce a burst of alerts later (e.g. when leaving focus mode).
+func (app *App) notifiablePRs(toNotify []PR) []PR {
+	// Team mode only observes; none of its PRs are blocked on me
+	if app.teamMode() {
+		slog.Debug("[NOTIFY] Team mode, skipping notifications", "count", len(toNotify))
+		return nil
+	}
+	var alerts []PR
+	for i := range toNotify {
+		pr := &toNotify[i]
+		if !app.inFocus(pr.Repository) {
+			slog.Debug("[NOTIFY] Skipping notification outside focused repo",
+				"repo", pr.Repository, "number", pr.Number)
+			continue
+		}
+		if policy := app.prPolicy(pr.Repository); policy != orgPolicyFull {
+			slog.Debug("[NOTIFY] Skipping notification due to org policy",
+				"repo", pr.Repository, "number", pr.Number, "policy", policy)
+			continue
+		}
+		if !app.sectionShown(pr.URL) {
+			slog.Debug("[NOTIFY] Skipping notification for hidden section",
+				"repo", pr.Repository, "number", pr.Number)
+			continue
+		}
+		// Unsubmitted reviews get their own reminder once they've been pending a while
+		if pr.ActionKind == actionSubmitReview {
+			slog.Debug("[NOTIFY] Skipping notification for unsubmitted review",
+				"repo", pr.Repository, "number", pr.Number)
+			continue
+		}
+		// A real-time event may already have notified for this block
+		if state, ok := app.stateManager.PRState(pr.URL); ok && !app.stateManager.MarkNotified(pr.URL, state.FirstBlockedAt) {
+			continue
+		}
+		alerts = append(alerts, *pr)
+	}
+	return alerts
+}
+
 // sendPRNotification sends a notification for a single PR.
 func (app *App) sendPRNotification(ctx context.Context, pr *PR, title string, soundType string, playedSound *bool) {
-	message := fmt.Sprintf("%s #%d: %s", pr.Repository, pr.Number, pr.Title)
+	message := app.explainedBody(pr)
+	app.recordNotified(pr.URL)
 
 	// Send desktop notification in a goroutine to avoid blocking
-	go func() {
-		if err := beeep.Notify(title, message, ""); err != nil {
+	app.goTracked("notification", func() {
+		if err := app.notifyEvent(notificationEvent{kind: notifyKindBlocked, prURL: pr.URL, title: title, message: message}); err != nil {
 			slog.Error("[NOTIFY] Failed to send notification", "url", pr.URL, "error", err)
 		}
-	}()
+	})
 
 	// Play sound (only once per type per cycle) - already async in playSound
 	if !*playedSound {
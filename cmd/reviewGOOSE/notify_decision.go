@@ -0,0 +1,122 @@
+package main
+
+import "time"
+
+// Whether a PR notifies is decided in one place, decideNotify, from one row per PR per
+// poll. Columns are read left to right and the first one that decides wins; "-" means
+// the column isn't consulted:
+//
+//	hidden  snoozed  blocked  initial  was blocked  notified  grace  stale  →  outcome
+//	yes     -        -        -        -            -         -      -         none, untracked
+//	no      yes      -        -        -            -         -      -         none, unblocked
+//	no      no       no       -        -            -         -      -         none, unblocked
+//	no      no       yes      yes      -            -         -      -         none
+//	no      no       yes      no       yes          yes       -      -         none
+//	no      no       yes      no       no           yes       -      -         none
+//	no      no       yes      no       -            no        yes    -         held
+//	no      no       yes      no       -            no        no     yes       held
+//	no      no       yes      no       -            no        no     no        alert
+//
+// "Initial" is a PR first seen blocked during initial discovery: it was blocked before
+// goose started, so it never notifies for that block. Polls never pass a notified PR
+// that wasn't blocked at the previous poll, since unblocking drops the PR's state; the
+// block was announced all the same, so it stays quiet. A held PR is decided again next
+// poll, so a block that began during the grace period notifies once the period ends,
+// and a stale PR notifies if new activity makes it fresh.
+//
+// An alert shows a notification, plays its category's sound (honk for incoming, rocket
+// for outgoing) unless that sound already played this poll, and auto-opens the PR when
+// auto-open is on; tryAutoOpenPR applies the auto-open limits.
+
+// notifyRow is one PR's inputs to the decision table.
+type notifyRow struct {
+	hidden     bool // The PR, or its org, is hidden
+	snoozed    bool // Snoozed with the other incoming PRs blocked on me
+	blocked    bool
+	wasBlocked bool // Blocked at the previous poll
+	initial    bool // First seen blocked during initial discovery
+	notified   bool // Already notified for this block
+	grace      bool // Within the startup grace period
+}
+
+// notifyOutcome is the decision table's result.
+type notifyOutcome int
+
+const (
+	// notifyNone means nothing to do for this block.
+	notifyNone notifyOutcome = iota
+	// notifyHeld means not yet: the grace period or the PR's staleness holds it back.
+	notifyHeld
+	// notifyAlert means notify now.
+	notifyAlert
+)
+
+// decideNotify applies the decision table to row. stale is consulted last, and only
+// when it decides, since the freshness check logs loudly for stale PRs.
+func decideNotify(row notifyRow, stale func() bool) notifyOutcome {
+	switch {
+	case row.hidden, row.snoozed, !row.blocked, row.initial:
+		return notifyNone
+	case row.notified:
+		return notifyNone
+	case row.grace, stale():
+		return notifyHeld
+	default:
+		return notifyAlert
+	}
+}
+
+// eventNotifyRow is the decision table row for a real-time event saying pr became
+// blocked. An event is a fresh transition by definition; MarkNotified settles whether the
+// poll already notified for the block.
+func (app *App) eventNotifyRow(pr PR) notifyRow {
+	now := time.Now()
+	org := extractOrgFromRepo(pr.Repository)
+	app.mu.RLock()
+	hidden := org != "" && app.hiddenOrgs[org]
+	app.mu.RUnlock()
+	hidden = hidden || app.hiddenPRURLs()[pr.URL]
+	shown := app.withDismissals(applySnooze([]PR{pr}, app.currentSnooze(now), now))[0]
+	return notifyRow{
+		hidden:  hidden,
+		snoozed: shown.Snoozed,
+		blocked: shown.NeedsReview || shown.IsBlocked,
+		grace:   app.inGracePeriod(),
+	}
+}
+
+// alertPlan is how one alert is delivered.
+type alertPlan struct {
+	pr        PR
+	title     string
+	sound     string // "honk" for incoming, "rocket" for outgoing
+	incoming  bool
+	playSound bool // The first alert of its sound this poll
+	autoOpen  bool
+}
+
+// planAlerts decides how each of a poll's alerts is delivered: its title, and whether it
+// plays a sound or auto-opens. Each sound plays at most once per poll.
+func planAlerts(alerts, incoming []PR, autoOpen bool) []alertPlan {
+	incomingURLs := make(map[string]bool, len(incoming))
+	for i := range incoming {
+		incomingURLs[incoming[i].URL] = true
+	}
+	played := make(map[string]bool, 2)
+	plans := make([]alertPlan, 0, len(alerts))
+	for i := range alerts {
+		plan := alertPlan{pr: alerts[i], incoming: incomingURLs[alerts[i].URL], autoOpen: autoOpen}
+		switch {
+		case plan.incoming:
+			plan.title, plan.sound = msg("notify.incoming_blocked"), "honk"
+		case plan.pr.ActionKind == actionInvestigateCI:
+			plan.title, plan.sound = msg("notify.tests_stuck"), "rocket"
+		default:
+			plan.title, plan.sound = msg("notify.outgoing_blocked"), "rocket"
+		}
+		plan.playSound = !played[plan.sound]
+		played[plan.sound] = true
+		plans = append(plans, plan)
+	}
+	return plans
+}
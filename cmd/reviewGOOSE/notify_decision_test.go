@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestDecideNotify(t *testing.T) {
+	tests := []struct {
+		name  string
+		row   notifyRow
+		stale bool
+		want  notifyOutcome
+	}{
+		{name: "hidden org", row: notifyRow{hidden: true, blocked: true}, want: notifyNone},
+		{name: "snoozed", row: notifyRow{snoozed: true}, want: notifyNone},
+		{name: "not blocked", row: notifyRow{wasBlocked: true, notified: true}, want: notifyNone},
+		{name: "blocked at startup", row: notifyRow{blocked: true, initial: true}, want: notifyNone},
+		{name: "blocked at startup, still blocked", row: notifyRow{blocked: true, wasBlocked: true, initial: true}, want: notifyNone},
+		{name: "stays blocked after notifying", row: notifyRow{blocked: true, wasBlocked: true, notified: true}, want: notifyNone},
+		{name: "notified but not blocked last poll", row: notifyRow{blocked: true, notified: true}, want: notifyNone},
+		{name: "newly blocked during grace", row: notifyRow{blocked: true, grace: true}, want: notifyHeld},
+		{name: "held through grace, still in it", row: notifyRow{blocked: true, wasBlocked: true, grace: true}, want: notifyHeld},
+		{name: "newly blocked but stale", row: notifyRow{blocked: true}, stale: true, want: notifyHeld},
+		{name: "newly blocked", row: notifyRow{blocked: true}, want: notifyAlert},
+		{name: "held through grace, now past it", row: notifyRow{blocked: true, wasBlocked: true}, want: notifyAlert},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consulted := false
+			stale := func() bool {
+				consulted = true
+				return tt.stale
+			}
+			if got := decideNotify(tt.row, stale); got != tt.want {
+				t.Errorf("decideNotify(%+v) = %v, want %v", tt.row, got, tt.want)
+			}
+			// Staleness is only checked when nothing before it decided
+			decidedEarly := tt.row.hidden || tt.row.snoozed || !tt.row.blocked || tt.row.initial ||
+				tt.row.notified || tt.row.grace
+			if consulted == decidedEarly {
+				t.Errorf("stale consulted = %v, want %v", consulted, !decidedEarly)
+			}
+		})
+	}
+}
+
+func TestPlanAlerts(t *testing.T) {
+	incoming := []PR{
+		{URL: "https://github.com/acme/widgets/pull/1"},
+		{URL: "https://github.com/acme/widgets/pull/2"},
+	}
+	alerts := []PR{
+		incoming[0],
+		{URL: "https://github.com/acme/gears/pull/3"},
+		incoming[1],
+		{URL: "https://github.com/acme/gears/pull/4", ActionKind: actionInvestigateCI},
+	}
+	type delivery struct {
+		title, sound string
+		playSound    bool
+	}
+	want := []delivery{
+		{msg("notify.incoming_blocked"), "honk", true},
+		{msg("notify.outgoing_blocked"), "rocket", true},
+		{msg("notify.incoming_blocked"), "honk", false},
+		{msg("notify.tests_stuck"), "rocket", false},
+	}
+
+	for _, autoOpen := range []bool{false, true} {
+		plans := planAlerts(alerts, incoming, autoOpen)
+		var got []delivery
+		for _, plan := range plans {
+			got = append(got, delivery{plan.title, plan.sound, plan.playSound})
+			if plan.autoOpen != autoOpen {
+				t.Errorf("plan for %s auto-opens = %v, want %v", plan.pr.URL, plan.autoOpen, autoOpen)
+			}
+			if plan.incoming != (plan.sound == "honk") {
+				t.Errorf("plan for %s: incoming %v with sound %s", plan.pr.URL, plan.incoming, plan.sound)
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("planAlerts() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+// pipelinePR is an incoming PR for the notification pipeline tests.
+func pipelinePR(repo string, number int, blocked bool, updated time.Time) PR {
+	return PR{
+		Repository: repo, Number: number, URL: fmt.Sprintf("https://github.com/%s/pull/%d", repo, number),
+		NeedsReview: blocked, UpdatedAt: updated,
+	}
+}
+
+func TestNotificationPipeline(t *testing.T) {
+	now := time.Now()
+	unblocked := []PR{pipelinePR("acme/widgets", 1, false, now)}
+	blocked := []PR{pipelinePR("acme/widgets", 1, true, now)}
+	tests := []struct {
+		name       string
+		startedAgo time.Duration
+		setup      func(app *App)
+		polls      [][]PR
+		want       int
+	}{
+		{name: "newly blocked", startedAgo: 2 * time.Minute, polls: [][]PR{unblocked, blocked}, want: 1},
+		{name: "newly blocked notifies once", startedAgo: 2 * time.Minute, polls: [][]PR{unblocked, blocked, blocked, blocked}, want: 1},
+		{name: "blocked at startup", startedAgo: 2 * time.Minute, polls: [][]PR{blocked, blocked}, want: 0},
+		{name: "newly blocked during grace", startedAgo: 5 * time.Second, polls: [][]PR{unblocked, blocked}, want: 0},
+		{name: "becomes unblocked", startedAgo: 2 * time.Minute, polls: [][]PR{blocked, unblocked}, want: 0},
+		{
+			name:       "hidden org",
+			startedAgo: 2 * time.Minute,
+			setup:      func(app *App) { app.hiddenOrgs["acme"] = true },
+			polls:      [][]PR{unblocked, blocked},
+			want:       0,
+		},
+		{
+			name:       "snoozed",
+			startedAgo: 2 * time.Minute,
+			setup: func(app *App) {
+				app.snooze = &incomingSnooze{until: now.Add(time.Hour), urls: map[string]bool{blocked[0].URL: true}}
+			},
+			polls: [][]PR{unblocked, blocked},
+			want:  0,
+		},
+		{
+			name:       "stale",
+			startedAgo: 2 * time.Minute,
+			polls: [][]PR{
+				{pipelinePR("acme/widgets", 1, false, now.Add(-2*ancientPRThreshold))},
+				{pipelinePR("acme/widgets", 1, true, now.Add(-2*ancientPRThreshold))},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, notifier := newGraceTestApp(tt.startedAgo)
+			if tt.setup != nil {
+				tt.setup(app)
+			}
+			for _, incoming := range tt.polls {
+				app.mu.Lock()
+				app.incoming = incoming
+				app.mu.Unlock()
+				app.processNotifications(context.Background())
+			}
+			if notes := waitForNotes(notifier, tt.want); len(notes) != tt.want {
+				t.Errorf("notifications = %q, want %d", notes, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationHeldThroughGraceNotifiesAfter(t *testing.T) {
+	app, notifier := newGraceTestApp(5 * time.Second)
+	ctx := context.Background()
+	now := time.Now()
+
+	app.incoming = []PR{pipelinePR("acme/widgets", 1, false, now)}
+	app.processNotifications(ctx)
+	app.incoming = []PR{pipelinePR("acme/widgets", 1, true, now)}
+	app.processNotifications(ctx)
+	if notes := waitForNotes(notifier, 0); len(notes) != 0 {
+		t.Fatalf("notifications during grace = %q, want none", notes)
+	}
+
+	// The grace period ends while the PR is still blocked
+	app.stateManager.startTime = now.Add(-2 * time.Minute)
+	app.processNotifications(ctx)
+	app.processNotifications(ctx)
+	if notes := waitForNotes(notifier, 1); len(notes) != 1 {
+		t.Errorf("notifications after grace = %q, want one", notes)
+	}
+}
+
+func TestSprinklerEventsUseDecisionTable(t *testing.T) {
+	act := &turn.Action{Kind: "review", Reason: "needs review", Critical: true}
+	tests := []struct {
+		name  string
+		setup func(app *App, url string)
+		want  int
+	}{
+		{name: "blocked", setup: func(*App, string) {}, want: 1},
+		{name: "hidden org", setup: func(app *App, _ string) { app.hiddenOrgs["acme"] = true }, want: 0},
+		{
+			name: "snoozed",
+			setup: func(app *App, url string) {
+				app.snooze = &incomingSnooze{until: time.Now().Add(time.Hour), urls: map[string]bool{url: true}}
+			},
+			want: 0,
+		},
+		{
+			name: "dismissed",
+			setup: func(app *App, url string) {
+				app.stateManager.Dismiss(&PR{URL: url, Repository: "acme/widgets", Number: 1, ActionKind: "review"})
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const url = "https://github.com/acme/widgets/pull/1"
+			app, notifier := newGraceTestApp(2 * time.Minute)
+			tt.setup(app, url)
+			sm := &sprinklerMonitor{app: app}
+			sm.sendNotifications(context.Background(), url, "acme/widgets", 1, act)
+			if notes := waitForNotes(notifier, tt.want); len(notes) != tt.want {
+				t.Errorf("notifications = %q, want %d", notes, tt.want)
+			}
+		})
+	}
+}
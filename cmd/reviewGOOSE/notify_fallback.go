@@ -0,0 +1,231 @@
+package main
+
+import (
+	_ "embed"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/icon"
+)
+
+//go:embed icons/goose.png
+var iconFlashGooseSource []byte
+
+var (
+	flashGoose     []byte
+	flashGooseOnce sync.Once
+)
+
+// Linux desktop notifications need a freedesktop notification daemon. Minimal window
+// manager setups often run none, and then beeep fails quietly and the goose looks broken.
+// While no daemon is reachable, notifications are shown on the tray icon instead.
+
+const (
+	// notificationServiceName is the bus name freedesktop notification daemons own.
+	notificationServiceName = "org.freedesktop.Notifications"
+	// notifyServiceRecheck is how long a notification service probe is trusted.
+	notifyServiceRecheck = time.Minute
+	// trayFlashDuration is how long the tray shows a notification it stood in for.
+	trayFlashDuration = 10 * time.Second
+	// trayFlashInterval is how often the icon alternates while flashing.
+	trayFlashInterval = 500 * time.Millisecond
+)
+
+// fallbackNotifier sends notifications through primary while the notification service is
+// reachable, and through fallback otherwise. The service is probed again every
+// notifyServiceRecheck, so starting a daemon later upgrades delivery without a restart.
+type fallbackNotifier struct {
+	checkedAt time.Time
+	primary   Notifier
+	fallback  Notifier
+	probe     func() bool
+	now       func() time.Time
+	mu        sync.Mutex
+	checked   bool
+	available bool
+}
+
+func newFallbackNotifier(primary, fallback Notifier, probe func() bool) *fallbackNotifier {
+	return &fallbackNotifier{primary: primary, fallback: fallback, probe: probe, now: time.Now}
+}
+
+func (n *fallbackNotifier) Notify(title, message string) error {
+	if n.serviceAvailable() {
+		return n.primary.Notify(title, message)
+	}
+	return n.fallback.Notify(title, message)
+}
+
+// serviceAvailable returns the latest probe, probing again when it's stale, and logs when
+// the service goes away or comes back.
+func (n *fallbackNotifier) serviceAvailable() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.now()
+	if n.checked && now.Sub(n.checkedAt) < notifyServiceRecheck {
+		return n.available
+	}
+	available := n.probe()
+	switch {
+	case !available && (n.available || !n.checked):
+		slog.Warn("[NOTIFY] No desktop notification service is running (" + notificationServiceName +
+			"); notifications will flash the tray icon instead. Install a notification daemon such as dunst or mako to get desktop notifications.")
+	case available && n.checked && !n.available:
+		slog.Info("[NOTIFY] Desktop notification service found; using desktop notifications again")
+	default:
+	}
+	n.checked, n.checkedAt, n.available = true, now, available
+	return available
+}
+
+// trayFlashState is the notification the tray is standing in for. While it lasts,
+// presentTrayStateFrom shows its text as the tooltip and, on goose frames, the goose as
+// the icon, so refreshes and menu rebuilds during a flash keep it on screen. Its zero
+// value is no flash.
+type trayFlashState struct {
+	text  string
+	mu    sync.Mutex
+	on    bool
+	goose bool
+}
+
+// start shows text, leading with a goose frame.
+func (f *trayFlashState) start(text string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.text, f.on, f.goose = text, true, true
+}
+
+// toggle switches between the goose and the tray state's own icon.
+func (f *trayFlashState) toggle() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.goose = !f.goose
+}
+
+// stop ends the flash.
+func (f *trayFlashState) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.text, f.on, f.goose = "", false, false
+}
+
+// current returns the flash's tooltip and frame; ok is false when there's no flash.
+func (f *trayFlashState) current() (text string, goose, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.text, f.goose, f.on
+}
+
+// trayNotifier shows a notification as a tray flash: its text in the tooltip and the
+// icon alternating between the current state and the goose for trayFlashDuration. A
+// notification during a flash replaces the text and restarts the clock.
+type trayNotifier struct {
+	until     time.Time
+	app       *App
+	now       func() time.Time
+	newTicker func(time.Duration) (ticks <-chan time.Time, stop func())
+	nudge     chan struct{} // A new notification arrived during the flash
+	duration  time.Duration
+	interval  time.Duration
+	mu        sync.Mutex
+	flashing  bool
+}
+
+func newTrayNotifier(app *App) *trayNotifier {
+	return &trayNotifier{
+		app:      app,
+		now:      time.Now,
+		nudge:    make(chan struct{}, 1),
+		duration: trayFlashDuration,
+		interval: trayFlashInterval,
+		newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+			t := time.NewTicker(d)
+			return t.C, t.Stop
+		},
+	}
+}
+
+// Notify starts or extends the flash. It doesn't touch the tray itself, so it's safe
+// to call with app.mu held; the flash loop presents.
+func (n *trayNotifier) Notify(title, message string) error {
+	text := title
+	if message != "" {
+		text += "\n" + message
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.until = n.now().Add(n.duration)
+	n.app.trayFlash.start(text)
+	if n.flashing {
+		select {
+		case n.nudge <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	// The flash ends early on shutdown, so the wait for it stays short
+	n.flashing = n.app.goTracked("tray flash", n.flash)
+	if !n.flashing {
+		n.app.trayFlash.stop()
+	}
+	return nil
+}
+
+// flash presents a frame per tick until the flash is over, then presents the tray
+// without it.
+func (n *trayNotifier) flash() {
+	ticks, stop := n.newTicker(n.interval)
+	defer stop()
+	for {
+		n.mu.Lock()
+		if !n.now().Before(n.until) {
+			// Ended under the lock, so a notification arriving now starts a new flash
+			n.app.trayFlash.stop()
+			n.flashing = false
+			n.mu.Unlock()
+			n.app.presentTrayState()
+			return
+		}
+		n.mu.Unlock()
+		n.app.presentTrayState()
+		select {
+		case <-ticks:
+			n.app.trayFlash.toggle()
+		case <-n.nudge:
+		case <-n.app.shutdownStarted():
+			n.mu.Lock()
+			n.flashing = false
+			n.mu.Unlock()
+			return
+		}
+	}
+}
+
+// flashGooseIcon is the goose shown between flash frames. Badge icons only show counts,
+// so it's drawn from the goose picture rather than from an IconType.
+func flashGooseIcon() []byte {
+	flashGooseOnce.Do(func() {
+		scaled, err := icon.Scale(iconFlashGooseSource)
+		if err != nil {
+			slog.Error("failed to scale flash goose icon", "error", err)
+			scaled = iconFlashGooseSource
+		}
+		flashGoose = scaled
+	})
+	return flashGoose
+}
+
+// newDesktopNotifier returns the notifier for desktop notifications, falling back to the
+// tray where the notification service can be missing.
+func (app *App) newDesktopNotifier() Notifier {
+	probe := newNotificationServiceProbe()
+	if probe == nil {
+		return desktopNotifier{}
+	}
+	n := newFallbackNotifier(desktopNotifier{}, newTrayNotifier(app), probe)
+	// Probe at startup, so a missing service is logged before the first notification
+	app.goTracked("notification probe", func() { n.serviceAvailable() })
+	return n
+}
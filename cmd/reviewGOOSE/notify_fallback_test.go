@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFallbackNotifierFollowsService(t *testing.T) {
+	primary, fallback := &messageNotifier{}, &messageNotifier{}
+	available, probes := false, 0
+	n := newFallbackNotifier(primary, fallback, func() bool {
+		probes++
+		return available
+	})
+	now := time.Now()
+	n.now = func() time.Time { return now }
+
+	for range 2 {
+		if err := n.Notify("Review needed", "acme/widgets#1"); err != nil {
+			t.Fatalf("Notify() = %v", err)
+		}
+	}
+	if len(fallback.notes) != 2 || len(primary.notes) != 0 {
+		t.Fatalf("without a service: primary %q, fallback %q, want both on the fallback", primary.notes, fallback.notes)
+	}
+
+	// A daemon started later is picked up on the next probe, without a restart
+	available = true
+	_ = n.Notify("Review needed", "acme/widgets#2")
+	if len(primary.notes) != 0 {
+		t.Error("probed again before notifyServiceRecheck")
+	}
+	now = now.Add(notifyServiceRecheck)
+	_ = n.Notify("Review needed", "acme/widgets#3")
+	if len(primary.notes) != 1 || primary.notes[0] != "Review needed: acme/widgets#3" {
+		t.Errorf("after the service appeared: primary %q, want the latest notification", primary.notes)
+	}
+	if probes != 2 {
+		t.Errorf("probes = %d, want 2", probes)
+	}
+}
+
+func TestTrayFlashEndsOnShutdown(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.lifecycle = newLifecycle(func() {})
+	n := newTrayNotifier(app)
+	n.newTicker = func(time.Duration) (<-chan time.Time, func()) { return nil, func() {} }
+	if err := n.Notify("Review needed", ""); err != nil {
+		t.Fatalf("Notify() = %v", err)
+	}
+	if stuck := app.lifecycle.Shutdown(time.Second, func() {}); len(stuck) != 0 {
+		t.Errorf("stuck components = %q, want the flash to end on shutdown", stuck)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.flashing {
+		t.Error("the flash outlived the shutdown wait")
+	}
+}
+
+func TestTrayNotifierFlashesAndRestores(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	mock := app.systrayInterface.(*MockSystray)
+	app.presentTrayState()
+	restored := mock.tooltip
+	current, _ := mock.iconState()
+
+	// Frames advance only when the test ticks, and the flash ends when the clock says
+	var clockMu sync.Mutex
+	now := time.Now()
+	ticks, stopped := make(chan time.Time), make(chan struct{})
+	n := newTrayNotifier(app)
+	n.now = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return now
+	}
+	n.newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return ticks, func() { close(stopped) }
+	}
+	if err := n.Notify("Review needed", "acme/widgets#1"); err != nil {
+		t.Fatalf("Notify() = %v", err)
+	}
+	const frames = 4
+	for i := range frames {
+		// The flash loop takes a tick only after presenting the frame before it
+		ticks <- time.Time{}
+		if i == 0 {
+			// A refresh during the flash keeps the notification on the tray
+			app.presentTrayState()
+			mock.mu.Lock()
+			tooltip := mock.tooltip
+			mock.mu.Unlock()
+			if tooltip != "Review needed\nacme/widgets#1" {
+				t.Errorf("tooltip while flashing = %q, want the notification", tooltip)
+			}
+		}
+	}
+	clockMu.Lock()
+	now = now.Add(trayFlashDuration)
+	clockMu.Unlock()
+	// The loop may see the new time before it waits for the next tick
+	select {
+	case ticks <- time.Time{}:
+		<-stopped
+	case <-stopped:
+	}
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	goose := flashGooseIcon()
+	var gooseFrames, currentFrames int
+	for _, c := range mock.trayCalls {
+		switch {
+		case c.icon == nil:
+		case bytes.Equal(c.icon, goose):
+			gooseFrames++
+		case bytes.Equal(c.icon, current):
+			currentFrames++
+		default:
+		}
+	}
+	if gooseFrames < frames/2 || currentFrames < frames/2 {
+		t.Errorf("flash showed %d goose and %d current frames, want it to alternate", gooseFrames, currentFrames)
+	}
+	if mock.tooltip != restored || !bytes.Equal(mock.lastIcon, current) {
+		t.Errorf("after the flash: tooltip %q, want %q restored with the current icon", mock.tooltip, restored)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// waitForNotes waits for want notifications, then briefly for any stragglers.
+func waitForNotes(n *messageNotifier, want int) []string {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		n.mu.Lock()
+		got := len(n.notes)
+		n.mu.Unlock()
+		if got >= want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.notes...)
+}
+
+func TestSprinklerAndPollNotifyOnce(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.hasPerformedInitialDiscovery = true
+	now := time.Now()
+	pr := PR{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", Title: "Fix it", UpdatedAt: now, LastActivityAt: now}
+	app.incoming = []PR{pr}
+	app.processNotifications(ctx) // Baseline: not blocked
+
+	// The sprinkler event sees the block first...
+	sm := &sprinklerMonitor{app: app}
+	sm.sendNotifications(ctx, pr.URL, pr.Repository, pr.Number, &turn.Action{Kind: "review", Reason: "needs review", Critical: true})
+
+	// ...then the scheduled poll sees it within the same episode
+	pr.NeedsReview = true
+	app.incoming = []PR{pr}
+	app.processNotifications(ctx)
+
+	if notes := waitForNotes(notifier, 1); len(notes) != 1 {
+		t.Errorf("got %d notifications for one block, want 1: %q", len(notes), notes)
+	}
+}
+
+func TestPollThenSprinklerNotifyOnce(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.hasPerformedInitialDiscovery = true
+	now := time.Now()
+	pr := PR{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", UpdatedAt: now, LastActivityAt: now}
+	app.incoming = []PR{pr}
+	app.processNotifications(ctx)
+
+	pr.NeedsReview = true
+	app.incoming = []PR{pr}
+	app.processNotifications(ctx)
+	sm := &sprinklerMonitor{app: app}
+	sm.sendNotifications(ctx, pr.URL, pr.Repository, pr.Number, &turn.Action{Kind: "review", Critical: true})
+
+	if notes := waitForNotes(notifier, 1); len(notes) != 1 {
+		t.Errorf("got %d notifications for one block, want 1: %q", len(notes), notes)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build linux
+
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const notificationServiceTimeout = 2 * time.Second
+
+// newNotificationServiceProbe reports whether a freedesktop notification daemon owns its
+// bus name, or can be started on demand. Minimal window managers often run none, and
+// beeep fails without one.
+func newNotificationServiceProbe() func() bool {
+	return notificationServiceReachable
+}
+
+func notificationServiceReachable() bool {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return false
+	}
+	defer closeTrayHostConn(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), notificationServiceTimeout)
+	defer cancel()
+	bus := conn.BusObject()
+	var owned bool
+	if err := bus.CallWithContext(ctx, "org.freedesktop.DBus.NameHasOwner", 0, notificationServiceName).Store(&owned); err == nil && owned {
+		return true
+	}
+	var activatable []string
+	if err := bus.CallWithContext(ctx, "org.freedesktop.DBus.ListActivatableNames", 0).Store(&activatable); err != nil {
+		return false
+	}
+	return slices.Contains(activatable, notificationServiceName)
+}
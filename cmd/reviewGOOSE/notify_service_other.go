@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// newNotificationServiceProbe returns nil: macOS and Windows always have a notification center.
+func newNotificationServiceProbe() func() bool {
+	return nil
+}
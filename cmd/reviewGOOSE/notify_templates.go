@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// Notification bodies come from per-action-kind templates such as
+// "Review requested: {repo}#{number}[ by @{author}]". A [bracketed] group is dropped
+// when any placeholder in it is empty, so missing fields leave no dangling text.
+// Settings can override a kind's template under "notification_templates", with
+// "default" covering kinds without one.
+
+// notifyTemplateDefault is the notification_templates key used for kinds without a template.
+const notifyTemplateDefault = "default"
+
+// notifyPlaceholders are the fields a notification template can use.
+var notifyPlaceholders = []string{"repo", "number", "title", "author", "size", "age", "first_failing_check", "action", "reason"}
+
+// builtinNotifyTemplate returns the translated template for an action kind, if it has one.
+func builtinNotifyTemplate(kind ActionKind) (string, bool) {
+	switch parseActionKind(kind) {
+	case actionReview, actionReReview:
+		return msg("notify.template.review"), true
+	case actionFixTests:
+		return msg("notify.template.fix_tests"), true
+	case actionMerge:
+		return msg("notify.template.merge"), true
+	case actionNone, actionUnknown,
+		actionResolveComments, actionPublishDraft, actionRequestReviewers,
+		actionReviewDiscussion, actionApprove, actionTestsPending, actionRerunTests,
+		actionRespond, actionFixConflict, actionApproveWorkflows, actionInvestigateCI, actionSubmitReview:
+		return "", false
+	}
+	return "", false
+}
+
+// validateNotifyTemplate rejects templates with unknown placeholders, unbalanced braces,
+// or nested or unbalanced optional groups.
+func validateNotifyTemplate(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("template is empty")
+	}
+	inGroup := false
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[':
+			if inGroup {
+				return errors.New("optional groups can't be nested")
+			}
+			inGroup = true
+		case ']':
+			if !inGroup {
+				return errors.New("unmatched ]")
+			}
+			inGroup = false
+		case '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return errors.New("unterminated placeholder")
+			}
+			name := text[i+1 : i+end]
+			if !slices.Contains(notifyPlaceholders, name) {
+				return fmt.Errorf("unknown placeholder {%s}", name)
+			}
+			i += end
+		case '}':
+			return errors.New("unmatched }")
+		default:
+		}
+	}
+	if inGroup {
+		return errors.New("unterminated optional group")
+	}
+	return nil
+}
+
+// validNotifyTemplates keeps the configured templates that validate, logging the rest.
+func validNotifyTemplates(templates map[string]string) map[string]string {
+	valid := make(map[string]string, len(templates))
+	for kind, text := range templates {
+		if err := validateNotifyTemplate(text); err != nil || kind == "" {
+			slog.Warn("[SETTINGS] Ignoring invalid notification template", "kind", kind, "template", text, "error", err)
+			continue
+		}
+		valid[kind] = text
+	}
+	return valid
+}
+
+// firstFailingCheck names the alphabetically first failing check, or "".
+func firstFailingCheck(data *turn.CheckResponse) string {
+	if data.PullRequest.CheckSummary == nil || len(data.PullRequest.CheckSummary.Failing) == 0 {
+		return ""
+	}
+	return slices.Min(slices.Collect(maps.Keys(data.PullRequest.CheckSummary.Failing)))
+}
+
+// notifyFields are the template values for pr, empty where unknown.
+func notifyFields(pr *PR) map[string]string {
+	fields := map[string]string{
+		"repo":                pr.Repository,
+		"number":              strconv.Itoa(pr.Number),
+		"title":               pr.Title,
+		"author":              pr.Author,
+		"size":                pr.Size,
+		"first_failing_check": pr.FailingCheck,
+		"action":              string(pr.ActionKind),
+		"reason":              pr.ActionReason,
+	}
+	if !pr.ActionSince.IsZero() {
+		fields["age"] = prAge(pr.ActionSince)
+	}
+	return fields
+}
+
+// renderNotifyTemplate expands a validated template, dropping optional groups with an
+// empty placeholder.
+func renderNotifyTemplate(text string, fields map[string]string) string {
+	var out, group strings.Builder
+	inGroup, groupEmpty := false, false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '[':
+			inGroup, groupEmpty = true, false
+			group.Reset()
+		case c == ']':
+			if !groupEmpty {
+				out.WriteString(group.String())
+			}
+			inGroup = false
+		case c == '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				end = len(text) - i - 1
+			}
+			value := fields[text[i+1:i+end]]
+			if value == "" {
+				groupEmpty = true
+			}
+			if inGroup {
+				group.WriteString(value)
+			} else {
+				out.WriteString(value)
+			}
+			i += end
+		case inGroup:
+			group.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return strings.TrimSpace(out.String())
+}
+
+// notificationBody renders the notification text for pr from its action kind's
+// template: a configured one, then the built-in one, then the configured and built-in
+// defaults.
+func (app *App) notificationBody(pr *PR) string {
+	app.mu.RLock()
+	custom, hasCustom := app.notifyTemplates[string(pr.ActionKind)]
+	fallback, hasFallback := app.notifyTemplates[notifyTemplateDefault]
+	app.mu.RUnlock()
+
+	text := msg("notify.template.default")
+	if builtin, ok := builtinNotifyTemplate(pr.ActionKind); ok {
+		text = builtin
+	} else if hasFallback {
+		text = fallback
+	}
+	if hasCustom {
+		text = custom
+	}
+	return renderNotifyTemplate(text, notifyFields(pr))
+}
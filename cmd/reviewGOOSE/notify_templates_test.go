@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestNotificationBodyByKind(t *testing.T) {
+	full := PR{
+		Repository: "acme/widgets", Number: 7, Title: "Add retries", Author: "alice", Size: "M",
+		FailingCheck: "lint", ActionReason: "needs approval", ActionSince: time.Now().Add(-3 * time.Hour),
+	}
+	tests := []struct {
+		name string
+		kind ActionKind
+		pr   PR
+		want string
+	}{
+		{name: "review", kind: "review", pr: full, want: "Review requested: acme/widgets#7 by @alice (M, waiting 3h)"},
+		{name: "re-review", kind: "re_review", pr: full, want: "Review requested: acme/widgets#7 by @alice (M, waiting 3h)"},
+		{name: "review without size", kind: "review", pr: PR{Repository: "acme/widgets", Number: 7, Author: "alice"}, want: "Review requested: acme/widgets#7 by @alice"},
+		{name: "review without anything", kind: "review", pr: PR{Repository: "acme/widgets", Number: 7}, want: "Review requested: acme/widgets#7"},
+		{name: "fix tests", kind: "fix_tests", pr: full, want: "Tests failing on your PR acme/widgets#7: lint"},
+		{name: "fix tests without a check", kind: "fix_tests", pr: PR{Repository: "acme/widgets", Number: 7}, want: "Tests failing on your PR acme/widgets#7"},
+		{name: "merge", kind: "merge", pr: full, want: "Ready to merge: acme/widgets#7"},
+		{name: "other kind", kind: "approve", pr: full, want: "acme/widgets #7: Add retries – needs approval"},
+		{name: "other kind, no title", kind: "respond", pr: PR{Repository: "acme/widgets", Number: 7}, want: "acme/widgets #7"},
+	}
+	app := newFocusTestApp(time.Hour)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := tt.pr
+			pr.ActionKind = tt.kind
+			if got := app.notificationBody(&pr); got != tt.want {
+				t.Errorf("notificationBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationTemplateOverrides(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.notifyTemplates = validNotifyTemplates(map[string]string{
+		"merge":   "Ship it: {title}",
+		"default": "{action} on {repo}#{number}",
+		"review":  "{nope}",
+	})
+	pr := PR{Repository: "acme/widgets", Number: 7, Title: "Add retries", Author: "alice"}
+	tests := []struct {
+		kind ActionKind
+		want string
+	}{
+		{kind: "merge", want: "Ship it: Add retries"},
+		{kind: "respond", want: "respond on acme/widgets#7"},
+		{kind: "review", want: "Review requested: acme/widgets#7 by @alice"}, // The invalid override was dropped
+	}
+	for _, tt := range tests {
+		pr.ActionKind = tt.kind
+		if got := app.notificationBody(&pr); got != tt.want {
+			t.Errorf("%s: notificationBody() = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestValidateNotifyTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{template: "Review {repo}#{number}[ by @{author}]"},
+		{template: "plain text"},
+		{template: "{first_failing_check} [({size}, waiting {age})]"},
+		{template: "", wantErr: true},
+		{template: "{repository}", wantErr: true},
+		{template: "{Repo}", wantErr: true},
+		{template: "{repo", wantErr: true},
+		{template: "repo}", wantErr: true},
+		{template: "[ by {author}", wantErr: true},
+		{template: "by {author}]", wantErr: true},
+		{template: "[[{author}]]", wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := validateNotifyTemplate(tt.template); (err != nil) != tt.wantErr {
+			t.Errorf("validateNotifyTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+		}
+	}
+	// Built-in templates must pass the same validation as configured ones
+	for _, kind := range []ActionKind{actionReview, actionFixTests, actionMerge} {
+		text, _ := builtinNotifyTemplate(kind)
+		if err := validateNotifyTemplate(text); err != nil {
+			t.Errorf("built-in %s template %q: %v", kind, text, err)
+		}
+	}
+	if err := validateNotifyTemplate(msg("notify.template.default")); err != nil {
+		t.Errorf("built-in default template: %v", err)
+	}
+}
+
+func TestFirstFailingCheck(t *testing.T) {
+	data := &turn.CheckResponse{}
+	if got := firstFailingCheck(data); got != "" {
+		t.Errorf("firstFailingCheck() without a summary = %q", got)
+	}
+	data.PullRequest.CheckSummary = &prx.CheckSummary{Failing: map[string]string{"unit": "", "lint": "", "e2e": ""}}
+	if got := firstFailingCheck(data); got != "e2e" {
+		t.Errorf("firstFailingCheck() = %q, want e2e", got)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+const (
+	// orgStaleAfter moves organizations without PRs for this long under "Older organizations…".
+	orgStaleAfter = 60 * 24 * time.Hour
+	// orgForgetAfter drops organizations without PRs for this long from the org activity.
+	// Orgs with a policy stay in the menu through it.
+	orgForgetAfter = 365 * 24 * time.Hour
+	// orgActivitySaveInterval limits how often a refreshed last-seen time is written to settings.
+	orgActivitySaveInterval = 24 * time.Hour
+)
+
+// orgActivity records when PRs from an organization were last fetched.
+type orgActivity struct {
+	LastSeenAt time.Time `json:"last_seen_at,omitzero"` // Zero: known only from a policy, never seen
+}
+
+// noteOrgSeen records a PR from org in the current fetch, marking the activity for saving
+// when the org is new or its saved time is more than orgActivitySaveInterval old. The
+// in-memory time moves every cycle, so the saved one is what's compared.
+// Caller must hold app.mu.
+func (app *App) noteOrgSeen(org string, now time.Time) {
+	prev, known := app.seenOrgs[org]
+	if !known || prev.LastSeenAt.IsZero() {
+		slog.Info("[ORG] Discovered new organization", "org", org)
+	}
+	if now.Sub(app.orgActivitySaved[org]) >= orgActivitySaveInterval {
+		app.orgActivityDirty = true
+	}
+	app.seenOrgs[org] = orgActivity{LastSeenAt: now}
+}
+
+// persistOrgActivity saves settings if org activity has changed enough to be worth keeping.
+func (app *App) persistOrgActivity() {
+	app.mu.Lock()
+	dirty := app.orgActivityDirty
+	app.orgActivityDirty = false
+	app.mu.Unlock()
+	if dirty {
+		app.saveSettings()
+	}
+}
+
+// savedOrgTimes returns each org's LastSeenAt from activity written to settings.
+func savedOrgTimes(activity map[string]orgActivity) map[string]time.Time {
+	saved := make(map[string]time.Time, len(activity))
+	for org, a := range activity {
+		saved[org] = a.LastSeenAt
+	}
+	return saved
+}
+
+// migrateOrgActivity returns the saved org activity. Settings older than schema version 2
+// only knew orgs through their policies; those start out as never seen.
+func migrateOrgActivity(settings *Settings) map[string]orgActivity {
+	activity := make(map[string]orgActivity, len(settings.OrgActivity))
+	for org, a := range settings.OrgActivity {
+		activity[org] = a
+	}
+	if settings.SchemaVersion < 2 {
+		for org := range migrateOrgPolicies(settings) {
+			if _, ok := activity[org]; !ok {
+				activity[org] = orgActivity{}
+			}
+		}
+	}
+	return activity
+}
+
+// orderOrgs splits organizations into recently active ones, most recent first, and those
+// not seen within orgStaleAfter (or ever). Ties are alphabetical.
+func orderOrgs(activity map[string]orgActivity, now time.Time) (recent, older []string) {
+	orgs := make([]string, 0, len(activity))
+	for org := range activity {
+		orgs = append(orgs, org)
+	}
+	slices.SortFunc(orgs, func(a, b string) int {
+		if c := activity[b].LastSeenAt.Compare(activity[a].LastSeenAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	for _, org := range orgs {
+		seen := activity[org].LastSeenAt
+		if seen.IsZero() || now.Sub(seen) > orgStaleAfter {
+			older = append(older, org)
+		} else {
+			recent = append(recent, org)
+		}
+	}
+	return recent, older
+}
+
+// addOrgsMenu adds the Organizations submenu with a notification policy per org, ordered by
+// recent activity. Long-inactive orgs are collapsed into "Older organizations…".
+func (app *App) addOrgsMenu(ctx context.Context) {
+	orgsMenu := app.systrayInterface.AddMenuItem(msg("orgs.menu"), msg("orgs.menu.tooltip"))
+
+	// Seen orgs plus orgs with a policy override that haven't been seen yet
+	app.mu.RLock()
+	activity := make(map[string]orgActivity, len(app.seenOrgs))
+	for org, a := range app.seenOrgs {
+		activity[org] = a
+	}
+	for org := range app.orgPolicySnapshot() {
+		if _, ok := activity[org]; !ok {
+			activity[org] = orgActivity{}
+		}
+	}
+	app.mu.RUnlock()
+
+	recent, older := orderOrgs(activity, time.Now())
+	if len(recent) == 0 && len(older) == 0 {
+		orgsMenu.AddSubMenuItem(msg("orgs.none"), "").Disable()
+		return
+	}
+	for _, org := range recent {
+		app.addOrgPolicyItem(ctx, orgsMenu, org)
+	}
+	if len(older) > 0 {
+		olderMenu := orgsMenu.AddSubMenuItem(msg("orgs.older", len(older)), msg("orgs.older.tooltip"))
+		for _, org := range older {
+			app.addOrgPolicyItem(ctx, olderMenu, org)
+		}
+	}
+}
+
+// addOrgPolicyItem adds an organization with its policy choices under parent.
+func (app *App) addOrgPolicyItem(ctx context.Context, parent MenuItem, org string) {
+	current := app.orgPolicy(org)
+	orgText := org
+	if current != orgPolicyFull {
+		orgText = msg("orgs.item", org, current.shortLabel())
+	}
+	orgItem := parent.AddSubMenuItem(orgText, "")
+
+	// Add text checkmark for all platforms
+	for _, policy := range orgPolicies {
+		policyText := policy.label()
+		if policy == current {
+			policyText = "✓ " + policyText
+		}
+		orgItem.AddSubMenuItem(policyText, "").Click(func() {
+			app.setOrgPolicy(org, policy)
+
+			// Save settings
+			app.saveSettings()
+
+			// Rebuild menu to update checkmarks
+			app.rebuildMenu(ctx)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOrderOrgs(t *testing.T) {
+	now := time.Now()
+	activity := map[string]orgActivity{
+		"zeta":    {LastSeenAt: now.Add(-time.Hour)},
+		"alpha":   {LastSeenAt: now.Add(-time.Hour)},
+		"beta":    {LastSeenAt: now.Add(-10 * time.Minute)},
+		"stale":   {LastSeenAt: now.Add(-orgStaleAfter - time.Hour)},
+		"edge":    {LastSeenAt: now.Add(-orgStaleAfter + time.Hour)},
+		"never":   {},
+		"ancient": {LastSeenAt: now.Add(-400 * 24 * time.Hour)},
+	}
+
+	recent, older := orderOrgs(activity, now)
+	if want := []string{"beta", "alpha", "zeta", "edge"}; !slices.Equal(recent, want) {
+		t.Errorf("recent = %v, want %v", recent, want)
+	}
+	if want := []string{"stale", "ancient", "never"}; !slices.Equal(older, want) {
+		t.Errorf("older = %v, want %v", older, want)
+	}
+}
+
+func TestMigrateOrgActivity(t *testing.T) {
+	seen := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	t.Run("version 1 adds policy-only orgs as never seen", func(t *testing.T) {
+		got := migrateOrgActivity(&Settings{
+			SchemaVersion: 1,
+			OrgPolicies:   map[string]orgPolicy{"muted": orgPolicySilent},
+			HiddenOrgs:    map[string]bool{"legacy": true},
+		})
+		if len(got) != 2 || !got["muted"].LastSeenAt.IsZero() || !got["legacy"].LastSeenAt.IsZero() {
+			t.Errorf("migrateOrgActivity() = %v, want muted and legacy as never seen", got)
+		}
+	})
+
+	t.Run("version 2 keeps saved activity only", func(t *testing.T) {
+		got := migrateOrgActivity(&Settings{
+			SchemaVersion: 2,
+			OrgPolicies:   map[string]orgPolicy{"muted": orgPolicySilent},
+			OrgActivity:   map[string]orgActivity{"acme": {LastSeenAt: seen}},
+		})
+		if len(got) != 1 || !got["acme"].LastSeenAt.Equal(seen) {
+			t.Errorf("migrateOrgActivity() = %v, want only acme", got)
+		}
+	})
+}
+
+func TestNoteOrgSeenMarksDirty(t *testing.T) {
+	now := time.Now()
+	app := &App{seenOrgs: map[string]orgActivity{
+		"fresh": {LastSeenAt: now.Add(-time.Hour)},
+		"old":   {LastSeenAt: now.Add(-2 * orgActivitySaveInterval)},
+	}}
+	app.orgActivitySaved = savedOrgTimes(app.seenOrgs)
+
+	app.noteOrgSeen("fresh", now)
+	if app.orgActivityDirty {
+		t.Error("a recently saved org should not force a settings write")
+	}
+	if !app.seenOrgs["fresh"].LastSeenAt.Equal(now) {
+		t.Error("last seen time should still advance in memory")
+	}
+
+	// Seen every cycle since, the org is saved again once its saved time is old enough
+	for at := now; at.Before(now.Add(orgActivitySaveInterval)); at = at.Add(time.Hour) {
+		app.noteOrgSeen("fresh", at)
+	}
+	if !app.orgActivityDirty {
+		t.Error("an org seen every cycle is never saved again")
+	}
+
+	for _, org := range []string{"old", "brand-new"} {
+		app.orgActivityDirty = false
+		app.noteOrgSeen(org, now)
+		if !app.orgActivityDirty {
+			t.Errorf("noteOrgSeen(%q) should mark activity for saving", org)
+		}
+	}
+}
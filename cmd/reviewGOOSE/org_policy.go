@@ -0,0 +1,151 @@
+package main
+
+import "log/slog"
+
+// orgPolicy controls how PRs from an organization are shown and notified.
+type orgPolicy string
+
+const (
+	orgPolicyFull   orgPolicy = "full"   // Show, notify, play sounds, and auto-open (default)
+	orgPolicySilent orgPolicy = "silent" // Show and count, but never make noise or auto-open
+	orgPolicyHidden orgPolicy = "hidden" // Hide from the menu entirely
+)
+
+// orgPolicies lists the policies in the order they appear in the Organizations menu.
+var orgPolicies = []orgPolicy{orgPolicyFull, orgPolicySilent, orgPolicyHidden}
+
+// label returns the menu label for a policy.
+func (p orgPolicy) label() string {
+	switch p {
+	case orgPolicySilent:
+		return msg("org_policy.silent")
+	case orgPolicyHidden:
+		return msg("org_policy.hidden")
+	default:
+		return msg("org_policy.full")
+	}
+}
+
+// shortLabel returns the compact name shown next to an organization with a non-default policy.
+func (p orgPolicy) shortLabel() string {
+	switch p {
+	case orgPolicySilent:
+		return msg("org_policy.short.silent")
+	case orgPolicyHidden:
+		return msg("org_policy.short.hidden")
+	default:
+		return string(p)
+	}
+}
+
+// valid reports whether p is a known policy.
+func (p orgPolicy) valid() bool {
+	switch p {
+	case orgPolicyFull, orgPolicySilent, orgPolicyHidden:
+		return true
+	default:
+		return false
+	}
+}
+
+// migrateOrgPolicies merges the legacy hidden_orgs setting into per-org policies.
+// Legacy hidden orgs become policy=hidden; explicit policies take precedence.
+func migrateOrgPolicies(settings *Settings) map[string]orgPolicy {
+	policies := make(map[string]orgPolicy)
+	for org, hidden := range settings.HiddenOrgs {
+		if hidden {
+			policies[org] = orgPolicyHidden
+		}
+	}
+	for org, p := range settings.OrgPolicies {
+		if !p.valid() {
+			slog.Warn("[SETTINGS] Ignoring unknown org policy", "org", org, "policy", p)
+			continue
+		}
+		policies[org] = p
+	}
+	// Full is the default, so there's no need to keep it around
+	for org, p := range policies {
+		if p == orgPolicyFull {
+			delete(policies, org)
+		}
+	}
+	return policies
+}
+
+// applyOrgPolicies replaces the in-memory policies. Hidden orgs are tracked in
+// hiddenOrgs, which the menu and state manager already use for filtering.
+// Caller must hold app.mu.
+func (app *App) applyOrgPolicies(policies map[string]orgPolicy) {
+	app.hiddenOrgs = make(map[string]bool)
+	app.silentOrgs = make(map[string]bool)
+	for org, p := range policies {
+		switch p {
+		case orgPolicyHidden:
+			app.hiddenOrgs[org] = true
+		case orgPolicySilent:
+			app.silentOrgs[org] = true
+		default:
+		}
+	}
+}
+
+// orgPolicySnapshot returns the non-default policies for persisting.
+// Caller must hold app.mu (read).
+func (app *App) orgPolicySnapshot() map[string]orgPolicy {
+	policies := make(map[string]orgPolicy)
+	for org := range app.silentOrgs {
+		policies[org] = orgPolicySilent
+	}
+	for org, hidden := range app.hiddenOrgs {
+		if hidden {
+			policies[org] = orgPolicyHidden
+		}
+	}
+	return policies
+}
+
+// orgPolicy returns the notification policy for an organization.
+func (app *App) orgPolicy(org string) orgPolicy {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+
+	switch {
+	case org == "":
+		return orgPolicyFull
+	case app.hiddenOrgs[org]:
+		return orgPolicyHidden
+	case app.silentOrgs[org]:
+		return orgPolicySilent
+	default:
+		return orgPolicyFull
+	}
+}
+
+// prPolicy returns the notification policy for the organization that owns a PR.
+func (app *App) prPolicy(repo string) orgPolicy {
+	return app.orgPolicy(extractOrgFromRepo(repo))
+}
+
+// setOrgPolicy updates the policy for an organization.
+func (app *App) setOrgPolicy(org string, p orgPolicy) {
+	app.mu.Lock()
+	delete(app.hiddenOrgs, org)
+	delete(app.silentOrgs, org)
+	switch p {
+	case orgPolicyHidden:
+		if app.hiddenOrgs == nil {
+			app.hiddenOrgs = make(map[string]bool)
+		}
+		app.hiddenOrgs[org] = true
+	case orgPolicySilent:
+		if app.silentOrgs == nil {
+			app.silentOrgs = make(map[string]bool)
+		}
+		app.silentOrgs[org] = true
+	default:
+	}
+	app.mu.Unlock()
+
+	slog.Info("[SETTINGS] Org policy changed", "org", org, "policy", p)
+}
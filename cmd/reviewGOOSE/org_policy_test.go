@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"sync"
+	"testing"
+)
+
+func TestPRPolicyResolution(t *testing.T) {
+	app := &App{
+		mu:         sync.RWMutex{},
+		hiddenOrgs: make(map[string]bool),
+		silentOrgs: make(map[string]bool),
+	}
+	app.setOrgPolicy("oss-org", orgPolicySilent)
+	app.setOrgPolicy("noisy-org", orgPolicyHidden)
+
+	tests := []struct {
+		name string
+		repo string
+		want orgPolicy
+	}{
+		{"work org defaults to full", "employer/service", orgPolicyFull},
+		{"silent org", "oss-org/lib", orgPolicySilent},
+		{"hidden org", "noisy-org/bot-repo", orgPolicyHidden},
+		{"repo without org", "", orgPolicyFull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := app.prPolicy(tt.repo); got != tt.want {
+				t.Errorf("prPolicy(%q) = %q, want %q", tt.repo, got, tt.want)
+			}
+		})
+	}
+
+	// Hidden orgs keep filtering through hiddenOrgs; silent orgs are still shown
+	if !app.hiddenOrgs["noisy-org"] {
+		t.Error("expected hidden policy to populate hiddenOrgs")
+	}
+	if app.hiddenOrgs["oss-org"] {
+		t.Error("silent org should not be hidden")
+	}
+
+	// Changing policy replaces the previous one
+	app.setOrgPolicy("noisy-org", orgPolicyFull)
+	if got := app.prPolicy("noisy-org/bot-repo"); got != orgPolicyFull {
+		t.Errorf("after reset, prPolicy = %q, want %q", got, orgPolicyFull)
+	}
+	if app.hiddenOrgs["noisy-org"] {
+		t.Error("expected org to be removed from hiddenOrgs")
+	}
+}
+
+func TestOrgPolicySettingsMigration(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want map[string]orgPolicy
+	}{
+		{
+			name: "legacy hidden orgs become hidden policy",
+			json: `{"hidden_orgs": {"old-org": true, "unhidden": false}}`,
+			want: map[string]orgPolicy{"old-org": orgPolicyHidden},
+		},
+		{
+			name: "explicit policy wins over legacy hidden",
+			json: `{"hidden_orgs": {"oss": true}, "org_policies": {"oss": "silent", "work": "full"}}`,
+			want: map[string]orgPolicy{"oss": orgPolicySilent},
+		},
+		{
+			name: "unknown policy ignored",
+			json: `{"org_policies": {"weird": "loud", "quiet": "silent"}}`,
+			want: map[string]orgPolicy{"quiet": orgPolicySilent},
+		},
+		{
+			name: "no org settings",
+			json: `{"enable_audio_cues": true}`,
+			want: map[string]orgPolicy{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var settings Settings
+			if err := json.Unmarshal([]byte(tt.json), &settings); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			got := migrateOrgPolicies(&settings)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("migrateOrgPolicies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgPolicySettingsRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	app := &App{mu: sync.RWMutex{}}
+	app.loadSettings()
+	app.setOrgPolicy("oss", orgPolicySilent)
+	app.setOrgPolicy("spam", orgPolicyHidden)
+	app.saveSettings()
+
+	loaded := &App{mu: sync.RWMutex{}}
+	loaded.loadSettings()
+	if got := loaded.orgPolicy("oss"); got != orgPolicySilent {
+		t.Errorf("oss policy = %q, want %q", got, orgPolicySilent)
+	}
+	if !loaded.hiddenOrgs["spam"] {
+		t.Error("expected spam org to be hidden after reload")
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// orgSyncInterval is how often org membership is re-read for the sprinkler.
+const orgSyncInterval = time.Hour
+
+// orgSyncState tracks org membership between syncs. Its zero value is ready to use.
+type orgSyncState struct {
+	lastSync time.Time
+	members  []string        // My org memberships as of the last sync
+	departed map[string]bool // Orgs I left whose PRs may still be listed
+	mu       sync.Mutex
+	inFlight bool
+}
+
+// currentOrgs returns the organizations the monitor was last given.
+func (sm *sprinklerMonitor) currentOrgs() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return slices.Clone(sm.orgs)
+}
+
+// orgDiff returns the orgs in next but not prev, and in prev but not next, case-insensitively.
+func orgDiff(prev, next []string) (added, removed []string) {
+	has := func(orgs []string, org string) bool {
+		return slices.ContainsFunc(orgs, func(o string) bool { return strings.EqualFold(o, org) })
+	}
+	for _, org := range next {
+		if !has(prev, org) {
+			added = append(added, org)
+		}
+	}
+	for _, org := range prev {
+		if !has(next, org) {
+			removed = append(removed, org)
+		}
+	}
+	return added, removed
+}
+
+// unionOrgs returns members followed by the observed orgs not among them,
+// case-insensitively.
+func unionOrgs(members, observed []string) []string {
+	orgs := slices.Clone(members)
+	for _, org := range observed {
+		if !slices.ContainsFunc(orgs, func(o string) bool { return strings.EqualFold(o, org) }) {
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs
+}
+
+// observedOrgs returns the orgs seen in fetched PRs, sorted.
+func (app *App) observedOrgs() []string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return slices.Sorted(maps.Keys(app.seenOrgs))
+}
+
+// watchOrgs gives the monitor my memberships plus the orgs seen in fetched PRs, which
+// covers orgs where I'm only an outside collaborator, and starts it if it had nothing
+// to watch before. It reports whether the list changed.
+func (app *App) watchOrgs(ctx context.Context) (bool, error) {
+	app.orgSync.mu.Lock()
+	members := app.orgSync.members
+	app.orgSync.mu.Unlock()
+
+	orgs := unionOrgs(members, app.observedOrgs())
+	if added, removed := orgDiff(app.sprinklerMonitor.currentOrgs(), orgs); len(added) == 0 && len(removed) == 0 {
+		return false, nil
+	}
+	app.sprinklerMonitor.updateOrgs(orgs)
+	return true, app.sprinklerMonitor.start(ctx)
+}
+
+// syncSprinklerOrgs re-reads my org memberships. On a change the monitor gets the new
+// list, joined with the orgs seen in fetched PRs, and it starts if it had nothing to
+// watch before; it subscribes to every org at once ("*"), so a running subscription
+// needs no restart. A failed lookup is logged and leaves the current list in place.
+func (app *App) syncSprinklerOrgs(ctx context.Context) error {
+	if app.client == nil || app.sprinklerMonitor == nil {
+		return nil
+	}
+	app.mu.RLock()
+	user := app.targetUser
+	if user == "" {
+		user = app.login()
+	}
+	app.mu.RUnlock()
+	if user == "" {
+		return errors.New("no user configured")
+	}
+
+	slog.Info("[SPRINKLER] Fetching user's organizations", "user", user)
+	members, err := app.fetchUserOrgs(ctx, user)
+
+	app.orgSync.mu.Lock()
+	app.orgSync.inFlight = false
+	if err == nil {
+		app.orgSync.lastSync = time.Now()
+	}
+	app.orgSync.mu.Unlock()
+
+	if err != nil {
+		// Gracefully degrade - keep the current orgs and try again later
+		slog.Warn("[SPRINKLER] Failed to fetch organizations after retries, keeping current list",
+			"error", err,
+			"maxRetries", maxRetries)
+		return nil
+	}
+
+	app.orgSync.mu.Lock()
+	previous := app.orgSync.members
+	app.orgSync.members = members
+	added, removed := orgDiff(previous, members)
+	if app.orgSync.departed == nil {
+		app.orgSync.departed = make(map[string]bool)
+	}
+	for _, org := range removed {
+		app.orgSync.departed[org] = true
+	}
+	for _, org := range members {
+		delete(app.orgSync.departed, org)
+	}
+	app.orgSync.mu.Unlock()
+
+	switch {
+	case previous == nil:
+		slog.Info("[SPRINKLER] Discovered user organizations",
+			"user", user,
+			"orgs", members,
+			"count", len(members))
+	case len(added) > 0 || len(removed) > 0:
+		slog.Info("[SPRINKLER] Organization membership changed",
+			"user", user,
+			"added", added,
+			"removed", removed,
+			"count", len(members))
+	default:
+		slog.Debug("[SPRINKLER] Organization membership unchanged", "count", len(members))
+	}
+
+	_, err = app.watchOrgs(ctx)
+	return err
+}
+
+// orgSyncLoop re-reads org membership every orgSyncInterval, so joining an org takes
+// effect without a restart.
+func (app *App) orgSyncLoop(ctx context.Context) {
+	if app.sprinklerMonitor == nil {
+		return
+	}
+	// Without /user there are no memberships to read; reconcileOrgs watches the orgs seen in PRs
+	app.mu.RLock()
+	overridden := app.loginOverride != ""
+	app.mu.RUnlock()
+	if overridden {
+		slog.Info("[SPRINKLER] Login override in use, not syncing organization memberships")
+		return
+	}
+	ticker := time.NewTicker(orgSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !app.beginOrgSync() {
+				continue
+			}
+			if err := app.syncSprinklerOrgs(ctx); err != nil {
+				slog.Warn("[SPRINKLER] Organization sync failed", "error", err)
+			}
+		}
+	}
+}
+
+// beginOrgSync claims the next sync, returning false if one is already running.
+func (app *App) beginOrgSync() bool {
+	app.orgSync.mu.Lock()
+	defer app.orgSync.mu.Unlock()
+	if app.orgSync.inFlight {
+		return false
+	}
+	app.orgSync.inFlight = true
+	return true
+}
+
+// reconcileOrgs runs after each fetch. Orgs I've left drop out of seenOrgs once none of
+// their PRs remain, and the sprinkler starts watching orgs that newly appear in PRs.
+func (app *App) reconcileOrgs(ctx context.Context) {
+	app.mu.RLock()
+	present := make(map[string]bool)
+	for _, prs := range [][]PR{app.incoming, app.outgoing, app.filteredPRs} {
+		for i := range prs {
+			if org := extractOrgFromRepo(prs[i].Repository); org != "" {
+				present[strings.ToLower(org)] = true
+			}
+		}
+	}
+	app.mu.RUnlock()
+
+	app.orgSync.mu.Lock()
+	var gone []string
+	for org := range app.orgSync.departed {
+		if !present[strings.ToLower(org)] {
+			gone = append(gone, org)
+			delete(app.orgSync.departed, org)
+		}
+	}
+	app.orgSync.mu.Unlock()
+
+	if len(gone) > 0 {
+		app.mu.Lock()
+		for _, org := range gone {
+			for seen := range app.seenOrgs {
+				if strings.EqualFold(seen, org) {
+					delete(app.seenOrgs, seen)
+					app.orgActivityDirty = true
+				}
+			}
+		}
+		app.mu.Unlock()
+		slog.Info("[ORG] Forgot organizations I left", "orgs", gone)
+	}
+
+	if app.sprinklerMonitor == nil {
+		return
+	}
+	before := app.sprinklerMonitor.currentOrgs()
+	changed, err := app.watchOrgs(ctx)
+	if err != nil {
+		slog.Warn("[SPRINKLER] Failed to start event monitor", "error", err)
+	}
+	if changed {
+		added, removed := orgDiff(before, app.sprinklerMonitor.currentOrgs())
+		slog.Info("[SPRINKLER] Organizations seen in PRs changed", "added", added, "removed", removed)
+	}
+}
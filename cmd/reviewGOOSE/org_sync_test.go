@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/dedup"
+	"github.com/codeGROOVE-dev/sprinkler/pkg/client"
+	"github.com/google/go-github/v57/github"
+)
+
+// orgsServer serves /users/me/orgs from a list the test can change between cycles.
+type orgsServer struct {
+	orgs     []string
+	mu       sync.Mutex
+	requests atomic.Int32
+}
+
+func (s *orgsServer) set(orgs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs = orgs
+}
+
+func (s *orgsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if r.URL.Path != "/users/me/orgs" {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	var body []map[string]string
+	for _, org := range s.orgs {
+		body = append(body, map[string]string{"login": org})
+	}
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func newOrgSyncTestApp(t *testing.T, orgs *orgsServer) *App {
+	t.Helper()
+	server := httptest.NewServer(orgs)
+	t.Cleanup(server.Close)
+	app := &App{
+		client:      newETagTestClient(t, server.URL),
+		currentUser: &github.User{Login: github.String("me")},
+		seenOrgs:    make(map[string]orgActivity),
+	}
+	// isRunning keeps start() from dialing the real sprinkler server
+	app.sprinklerMonitor = &sprinklerMonitor{app: app, isRunning: true}
+	return app
+}
+
+func TestSyncSprinklerOrgsPicksUpMembershipChanges(t *testing.T) {
+	ctx := context.Background()
+	orgs := &orgsServer{}
+	orgs.set("acme", "gadgets")
+	app := newOrgSyncTestApp(t, orgs)
+
+	if err := app.initSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.sprinklerMonitor.currentOrgs(); !slices.Equal(got, []string{"acme", "gadgets"}) {
+		t.Fatalf("orgs after startup = %q", got)
+	}
+
+	// Joined widgets, left gadgets
+	orgs.set("acme", "widgets")
+	if err := app.syncSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.sprinklerMonitor.currentOrgs(); !slices.Equal(got, []string{"acme", "widgets"}) {
+		t.Errorf("updateOrgs got %q, want the new membership", got)
+	}
+	if !app.orgSync.departed["gadgets"] {
+		t.Error("a departed org wasn't recorded")
+	}
+}
+
+func TestReconcileOrgsAgesOutDepartedOrgs(t *testing.T) {
+	ctx := context.Background()
+	orgs := &orgsServer{}
+	orgs.set("acme", "gadgets")
+	app := newOrgSyncTestApp(t, orgs)
+	if err := app.initSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	orgs.set("acme")
+	if err := app.syncSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	app.seenOrgs["gadgets"] = orgActivity{LastSeenAt: time.Now()}
+
+	// Its PRs are still listed: keep it
+	app.incoming = []PR{{Repository: "gadgets/thing", URL: "https://github.com/gadgets/thing/pull/1"}}
+	app.reconcileOrgs(ctx)
+	if _, ok := app.seenOrgs["gadgets"]; !ok {
+		t.Fatal("an org with PRs still listed was forgotten")
+	}
+
+	app.incoming = nil
+	app.reconcileOrgs(ctx)
+	if _, ok := app.seenOrgs["gadgets"]; ok {
+		t.Error("a departed org without PRs should age out of seenOrgs")
+	}
+	if !app.orgActivityDirty {
+		t.Error("forgetting an org should be saved")
+	}
+}
+
+func TestReconcileOrgsWatchesOrgsFromPRs(t *testing.T) {
+	ctx := context.Background()
+	orgs := &orgsServer{}
+	orgs.set("acme")
+	app := newOrgSyncTestApp(t, orgs)
+	if err := app.initSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// I'm only an outside collaborator on outside/lib: no membership, but its PRs are listed
+	before := orgs.requests.Load()
+	app.seenOrgs["outside"] = orgActivity{LastSeenAt: time.Now()}
+	app.incoming = []PR{{Repository: "outside/lib", URL: "https://github.com/outside/lib/pull/2"}}
+	app.reconcileOrgs(ctx)
+	if got := app.sprinklerMonitor.currentOrgs(); !slices.Equal(got, []string{"acme", "outside"}) {
+		t.Fatalf("orgs after a PR from an outside org = %q, want acme and outside", got)
+	}
+	if got := orgs.requests.Load(); got != before {
+		t.Errorf("watching an org seen in PRs made %d membership lookups", got-before)
+	}
+
+	// A membership sync keeps the orgs seen in PRs
+	orgs.set("acme", "newco")
+	if err := app.syncSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.sprinklerMonitor.currentOrgs(); !slices.Equal(got, []string{"acme", "newco", "outside"}) {
+		t.Errorf("orgs after a sync = %q, want memberships plus outside", got)
+	}
+}
+
+func TestSprinklerKeepsOutsideCollaboratorEvents(t *testing.T) {
+	ctx := context.Background()
+	orgs := &orgsServer{}
+	orgs.set("acme")
+	app := newOrgSyncTestApp(t, orgs)
+	sm := app.sprinklerMonitor
+	sm.eventChan = make(chan prEvent, 4)
+	sm.dedup = dedup.New(eventDedupWindow, eventMapCleanupAge, eventMapMaxSize)
+	if err := app.initSprinklerOrgs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	received := func(url string) bool {
+		sm.handleEvent(client.Event{Type: "pull_request", URL: url, Timestamp: time.Now()})
+		select {
+		case evt := <-sm.eventChan:
+			return evt.url == url
+		default:
+			return false
+		}
+	}
+
+	// Before any fetch, outside isn't watched and its events drop
+	if received("https://github.com/outside/lib/pull/1") {
+		t.Fatal("an event from an org with nothing of mine was kept")
+	}
+
+	// A fetch lists a PR from outside, where I'm an outside collaborator
+	app.seenOrgs["outside"] = orgActivity{LastSeenAt: time.Now()}
+	app.incoming = []PR{{Repository: "outside/lib", Number: 2, URL: "https://github.com/outside/lib/pull/2"}}
+	app.reconcileOrgs(ctx)
+	if !received("https://github.com/outside/lib/pull/3") {
+		t.Error("an event from an outside-collaborator org was dropped")
+	}
+	if !received("https://github.com/Outside/lib/pull/4") {
+		t.Error("org matching should ignore case")
+	}
+
+	// A PR in my lists is relevant even from an org that isn't watched
+	app.filteredPRs = []PR{{Repository: "elsewhere/tool", Number: 5, URL: "https://github.com/elsewhere/tool/pull/5"}}
+	if !received("https://github.com/elsewhere/tool/pull/5") {
+		t.Error("an event about a listed PR was dropped")
+	}
+	if received("https://github.com/elsewhere/tool/pull/6") {
+		t.Error("an event from an unrelated org was kept")
+	}
+}
+
+func TestSprinklerEventURLs(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.healthMonitor = newHealthMonitor()
+	sm := &sprinklerMonitor{app: app, orgs: []string{"acme"}}
+	sm.eventChan = make(chan prEvent, 8)
+	sm.dedup = dedup.New(eventDedupWindow, eventMapCleanupAge, eventMapMaxSize)
+	handle := func(url string) (prEvent, bool) {
+		sm.handleEvent(client.Event{Type: "pull_request", URL: url, Timestamp: time.Now()})
+		select {
+		case evt := <-sm.eventChan:
+			return evt, true
+		default:
+			return prEvent{}, false
+		}
+	}
+
+	tests := []struct {
+		url  string
+		want string // Queued URL; empty when the event is dropped
+	}{
+		{url: "https://github.com/acme/widgets/pull/1", want: "https://github.com/acme/widgets/pull/1"},
+		{url: "https://github.com/ACME/widgets/pull/2", want: "https://github.com/ACME/widgets/pull/2"},
+		{url: "https://github.com/acme/widgets/pull/3/", want: "https://github.com/acme/widgets/pull/3"},
+		{url: "https://github.com/Acme/widgets/pull/4?notification_referrer_id=x#top", want: "https://github.com/Acme/widgets/pull/4"},
+		{url: "https://github.com/other/widgets/pull/5"},
+		{url: "https://github.example.com/acme/widgets/pull/6"},
+		{url: "github.com/acme/widgets/pull/7"},
+		{url: "https://github.com/acme/widgets/issues/8"},
+		{url: ""},
+	}
+	for _, tt := range tests {
+		evt, ok := handle(tt.url)
+		if got := evt.url; ok != (tt.want != "") || got != tt.want {
+			t.Errorf("handleEvent(%q) queued %q (queued=%v), want %q", tt.url, got, ok, tt.want)
+		}
+	}
+
+	// The canonical URL is what's deduped, so a trailing slash is the same PR
+	if _, ok := handle("https://github.com/acme/widgets/pull/1/"); ok {
+		t.Error("a repeat event spelled with a trailing slash wasn't deduped")
+	}
+	if got := app.healthMonitor.sprinklerInvalid.Load(); got != 4 {
+		t.Errorf("unparseable events = %d, want 4", got)
+	}
+}
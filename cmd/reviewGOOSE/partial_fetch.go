@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// PartialError reports that some of the GitHub searches failed. The PRs returned with it
+// come from the searches that succeeded, so they're usable but may be incomplete.
+type PartialError struct {
+	Queries []string // The searches that failed
+	Errs    []error
+	Total   int // Searches attempted
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d of %d GitHub queries failed: %v", len(e.Queries), e.Total, errors.Join(e.Errs...))
+}
+
+func (e *PartialError) Unwrap() []error {
+	return e.Errs
+}
+
+// asPartialFetch returns err as a *PartialError, or nil if it is anything else.
+func asPartialFetch(err error) *PartialError {
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return partial
+	}
+	return nil
+}
+
+// setPartialFetch records the outcome of a successful update: partial is nil once
+// every search succeeds again.
+func (app *App) setPartialFetch(partial *PartialError) {
+	app.mu.Lock()
+	was := app.partialFetch != nil
+	app.partialFetch = partial
+	app.mu.Unlock()
+
+	switch {
+	case partial != nil && !was:
+		slog.Warn("[GITHUB] Showing partial results", "failed", len(partial.Queries), "total", partial.Total, "queries", partial.Queries)
+	case partial == nil && was:
+		slog.Info("[GITHUB] All searches succeeded again, results are complete")
+	default:
+	}
+}
+
+// partialFetchTitle returns the menu and tooltip line shown while results are partial
+// or GitHub refuses a search, or "" when they're complete.
+func (app *App) partialFetchTitle() string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.searchUnavailable != nil {
+		return msg("menu.search_unavailable", app.searchUnavailable.StatusCode)
+	}
+	if app.partialFetch == nil {
+		return ""
+	}
+	return msg("menu.partial_fetch", len(app.partialFetch.Queries), app.partialFetch.Total)
+}
+
+// addPartialFetchNotice adds a disabled line while results are partial, listing the
+// failed searches in its tooltip, or saying which search GitHub refuses.
+func (app *App) addPartialFetchNotice(_ context.Context) {
+	title := app.partialFetchTitle()
+	if title == "" {
+		return
+	}
+	app.mu.RLock()
+	var tooltip string
+	if unavailable := app.searchUnavailable; unavailable != nil {
+		tooltip = msg("menu.search_unavailable.tooltip", unavailable.StatusCode, http.StatusText(unavailable.StatusCode), unavailable.Query)
+	} else {
+		tooltip = msg("menu.partial_fetch.tooltip", strings.Join(app.partialFetch.Queries, "\n"))
+	}
+	app.mu.RUnlock()
+
+	app.systrayInterface.AddMenuItem(title, tooltip).Disable()
+	app.systrayInterface.AddSeparator()
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const (
+	involvesPR   = "https://github.com/test/repo/pull/1"
+	reviewNonePR = "https://github.com/test/repo/pull/2"
+)
+
+// partialSearchServer answers each search with its own PR, failing searches whose
+// query contains the fail substring with failStatus (422 unless set).
+type partialSearchServer struct {
+	*httptest.Server
+	fail       string
+	failStatus int
+	mu         sync.Mutex
+}
+
+func newPartialSearchServer(t *testing.T, updatedAt time.Time) *partialSearchServer {
+	t.Helper()
+	s := &partialSearchServer{}
+	s.Server = newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		q := r.URL.Query().Get("q")
+		s.mu.Lock()
+		fail, status := s.fail, s.failStatus
+		s.mu.Unlock()
+		if fail != "" && strings.Contains(q, fail) {
+			// 422 isn't retried, so the failure is immediate
+			if status == 0 {
+				status = http.StatusUnprocessableEntity
+			}
+			return fakeSearch{status: status}
+		}
+		number := 1
+		if strings.Contains(q, "review:none") {
+			number = 2
+		}
+		return fakeSearch{prs: []fakePR{{repo: "test/repo", number: number, updated: updatedAt}}}
+	})
+	return s
+}
+
+func (s *partialSearchServer) setFail(substr string) {
+	s.mu.Lock()
+	s.fail = substr
+	s.mu.Unlock()
+}
+
+// newPartialFetchTestApp returns an app fetching from githubURL, with Turn verdicts
+// that leave every PR waiting on nobody.
+func newPartialFetchTestApp(t *testing.T, githubURL string) *App {
+	t.Helper()
+	turnClient := newFakeTurn(t, func(context.Context, turn.CheckRequest) fakeVerdict { return fakeVerdict{} })
+	return newFetchTestApp(t, githubURL, turnClient, "testuser")
+}
+
+func TestPartialFetch(t *testing.T) {
+	tests := []struct {
+		fail    string
+		present string
+		missing string
+	}{
+		{fail: "involves:", present: reviewNonePR, missing: involvesPR},
+		{fail: "review:none", present: involvesPR, missing: reviewNonePR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fail, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now()
+			server := newPartialSearchServer(t, now)
+			app := newPartialFetchTestApp(t, server.URL)
+			mock, ok := app.systrayInterface.(*MockSystray)
+			if !ok {
+				t.Fatal("expected a MockSystray")
+			}
+
+			server.setFail(tt.fail)
+			app.updatePRs(ctx)
+
+			urls := make([]string, 0, len(app.incoming))
+			for i := range app.incoming {
+				urls = append(urls, app.incoming[i].URL)
+			}
+			if !slices.Contains(urls, tt.present) || slices.Contains(urls, tt.missing) {
+				t.Fatalf("incoming = %v, want only the PR from the search that succeeded", urls)
+			}
+			if app.consecutiveFailures != 0 || app.lastFetchError != "" {
+				t.Errorf("partial result counted as a failure: failures=%d error=%q", app.consecutiveFailures, app.lastFetchError)
+			}
+			if app.lastSuccessfulFetch.IsZero() {
+				t.Error("partial result should count as a successful fetch")
+			}
+			if last, _ := mock.iconState(); bytes.Equal(last, getIcon(IconWarning, PRCounts{})) {
+				t.Error("partial result should not switch to the warning icon")
+			}
+			want := "⚠️ Some PRs may be missing (1 of 2 queries failed)"
+			if got := app.partialFetchTitle(); got != want {
+				t.Errorf("partialFetchTitle() = %q, want %q", got, want)
+			}
+			if !slices.Contains(app.generateMenuTitles(), want) {
+				t.Error("menu titles missing the partial results warning")
+			}
+			if len(app.partialFetch.Queries) != 1 || !strings.Contains(app.partialFetch.Queries[0], tt.fail) {
+				t.Errorf("failed queries = %v, want the %q search", app.partialFetch.Queries, tt.fail)
+			}
+
+			server.setFail("")
+			app.updatePRs(ctx)
+			if got := app.partialFetchTitle(); got != "" {
+				t.Errorf("partialFetchTitle() = %q once both searches succeed, want it cleared", got)
+			}
+			if len(app.incoming) != 2 {
+				t.Errorf("incoming has %d PRs once both searches succeed, want 2", len(app.incoming))
+			}
+		})
+	}
+}
+
+func TestBothQueriesFailingIsNotPartial(t *testing.T) {
+	now := time.Now()
+	server := newPartialSearchServer(t, now)
+	app := newPartialFetchTestApp(t, server.URL)
+
+	server.setFail("is:open")
+	app.updatePRs(context.Background())
+	if app.consecutiveFailures != 1 {
+		t.Errorf("consecutiveFailures = %d, want 1 when every search fails", app.consecutiveFailures)
+	}
+	if app.partialFetch != nil {
+		t.Error("a total failure should not be reported as partial")
+	}
+}
+
+func TestPartialErrorUnwraps(t *testing.T) {
+	cause := errors.New("boom")
+	wrapped := fmt.Errorf("cycle: %w", &PartialError{Queries: []string{"q"}, Errs: []error{cause}, Total: 2})
+	if asPartialFetch(wrapped) == nil {
+		t.Error("asPartialFetch should find a wrapped PartialError")
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Error("PartialError should unwrap to the failed query's error")
+	}
+	if asPartialFetch(cause) != nil {
+		t.Error("asPartialFetch should ignore other errors")
+	}
+}
+
+// TestPartialFetchKeepsBlockedState verifies a blocked PR missing from a partial fetch
+// isn't taken as unblocked, so it doesn't notify again once its search recovers.
+func TestPartialFetchKeepsBlockedState(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.hook = newNotificationHook("/unused", nil) // Events stay queued without a worker
+	app.hasPerformedInitialDiscovery = true
+	now := time.Now()
+	blocked := PR{Repository: "test/repo", Number: 1, URL: involvesPR, NeedsReview: true, UpdatedAt: now}
+	other := PR{Repository: "test/repo", Number: 2, URL: reviewNonePR, UpdatedAt: now}
+	notes := func() int {
+		notifier.mu.Lock()
+		defer notifier.mu.Unlock()
+		return len(notifier.notes)
+	}
+	hookEvents := func() []string {
+		var types []string
+		for len(app.hook.events) > 0 {
+			types = append(types, (<-app.hook.events).Type)
+		}
+		return types
+	}
+
+	app.incoming = []PR{blocked, other}
+	app.processNotifications(ctx)
+	deadline := time.Now().Add(5 * time.Second)
+	for notes() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the blocked PR never notified")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	hookEvents()
+	st, ok := app.stateManager.PRState(involvesPR)
+	if !ok || !st.HasNotified {
+		t.Fatalf("state = %+v, %v, want the PR tracked as notified", st, ok)
+	}
+	firstBlocked := st.FirstBlockedAt
+
+	// The involves: search fails, so #1 is missing
+	app.incoming = []PR{other}
+	app.partialFetch = &PartialError{Queries: []string{"involves:testuser"}, Total: 2}
+	app.processNotifications(ctx)
+	if _, ok := app.stateManager.PRState(involvesPR); !ok {
+		t.Error("a partial fetch dropped the blocked PR's state")
+	}
+	if got := hookEvents(); len(got) != 0 {
+		t.Errorf("hook events after a partial fetch = %q, want none", got)
+	}
+
+	// The search recovers
+	app.incoming = []PR{blocked, other}
+	app.partialFetch = nil
+	app.processNotifications(ctx)
+	if got := hookEvents(); len(got) != 0 {
+		t.Errorf("hook events after recovery = %q, want none", got)
+	}
+	if st, ok := app.stateManager.PRState(involvesPR); !ok || !st.FirstBlockedAt.Equal(firstBlocked) {
+		t.Errorf("state after recovery = %+v, want the original episode", st)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := notes(); got != 1 {
+		t.Errorf("sent %d notifications, want the PR notified once", got)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// When Turn says I owe a response on my own PR, the question I'm being asked is usually
+// a review comment. It's looked up for blocked respond-kind outgoing PRs only, shown in
+// the tooltip, and the PR's link jumps straight to it.
+
+// questionExcerptRunes is how much of a question the tooltip shows.
+const questionExcerptRunes = 120
+
+// pendingQuestion is the latest review comment on my PR that I haven't answered.
+type pendingQuestion struct {
+	author  string
+	excerpt string // Sanitized and truncated to questionExcerptRunes
+	id      int64  // The review comment's ID, for its anchor
+}
+
+// latestQuestion finds the most recent review comment thread whose last word isn't
+// mine, and returns that last comment. The REST API doesn't say which threads are
+// resolved, so a thread counts as open until I reply in it.
+func latestQuestion(comments []*github.PullRequestComment, me string) (pendingQuestion, bool) {
+	last := make(map[int64]*github.PullRequestComment) // Thread root ID -> its newest comment
+	for _, c := range comments {
+		root := c.GetInReplyTo()
+		if root == 0 {
+			root = c.GetID()
+		}
+		if prev, ok := last[root]; !ok || c.GetCreatedAt().After(prev.GetCreatedAt().Time) {
+			last[root] = c
+		}
+	}
+	var newest *github.PullRequestComment
+	for _, c := range last {
+		login := c.GetUser().GetLogin()
+		if login == "" || strings.EqualFold(login, me) || isBotLogin(login) || c.GetUser().GetType() == "Bot" {
+			continue
+		}
+		if newest == nil || c.GetCreatedAt().After(newest.GetCreatedAt().Time) ||
+			(c.GetCreatedAt().Equal(newest.GetCreatedAt()) && c.GetID() > newest.GetID()) {
+			newest = c
+		}
+	}
+	if newest == nil {
+		return pendingQuestion{}, false
+	}
+	return pendingQuestion{
+		author:  newest.GetUser().GetLogin(),
+		excerpt: questionExcerpt(newest.GetBody()),
+		id:      newest.GetID(),
+	}, true
+}
+
+// questionExcerpt is a comment body as one line for the tooltip: quoted replies and
+// control characters dropped, whitespace collapsed, tokens redacted, and cut to
+// questionExcerptRunes with an ellipsis.
+func questionExcerpt(body string) string {
+	var lines []string
+	for line := range strings.Lines(body) {
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	text := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return ' '
+		}
+		return r
+	}, strings.Join(lines, " "))
+	text = sanitizeForLog(strings.Join(strings.Fields(text), " "))
+	runes := []rune(text)
+	if len(runes) <= questionExcerptRunes {
+		return text
+	}
+	return strings.TrimRightFunc(string(runes[:questionExcerptRunes-1]), unicode.IsSpace) + "…"
+}
+
+// questionAnchor is the link to a review comment on a PR.
+func questionAnchor(prURL string, id int64) string {
+	return prURL + "#discussion_r" + strconv.FormatInt(id, 10)
+}
+
+// questionDetail is the tooltip line quoting a PR's pending question, or "".
+func questionDetail(pr PR) string {
+	if pr.PendingQuestion == "" {
+		return ""
+	}
+	return msg("question.detail", pr.QuestionBy, pr.PendingQuestion)
+}
+
+// pendingQuestion looks up the open question on my PR. Lookups are cached per PR
+// revision, failures included, and only logged at debug level. A new comment or reply
+// moves the PR's UpdatedAt; until then the cached comment ID keeps the link on the same
+// comment.
+func (app *App) pendingQuestion(ctx context.Context, repo string, number int, url string, updatedAt time.Time, me string) (pendingQuestion, bool) {
+	if app.client == nil || app.questions == nil {
+		return pendingQuestion{}, false
+	}
+	if q, found, ok := app.questions.get(url, updatedAt); ok {
+		return q, found
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return pendingQuestion{}, false
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, turnAPITimeout)
+	defer cancel()
+	// The newest page holds the latest question, and the rest of its thread
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	comments, _, err := app.client.PullRequests.ListComments(apiCtx, owner, name, number, opts)
+	if err != nil {
+		if cacheableFailure(err) {
+			app.questions.put(url, updatedAt, pendingQuestion{}, false)
+		}
+		if goneStatus(err) != 0 {
+			app.quarantinePR(url, err)
+		}
+		slog.Debug("[GITHUB] Review comments lookup failed", "url", url, "error", err)
+		return pendingQuestion{}, false
+	}
+
+	q, found := latestQuestion(comments, me)
+	app.questions.put(url, updatedAt, q, found)
+	if found {
+		slog.Debug("[GITHUB] Found pending question", "url", url, "author", q.author, "comment", q.id)
+	}
+	return q, found
+}
+
+// blockedQuestion is pendingQuestion limited to my PRs that Turn says are blocked on my
+// response, so review comments are never fetched for anything else.
+func (app *App) blockedQuestion(ctx context.Context, data *turn.CheckResponse, isOwner bool, repo string, number int, url string, updatedAt time.Time, me string) pendingQuestion {
+	if !isOwner || data == nil {
+		return pendingQuestion{}
+	}
+	act, ok := data.Analysis.NextAction[me]
+	if !ok || !act.Critical || parseActionKind(act.Kind) != actionRespond {
+		return pendingQuestion{}
+	}
+	q, _ := app.pendingQuestion(ctx, repo, number, url, updatedAt, me)
+	return q
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func TestQuestionExcerpt(t *testing.T) {
+	long := strings.Repeat("why not use the existing helper ", 10)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "short", body: "Why not use the existing helper?", want: "Why not use the existing helper?"},
+		{name: "whitespace collapsed", body: "Why not\n\n  use\tthe helper?\r\n", want: "Why not use the helper?"},
+		{name: "quoted reply dropped", body: "> the old code\n> did this\nIs that still true?", want: "Is that still true?"},
+		{name: "control characters", body: "bell\a and \u202eoverride", want: "bell and override"},
+		{name: "token redacted", body: "try ghp_" + strings.Repeat("a", 36), want: "try [REDACTED-TOKEN]"},
+		{name: "truncated", body: long, want: strings.TrimSpace(string([]rune(long)[:questionExcerptRunes-1])) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := questionExcerpt(tt.body)
+			if got != tt.want {
+				t.Errorf("questionExcerpt() = %q, want %q", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > questionExcerptRunes {
+				t.Errorf("excerpt is %d runes, want at most %d", n, questionExcerptRunes)
+			}
+		})
+	}
+
+	// Multi-byte text is cut on rune boundaries
+	if got := questionExcerpt(strings.Repeat("ü", 200)); !utf8.ValidString(got) || utf8.RuneCountInString(got) != questionExcerptRunes {
+		t.Errorf("questionExcerpt(ü×200) = %q", got)
+	}
+}
+
+func TestQuestionAnchor(t *testing.T) {
+	pr := PR{
+		URL: "https://github.com/acme/widgets/pull/7", ActionKind: actionRespond, PendingQuestion: "why not use the existing helper?",
+		QuestionBy: "carol", QuestionURL: questionAnchor("https://github.com/acme/widgets/pull/7", 1234),
+	}
+	if pr.QuestionURL != "https://github.com/acme/widgets/pull/7#discussion_r1234" {
+		t.Errorf("questionAnchor() = %q", pr.QuestionURL)
+	}
+	if got := prLink(&pr); got != pr.QuestionURL {
+		t.Errorf("prLink() = %q, want the comment", got)
+	}
+	got, err := gooseURL(prLink(&pr), "respond")
+	if err != nil || got != "https://github.com/acme/widgets/pull/7?goose=respond#discussion_r1234" {
+		t.Errorf("gooseURL() = %q, %v; want the query before the anchor", got, err)
+	}
+	if _, err := gooseURL(pr.URL+"#top", "respond"); err == nil {
+		t.Error("gooseURL() accepted an arbitrary fragment")
+	}
+	if tooltip := formatMenuTooltip(pr, DisplayRepoNumber, "5m"); !strings.Contains(tooltip, "@carol: 'why not use the existing helper?'") {
+		t.Errorf("tooltip = %q, want the question", tooltip)
+	}
+
+	// Other kinds keep their usual link
+	pr.ActionKind = actionFixTests
+	if got := prLink(&pr); got != pr.URL {
+		t.Errorf("prLink() for fix_tests = %q, want the PR", got)
+	}
+}
+
+func TestLatestQuestion(t *testing.T) {
+	now := time.Now()
+	comment := func(id, replyTo int64, login string, ago time.Duration, body string) *github.PullRequestComment {
+		return &github.PullRequestComment{
+			ID: github.Int64(id), InReplyTo: github.Int64(replyTo), Body: github.String(body),
+			User:      &github.User{Login: github.String(login)},
+			CreatedAt: &github.Timestamp{Time: now.Add(-ago)},
+		}
+	}
+	comments := []*github.PullRequestComment{
+		comment(1, 0, "carol", 3*time.Hour, "Why not use the existing helper?"),
+		comment(2, 1, "me", 2*time.Hour, "Done"), // Answered
+		comment(3, 0, "dave", 90*time.Minute, "Is this safe under load?"),
+		comment(4, 0, "renovate[bot]", time.Minute, "Bots don't ask"),
+		comment(5, 0, "me", 30*time.Minute, "Note to self"),
+	}
+	q, ok := latestQuestion(comments, "me")
+	if !ok || q.id != 3 || q.author != "dave" || q.excerpt != "Is this safe under load?" {
+		t.Errorf("latestQuestion() = %+v, %v; want dave's unanswered comment", q, ok)
+	}
+	if _, ok := latestQuestion(comments[:2], "me"); ok {
+		t.Error("latestQuestion() found a question in an answered thread")
+	}
+}
+
+func TestBlockedQuestionOnlyForRespond(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/acme/widgets/pulls/7/comments" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		comments := []map[string]any{{"id": 42, "body": "Why not use the existing helper?", "user": map[string]any{"login": "carol"}}}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(comments); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	app := newFocusTestApp(time.Hour)
+	app.client = newETagTestClient(t, server.URL)
+	app.questions = newPRRevisionCache[pendingQuestion]()
+
+	ctx := context.Background()
+	updated := time.Now()
+	respond := &turn.CheckResponse{}
+	respond.Analysis.NextAction = map[string]turn.Action{"me": {Kind: turn.ActionRespond, Critical: true}}
+	review := &turn.CheckResponse{}
+	review.Analysis.NextAction = map[string]turn.Action{"me": {Kind: turn.ActionReview, Critical: true}}
+
+	const url = "https://github.com/acme/widgets/pull/7"
+	// Not my PR, not a response, or not blocked: nothing is fetched
+	app.blockedQuestion(ctx, respond, false, "acme/widgets", 7, url, updated, "me")
+	app.blockedQuestion(ctx, review, true, "acme/widgets", 7, url, updated, "me")
+	informational := &turn.CheckResponse{}
+	informational.Analysis.NextAction = map[string]turn.Action{"me": {Kind: turn.ActionRespond}}
+	app.blockedQuestion(ctx, informational, true, "acme/widgets", 7, url, updated, "me")
+	if got := requests.Load(); got != 0 {
+		t.Fatalf("comments fetched %d times for PRs without a blocking response, want 0", got)
+	}
+
+	for range 2 {
+		if q := app.blockedQuestion(ctx, respond, true, "acme/widgets", 7, url, updated, "me"); q.id != 42 || q.author != "carol" {
+			t.Errorf("blockedQuestion() = %+v, want carol's comment", q)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("comments fetched %d times, want 1 (cached)", got)
+	}
+}
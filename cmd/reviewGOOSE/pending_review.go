@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Review comments I drafted but never submitted are invisible to the author, and to Turn,
+// so the PR looks like it's waiting on nothing of mine. GitHub lists my own pending
+// review to me, so incoming PRs I've been involved with are checked for one. Writing a
+// pending review doesn't move a PR's UpdatedAt, so the check runs on its own TTL.
+
+const (
+	// pendingReviewCacheTTL is how long a pending review lookup is reused.
+	pendingReviewCacheTTL = 10 * time.Minute
+	// pendingReviewReminderAfter is how long a review stays pending before a reminder.
+	pendingReviewReminderAfter = time.Hour
+	// pendingReviewWindow is how recently an incoming PR must have been updated to be checked.
+	pendingReviewWindow = 14 * 24 * time.Hour
+	// pendingReviewMaxLookups bounds the reviews API calls one cycle makes.
+	pendingReviewMaxLookups = 20
+	// pendingReviewMaxPages bounds how much of a long PR's review list is read.
+	pendingReviewMaxPages = 3
+	// pendingReviewReason replaces Turn's reason while I have an unsubmitted review.
+	pendingReviewReason = "you have an unsubmitted review"
+)
+
+// pendingReviewEntry is the latest lookup for one PR.
+type pendingReviewEntry struct {
+	checkedAt time.Time
+	updatedAt time.Time // The PR's UpdatedAt when checked; submitting a review moves it
+	since     time.Time // When the pending review was first seen; zero when there is none
+	reminded  bool      // The reminder for this pending review went out
+}
+
+// pendingReviewCache remembers pending review lookups by PR URL for
+// pendingReviewCacheTTL, or until the PR's UpdatedAt moves, and when each pending review
+// was first seen.
+type pendingReviewCache struct {
+	entries map[string]pendingReviewEntry
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func newPendingReviewCache() *pendingReviewCache {
+	return &pendingReviewCache{entries: make(map[string]pendingReviewEntry), now: time.Now}
+}
+
+// get returns when the PR's pending review was first seen, if the lookup is fresh.
+func (c *pendingReviewCache) get(url string, updatedAt time.Time) (since time.Time, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[url]
+	if !exists || !entry.updatedAt.Equal(updatedAt) || c.now().Sub(entry.checkedAt) >= pendingReviewCacheTTL {
+		return time.Time{}, false
+	}
+	return entry.since, true
+}
+
+// put records a lookup and returns when the pending review was first seen, keeping the
+// original time while the review stays pending.
+func (c *pendingReviewCache) put(url string, updatedAt time.Time, pending bool) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	entry := c.entries[url]
+	entry.checkedAt, entry.updatedAt = now, updatedAt
+	switch {
+	case !pending:
+		entry.since, entry.reminded = time.Time{}, false
+	case entry.since.IsZero():
+		entry.since = now
+	default:
+	}
+	c.entries[url] = entry
+	for u, e := range c.entries {
+		if now.Sub(e.checkedAt) > pendingReviewWindow {
+			delete(c.entries, u)
+		}
+	}
+	return entry.since
+}
+
+// remind reports whether a reminder is due for the PR's pending review, marking it sent.
+func (c *pendingReviewCache) remind(url string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if !ok || entry.since.IsZero() || entry.reminded || c.now().Sub(entry.since) < pendingReviewReminderAfter {
+		return false
+	}
+	entry.reminded = true
+	c.entries[url] = entry
+	return true
+}
+
+// hasPendingReview reports whether reviews include a pending one by me.
+func hasPendingReview(reviews []*github.PullRequestReview, me string) bool {
+	for _, r := range reviews {
+		if r.GetState() == "PENDING" && strings.EqualFold(r.GetUser().GetLogin(), me) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingReviewCandidate reports whether an incoming PR is one I've been involved with
+// recently enough to check for a pending review.
+func pendingReviewCandidate(pr *PR, me string, now time.Time) bool {
+	if now.Sub(pr.UpdatedAt) > pendingReviewWindow {
+		return false
+	}
+	return pr.NeedsReview || pr.MyReviewState != "" || pr.RequestedBy != "" || pr.RequestedAuto ||
+		!pr.PendingReviewAt.IsZero() || strings.EqualFold(pr.LastActivityActor, me)
+}
+
+// pendingReviewer returns the login whose pending reviews can be looked up, or "" when
+// they can't: GitHub only shows pending reviews to their author, so the queried user must
+// be the authenticated one, and repo and team mode aren't about my reviews.
+func (app *App) pendingReviewer(user string) string {
+	if app.client == nil || app.pendingReviews == nil || app.currentUser == nil {
+		return ""
+	}
+	if me := app.currentUser.GetLogin(); me == "" || !strings.EqualFold(me, user) {
+		return ""
+	}
+	if shared, _ := app.sharedQueue(); shared {
+		return ""
+	}
+	return user
+}
+
+// lookupPendingReview asks GitHub whether I have a pending review on a PR, and returns
+// when it was first seen, or zero. Failures are cached like a lookup that found none.
+func (app *App) lookupPendingReview(ctx context.Context, pr *PR, me string) time.Time {
+	owner, name, ok := strings.Cut(pr.Repository, "/")
+	if !ok {
+		return time.Time{}
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, turnAPITimeout)
+	defer cancel()
+	var reviews []*github.PullRequestReview
+	opts := &github.ListOptions{PerPage: 100}
+	for range pendingReviewMaxPages {
+		page, resp, err := app.client.PullRequests.ListReviews(apiCtx, owner, name, pr.Number, opts)
+		if err != nil {
+			if !cacheableFailure(err) {
+				return time.Time{}
+			}
+			slog.Debug("[GITHUB] Reviews lookup failed", "url", pr.URL, "error", err)
+			return app.pendingReviews.put(pr.URL, pr.UpdatedAt, false)
+		}
+		reviews = append(reviews, page...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return app.pendingReviews.put(pr.URL, pr.UpdatedAt, hasPendingReview(reviews, me))
+}
+
+// markPendingReviews finds incoming PRs where I have an unsubmitted review and counts
+// them as blocked on me, in place.
+func (app *App) markPendingReviews(ctx context.Context, incoming []PR, user string) {
+	me := app.pendingReviewer(user)
+	if me == "" {
+		return
+	}
+	now := time.Now()
+	lookups := 0
+	for i := range incoming {
+		pr := &incoming[i]
+		if !pendingReviewCandidate(pr, me, now) {
+			continue
+		}
+		since, fresh := app.pendingReviews.get(pr.URL, pr.UpdatedAt)
+		if !fresh {
+			if lookups == pendingReviewMaxLookups {
+				continue
+			}
+			lookups++
+			since = app.lookupPendingReview(ctx, pr, me)
+		}
+		if since.IsZero() {
+			pr.PendingReviewAt = time.Time{}
+			continue
+		}
+		if pr.PendingReviewAt.IsZero() {
+			slog.Info("[REVIEW] Unsubmitted review found", "repo", pr.Repository, "number", pr.Number)
+		}
+		pr.PendingReviewAt = since
+		applyPendingReview(pr)
+	}
+}
+
+// applyPendingReview counts a PR with my unsubmitted review as blocked on me, whatever
+// Turn's next action was.
+func applyPendingReview(pr *PR) {
+	if pr.PendingReviewAt.IsZero() {
+		return
+	}
+	pr.NeedsReview = true
+	pr.IsBlocked = true
+	pr.ActionKind = actionSubmitReview
+	pr.ActionReason = pendingReviewReason
+	pr.ActionSince = pr.PendingReviewAt
+}
+
+// pendingReviewSuffix is the label suffix for a PR with my unsubmitted review, or "".
+func pendingReviewSuffix(pr *PR) string {
+	if pr.PendingReviewAt.IsZero() {
+		return ""
+	}
+	return " " + msg("pending_review.suffix")
+}
+
+// remindPendingReviews sends one quiet notification, with no sound or auto-open, for each
+// shown incoming PR whose review has stayed unsubmitted past pendingReviewReminderAfter.
+func (app *App) remindPendingReviews(view *prView) {
+	if app.pendingReviews == nil {
+		return
+	}
+	for i := range view.incoming {
+		pr := view.incoming[i]
+		if pr.PendingReviewAt.IsZero() || app.prPolicy(pr.Repository) != orgPolicyFull || !app.pendingReviews.remind(pr.URL) {
+			continue
+		}
+		slog.Info("[NOTIFY] Review still unsubmitted", "repo", pr.Repository, "number", pr.Number,
+			"pending_for", time.Since(pr.PendingReviewAt).Round(time.Minute))
+		e := notificationEvent{
+			kind:    notifyKindReview,
+			prURL:   pr.URL,
+			title:   msg("notify.pending_review"),
+			message: msg("notify.pending_review.body", strings.TrimSpace(prRef(pr)+" "+pr.Title)),
+		}
+		app.goTracked("notification", func() {
+			if err := app.notifyEvent(e); err != nil {
+				slog.Error("[NOTIFY] Failed to send pending review reminder", "url", e.prURL, "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// newPendingReviewTestApp serves reviews for acme/widgets#7 as the authenticated "me".
+func newPendingReviewTestApp(t *testing.T, reviews []map[string]any) (*App, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/acme/widgets/pulls/7/reviews" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reviews); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	app, _ := newGraceTestApp(time.Hour)
+	app.client = newETagTestClient(t, server.URL)
+	app.currentUser = &github.User{Login: github.String("me")}
+	app.pendingReviews = newPendingReviewCache()
+	return app, &requests
+}
+
+func involvedPR() PR {
+	return PR{
+		Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7", Title: "Add retries",
+		UpdatedAt: time.Now().Add(-time.Hour), MyReviewState: "commented", ActionReason: "waiting on others",
+	}
+}
+
+func TestMarkPendingReviews(t *testing.T) {
+	pending := map[string]any{"id": 1, "state": "PENDING", "user": map[string]any{"login": "me"}}
+	submitted := map[string]any{"id": 2, "state": "COMMENTED", "user": map[string]any{"login": "me"}}
+	tests := []struct {
+		name    string
+		reviews []map[string]any
+		want    bool
+	}{
+		{name: "pending", reviews: []map[string]any{submitted, pending}, want: true},
+		{name: "none pending", reviews: []map[string]any{submitted}},
+		{name: "someone else's pending", reviews: []map[string]any{{"id": 3, "state": "PENDING", "user": map[string]any{"login": "carol"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, requests := newPendingReviewTestApp(t, tt.reviews)
+			prs := []PR{involvedPR()}
+			for range 2 {
+				app.markPendingReviews(context.Background(), prs, "me")
+			}
+			if got := requests.Load(); got != 1 {
+				t.Errorf("reviews fetched %d times, want 1 (cached)", got)
+			}
+			pr := prs[0]
+			if got := !pr.PendingReviewAt.IsZero(); got != tt.want {
+				t.Fatalf("pending = %v, want %v", got, tt.want)
+			}
+			if tt.want {
+				if !pr.IsBlocked || !pr.NeedsReview || pr.ActionKind != actionSubmitReview {
+					t.Errorf("pending review PR = %+v, want blocked with submit_review", pr)
+				}
+				if got := pendingReviewSuffix(&pr); got != " 📝 unsubmitted review" {
+					t.Errorf("pendingReviewSuffix() = %q", got)
+				}
+			} else if pr.IsBlocked || pr.ActionKind != actionNone {
+				t.Errorf("PR without a pending review was changed: %+v", pr)
+			}
+		})
+	}
+}
+
+func TestMarkPendingReviewsSkipsOtherUsers(t *testing.T) {
+	app, requests := newPendingReviewTestApp(t, nil)
+	prs := []PR{involvedPR()}
+	// -user shows someone else's queue; their pending reviews aren't visible to me
+	app.markPendingReviews(context.Background(), prs, "carol")
+	// Team mode is about teammates' queues, not my reviews
+	app.team = []string{"carol"}
+	app.markPendingReviews(context.Background(), prs, "me")
+	// PRs I haven't been involved with, or that went quiet long ago, aren't checked
+	app.team = nil
+	uninvolved := PR{Repository: "acme/widgets", Number: 7, URL: "https://github.com/acme/widgets/pull/7", UpdatedAt: time.Now()}
+	stale := involvedPR()
+	stale.UpdatedAt = time.Now().Add(-30 * 24 * time.Hour)
+	app.markPendingReviews(context.Background(), []PR{uninvolved, stale}, "me")
+	if got := requests.Load(); got != 0 {
+		t.Errorf("reviews fetched %d times, want none", got)
+	}
+}
+
+func TestPendingReviewReminder(t *testing.T) {
+	app, requests := newPendingReviewTestApp(t, []map[string]any{{"id": 1, "state": "PENDING", "user": map[string]any{"login": "me"}}})
+	notifier := app.notifier.(*messageNotifier)
+	now := time.Now()
+	app.pendingReviews.now = func() time.Time { return now }
+
+	prs := []PR{involvedPR()}
+	app.markPendingReviews(context.Background(), prs, "me")
+	app.incoming = prs
+	app.remindPendingReviews(app.snapshotPRs())
+	if notes := waitForNotes(notifier, 0); len(notes) != 0 {
+		t.Fatalf("reminded before the review was pending an hour: %q", notes)
+	}
+
+	// Still pending an hour later: one reminder, however often it's checked
+	now = now.Add(pendingReviewReminderAfter + time.Minute)
+	app.markPendingReviews(context.Background(), prs, "me")
+	app.incoming = prs
+	for range 2 {
+		app.remindPendingReviews(app.snapshotPRs())
+	}
+	if notes := waitForNotes(notifier, 1); len(notes) != 1 {
+		t.Errorf("reminders = %q, want 1", notes)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("reviews fetched %d times, want 2 (one per cache TTL)", got)
+	}
+	if since := prs[0].PendingReviewAt; now.Sub(since) < pendingReviewReminderAfter {
+		t.Errorf("pending since %v was reset by the re-check", since)
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// A pinned PR is one I want to keep an eye on for days, like a release blocker that
+// isn't waiting on me. Pinned PRs are repeated in a "📌 Pinned" section at the top of
+// the menu. The section is display-only: the PR still counts, and is listed, in its
+// own section. A pin lapses once the PR closes.
+
+// pinnedFileName persists pinned PRs in the cache directory.
+const pinnedFileName = "pinned_prs.json"
+
+// pin is a PR I pinned to the top of the menu.
+type pin struct {
+	PinnedAt   time.Time `json:"pinned_at"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+}
+
+// LoadPins restores the pins saved at path and saves later changes there. A missing or
+// unreadable file starts empty.
+func (m *PRStateManager) LoadPins(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pinnedPath = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("[STATE] Failed to read pinned PRs", "path", path, "error", err)
+		}
+		return
+	}
+	var pinned map[string]pin
+	if err := json.Unmarshal(data, &pinned); err != nil {
+		slog.Warn("[STATE] Ignoring unreadable pinned PRs", "path", path, "error", err)
+		return
+	}
+	maps.Copy(m.pinned, pinned)
+	if len(m.pinned) > 0 {
+		slog.Info("[STATE] Restored pinned PRs", "count", len(m.pinned))
+	}
+}
+
+// Pin keeps pr at the top of the menu until it closes or is unpinned.
+func (m *PRStateManager) Pin(pr *PR) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.pinned[pr.URL]; ok {
+		return
+	}
+	m.pinned[pr.URL] = pin{Repository: pr.Repository, Number: pr.Number, PinnedAt: m.now()}
+	slog.Info("[STATE] PR pinned", "repo", pr.Repository, "number", pr.Number)
+	m.savePinsLocked()
+}
+
+// Unpin removes the pin on a PR, if any.
+func (m *PRStateManager) Unpin(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.pinned[url]; !ok {
+		return
+	}
+	delete(m.pinned, url)
+	slog.Info("[STATE] PR unpinned", "url", url)
+	m.savePinsLocked()
+}
+
+// Pinned reports whether a PR is pinned.
+func (m *PRStateManager) Pinned(url string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.pinned[url]
+	return ok
+}
+
+// PinnedURLs returns the pinned PR URLs, oldest pin first.
+func (m *PRStateManager) PinnedURLs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	urls := slices.Collect(maps.Keys(m.pinned))
+	slices.SortFunc(urls, func(a, b string) int {
+		if c := m.pinned[a].PinnedAt.Compare(m.pinned[b].PinnedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return urls
+}
+
+// ReconcilePins drops pins on PRs missing from a complete fetch, which have closed.
+func (m *PRStateManager) ReconcilePins(incoming, outgoing []PR, complete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.pinned) == 0 || !complete {
+		return
+	}
+	open := make(map[string]bool, len(incoming)+len(outgoing))
+	for _, list := range [][]PR{incoming, outgoing} {
+		for i := range list {
+			open[list[i].URL] = true
+		}
+	}
+	before := len(m.pinned)
+	for url, p := range maps.Clone(m.pinned) {
+		if !open[url] {
+			slog.Info("[STATE] Pinned PR closed, unpinning", "repo", p.Repository, "number", p.Number)
+			delete(m.pinned, url)
+		}
+	}
+	if len(m.pinned) != before {
+		m.savePinsLocked()
+	}
+}
+
+// savePinsLocked persists the pins, if LoadPins set a path. The caller holds m.mu.
+func (m *PRStateManager) savePinsLocked() {
+	if m.pinnedPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(m.pinned, "", "  ")
+	if err != nil {
+		slog.Warn("[STATE] Failed to marshal pinned PRs", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(m.pinnedPath), 0o700); err != nil {
+		slog.Warn("[STATE] Failed to create pinned PR directory", "error", err)
+		return
+	}
+	if err := os.WriteFile(m.pinnedPath, data, 0o600); err != nil {
+		slog.Warn("[STATE] Failed to save pinned PRs", "path", m.pinnedPath, "error", err)
+	}
+}
+
+// FlushPins writes the pins to disk, for shutdown.
+func (m *PRStateManager) FlushPins() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.savePinsLocked()
+}
+
+// pinnedRow is a pinned PR as shown, with the section it belongs to.
+type pinnedRow struct {
+	section string
+	pr      PR
+}
+
+// pinnedRows returns the view's shown PRs that are pinned, oldest pin first.
+func (app *App) pinnedRows(view *prView) []pinnedRow {
+	if app.stateManager == nil {
+		return nil
+	}
+	urls := app.stateManager.PinnedURLs()
+	if len(urls) == 0 {
+		return nil
+	}
+	var rows []pinnedRow
+	for _, url := range urls {
+		same := func(pr PR) bool { return pr.URL == url }
+		if i := slices.IndexFunc(view.incoming, same); i >= 0 {
+			rows = append(rows, pinnedRow{section: "Incoming", pr: view.incoming[i]})
+		} else if i := slices.IndexFunc(view.outgoing, same); i >= 0 {
+			rows = append(rows, pinnedRow{section: "Outgoing", pr: view.outgoing[i]})
+		}
+	}
+	return rows
+}
+
+// pinnedTitles lists the pinned section for change detection.
+func (app *App) pinnedTitles(view *prView) []string {
+	rows := app.pinnedRows(view)
+	if len(rows) == 0 {
+		return nil
+	}
+	displayMode, labelWidth := app.menuLabelSettings()
+	highlight := app.highlightWindow()
+	titles := []string{msg("pinned.header")}
+	for i := range rows {
+		titles = append(titles, app.prRowTitle(&rows[i].pr, rows[i].section, displayMode, labelWidth, highlight))
+	}
+	return titles
+}
+
+// addPinnedSection adds the pinned PRs above the incoming section. Nothing here counts
+// toward a section header or the tray title.
+func (app *App) addPinnedSection(ctx context.Context, view *prView) {
+	rows := app.pinnedRows(view)
+	if len(rows) == 0 {
+		return
+	}
+	app.systrayInterface.AddMenuItem(msg("pinned.header"), "").Disable()
+	displayMode, labelWidth := app.menuLabelSettings()
+	highlight := app.highlightWindow()
+	for i := range rows {
+		pr := &rows[i].pr
+		item := app.systrayInterface.AddMenuItem(
+			app.prRowTitle(pr, rows[i].section, displayMode, labelWidth, highlight),
+			formatMenuTooltip(*pr, displayMode, prAge(pr.UpdatedAt)))
+		url := prLink(pr)
+		item.Click(func() {
+			if err := app.openBrowser(ctx, url, ""); err != nil {
+				slog.Error("failed to open url", "error", err)
+				return
+			}
+			app.resumeAutoOpen(ctx)
+		})
+		app.addPRActions(ctx, item, pr, url)
+		app.addPinAction(ctx, item, pr)
+	}
+	app.systrayInterface.AddSeparator()
+}
+
+// addPinAction adds "Pin to top", or "Unpin" on a pinned PR, to a PR's submenu.
+func (app *App) addPinAction(ctx context.Context, item MenuItem, pr *PR) {
+	if app.stateManager == nil {
+		return
+	}
+	p := *pr
+	if app.stateManager.Pinned(p.URL) {
+		item.AddSubMenuItem(msg("pr.unpin"), "").Click(func() {
+			app.stateManager.Unpin(p.URL)
+			app.rebuildMenu(ctx)
+		})
+		return
+	}
+	item.AddSubMenuItem(msg("pr.pin"), msg("pr.pin.tooltip")).Click(func() {
+		app.stateManager.Pin(&p)
+		app.rebuildMenu(ctx)
+	})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func pinnablePR(n int, blocked bool) PR {
+	return PR{
+		Repository: "acme/widgets", Number: n, URL: "https://github.com/acme/widgets/pull/" + strconv.Itoa(n),
+		Title: "Release blocker", NeedsReview: blocked, UpdatedAt: time.Now(),
+	}
+}
+
+func TestPinPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), pinnedFileName)
+	m := NewPRStateManager(time.Now())
+	m.LoadPins(path)
+	first, second := pinnablePR(1, false), pinnablePR(2, true)
+	m.Pin(&first)
+	m.Pin(&second)
+
+	restarted := NewPRStateManager(time.Now())
+	restarted.LoadPins(path)
+	if got, want := restarted.PinnedURLs(), []string{first.URL, second.URL}; !slices.Equal(got, want) {
+		t.Fatalf("pins after restart = %v, want %v", got, want)
+	}
+
+	restarted.Unpin(first.URL)
+	again := NewPRStateManager(time.Now())
+	again.LoadPins(path)
+	if got := again.PinnedURLs(); !slices.Equal(got, []string{second.URL}) {
+		t.Errorf("pins after unpinning and restarting = %v, want only %s", got, second.URL)
+	}
+}
+
+func TestPinLapsesWhenPRCloses(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	app.hasPerformedInitialDiscovery = true
+	pr := pinnablePR(1, false)
+	app.stateManager.Pin(&pr)
+
+	// Missing from a partial fetch isn't closed
+	app.partialFetch = &PartialError{Queries: []string{"q"}, Total: 2}
+	app.processNotifications(ctx)
+	if !app.stateManager.Pinned(pr.URL) {
+		t.Fatal("a partial fetch shouldn't unpin")
+	}
+
+	app.partialFetch = nil
+	app.incoming = []PR{pr}
+	app.processNotifications(ctx)
+	if !app.stateManager.Pinned(pr.URL) {
+		t.Fatal("an open PR shouldn't be unpinned")
+	}
+
+	app.incoming = nil
+	app.processNotifications(ctx)
+	if app.stateManager.Pinned(pr.URL) {
+		t.Error("expected the pin to lapse once the PR closed")
+	}
+}
+
+func TestPinnedSectionIsNotCounted(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	blocked, other := pinnablePR(1, true), pinnablePR(2, false)
+	app.incoming = []PR{blocked, other}
+	before := app.countPRs()
+
+	item := &MockMenuItem{title: "row"}
+	app.addPinAction(ctx, item, &blocked)
+	prActionItem(t, item, "📌 Pin to top").clickHandler()
+	if !app.stateManager.Pinned(blocked.URL) {
+		t.Fatal("Pin action didn't pin the PR")
+	}
+
+	if after := app.countPRs(); after != before {
+		t.Errorf("counts with a pin = %+v, want %+v", after, before)
+	}
+	titles := app.generateMenuTitles()
+	header := slices.Index(titles, "📌 Pinned")
+	incoming := slices.Index(titles, msg("menu.incoming_prs"))
+	if header < 0 || incoming < header {
+		t.Fatalf("menu titles %q: want the pinned section above Incoming", titles)
+	}
+	pinnedRow := titles[header+1]
+	if !strings.Contains(pinnedRow, "widgets") || strings.HasPrefix(pinnedRow, "• ") || pinnedRow == formatMenuLabel(blocked, app.displayMode, app.menuLabelWidth) {
+		t.Errorf("pinned row = %q, want the blocked PR with its blocked prefix", pinnedRow)
+	}
+	if n := strings.Count(strings.Join(titles, "\n"), pinnedRow); n != 2 {
+		t.Errorf("pinned PR listed %d times, want in the pinned section and its own section", n)
+	}
+
+	// assertMenuCounts is on in tests, so a pinned row counted in a header would panic
+	app.rebuildMenu(ctx)
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("expected a MockSystray")
+	}
+	row := topMenuItem(mock, pinnedRow)
+	if row == nil {
+		t.Fatal("pinned row missing from the menu")
+	}
+	prActionItem(t, row, "Unpin").clickHandler()
+	if app.stateManager.Pinned(blocked.URL) {
+		t.Error("Unpin action didn't unpin the PR")
+	}
+	if slices.Contains(app.generateMenuTitles(), "📌 Pinned") {
+		t.Error("the pinned section should disappear once nothing is pinned")
+	}
+}
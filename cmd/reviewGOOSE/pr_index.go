@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// prKey identifies a PR however its URL is spelled: GitHub owner and repository names
+// are case-insensitive, and event URLs can carry a query, fragment, or /files suffix.
+type prKey struct {
+	repo   string // "owner/repo", lowercased
+	raw    string // The URL as given, when it isn't a github.com PR URL
+	number int
+}
+
+// prKeyOf returns the key for a PR URL.
+func prKeyOf(url string) prKey {
+	rest, ok := strings.CutPrefix(url, "https://github.com/")
+	if ok {
+		if i := strings.IndexAny(rest, "?#"); i >= 0 {
+			rest = rest[:i]
+		}
+		parts := strings.SplitN(rest, "/", 5)
+		if len(parts) >= 4 && parts[2] == "pull" {
+			if n, err := strconv.Atoi(parts[3]); err == nil && n > 0 {
+				return prKey{repo: strings.ToLower(parts[0] + "/" + parts[1]), number: n}
+			}
+		}
+	}
+	return prKey{raw: url}
+}
+
+// prIndex finds PRs in one or more lists by key. It points into the lists, so patching
+// through it updates them in place; it's stale once a list is reallocated.
+type prIndex map[prKey]*PR
+
+// indexPRs indexes lists. A PR listed twice resolves to its first occurrence.
+func indexPRs(lists ...[]PR) prIndex {
+	n := 0
+	for _, list := range lists {
+		n += len(list)
+	}
+	ix := make(prIndex, n)
+	for _, list := range lists {
+		for i := range list {
+			key := prKeyOf(list[i].URL)
+			if _, dup := ix[key]; !dup {
+				ix[key] = &list[i]
+			}
+		}
+	}
+	return ix
+}
+
+// get returns the PR with url, or nil.
+func (ix prIndex) get(url string) *PR {
+	return ix[prKeyOf(url)]
+}
+
+// findPR returns the first PR with url in lists, or nil. It's for a single lookup, such
+// as for one event, where building an index would cost more than the scan.
+func findPR(url string, lists ...[]PR) *PR {
+	key := prKeyOf(url)
+	for _, list := range lists {
+		for i := range list {
+			if prKeyOf(list[i].URL) == key {
+				return &list[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+)
+
+func TestPRKeyOf(t *testing.T) {
+	want := prKeyOf("https://github.com/acme/widgets/pull/7")
+	for _, url := range []string{
+		"https://github.com/ACME/Widgets/pull/7",
+		"https://github.com/acme/widgets/pull/7/files",
+		"https://github.com/acme/widgets/pull/7?notification_referrer_id=abc",
+		"https://github.com/acme/widgets/pull/7#discussion_r1",
+	} {
+		if got := prKeyOf(url); got != want {
+			t.Errorf("prKeyOf(%q) = %+v, want %+v", url, got, want)
+		}
+	}
+	if prKeyOf("https://github.com/acme/widgets/pull/8") == want {
+		t.Error("different PR numbers should not share a key")
+	}
+	if prKeyOf("https://github.com/acme/widgets/issues/7") == want {
+		t.Error("an issue URL should not match the PR")
+	}
+	other := "https://ghe.example.com/acme/widgets/pull/7"
+	if got := prKeyOf(other); got != (prKey{raw: other}) {
+		t.Errorf("prKeyOf(%q) = %+v, want the raw URL", other, got)
+	}
+}
+
+func TestApplyTurnResultsPatchesInPlace(t *testing.T) {
+	incoming := []PR{
+		{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1"},
+		{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2"},
+	}
+	outgoing := []PR{{Repository: "acme/widgets", Number: 3, URL: "https://github.com/acme/widgets/pull/3"}}
+	results := []prResult{
+		{url: "https://github.com/Acme/Widgets/pull/2", turnData: reviewTurnData("")},
+		{url: "https://github.com/acme/widgets/pull/3", turnData: reviewTurnData(""), isOwner: true},
+		{url: "https://github.com/acme/widgets/pull/1", turnData: reviewTurnData(""), isOwner: true}, // Not mine
+		{url: "https://github.com/acme/widgets/pull/9", turnData: reviewTurnData("")},
+	}
+
+	if got := applyTurnResults(results, "me", incoming, outgoing); got != 2 {
+		t.Errorf("applyTurnResults patched %d PRs, want 2", got)
+	}
+	if incoming[0].IsBlocked || !incoming[1].IsBlocked || !outgoing[0].IsBlocked {
+		t.Errorf("want only incoming #2 and outgoing #3 blocked: %+v %+v", incoming, outgoing)
+	}
+	if findPR("https://github.com/acme/WIDGETS/pull/3/files", incoming, outgoing) != &outgoing[0] {
+		t.Error("findPR should find the listed PR however its URL is spelled")
+	}
+}
+
+// benchmarkPRs returns n incoming PRs and a Turn result for each, in reverse order so
+// a scan finds each PR as late as possible.
+func benchmarkPRs(n int) ([]PR, []prResult) {
+	prs := make([]PR, n)
+	results := make([]prResult, n)
+	data := reviewTurnData(prx.ReviewStatePending)
+	for i := range prs {
+		url := "https://github.com/acme/widgets/pull/" + strconv.Itoa(i+1)
+		prs[i] = PR{Repository: "acme/widgets", Number: i + 1, URL: url}
+		results[n-1-i] = prResult{url: url, turnData: data}
+	}
+	return prs, results
+}
+
+// applyTurnResultsByScan is the former patch loop, which scanned the list per
+// result, kept as the benchmark baseline.
+func applyTurnResultsByScan(results []prResult, user string, incoming []PR) int {
+	applied := 0
+	appliedAt := time.Now()
+	for i := range results {
+		for j := range incoming {
+			if incoming[j].URL == results[i].url {
+				applyTurnResult(&incoming[j], &results[i], user, appliedAt)
+				applied++
+				break
+			}
+		}
+	}
+	return applied
+}
+
+func BenchmarkApplyTurnResults(b *testing.B) {
+	for _, n := range []int{200, 1000} {
+		prs, results := benchmarkPRs(n)
+		b.Run(strconv.Itoa(n)+"/index", func(b *testing.B) {
+			for range b.N {
+				applyTurnResults(results, "me", prs, nil)
+			}
+		})
+		b.Run(strconv.Itoa(n)+"/scan", func(b *testing.B) {
+			for range b.N {
+				applyTurnResultsByScan(results, "me", prs)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// trackedCache is per-PR state the janitor can prune.
+type trackedCache interface {
+	tracked() map[string]time.Time
+	forget(urls []string)
+}
+
+// prRevisionEntry is one PR revision's lookup.
+type prRevisionEntry[T any] struct {
+	updatedAt time.Time
+	value     T
+	found     bool
+}
+
+// prRevisionCache remembers a per-PR GitHub lookup by PR URL, reusing it until the PR's
+// UpdatedAt moves. Failed lookups are cached as not found, so a repo that can't be read
+// isn't retried every cycle.
+type prRevisionCache[T any] struct {
+	entries map[string]prRevisionEntry[T]
+	mu      sync.Mutex
+}
+
+func newPRRevisionCache[T any]() *prRevisionCache[T] {
+	return &prRevisionCache[T]{entries: make(map[string]prRevisionEntry[T])}
+}
+
+// get returns the lookup for the PR at updatedAt; ok is false when there's none.
+func (c *prRevisionCache[T]) get(url string, updatedAt time.Time) (value T, found, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[url]
+	if !exists || !entry.updatedAt.Equal(updatedAt) {
+		var zero T
+		return zero, false, false
+	}
+	return entry.value, entry.found, true
+}
+
+func (c *prRevisionCache[T]) put(url string, updatedAt time.Time, value T, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[url] = prRevisionEntry[T]{updatedAt: updatedAt, value: value, found: found}
+}
+
+// tracked returns the cached PRs, by URL, with the UpdatedAt each was looked up at.
+func (c *prRevisionCache[T]) tracked() map[string]time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tracked := make(map[string]time.Time, len(c.entries))
+	for url, entry := range c.entries {
+		tracked[url] = entry.updatedAt
+	}
+	return tracked
+}
+
+// forget drops the lookups for urls.
+func (c *prRevisionCache[T]) forget(urls []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, url := range urls {
+		delete(c.entries, url)
+	}
+}
+
+// cacheableFailure reports whether a failed lookup should be cached. One abandoned with
+// its cycle, by shutdown or the cycle timeout, says nothing about the PR.
+func cacheableFailure(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
@@ -13,35 +13,91 @@ type PRState struct {
 	FirstBlockedAt     time.Time
 	LastSeenBlocked    time.Time
 	PR                 PR
+	NotifiedEpisode    time.Time // FirstBlockedAt of the block episode a notification was sent for
 	HasNotified        bool
 	IsInitialDiscovery bool // True if this PR was discovered as already blocked during startup
 }
 
 // PRStateManager manages all PR states with proper synchronization.
 type PRStateManager struct {
-	startTime   time.Time
-	states      map[string]*PRState
-	gracePeriod time.Duration
-	mu          sync.RWMutex
+	startTime     time.Time
+	states        map[string]*PRState
+	runningSince  map[string]time.Time    // When each PR's tests started continuously reporting "running"
+	cleared       map[string]clearedPR    // Incoming PRs that recently left the blocked state
+	clearedLog    []clearedPR             // Every clear within clearedHistoryWindow, oldest first
+	testWatches   map[string]testWatch    // PRs to notify about once their tests finish, by URL
+	dismissed     map[string]dismissal    // PRs I marked "Not my review", by URL
+	pinned        map[string]pin          // PRs pinned to the top of the menu, by URL
+	hiddenPRs     map[string]hiddenPR     // PRs I hid forever, by URL
+	comments      map[string]commentCount // Comment counts from the searches, by responseKey
+	now           func() time.Time
+	testWatchPath string
+	dismissedPath string
+	pinnedPath    string
+	hiddenPRsPath string
+	gracePeriod   time.Duration
+	mu            sync.RWMutex
 }
 
 // NewPRStateManager creates a new PR state manager.
 func NewPRStateManager(startTime time.Time) *PRStateManager {
 	return &PRStateManager{
-		states:      make(map[string]*PRState),
-		startTime:   startTime,
-		gracePeriod: 30 * time.Second,
+		states:       make(map[string]*PRState),
+		runningSince: make(map[string]time.Time),
+		cleared:      make(map[string]clearedPR),
+		testWatches:  make(map[string]testWatch),
+		dismissed:    make(map[string]dismissal),
+		pinned:       make(map[string]pin),
+		hiddenPRs:    make(map[string]hiddenPR),
+		comments:     make(map[string]commentCount),
+		now:          time.Now,
+		startTime:    startTime,
+		gracePeriod:  30 * time.Second,
 	}
 }
 
+// stateTransition is a PR entering or leaving the blocked state.
+type stateTransition struct {
+	PR       PR
+	incoming bool
+	gone     bool // Left the lists, or was hidden, rather than unblocked
+}
+
+// stateChanges is what reconciling a poll's PRs changed. Notifications, hook events,
+// "Recently cleared", and the change journal are all driven from it.
+type stateChanges struct {
+	notify    []PR // Blocked PRs to notify for now
+	blocked   []stateTransition
+	unblocked []stateTransition
+}
+
+// journal returns the transitions as change journal entries.
+func (c *stateChanges) journal() *ChangeSet {
+	var cs ChangeSet
+	for i := range c.blocked {
+		cs.BlockedTransitions = append(cs.BlockedTransitions, refOf(&c.blocked[i].PR))
+	}
+	for i := range c.unblocked {
+		cs.UnblockedTransitions = append(cs.UnblockedTransitions, refOf(&c.unblocked[i].PR))
+	}
+	return &cs
+}
+
 // UpdatePRs updates the state with new PR data and returns which PRs need notifications.
-// This function is thread-safe and handles all state transitions atomically.
 // isInitialDiscovery should be true only on the very first poll to prevent notifications for already-blocked PRs.
 func (m *PRStateManager) UpdatePRs(incoming, outgoing []PR, hiddenOrgs map[string]bool, isInitialDiscovery bool) (toNotify []PR) {
+	return m.Reconcile(incoming, outgoing, hiddenOrgs, isInitialDiscovery, true).notify
+}
+
+// Reconcile updates the state with new PR data and returns the transitions it found,
+// with the PRs that need notifications. This function is thread-safe and handles all
+// state transitions atomically. After a partial fetch (complete false) PRs missing from
+// the lists may just be in a failed search, so they keep their state.
+func (m *PRStateManager) Reconcile(incoming, outgoing []PR, hiddenOrgs map[string]bool, isInitialDiscovery, complete bool) (changes stateChanges) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	now := time.Now()
+	now := m.now()
 	inGracePeriod := time.Since(m.startTime) < m.gracePeriod
 
 	slog.Debug("[STATE] UpdatePRs called",
@@ -56,15 +112,20 @@ func (m *PRStateManager) UpdatePRs(incoming, outgoing []PR, hiddenOrgs map[strin
 
 	for i := range allPRs {
 		pr := allPRs[i]
-		// Skip hidden orgs
 		org := extractOrgFromRepo(pr.Repository)
-		if org != "" && hiddenOrgs[org] {
+		_, hiddenPR := m.hiddenPRs[pr.URL]
+		row := notifyRow{
+			hidden:  org != "" && hiddenOrgs[org] || hiddenPR,
+			snoozed: pr.Snoozed,
+			blocked: pr.NeedsReview || pr.IsBlocked,
+			grace:   inGracePeriod,
+		}
+		// Hidden orgs and PRs aren't tracked at all
+		if row.hidden {
 			continue
 		}
 
-		// Check if PR is blocked
-		blocked := pr.NeedsReview || pr.IsBlocked
-		if !blocked {
+		if !row.blocked {
 			// PR is not blocked - remove from tracking if it was
 			if st, ok := m.states[pr.URL]; ok {
 				slog.Info("[STATE] State transition: blocked -> unblocked",
@@ -72,91 +133,74 @@ func (m *PRStateManager) UpdatePRs(incoming, outgoing []PR, hiddenOrgs map[strin
 					"was_blocked_since", st.FirstBlockedAt.Format(time.RFC3339),
 					"blocked_duration", time.Since(st.FirstBlockedAt).Round(time.Second))
 				delete(m.states, pr.URL)
+				changes.unblocked = append(changes.unblocked, stateTransition{PR: pr, incoming: i < len(incoming)})
 			}
 			continue
 		}
 
 		currentlyBlocked[pr.URL] = true
+		delete(m.cleared, pr.URL)
 
 		// Get or create state for this PR
 		state, exists := m.states[pr.URL]
-		if !exists {
-			// This PR was not in our state before
-			if isInitialDiscovery {
-				// Initial discovery: PR was already blocked when we started, no state transition
-				state = &PRState{
-					PR:                 pr,
-					FirstBlockedAt:     now,
-					LastSeenBlocked:    now,
-					HasNotified:        false, // Don't consider this as notified since no actual notification was sent
-					IsInitialDiscovery: true,  // Mark as initial discovery to prevent notifications and party poppers
-				}
-				m.states[pr.URL] = state
-
-				slog.Info("[STATE] Initial discovery: already blocked PR",
-					"repo", pr.Repository,
-					"number", pr.Number,
-					"url", pr.URL,
-					"pr_updated_at", pr.UpdatedAt.Format(time.RFC3339),
-					"firstBlockedAt", state.FirstBlockedAt.Format(time.RFC3339))
-			} else {
-				// Actual state transition: unblocked -> blocked
-				state = &PRState{
-					PR:                 pr,
-					FirstBlockedAt:     now,
-					LastSeenBlocked:    now,
-					HasNotified:        false,
-					IsInitialDiscovery: false, // This is a real state transition
-				}
-				m.states[pr.URL] = state
-
-				slog.Info("[STATE] State transition: unblocked -> blocked",
-					"repo", pr.Repository,
-					"number", pr.Number,
-					"url", pr.URL,
-					"pr_updated_at", pr.UpdatedAt.Format(time.RFC3339),
-					"firstBlockedAt", state.FirstBlockedAt.Format(time.RFC3339),
-					"inGracePeriod", inGracePeriod)
-
-				// Should we notify for actual state transitions?
-				if !inGracePeriod && !state.HasNotified {
-					if isPRFreshEnoughForNotification(&pr, time.Since(m.startTime), nil) {
-						slog.Debug("[STATE] Will notify for newly blocked PR", "repo", pr.Repository, "number", pr.Number)
-						toNotify = append(toNotify, pr)
-						state.HasNotified = true
-					}
-				} else if inGracePeriod {
-					slog.Debug("[STATE] In grace period, not notifying", "repo", pr.Repository, "number", pr.Number)
-				}
-			}
-		} else {
+		var prev *PRState
+		switch {
+		case exists:
 			// PR was already blocked in our state - update data, preserve FirstBlockedAt
+			prev = state
 			state.LastSeenBlocked = now
 			state.PR = pr
-
 			slog.Debug("[STATE] State transition: blocked -> blocked (no change)",
 				"repo", pr.Repository, "number", pr.Number, "url", pr.URL,
 				"original_first_blocked", state.FirstBlockedAt.Format(time.RFC3339),
 				"time_since_first_blocked", time.Since(state.FirstBlockedAt).Round(time.Second),
 				"has_notified", state.HasNotified)
+		case isInitialDiscovery:
+			// Initial discovery: PR was already blocked when we started, no state transition.
+			// It's marked so it never notifies or gets a party popper for this block.
+			state = &PRState{PR: pr, FirstBlockedAt: now, LastSeenBlocked: now, IsInitialDiscovery: true}
+			m.states[pr.URL] = state
+			slog.Info("[STATE] Initial discovery: already blocked PR",
+				"repo", pr.Repository,
+				"number", pr.Number,
+				"url", pr.URL,
+				"pr_updated_at", pr.UpdatedAt.Format(time.RFC3339),
+				"firstBlockedAt", state.FirstBlockedAt.Format(time.RFC3339))
+		default:
+			// Actual state transition: unblocked -> blocked
+			state = &PRState{PR: pr, FirstBlockedAt: now, LastSeenBlocked: now}
+			m.states[pr.URL] = state
+			changes.blocked = append(changes.blocked, stateTransition{PR: pr, incoming: i < len(incoming)})
+			slog.Info("[STATE] State transition: unblocked -> blocked",
+				"repo", pr.Repository,
+				"number", pr.Number,
+				"url", pr.URL,
+				"pr_updated_at", pr.UpdatedAt.Format(time.RFC3339),
+				"firstBlockedAt", state.FirstBlockedAt.Format(time.RFC3339),
+				"inGracePeriod", inGracePeriod)
+		}
 
-			// If we haven't notified yet and we're past grace period, notify now
-			// But don't notify for initial discovery PRs
-			if !state.HasNotified && !inGracePeriod && !state.IsInitialDiscovery {
-				if isPRFreshEnoughForNotification(&pr, time.Since(m.startTime), state) {
-					slog.Info("[STATE] Past grace period, notifying for previously blocked PR",
-						"repo", pr.Repository, "number", pr.Number)
-					toNotify = append(toNotify, pr)
-					state.HasNotified = true
-				}
-			}
+		row.wasBlocked, row.initial, row.notified = exists, state.IsInitialDiscovery, state.HasNotified
+		stale := func() bool { return !isPRFreshEnoughForNotification(&pr, time.Since(m.startTime), prev) }
+		switch decideNotify(row, stale) {
+		case notifyAlert:
+			slog.Info("[STATE] Will notify for blocked PR",
+				"repo", pr.Repository, "number", pr.Number, "deferred", exists)
+			changes.notify = append(changes.notify, pr)
+			state.HasNotified = true
+		case notifyHeld:
+			slog.Debug("[STATE] Holding notification", "repo", pr.Repository, "number", pr.Number, "in_grace_period", inGracePeriod)
+		case notifyNone:
 		}
 	}
 
-	// Clean up states for PRs that are no longer in our lists
+	// Clean up states for PRs that are no longer in our lists. After a partial fetch only
+	// listed PRs, hidden this poll, are dropped: the rest may be in the failed search.
 	removed := 0
+	listedIncoming := indexPRs(incoming)
+	listed := indexPRs(incoming, outgoing)
 	for url, st := range m.states {
-		if !currentlyBlocked[url] {
+		if !currentlyBlocked[url] && (complete || listed.get(url) != nil) {
 			slog.Info("[STATE] Removing stale PR state (no longer blocked)",
 				"url", url, "repo", st.PR.Repository, "number", st.PR.Number,
 				"first_blocked_at", st.FirstBlockedAt.Format(time.RFC3339),
@@ -165,14 +209,23 @@ func (m *PRStateManager) UpdatePRs(incoming, outgoing []PR, hiddenOrgs map[strin
 				"was_notified", st.HasNotified)
 			delete(m.states, url)
 			removed++
+			changes.unblocked = append(changes.unblocked, stateTransition{PR: st.PR, incoming: listedIncoming.get(url) != nil, gone: true})
 		}
 	}
 
 	if removed > 0 {
 		slog.Info("[STATE] State cleanup completed", "removed_states", removed, "remaining_states", len(m.states))
 	}
+	// Incoming PRs that were unblocked, not dismissed, snoozed, or closed, are "Recently cleared"
+	for i := range changes.unblocked {
+		t := &changes.unblocked[i]
+		if t.incoming && !t.gone && !t.PR.Dismissed && !t.PR.Snoozed {
+			m.recordCleared(t.PR, now)
+		}
+	}
+	m.pruneCleared(now)
 
-	return toNotify
+	return changes
 }
 
 // BlockedPRs returns all currently blocked PRs with their states.
@@ -194,6 +247,47 @@ func (m *PRStateManager) PRState(url string) (*PRState, bool) {
 	return state, exists
 }
 
+// TrackBlocked records that pr is blocked, as seen by a real-time event, and returns
+// the current block episode: the FirstBlockedAt of its existing state, or now when the
+// event is the first to see it blocked. Events that start an episode count as
+// notified, so the next poll doesn't offer the PR for another notification.
+func (m *PRStateManager) TrackBlocked(pr PR) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state, ok := m.states[pr.URL]; ok {
+		return state.FirstBlockedAt
+	}
+	now := m.now()
+	m.states[pr.URL] = &PRState{PR: pr, FirstBlockedAt: now, LastSeenBlocked: now, HasNotified: true}
+	delete(m.cleared, pr.URL)
+	slog.Info("[STATE] State transition: unblocked -> blocked (real-time event)",
+		"repo", pr.Repository, "number", pr.Number, "url", pr.URL)
+	return now
+}
+
+// MarkNotified is the single gate for user-facing notifications. It reports whether
+// the caller should notify for the block episode starting at episodeID, recording that
+// it has. It returns false once the episode has notified, or if it is no longer the
+// PR's current episode.
+func (m *PRStateManager) MarkNotified(url string, episodeID time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[url]
+	if !ok || !state.FirstBlockedAt.Equal(episodeID) {
+		slog.Debug("[STATE] Not notifying for stale block episode", "url", url, "episode", episodeID.Format(time.RFC3339))
+		return false
+	}
+	if state.NotifiedEpisode.Equal(episodeID) {
+		slog.Info("[STATE] Block episode already notified, skipping duplicate",
+			"url", url, "episode", episodeID.Format(time.RFC3339))
+		return false
+	}
+	state.NotifiedEpisode = episodeID
+	return true
+}
+
 // ResetNotifications resets the notification flag for all PRs (useful for testing).
 func (m *PRStateManager) ResetNotifications() {
 	m.mu.Lock()
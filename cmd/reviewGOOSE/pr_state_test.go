@@ -297,3 +297,32 @@ func TestPRStateTransitions(t *testing.T) {
 		t.Error("Expected new state to be marked as notified")
 	}
 }
+
+func TestMarkNotifiedOncePerEpisode(t *testing.T) {
+	mgr := NewPRStateManager(time.Now().Add(-time.Hour))
+	pr := PR{Repository: "test/repo", Number: 1, URL: "https://github.com/test/repo/pull/1", IsBlocked: true}
+
+	episode := mgr.TrackBlocked(pr)
+	if again := mgr.TrackBlocked(pr); !again.Equal(episode) {
+		t.Errorf("TrackBlocked started a new episode for a PR that is still blocked")
+	}
+	if !mgr.MarkNotified(pr.URL, episode) {
+		t.Fatal("first MarkNotified for an episode should allow the notification")
+	}
+	if mgr.MarkNotified(pr.URL, episode) {
+		t.Error("second MarkNotified for the same episode should be refused")
+	}
+	if mgr.MarkNotified(pr.URL, episode.Add(-time.Minute)) {
+		t.Error("MarkNotified should refuse an episode that isn't current")
+	}
+
+	// Unblocking ends the episode; the next block is a new one
+	pr.IsBlocked = false
+	mgr.UpdatePRs(nil, []PR{pr}, nil, false)
+	mgr.now = func() time.Time { return episode.Add(time.Minute) }
+	pr.IsBlocked = true
+	next := mgr.TrackBlocked(pr)
+	if next.Equal(episode) || !mgr.MarkNotified(pr.URL, next) {
+		t.Error("a new block episode should notify again")
+	}
+}
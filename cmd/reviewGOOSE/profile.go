@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultAppDirName names the cache, log, and settings directories when no profile is set.
+	defaultAppDirName = "reviewGOOSE"
+	maxProfileNameLen = 32
+	instanceLockFile  = "instance.lock"
+)
+
+// profileNamePattern restricts profile names to characters that are safe in paths on every platform.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// errInstanceRunning is returned when another goose is already running with the same profile.
+var errInstanceRunning = errors.New("another instance is already running")
+
+// validateProfileName checks a -profile-name value. The empty string selects the default profile.
+func validateProfileName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if len(name) > maxProfileNameLen {
+		return fmt.Errorf("profile name too long (max %d characters)", maxProfileNameLen)
+	}
+	if !profileNamePattern.MatchString(name) {
+		return errors.New("profile name may only contain lowercase letters, digits, and hyphens")
+	}
+	return nil
+}
+
+// appDirName returns the directory name used for cache, logs, and settings.
+// The default profile keeps the historical name so existing installs are unaffected.
+func appDirName(profile string) string {
+	if profile == "" {
+		return defaultAppDirName
+	}
+	return defaultAppDirName + "-" + profile
+}
+
+// profileLogger returns a logger that tags every record with the profile name, if any.
+func profileLogger(h slog.Handler, profile string) *slog.Logger {
+	logger := slog.New(h)
+	if profile != "" {
+		logger = logger.With("profile", profile)
+	}
+	return logger
+}
+
+// acquireInstanceLock ensures only one goose runs per profile by holding a PID file in dir.
+// A lock left behind by a process that has exited is taken over.
+func acquireInstanceLock(dir string) (release func(), err error) {
+	path := filepath.Join(dir, instanceLockFile)
+	pid := strconv.Itoa(os.Getpid())
+
+	for range 2 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, werr := f.WriteString(pid)
+			cerr := f.Close()
+			if werr != nil || cerr != nil {
+				_ = os.Remove(path) //nolint:errcheck // best-effort cleanup of a partial lock
+				return nil, fmt.Errorf("write instance lock: %w", errors.Join(werr, cerr))
+			}
+			return func() {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					slog.Warn("Failed to remove instance lock", "path", path, "error", err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("create instance lock: %w", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read instance lock: %w", err)
+		}
+		if owner, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && processAlive(owner) {
+			return nil, fmt.Errorf("%w (pid %d)", errInstanceRunning, owner)
+		}
+		slog.Info("Removing stale instance lock", "path", path, "contents", strings.TrimSpace(string(data)))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("remove stale instance lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("%w: lock at %s keeps reappearing", errInstanceRunning, path)
+}
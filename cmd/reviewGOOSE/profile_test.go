@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	valid := []string{"", "staging", "turn-staging-2", "a"}
+	for _, name := range valid {
+		if err := validateProfileName(name); err != nil {
+			t.Errorf("validateProfileName(%q) = %v, want nil", name, err)
+		}
+	}
+	invalid := []string{"Staging", "my_profile", "../etc", "a/b", "dev profile", "ünïcode", strings.Repeat("a", maxProfileNameLen+1)}
+	for _, name := range invalid {
+		if err := validateProfileName(name); err == nil {
+			t.Errorf("validateProfileName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestProfilePaths(t *testing.T) {
+	if got := appDirName(""); got != "reviewGOOSE" {
+		t.Errorf("default appDirName() = %q, want reviewGOOSE", got)
+	}
+	if got := appDirName("staging"); got != "reviewGOOSE-staging" {
+		t.Errorf("appDirName(staging) = %q", got)
+	}
+
+	stateHome := t.TempDir()
+	home := t.TempDir()
+	localAppData := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	t.Setenv("HOME", home)
+	t.Setenv("LOCALAPPDATA", localAppData)
+
+	var defaultLogs, stagingLogs string
+	switch runtime.GOOS {
+	case "darwin":
+		defaultLogs = filepath.Join(home, "Library", "Logs", "reviewGOOSE")
+		stagingLogs = filepath.Join(home, "Library", "Logs", "reviewGOOSE-staging")
+	case "windows":
+		defaultLogs = filepath.Join(localAppData, "reviewGOOSE", "Logs")
+		stagingLogs = filepath.Join(localAppData, "reviewGOOSE-staging", "Logs")
+	default:
+		defaultLogs = filepath.Join(stateHome, "reviewGOOSE")
+		stagingLogs = filepath.Join(stateHome, "reviewGOOSE-staging")
+	}
+	for profile, want := range map[string]string{"": defaultLogs, "staging": stagingLogs} {
+		got, err := logDir(appDirName(profile))
+		if err != nil {
+			t.Fatalf("logDir() error: %v", err)
+		}
+		if got != want {
+			t.Errorf("logDir(%q) = %q, want %q", profile, got, want)
+		}
+	}
+}
+
+func TestProfileSettingsIsolated(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", configDir)
+
+	defaultApp := &App{}
+	defaultApp.loadSettings()
+	defaultApp.enableAudioCues = false
+	defaultApp.saveSettings()
+
+	staging := &App{profileName: "staging"}
+	staging.loadSettings()
+	if !staging.enableAudioCues {
+		t.Error("staging profile loaded the default profile's settings")
+	}
+	staging.saveSettings()
+
+	// The default profile still writes to the pre-profile location
+	defaultPath, err := appsettings.NewManager("reviewGOOSE").Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagingPath, err := appsettings.NewManager("reviewGOOSE-staging").Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{defaultPath, stagingPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected settings file %s: %v", path, err)
+		}
+	}
+}
+
+func TestProfileTooltip(t *testing.T) {
+	app := &App{profileName: "staging", silentMode: true}
+	if got := app.tooltipText("reviewGOOSE - 2 incoming"); got != "reviewGOOSE - 2 incoming [staging] (silent mode)" {
+		t.Errorf("tooltipText() = %q", got)
+	}
+	if got := (&App{}).tooltipText("reviewGOOSE"); got != "reviewGOOSE" {
+		t.Errorf("default profile tooltipText() = %q, want unchanged", got)
+	}
+}
+
+func TestInstanceLockPerProfile(t *testing.T) {
+	cacheRoot := t.TempDir()
+	defaultDir := filepath.Join(cacheRoot, appDirName(""))
+	stagingDir := filepath.Join(cacheRoot, appDirName("staging"))
+	for _, dir := range []string{defaultDir, stagingDir} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	releaseDefault, err := acquireInstanceLock(defaultDir)
+	if err != nil {
+		t.Fatalf("acquire default lock: %v", err)
+	}
+
+	// A differently named profile runs concurrently
+	releaseStaging, err := acquireInstanceLock(stagingDir)
+	if err != nil {
+		t.Fatalf("staging profile blocked by default profile: %v", err)
+	}
+
+	// A second instance of the same profile is refused
+	if _, err := acquireInstanceLock(defaultDir); !errors.Is(err, errInstanceRunning) {
+		t.Errorf("second default instance: err = %v, want errInstanceRunning", err)
+	}
+
+	releaseDefault()
+	releaseAgain, err := acquireInstanceLock(defaultDir)
+	if err != nil {
+		t.Errorf("lock not reusable after release: %v", err)
+	} else {
+		releaseAgain()
+	}
+	releaseStaging()
+	if _, err := os.Stat(filepath.Join(stagingDir, instanceLockFile)); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after release: %v", err)
+	}
+}
+
+func TestInstanceLockTakesOverStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	// Garbage and PIDs of exited processes don't hold the lock
+	for _, contents := range []string{"not-a-pid", "0"} {
+		if err := os.WriteFile(filepath.Join(dir, instanceLockFile), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		release, err := acquireInstanceLock(dir)
+		if err != nil {
+			t.Fatalf("stale lock %q not taken over: %v", contents, err)
+		}
+		release()
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+const quarantineStrikeThreshold = 3 // Consecutive cycles of 403/404 before a PR URL is quarantined
+
+// quarantineBackoff is how long to wait before re-checking a quarantined PR.
+// Each failed re-check moves to the next interval; the last one repeats.
+var quarantineBackoff = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// isPermanentPRError reports whether an error means we've lost access to a PR
+// (403/404/410/451 from GitHub or Turn), as opposed to a transient failure worth retrying.
+func isPermanentPRError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if goneStatus(err) != 0 {
+		return true
+	}
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		code := ghErr.Response.StatusCode
+		return code == http.StatusForbidden || code == http.StatusNotFound
+	}
+	// turnclient reports non-200 responses as "api request failed with status N: ..."
+	msg := err.Error()
+	return strings.Contains(msg, "status 403") || strings.Contains(msg, "status 404")
+}
+
+// quarantineEntry tracks permanent failures for a single PR URL.
+type quarantineEntry struct {
+	nextCheck   time.Time
+	seenAt      time.Time // Last failure, or last search that still listed the PR
+	strikes     int
+	level       int
+	gone        int // 410 or 451: quarantined for good, never re-checked
+	quarantined bool
+}
+
+// prQuarantine is a small denylist for PR URLs we repeatedly can't access
+// (e.g. archived or access-revoked repositories), so they stop burning the
+// retry budget and failing every update cycle.
+type prQuarantine struct {
+	entries map[string]*quarantineEntry
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func newPRQuarantine() *prQuarantine {
+	return &prQuarantine{
+		entries: make(map[string]*quarantineEntry),
+		now:     time.Now,
+	}
+}
+
+// recordFailure records a permanent failure for a PR in this cycle. It returns true if
+// the PR is quarantined after this failure: after quarantineStrikeThreshold 403s or
+// 404s, or at once for a 410 or 451.
+func (q *prQuarantine) recordFailure(url string, err error) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[url]
+	if !ok {
+		e = &quarantineEntry{}
+		q.entries[url] = e
+	}
+	e.seenAt = q.now()
+
+	if code := goneStatus(err); code != 0 {
+		if e.gone == 0 {
+			slog.Info("[QUARANTINE] GitHub removed or legally blocked PR, dropping it for good",
+				"url", url, "status", code, "error", err)
+		}
+		e.gone, e.quarantined = code, true
+		return true
+	}
+	if e.quarantined {
+		// Re-check failed: back off further
+		if e.level < len(quarantineBackoff)-1 {
+			e.level++
+		}
+		e.nextCheck = q.now().Add(quarantineBackoff[e.level])
+		slog.Debug("[QUARANTINE] Re-check failed, still inaccessible",
+			"url", url, "next_check", e.nextCheck.Format(time.RFC3339), "error", err)
+		return true
+	}
+
+	e.strikes++
+	if e.strikes < quarantineStrikeThreshold {
+		return false
+	}
+
+	e.quarantined = true
+	e.level = 0
+	e.nextCheck = q.now().Add(quarantineBackoff[0])
+	slog.Info("[QUARANTINE] PR inaccessible, hiding until re-check",
+		"url", url, "consecutive_failures", e.strikes, "recheck_in", quarantineBackoff[0], "error", err)
+	return true
+}
+
+// recordSuccess clears any failure history for a PR.
+func (q *prQuarantine) recordSuccess(url string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[url]
+	if !ok || e.gone != 0 {
+		return
+	}
+	if e.quarantined {
+		slog.Info("[QUARANTINE] Access to PR restored", "url", url)
+	}
+	delete(q.entries, url)
+}
+
+// isQuarantined reports whether a PR is currently quarantined and should be
+// dropped from menus, counts, and state.
+func (q *prQuarantine) isQuarantined(url string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[url]
+	return ok && e.quarantined
+}
+
+// shouldSkip reports whether lookups for a PR should be skipped this cycle.
+// Quarantined PRs are skipped until their next re-check time; gone ones always are.
+func (q *prQuarantine) shouldSkip(url string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[url]
+	return ok && e.quarantined && (e.gone != 0 || q.now().Before(e.nextCheck))
+}
+
+// filter returns prs without quarantined entries. Quarantined PRs the search still
+// lists are marked seen, so prune keeps them.
+func (q *prQuarantine) filter(prs []PR) []PR {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	kept := prs[:0]
+	for i := range prs {
+		if e, ok := q.entries[prs[i].URL]; ok && e.quarantined {
+			e.seenAt = now
+			continue
+		}
+		kept = append(kept, prs[i])
+	}
+	return kept
+}
+
+// prune drops entries for PRs neither searched nor looked up for longer than ttl, and
+// returns how many it dropped. Quarantined PRs are filtered out of the lists, so the
+// janitor's clocks don't see them; this is their equivalent.
+func (q *prQuarantine) prune(now time.Time, ttl time.Duration) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pruned := 0
+	for url, e := range q.entries {
+		if now.Sub(e.seenAt) > ttl {
+			delete(q.entries, url)
+			pruned++
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func TestIsPermanentPRError(t *testing.T) {
+	ghErr := func(code int) error {
+		return &github.ErrorResponse{Response: &http.Response{StatusCode: code}}
+	}
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "github 404", err: ghErr(http.StatusNotFound), want: true},
+		{name: "github 403", err: fmt.Errorf("wrapped: %w", ghErr(http.StatusForbidden)), want: true},
+		{name: "github 500", err: ghErr(http.StatusInternalServerError), want: false},
+		{name: "turn 404", err: errors.New("api request failed with status 404: not found"), want: true},
+		{name: "turn 403", err: errors.New("api request failed with status 403: forbidden"), want: true},
+		{name: "turn 502", err: errors.New("api request failed with status 502: bad gateway"), want: false},
+		{name: "timeout", err: context.DeadlineExceeded, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPermanentPRError(tt.err); got != tt.want {
+				t.Errorf("isPermanentPRError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarantineStateMachine(t *testing.T) {
+	now := time.Now()
+	q := newPRQuarantine()
+	q.now = func() time.Time { return now }
+
+	const url = "https://github.com/org/private/pull/1"
+	errNotFound := errors.New("api request failed with status 404: not found")
+
+	for i := 1; i < quarantineStrikeThreshold; i++ {
+		if q.recordFailure(url, errNotFound) {
+			t.Fatalf("quarantined after %d failures, want %d", i, quarantineStrikeThreshold)
+		}
+	}
+	// A success in between resets the streak
+	q.recordSuccess(url)
+	for i := 1; i < quarantineStrikeThreshold; i++ {
+		q.recordFailure(url, errNotFound)
+	}
+	if q.isQuarantined(url) {
+		t.Fatal("success should reset the failure streak")
+	}
+
+	if !q.recordFailure(url, errNotFound) {
+		t.Fatalf("expected quarantine after %d consecutive failures", quarantineStrikeThreshold)
+	}
+	if !q.isQuarantined(url) || !q.shouldSkip(url) {
+		t.Fatal("expected quarantined PR to be skipped")
+	}
+
+	// Re-check intervals back off: 1h, 6h, 24h, then stay at 24h
+	for _, wait := range []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 24 * time.Hour} {
+		now = now.Add(wait - time.Minute)
+		if !q.shouldSkip(url) {
+			t.Fatalf("expected skip before %v re-check", wait)
+		}
+		now = now.Add(time.Minute)
+		if q.shouldSkip(url) {
+			t.Fatalf("expected re-check to be due after %v", wait)
+		}
+		q.recordFailure(url, errNotFound)
+	}
+
+	// Access returns: PR re-enters normally
+	q.recordSuccess(url)
+	if q.isQuarantined(url) || q.shouldSkip(url) {
+		t.Error("expected PR to leave quarantine after a successful lookup")
+	}
+}
+
+func TestQuarantineDropsPRFromState(t *testing.T) {
+	now := time.Now()
+	blocked := PR{Repository: "org/private", Number: 1, URL: "https://github.com/org/private/pull/1", NeedsReview: true, UpdatedAt: now}
+	other := PR{Repository: "org/public", Number: 2, URL: "https://github.com/org/public/pull/2", NeedsReview: true, UpdatedAt: now}
+
+	sm := NewPRStateManager(now.Add(-time.Hour))
+	sm.UpdatePRs([]PR{blocked, other}, nil, map[string]bool{}, true)
+	if _, ok := sm.PRState(blocked.URL); !ok {
+		t.Fatal("expected blocked PR to be tracked")
+	}
+
+	q := newPRQuarantine()
+	for range quarantineStrikeThreshold {
+		q.recordFailure(blocked.URL, errors.New("api request failed with status 403: forbidden"))
+	}
+
+	incoming := q.filter([]PR{blocked, other})
+	if len(incoming) != 1 || incoming[0].URL != other.URL {
+		t.Fatalf("filter() = %+v, want only the accessible PR", incoming)
+	}
+
+	sm.UpdatePRs(incoming, nil, map[string]bool{}, false)
+	if _, ok := sm.PRState(blocked.URL); ok {
+		t.Error("expected quarantined PR to be cleaned up from state")
+	}
+	if _, ok := sm.PRState(other.URL); !ok {
+		t.Error("expected accessible PR to remain tracked")
+	}
+}
+
+func TestQuarantineStopsRetryingRevokedPR(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	turnClient, err := turn.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create turn client: %v", err)
+	}
+	turnClient.SetAuthToken("test-token")
+
+	login := "testuser"
+	app := &App{
+		mu:          sync.RWMutex{},
+		turnClient:  turnClient,
+		currentUser: &github.User{Login: &login},
+		cacheDir:    t.TempDir(),
+		noCache:     true,
+		quarantine:  newPRQuarantine(),
+	}
+
+	url := "https://github.com/org/private/pull/1"
+	issue := &github.Issue{
+		HTMLURL:          &url,
+		User:             &github.User{Login: github.String("author")},
+		UpdatedAt:        &github.Timestamp{Time: time.Now()},
+		PullRequestLinks: &github.PullRequestLinks{},
+	}
+
+	for cycle := 1; cycle <= quarantineStrikeThreshold+1; cycle++ {
+		incoming := []PR{{URL: url, Repository: "org/private", Number: 1}}
+		var outgoing []PR
+		app.fetchTurnDataSync(context.Background(), []*github.Issue{issue}, login, &incoming, &outgoing)
+	}
+
+	if !app.quarantine.isQuarantined(url) {
+		t.Fatal("expected PR to be quarantined after repeated 404s")
+	}
+	// 404s are not retried, and the quarantined cycle makes no request at all. The Turn
+	// client itself may retry once internally, so allow up to two requests per cycle.
+	if got := requests.Load(); got > 2*quarantineStrikeThreshold {
+		t.Errorf("Turn server received %d requests, want at most %d", got, 2*quarantineStrikeThreshold)
+	}
+}
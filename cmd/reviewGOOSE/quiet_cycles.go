@@ -0,0 +1,122 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultQuietSkipWindow is how long after a full fetch a quiet sprinkler lets us skip the next one.
+	defaultQuietSkipWindow = 5 * time.Minute
+	// quietSafetyNetCycles forces a full fetch every Nth cycle to catch events the sprinkler missed.
+	quietSafetyNetCycles = 10
+)
+
+// sprinklerActivity is a snapshot of the websocket's state, taken at the start of a cycle.
+type sprinklerActivity struct {
+	connectedAt time.Time // Identifies the connection; changes on every reconnect
+	events      uint64    // Relevant PR events received so far
+	connected   bool
+}
+
+// activity returns the sprinkler's current connection state and event count.
+func (sm *sprinklerMonitor) activity() sprinklerActivity {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sprinklerActivity{
+		connectedAt: sm.lastConnectedAt,
+		events:      sm.eventCount,
+		connected:   sm.isRunning && sm.isConnected,
+	}
+}
+
+// sprinklerActivity returns the sprinkler snapshot, reporting disconnected when it's disabled.
+func (app *App) sprinklerActivity() sprinklerActivity {
+	if app.sprinklerMonitor == nil {
+		return sprinklerActivity{}
+	}
+	return app.sprinklerMonitor.activity()
+}
+
+// quietCycles decides when a scheduled cycle can reuse the in-memory PR list because the
+// sprinkler delivered no relevant events since the last successful fetch.
+type quietCycles struct {
+	lastFetch sprinklerActivity // Snapshot taken when the last successful fetch started
+	fetchedAt time.Time
+	window    time.Duration // 0 disables skipping
+	skipped   int           // Consecutive skipped cycles
+	mu        sync.Mutex
+}
+
+func newQuietCycles(window time.Duration) *quietCycles {
+	return &quietCycles{window: window}
+}
+
+// shouldSkip reports whether the cycle starting now can skip the search and Turn calls.
+// A skip is only allowed while the websocket has stayed connected on the same connection
+// with no new events, within the window, and short of the safety-net cycle.
+func (q *quietCycles) shouldSkip(now time.Time, forced bool, act sprinklerActivity) (skip bool, reason string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case q.window <= 0:
+		return false, "disabled"
+	case forced:
+		return false, "forced refresh"
+	case q.fetchedAt.IsZero():
+		return false, "no successful fetch yet"
+	case !act.connected:
+		return false, "sprinkler disconnected"
+	case !act.connectedAt.Equal(q.lastFetch.connectedAt):
+		return false, "sprinkler reconnected since last fetch"
+	case act.events != q.lastFetch.events:
+		return false, "sprinkler events since last fetch"
+	case now.Sub(q.fetchedAt) >= q.window:
+		return false, "last fetch too old"
+	case q.skipped+1 >= quietSafetyNetCycles:
+		return false, "safety-net cycle"
+	default:
+		q.skipped++
+		return true, "sprinkler quiet"
+	}
+}
+
+// recordFetch notes a successful full fetch. act must be the snapshot taken before the fetch
+// started, so events that arrived mid-cycle invalidate the next skip.
+func (q *quietCycles) recordFetch(startedAt time.Time, act sprinklerActivity) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.fetchedAt = startedAt
+	q.lastFetch = act
+	q.skipped = 0
+}
+
+// invalidate forces the next cycle to run a full fetch, e.g. after a failed one.
+func (q *quietCycles) invalidate() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.fetchedAt = time.Time{}
+}
+
+// skipQuietCycle reports whether this update can be skipped, recording the skip.
+func (app *App) skipQuietCycle() bool {
+	if app.quietCycles == nil {
+		return false
+	}
+	app.mu.RLock()
+	forced := app.forceNextRefresh
+	app.mu.RUnlock()
+
+	skip, reason := app.quietCycles.shouldSkip(time.Now(), forced, app.sprinklerActivity())
+	if !skip {
+		slog.Debug("[QUIET] Running full fetch", "reason", reason)
+		return false
+	}
+	slog.Info("[QUIET] Sprinkler reported no changes, reusing PR list")
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordSkippedCycle()
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQuietCyclesDecision(t *testing.T) {
+	start := time.Now()
+	conn := start.Add(-time.Hour)
+	quiet := sprinklerActivity{connected: true, connectedAt: conn, events: 3}
+
+	tests := []struct {
+		act    sprinklerActivity
+		now    time.Time
+		name   string
+		forced bool
+		want   bool
+	}{
+		{name: "quiet and recent", act: quiet, now: start.Add(time.Minute), want: true},
+		{name: "forced refresh", act: quiet, now: start.Add(time.Minute), forced: true},
+		{name: "event since fetch", act: sprinklerActivity{connected: true, connectedAt: conn, events: 4}, now: start.Add(time.Minute)},
+		{name: "disconnected", act: sprinklerActivity{connectedAt: conn, events: 3}, now: start.Add(time.Minute)},
+		{name: "reconnected", act: sprinklerActivity{connected: true, connectedAt: conn.Add(time.Minute), events: 3}, now: start.Add(time.Minute)},
+		{name: "window elapsed", act: quiet, now: start.Add(defaultQuietSkipWindow)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuietCycles(defaultQuietSkipWindow)
+			q.recordFetch(start, quiet)
+			if got, reason := q.shouldSkip(tt.now, tt.forced, tt.act); got != tt.want {
+				t.Errorf("shouldSkip() = %v (%s), want %v", got, reason, tt.want)
+			}
+		})
+	}
+
+	if skip, _ := newQuietCycles(defaultQuietSkipWindow).shouldSkip(start, false, quiet); skip {
+		t.Error("skipped before any successful fetch")
+	}
+	disabled := newQuietCycles(0)
+	disabled.recordFetch(start, quiet)
+	if skip, _ := disabled.shouldSkip(start.Add(time.Second), false, quiet); skip {
+		t.Error("skipped with a zero window")
+	}
+}
+
+func TestQuietCyclesTimeline(t *testing.T) {
+	start := time.Now()
+	conn := start.Add(-time.Hour)
+	act := sprinklerActivity{connected: true, connectedAt: conn}
+	q := newQuietCycles(time.Hour)
+
+	// cycle runs one scheduled minute and reports whether it was skipped
+	now := start
+	cycle := func() bool {
+		t.Helper()
+		now = now.Add(time.Minute)
+		if skip, _ := q.shouldSkip(now, false, act); skip {
+			return true
+		}
+		q.recordFetch(now, act)
+		return false
+	}
+
+	if cycle() {
+		t.Fatal("first cycle skipped")
+	}
+	// Safety net: with no events, every quietSafetyNetCycles-th cycle still fetches
+	fetches := 0
+	for range 3 * quietSafetyNetCycles {
+		if !cycle() {
+			fetches++
+		}
+	}
+	if fetches != 3 {
+		t.Errorf("got %d safety-net fetches in %d quiet cycles, want 3", fetches, 3*quietSafetyNetCycles)
+	}
+
+	// An event arriving mid-fetch invalidates the next skip, then quiet resumes
+	now = now.Add(time.Minute)
+	q.recordFetch(now, act)
+	act.events++
+	if cycle() {
+		t.Error("skipped despite an event that arrived during the previous fetch")
+	}
+	if !cycle() {
+		t.Error("did not skip once the sprinkler went quiet again")
+	}
+
+	// A disconnect disables skipping immediately
+	act.connected = false
+	if cycle() {
+		t.Error("skipped while the sprinkler was disconnected")
+	}
+
+	// A failed fetch forces the next cycle to fetch
+	act.connected = true
+	cycle()
+	q.invalidate()
+	if skip, _ := q.shouldSkip(now.Add(time.Minute), false, act); skip {
+		t.Error("skipped after a failed fetch")
+	}
+}
+
+func TestUpdatePRsSkipsQuietCycle(t *testing.T) {
+	connectedAt := time.Now().Add(-time.Hour)
+	app := &App{
+		mu:               sync.RWMutex{},
+		stateManager:     NewPRStateManager(time.Now().Add(-time.Hour)),
+		healthMonitor:    newHealthMonitor(),
+		quietCycles:      newQuietCycles(defaultQuietSkipWindow),
+		systrayInterface: &MockSystray{},
+		sprinklerMonitor: &sprinklerMonitor{
+			lastConnectedAt: connectedAt,
+			eventCount:      5,
+			isRunning:       true,
+			isConnected:     true,
+		},
+		incoming: []PR{{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1"}},
+	}
+	app.quietCycles.recordFetch(time.Now(), app.sprinklerActivity())
+
+	// No GitHub client: a real fetch would fail and count a failure
+	app.updatePRs(context.Background())
+	if app.consecutiveFailures != 0 || len(app.incoming) != 1 {
+		t.Fatalf("quiet cycle should reuse the PR list: failures=%d incoming=%d", app.consecutiveFailures, len(app.incoming))
+	}
+	if got := app.healthMonitor.metrics()["skipped_cycles"]; got != int64(1) {
+		t.Errorf("skipped_cycles = %v, want 1", got)
+	}
+
+	// A forced refresh bypasses the skip
+	app.forceNextRefresh = true
+	app.updatePRs(context.Background())
+	if app.consecutiveFailures != 1 {
+		t.Errorf("forced refresh should have fetched, failures=%d", app.consecutiveFailures)
+	}
+}
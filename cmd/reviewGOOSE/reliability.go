@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -39,9 +41,18 @@ func safeExecute(operation string, fn func() error) (err error) {
 	return err
 }
 
+// Circuit breaker states.
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half-open"
+)
+
 // circuitBreaker provides circuit breaker pattern for external API calls.
+// The lock isn't held while the call runs, so status never waits on the network.
 type circuitBreaker struct {
 	lastFailureTime time.Time
+	now             func() time.Time // Stubbed in tests
 	name            string
 	state           string
 	timeout         time.Duration
@@ -50,37 +61,50 @@ type circuitBreaker struct {
 	mu              sync.RWMutex
 }
 
+// circuitStatus is a snapshot of a breaker for the menu and diagnostic report.
+type circuitStatus struct {
+	name      string
+	state     string
+	retryIn   time.Duration // Until an open breaker lets the next call through
+	failures  int
+	threshold int
+}
+
 func newCircuitBreaker(name string, threshold int, timeout time.Duration) *circuitBreaker {
 	return &circuitBreaker{
 		name:      name,
 		threshold: threshold,
 		timeout:   timeout,
-		state:     "closed",
+		state:     circuitClosed,
+		now:       time.Now,
 	}
 }
 
 func (cb *circuitBreaker) call(fn func() error) error {
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
-
 	// Check if circuit is open
-	if cb.state == "open" {
-		if time.Since(cb.lastFailureTime) <= cb.timeout {
+	if cb.state == circuitOpen {
+		if cb.now().Sub(cb.lastFailureTime) <= cb.timeout {
+			cb.mu.Unlock()
 			return fmt.Errorf("circuit breaker open for %s", cb.name)
 		}
-		cb.state = "half-open"
+		cb.state = circuitHalfOpen
 		slog.Info("[CIRCUIT] Circuit breaker transitioning to half-open",
 			"name", cb.name)
 	}
+	cb.mu.Unlock()
 
 	// Execute the function
 	err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 	if err != nil {
 		cb.failures++
-		cb.lastFailureTime = time.Now()
+		cb.lastFailureTime = cb.now()
 
 		if cb.failures >= cb.threshold {
-			cb.state = "open"
+			cb.state = circuitOpen
 			slog.Error("[CIRCUIT] Circuit breaker opened",
 				"name", cb.name,
 				"failures", cb.failures,
@@ -91,8 +115,8 @@ func (cb *circuitBreaker) call(fn func() error) error {
 	}
 
 	// Success - reset on half-open or reduce failure count
-	if cb.state == "half-open" {
-		cb.state = "closed"
+	if cb.state == circuitHalfOpen {
+		cb.state = circuitClosed
 		cb.failures = 0
 		slog.Info("[CIRCUIT] Circuit breaker closed after successful call",
 			"name", cb.name)
@@ -103,6 +127,82 @@ func (cb *circuitBreaker) call(fn func() error) error {
 	return nil
 }
 
+// status reports the breaker's state and, when open, how long until it retries.
+func (cb *circuitBreaker) status() circuitStatus {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+	st := circuitStatus{
+		name:      cb.name,
+		state:     cb.state,
+		failures:  cb.failures,
+		threshold: cb.threshold,
+	}
+	if cb.state == circuitOpen {
+		st.retryIn = max(0, cb.timeout-cb.now().Sub(cb.lastFailureTime))
+	}
+	return st
+}
+
+// retryNow moves an open breaker to half-open so the next call goes through without
+// waiting out the cooldown. If that call fails, the breaker reopens for the full timeout.
+// It returns false if the breaker wasn't open.
+func (cb *circuitBreaker) retryNow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state != circuitOpen {
+		return false
+	}
+	cb.state = circuitHalfOpen
+	slog.Info("[CIRCUIT] Circuit breaker half-open on manual retry", "name", cb.name)
+	return true
+}
+
+// circuits returns the app's breakers. Turn has none yet; GitHub is the only one.
+func (app *App) circuits() []*circuitBreaker {
+	if app.githubCircuit == nil {
+		return nil
+	}
+	return []*circuitBreaker{app.githubCircuit}
+}
+
+// addCircuitItems shows each breaker that isn't closed, with a "Retry now" item that
+// skips the cooldown. It's part of the failure section of the menu.
+func (app *App) addCircuitItems(ctx context.Context) {
+	tripped := false
+	for _, cb := range app.circuits() {
+		st := cb.status()
+		var title string
+		switch st.state {
+		case circuitOpen:
+			title = msg("circuit.open", int(st.retryIn.Round(time.Second)/time.Second))
+		case circuitHalfOpen:
+			title = msg("circuit.half_open")
+		default:
+			continue
+		}
+		stateItem := app.systrayInterface.AddMenuItem(title, st.name)
+		stateItem.Disable()
+		tripped = true
+	}
+	if !tripped {
+		return
+	}
+	retryItem := app.systrayInterface.AddMenuItem(msg("circuit.retry"), msg("circuit.retry.tooltip"))
+	retryItem.Click(func() {
+		app.goTracked("manual retry", func() { app.retryCircuitsNow(ctx) })
+	})
+}
+
+// retryCircuitsNow half-opens every open breaker and runs an update right away,
+// ignoring minUpdateInterval.
+func (app *App) retryCircuitsNow(ctx context.Context) {
+	for _, cb := range app.circuits() {
+		cb.retryNow()
+	}
+	slog.Info("[CIRCUIT] Manual retry requested, updating now")
+	app.updatePRs(ctx)
+}
+
 // healthMonitor tracks application health metrics.
 type healthMonitor struct {
 	lastCheckTime time.Time
@@ -112,7 +212,25 @@ type healthMonitor struct {
 	apiErrors     int64
 	cacheHits     int64
 	cacheMisses   int64
-	mu            sync.RWMutex
+	cacheMismatch int64         // Cache entries whose Turn updated_at disagreed with GitHub's
+	skippedCycles int64         // Update cycles skipped because the sprinkler reported no changes
+	hookRuns      int64         // Notification hook runs that succeeded
+	hookFailures  int64         // Notification hook runs that failed, timed out, or were dropped
+	trayRecovered int64         // Times the tray item was re-registered with a returning host (Linux)
+	searchCapped  int64         // Searches that hit GitHub's 1,000-result ceiling and were narrowed
+	offHoursOpens int64         // Auto-opens skipped because they fell outside auto_open_hours
+	trayDowntime  time.Duration // Total time without a tray host before those recoveries
+	// Exported on the metrics port; see metrics.go
+	githubCalls        atomic.Int64
+	notificationsSent  atomic.Int64
+	sprinklerProcessed atomic.Int64
+	sprinklerDropped   atomic.Int64
+	sprinklerDeduped   atomic.Int64
+	sprinklerInvalid   atomic.Int64 // Events dropped because their URL isn't a PR URL
+	blockedIncoming    atomic.Int64 // Gauge: last tray count
+	blockedOutgoing    atomic.Int64 // Gauge: last tray count
+	mu                 sync.RWMutex
+	trayHostLost       bool // The tray host went away and hasn't come back
 }
 
 func newHealthMonitor() *healthMonitor {
@@ -144,6 +262,59 @@ func (hm *healthMonitor) recordCacheAccess(hit bool) {
 	}
 }
 
+func (hm *healthMonitor) recordCacheMismatch() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.cacheMismatch++
+}
+
+func (hm *healthMonitor) recordSkippedCycle() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.skippedCycles++
+}
+
+func (hm *healthMonitor) recordHookRun(success bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if success {
+		hm.hookRuns++
+	} else {
+		hm.hookFailures++
+	}
+}
+
+// recordSearchCapped records a search hitting the result ceiling.
+func (hm *healthMonitor) recordSearchCapped() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.searchCapped++
+}
+
+// recordOffHoursOpen records an auto-open the auto-open schedule held back.
+func (hm *healthMonitor) recordOffHoursOpen() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.offHoursOpens++
+}
+
+// recordTrayHost records the tray host going away or, with the time it was gone, coming back.
+func (hm *healthMonitor) recordTrayHost(present bool, downtime time.Duration) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.trayHostLost = !present
+	if present {
+		hm.trayRecovered++
+		hm.trayDowntime += downtime
+	}
+}
+
 func (hm *healthMonitor) metrics() map[string]any {
 	hm.mu.RLock()
 	defer hm.mu.RUnlock()
@@ -160,14 +331,24 @@ func (hm *healthMonitor) metrics() map[string]any {
 	}
 
 	return map[string]any{
-		"uptime":         time.Since(hm.uptime),
-		"api_calls":      hm.apiCalls,
-		"api_errors":     hm.apiErrors,
-		"error_rate":     errorRate,
-		"cache_hits":     hm.cacheHits,
-		"cache_misses":   hm.cacheMisses,
-		"cache_hit_rate": cacheHitRate,
-		"last_check":     hm.lastCheckTime,
+		"uptime":           time.Since(hm.uptime),
+		"api_calls":        hm.apiCalls,
+		"api_errors":       hm.apiErrors,
+		"error_rate":       errorRate,
+		"cache_hits":       hm.cacheHits,
+		"cache_misses":     hm.cacheMisses,
+		"cache_hit_rate":   cacheHitRate,
+		"cache_mismatches": hm.cacheMismatch,
+		"skipped_cycles":   hm.skippedCycles,
+		"hook_runs":        hm.hookRuns,
+		"hook_failures":    hm.hookFailures,
+		"tray_host_lost":   hm.trayHostLost,
+		"tray_recovered":   hm.trayRecovered,
+		"tray_downtime":    hm.trayDowntime,
+		"bad_event_urls":   hm.sprinklerInvalid.Load(),
+		"search_capped":    hm.searchCapped,
+		"off_hours_opens":  hm.offHoursOpens,
+		"last_check":       hm.lastCheckTime,
 	}
 }
 
@@ -195,6 +376,16 @@ func (hm *healthMonitor) logMetrics() {
 		"api_errors", m["api_errors"],
 		"error_rate_pct", fmt.Sprintf("%.1f", m["error_rate"]),
 		"cache_hit_rate_pct", fmt.Sprintf("%.1f", m["cache_hit_rate"]),
+		"cache_mismatches", m["cache_mismatches"],
+		"skipped_cycles", m["skipped_cycles"],
+		"hook_runs", m["hook_runs"],
+		"hook_failures", m["hook_failures"],
+		"tray_host_lost", m["tray_host_lost"],
+		"tray_recovered", m["tray_recovered"],
+		"tray_downtime", m["tray_downtime"],
+		"bad_event_urls", m["bad_event_urls"],
+		"search_capped", m["search_capped"],
+		"off_hours_opens", m["off_hours_opens"],
 		"sprinkler_connected", sprinklerConnected,
 		"sprinkler_last_connected", sprinklerLastConnected)
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// trippedBreaker returns a breaker opened by threshold failures, with a clock the test controls.
+func trippedBreaker(t *testing.T) (cb *circuitBreaker, now *time.Time) {
+	t.Helper()
+	clock := time.Now()
+	cb = newCircuitBreaker("github", 2, 2*time.Minute)
+	cb.now = func() time.Time { return clock }
+	fail := errors.New("dial tcp: i/o timeout")
+	for range 2 {
+		if err := cb.call(func() error { return fail }); !errors.Is(err, fail) {
+			t.Fatalf("call() = %v, want the call's error", err)
+		}
+	}
+	if st := cb.status(); st.state != circuitOpen || st.retryIn != 2*time.Minute {
+		t.Fatalf("after %d failures: %+v, want open for the full timeout", cb.threshold, st)
+	}
+	return cb, &clock
+}
+
+func TestCircuitRetryNowHalfOpens(t *testing.T) {
+	cb, now := trippedBreaker(t)
+	*now = now.Add(46 * time.Second)
+	if st := cb.status(); st.retryIn != 74*time.Second {
+		t.Errorf("retryIn = %v, want 74s", st.retryIn)
+	}
+
+	if !cb.retryNow() {
+		t.Fatal("retryNow() on an open breaker returned false")
+	}
+	if st := cb.status(); st.state != circuitHalfOpen || st.retryIn != 0 {
+		t.Errorf("after retryNow: %+v, want half-open", st)
+	}
+	if cb.retryNow() {
+		t.Error("retryNow() on a half-open breaker should do nothing")
+	}
+
+	called := false
+	if err := cb.call(func() error { called = true; return nil }); err != nil || !called {
+		t.Fatalf("call() after retryNow = %v, called %t; want the call let through", err, called)
+	}
+	if st := cb.status(); st.state != circuitClosed || st.failures != 0 {
+		t.Errorf("after a successful retry: %+v, want closed and reset", st)
+	}
+}
+
+func TestCircuitFailedRetryReopens(t *testing.T) {
+	cb, now := trippedBreaker(t)
+	*now = now.Add(30 * time.Second)
+	cb.retryNow()
+
+	if err := cb.call(func() error { return errors.New("still down") }); err == nil {
+		t.Fatal("call() should return the failure")
+	}
+	if st := cb.status(); st.state != circuitOpen || st.retryIn != 2*time.Minute {
+		t.Errorf("after a failed retry: %+v, want open with the original 2m cooldown", st)
+	}
+	if err := cb.call(func() error { t.Error("an open breaker let a call through"); return nil }); err == nil {
+		t.Error("call() on a reopened breaker should fail fast")
+	}
+}
+
+func TestCircuitMenuItems(t *testing.T) {
+	mock := &MockSystray{}
+	app := &App{
+		mu:                  sync.RWMutex{},
+		stateManager:        NewPRStateManager(time.Now()),
+		systrayInterface:    mock,
+		hiddenOrgs:          make(map[string]bool),
+		seenOrgs:            make(map[string]orgActivity),
+		consecutiveFailures: 2,
+		lastFetchError:      "circuit breaker open for github",
+	}
+	app.githubCircuit, _ = trippedBreaker(t)
+
+	app.rebuildMenu(context.Background())
+	for _, title := range []string{"Circuit open — retrying in 120s", "Retry now"} {
+		if !slices.Contains(mock.menuItems, title) {
+			t.Errorf("%q missing from the failure section: %q", title, mock.menuItems)
+		}
+	}
+
+	app.githubCircuit = newCircuitBreaker("github", 2, 2*time.Minute)
+	mock.ResetMenu()
+	app.rebuildMenu(context.Background())
+	if slices.Contains(mock.menuItems, "Retry now") {
+		t.Error("a closed breaker shouldn't offer a retry")
+	}
+}
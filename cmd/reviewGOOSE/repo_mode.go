@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// searchQueries returns the GitHub searches for one update cycle. Normally these find
+// PRs involving user; in repo mode ("repos" in settings.json) there is one query per
+// repository instead, whoever is logged in.
+func searchQueries(user string, repos []string) []string {
+	if len(repos) > 0 {
+		queries := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			queries = append(queries, fmt.Sprintf("repo:%s is:open is:pr", repo))
+		}
+		return queries
+	}
+	return []string{
+		// PRs involving the user
+		fmt.Sprintf("is:open is:pr involves:%s archived:false", user),
+		// PRs in user-owned repos with no reviewers
+		fmt.Sprintf("is:open is:pr user:%s review:none archived:false", user),
+	}
+}
+
+// validRepos normalizes the configured repo list to unique "owner/name" entries,
+// skipping and logging anything else.
+func validRepos(repos []string) []string {
+	var valid []string
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		repo = strings.TrimSpace(repo)
+		owner, name, ok := strings.Cut(repo, "/")
+		if !ok || owner == "" || name == "" || strings.ContainsAny(name, "/ ") || strings.Contains(owner, " ") {
+			slog.Warn("[SETTINGS] Ignoring invalid repo, want owner/name", "repo", repo)
+			continue
+		}
+		if seen[strings.ToLower(repo)] {
+			continue
+		}
+		seen[strings.ToLower(repo)] = true
+		valid = append(valid, repo)
+	}
+	return valid
+}
+
+// repoMode reports whether a fixed repository list replaces the user's searches.
+func (app *App) repoMode() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return len(app.repos) > 0
+}
+
+// repoModeState returns the monitored repos and the users whose actions count as blocked.
+func (app *App) repoModeState() (repos, users []string) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.repos, app.repoModeUsers
+}
+
+// repoModeActor picks whose next action a PR is judged by when several users are watched:
+// a critical action beats a non-critical one, then the longest-waiting. With none pending
+// it returns the first user, who then has no action. It returns "" when users is empty.
+func repoModeActor(next map[string]turn.Action, users []string) string {
+	if len(users) == 0 {
+		return ""
+	}
+	best := ""
+	var bestAction turn.Action
+	for _, login := range users {
+		action, ok := next[login]
+		if !ok {
+			continue
+		}
+		switch {
+		case best == "":
+		case action.Critical != bestAction.Critical:
+			if !action.Critical {
+				continue
+			}
+		case !action.Since.IsZero() && (bestAction.Since.IsZero() || action.Since.Before(bestAction.Since)):
+		default:
+			continue
+		}
+		best, bestAction = login, action
+	}
+	if best == "" {
+		return users[0]
+	}
+	return best
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestSearchQueries(t *testing.T) {
+	user := searchQueries("me", nil)
+	if len(user) != 2 || !strings.Contains(user[0], "involves:me") || !strings.Contains(user[1], "user:me review:none") {
+		t.Errorf("user mode queries = %q", user)
+	}
+
+	repos := searchQueries("me", []string{"org/a", "org/b"})
+	want := []string{"repo:org/a is:open is:pr", "repo:org/b is:open is:pr"}
+	if !slices.Equal(repos, want) {
+		t.Errorf("repo mode queries = %q, want %q", repos, want)
+	}
+}
+
+func TestValidRepos(t *testing.T) {
+	got := validRepos([]string{" org/a ", "org/b", "ORG/A", "org", "/b", "org/", "org/b/c", "o rg/x"})
+	want := []string{"org/a", "org/b"}
+	if !slices.Equal(got, want) {
+		t.Errorf("validRepos() = %q, want %q", got, want)
+	}
+}
+
+func TestRepoModeActor(t *testing.T) {
+	now := time.Now()
+	next := map[string]turn.Action{
+		"alice": {Kind: "review", Since: now.Add(-time.Hour)},
+		"bob":   {Kind: "review", Since: now.Add(-3 * time.Hour)},
+		"carol": {Kind: "fix_tests", Critical: true, Since: now.Add(-time.Minute)},
+		"dave":  {Kind: "review", Critical: true, Since: now.Add(-2 * time.Hour)},
+	}
+	tests := []struct {
+		name  string
+		want  string
+		users []string
+	}{
+		{name: "no users", users: nil, want: ""},
+		{name: "nobody watched has an action", users: []string{"erin", "frank"}, want: "erin"},
+		{name: "longest waiting", users: []string{"alice", "bob"}, want: "bob"},
+		{name: "critical beats older", users: []string{"bob", "carol"}, want: "carol"},
+		{name: "oldest critical", users: []string{"alice", "carol", "dave"}, want: "dave"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repoModeActor(next, tt.users); got != tt.want {
+				t.Errorf("repoModeActor(%q) = %q, want %q", tt.users, got, tt.want)
+			}
+		})
+	}
+}
+
+// newRepoModeTestApp serves one PR per repo search. The PR in org/a is by the logged-in
+// user and waits on bob; the one in org/b waits on carol.
+func newRepoModeTestApp(t *testing.T, now time.Time) (*App, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	search := newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		queries = append(queries, q)
+		mu.Unlock()
+		repo, author := "org/a", "testuser"
+		if strings.Contains(q, "repo:org/b") {
+			repo, author = "org/b", "someone"
+		}
+		return fakeSearch{prs: []fakePR{{repo: repo, number: 1, title: "PR in " + repo, author: author, updated: now}}}
+	})
+	turnClient := newFakeTurn(t, func(_ context.Context, req turn.CheckRequest) fakeVerdict {
+		waitingOn := "bob"
+		if strings.Contains(req.URL, "org/b") {
+			waitingOn = "carol"
+		}
+		return fakeVerdict{next: map[string]any{waitingOn: map[string]any{"kind": "review", "critical": true, "since": now.Format(time.RFC3339)}}}
+	})
+	app := newFetchTestApp(t, search.URL, turnClient, "testuser")
+	app.repos = []string{"org/a", "org/b"}
+	return app, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(queries)
+	}
+}
+
+func TestRepoModeCounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		users       []string
+		wantBlocked int
+	}{
+		{name: "logged-in user", users: nil, wantBlocked: 0},
+		{name: "watched users", users: []string{"alice", "bob"}, wantBlocked: 1},
+		{name: "all waiting users", users: []string{"bob", "carol"}, wantBlocked: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, queries := newRepoModeTestApp(t, time.Now())
+			app.repoModeUsers = tt.users
+			app.updatePRs(context.Background())
+
+			if got := queries(); !slices.Contains(got, "repo:org/a is:open is:pr") || !slices.Contains(got, "repo:org/b is:open is:pr") {
+				t.Errorf("searches = %q, want one per repo", got)
+			}
+			if len(app.outgoing) != 0 || len(app.incoming) != 2 {
+				t.Fatalf("incoming=%d outgoing=%d, want every PR incoming, even the logged-in user's", len(app.incoming), len(app.outgoing))
+			}
+			if counts := app.countPRs(); counts.IncomingBlocked != tt.wantBlocked || counts.OutgoingTotal != 0 {
+				t.Errorf("counts = %+v, want %d incoming blocked", counts, tt.wantBlocked)
+			}
+			if got := app.baseTooltip(); got != "reviewGOOSE (Repo mode)" {
+				t.Errorf("tooltip = %q, want repo mode", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// statsFileName holds local-only response metrics in the cache directory. Nothing is uploaded.
+	statsFileName = "stats.json"
+	// maxResponseEvents caps the notification history kept in the stats file.
+	maxResponseEvents = 500
+	// responseStatsWindow is the span the Stats submenu summarizes.
+	responseStatsWindow = 7 * 24 * time.Hour
+)
+
+// responseEvent is one blocked-PR notification and, if it happened, the matching open.
+// PRs are stored as a hash so the stats file doesn't record which repos you work on.
+type responseEvent struct {
+	NotifiedAt time.Time `json:"notified_at"`
+	OpenedAt   time.Time `json:"opened_at,omitzero"`
+	PR         string    `json:"pr"`
+}
+
+// responseSummary aggregates notification responsiveness over a window.
+type responseSummary struct {
+	Median   time.Duration `json:"median_ns"`
+	Opened   int           `json:"opened"`
+	Unopened int           `json:"unopened"` // Notified but never opened; excluded from Median
+}
+
+// statsFile is the on-disk layout of statsFileName.
+type statsFile struct {
+	Summary responseSummary `json:"notification_response_week"`
+	Events  []responseEvent `json:"events"`
+}
+
+// responseTracker correlates notifications with the PR being opened from goose afterwards.
+type responseTracker struct {
+	path   string
+	events []responseEvent // Oldest first, capped at maxResponseEvents
+	mu     sync.Mutex
+}
+
+// newResponseTracker loads any history saved at path; a missing or unreadable file starts empty.
+func newResponseTracker(path string) *responseTracker {
+	rt := &responseTracker{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("[STATS] Failed to read stats file", "path", path, "error", err)
+		}
+		return rt
+	}
+	var sf statsFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		slog.Warn("[STATS] Ignoring unreadable stats file", "path", path, "error", err)
+		return rt
+	}
+	rt.events = sf.Events
+	return rt
+}
+
+// responseKey identifies a PR from any goose-opened link to it (e.g. the /checks tab),
+// returning "" for URLs that aren't GitHub PRs.
+func responseKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(u.Host, "github.com") {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.Join(parts[:4], "/"))))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// recordNotification notes that the PR was just announced. A repeat notification while an
+// earlier one is still unopened keeps the first, so the delta measures from the first honk.
+func (rt *responseTracker) recordNotification(prURL string, at time.Time) {
+	key := responseKey(prURL)
+	if key == "" {
+		return
+	}
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.pendingLocked(key, at) >= 0 {
+		return
+	}
+	rt.events = append(rt.events, responseEvent{PR: key, NotifiedAt: at})
+	if len(rt.events) > maxResponseEvents {
+		rt.events = rt.events[len(rt.events)-maxResponseEvents:]
+	}
+}
+
+// recordOpen matches an open to the PR's pending notification, reporting whether there was one.
+// PRs opened without a prior notification are ignored.
+func (rt *responseTracker) recordOpen(rawURL string, at time.Time) bool {
+	key := responseKey(rawURL)
+	if key == "" {
+		return false
+	}
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	i := rt.pendingLocked(key, at)
+	if i < 0 {
+		return false
+	}
+	rt.events[i].OpenedAt = at
+	return true
+}
+
+// pendingLocked returns the index of the newest unopened notification for key within the window, or -1.
+func (rt *responseTracker) pendingLocked(key string, now time.Time) int {
+	for i := len(rt.events) - 1; i >= 0; i-- {
+		e := rt.events[i]
+		if now.Sub(e.NotifiedAt) > responseStatsWindow {
+			break
+		}
+		if e.PR == key && e.OpenedAt.IsZero() {
+			return i
+		}
+	}
+	return -1
+}
+
+// summary aggregates notifications sent within responseStatsWindow of now.
+func (rt *responseTracker) summary(now time.Time) responseSummary {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return summarizeResponses(rt.events, now)
+}
+
+func summarizeResponses(events []responseEvent, now time.Time) responseSummary {
+	var s responseSummary
+	var deltas []time.Duration
+	for _, e := range events {
+		if now.Sub(e.NotifiedAt) > responseStatsWindow {
+			continue
+		}
+		if e.OpenedAt.IsZero() {
+			s.Unopened++
+			continue
+		}
+		deltas = append(deltas, e.OpenedAt.Sub(e.NotifiedAt))
+	}
+	s.Opened = len(deltas)
+	if len(deltas) == 0 {
+		return s
+	}
+	slices.Sort(deltas)
+	mid := len(deltas) / 2
+	if len(deltas)%2 == 1 {
+		s.Median = deltas[mid]
+	} else {
+		s.Median = (deltas[mid-1] + deltas[mid]) / 2
+	}
+	return s
+}
+
+// save writes the history and this week's summary to the stats file.
+func (rt *responseTracker) save(now time.Time) error {
+	rt.mu.Lock()
+	sf := statsFile{Summary: summarizeResponses(rt.events, now), Events: slices.Clone(rt.events)}
+	rt.mu.Unlock()
+
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(rt.path), 0o700); err != nil {
+		return fmt.Errorf("create stats directory: %w", err)
+	}
+	if err := os.WriteFile(rt.path, data, 0o600); err != nil {
+		return fmt.Errorf("write stats file: %w", err)
+	}
+	return nil
+}
+
+// trackingResponses reports whether the opt-in response metrics are on. Silent mode
+// neither notifies nor opens anything, so it records nothing.
+func (app *App) trackingResponses() bool {
+	return app.responses != nil && !app.silentMode && app.readSetting(&app.trackResponseTimes)
+}
+
+// recordNotified starts the clock for a blocked-PR notification.
+func (app *App) recordNotified(prURL string) {
+	if !app.trackingResponses() {
+		return
+	}
+	now := time.Now()
+	app.responses.recordNotification(prURL, now)
+	if err := app.responses.save(now); err != nil {
+		slog.Warn("[STATS] Failed to save stats", "error", err)
+	}
+}
+
+// recordOpened stops the clock if rawURL is a PR goose notified about.
+func (app *App) recordOpened(rawURL string) {
+	if !app.trackingResponses() {
+		return
+	}
+	now := time.Now()
+	if !app.responses.recordOpen(rawURL, now) {
+		return
+	}
+	slog.Debug("[STATS] Notified PR opened")
+	if err := app.responses.save(now); err != nil {
+		slog.Warn("[STATS] Failed to save stats", "error", err)
+	}
+}
+
+// statsTitles are the Stats submenu lines; empty unless response tracking is on.
+func (app *App) statsTitles() []string {
+	if !app.trackingResponses() {
+		return nil
+	}
+	s := app.responses.summary(time.Now())
+	median := msg("stats.median.none")
+	if s.Opened > 0 {
+		median = msg("stats.median", formatResponseTime(s.Median))
+	}
+	return []string{msg("stats.menu"), median, msg("stats.unopened", s.Unopened)}
+}
+
+// formatResponseTime renders a response delta compactly: 45s, 11m, 2h5m.
+func formatResponseTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// addStatsMenu adds the Stats submenu while response tracking is on.
+func (app *App) addStatsMenu() {
+	titles := app.statsTitles()
+	if len(titles) == 0 {
+		return
+	}
+	statsMenu := app.systrayInterface.AddMenuItem(titles[0], msg("stats.menu.tooltip"))
+	for _, title := range titles[1:] {
+		statsMenu.AddSubMenuItem(title, "").Disable()
+	}
+}
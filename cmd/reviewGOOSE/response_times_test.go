@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+const (
+	responsePR1 = "https://github.com/acme/widgets/pull/1"
+	responsePR2 = "https://github.com/acme/widgets/pull/2"
+	responsePR3 = "https://github.com/acme/widgets/pull/3"
+)
+
+func TestResponseTrackerCorrelation(t *testing.T) {
+	rt := newResponseTracker(filepath.Join(t.TempDir(), statsFileName))
+	start := time.Now().Add(-time.Hour)
+
+	rt.recordNotification(responsePR1, start)
+	rt.recordNotification(responsePR2, start)
+
+	// The checks tab and query parameters still identify the notified PR.
+	if !rt.recordOpen(responsePR1+"/checks?goose=review", start.Add(11*time.Minute)) {
+		t.Error("opening a notified PR should correlate")
+	}
+	// Opened in the browser without ever being notified: ignored.
+	if rt.recordOpen(responsePR3, start.Add(time.Minute)) {
+		t.Error("opening a PR that was never notified should be ignored")
+	}
+	// A second open doesn't move the first response time.
+	if rt.recordOpen(responsePR1, start.Add(30*time.Minute)) {
+		t.Error("an already-opened notification should not correlate again")
+	}
+	if rt.recordOpen("https://my.reviewgoose.dev/", start) {
+		t.Error("non-PR URLs should be ignored")
+	}
+
+	got := rt.summary(start.Add(time.Hour))
+	want := responseSummary{Median: 11 * time.Minute, Opened: 1, Unopened: 1}
+	if got != want {
+		t.Errorf("summary = %+v, want %+v", got, want)
+	}
+}
+
+func TestResponseTrackerRepeatNotificationKeepsFirst(t *testing.T) {
+	rt := newResponseTracker(filepath.Join(t.TempDir(), statsFileName))
+	start := time.Now().Add(-time.Hour)
+
+	rt.recordNotification(responsePR1, start)
+	rt.recordNotification(responsePR1, start.Add(5*time.Minute))
+	rt.recordOpen(responsePR1, start.Add(20*time.Minute))
+
+	if got := rt.summary(start.Add(time.Hour)); got.Median != 20*time.Minute || got.Opened != 1 || got.Unopened != 0 {
+		t.Errorf("summary = %+v, want one 20m response", got)
+	}
+}
+
+func TestSummarizeResponses(t *testing.T) {
+	now := time.Now()
+	event := func(notifiedAgo, response time.Duration) responseEvent {
+		e := responseEvent{PR: "k", NotifiedAt: now.Add(-notifiedAgo)}
+		if response > 0 {
+			e.OpenedAt = e.NotifiedAt.Add(response)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name   string
+		events []responseEvent
+		want   responseSummary
+	}{
+		{name: "empty", want: responseSummary{}},
+		{
+			name:   "odd count",
+			events: []responseEvent{event(time.Hour, 3*time.Minute), event(time.Hour, time.Minute), event(time.Hour, 20*time.Minute)},
+			want:   responseSummary{Median: 3 * time.Minute, Opened: 3},
+		},
+		{
+			name:   "even count averages the middle pair",
+			events: []responseEvent{event(time.Hour, 2*time.Minute), event(time.Hour, 10*time.Minute)},
+			want:   responseSummary{Median: 6 * time.Minute, Opened: 2},
+		},
+		{
+			name:   "never opened is excluded from the median but counted",
+			events: []responseEvent{event(time.Hour, 4*time.Minute), event(time.Hour, 0), event(2*time.Hour, 0)},
+			want:   responseSummary{Median: 4 * time.Minute, Opened: 1, Unopened: 2},
+		},
+		{
+			name:   "only this week counts",
+			events: []responseEvent{event(8*24*time.Hour, time.Minute), event(8*24*time.Hour, 0), event(time.Hour, 9*time.Minute)},
+			want:   responseSummary{Median: 9 * time.Minute, Opened: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeResponses(tt.events, now); got != tt.want {
+				t.Errorf("summarizeResponses() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseTrackerCapsEvents(t *testing.T) {
+	rt := newResponseTracker(filepath.Join(t.TempDir(), statsFileName))
+	start := time.Now().Add(-time.Hour)
+	for i := range maxResponseEvents + 10 {
+		rt.recordNotification(responsePR1, start.Add(time.Duration(i)*time.Second))
+		rt.recordOpen(responsePR1, start.Add(time.Duration(i)*time.Second))
+	}
+	if len(rt.events) != maxResponseEvents {
+		t.Errorf("kept %d events, want %d", len(rt.events), maxResponseEvents)
+	}
+}
+
+func TestResponseTrackerPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), statsFileName)
+	rt := newResponseTracker(path)
+	start := time.Now().Add(-time.Hour)
+	rt.recordNotification(responsePR1, start)
+	rt.recordOpen(responsePR1, start.Add(7*time.Minute))
+	if err := rt.save(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := newResponseTracker(path)
+	if got := reloaded.summary(time.Now()); got.Median != 7*time.Minute || got.Opened != 1 {
+		t.Errorf("reloaded summary = %+v, want one 7m response", got)
+	}
+}
+
+func TestResponseTrackingIsOptIn(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	app.responses = newResponseTracker(filepath.Join(t.TempDir(), statsFileName))
+	app.browser = silentBrowser{} // Stands in for a browser; silentMode stays off
+
+	app.recordNotified(responsePR1)
+	if err := app.openBrowser(context.Background(), responsePR1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(app.responses.events) != 0 {
+		t.Fatalf("nothing should be recorded while tracking is off, got %+v", app.responses.events)
+	}
+
+	app.rebuildMenu(context.Background())
+	mock.clickSetting(t, "response_times")
+	app.recordNotified(responsePR1)
+	if err := app.openBrowser(context.Background(), responsePR1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.responses.summary(time.Now()); got.Opened != 1 {
+		t.Errorf("summary = %+v, want the open to be recorded", got)
+	}
+
+	app.rebuildMenu(context.Background())
+	if !slices.Contains(mock.menuItems, "📊 Stats") {
+		t.Errorf("expected the Stats submenu once tracking is on, got %v", mock.menuItems)
+	}
+}
+
+func TestFormatResponseTime(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		45 * time.Second:              "45s",
+		11 * time.Minute:              "11m",
+		2*time.Hour + 5*time.Minute:   "2h5m",
+		26*time.Hour + 30*time.Minute: "26h30m",
+	} {
+		if got := formatResponseTime(d); got != want {
+			t.Errorf("formatResponseTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// reviewRequestMaxPages bounds how much of a long PR's timeline is read for its review request.
+const reviewRequestMaxPages = 3
+
+// reviewRequest is who asked for my review on an incoming PR, per the issue timeline.
+type reviewRequest struct {
+	at    time.Time
+	login string // Empty when auto is set
+	auto  bool   // CODEOWNERS, team auto-assignment, or a bot/app made the request
+}
+
+// latestReviewRequest finds the most recent review_requested event naming me, falling back
+// to a team request when I was never requested directly. Requests made by an app or bot,
+// or through a team, count as automated assignments.
+func latestReviewRequest(events []*github.Timeline, me string) (reviewRequest, bool) {
+	var direct, team *github.Timeline
+	for _, e := range events {
+		if e.GetEvent() != "review_requested" {
+			continue
+		}
+		switch {
+		case e.Reviewer != nil && strings.EqualFold(e.Reviewer.GetLogin(), me):
+			if direct == nil || !e.GetCreatedAt().Before(direct.GetCreatedAt().Time) {
+				direct = e
+			}
+		case e.RequestedTeam != nil:
+			if team == nil || !e.GetCreatedAt().Before(team.GetCreatedAt().Time) {
+				team = e
+			}
+		default:
+		}
+	}
+	e := direct
+	if e == nil {
+		e = team
+	}
+	if e == nil {
+		return reviewRequest{}, false
+	}
+
+	requester := e.Requester
+	if requester == nil {
+		requester = e.Actor
+	}
+	login := requester.GetLogin()
+	req := reviewRequest{at: e.GetCreatedAt().Time, login: login}
+	if e == team || e.PerformedViaGithubApp != nil || login == "" || isBotLogin(login) || requester.GetType() == "Bot" {
+		req.login, req.auto = "", true
+	}
+	return req, true
+}
+
+// reviewRequester looks up who requested my review on a blocked incoming PR. Lookups are
+// cached per PR revision, which any new review request moves; failures are cached too, so a repo whose timeline I can't read
+// isn't retried every cycle, and are only logged at debug level.
+func (app *App) reviewRequester(ctx context.Context, repo string, number int, url string, updatedAt time.Time, me string) (reviewRequest, bool) {
+	if app.client == nil || app.reviewRequests == nil {
+		return reviewRequest{}, false
+	}
+	if req, found, ok := app.reviewRequests.get(url, updatedAt); ok {
+		return req, found
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return reviewRequest{}, false
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, turnAPITimeout)
+	defer cancel()
+	var events []*github.Timeline
+	opts := &github.ListOptions{PerPage: 100}
+	for range reviewRequestMaxPages {
+		page, resp, err := app.client.Issues.ListIssueTimeline(apiCtx, owner, name, number, opts)
+		if err != nil {
+			if cacheableFailure(err) {
+				app.reviewRequests.put(url, updatedAt, reviewRequest{}, false)
+			}
+			if goneStatus(err) != 0 {
+				app.quarantinePR(url, err)
+			}
+			slog.Debug("[GITHUB] Timeline lookup failed", "url", url, "error", err)
+			return reviewRequest{}, false
+		}
+		events = append(events, page...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	req, found := latestReviewRequest(events, me)
+	app.reviewRequests.put(url, updatedAt, req, found)
+	if found {
+		slog.Debug("[GITHUB] Found review request", "url", url, "requester", req.login, "auto", req.auto)
+	}
+	return req, found
+}
+
+// blockedReviewRequester is reviewRequester limited to incoming PRs Turn says are waiting on me,
+// so the timeline is never fetched for anything else.
+func (app *App) blockedReviewRequester(ctx context.Context, data *turn.CheckResponse, isOwner bool, repo string, number int, url string, updatedAt time.Time, me string) (reviewRequest, bool) {
+	if isOwner || data == nil {
+		return reviewRequest{}, false
+	}
+	if _, waitingOnMe := data.Analysis.NextAction[me]; !waitingOnMe {
+		return reviewRequest{}, false
+	}
+	return app.reviewRequester(ctx, repo, number, url, updatedAt, me)
+}
+
+// reviewRequestDetail is the tooltip line saying who requested my review, or "" if unknown.
+func reviewRequestDetail(pr PR, now time.Time) string {
+	switch {
+	case pr.RequestedAuto:
+		return msg("review_request.auto")
+	case pr.RequestedBy == "":
+		return ""
+	case pr.RequestedAt.IsZero():
+		return msg("review_request.by", pr.RequestedBy)
+	default:
+		return msg("review_request.by.since", pr.RequestedBy, stuckDuration(now.Sub(pr.RequestedAt)))
+	}
+}
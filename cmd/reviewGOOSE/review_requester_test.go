@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func requestEvent(reviewer, requester string, at time.Time) *github.Timeline {
+	e := &github.Timeline{
+		Event:     github.String("review_requested"),
+		CreatedAt: &github.Timestamp{Time: at},
+		Actor:     &github.User{Login: github.String(requester)},
+		Requester: &github.User{Login: github.String(requester)},
+	}
+	if reviewer != "" {
+		e.Reviewer = &github.User{Login: github.String(reviewer)}
+	}
+	return e
+}
+
+func TestLatestReviewRequest(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	viaApp := requestEvent("me", "author", day)
+	viaApp.PerformedViaGithubApp = &github.App{}
+	teamRequest := requestEvent("", "carol", day)
+	teamRequest.RequestedTeam = &github.Team{Slug: github.String("reviewers")}
+	botType := requestEvent("me", "robot", day)
+	botType.Requester.Type = github.String("Bot")
+
+	tests := []struct {
+		name   string
+		events []*github.Timeline
+		want   reviewRequest
+		found  bool
+	}{
+		{name: "no events"},
+		{name: "someone else requested", events: []*github.Timeline{requestEvent("dave", "carol", day)}},
+		{
+			name:   "author requested me",
+			events: []*github.Timeline{requestEvent("ME", "author", day)},
+			want:   reviewRequest{at: day, login: "author"}, found: true,
+		},
+		{
+			name: "latest request wins",
+			events: []*github.Timeline{
+				requestEvent("me", "author", day),
+				{Event: github.String("commented"), CreatedAt: &github.Timestamp{Time: day.Add(time.Hour)}},
+				requestEvent("me", "carol", day.Add(24*time.Hour)),
+			},
+			want: reviewRequest{at: day.Add(24 * time.Hour), login: "carol"}, found: true,
+		},
+		{
+			name:   "direct request beats a team request",
+			events: []*github.Timeline{requestEvent("me", "carol", day), teamRequest},
+			want:   reviewRequest{at: day, login: "carol"}, found: true,
+		},
+		{name: "team request is auto-assigned", events: []*github.Timeline{teamRequest}, want: reviewRequest{at: day, auto: true}, found: true},
+		{name: "app request is auto-assigned", events: []*github.Timeline{viaApp}, want: reviewRequest{at: day, auto: true}, found: true},
+		{
+			name:   "bot login is auto-assigned",
+			events: []*github.Timeline{requestEvent("me", "codeowners[bot]", day)},
+			want:   reviewRequest{at: day, auto: true}, found: true,
+		},
+		{name: "bot account type is auto-assigned", events: []*github.Timeline{botType}, want: reviewRequest{at: day, auto: true}, found: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := latestReviewRequest(tt.events, "me")
+			if found != tt.found || got != tt.want {
+				t.Errorf("latestReviewRequest() = %+v, %v; want %+v, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestReviewRequesterCachesByUpdatedAt(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/org/repo/issues/7/timeline" {
+			t.Errorf("unexpected timeline request: %s", r.URL)
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+		}
+		events := []map[string]any{{"event": "labeled"}}
+		if r.URL.Query().Get("page") == "2" {
+			events = []map[string]any{{
+				"event":              "review_requested",
+				"created_at":         "2026-03-01T00:00:00Z",
+				"requested_reviewer": map[string]any{"login": "me"},
+				"review_requester":   map[string]any{"login": "carol"},
+			}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(events); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	app := &App{
+		mu:             sync.RWMutex{},
+		client:         newETagTestClient(t, server.URL),
+		reviewRequests: newPRRevisionCache[reviewRequest](),
+	}
+	ctx := context.Background()
+	url := "https://github.com/org/repo/pull/7"
+	updated := time.Now()
+	blocked := &turn.CheckResponse{Analysis: turn.Analysis{NextAction: map[string]turn.Action{"me": {Kind: "review"}}}}
+
+	for range 3 {
+		req, found := app.blockedReviewRequester(ctx, blocked, false, "org/repo", 7, url, updated, "me")
+		if !found || req.login != "carol" {
+			t.Fatalf("blockedReviewRequester() = %+v, %v; want carol", req, found)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("timeline fetched %d pages, want 2 (one lookup, cached)", got)
+	}
+
+	// A newer UpdatedAt looks again
+	app.blockedReviewRequester(ctx, blocked, false, "org/repo", 7, url, updated.Add(time.Minute), "me")
+	if got := requests.Load(); got != 4 {
+		t.Errorf("timeline fetched %d pages after an update, want 4", got)
+	}
+
+	// PRs that aren't waiting on me, and my own PRs, never hit the timeline
+	notBlocked := &turn.CheckResponse{Analysis: turn.Analysis{NextAction: map[string]turn.Action{"dave": {Kind: "review"}}}}
+	if _, found := app.blockedReviewRequester(ctx, notBlocked, false, "org/repo", 8, "https://github.com/org/repo/pull/8", updated, "me"); found {
+		t.Error("found a requester for a PR not waiting on me")
+	}
+	if _, found := app.blockedReviewRequester(ctx, blocked, true, "org/repo", 9, "https://github.com/org/repo/pull/9", updated, "me"); found {
+		t.Error("found a requester for my own PR")
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("timeline fetched for PRs that don't qualify (%d pages)", got)
+	}
+}
+
+func TestReviewRequesterCachesFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	app := &App{mu: sync.RWMutex{}, client: newETagTestClient(t, server.URL), reviewRequests: newPRRevisionCache[reviewRequest]()}
+	updated := time.Now()
+	for range 2 {
+		if _, found := app.reviewRequester(context.Background(), "org/repo", 7, "https://github.com/org/repo/pull/7", updated, "me"); found {
+			t.Error("a failed lookup reported a requester")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("timeline fetched %d times, want the failure cached", got)
+	}
+}
+
+func TestReviewRequestTooltip(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		pr   PR
+		want string
+	}{
+		{name: "unknown", pr: PR{Title: "Fix"}, want: "Fix (2h)"},
+		{name: "requested by", pr: PR{Title: "Fix", RequestedBy: "carol", RequestedAt: now.Add(-25 * time.Hour)}, want: "Fix (2h) - review requested by @carol, 1d ago"},
+		{name: "auto-assigned", pr: PR{Title: "Fix", RequestedAuto: true, RequestedAt: now}, want: "Fix (2h) - (auto-assigned)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatMenuTooltip(tt.pr, DisplayRepoNumber, "2h"); got != tt.want {
+				t.Errorf("formatMenuTooltip() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+)
+
+// A review session walks me through the blocked incoming PRs one at a time. "Start
+// review session" opens the first PR as the menu sorts them; each time the open PR
+// unblocks, the next one opens, until the queue snapshot taken at the start runs out.
+// Opening some other PR meanwhile holds auto-advance until I continue from the menu.
+
+// defaultSessionCap is how many PRs a session queues unless settings say otherwise.
+const defaultSessionCap = 5
+
+// Sessions open tabs from their own allowance, so they neither use up nor wait on the
+// auto-open limits.
+const (
+	sessionOpensPerMinute = 3
+	sessionOpensPerDay    = 50
+)
+
+// reviewSession is a session in progress.
+type reviewSession struct {
+	started  time.Time
+	queue    []PR // Blocked incoming PRs when the session started, in menu order
+	index    int  // The PR under review
+	reviewed int  // PRs that unblocked while under review
+	held     bool // Auto-advance is held: I opened another PR, or the allowance ran out
+}
+
+// current returns the PR under review.
+func (s *reviewSession) current() *PR {
+	return &s.queue[s.index]
+}
+
+// status is the session's menu line, like "Session: 2 of 5, next: org/repo #88".
+func (s *reviewSession) status() string {
+	if s.index+1 < len(s.queue) {
+		next := &s.queue[s.index+1]
+		return msg("session.status", s.index+1, len(s.queue), fmt.Sprintf("%s #%d", next.Repository, next.Number))
+	}
+	return msg("session.status.last", s.index+1, len(s.queue))
+}
+
+// stillBlocked reports whether view lists pr as blocked on me. A PR missing from a
+// partial fetch may still be blocked.
+func stillBlocked(view *prView, pr *PR, partial bool) bool {
+	shown := findPR(pr.URL, view.incoming)
+	if shown == nil {
+		return partial
+	}
+	return shown.NeedsReview || shown.IsBlocked
+}
+
+// sessionCapacity is how many PRs a new session queues.
+func (app *App) sessionCapacity() int {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.sessionCap > 0 {
+		return app.sessionCap
+	}
+	return defaultSessionCap
+}
+
+// startSession queues the blocked incoming PRs and opens the first, returning how many
+// it queued.
+func (app *App) startSession(ctx context.Context, now time.Time) int {
+	queue := app.blockedIncomingToOpen()
+	if len(queue) == 0 {
+		return 0
+	}
+	if limit := app.sessionCapacity(); len(queue) > limit {
+		queue = queue[:limit]
+	}
+	app.mu.Lock()
+	app.session = &reviewSession{started: now, queue: queue}
+	if app.sessionBudget == nil {
+		app.sessionBudget = ratelimit.NewBrowserRateLimiter(0, sessionOpensPerMinute, sessionOpensPerDay)
+	}
+	app.mu.Unlock()
+	slog.Info("[SESSION] Review session started", "queued", len(queue))
+	app.openSessionPR(ctx)
+	return len(queue)
+}
+
+// stopSession ends the session early.
+func (app *App) stopSession() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.session == nil {
+		return
+	}
+	slog.Info("[SESSION] Review session stopped", "reviewed", app.session.reviewed, "queued", len(app.session.queue))
+	app.session = nil
+}
+
+// openSessionPR opens the PR under review, holding the session if its allowance is
+// used up.
+func (app *App) openSessionPR(ctx context.Context) {
+	app.mu.Lock()
+	s := app.session
+	if s == nil {
+		app.mu.Unlock()
+		return
+	}
+	pr := *s.current()
+	budget := app.sessionBudget
+	app.mu.Unlock()
+
+	if budget != nil && !budget.AllowManualOpen(pr.URL) {
+		slog.Warn("[SESSION] Session open allowance used up, holding", "repo", pr.Repository, "number", pr.Number)
+		app.holdSession(pr.URL)
+		return
+	}
+	app.mu.Lock()
+	if app.session == s {
+		s.held = false
+	}
+	app.mu.Unlock()
+	// The goose parameter marks this as goose's own open, not a manual one
+	if err := app.openBrowser(ctx, prLink(&pr), pr.ActionKind.gooseParam()); err != nil {
+		slog.Error("[SESSION] Failed to open PR", "url", sanitizeForLog(pr.URL), "error", err)
+	}
+}
+
+// holdSession stops the session from advancing on its own while it's still on url.
+func (app *App) holdSession(url string) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if s := app.session; s != nil && s.current().URL == url {
+		s.held = true
+	}
+}
+
+// noteSessionOpen holds the session when I open a PR other than the one under review.
+// Opens that carry a goose parameter are goose's own.
+func (app *App) noteSessionOpen(rawURL, gooseParam string) {
+	if gooseParam != "" {
+		return
+	}
+	key := prKeyOf(rawURL)
+	if key.number == 0 {
+		return
+	}
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	s := app.session
+	if s == nil || s.held || prKeyOf(s.current().URL) == key {
+		return
+	}
+	slog.Info("[SESSION] Another PR was opened, holding auto-advance", "url", sanitizeForLog(rawURL))
+	s.held = true
+}
+
+// advanceSession moves past the PR under review once view no longer lists it as
+// blocked, skipping queued PRs that unblocked meanwhile. It opens the next PR unless
+// the session is held or startup grace is on, and ends the session with a summary
+// notification when the queue runs out.
+func (app *App) advanceSession(ctx context.Context, view *prView) {
+	app.mu.Lock()
+	s := app.session
+	partial := app.partialFetch != nil
+	if s == nil || stillBlocked(view, s.current(), partial) {
+		app.mu.Unlock()
+		return
+	}
+	s.reviewed++
+	s.index++
+	for s.index < len(s.queue) && !stillBlocked(view, s.current(), partial) {
+		s.index++
+	}
+	if s.index == len(s.queue) {
+		app.session = nil
+		app.mu.Unlock()
+		app.finishSession(s)
+		return
+	}
+	held, reviewed := s.held, s.reviewed
+	pr := *s.current()
+	app.mu.Unlock()
+
+	slog.Info("[SESSION] PR under review unblocked, advancing",
+		"reviewed", reviewed, "next_repo", pr.Repository, "next_number", pr.Number, "held", held)
+	if held || app.inGracePeriod() {
+		return
+	}
+	app.openSessionPR(ctx)
+}
+
+// finishSession sends "Session complete: 4 PRs reviewed in 38m".
+func (app *App) finishSession(s *reviewSession) {
+	elapsed := formatResponseTime(time.Since(s.started))
+	message := msg("session.complete.message", s.reviewed, elapsed)
+	if s.reviewed == 1 {
+		message = msg("session.complete.message.one", elapsed)
+	}
+	slog.Info("[SESSION] Review session complete", "reviewed", s.reviewed, "elapsed", elapsed)
+	if err := app.notify(msg("session.complete"), message); err != nil {
+		slog.Error("[SESSION] Failed to send the session summary", "error", err)
+	}
+}
+
+// sessionTitles lists the session lines for change detection.
+func (app *App) sessionTitles(blockedIncoming int) []string {
+	app.mu.RLock()
+	s := app.session
+	var status string
+	if s != nil {
+		status = s.status()
+	}
+	app.mu.RUnlock()
+	if s != nil {
+		return []string{status, msg("session.stop")}
+	}
+	if blockedIncoming == 0 {
+		return nil
+	}
+	return []string{msg("session.start")}
+}
+
+// addReviewSession adds "Start review session" under the Incoming header, or, during a
+// session, its status line, which reopens the PR under review, and "Stop session".
+func (app *App) addReviewSession(ctx context.Context, blockedIncoming int) {
+	app.mu.RLock()
+	s := app.session
+	var status string
+	var held bool
+	if s != nil {
+		status, held = s.status(), s.held
+	}
+	app.mu.RUnlock()
+
+	if s != nil {
+		tooltip := msg("session.status.tooltip")
+		if held {
+			tooltip = msg("session.held.tooltip")
+		}
+		app.systrayInterface.AddMenuItem(status, tooltip).Click(func() {
+			app.openSessionPR(ctx)
+			app.rebuildMenu(ctx)
+		})
+		app.systrayInterface.AddMenuItem(msg("session.stop"), "").Click(func() {
+			app.stopSession()
+			app.rebuildMenu(ctx)
+		})
+		return
+	}
+	if blockedIncoming == 0 {
+		return
+	}
+	app.systrayInterface.AddMenuItem(msg("session.start"), msg("session.start.tooltip", app.sessionCapacity())).Click(func() {
+		app.startSession(ctx, time.Now())
+		app.rebuildMenu(ctx)
+	})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newSessionTestApp returns an app with blocked incoming PRs 1..n and a session
+// allowance of ten opens.
+func newSessionTestApp(t *testing.T, blocked int) (*App, *countingBrowser, *messageNotifier) {
+	t.Helper()
+	app, browser := newBatchOpenTestApp(t, blocked)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.sessionBudget = &fakeOpenBudget{n: 10}
+	return app, browser, notifier
+}
+
+// unblock marks incoming PR number as no longer needing me and advances the session.
+func unblock(ctx context.Context, app *App, number int) {
+	for i := range app.incoming {
+		if app.incoming[i].Number == number {
+			app.incoming[i].NeedsReview = false
+		}
+	}
+	app.advanceSession(ctx, app.snapshotPRs())
+}
+
+// openedNumbers lists the PR numbers the browser opened, in order.
+func openedNumbers(b *countingBrowser) []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var numbers []int
+	for _, u := range b.urls {
+		numbers = append(numbers, prKeyOf(u).number)
+	}
+	return numbers
+}
+
+func TestReviewSessionAdvancesAsPRsUnblock(t *testing.T) {
+	ctx := context.Background()
+	app, browser, notifier := newSessionTestApp(t, 3)
+
+	if queued := app.startSession(ctx, time.Now().Add(-38*time.Minute)); queued != 3 {
+		t.Fatalf("startSession queued %d PRs, want 3", queued)
+	}
+	if got := app.sessionTitles(3); !slices.Equal(got, []string{"Session: 1 of 3, next: acme/widgets #2", "⏹ Stop session"}) {
+		t.Errorf("session titles = %q", got)
+	}
+
+	// Still blocked: nothing happens
+	app.advanceSession(ctx, app.snapshotPRs())
+	unblock(ctx, app, 1)
+	// #3 unblocked before its turn, so it's skipped along with #2
+	app.incoming[2].NeedsReview = false
+	unblock(ctx, app, 2)
+
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("opened %v, want [1 2]", got)
+	}
+	if app.session != nil {
+		t.Error("session still running after its queue ran out")
+	}
+	if want := []string{"Session complete: 2 PRs reviewed in 38m"}; !slices.Equal(notifier.notes, want) {
+		t.Errorf("notifications = %q, want %q", notifier.notes, want)
+	}
+}
+
+func TestReviewSessionHoldsOnManualOpen(t *testing.T) {
+	ctx := context.Background()
+	app, browser, _ := newSessionTestApp(t, 3)
+	app.startSession(ctx, time.Now())
+
+	// Opening the PR under review doesn't hold; opening another does
+	if err := app.openBrowser(ctx, app.incoming[0].URL, ""); err != nil {
+		t.Fatal(err)
+	}
+	if app.session.held {
+		t.Fatal("reopening the PR under review held the session")
+	}
+	if err := app.openBrowser(ctx, app.incoming[2].URL, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !app.session.held {
+		t.Fatal("opening another PR didn't hold the session")
+	}
+
+	unblock(ctx, app, 1)
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1, 1, 3}) {
+		t.Errorf("opened %v, want no auto-advance while held", got)
+	}
+	if app.session.current().Number != 2 {
+		t.Errorf("session is on #%d, want #2", app.session.current().Number)
+	}
+
+	// Continuing from the menu opens the PR under review and resumes auto-advance
+	app.openSessionPR(ctx)
+	if app.session.held {
+		t.Error("session still held after continuing")
+	}
+	unblock(ctx, app, 2)
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1, 1, 3, 2, 3}) {
+		t.Errorf("opened %v, want #2 then #3 after continuing", got)
+	}
+}
+
+func TestReviewSessionCapAndStop(t *testing.T) {
+	ctx := context.Background()
+	app, browser, notifier := newSessionTestApp(t, 4)
+	app.sessionCap = 2
+
+	if queued := app.startSession(ctx, time.Now()); queued != 2 {
+		t.Errorf("startSession queued %d PRs, want the cap of 2", queued)
+	}
+	app.stopSession()
+	unblock(ctx, app, 1)
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1}) {
+		t.Errorf("opened %v after stopping, want [1]", got)
+	}
+	if len(notifier.notes) != 0 {
+		t.Errorf("notifications = %q, want none for a stopped session", notifier.notes)
+	}
+	if got := app.sessionTitles(3); !slices.Equal(got, []string{"▶️ Start review session"}) {
+		t.Errorf("session titles = %q", got)
+	}
+	if got := app.sessionTitles(0); got != nil {
+		t.Errorf("session titles with nothing blocked = %q, want none", got)
+	}
+}
+
+func TestReviewSessionHoldsWhenAllowanceRunsOut(t *testing.T) {
+	ctx := context.Background()
+	app, browser, _ := newSessionTestApp(t, 3)
+	app.sessionBudget = &fakeOpenBudget{n: 1}
+
+	app.startSession(ctx, time.Now())
+	unblock(ctx, app, 1)
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1}) {
+		t.Errorf("opened %v, want only #1 within the allowance", got)
+	}
+	if !app.session.held || app.session.current().Number != 2 {
+		t.Errorf("session held %v on #%d, want held on #2", app.session.held, app.session.current().Number)
+	}
+}
+
+func TestReviewSessionWaitsOutPartialFetch(t *testing.T) {
+	ctx := context.Background()
+	app, browser, _ := newSessionTestApp(t, 2)
+	app.startSession(ctx, time.Now())
+
+	// #1 is missing because its search failed, not because it unblocked
+	app.partialFetch = &PartialError{Queries: []string{"q"}, Total: 2}
+	view := app.snapshotPRs()
+	view.incoming = view.incoming[1:]
+	app.advanceSession(ctx, view)
+	if app.session.current().Number != 1 || browser.opened() != 1 {
+		t.Errorf("session advanced to #%d during a partial fetch", app.session.current().Number)
+	}
+
+	app.partialFetch = nil
+	app.advanceSession(ctx, view)
+	if got := openedNumbers(browser); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("opened %v, want #2 once the fetch is complete", got)
+	}
+}
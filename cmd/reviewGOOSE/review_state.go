@@ -0,0 +1,55 @@
+package main
+
+import (
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const (
+	// reviewApproved is the PR.MyReviewState for an approval that still covers the head commit.
+	reviewApproved = string(prx.ReviewStateApproved)
+	// waitingOnOthersReason replaces Turn's reason for incoming PRs I've already approved.
+	waitingOnOthersReason = "you approved — waiting on others"
+)
+
+// myReviewState returns the user's latest submitted review on a PR according to Turn.
+// An approval followed by new commits no longer counts, so the PR is promoted again;
+// GitHub reports dismissed approvals as a different state, which has the same effect.
+func myReviewState(data *turn.CheckResponse, user string) string {
+	if data == nil || user == "" {
+		return ""
+	}
+	switch state := data.PullRequest.Reviewers[user]; state {
+	case prx.ReviewStateApproved:
+		if commitsSinceApproval(data.Events, user) {
+			return ""
+		}
+		return reviewApproved
+	case prx.ReviewStateChangesRequested, prx.ReviewStateCommented:
+		return string(state)
+	default:
+		return ""
+	}
+}
+
+// commitsSinceApproval reports whether any commit landed after the user's most recent approval.
+func commitsSinceApproval(events []prx.Event, user string) bool {
+	var approvedAt, lastCommit time.Time
+	for i := range events {
+		e := &events[i]
+		switch e.Kind {
+		case prx.EventKindReview:
+			if e.Actor == user && e.Outcome == reviewApproved && e.Timestamp.After(approvedAt) {
+				approvedAt = e.Timestamp
+			}
+		case prx.EventKindCommit:
+			if e.Timestamp.After(lastCommit) {
+				lastCommit = e.Timestamp
+			}
+		default:
+		}
+	}
+	return !approvedAt.IsZero() && lastCommit.After(approvedAt)
+}
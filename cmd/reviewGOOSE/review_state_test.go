@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const reviewStateTestURL = "https://github.com/org/repo/pull/1"
+
+// reviewTurnData returns Turn data naming "me" as a blocking reviewer, with the given review history.
+func reviewTurnData(state prx.ReviewState, events ...prx.Event) *turn.CheckResponse {
+	data := &turn.CheckResponse{
+		PullRequest: prx.PullRequest{State: "open", Author: "contributor"},
+		Events:      events,
+	}
+	if state != "" {
+		data.PullRequest.Reviewers = map[string]prx.ReviewState{"me": state}
+	}
+	data.Analysis.NextAction = map[string]turn.Action{"me": {Kind: "review", Reason: "needs approval", Critical: true}}
+	return data
+}
+
+func TestMyReviewState(t *testing.T) {
+	approvedAt := time.Now().Add(-time.Hour)
+	approval := prx.Event{Kind: prx.EventKindReview, Actor: "me", Outcome: "approved", Timestamp: approvedAt}
+	oldCommit := prx.Event{Kind: prx.EventKindCommit, Actor: "contributor", Timestamp: approvedAt.Add(-time.Hour)}
+	newCommit := prx.Event{Kind: prx.EventKindCommit, Actor: "contributor", Timestamp: approvedAt.Add(time.Minute)}
+	otherApproval := prx.Event{Kind: prx.EventKindReview, Actor: "someone", Outcome: "approved", Timestamp: approvedAt.Add(time.Hour)}
+
+	tests := []struct {
+		data *turn.CheckResponse
+		name string
+		want string
+	}{
+		{name: "nil data", data: nil, want: ""},
+		{name: "not reviewed", data: reviewTurnData(""), want: ""},
+		{name: "review requested", data: reviewTurnData(prx.ReviewStatePending), want: ""},
+		{name: "approved", data: reviewTurnData(prx.ReviewStateApproved, oldCommit, approval), want: "approved"},
+		{name: "approved then new commits", data: reviewTurnData(prx.ReviewStateApproved, approval, newCommit, otherApproval), want: ""},
+		{name: "changes requested", data: reviewTurnData(prx.ReviewStateChangesRequested), want: "changes_requested"},
+		{name: "commented", data: reviewTurnData(prx.ReviewStateCommented, newCommit), want: "commented"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := myReviewState(tt.data, "me"); got != tt.want {
+				t.Errorf("myReviewState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApprovedPRDemotionAndPromotion(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.initialLoadComplete = true
+	approvedAt := time.Now().Add(-30 * time.Minute)
+	approval := prx.Event{Kind: prx.EventKindReview, Actor: "me", Outcome: "approved", Timestamp: approvedAt}
+
+	// apply runs one update cycle with the given Turn data and returns PRs to notify about
+	apply := func(data *turn.CheckResponse) (PR, []PR) {
+		t.Helper()
+		incoming := []PR{{Repository: "org/repo", Number: 1, URL: reviewStateTestURL, UpdatedAt: time.Now()}}
+		applyTurnResult(&incoming[0], &prResult{url: reviewStateTestURL, turnData: data}, "me", time.Now())
+		app.incoming = incoming
+		return incoming[0], app.stateManager.UpdatePRs(incoming, nil, nil, false)
+	}
+
+	// Not yet reviewed: blocked and notified
+	pr, notify := apply(reviewTurnData(""))
+	if !pr.NeedsReview || !pr.IsBlocked || len(notify) != 1 {
+		t.Fatalf("unreviewed PR should be blocked and notified: blocked=%v notify=%d", pr.IsBlocked, len(notify))
+	}
+
+	// Approved but Turn still names me: demoted, not counted, no notification
+	pr, notify = apply(reviewTurnData(prx.ReviewStateApproved, approval))
+	if pr.NeedsReview || pr.IsBlocked || pr.ActionKind != "" || pr.MyReviewState != "approved" || len(notify) != 0 {
+		t.Fatalf("approved PR should be demoted: %+v notify=%d", pr, len(notify))
+	}
+	if got := app.countPRs().IncomingBlocked; got != 0 {
+		t.Errorf("IncomingBlocked = %d for an approved PR, want 0", got)
+	}
+	if got := formatMenuTooltip(pr, DisplayRepoNumber, "5m"); got != "(5m) - "+waitingOnOthersReason {
+		t.Errorf("formatMenuTooltip() = %q", got)
+	}
+
+	// Another cycle with nothing new: stays demoted and quiet
+	if _, notify = apply(reviewTurnData(prx.ReviewStateApproved, approval)); len(notify) != 0 {
+		t.Errorf("re-notified for an approved PR with no new commits")
+	}
+
+	// New commits after my approval: promoted and notified again
+	push := prx.Event{Kind: prx.EventKindCommit, Actor: "contributor", Timestamp: approvedAt.Add(10 * time.Minute)}
+	pr, notify = apply(reviewTurnData(prx.ReviewStateApproved, approval, push))
+	if !pr.IsBlocked || pr.MyReviewState != "" || len(notify) != 1 {
+		t.Fatalf("new commits should re-promote the PR: blocked=%v state=%q notify=%d", pr.IsBlocked, pr.MyReviewState, len(notify))
+	}
+	if got := app.countPRs().IncomingBlocked; got != 1 {
+		t.Errorf("IncomingBlocked = %d after re-promotion, want 1", got)
+	}
+}
+
+func TestDismissedApprovalPromotesPR(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	incoming := []PR{{Repository: "org/repo", Number: 1, URL: reviewStateTestURL, UpdatedAt: time.Now()}}
+
+	applyTurnResult(&incoming[0], &prResult{url: reviewStateTestURL, turnData: reviewTurnData(prx.ReviewStateApproved)}, "me", time.Now())
+	if incoming[0].IsBlocked || app.stateManager.UpdatePRs(incoming, nil, nil, false) != nil {
+		t.Fatal("approved PR should start demoted")
+	}
+
+	// A push that dismisses the approval puts me back in the pending reviewers
+	applyTurnResult(&incoming[0], &prResult{url: reviewStateTestURL, turnData: reviewTurnData(prx.ReviewStatePending)}, "me", time.Now())
+	if !incoming[0].IsBlocked || incoming[0].ActionReason != "needs approval" {
+		t.Errorf("dismissed approval should re-promote the PR: %+v", incoming[0])
+	}
+	if notify := app.stateManager.UpdatePRs(incoming, nil, nil, false); len(notify) != 1 {
+		t.Errorf("expected a notification after the approval was dismissed, got %d", len(notify))
+	}
+}
+
+func TestOwnReviewStateIgnoredOnOutgoingPRs(t *testing.T) {
+	outgoing := []PR{{Repository: "org/repo", Number: 1, URL: reviewStateTestURL, UpdatedAt: time.Now()}}
+	result := &prResult{url: reviewStateTestURL, turnData: reviewTurnData(prx.ReviewStateApproved), isOwner: true}
+	applyTurnResult(&outgoing[0], result, "me", time.Now())
+	if !outgoing[0].IsBlocked || outgoing[0].MyReviewState != "" {
+		t.Errorf("outgoing PR should not be demoted by my own review state: %+v", outgoing[0])
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Tests that finish between update cycles aren't noticed until something fetches the PR
+// again. The running-tests sweep re-checks just the PRs whose last known tests were in
+// progress, every runningTestsCacheTTL, and patches their results in like the backfill.
+
+// runningSweepConcurrency bounds the sweep's concurrent Turn lookups.
+const runningSweepConcurrency = 3
+
+// runningTestsSweepLoop runs the sweep every runningTestsCacheTTL until ctx is done.
+func (app *App) runningTestsSweepLoop(ctx context.Context) {
+	if app.turnClient == nil {
+		return
+	}
+	ticker := time.NewTicker(runningTestsCacheTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			app.sweepRunningTests(ctx)
+		}
+	}
+}
+
+// runningTargets returns the shown PRs whose last known tests were still in progress.
+// Caller must hold app.mu.
+func (app *App) runningTargets() []backfillTarget {
+	var targets []backfillTarget
+	add := func(prs []PR, isOwner bool) {
+		for i := range prs {
+			if !testsInProgress(prs[i].TestState) {
+				continue
+			}
+			if app.quarantine != nil && app.quarantine.shouldSkip(prs[i].URL) {
+				continue
+			}
+			targets = append(targets, backfillTarget{
+				url:       prs[i].URL,
+				repo:      prs[i].Repository,
+				number:    prs[i].Number,
+				updatedAt: prs[i].UpdatedAt,
+				isOwner:   isOwner,
+			})
+		}
+	}
+	add(app.incoming, false)
+	add(app.outgoing, true)
+	return targets
+}
+
+// sweepOpen reports whether a circuit breaker is open. The sweep is optional work, so
+// it waits out an outage rather than probing it.
+func (app *App) sweepOpen() bool {
+	for _, cb := range app.circuits() {
+		if cb.status().state == circuitOpen {
+			return true
+		}
+	}
+	return false
+}
+
+// sweepRunningTests re-queries Turn for PRs with tests in progress and patches the
+// results in place, then updates the menu and sends notifications for them, including
+// watched tests that finished. It skips a turn while an update cycle is running, and
+// drops its results if one started while it was fetching.
+func (app *App) sweepRunningTests(ctx context.Context) {
+	if ctx.Err() != nil || app.sweepOpen() {
+		return
+	}
+	if !app.updateMutex.TryLock() {
+		slog.Debug("[SWEEP] Update cycle in progress, skipping running tests sweep")
+		return
+	}
+	app.mu.RLock()
+	generation := app.updateGeneration
+	ready := app.initialLoadComplete
+	user, actionUsers := app.lookupUsersLocked()
+	var targets []backfillTarget
+	if ready {
+		targets = app.runningTargets()
+	}
+	app.mu.RUnlock()
+	app.updateMutex.Unlock()
+
+	if len(targets) == 0 || user == "" {
+		return
+	}
+
+	slog.Info("[SWEEP] Re-checking PRs with tests in progress", "count", len(targets))
+	start := time.Now()
+	sweepCtx, cancel := context.WithTimeout(ctx, app.updateCycleTimeout())
+	defer cancel()
+
+	results := make(chan prResult, len(targets))
+	sem := make(chan struct{}, runningSweepConcurrency)
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-sweepCtx.Done():
+				return
+			}
+			// One attempt: a failure just waits for the next sweep or cycle
+			results <- app.lookupTarget(sweepCtx, target, user, actionUsers, 1)
+		})
+	}
+	wg.Wait()
+	close(results)
+
+	var fetched []prResult
+	finished := 0
+	for result := range results {
+		if result.err != nil {
+			if isPermanentPRError(result.err) {
+				app.quarantinePR(result.url, result.err)
+			}
+			slog.Debug("[SWEEP] Turn lookup failed", "url", result.url, "error", result.err)
+			continue
+		}
+		if result.turnData == nil || result.turnData.Analysis.NextAction == nil {
+			continue
+		}
+		if !testsInProgress(result.turnData.PullRequest.TestState) {
+			finished++
+		}
+		fetched = append(fetched, result)
+	}
+
+	// Patch and notify under updateMutex, like an update cycle, so the two don't interleave
+	app.updateMutex.Lock()
+	defer app.updateMutex.Unlock()
+	patched := 0
+	app.mu.Lock()
+	stale := app.updateGeneration != generation
+	if !stale {
+		patched = app.patchTurnResults(fetched, user)
+	}
+	app.mu.Unlock()
+
+	if stale {
+		slog.Debug("[SWEEP] Discarding results, a new update cycle has started", "generation", generation)
+		return
+	}
+	slog.Info("[SWEEP] Running tests sweep completed",
+		"duration", time.Since(start).Round(time.Millisecond), "patched", patched, "finished", finished)
+	if finished == 0 || ctx.Err() != nil {
+		return
+	}
+	app.updateMenu(ctx)
+	app.processNotifications(ctx)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSweepTestApp shows one incoming PR whose tests were running at the last cycle.
+func newSweepTestApp(t *testing.T, serverURL string) *App {
+	t.Helper()
+	app := newBackfillTestApp(t, serverURL)
+	app.initialLoadComplete = true
+	app.incoming = []PR{
+		{
+			Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", Title: "Add retries",
+			UpdatedAt: time.Now().Add(-time.Minute), TurnDataAppliedAt: time.Now(), TestState: "running",
+		},
+		{
+			Repository: "org/repo", Number: 2, URL: "https://github.com/org/repo/pull/2", Title: "Fix typo",
+			UpdatedAt: time.Now().Add(-time.Hour), TurnDataAppliedAt: time.Now(), TestState: "passing",
+		},
+	}
+	return app
+}
+
+func TestRunningTestsSweepPatchesFinishedTests(t *testing.T) {
+	server, calls := newFlakyTurnServer(t, 0)
+	app := newSweepTestApp(t, server.URL)
+	app.updateMenu(t.Context())
+	before := app.generateMenuTitles()
+
+	// Tests pass between polls: the sweep notices without a full cycle
+	app.sweepRunningTests(t.Context())
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Turn called %d times, want 1 (only the running PR)", got)
+	}
+	pr := app.incoming[0]
+	if pr.TestState != "passing" || !pr.NeedsReview {
+		t.Fatalf("PR not patched in place: %+v", pr)
+	}
+	if slices.Equal(app.lastMenuTitles, before) {
+		t.Error("menu not updated after the sweep")
+	}
+	found := false
+	for _, title := range app.lastMenuTitles {
+		if strings.HasPrefix(title, "■ org/repo #1") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("menu titles = %q, want org/repo #1 shown blocked", app.lastMenuTitles)
+	}
+
+	// Nothing is running any more, so the next sweep has nothing to do
+	app.sweepRunningTests(t.Context())
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Turn called %d times after tests finished, want still 1", got)
+	}
+}
+
+func TestRunningTestsSweepYieldsToUpdateCycles(t *testing.T) {
+	server, calls := newFlakyTurnServer(t, 0)
+
+	// Paused while a cycle runs
+	app := newSweepTestApp(t, server.URL)
+	app.updateMutex.Lock()
+	app.sweepRunningTests(t.Context())
+	app.updateMutex.Unlock()
+	if got := calls.Load(); got != 0 {
+		t.Errorf("Turn called %d times during an update cycle, want 0", got)
+	}
+
+	// Waits out an open circuit breaker
+	app.githubCircuit = newCircuitBreaker("github", 1, time.Hour)
+	if err := app.githubCircuit.call(func() error { return errUpdateTimedOut }); err == nil {
+		t.Fatal("failing call through the breaker succeeded")
+	}
+	app.sweepRunningTests(t.Context())
+	if got := calls.Load(); got != 0 {
+		t.Errorf("Turn called %d times with the breaker open, want 0", got)
+	}
+
+	// Stops with the app context
+	app.githubCircuit = nil
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	app.sweepRunningTests(ctx)
+	if got := calls.Load(); got != 0 {
+		t.Errorf("Turn called %d times after shutdown, want 0", got)
+	}
+	if app.incoming[0].TestState != "running" {
+		t.Errorf("skipped sweeps patched the PR: %+v", app.incoming[0])
+	}
+}
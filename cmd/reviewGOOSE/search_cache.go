@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// searchCacheEntry holds the validators and parsed results of the last 200 response for a query.
+type searchCacheEntry struct {
+	result       *github.IssuesSearchResult
+	etag         string
+	lastModified string
+}
+
+// searchCache remembers search responses so unchanged results can be revalidated
+// with a conditional request instead of re-downloaded. It is in-memory only: a
+// validator is useless without the body it describes.
+type searchCache struct {
+	entries map[string]searchCacheEntry
+	mu      sync.Mutex
+}
+
+func newSearchCache() *searchCache {
+	return &searchCache{entries: make(map[string]searchCacheEntry)}
+}
+
+func (c *searchCache) get(key string) (searchCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *searchCache) put(key string, entry searchCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// searchIssuesPath builds the search/issues request path, matching the parameters
+// go-github's Search.Issues would send.
+func searchIssuesPath(query string, opts *github.SearchOptions) string {
+	params := url.Values{}
+	params.Set("q", query)
+	if opts != nil {
+		if opts.Sort != "" {
+			params.Set("sort", opts.Sort)
+		}
+		if opts.Order != "" {
+			params.Set("order", opts.Order)
+		}
+		if opts.PerPage > 0 {
+			params.Set("per_page", strconv.Itoa(opts.PerPage))
+		}
+		if opts.Page > 0 {
+			params.Set("page", strconv.Itoa(opts.Page))
+		}
+	}
+	return "search/issues?" + params.Encode()
+}
+
+// searchIssues runs a search query, sending If-None-Match (or If-Modified-Since) when a
+// previous response is cached. A 304 is a success: the cached result is returned with
+// notModified set, so callers and retry logic never see it as an error.
+func (app *App) searchIssues(
+	ctx context.Context, query string, opts *github.SearchOptions,
+) (result *github.IssuesSearchResult, resp *github.Response, notModified bool, err error) {
+	if app.searchCache == nil {
+		result, resp, err = app.client.Search.Issues(ctx, query, opts)
+		return result, resp, false, err
+	}
+
+	path := searchIssuesPath(query, opts)
+	req, err := app.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("create search request: %w", err)
+	}
+
+	cached, ok := app.searchCache.get(path)
+	if ok {
+		switch {
+		case cached.etag != "":
+			req.Header.Set("If-None-Match", cached.etag)
+		case cached.lastModified != "":
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		default:
+		}
+	}
+
+	fresh := &github.IssuesSearchResult{}
+	resp, err = app.client.Do(ctx, req, fresh)
+	if ok && resp != nil && resp.StatusCode == http.StatusNotModified {
+		slog.Debug("[GITHUB] Search results not modified", "query", query)
+		return cached.result, resp, true, nil
+	}
+	if err != nil {
+		return nil, resp, false, err
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		app.searchCache.put(path, searchCacheEntry{result: fresh, etag: etag, lastModified: lastModified})
+	}
+	return fresh, resp, false, nil
+}
+
+// reusablePRs returns the PRs from the previous update keyed by URL, so unchanged search
+// results can skip Turn enrichment.
+func (app *App) reusablePRs() map[string]PR {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	prs := make(map[string]PR, len(app.incoming)+len(app.outgoing))
+	for i := range app.incoming {
+		prs[app.incoming[i].URL] = app.incoming[i]
+	}
+	for i := range app.outgoing {
+		prs[app.outgoing[i].URL] = app.outgoing[i]
+	}
+	return prs
+}
+
+// canReuseTurnData reports whether a previous PR's Turn data still applies to a PR
+// with the given UpdatedAt. PRs with tests in flight are always re-checked, as are PRs
+// with my unsubmitted review, whose Turn fields it replaced.
+func canReuseTurnData(prev PR, found bool, pr PR) bool {
+	if !found || prev.TurnDataAppliedAt.IsZero() || !prev.UpdatedAt.Equal(pr.UpdatedAt) || !prev.PendingReviewAt.IsZero() {
+		return false
+	}
+	switch prev.TestState {
+	case "running", "queued", "pending":
+		return false
+	default:
+		return true
+	}
+}
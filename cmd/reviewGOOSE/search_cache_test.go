@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// etagSearchServer serves a fixed search result with an ETag, answering 304 once the
+// client revalidates with it.
+type etagSearchServer struct {
+	*httptest.Server
+	conditional atomic.Int32 // Requests that carried If-None-Match
+	notModified atomic.Int32 // Requests answered with 304
+	full        atomic.Int32 // Requests answered with a full body
+}
+
+func newETagSearchServer(t *testing.T, updatedAt time.Time) *etagSearchServer {
+	t.Helper()
+	const etag = `"results-v1"`
+	s := &etagSearchServer{}
+	s.Server = newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		if match := r.Header.Get("If-None-Match"); match != "" {
+			s.conditional.Add(1)
+			if match == etag {
+				s.notModified.Add(1)
+				return fakeSearch{status: http.StatusNotModified}
+			}
+		}
+		s.full.Add(1)
+		return fakeSearch{etag: etag, prs: []fakePR{{repo: "test/repo", number: 1, title: "Cached PR", updated: updatedAt}}}
+	})
+	return s
+}
+
+func TestSearchIssuesConditionalRequest(t *testing.T) {
+	ctx := context.Background()
+	server := newETagSearchServer(t, time.Now())
+
+	app := &App{
+		mu:          sync.RWMutex{},
+		client:      newETagTestClient(t, server.URL),
+		searchCache: newSearchCache(),
+	}
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}, Sort: "updated", Order: "desc"}
+
+	first, notModified, err := app.executeGitHubQuery(ctx, "is:open is:pr involves:testuser", opts)
+	if err != nil {
+		t.Fatalf("first query: %v", err)
+	}
+	if notModified || len(first.Issues) != 1 {
+		t.Fatalf("first query: notModified=%v issues=%d, want a fresh result with 1 issue", notModified, len(first.Issues))
+	}
+
+	second, notModified, err := app.executeGitHubQuery(ctx, "is:open is:pr involves:testuser", opts)
+	if err != nil {
+		t.Fatalf("304 must not be treated as an error: %v", err)
+	}
+	if !notModified || second != first {
+		t.Error("expected the previously parsed result to be reused on 304")
+	}
+	if got := server.notModified.Load(); got != 1 {
+		t.Errorf("server answered %d requests with 304, want 1 (no retries)", got)
+	}
+
+	// A different query has no validator yet
+	if _, notModified, err := app.executeGitHubQuery(ctx, "is:open is:pr user:testuser review:none", opts); err != nil || notModified {
+		t.Errorf("new query: notModified=%v err=%v, want a fresh result", notModified, err)
+	}
+}
+
+func TestUnchangedSearchSkipsTurnEnrichment(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	githubServer := newETagSearchServer(t, now)
+
+	var turnRequests atomic.Int32
+	turnClient := newFakeTurn(t, func(context.Context, turn.CheckRequest) fakeVerdict {
+		turnRequests.Add(1)
+		return fakeVerdict{next: map[string]any{"testuser": reviewAction()}}
+	})
+	app := newFetchTestApp(t, githubServer.URL, turnClient, "testuser")
+	app.noCache = true
+
+	// Cycle 1: full results, PR enriched by Turn
+	app.updatePRs(ctx)
+	if got := turnRequests.Load(); got == 0 {
+		t.Fatal("expected Turn lookup on the first cycle")
+	}
+	app.mu.RLock()
+	if len(app.incoming) != 1 || !app.incoming[0].IsBlocked {
+		t.Fatalf("expected one blocked incoming PR after first cycle, got %+v", app.incoming)
+	}
+	app.mu.RUnlock()
+
+	// Cycle 2: every query returns 304, so Turn is skipped and the PR keeps its state
+	afterFirst := turnRequests.Load()
+	app.updatePRs(ctx)
+	if got := githubServer.notModified.Load(); got != 2 {
+		t.Errorf("expected both queries to be revalidated with 304, got %d", got)
+	}
+	if got := turnRequests.Load(); got != afterFirst {
+		t.Errorf("expected no Turn lookups for unchanged PRs, got %d", got-afterFirst)
+	}
+	app.mu.RLock()
+	if len(app.incoming) != 1 || !app.incoming[0].IsBlocked {
+		t.Errorf("expected reused PR to stay blocked, got %+v", app.incoming)
+	}
+	app.mu.RUnlock()
+
+	// Forced refresh: ETag is still sent, but every PR is re-checked with Turn
+	conditionalBefore := githubServer.conditional.Load()
+	app.forceRefresh(ctx)
+	if got := githubServer.conditional.Load() - conditionalBefore; got != 2 {
+		t.Errorf("forced refresh sent %d conditional requests, want 2", got)
+	}
+	if got := turnRequests.Load(); got == afterFirst {
+		t.Error("expected forced refresh to bypass Turn data reuse")
+	}
+	if got := githubServer.full.Load(); got != 2 {
+		t.Errorf("server sent %d full responses, want 2 (one per query, first cycle only)", got)
+	}
+}
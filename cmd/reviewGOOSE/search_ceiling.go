@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// GitHub's search API stops at 1,000 results, and in a busy org the involves: search
+// can match far more. A capped search keeps only its most recently updated page, but
+// PRs untouched for longer than stalePRThreshold are hidden as stale anyway, so a
+// capped search is narrowed to that window and rerun, for the rest of the session.
+
+// searchResultCeiling is the most results GitHub's search API returns for one query.
+const searchResultCeiling = 1000
+
+// hitsSearchCeiling reports whether GitHub returned only part of a search's matches.
+func hitsSearchCeiling(res *github.IssuesSearchResult) bool {
+	return res.GetIncompleteResults() || res.GetTotal() >= searchResultCeiling
+}
+
+// narrowSearchQuery limits a search to PRs updated within stalePRThreshold of now.
+func narrowSearchQuery(query string, now time.Time) string {
+	return query + " updated:>=" + now.Add(-stalePRThreshold).UTC().Format(time.DateOnly)
+}
+
+// searchNarrowed reports whether a search has hit the ceiling this session.
+func (app *App) searchNarrowed(query string) bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.cappedSearches[query]
+}
+
+// executeBoundedQuery runs a search, or its narrowed form once it has hit the ceiling.
+// The first capped response is rerun narrowed straight away; if that fails, the capped
+// results are still better than none.
+func (app *App) executeBoundedQuery(
+	ctx context.Context, query string, opts *github.SearchOptions,
+) (result *github.IssuesSearchResult, notModified bool, err error) {
+	if app.searchNarrowed(query) {
+		return app.executeGitHubQuery(ctx, narrowSearchQuery(query, time.Now()), opts)
+	}
+	result, notModified, err = app.executeGitHubQuery(ctx, query, opts)
+	if err != nil || !hitsSearchCeiling(result) {
+		return result, notModified, err
+	}
+
+	slog.Warn("[GITHUB] Search hit the result ceiling, narrowing it to recent PRs",
+		"query", query, "total", result.GetTotal(), "incomplete", result.GetIncompleteResults())
+	app.mu.Lock()
+	if app.cappedSearches == nil {
+		app.cappedSearches = make(map[string]bool)
+	}
+	app.cappedSearches[query] = true
+	app.mu.Unlock()
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordSearchCapped()
+	}
+
+	narrowed, narrowedNotModified, err := app.executeGitHubQuery(ctx, narrowSearchQuery(query, time.Now()), opts)
+	if err != nil {
+		slog.Warn("[GITHUB] Narrowed search failed, keeping the capped results", "query", query, "error", err)
+		return result, notModified, nil
+	}
+	if hitsSearchCeiling(narrowed) {
+		slog.Warn("[GITHUB] Narrowed search is still capped", "query", query, "total", narrowed.GetTotal())
+	}
+	return narrowed, narrowedNotModified, nil
+}
+
+// searchCappedTitle returns the menu line shown once a search has hit the ceiling, or "".
+func (app *App) searchCappedTitle() string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if len(app.cappedSearches) == 0 {
+		return ""
+	}
+	return msg("menu.search_capped")
+}
+
+// addSearchCappedNotice adds a disabled line once a search has hit the ceiling.
+func (app *App) addSearchCappedNotice(_ context.Context) {
+	title := app.searchCappedTitle()
+	if title == "" {
+		return
+	}
+	app.systrayInterface.AddMenuItem(title, msg("menu.search_capped.tooltip", searchResultCeiling)).Disable()
+	app.systrayInterface.AddSeparator()
+}
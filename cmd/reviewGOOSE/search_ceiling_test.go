@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNarrowSearchQuery(t *testing.T) {
+	now := time.Date(2026, 4, 30, 23, 0, 0, 0, time.UTC)
+	got := narrowSearchQuery("is:open is:pr involves:me archived:false", now)
+	if want := "is:open is:pr involves:me archived:false updated:>=2026-01-30"; got != want {
+		t.Errorf("narrowSearchQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestSearchCeilingNarrowsQuery(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	var queries []string
+	// The involves: search matches too much until it's narrowed
+	server := newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		queries = append(queries, q)
+		mu.Unlock()
+		number := 1
+		if strings.Contains(q, "review:none") {
+			number = 2
+		}
+		result := fakeSearch{prs: []fakePR{{repo: "test/repo", number: number, updated: now.Add(-time.Hour)}}}
+		if strings.Contains(q, "involves:") && !strings.Contains(q, "updated:>=") {
+			result.total, result.incomplete = 1500, true
+		}
+		return result
+	})
+
+	app := newPartialFetchTestApp(t, server.URL)
+	app.healthMonitor = newHealthMonitor()
+	app.healthMonitor.app = app
+	ctx := context.Background()
+	app.updatePRs(ctx)
+
+	narrowed := narrowSearchQuery("is:open is:pr involves:testuser archived:false", time.Now())
+	mu.Lock()
+	first := slices.Clone(queries)
+	queries = nil
+	mu.Unlock()
+	if !slices.Contains(first, narrowed) {
+		t.Errorf("searches = %q, want the capped search re-run as %q", first, narrowed)
+	}
+	if slices.ContainsFunc(first, func(q string) bool { return strings.Contains(q, "review:none") && strings.Contains(q, "updated:>=") }) {
+		t.Errorf("searches = %q, the uncapped search was narrowed", first)
+	}
+	if !slices.Contains(app.generateMenuTitles(), msg("menu.search_capped")) {
+		t.Errorf("menu titles = %q, want the ceiling notice", app.generateMenuTitles())
+	}
+	if got := app.healthMonitor.metrics()["search_capped"]; got != int64(1) {
+		t.Errorf("search_capped = %v, want 1", got)
+	}
+
+	// Later cycles go straight to the narrowed search
+	app.updatePRs(ctx)
+	mu.Lock()
+	second := slices.Clone(queries)
+	mu.Unlock()
+	if len(second) == 0 {
+		t.Fatal("second cycle didn't search")
+	}
+	for _, q := range second {
+		if strings.Contains(q, "involves:") && q != narrowed {
+			t.Errorf("later cycle searched %q, want only the narrowed form", q)
+		}
+	}
+	if got := app.healthMonitor.metrics()["search_capped"]; got != int64(1) {
+		t.Errorf("search_capped = %v after the narrowed cycle, want still 1", got)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// sectionHeaderItem is a built section header, kept so its "changed" suffix can age
+// without rebuilding the menu.
+type sectionHeaderItem struct {
+	item MenuItem
+	text string // The header without the suffix
+}
+
+// currentSectionTitles returns the PR titles each section contributes to generateMenuTitles,
+// which include every PR's blocked prefix, keyed by "Incoming" and "Outgoing".
+func (app *App) currentSectionTitles() map[string][]string {
+	view := app.snapshotPRs()
+	return map[string][]string{
+		"Incoming": app.generatePRSectionTitles(view.incoming, "Incoming"),
+		"Outgoing": app.generatePRSectionTitles(view.outgoing, "Outgoing"),
+	}
+}
+
+// markSectionChanges stamps each section whose visible PRs or their blocked states differ
+// from the last call. updateMenu calls it only when its title diff found a change, so
+// rebuilds for other reasons don't reset the clock.
+func (app *App) markSectionChanges() {
+	current := app.currentSectionTitles()
+	now := time.Now()
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.sectionChangedAt == nil {
+		app.sectionChangedAt = make(map[string]time.Time)
+	}
+	for section, titles := range current {
+		last, seen := app.lastSectionTitles[section]
+		if !seen || !slices.Equal(last, titles) {
+			app.sectionChangedAt[section] = now
+		}
+	}
+	app.lastSectionTitles = current
+}
+
+// sectionChangedSuffix returns " · changed 4m ago" for a section, or "" before its first change.
+func (app *App) sectionChangedSuffix(section string) string {
+	app.mu.RLock()
+	at, ok := app.sectionChangedAt[section]
+	app.mu.RUnlock()
+	if !ok {
+		return ""
+	}
+	age := time.Since(at)
+	if age < time.Minute {
+		return " · " + msg("section.changed_now")
+	}
+	return " · " + msg("section.changed", stuckDuration(age))
+}
+
+// trackSectionHeader remembers a section's header item for refreshSectionHeaders.
+func (app *App) trackSectionHeader(section string, item MenuItem, text string) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.sectionHeaders == nil {
+		app.sectionHeaders = make(map[string]sectionHeaderItem)
+	}
+	app.sectionHeaders[section] = sectionHeaderItem{item: item, text: text}
+}
+
+// refreshSectionHeaders re-renders the "changed" suffixes in place. The suffix never
+// appears in generateMenuTitles, so its aging can't trigger a rebuild.
+func (app *App) refreshSectionHeaders() {
+	app.mu.RLock()
+	headers := maps.Clone(app.sectionHeaders)
+	app.mu.RUnlock()
+	for section, h := range headers {
+		h.item.SetTitle(h.text + app.sectionChangedSuffix(section))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// headerTitle returns the current title of a built section header.
+func headerTitle(t *testing.T, app *App, section string) string {
+	t.Helper()
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	h, ok := app.sectionHeaders[section]
+	if !ok {
+		t.Fatalf("no %s header was built", section)
+	}
+	item, ok := h.item.(*MockMenuItem)
+	if !ok {
+		t.Fatalf("header is %T, want *MockMenuItem", h.item)
+	}
+	return item.title
+}
+
+func backdateSection(app *App, section string, ago time.Duration) {
+	app.mu.Lock()
+	app.sectionChangedAt[section] = time.Now().Add(-ago)
+	app.mu.Unlock()
+}
+
+func TestSectionChangedSuffix(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	now := time.Now()
+	app.incoming = []PR{
+		{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: now},
+		{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2", UpdatedAt: now},
+	}
+	app.outgoing = []PR{{Repository: "acme/gears", Number: 3, URL: "https://github.com/acme/gears/pull/3", UpdatedAt: now}}
+
+	app.updateMenu(ctx)
+	if got := headerTitle(t, app, "Incoming"); !strings.HasSuffix(got, " · changed just now") {
+		t.Errorf("header after the first update = %q", got)
+	}
+
+	// Aging refreshes the header in place
+	backdateSection(app, "Incoming", 4*time.Minute)
+	backdateSection(app, "Outgoing", 2*time.Hour)
+	header := app.sectionHeaders["Incoming"].item
+	app.updateMenu(ctx)
+	if app.sectionHeaders["Incoming"].item != header {
+		t.Error("an aging suffix rebuilt the menu")
+	}
+	if got, want := headerTitle(t, app, "Incoming"), "Incoming — 1 blocked on you · changed 4m ago"; got != want {
+		t.Errorf("aged header = %q, want %q", got, want)
+	}
+
+	// A blocked state change restarts the incoming clock only
+	app.mu.Lock()
+	app.incoming[1].NeedsReview = true
+	app.mu.Unlock()
+	app.updateMenu(ctx)
+	if got := headerTitle(t, app, "Incoming"); !strings.HasSuffix(got, " · changed just now") {
+		t.Errorf("header after a change = %q", got)
+	}
+	if got := headerTitle(t, app, "Outgoing"); !strings.HasSuffix(got, " · changed 2h ago") {
+		t.Errorf("unchanged outgoing header = %q", got)
+	}
+}
+
+func TestSectionChangedSuffixExcludedFromChangeDetection(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.incoming = []PR{{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: time.Now()}}
+	app.markSectionChanges()
+
+	before := app.generateMenuTitles()
+	backdateSection(app, "Incoming", 3*time.Hour)
+	if after := app.generateMenuTitles(); !slices.Equal(before, after) {
+		t.Errorf("menu titles changed as the suffix aged:\n%q\n%q", before, after)
+	}
+	for _, title := range before {
+		if strings.Contains(title, "changed") {
+			t.Errorf("change-detection title %q includes the suffix", title)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log/slog"
+)
+
+// shownSection returns prs, or nil when its section is hidden. Hidden sections drop out
+// of the menu, counts, tray title, and icon.
+func shownSection(prs []PR, hidden bool) []PR {
+	if hidden {
+		return nil
+	}
+	return prs
+}
+
+// sectionShown reports whether the section holding the PR at url is shown. PRs not in
+// either list are treated as shown. Callers must not hold app.mu.
+func (app *App) sectionShown(url string) bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	for i := range app.incoming {
+		if app.incoming[i].URL == url {
+			return !app.hideIncoming
+		}
+	}
+	for i := range app.outgoing {
+		if app.outgoing[i].URL == url {
+			return !app.hideOutgoing
+		}
+	}
+	return true
+}
+
+// toggleSection flips a section's visibility, refusing to hide the last shown section.
+func (app *App) toggleSection(hide, other *bool, name string) {
+	app.mu.Lock()
+	if !*hide && *other {
+		app.mu.Unlock()
+		slog.Info("[SETTINGS] Refusing to hide the only shown section", "section", name)
+		if err := app.notify(msg("sections.last_one"), msg("sections.last_one.message", name)); err != nil {
+			slog.Warn("[SETTINGS] Failed to send notification", "error", err)
+		}
+		return
+	}
+	*hide = !*hide
+	app.mu.Unlock()
+	app.presentTrayState()
+}
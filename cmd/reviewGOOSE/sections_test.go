@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSectionToggles(t *testing.T) {
+	ctx := context.Background()
+	app, mock := newSettingsMenuTestApp(t)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	app.incoming = []PR{{Repository: "org/api", Number: 1, URL: "https://github.com/org/api/pull/1", NeedsReview: true, UpdatedAt: time.Now()}}
+	app.outgoing = []PR{{Repository: "org/api", Number: 2, URL: "https://github.com/org/api/pull/2", IsBlocked: true, UpdatedAt: time.Now()}}
+	app.rebuildMenu(ctx)
+
+	mock.clickSetting(t, "show_outgoing")
+	if !app.hideOutgoing {
+		t.Fatal("unchecking Show outgoing PRs should hide the section")
+	}
+	if titles := app.generateMenuTitles(); slices.Contains(titles, msg("menu.outgoing_prs")) {
+		t.Error("outgoing section still in the menu titles")
+	}
+	if counts := app.countPRs(); counts.OutgoingTotal != 0 || counts.IncomingBlocked != 1 {
+		t.Errorf("counts = %+v, want only incoming PRs", counts)
+	}
+	if last, _ := mock.iconState(); !bytes.Equal(last, getIcon(IconGoose, app.countPRs())) {
+		t.Error("icon should only reflect the incoming section")
+	}
+
+	// The last shown section can't be hidden
+	mock.clickSetting(t, "show_incoming")
+	if app.hideIncoming {
+		t.Fatal("hid the only shown section")
+	}
+	if len(notifier.notes) != 1 || !strings.Contains(notifier.notes[0], "At least one section") {
+		t.Errorf("notifications = %q, want one explaining the refusal", notifier.notes)
+	}
+
+	// Persisted across restarts
+	app.hideOutgoing = false
+	app.hideIncoming = true
+	app.saveSettings()
+	app.hideIncoming = false
+	app.loadSettings()
+	if !app.hideIncoming || app.hideOutgoing {
+		t.Errorf("after reload hideIncoming=%v hideOutgoing=%v, want incoming hidden", app.hideIncoming, app.hideOutgoing)
+	}
+}
+
+func TestHiddenSectionSkipsNotifications(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.incoming = []PR{{Repository: "org/api", Number: 1, URL: "https://github.com/org/api/pull/1", NeedsReview: true}}
+	app.outgoing = []PR{{Repository: "org/api", Number: 2, URL: "https://github.com/org/api/pull/2", IsBlocked: true}}
+	app.hideOutgoing = true
+
+	alerts := app.notifiablePRs(append(slices.Clone(app.incoming), app.outgoing...))
+	if len(alerts) != 1 || alerts[0].Number != 1 {
+		t.Errorf("alerts = %+v, want only the incoming PR", alerts)
+	}
+}
@@ -5,24 +5,21 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ghref"
 )
 
 const (
 	// Security constants.
-	minTokenLength   = 40   // GitHub tokens are at least 40 chars
-	maxTokenLength   = 255  // Reasonable upper bound
-	maxUsernameLen   = 39   // GitHub username max length
-	maxURLLength     = 2048 // Maximum URL length
-	minPrintableChar = 0x20 // Minimum printable character
-	deleteChar       = 0x7F // Delete character
+	minTokenLength   = 40                // GitHub tokens are at least 40 chars
+	maxTokenLength   = 255               // Reasonable upper bound
+	maxUsernameLen   = ghref.MaxLoginLen // GitHub username max length
+	maxURLLength     = 2048              // Maximum URL length
+	minPrintableChar = 0x20              // Minimum printable character
+	deleteChar       = 0x7F              // Delete character
 )
 
 var (
-	// githubUsernameRegex validates GitHub usernames.
-	// GitHub usernames can only contain alphanumeric characters and hyphens,
-	// cannot start or end with hyphen, and max 39 characters.
-	githubUsernameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]{0,37}[a-zA-Z0-9]$|^[a-zA-Z0-9]$`)
-
 	// githubTokenRegex validates GitHub token format.
 	// Classic tokens: 40 hex chars.
 	// New tokens: ghp_ (personal), ghs_ (server), ghr_ (refresh), gho_ (OAuth), ghu_ (user-to-server) followed by base62 chars.
@@ -30,7 +27,7 @@ var (
 	githubTokenRegex = regexp.MustCompile(`^[a-f0-9]{40}$|^gh[psoru]_[A-Za-z0-9]{36,251}$|^github_pat_[A-Za-z0-9]{82}$`)
 )
 
-// validateGitHubUsername validates a GitHub username.
+// validateGitHubUsername validates a GitHub username, including EMU logins like "jane.doe_acmecorp".
 func validateGitHubUsername(username string) error {
 	if username == "" {
 		return errors.New("username cannot be empty")
@@ -38,7 +35,7 @@ func validateGitHubUsername(username string) error {
 	if len(username) > maxUsernameLen {
 		return fmt.Errorf("username too long: %d > %d", len(username), maxUsernameLen)
 	}
-	if !githubUsernameRegex.MatchString(username) {
+	if !ghref.IsLogin(username) {
 		return fmt.Errorf("invalid GitHub username format: %s", username)
 	}
 	return nil
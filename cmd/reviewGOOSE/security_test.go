@@ -68,13 +68,23 @@ func TestValidateGitHubUsername(t *testing.T) {
 			wantErr:  true,
 		},
 		{
-			name:     "username with underscore",
-			username: "user_name",
+			name:     "EMU username with underscore",
+			username: "user_acmecorp",
+			wantErr:  false,
+		},
+		{
+			name:     "EMU username with dot and underscore",
+			username: "jane.doe_acmecorp",
+			wantErr:  false,
+		},
+		{
+			name:     "username ending with underscore",
+			username: "user_",
 			wantErr:  true,
 		},
 		{
-			name:     "username with dot",
-			username: "user.name",
+			name:     "username with path traversal",
+			username: "user..name",
 			wantErr:  true,
 		},
 		{
@@ -1,17 +1,65 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"maps"
+	"time"
 
 	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
 )
 
-// Settings represents persistent user settings.
+// settingsSchemaVersion is the Settings layout this build writes. Files without a
+// version predate versioning (0) and may still carry HiddenOrgs; version 2 added OrgActivity.
+const settingsSchemaVersion = 2
+
+// Settings represents persistent user settings. Keys this build doesn't know about
+// are preserved on save, so running an older build doesn't discard newer settings.
 type Settings struct {
-	HiddenOrgs        map[string]bool `json:"hidden_orgs"`
-	EnableAudioCues   bool            `json:"enable_audio_cues"`
-	HideStale         bool            `json:"hide_stale"`
-	EnableAutoBrowser bool            `json:"enable_auto_browser"`
+	OrgPolicies           map[string]orgPolicy   `json:"org_policies,omitempty"`
+	OrgActivity           map[string]orgActivity `json:"org_activity,omitempty"`
+	HiddenOrgs            map[string]bool        `json:"hidden_orgs,omitempty"`       // Legacy: migrated to OrgPolicies
+	RefreshAnimation      *bool                  `json:"refresh_animation,omitempty"` // nil: platform default
+	Filters               []FilterRule           `json:"filters,omitempty"`           // Hide matching PRs; edited by hand
+	Repos                 []string               `json:"repos,omitempty"`             // Repo mode: watch these "owner/name" repos; edited by hand
+	RepoModeUsers         []string               `json:"repo_mode_users,omitempty"`   // Repo mode: blocked on these users; empty means the logged-in user
+	Team                  []string               `json:"team,omitempty"`              // Team mode: show PRs blocked on these teammates; edited by hand
+	DisplayMode           DisplayMode            `json:"display_mode,omitempty"`
+	IncomingSort          IncomingSort           `json:"incoming_sort,omitempty"`
+	Highlight             HighlightWindow        `json:"highlight_new_blocks,omitempty"`
+	OpenedURLParam        URLParam               `json:"opened_url_param,omitempty"`        // What goose appends to opened links; empty: action
+	Locale                string                 `json:"locale,omitempty"`                  // Empty: detect from LC_ALL / LC_MESSAGES / LANG
+	DashboardURL          string                 `json:"dashboard_url,omitempty"`           // Self-hosted dashboard; overridden by DASHBOARD_URL
+	SnoozeUntil           string                 `json:"snooze_until,omitempty"`            // When "Snooze incoming" ends, e.g. "09:00"
+	ReviewSLA             string                 `json:"review_sla,omitempty"`              // How long a PR may wait on my review, e.g. "48h"
+	DigestAt              string                 `json:"digest_at,omitempty"`               // When the daily digest goes out, e.g. "08:45"
+	AutoOpenHours         string                 `json:"auto_open_hours,omitempty"`         // When auto-open may open tabs, e.g. "Mon-Fri 09:00-17:00"; empty: always
+	AutoOpenTimezone      string                 `json:"auto_open_timezone,omitempty"`      // IANA zone for auto_open_hours; empty: local
+	DashboardPRTemplate   string                 `json:"dashboard_pr_template,omitempty"`   // e.g. "{base}/pr/{org}/{repo}/{number}"
+	NotificationHook      string                 `json:"notification_hook,omitempty"`       // Absolute path to an executable run on notification events
+	NotificationTemplates map[string]string      `json:"notification_templates,omitempty"`  // By action kind or "default"; edited by hand
+	MenuLabelWidth        int                    `json:"menu_label_width,omitempty"`        // 0: default width, negative: no truncation
+	ReviewSessionCap      int                    `json:"review_session_cap,omitempty"`      // PRs a review session queues; 0: default
+	CommentBurstThreshold int                    `json:"comment_burst_threshold,omitempty"` // New comments that make a burst; 0: default
+	MaxTrackedPRs         int                    `json:"max_tracked_prs,omitempty"`         // PRs whose state is kept in memory; 0: default
+	SchemaVersion         int                    `json:"schema_version"`
+	CountRepos            bool                   `json:"count_repos,omitempty"`
+	GroupBotPRs           bool                   `json:"group_bot_prs,omitempty"`
+	ArchWarningDismissed  bool                   `json:"arch_warning_dismissed,omitempty"`
+	ShowDockBadge         bool                   `json:"show_dock_badge,omitempty"`
+	TrackResponseTimes    bool                   `json:"track_response_times,omitempty"`
+	DraftsBlock           bool                   `json:"drafts_block,omitempty"`
+	HideNonDefaultBase    bool                   `json:"hide_non_default_base,omitempty"`
+	HideIncoming          bool                   `json:"hide_incoming,omitempty"`
+	HideOutgoing          bool                   `json:"hide_outgoing,omitempty"`
+	Digest                bool                   `json:"daily_digest,omitempty"`
+	CommentBurstNotify    bool                   `json:"comment_burst_notifications,omitempty"`
+	AdaptiveInterval      bool                   `json:"adaptive_interval,omitempty"`
+	DigestWeekdaysOnly    bool                   `json:"digest_weekdays_only,omitempty"`
+	EnableAudioCues       bool                   `json:"enable_audio_cues"`
+	HideStale             bool                   `json:"hide_stale"`
+	EnableAutoBrowser     bool                   `json:"enable_auto_browser"`
 }
 
 // loadSettings loads settings from disk or returns defaults.
@@ -20,13 +68,26 @@ func (app *App) loadSettings() {
 	app.enableAudioCues = true
 	app.hideStaleIncoming = true
 	app.enableAutoBrowser = true
+	app.enableRefreshAnimation = refreshAnimationDefault()
+	app.displayMode = DisplayRepoNumber
 	app.hiddenOrgs = make(map[string]bool)
+	app.silentOrgs = make(map[string]bool)
+	app.settingsSchemaLoaded = 0
 
-	manager := appsettings.NewManager("reviewGOOSE")
+	manager := appsettings.NewManager(appDirName(app.profileName))
 
 	var settings Settings
 	found, err := manager.Load(&settings)
 	if err != nil {
+		var corrupt *appsettings.CorruptError
+		if errors.As(err, &corrupt) {
+			slog.Warn("Settings file is corrupt, using defaults", "backup", corrupt.BackupPath, "error", corrupt.Err)
+			app.mu.Lock()
+			app.settingsResetBackup = corrupt.BackupPath
+			app.settingsReset = true
+			app.mu.Unlock()
+			return
+		}
 		slog.Error("Failed to load settings", "error", err)
 		return
 	}
@@ -36,41 +97,269 @@ func (app *App) loadSettings() {
 		return
 	}
 
+	switch {
+	case settings.SchemaVersion > settingsSchemaVersion:
+		slog.Warn("Settings were written by a newer version; unrecognized settings are kept but ignored",
+			"file_version", settings.SchemaVersion, "supported_version", settingsSchemaVersion)
+	case settings.SchemaVersion < settingsSchemaVersion:
+		slog.Info("Upgrading settings schema", "from", settings.SchemaVersion, "to", settingsSchemaVersion)
+	}
+
 	// Override defaults with loaded values
+	app.settingsSchemaLoaded = settings.SchemaVersion
 	app.enableAudioCues = settings.EnableAudioCues
 	app.hideStaleIncoming = settings.HideStale
 	app.enableAutoBrowser = settings.EnableAutoBrowser
-	if settings.HiddenOrgs != nil {
-		app.hiddenOrgs = settings.HiddenOrgs
+	if settings.RefreshAnimation != nil {
+		app.enableRefreshAnimation = *settings.RefreshAnimation
+	}
+	if settings.DisplayMode.valid() {
+		app.displayMode = settings.DisplayMode
+	}
+	if settings.IncomingSort.valid() {
+		app.incomingSort = settings.IncomingSort
+	}
+	if settings.Highlight.valid() {
+		app.highlight = settings.Highlight
+	}
+	if settings.OpenedURLParam.valid() {
+		app.openedURLParam = settings.OpenedURLParam
+	}
+	app.menuLabelWidth = settings.MenuLabelWidth
+	app.sessionCap = 0
+	switch {
+	case settings.ReviewSessionCap > 0:
+		app.sessionCap = settings.ReviewSessionCap
+	case settings.ReviewSessionCap < 0:
+		slog.Warn("[SETTINGS] Ignoring negative review_session_cap", "review_session_cap", settings.ReviewSessionCap)
+	default:
+	}
+	app.commentBurstMin = 0
+	switch {
+	case settings.CommentBurstThreshold > 0:
+		app.commentBurstMin = settings.CommentBurstThreshold
+	case settings.CommentBurstThreshold < 0:
+		slog.Warn("[SETTINGS] Ignoring negative comment_burst_threshold", "comment_burst_threshold", settings.CommentBurstThreshold)
+	default:
+	}
+	app.commentBurstNotify = settings.CommentBurstNotify
+	app.adaptiveInterval = settings.AdaptiveInterval
+	app.maxTrackedPRs = 0
+	switch {
+	case settings.MaxTrackedPRs > 0:
+		app.maxTrackedPRs = settings.MaxTrackedPRs
+	case settings.MaxTrackedPRs < 0:
+		slog.Warn("[SETTINGS] Ignoring negative max_tracked_prs", "max_tracked_prs", settings.MaxTrackedPRs)
+	default:
+	}
+	app.snoozeClock = defaultSnoozeClock
+	if settings.SnoozeUntil != "" {
+		if validSnoozeClock(settings.SnoozeUntil) {
+			app.snoozeClock = settings.SnoozeUntil
+		} else {
+			slog.Warn("[SETTINGS] Ignoring invalid snooze_until, want HH:MM", "snooze_until", settings.SnoozeUntil)
+		}
+	}
+	app.digestClock = defaultDigestClock
+	if settings.DigestAt != "" {
+		if validSnoozeClock(settings.DigestAt) {
+			app.digestClock = settings.DigestAt
+		} else {
+			slog.Warn("[SETTINGS] Ignoring invalid digest_at, want HH:MM", "digest_at", settings.DigestAt)
+		}
+	}
+	app.autoOpenSchedule = weeklySchedule{}
+	if schedule, err := parseWeeklySchedule(settings.AutoOpenHours, settings.AutoOpenTimezone); err == nil {
+		app.autoOpenSchedule = schedule
+	} else {
+		slog.Warn("[SETTINGS] Ignoring invalid auto_open_hours, want ranges like \"Mon-Fri 09:00-17:00\"",
+			"auto_open_hours", settings.AutoOpenHours, "auto_open_timezone", settings.AutoOpenTimezone, "error", err)
+	}
+	app.digestEnabled = settings.Digest
+	app.digestWeekdaysOnly = settings.DigestWeekdaysOnly
+	app.reviewSLA = defaultReviewSLA
+	if settings.ReviewSLA != "" {
+		if sla, err := time.ParseDuration(settings.ReviewSLA); err == nil && sla > 0 {
+			app.reviewSLA = sla
+		} else {
+			slog.Warn("[SETTINGS] Ignoring invalid review_sla, want a positive duration like 48h", "review_sla", settings.ReviewSLA)
+		}
+	}
+	app.countRepos = settings.CountRepos
+	app.groupBotPRs = settings.GroupBotPRs
+	app.archWarningDismissed = settings.ArchWarningDismissed
+	app.showDockBadge = settings.ShowDockBadge
+	app.trackResponseTimes = settings.TrackResponseTimes
+	app.draftsBlock = settings.DraftsBlock
+	app.hideNonDefaultBase = settings.HideNonDefaultBase
+	app.hideIncoming = settings.HideIncoming
+	app.hideOutgoing = settings.HideOutgoing
+	if app.hideIncoming && app.hideOutgoing {
+		slog.Warn("Settings hide both PR sections, showing both")
+		app.hideIncoming, app.hideOutgoing = false, false
 	}
+	app.localeSetting = settings.Locale
+	app.dashboardURLSetting = settings.DashboardURL
+	app.dashboardPRTemplateSetting = settings.DashboardPRTemplate
+	app.notificationHookSetting = settings.NotificationHook
+	app.notifyTemplates = validNotifyTemplates(settings.NotificationTemplates)
+	app.filters = settings.Filters
+	app.repos = validRepos(settings.Repos)
+	app.repoModeUsers = settings.RepoModeUsers
+	app.team = validTeam(settings.Team)
+	if len(app.team) > 0 && len(app.repos) > 0 {
+		slog.Warn("[SETTINGS] Both repos and team are set, using repo mode")
+	}
+	app.applyOrgPolicies(migrateOrgPolicies(&settings))
+	app.mu.Lock()
+	app.seenOrgs = migrateOrgActivity(&settings)
+	app.orgActivitySaved = savedOrgTimes(app.seenOrgs)
+	app.mu.Unlock()
 
 	slog.Info("Loaded settings",
 		"audio_cues", app.enableAudioCues,
 		"hide_stale", app.hideStaleIncoming,
 		"auto_browser", app.enableAutoBrowser,
-		"hidden_orgs", len(app.hiddenOrgs))
+		"refresh_animation", app.enableRefreshAnimation,
+		"display_mode", app.displayMode,
+		"incoming_sort", app.incomingSort,
+		"opened_url_param", app.openedURLParam,
+		"count_repos", app.countRepos,
+		"group_bot_prs", app.groupBotPRs,
+		"snooze_until", app.snoozeClock,
+		"review_sla", app.reviewSLA,
+		"daily_digest", app.digestEnabled,
+		"digest_at", app.digestClock,
+		"digest_weekdays_only", app.digestWeekdaysOnly,
+		"auto_open_hours", app.autoOpenSchedule.label(),
+		"review_session_cap", app.sessionCap,
+		"comment_burst_threshold", app.commentBurstMin,
+		"comment_burst_notifications", app.commentBurstNotify,
+		"adaptive_interval", app.adaptiveInterval,
+		"max_tracked_prs", app.maxTrackedPRs,
+		"dock_badge", app.showDockBadge,
+		"drafts_block", app.draftsBlock,
+		"hide_non_default_base", app.hideNonDefaultBase,
+		"hide_incoming", app.hideIncoming,
+		"hide_outgoing", app.hideOutgoing,
+		"repos", len(app.repos),
+		"repo_mode_users", len(app.repoModeUsers),
+		"team", len(app.team),
+		"notification_templates", len(app.notifyTemplates),
+		"hidden_orgs", len(app.hiddenOrgs),
+		"silent_orgs", len(app.silentOrgs))
 }
 
 // saveSettings saves current settings to disk.
 func (app *App) saveSettings() {
 	app.mu.RLock()
+	refreshAnimation := app.enableRefreshAnimation
+	var reviewSLA string
+	if app.reviewSLA > 0 {
+		reviewSLA = formatSLA(app.reviewSLA)
+	}
 	settings := Settings{
-		EnableAudioCues:   app.enableAudioCues,
-		HideStale:         app.hideStaleIncoming,
-		EnableAutoBrowser: app.enableAutoBrowser,
-		HiddenOrgs:        app.hiddenOrgs,
+		// A newer build's file keeps its version, so that build doesn't re-run its upgrade
+		SchemaVersion:         max(app.settingsSchemaLoaded, settingsSchemaVersion),
+		RefreshAnimation:      &refreshAnimation,
+		DisplayMode:           app.displayMode,
+		IncomingSort:          app.incomingSort,
+		Highlight:             app.highlight,
+		OpenedURLParam:        app.openedURLParam,
+		MenuLabelWidth:        app.menuLabelWidth,
+		ReviewSessionCap:      app.sessionCap,
+		CommentBurstThreshold: app.commentBurstMin,
+		CommentBurstNotify:    app.commentBurstNotify,
+		AdaptiveInterval:      app.adaptiveInterval,
+		MaxTrackedPRs:         app.maxTrackedPRs,
+		CountRepos:            app.countRepos,
+		GroupBotPRs:           app.groupBotPRs,
+		ArchWarningDismissed:  app.archWarningDismissed,
+		ShowDockBadge:         app.showDockBadge,
+		TrackResponseTimes:    app.trackResponseTimes,
+		DraftsBlock:           app.draftsBlock,
+		HideNonDefaultBase:    app.hideNonDefaultBase,
+		HideIncoming:          app.hideIncoming,
+		HideOutgoing:          app.hideOutgoing,
+		Locale:                app.localeSetting,
+		DashboardURL:          app.dashboardURLSetting,
+		SnoozeUntil:           app.snoozeClock,
+		ReviewSLA:             reviewSLA,
+		DigestAt:              app.digestClock,
+		Digest:                app.digestEnabled,
+		DigestWeekdaysOnly:    app.digestWeekdaysOnly,
+		AutoOpenHours:         app.autoOpenSchedule.String(),
+		AutoOpenTimezone:      app.autoOpenSchedule.zone(),
+		DashboardPRTemplate:   app.dashboardPRTemplateSetting,
+		NotificationHook:      app.notificationHookSetting,
+		NotificationTemplates: app.notifyTemplates,
+		Filters:               app.filters,
+		Repos:                 app.repos,
+		RepoModeUsers:         app.repoModeUsers,
+		Team:                  app.team,
+		EnableAudioCues:       app.enableAudioCues,
+		HideStale:             app.hideStaleIncoming,
+		EnableAutoBrowser:     app.enableAutoBrowser,
+		OrgPolicies:           app.orgPolicySnapshot(),
+		OrgActivity:           maps.Clone(app.seenOrgs),
 	}
 	app.mu.RUnlock()
 
-	manager := appsettings.NewManager("reviewGOOSE")
+	manager := appsettings.NewManager(appDirName(app.profileName))
 	if err := manager.Save(&settings); err != nil {
+		if app.storage != nil {
+			// Keep the in-memory settings; they are flushed once storage recovers.
+			app.storage.recordFailure("settings", err)
+			return
+		}
 		slog.Error("Failed to save settings", "error", err)
 		return
 	}
+	if app.storage != nil {
+		app.storage.recordSuccess("settings")
+	}
+	app.mu.Lock()
+	app.orgActivitySaved = savedOrgTimes(settings.OrgActivity)
+	app.mu.Unlock()
 
 	slog.Info("Saved settings",
 		"audio_cues", settings.EnableAudioCues,
 		"hide_stale", settings.HideStale,
 		"auto_browser", settings.EnableAutoBrowser,
-		"hidden_orgs", len(settings.HiddenOrgs))
+		"org_policies", len(settings.OrgPolicies))
+}
+
+// settingsResetTitle returns the one-time notice shown after corrupt settings were
+// replaced by defaults, or "" if there is nothing to tell the user.
+func (app *App) settingsResetTitle() string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if !app.settingsReset {
+		return ""
+	}
+	return msg("settings.reset")
+}
+
+// addSettingsResetNotice shows the settings reset notice until it's clicked.
+func (app *App) addSettingsResetNotice(ctx context.Context) {
+	title := app.settingsResetTitle()
+	if title == "" {
+		return
+	}
+	app.mu.RLock()
+	backup := app.settingsResetBackup
+	app.mu.RUnlock()
+	tooltip := msg("settings.reset.tooltip.no_backup")
+	if backup != "" {
+		tooltip = msg("settings.reset.tooltip", backup)
+	}
+
+	item := app.systrayInterface.AddMenuItem(title, tooltip)
+	item.Click(func() {
+		app.mu.Lock()
+		app.settingsReset = false
+		app.mu.Unlock()
+		app.rebuildMenu(ctx)
+	})
+	app.systrayInterface.AddSeparator()
 }
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ratelimit"
+)
+
+// clickSetting invokes the click handler of the rendered settings entry with the given ID.
+func (m *MockSystray) clickSetting(t *testing.T, id string) {
+	t.Helper()
+	m.mu.Lock()
+	item := m.settingItems[id]
+	m.mu.Unlock()
+	if item == nil || item.clickHandler == nil {
+		t.Fatalf("no clickable setting %q in menu", id)
+	}
+	item.clickHandler()
+}
+
+// checkedStates returns the checked state of every toggle in the snapshot, keyed by ID.
+func checkedStates(snapshot []SettingState) map[string]bool {
+	states := make(map[string]bool)
+	for _, s := range snapshot {
+		if s.Checkable {
+			states[s.ID] = s.Checked
+		}
+	}
+	return states
+}
+
+func newSettingsMenuTestApp(t *testing.T) (*App, *MockSystray) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	mock := &MockSystray{}
+	app := &App{
+		mu:                 sync.RWMutex{},
+		stateManager:       NewPRStateManager(time.Now()),
+		seenOrgs:           make(map[string]orgActivity),
+		systrayInterface:   mock,
+		browserRateLimiter: ratelimit.NewBrowserRateLimiter(startupGracePeriod, 5, defaultMaxBrowserOpensDay),
+	}
+	app.loadSettings()
+	return app, mock
+}
+
+func TestStaticMenuSnapshot(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	app.enableRefreshAnimation = false
+
+	app.rebuildMenu(context.Background())
+
+	want := []SettingState{
+		{ID: "hide_stale", Label: "Hide stale PRs (>90 days)", Checkable: true, Checked: true},
+		{ID: "show_incoming", Label: "Show incoming PRs", Checkable: true, Checked: true},
+		{ID: "show_outgoing", Label: "Show outgoing PRs", Checkable: true, Checked: true},
+		{ID: "honks", Label: "Honks enabled", Tooltip: "Play sounds for notifications", Checkable: true, Checked: true},
+		{
+			ID:        "auto_open",
+			Label:     "Auto-open incoming PRs",
+			Tooltip:   "Automatically open newly blocked PRs in browser (rate limited)",
+			Checkable: true,
+			Checked:   true,
+		},
+		{ID: "refresh_animation", Label: "Animate icon while refreshing", Tooltip: "Turn off if the tray icon flickers on your desktop", Checkable: true},
+		{
+			ID:        "count_repos",
+			Label:     "Count repos instead of PRs",
+			Tooltip:   "Tray title counts repositories with blocked PRs, so bot storms look less alarming",
+			Checkable: true,
+		},
+		{
+			ID:        "group_bot_prs",
+			Label:     "Group bot PRs",
+			Tooltip:   "Collapse each repository's dependabot and renovate PRs into one menu entry",
+			Checkable: true,
+		},
+		{
+			ID:        "drafts_block",
+			Label:     "Treat draft actions as blocking",
+			Tooltip:   "Count, notify, and auto-open draft PRs that have a next action",
+			Checkable: true,
+		},
+		{
+			ID:        "hide_non_default_base",
+			Label:     "Hide PRs targeting non-default branches",
+			Tooltip:   "Move blocked PRs against release or backport branches to Filtered",
+			Checkable: true,
+		},
+		{
+			ID:        "response_times",
+			Label:     "Track response to honks (local only)",
+			Tooltip:   "Record how long you take to open notified PRs; stored on this computer only",
+			Checkable: true,
+		},
+		{
+			ID:        "daily_digest",
+			Label:     "Daily digest at 8:45",
+			Tooltip:   "One notification a day listing everything blocked on you",
+			Checkable: true,
+		},
+		{
+			ID:        "comment_bursts",
+			Label:     "Notify when discussion heats up",
+			Tooltip:   "A quiet notification when a burst of new comments lands on a PR you reviewed or wrote",
+			Checkable: true,
+		},
+		{
+			ID:        "adaptive_interval",
+			Label:     "Check less often when quiet",
+			Tooltip:   "Stretch the update interval up to 5 minutes while nothing changes; activity restores it",
+			Checkable: true,
+		},
+		{ID: "quit", Label: "Quit"},
+	}
+	if got := mock.SettingsSnapshot(); !slices.Equal(got, want) {
+		t.Errorf("SettingsSnapshot() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// Rendered titles and change-detection titles agree
+	titles := app.generateMenuTitles()
+	for _, s := range mock.SettingsSnapshot() {
+		if !slices.Contains(mock.menuItems, s.Title()) {
+			t.Errorf("menu is missing rendered title %q", s.Title())
+		}
+		if !slices.Contains(titles, s.Title()) {
+			t.Errorf("generateMenuTitles() is missing %q", s.Title())
+		}
+	}
+}
+
+func TestStaticMenuTogglesTwice(t *testing.T) {
+	ctx := context.Background()
+	app, mock := newSettingsMenuTestApp(t)
+	app.rebuildMenu(ctx)
+
+	for _, id := range []string{"hide_stale", "honks", "auto_open", "refresh_animation", "count_repos", "response_times"} {
+		t.Run(id, func(t *testing.T) {
+			initial := checkedStates(mock.SettingsSnapshot())
+			for toggle := 1; toggle <= 2; toggle++ {
+				mock.clickSetting(t, id)
+
+				want := maps.Clone(initial)
+				want[id] = initial[id] != (toggle%2 == 1)
+
+				got := checkedStates(mock.SettingsSnapshot())
+				if !maps.Equal(got, want) {
+					t.Errorf("after toggle %d: menu states = %v, want %v", toggle, got, want)
+				}
+
+				// Each toggle is persisted: a fresh load sees the same state
+				loaded := &App{mu: sync.RWMutex{}, systrayInterface: &MockSystray{}}
+				loaded.loadSettings()
+				persisted := make(map[string]bool)
+				for _, s := range loaded.settingItems() {
+					if s.Checked != nil {
+						persisted[s.ID] = s.Checked()
+					}
+				}
+				if !maps.Equal(persisted, want) {
+					t.Errorf("after toggle %d: saved settings = %v, want %v", toggle, persisted, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStaticMenuHandlersSurviveRebuilds(t *testing.T) {
+	ctx := context.Background()
+	app, mock := newSettingsMenuTestApp(t)
+	app.rebuildMenu(ctx)
+	handlers := mock.LiveHandlers()
+	initial := checkedStates(mock.SettingsSnapshot())
+
+	// Hold on to the first generation's items, as a platform that kept them would
+	quit := mock.settingItems["quit"]
+	for range 50 {
+		app.rebuildMenu(ctx)
+	}
+	if got := mock.LiveHandlers(); got != handlers {
+		t.Fatalf("live handlers = %d after 50 rebuilds, want %d", got, handlers)
+	}
+	if quit.clickHandler != nil {
+		t.Error("the first menu's Quit handler is still registered")
+	}
+
+	mock.clickSetting(t, "quit")
+	if mock.quits != 1 {
+		t.Errorf("Quit ran %d times, want 1", mock.quits)
+	}
+
+	persisted := func() map[string]bool {
+		loaded := &App{mu: sync.RWMutex{}, systrayInterface: &MockSystray{}}
+		loaded.loadSettings()
+		states := make(map[string]bool)
+		for _, s := range loaded.settingItems() {
+			if s.Checked != nil {
+				states[s.ID] = s.Checked()
+			}
+		}
+		return states
+	}
+	want := maps.Clone(initial)
+	for _, id := range []string{"hide_stale", "honks", "auto_open", "refresh_animation", "count_repos", "response_times"} {
+		mock.clickSetting(t, id)
+		want[id] = !initial[id]
+		if got := checkedStates(mock.SettingsSnapshot()); !maps.Equal(got, want) {
+			t.Errorf("after clicking %s: menu states = %v, want %v", id, got, want)
+		}
+		if got := persisted(); !maps.Equal(got, want) {
+			t.Errorf("after clicking %s: saved settings = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestStaticMenuReflectsStateOnNonClickRebuild(t *testing.T) {
+	ctx := context.Background()
+	app, mock := newSettingsMenuTestApp(t)
+	app.rebuildMenu(ctx)
+	before := app.generateMenuTitles()
+
+	// Settings changed outside of a menu click, e.g. by a settings reload
+	app.mu.Lock()
+	app.enableAudioCues = false
+	app.mu.Unlock()
+
+	if slices.Equal(before, app.generateMenuTitles()) {
+		t.Error("expected change detection to notice a setting change")
+	}
+
+	app.rebuildMenu(ctx)
+	if checkedStates(mock.SettingsSnapshot())["honks"] {
+		t.Error("expected honks checkmark to be cleared after rebuild")
+	}
+}
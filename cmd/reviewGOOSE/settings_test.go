@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
+)
+
+// writeSettingsFile writes raw settings for the default profile under the test's XDG dirs.
+func writeSettingsFile(t *testing.T, content string) string {
+	t.Helper()
+	path, err := appsettings.NewManager(appDirName("")).Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, content)
+	return path
+}
+
+// readSettingsKeys returns the top-level keys of the saved settings file.
+func readSettingsKeys(t *testing.T, path string) map[string]json.RawMessage {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		t.Fatalf("saved settings are not valid JSON: %v\n%s", err, data)
+	}
+	return keys
+}
+
+func TestLoadSettingsCorruptFileFallsBackToDefaults(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	path := writeSettingsFile(t, `{"hidden_orgs":{"oldcorp":true},"enable_audio_cues":fal`)
+
+	app.loadSettings()
+	if !app.enableAudioCues || app.hiddenOrgs["oldcorp"] {
+		t.Errorf("corrupt settings should fall back to defaults: audio=%v hidden=%v", app.enableAudioCues, app.hiddenOrgs)
+	}
+	if !app.settingsReset {
+		t.Fatal("expected the settings reset notice to be pending")
+	}
+	if backup, err := os.ReadFile(app.settingsResetBackup); err != nil || len(backup) == 0 {
+		t.Errorf("corrupt file should be backed up, got %q, %v", backup, err)
+	}
+	if filepath.Dir(app.settingsResetBackup) != filepath.Dir(path) {
+		t.Errorf("backup %q should sit next to %q", app.settingsResetBackup, path)
+	}
+
+	app.rebuildMenu(t.Context())
+	if !slices.Contains(mock.menuItems, msg("settings.reset")) {
+		t.Errorf("expected the settings reset notice in the menu, got %v", mock.menuItems)
+	}
+
+	app.settingsReset = false
+	app.rebuildMenu(t.Context())
+	if slices.Contains(mock.menuItems, msg("settings.reset")) {
+		t.Error("the notice should be gone once dismissed")
+	}
+}
+
+func TestSettingsSchemaUpgrade(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	// Pre-versioning file that still uses the legacy hidden_orgs key.
+	path := writeSettingsFile(t, `{"hidden_orgs":{"oldcorp":true},"enable_audio_cues":false,"hide_stale":true}`)
+
+	app.loadSettings()
+	if !app.hiddenOrgs["oldcorp"] || app.enableAudioCues {
+		t.Fatalf("legacy settings not applied: hidden=%v audio=%v", app.hiddenOrgs, app.enableAudioCues)
+	}
+	app.saveSettings()
+
+	keys := readSettingsKeys(t, path)
+	if got := string(keys["schema_version"]); got != strconv.Itoa(settingsSchemaVersion) {
+		t.Errorf("schema_version = %s, want %d", got, settingsSchemaVersion)
+	}
+	if _, ok := keys["hidden_orgs"]; ok {
+		t.Error("the legacy hidden_orgs key should be replaced by org_policies")
+	}
+	if _, ok := keys["org_policies"]; !ok {
+		t.Error("expected org_policies after the upgrade")
+	}
+}
+
+func TestSettingsSchemaDowngradeKeepsNewerSettings(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	// Written by a newer build that added keys this one doesn't know.
+	path := writeSettingsFile(t, `{
+  "schema_version": `+strconv.Itoa(settingsSchemaVersion+1)+`,
+  "hide_stale": false,
+  "enable_audio_cues": true,
+  "enable_auto_browser": false,
+  "quiet_hours": {"start": "22:00", "end": "07:00"}
+}`)
+
+	app.loadSettings()
+	if app.hideStaleIncoming || app.enableAutoBrowser {
+		t.Errorf("known settings from a newer file should still load: hide_stale=%v auto_browser=%v",
+			app.hideStaleIncoming, app.enableAutoBrowser)
+	}
+	app.hideStaleIncoming = true
+	app.saveSettings()
+
+	keys := readSettingsKeys(t, path)
+	if string(keys["hide_stale"]) != "true" {
+		t.Errorf("hide_stale = %s, want this build's change saved", keys["hide_stale"])
+	}
+	var quiet map[string]string
+	if err := json.Unmarshal(keys["quiet_hours"], &quiet); err != nil || quiet["start"] != "22:00" {
+		t.Errorf("quiet_hours = %s, want the newer build's key preserved", keys["quiet_hours"])
+	}
+
+	// Reloading in this build still works and keeps the preserved key on the next save.
+	app.loadSettings()
+	app.saveSettings()
+	if _, ok := readSettingsKeys(t, path)["quiet_hours"]; !ok {
+		t.Error("quiet_hours should survive repeated saves")
+	}
+}
+
+func TestSettingsSchemaRoundTripKeepsNewerVersion(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	newer := settingsSchemaVersion + 1
+	// A newer build wrote the file; this build saves it, then the newer build reads it back.
+	path := writeSettingsFile(t, `{"schema_version": `+strconv.Itoa(newer)+`, "hide_stale": true}`)
+
+	app.loadSettings()
+	app.hideStaleIncoming = false
+	app.saveSettings()
+	if got := string(readSettingsKeys(t, path)["schema_version"]); got != strconv.Itoa(newer) {
+		t.Errorf("schema_version after an older build's save = %s, want %d", got, newer)
+	}
+
+	// Loading and saving again doesn't lower it either.
+	app.loadSettings()
+	app.saveSettings()
+	if got := string(readSettingsKeys(t, path)["schema_version"]); got != strconv.Itoa(newer) {
+		t.Errorf("schema_version after a second save = %s, want %d", got, newer)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/gen2brain/beeep"
+)
+
+// Notifier shows desktop notifications.
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// SoundPlayer plays a sound file from disk.
+type SoundPlayer interface {
+	Play(ctx context.Context, path string)
+}
+
+// BrowserOpener opens URLs in the user's browser.
+type BrowserOpener interface {
+	Open(ctx context.Context, rawURL, gooseParam string) error
+}
+
+// desktopNotifier sends notifications through the OS notification center.
+type desktopNotifier struct{}
+
+func (desktopNotifier) Notify(title, message string) error {
+	return beeep.Notify(title, message, "")
+}
+
+// systemSoundPlayer plays sounds in the background using platform-specific commands.
+type systemSoundPlayer struct {
+	goFn goFunc // Starts playback; nil starts an untracked goroutine
+}
+
+func (p systemSoundPlayer) Play(ctx context.Context, path string) {
+	p.goFn.start("sound", func() {
+		// Use a timeout context for sound playback
+		soundCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		var commands [][]string
+		switch runtime.GOOS {
+		case "darwin":
+			commands = [][]string{{"afplay", path}}
+		case "windows":
+			// Use Windows Media Player API via rundll32 to avoid PowerShell script injection
+			// This is safer than constructing PowerShell scripts with user paths
+			commands = [][]string{{"cmd", "/c", "start", "/min", "", path}}
+		case "linux":
+			// Try paplay first (PulseAudio), then aplay (ALSA)
+			commands = [][]string{{"paplay", path}, {"aplay", "-q", path}}
+		default:
+			return
+		}
+
+		var err error
+		for _, args := range commands {
+			if err = exec.CommandContext(soundCtx, args[0], args[1:]...).Run(); err == nil {
+				return
+			}
+		}
+		slog.Error("Failed to play sound", "error", err)
+	})
+}
+
+// systemBrowser opens URLs with the default browser after safebrowse validation.
+type systemBrowser struct{}
+
+func (systemBrowser) Open(ctx context.Context, rawURL, gooseParam string) error {
+	return openURL(ctx, rawURL, gooseParam)
+}
+
+// silentNotifier, silentSoundPlayer, and silentBrowser drop every side effect.
+type (
+	silentNotifier    struct{}
+	silentSoundPlayer struct{}
+	silentBrowser     struct{}
+)
+
+func (silentNotifier) Notify(title, _ string) error {
+	slog.Debug("[SILENT] Suppressed notification", "title", title)
+	return nil
+}
+
+func (silentSoundPlayer) Play(_ context.Context, path string) {
+	slog.Debug("[SILENT] Suppressed sound", "path", path)
+}
+
+func (silentBrowser) Open(_ context.Context, rawURL, _ string) error {
+	slog.Debug("[SILENT] Suppressed browser open", "url", sanitizeForLog(rawURL))
+	return nil
+}
+
+// silentModeRequested reports whether silent mode is enabled by flag or GOOSE_SILENT=1.
+func silentModeRequested(flagValue bool) bool {
+	return flagValue || os.Getenv("GOOSE_SILENT") == "1"
+}
+
+// installSideEffects wires the notification, sound, and browser implementations.
+// Silent mode installs no-ops so the app can run against real data without any noise.
+func (app *App) installSideEffects(silent bool) {
+	app.silentMode = silent
+	if silent {
+		app.notifier = silentNotifier{}
+		app.soundPlayer = silentSoundPlayer{}
+		app.browser = silentBrowser{}
+		slog.Info("[SILENT] Silent mode active: notifications, sounds, and browser opens are disabled")
+		return
+	}
+	app.notifier = app.newDesktopNotifier()
+	app.soundPlayer = systemSoundPlayer{goFn: app.goTracked}
+	app.browser = systemBrowser{}
+	app.clipboard = systemClipboard{}
+}
+
+// notify sends a desktop notification that isn't about a single PR.
+func (app *App) notify(title, message string) error {
+	return app.notifyEvent(notificationEvent{kind: notifyKindOther, title: title, message: message})
+}
+
+// notifyEvent sends a desktop notification, defaulting to the OS notifier. If the
+// notification service is down, the notification is queued for retry and nil returned.
+func (app *App) notifyEvent(e notificationEvent) error {
+	if err := app.deliverNotification(e); err != nil {
+		if app.notifyQueue == nil || app.silentMode {
+			return err
+		}
+		slog.Warn("[NOTIFY] Notification failed, queued for retry", "title", e.title, "error", err)
+		app.notifyQueue.enqueue(e)
+		return nil
+	}
+	if app.notifyQueue != nil {
+		app.notifyQueue.recovered()
+	}
+	return nil
+}
+
+// deliverNotification shows one notification. Every notification, queued or not, passes
+// through here, so "Recent notifications" records exactly what was shown.
+func (app *App) deliverNotification(e notificationEvent) error {
+	var notifier Notifier = desktopNotifier{}
+	if app.notifier != nil {
+		notifier = app.notifier
+	}
+	if err := notifier.Notify(e.title, e.message); err != nil {
+		return err
+	}
+	if app.silentMode {
+		return nil
+	}
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordNotificationSent()
+	}
+	// Being told about a PR settles its new comments
+	app.settleComments(e.prURL)
+	app.noteNotified(e.prURL)
+	if app.notifications != nil {
+		app.notifications.record(e)
+	}
+	return nil
+}
+
+// openBrowser opens a URL, defaulting to the system browser. gooseParam is what the
+// opened link parameter setting appends in action mode; empty means a plain click.
+func (app *App) openBrowser(ctx context.Context, rawURL, gooseParam string) error {
+	var browser BrowserOpener = systemBrowser{}
+	if app.browser != nil {
+		browser = app.browser
+	}
+	if err := browser.Open(ctx, rawURL, app.urlParam().value(gooseParam)); err != nil {
+		return err
+	}
+	if app.silentMode {
+		// Nothing was opened, so there's no open to remember or time to a response
+		return nil
+	}
+	app.recordOpened(rawURL)
+	app.recordPROpened(ctx, rawURL)
+	app.noteSessionOpen(rawURL, gooseParam)
+	return nil
+}
+
+// tooltipText adds the profile name, and marks the tooltip while silent mode is
+// active so it isn't forgotten.
+func (app *App) tooltipText(tooltip string) string {
+	if app.profileName != "" {
+		tooltip = fmt.Sprintf("%s [%s]", tooltip, app.profileName)
+	}
+	if app.silentMode {
+		return tooltip + " " + msg("tray.tooltip.silent")
+	}
+	return tooltip
+}
+
+// baseTooltip is the idle tray tooltip, naming the target user when one is set and
+// saying so when GitHub never confirmed the login.
+func (app *App) baseTooltip() string {
+	if app.repoMode() {
+		return msg("tray.tooltip.repo_mode")
+	}
+	if app.teamMode() {
+		return msg("tray.tooltip.team_mode", len(app.team))
+	}
+	if app.targetUser != "" {
+		tooltip := msg("tray.tooltip.user", app.targetUser)
+		if app.loginOverride != "" {
+			tooltip += " " + msg("tray.tooltip.login_override")
+		}
+		return tooltip
+	}
+	return msg("tray.tooltip")
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingNotifier struct {
+	titles chan string
+}
+
+func (n *recordingNotifier) Notify(title, _ string) error {
+	n.titles <- title
+	return nil
+}
+
+func TestSilentModeRequested(t *testing.T) {
+	t.Setenv("GOOSE_SILENT", "")
+	if silentModeRequested(false) {
+		t.Error("silent mode enabled without flag or env var")
+	}
+	if !silentModeRequested(true) {
+		t.Error("-silent flag did not enable silent mode")
+	}
+
+	t.Setenv("GOOSE_SILENT", "1")
+	if !silentModeRequested(false) {
+		t.Error("GOOSE_SILENT=1 did not enable silent mode")
+	}
+
+	t.Setenv("GOOSE_SILENT", "true")
+	if silentModeRequested(false) {
+		t.Error("only GOOSE_SILENT=1 should enable silent mode")
+	}
+}
+
+func TestInstallSideEffectsFromEnv(t *testing.T) {
+	t.Setenv("GOOSE_SILENT", "1")
+	app := &App{}
+	app.installSideEffects(silentModeRequested(false))
+
+	if _, ok := app.notifier.(silentNotifier); !ok {
+		t.Errorf("notifier = %T, want silentNotifier", app.notifier)
+	}
+	if _, ok := app.soundPlayer.(silentSoundPlayer); !ok {
+		t.Errorf("soundPlayer = %T, want silentSoundPlayer", app.soundPlayer)
+	}
+	if _, ok := app.browser.(silentBrowser); !ok {
+		t.Errorf("browser = %T, want silentBrowser", app.browser)
+	}
+	if err := app.openBrowser(context.Background(), "https://github.com/org/repo/pull/1", ""); err != nil {
+		t.Errorf("silent browser returned error: %v", err)
+	}
+	if got := app.tooltipText("Goose - 1 incoming"); got != "Goose - 1 incoming (silent mode)" {
+		t.Errorf("tooltipText() = %q", got)
+	}
+
+	t.Setenv("GOOSE_SILENT", "")
+	app = &App{}
+	app.installSideEffects(silentModeRequested(false))
+	notifier := app.notifier
+	if fallback, ok := notifier.(*fallbackNotifier); ok {
+		notifier = fallback.primary
+	}
+	if _, ok := notifier.(desktopNotifier); !ok {
+		t.Errorf("notifier = %T, want desktopNotifier", app.notifier)
+	}
+	if _, ok := app.soundPlayer.(systemSoundPlayer); !ok {
+		t.Errorf("soundPlayer = %T, want systemSoundPlayer", app.soundPlayer)
+	}
+	if _, ok := app.browser.(systemBrowser); !ok {
+		t.Errorf("browser = %T, want systemBrowser", app.browser)
+	}
+	if got := app.tooltipText("Goose - 1 incoming"); got != "Goose - 1 incoming" {
+		t.Errorf("tooltipText() = %q, want no suffix outside silent mode", got)
+	}
+}
+
+func TestPRNotificationUsesInstalledNotifier(t *testing.T) {
+	notifier := &recordingNotifier{titles: make(chan string, 1)}
+	app := &App{mu: sync.RWMutex{}, notifier: notifier}
+	pr := PR{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1"}
+	playedSound := true // Skip sound playback
+	app.sendPRNotification(context.Background(), &pr, "Review needed", "honk", &playedSound)
+
+	select {
+	case title := <-notifier.titles:
+		if title != "Review needed" {
+			t.Errorf("notification title = %q, want %q", title, "Review needed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("installed notifier was not used")
+	}
+}
+
+// fakeSoundCommands puts paplay and aplay scripts first on PATH that log each run,
+// paplay exiting with paplayExit, and returns the log's path.
+func fakeSoundCommands(t *testing.T, paplayExit int) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("paplay and aplay are the Linux players")
+	}
+	dir := t.TempDir()
+	runs := filepath.Join(dir, "runs")
+	for name, exit := range map[string]int{"paplay": paplayExit, "aplay": 0} {
+		script := fmt.Sprintf("#!/bin/sh\necho %s >> %s\nexit %d\n", name, runs, exit)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return runs
+}
+
+// soundRuns returns the players the fake sound commands ran, in order.
+func soundRuns(t *testing.T, runs string) string {
+	t.Helper()
+	data, err := os.ReadFile(runs)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestLinuxPlayerFallsBackOnlyOnFailure(t *testing.T) {
+	tests := []struct {
+		want       string
+		paplayExit int
+	}{
+		{paplayExit: 0, want: "paplay\n"},
+		{paplayExit: 1, want: "paplay\naplay\n"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("paplay exits %d", tt.paplayExit), func(t *testing.T) {
+			runs := fakeSoundCommands(t, tt.paplayExit)
+			inline := goFunc(func(_ string, fn func()) bool { fn(); return true })
+			systemSoundPlayer{goFn: inline}.Play(context.Background(), "honk.wav")
+			if got := soundRuns(t, runs); got != tt.want {
+				t.Errorf("players run = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSilentModeTogglingHonksPlaysNothing(t *testing.T) {
+	t.Setenv("GOOSE_TEST_MODE", "")
+	runs := fakeSoundCommands(t, 0)
+	app := newFocusTestApp(time.Hour)
+	app.cacheDir = t.TempDir()
+	// initSoundCache only runs once per process, so cache the sound here
+	if err := os.MkdirAll(filepath.Join(app.cacheDir, "sounds"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(app.cacheDir, "sounds", "honk.wav"), honkSound, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	app.installSideEffects(true)
+	app.lifecycle = newLifecycle(func() {})
+
+	for _, item := range app.settingItems() {
+		if item.ID == "honks" {
+			item.OnToggle() // On, since they start off
+		}
+	}
+	if !app.enableAudioCues {
+		t.Fatal("honks weren't toggled back on")
+	}
+	app.playSound(context.Background(), "honk")
+	app.lifecycle.Shutdown(5*time.Second, func() {})
+	if got := soundRuns(t, runs); got != "" {
+		t.Errorf("silent mode played %q", got)
+	}
+}
+
+func TestSilentModeRecordsNoOpens(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.installSideEffects(true)
+	if err := app.openBrowser(context.Background(), "https://github.com/org/repo/pull/1", ""); err != nil {
+		t.Fatalf("openBrowser() = %v", err)
+	}
+	if len(app.prOpenedAt) != 0 {
+		t.Errorf("prOpenedAt = %v, want nothing recorded for a suppressed open", app.prOpenedAt)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// "Snooze incoming until tomorrow" is an end-of-day action: it stops the incoming PRs
+// blocked on me right now from counting or notifying until the next snooze_until time
+// (09:00 by default). Only those PRs are covered; one that becomes blocked later still
+// notifies, and outgoing PRs are never snoozed.
+
+// defaultSnoozeClock is when a snooze ends unless settings say otherwise.
+const defaultSnoozeClock = "09:00"
+
+// snoozeClockLayout is the format of the snooze_until setting.
+const snoozeClockLayout = "15:04"
+
+// incomingSnooze is the set of incoming PRs snoozed together and when they wake up.
+type incomingSnooze struct {
+	until time.Time
+	urls  map[string]bool
+}
+
+// active reports whether the snooze still holds at now.
+func (s *incomingSnooze) active(now time.Time) bool {
+	return s != nil && now.Before(s.until)
+}
+
+// validSnoozeClock reports whether clock is a usable snooze_until value.
+func validSnoozeClock(clock string) bool {
+	_, err := time.Parse(snoozeClockLayout, clock)
+	return err == nil
+}
+
+// nextSnoozeEnd is the first time after now that the wall clock reads clock, in now's
+// location: tomorrow morning in the evening, this morning after midnight.
+func nextSnoozeEnd(now time.Time, clock string) time.Time {
+	t, err := time.Parse(snoozeClockLayout, clock)
+	if err != nil {
+		t, _ = time.Parse(snoozeClockLayout, defaultSnoozeClock)
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !end.After(now) {
+		end = time.Date(now.Year(), now.Month(), now.Day()+1, t.Hour(), t.Minute(), 0, 0, now.Location())
+	}
+	return end
+}
+
+// snoozeEndLabel formats a snooze end like "9:00".
+func snoozeEndLabel(t time.Time) string {
+	return strings.TrimPrefix(t.Format(snoozeClockLayout), "0")
+}
+
+// applySnooze returns prs with the snoozed ones demoted to non-blocking and marked
+// Snoozed. Like applyDismissals, the input is never modified.
+func applySnooze(prs []PR, s *incomingSnooze, now time.Time) []PR {
+	if !s.active(now) {
+		return prs
+	}
+	var out []PR
+	for i := range prs {
+		if !s.urls[prs[i].URL] {
+			continue
+		}
+		if out == nil {
+			out = make([]PR, len(prs))
+			copy(out, prs)
+		}
+		out[i].NeedsReview = false
+		out[i].IsBlocked = false
+		out[i].Snoozed = true
+	}
+	if out == nil {
+		return prs
+	}
+	return out
+}
+
+// currentSnooze returns the snooze in effect at now, dropping one that has expired.
+func (app *App) currentSnooze(now time.Time) *incomingSnooze {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.snooze != nil && !app.snooze.active(now) {
+		slog.Info("[STATE] Incoming snooze ended", "until", app.snooze.until.Format(time.RFC3339), "prs", len(app.snooze.urls))
+		app.snooze = nil
+	}
+	return app.snooze
+}
+
+// snoozeIncoming snoozes every incoming PR the menu shows as blocked at now, returning
+// how many.
+func (app *App) snoozeIncoming(now time.Time) int {
+	view := app.snapshotPRs()
+	urls := make(map[string]bool)
+	for i := range view.incoming {
+		if view.incoming[i].NeedsReview || view.incoming[i].IsBlocked {
+			urls[view.incoming[i].URL] = true
+		}
+	}
+	if len(urls) == 0 {
+		return 0
+	}
+	app.mu.Lock()
+	until := nextSnoozeEnd(now, app.snoozeClock)
+	app.snooze = &incomingSnooze{until: until, urls: urls}
+	app.mu.Unlock()
+	slog.Info("[STATE] Snoozed incoming PRs", "prs", len(urls), "until", until.Format(time.RFC3339))
+	return len(urls)
+}
+
+// unsnooze ends the snooze early.
+func (app *App) unsnooze() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.snooze == nil {
+		return
+	}
+	slog.Info("[STATE] Incoming snooze ended early", "prs", len(app.snooze.urls))
+	app.snooze = nil
+}
+
+// snoozeTitles lists the snooze menu lines for change detection.
+func (app *App) snoozeTitles(blockedIncoming int) []string {
+	if s := app.currentSnooze(time.Now()); s != nil {
+		return []string{snoozeActiveTitle(s), msg("snooze.undo")}
+	}
+	if blockedIncoming == 0 {
+		return nil
+	}
+	return []string{msg("snooze.title")}
+}
+
+// snoozeActiveTitle is the disabled "Snoozed until 9:00 (7 PRs)" line.
+func snoozeActiveTitle(s *incomingSnooze) string {
+	if len(s.urls) == 1 {
+		return msg("snooze.active.one", snoozeEndLabel(s.until))
+	}
+	return msg("snooze.active", snoozeEndLabel(s.until), len(s.urls))
+}
+
+// addSnoozeIncoming adds "Snooze incoming until tomorrow" under the Incoming header, or,
+// while a snooze holds, the line saying so and "Unsnooze now".
+func (app *App) addSnoozeIncoming(ctx context.Context, blockedIncoming int) {
+	if s := app.currentSnooze(time.Now()); s != nil {
+		app.systrayInterface.AddMenuItem(snoozeActiveTitle(s), msg("snooze.active.tooltip")).Disable()
+		app.systrayInterface.AddMenuItem(msg("snooze.undo"), "").Click(func() {
+			app.unsnooze()
+			app.presentTrayState()
+			app.rebuildMenu(ctx)
+		})
+		return
+	}
+	if blockedIncoming == 0 {
+		return
+	}
+	app.mu.RLock()
+	clock := app.snoozeClock
+	app.mu.RUnlock()
+	end := snoozeEndLabel(nextSnoozeEnd(time.Now(), clock))
+	app.systrayInterface.AddMenuItem(msg("snooze.title"), msg("snooze.tooltip", end)).Click(func() {
+		app.snoozeIncoming(time.Now())
+		app.presentTrayState()
+		app.rebuildMenu(ctx)
+	})
+}
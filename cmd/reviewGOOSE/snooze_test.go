@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func snoozeTestPR(n int) PR {
+	return PR{
+		Repository: "org/repo", Number: n, URL: "https://github.com/org/repo/pull/" + strconv.Itoa(n),
+		NeedsReview: true, ActionKind: "review", UpdatedAt: time.Now(),
+	}
+}
+
+// topMenuItem returns the top-level menu item titled title, or nil.
+func topMenuItem(mock *MockSystray, title string) *MockMenuItem {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	for _, item := range mock.items {
+		if item.title == title {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestNextSnoozeEnd(t *testing.T) {
+	loc := time.FixedZone("test", -5*3600)
+	tests := []struct {
+		name  string
+		now   time.Time
+		clock string
+		want  time.Time
+	}{
+		{name: "evening", now: time.Date(2026, 3, 10, 18, 0, 0, 0, loc), clock: "09:00", want: time.Date(2026, 3, 11, 9, 0, 0, 0, loc)},
+		{name: "after midnight", now: time.Date(2026, 3, 11, 1, 30, 0, 0, loc), clock: "09:00", want: time.Date(2026, 3, 11, 9, 0, 0, 0, loc)},
+		{name: "at the time", now: time.Date(2026, 3, 11, 9, 0, 0, 0, loc), clock: "09:00", want: time.Date(2026, 3, 12, 9, 0, 0, 0, loc)},
+		{name: "new year", now: time.Date(2026, 12, 31, 23, 0, 0, 0, loc), clock: "08:30", want: time.Date(2027, 1, 1, 8, 30, 0, 0, loc)},
+		{name: "unset", now: time.Date(2026, 3, 10, 18, 0, 0, 0, loc), clock: "", want: time.Date(2026, 3, 11, 9, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		if got := nextSnoozeEnd(tt.now, tt.clock); !got.Equal(tt.want) {
+			t.Errorf("%s: nextSnoozeEnd(%v, %q) = %v, want %v", tt.name, tt.now, tt.clock, got, tt.want)
+		}
+	}
+	if validSnoozeClock("9am") || validSnoozeClock("25:00") || !validSnoozeClock("07:45") {
+		t.Error("validSnoozeClock() accepts the wrong values")
+	}
+}
+
+func TestSnoozeExpiresAtTargetAcrossMidnight(t *testing.T) {
+	evening := time.Date(2026, 3, 10, 18, 0, 0, 0, time.Local)
+	prs := []PR{snoozeTestPR(1), snoozeTestPR(2)}
+	s := &incomingSnooze{until: nextSnoozeEnd(evening, "09:00"), urls: map[string]bool{prs[0].URL: true}}
+
+	for _, tt := range []struct {
+		at      time.Time
+		snoozed bool
+	}{
+		{at: evening, snoozed: true},
+		{at: time.Date(2026, 3, 11, 0, 0, 1, 0, time.Local), snoozed: true},
+		{at: time.Date(2026, 3, 11, 8, 59, 59, 0, time.Local), snoozed: true},
+		{at: time.Date(2026, 3, 11, 9, 0, 0, 0, time.Local), snoozed: false},
+	} {
+		got := applySnooze(prs, s, tt.at)
+		if got[0].Snoozed != tt.snoozed || got[0].NeedsReview == tt.snoozed {
+			t.Errorf("at %v: snoozed PR = %+v, want snoozed=%v", tt.at, got[0], tt.snoozed)
+		}
+		if got[1].Snoozed || !got[1].NeedsReview {
+			t.Errorf("at %v: PR outside the snooze was demoted", tt.at)
+		}
+	}
+	if prs[0].Snoozed || !prs[0].NeedsReview {
+		t.Error("applySnooze() modified its input")
+	}
+}
+
+func TestSnoozeIncomingExemptsNewPRs(t *testing.T) {
+	app, notifier := newGraceTestApp(time.Hour)
+	app.hasPerformedInitialDiscovery = true
+	app.incoming = []PR{snoozeTestPR(1), snoozeTestPR(2)}
+	app.outgoing = []PR{{Repository: "org/mine", Number: 9, URL: "https://github.com/org/mine/pull/9", IsBlocked: true, ActionKind: "merge", UpdatedAt: time.Now()}}
+	app.processNotifications(context.Background())
+	if notes := waitForNotes(notifier, 3); len(notes) != 3 {
+		t.Fatalf("got %d notifications before the snooze, want 3", len(notes))
+	}
+
+	if n := app.snoozeIncoming(time.Now()); n != 2 {
+		t.Fatalf("snoozeIncoming() = %d, want both blocked incoming PRs", n)
+	}
+	if c := app.countPRs(); c.IncomingBlocked != 0 || c.OutgoingBlocked != 1 {
+		t.Errorf("counts while snoozed = %+v, want incoming cleared and outgoing kept", c)
+	}
+
+	// A PR that becomes blocked during the snooze still counts and notifies
+	app.incoming = append(app.incoming, snoozeTestPR(3))
+	app.processNotifications(context.Background())
+	notes := waitForNotes(notifier, 4)
+	if len(notes) != 4 || !strings.Contains(notes[3], "#3") {
+		t.Errorf("notifications during the snooze = %q, want only #3 added", notes)
+	}
+	if c := app.countPRs(); c.IncomingBlocked != 1 {
+		t.Errorf("incoming blocked during the snooze = %d, want the new PR", c.IncomingBlocked)
+	}
+	if cleared := app.stateManager.RecentlyCleared(); len(cleared) != 0 {
+		t.Errorf("snoozed PRs recorded as cleared: %+v", cleared)
+	}
+
+	// Once it ends they count again
+	app.mu.Lock()
+	app.snooze.until = time.Now().Add(-time.Second)
+	app.mu.Unlock()
+	if c := app.countPRs(); c.IncomingBlocked != 3 {
+		t.Errorf("incoming blocked after the snooze = %d, want 3", c.IncomingBlocked)
+	}
+	if app.currentSnooze(time.Now()) != nil {
+		t.Error("expired snooze still current")
+	}
+}
+
+func TestSnoozeMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	mock := &MockSystray{}
+	app.systrayInterface = mock
+	app.incoming = []PR{snoozeTestPR(1), snoozeTestPR(2)}
+	ctx := context.Background()
+
+	app.rebuildMenu(ctx)
+	snooze := topMenuItem(mock, msg("snooze.title"))
+	if snooze == nil {
+		t.Fatalf("menu %q lacks the snooze action", mock.menuItems)
+	}
+	snooze.clickHandler()
+
+	app.mu.RLock()
+	until := app.snooze.until
+	app.mu.RUnlock()
+	active := msg("snooze.active", snoozeEndLabel(until), 2)
+	if !slices.Contains(mock.menuItems, active) {
+		t.Fatalf("menu %q lacks %q", mock.menuItems, active)
+	}
+	if line := topMenuItem(mock, active); !line.disabled {
+		t.Error("snooze status line is clickable")
+	}
+	if titles := app.generateMenuTitles(); !slices.Contains(titles, active) {
+		t.Errorf("generateMenuTitles() = %q, missing %q", titles, active)
+	}
+
+	topMenuItem(mock, msg("snooze.undo")).clickHandler()
+	if app.currentSnooze(time.Now()) != nil || topMenuItem(mock, msg("snooze.title")) == nil {
+		t.Errorf("after unsnoozing menu = %q, want the snooze action back", mock.menuItems)
+	}
+}
@@ -5,12 +5,9 @@ import (
 	_ "embed"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
-	"time"
 )
 
 //go:embed sounds/jet.wav
@@ -97,34 +94,9 @@ func (app *App) playSound(ctx context.Context, soundType string) {
 		return
 	}
 
-	// Play sound in background
-	go func() {
-		// Use a timeout context for sound playback
-		soundCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		var cmd *exec.Cmd
-		switch runtime.GOOS {
-		case "darwin":
-			cmd = exec.CommandContext(soundCtx, "afplay", soundPath)
-		case "windows":
-			// Use Windows Media Player API via rundll32 to avoid PowerShell script injection
-			// This is safer than constructing PowerShell scripts with user paths
-			cmd = exec.CommandContext(soundCtx, "cmd", "/c", "start", "/min", "", soundPath)
-		case "linux":
-			// Try paplay first (PulseAudio), then aplay (ALSA)
-			cmd = exec.CommandContext(soundCtx, "paplay", soundPath)
-			if err := cmd.Run(); err != nil {
-				cmd = exec.CommandContext(soundCtx, "aplay", "-q", soundPath)
-			}
-		default:
-			return
-		}
-
-		if cmd != nil {
-			if err := cmd.Run(); err != nil {
-				slog.Error("Failed to play sound", "error", err)
-			}
-		}
-	}()
+	if app.soundPlayer == nil {
+		systemSoundPlayer{goFn: app.goTracked}.Play(ctx, soundPath)
+		return
+	}
+	app.soundPlayer.Play(ctx, soundPath)
 }
@@ -11,10 +11,10 @@ import (
 	"time"
 
 	"github.com/codeGROOVE-dev/goose/pkg/dedup"
+	"github.com/codeGROOVE-dev/goose/pkg/ghref"
 	"github.com/codeGROOVE-dev/retry"
 	"github.com/codeGROOVE-dev/sprinkler/pkg/client"
 	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
-	"github.com/gen2brain/beeep"
 )
 
 const (
@@ -43,6 +43,7 @@ type sprinklerMonitor struct {
 	token           string
 	serverAddress   string // Custom server hostname (empty = use default)
 	orgs            []string
+	eventCount      uint64 // Relevant PR events received; lets quiet cycles skip fetching
 	mu              sync.RWMutex
 	isRunning       bool
 	isConnected     bool
@@ -156,11 +157,11 @@ func (sm *sprinklerMonitor) start(ctx context.Context) error {
 
 	slog.Info("[SPRINKLER] Starting event processor goroutine")
 	// Start event processor
-	go sm.processEvents(monitorCtx)
+	sm.app.goTracked("sprinkler events", func() { sm.processEvents(monitorCtx) })
 
 	slog.Info("[SPRINKLER] Starting WebSocket client goroutine")
 	// Start WebSocket client with error recovery
-	go func() {
+	sm.app.goTracked("sprinkler websocket", func() {
 		defer func() {
 			if r := recover(); r != nil {
 				slog.Error("[SPRINKLER] WebSocket goroutine panic",
@@ -183,7 +184,7 @@ func (sm *sprinklerMonitor) start(ctx context.Context) error {
 			slog.Info("[SPRINKLER] WebSocket client stopped gracefully",
 				"uptime", time.Since(startTime).Round(time.Second))
 		}
-	}()
+	})
 
 	slog.Info("[SPRINKLER] Event monitor started successfully")
 	return nil
@@ -199,35 +200,34 @@ func (sm *sprinklerMonitor) handleEvent(event client.Event) {
 
 	if event.URL == "" {
 		slog.Warn("[SPRINKLER] Received PR event with empty URL", "type", event.Type)
+		sm.recordEvent(sprinklerEventInvalid)
 		return
 	}
 
-	// Extract org from URL (format: https://github.com/org/repo/pull/123)
-	parts := strings.Split(event.URL, "/")
-	const minParts = 5
-	if len(parts) < minParts || parts[2] != "github.com" {
-		slog.Warn("[SPRINKLER] Failed to extract org from URL", "url", event.URL)
+	// Extract org from URL (format: https://github.com/org/repo/pull/123). Events are
+	// handled by the canonical URL, so a trailing slash or query doesn't defeat dedup.
+	ref, err := ghref.ParsePRURL(event.URL)
+	if err != nil {
+		slog.Warn("[SPRINKLER] Dropping event with an unparseable PR URL", "url", sanitizeForLog(event.URL), "error", err)
+		sm.recordEvent(sprinklerEventInvalid)
 		return
 	}
-	org := parts[3]
-
-	// Check if this org is in our monitored list
-	sm.mu.RLock()
-	monitored := slices.Contains(sm.orgs, org)
-	orgCount := len(sm.orgs)
-	sm.mu.RUnlock()
+	org := ref.Owner
+	event.URL = ref.URL()
 
-	if !monitored {
+	// The subscription covers every org ("*"): keep events from a watched org, or about
+	// a PR already in my lists
+	if !sm.relevant(org, event.URL) {
 		slog.Debug("[SPRINKLER] Event from unmonitored org",
 			"org", org,
-			"url", event.URL,
-			"monitored_orgs", orgCount)
+			"url", event.URL)
 		return
 	}
 
 	// Dedupe events - only process if we haven't seen this URL recently
 	if !sm.dedup.ShouldProcess(event.URL, time.Now()) {
 		slog.Debug("[SPRINKLER] Skipping duplicate event", "url", event.URL)
+		sm.recordEvent(sprinklerEventDeduped)
 		return
 	}
 
@@ -246,6 +246,33 @@ func (sm *sprinklerMonitor) handleEvent(event client.Event) {
 		slog.Warn("[SPRINKLER] Event channel full, dropping event",
 			"url", event.URL,
 			"channel_size", cap(sm.eventChan))
+		sm.recordEvent(sprinklerEventDropped)
+	}
+}
+
+// relevant reports whether an event from org about url concerns me: the org is in the
+// watched list, or the PR is in my lists. Relevant events are counted for quiet cycles.
+func (sm *sprinklerMonitor) relevant(org, url string) bool {
+	sm.mu.RLock()
+	ok := slices.ContainsFunc(sm.orgs, func(o string) bool { return strings.EqualFold(o, org) })
+	sm.mu.RUnlock()
+	if !ok {
+		sm.app.mu.RLock()
+		ok = findPR(url, sm.app.incoming, sm.app.outgoing, sm.app.filteredPRs) != nil
+		sm.app.mu.RUnlock()
+	}
+	if ok {
+		sm.mu.Lock()
+		sm.eventCount++
+		sm.mu.Unlock()
+	}
+	return ok
+}
+
+// recordEvent counts an event outcome in the health monitor.
+func (sm *sprinklerMonitor) recordEvent(result string) {
+	if sm.app.healthMonitor != nil {
+		sm.app.healthMonitor.recordSprinklerEvent(result)
 	}
 }
 
@@ -262,6 +289,8 @@ func (sm *sprinklerMonitor) processEvents(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case evt := <-sm.eventChan:
+			sm.recordEvent(sprinklerEventProcessed)
+			sm.app.updateActivity("sprinkler event")
 			sm.checkAndNotify(ctx, evt)
 		}
 	}
@@ -272,10 +301,7 @@ func (sm *sprinklerMonitor) checkAndNotify(ctx context.Context, evt prEvent) {
 	start := time.Now()
 
 	// Determine user: targetUser takes precedence over currentUser
-	user := ""
-	if sm.app.currentUser != nil {
-		user = sm.app.currentUser.GetLogin()
-	}
+	user := sm.app.login()
 	if sm.app.targetUser != "" {
 		user = sm.app.targetUser
 	}
@@ -285,25 +311,29 @@ func (sm *sprinklerMonitor) checkAndNotify(ctx context.Context, evt prEvent) {
 	}
 
 	// Parse repo and PR number from URL (https://github.com/org/repo/pull/123)
-	parts := strings.Split(evt.url, "/")
-	const minParts = 7
-	if len(parts) < minParts || parts[2] != "github.com" {
-		slog.Warn("[SPRINKLER] Invalid PR URL format", "url", evt.url)
+	ref, err := ghref.ParsePRURL(evt.url)
+	if err != nil {
+		slog.Warn("[SPRINKLER] Invalid PR URL format", "url", evt.url, "error", err)
 		return
 	}
-	repo := fmt.Sprintf("%s/%s", parts[3], parts[4])
-	var n int
-	if _, err := fmt.Sscanf(parts[6], "%d", &n); err != nil {
-		slog.Warn("[SPRINKLER] Failed to parse PR number from URL", "url", evt.url, "error", err)
+	repo, n := ref.Repository(), ref.Number
+
+	if sm.app.quarantine != nil && sm.app.quarantine.isQuarantined(evt.url) {
+		slog.Debug("[SPRINKLER] Skipping quarantined PR", "url", evt.url)
 		return
 	}
 
-	data, cached := sm.fetchTurnData(ctx, evt, repo, n, start)
+	// The event means the PR changed, so its in-memory Turn response is stale
+	if sm.app.turnMemory != nil {
+		sm.app.turnMemory.invalidate(evt.url)
+	}
+
+	data, decision := sm.fetchTurnData(ctx, evt, repo, n, start)
 	if data == nil {
 		return
 	}
 
-	if sm.handleClosedPR(ctx, data, evt.url, repo, n, cached) {
+	if sm.handleClosedPR(ctx, data, evt.url, repo, n, decision) {
 		return
 	}
 
@@ -312,7 +342,7 @@ func (sm *sprinklerMonitor) checkAndNotify(ctx context.Context, evt prEvent) {
 		slog.Debug("[SPRINKLER] No turn data available",
 			"repo", repo,
 			"number", n,
-			"cached", cached)
+			"cache", decision)
 		return
 	}
 	act, exists := data.Analysis.NextAction[user]
@@ -333,7 +363,23 @@ func (sm *sprinklerMonitor) checkAndNotify(ctx context.Context, evt prEvent) {
 		return
 	}
 
-	if sm.handleNewPR(ctx, evt.url, repo, n, &act) {
+	if data.PullRequest.Draft && !sm.app.readSetting(&sm.app.draftsBlock) {
+		slog.Debug("[SPRINKLER] Draft PR action is informational, skipping notification",
+			"repo", repo,
+			"number", n,
+			"action", act.Kind)
+		return
+	}
+
+	if data.PullRequest.Author != user && myReviewState(data, user) == reviewApproved {
+		slog.Debug("[SPRINKLER] Already approved with no new commits, skipping notification",
+			"repo", repo,
+			"number", n,
+			"action", act.Kind)
+		return
+	}
+
+	if sm.handleNewPR(ctx, data, evt.url, repo, n, user, &act) {
 		return
 	}
 
@@ -353,15 +399,20 @@ func (sm *sprinklerMonitor) checkAndNotify(ctx context.Context, evt prEvent) {
 }
 
 // fetchTurnData retrieves PR data from Turn API with retry logic.
-func (sm *sprinklerMonitor) fetchTurnData(ctx context.Context, evt prEvent, repo string, n int, start time.Time) (*turn.CheckResponse, bool) {
+func (sm *sprinklerMonitor) fetchTurnData(
+	ctx context.Context, evt prEvent, repo string, n int, start time.Time,
+) (*turn.CheckResponse, cacheDecision) {
 	var data *turn.CheckResponse
-	var cached bool
+	var decision cacheDecision
 
 	err := retry.Do(func() error {
 		var err error
 		// Use event timestamp to bypass caching - this ensures we get fresh data for real-time events
-		data, cached, err = sm.app.turnData(ctx, evt.url, evt.timestamp)
+		data, decision, err = sm.app.turnData(ctx, evt.url, evt.timestamp)
 		if err != nil {
+			if isPermanentPRError(err) {
+				return retry.Unrecoverable(err)
+			}
 			slog.Debug("[SPRINKLER] Turn API call failed (will retry)",
 				"repo", repo,
 				"number", n,
@@ -383,6 +434,11 @@ func (sm *sprinklerMonitor) fetchTurnData(ctx context.Context, evt prEvent, repo
 		}),
 		retry.Context(ctx),
 	)
+	if goneStatus(err) != 0 {
+		sm.app.quarantinePR(evt.url, err)
+		sm.app.updateMenu(ctx)
+		return nil, decision
+	}
 	if err != nil {
 		slog.Warn("[SPRINKLER] Failed to get turn data after retries",
 			"repo", repo,
@@ -390,15 +446,15 @@ func (sm *sprinklerMonitor) fetchTurnData(ctx context.Context, evt prEvent, repo
 			"event_timestamp", evt.timestamp.Format(time.RFC3339),
 			"elapsed", time.Since(start).Round(time.Millisecond),
 			"error", err)
-		return nil, false
+		return nil, decision
 	}
 
-	return data, cached
+	return data, decision
 }
 
 // handleClosedPR processes closed or merged PRs and returns true if the PR was closed.
 func (sm *sprinklerMonitor) handleClosedPR(
-	ctx context.Context, data *turn.CheckResponse, url, repo string, n int, cached bool,
+	ctx context.Context, data *turn.CheckResponse, url, repo string, n int, decision cacheDecision,
 ) bool {
 	state := ""
 	merged := false
@@ -410,7 +466,7 @@ func (sm *sprinklerMonitor) handleClosedPR(
 	slog.Info("[SPRINKLER] Turn API response",
 		"repo", repo,
 		"number", n,
-		"cached", cached,
+		"cache", decision,
 		"state", state,
 		"merged", merged,
 		"has_data", data != nil,
@@ -424,32 +480,40 @@ func (sm *sprinklerMonitor) handleClosedPR(
 	return false
 }
 
-// handleNewPR triggers a refresh for PRs not in our lists and returns true if handled.
-func (sm *sprinklerMonitor) handleNewPR(ctx context.Context, url, repo string, n int, act *turn.Action) bool {
+// handleNewPR adds PRs not in our lists to the menu from their Turn data and notifies,
+// returning true if handled. The next poll confirms them.
+func (sm *sprinklerMonitor) handleNewPR(
+	ctx context.Context, data *turn.CheckResponse, url, repo string, n int, user string, act *turn.Action,
+) bool {
 	sm.app.mu.RLock()
-	found := false
-	for i := range sm.app.incoming {
-		if sm.app.incoming[i].URL == url {
-			found = true
-			break
-		}
-	}
-	if !found {
-		for i := range sm.app.outgoing {
-			if sm.app.outgoing[i].URL == url {
-				found = true
-				break
-			}
-		}
-	}
+	found := findPR(url, sm.app.incoming, sm.app.outgoing) != nil
+	filtered := !found && sm.app.isFilteredPR(url)
 	sm.app.mu.RUnlock()
 
+	if filtered {
+		slog.Debug("[SPRINKLER] Event for a filtered PR, ignoring", "repo", repo, "number", n)
+		return true
+	}
+	if !found && sm.app.inGracePeriod() {
+		// The refresh could notify before the startup poll settles; the next poll finds it
+		slog.Info("[SPRINKLER] New PR detected during startup grace period, waiting for the next poll",
+			"repo", repo,
+			"number", n)
+		return true
+	}
 	if !found {
-		slog.Info("[SPRINKLER] New PR detected, triggering refresh",
+		pr, outgoing := prFromEvent(data, url, repo, n, user, act)
+		if !sm.app.insertEventPR(pr, outgoing) {
+			slog.Debug("[SPRINKLER] New PR matches a filter rule, ignoring", "repo", repo, "number", n)
+			return true
+		}
+		slog.Info("[SPRINKLER] New PR detected, adding it to the menu",
 			"repo", repo,
 			"number", n,
-			"action", act.Kind)
-		go sm.app.updatePRs(ctx)
+			"action", act.Kind,
+			"outgoing", outgoing)
+		sm.app.updateMenu(ctx)
+		sm.sendNotifications(ctx, url, repo, n, act)
 		return true
 	}
 
@@ -461,18 +525,13 @@ func (sm *sprinklerMonitor) isAlreadyTrackedAsBlocked(url, repo string, n int) b
 	sm.app.mu.RLock()
 	defer sm.app.mu.RUnlock()
 
-	for i := range sm.app.incoming {
-		if sm.app.incoming[i].URL == url && sm.app.incoming[i].IsBlocked {
-			slog.Debug("[SPRINKLER] Found in incoming blocked PRs", "repo", repo, "number", n)
-			return true
-		}
+	if pr := findPR(url, sm.app.incoming); pr != nil && pr.IsBlocked {
+		slog.Debug("[SPRINKLER] Found in incoming blocked PRs", "repo", repo, "number", n)
+		return true
 	}
-
-	for i := range sm.app.outgoing {
-		if sm.app.outgoing[i].URL == url && sm.app.outgoing[i].IsBlocked {
-			slog.Debug("[SPRINKLER] Found in outgoing blocked PRs", "repo", repo, "number", n)
-			return true
-		}
+	if pr := findPR(url, sm.app.outgoing); pr != nil && pr.IsBlocked {
+		slog.Debug("[SPRINKLER] Found in outgoing blocked PRs", "repo", repo, "number", n)
+		return true
 	}
 
 	return false
@@ -480,11 +539,51 @@ func (sm *sprinklerMonitor) isAlreadyTrackedAsBlocked(url, repo string, n int) b
 
 // sendNotifications sends desktop notification, plays sound, and attempts auto-open.
 func (sm *sprinklerMonitor) sendNotifications(ctx context.Context, url, repo string, n int, act *turn.Action) {
-	title := fmt.Sprintf("PR Event: #%d needs %s", n, act.Kind)
-	msg := fmt.Sprintf("%s #%d - %s", repo, n, act.Reason)
+	title := msg("notify.pr_event", n, act.Kind)
+	message := sm.app.explainedBody(sm.eventNotifyPR(url, repo, n, act))
 
-	go func() {
-		if err := beeep.Notify(title, msg, ""); err != nil {
+	if sm.app.teamMode() {
+		slog.Debug("[SPRINKLER] Team mode, skipping notification", "repo", repo, "number", n)
+		return
+	}
+
+	// Events go through the poll's decision table, so an early event can't honk during the
+	// first connect, and hidden, snoozed, and dismissed PRs stay quiet
+	blocked := PR{URL: url, Repository: repo, Number: n, NeedsReview: true, ActionKind: ActionKind(act.Kind)}
+	if outcome := decideNotify(sm.app.eventNotifyRow(blocked), func() bool { return false }); outcome != notifyAlert {
+		slog.Debug("[SPRINKLER] Decision table skips notification",
+			"repo", repo, "number", n, "outcome", outcome)
+		return
+	}
+
+	if !sm.app.inFocus(repo) {
+		slog.Debug("[SPRINKLER] Skipping notification outside focused repo",
+			"repo", repo, "number", n)
+		return
+	}
+
+	if policy := sm.app.prPolicy(repo); policy != orgPolicyFull {
+		slog.Debug("[SPRINKLER] Skipping notification due to org policy",
+			"repo", repo, "number", n, "policy", policy)
+		return
+	}
+
+	if !sm.app.sectionShown(url) {
+		slog.Debug("[SPRINKLER] Skipping notification for hidden section",
+			"repo", repo, "number", n)
+		return
+	}
+
+	// The poll may be detecting the same block; only one of them notifies
+	if m := sm.app.stateManager; m != nil {
+		pr := PR{URL: url, Repository: repo, Number: n, IsBlocked: true, ActionKind: ActionKind(act.Kind)}
+		if !m.MarkNotified(url, m.TrackBlocked(pr)) {
+			return
+		}
+	}
+
+	sm.app.goTracked("sprinkler notification", func() {
+		if err := sm.app.notifyEvent(notificationEvent{kind: notifyKindEvent, prURL: url, title: title, message: message}); err != nil {
 			slog.Warn("[SPRINKLER] Failed to send desktop notification",
 				"repo", repo,
 				"number", n,
@@ -494,9 +593,9 @@ func (sm *sprinklerMonitor) sendNotifications(ctx context.Context, url, repo str
 				"repo", repo,
 				"number", n)
 		}
-	}()
+	})
 
-	if sm.app.enableAudioCues && time.Since(sm.app.startTime) > startupGracePeriod {
+	if sm.app.enableAudioCues {
 		slog.Debug("[SPRINKLER] Playing notification sound",
 			"repo", repo,
 			"number", n,
@@ -513,11 +612,24 @@ func (sm *sprinklerMonitor) sendNotifications(ctx context.Context, url, repo str
 			Repository: repo,
 			Number:     n,
 			IsBlocked:  true,
-			ActionKind: string(act.Kind),
+			ActionKind: ActionKind(act.Kind),
 		}, sm.app.enableAutoBrowser, sm.app.startTime)
 	}
 }
 
+// eventNotifyPR describes the PR an event is about for its notification: the listed PR
+// if there is one, with the event's action.
+func (sm *sprinklerMonitor) eventNotifyPR(url, repo string, n int, act *turn.Action) *PR {
+	pr := PR{URL: url, Repository: repo, Number: n}
+	sm.app.mu.RLock()
+	if listed := findPR(url, sm.app.incoming, sm.app.outgoing); listed != nil {
+		pr = *listed
+	}
+	sm.app.mu.RUnlock()
+	pr.ActionKind, pr.ActionReason, pr.ActionSince = ActionKind(act.Kind), act.Reason, act.Since
+	return &pr
+}
+
 // removeClosedPR removes a closed or merged PR from the in-memory lists.
 func (sm *sprinklerMonitor) removeClosedPR(ctx context.Context, url, repo string, n int, state string, merged bool) {
 	slog.Info("[SPRINKLER] PR closed/merged, removing from lists",
@@ -549,7 +661,10 @@ func (sm *sprinklerMonitor) removeClosedPR(ctx context.Context, url, repo string
 		}
 	}
 	sm.app.outgoing = out
+	delete(sm.app.eventPRs, url)
 	sm.app.mu.Unlock()
+	sm.app.pruneClosedHiddenPRs([]string{url})
+	sm.app.advanceSession(ctx, sm.app.snapshotPRs())
 
 	slog.Info("[SPRINKLER] Removed PR from lists",
 		"url", url,
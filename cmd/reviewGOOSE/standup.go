@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// "Copy standup summary" puts a short Markdown block on the clipboard: the reviews I
+// cleared yesterday, from the unblock attribution history, and the queue I face today.
+
+const (
+	// standupMaxListed caps the PRs named on the "Yesterday" line.
+	standupMaxListed = 5
+	// clearedHistoryWindow is how long cleared PRs are remembered for the standup summary:
+	// long enough to cover all of yesterday.
+	clearedHistoryWindow = 48 * time.Hour
+)
+
+// standupState is what the standup summary is built from.
+type standupState struct {
+	me           string
+	cleared      []clearedPR // Incoming PRs that left the blocked state, any order
+	waitingSince []time.Time // When each review in today's queue started waiting on me
+	readyToMerge int         // My PRs whose next action is merging
+}
+
+// standupSummary renders the summary, e.g.
+//
+//   - Yesterday: reviewed 3 PRs (org/a#12, org/b#7, org/b#9)
+//   - Today: 2 reviews waiting (oldest 26h), 1 of my PRs ready to merge
+//
+// "Yesterday" is the calendar day before now's, in now's location.
+func standupSummary(s standupState, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	yesterday := today.AddDate(0, 0, -1)
+
+	var reviewed []clearedPR
+	for _, c := range s.cleared {
+		at := c.UnblockReason.ClearedAt
+		if at.Before(yesterday) || !at.Before(today) || c.attribution(s.me) != clearedByYou {
+			continue
+		}
+		if slices.ContainsFunc(reviewed, func(r clearedPR) bool { return r.PR.URL == c.PR.URL }) {
+			continue
+		}
+		reviewed = append(reviewed, c)
+	}
+	slices.SortStableFunc(reviewed, func(a, b clearedPR) int {
+		return a.UnblockReason.ClearedAt.Compare(b.UnblockReason.ClearedAt)
+	})
+
+	var refs []string
+	for i := range reviewed {
+		if i == standupMaxListed {
+			refs = append(refs, msg("digest.more", len(reviewed)-standupMaxListed))
+			break
+		}
+		refs = append(refs, fmt.Sprintf("%s#%d", reviewed[i].PR.Repository, reviewed[i].PR.Number))
+	}
+	var lines []string
+	switch len(reviewed) {
+	case 0:
+		lines = append(lines, msg("standup.yesterday.none"))
+	case 1:
+		lines = append(lines, msg("standup.yesterday.one", refs[0]))
+	default:
+		lines = append(lines, msg("standup.yesterday", len(reviewed), strings.Join(refs, ", ")))
+	}
+
+	var queue []string
+	switch len(s.waitingSince) {
+	case 0:
+		queue = append(queue, msg("standup.reviews.none"))
+	case 1:
+		queue = append(queue, msg("standup.reviews.one", standupAge(now.Sub(s.waitingSince[0]))))
+	default:
+		queue = append(queue, msg("standup.reviews", len(s.waitingSince), standupAge(now.Sub(slices.MinFunc(s.waitingSince, time.Time.Compare)))))
+	}
+	switch s.readyToMerge {
+	case 0:
+	case 1:
+		queue = append(queue, msg("standup.merge.one"))
+	default:
+		queue = append(queue, msg("standup.merge", s.readyToMerge))
+	}
+	lines = append(lines, msg("standup.today", strings.Join(queue, ", ")))
+
+	for i, line := range lines {
+		lines[i] = "- " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// standupAge formats how long a review has waited: minutes, then hours for the first
+// three days, since "26h" says more at a standup than "1d", then days.
+func standupAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", max(int(d.Minutes()), 0))
+	case d < 72*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// standupState gathers the summary's inputs from the state manager and the view.
+func (app *App) standupState(view *prView) standupState {
+	s := standupState{me: app.login()}
+	if app.stateManager != nil {
+		s.cleared = app.stateManager.ClearedHistory()
+	}
+	for i := range view.incoming {
+		pr := &view.incoming[i]
+		if !blockedInSection(pr, "Incoming") {
+			continue
+		}
+		var state *PRState
+		if app.stateManager != nil {
+			state, _ = app.stateManager.PRState(pr.URL)
+		}
+		s.waitingSince = append(s.waitingSince, blockedSince(pr, state))
+	}
+	for i := range view.outgoing {
+		pr := &view.outgoing[i]
+		if blockedInSection(pr, "Outgoing") && parseActionKind(pr.ActionKind) == actionMerge {
+			s.readyToMerge++
+		}
+	}
+	return s
+}
+
+// addStandupItem adds "Copy standup summary". Team mode shows other people's queues, so
+// it has no standup of mine to copy.
+func (app *App) addStandupItem(ctx context.Context) {
+	if app.teamMode() {
+		return
+	}
+	item := app.systrayInterface.AddMenuItem(msg("standup.copy"), msg("standup.copy.tooltip"))
+	item.Click(func() {
+		summary := standupSummary(app.standupState(app.snapshotPRs()), time.Now())
+		slog.Info("[STANDUP] Copying standup summary")
+		app.copyToClipboard(ctx, summary)
+	})
+}
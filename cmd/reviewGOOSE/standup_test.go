@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func clearedAt(repo string, number int, actor string, at time.Time) clearedPR {
+	return clearedPR{
+		PR:            PR{Repository: repo, Number: number, URL: fmt.Sprintf("https://github.com/%s/pull/%d", repo, number)},
+		UnblockReason: unblockReason{ClearedAt: at, Actor: actor, Kind: "review"},
+	}
+}
+
+func TestStandupSummary(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	yesterday := time.Date(2026, 3, 9, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state standupState
+		want  string
+	}{
+		{
+			name: "reviews and queue",
+			state: standupState{
+				me: "me",
+				cleared: []clearedPR{
+					clearedAt("org/b", 7, "me", yesterday.Add(time.Hour)),
+					clearedAt("org/a", 12, "me", yesterday),
+					clearedAt("org/b", 9, "Me", yesterday.Add(2*time.Hour)),
+					clearedAt("org/b", 7, "me", yesterday.Add(3*time.Hour)), // Cleared twice
+					clearedAt("org/c", 1, "carol", yesterday),               // Someone else's unblock
+					clearedAt("org/c", 2, "me", now.Add(-time.Hour)),        // Today
+					clearedAt("org/c", 3, "me", yesterday.Add(-24*time.Hour)),
+				},
+				waitingSince: []time.Time{now.Add(-3 * time.Hour), now.Add(-26 * time.Hour)},
+				readyToMerge: 1,
+			},
+			want: "- Yesterday: reviewed 3 PRs (org/a#12, org/b#7, org/b#9)\n" +
+				"- Today: 2 reviews waiting (oldest 26h), 1 of my PRs ready to merge",
+		},
+		{
+			name:  "empty day",
+			state: standupState{me: "me"},
+			want:  "- Yesterday: no reviews\n- Today: no reviews waiting",
+		},
+		{
+			name: "one of each",
+			state: standupState{
+				me:           "me",
+				cleared:      []clearedPR{clearedAt("org/a", 1, "me", yesterday)},
+				waitingSince: []time.Time{now.Add(-4 * 24 * time.Hour)},
+			},
+			want: "- Yesterday: reviewed 1 PR (org/a#1)\n- Today: 1 review waiting (4d)",
+		},
+		{
+			name:  "only merges",
+			state: standupState{me: "me", readyToMerge: 2},
+			want:  "- Yesterday: no reviews\n- Today: no reviews waiting, 2 of my PRs ready to merge",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := standupSummary(tt.state, now); got != tt.want {
+				t.Errorf("standupSummary() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandupSummaryTruncates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	s := standupState{me: "me"}
+	for i := range 9 {
+		s.cleared = append(s.cleared, clearedAt("org/a", i+1, "me", now.Add(-time.Duration(24-i)*time.Hour)))
+	}
+	want := "- Yesterday: reviewed 9 PRs (org/a#1, org/a#2, org/a#3, org/a#4, org/a#5, …and 4 more)\n- Today: no reviews waiting"
+	if got := standupSummary(s, now); got != want {
+		t.Errorf("standupSummary() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestClearedHistoryOutlivesRecentlyCleared(t *testing.T) {
+	now := time.Now()
+	m := NewPRStateManager(now.Add(-time.Hour))
+	m.now = func() time.Time { return now }
+	m.mu.Lock()
+	m.recordCleared(PR{URL: "https://github.com/org/a/pull/1", Repository: "org/a", Number: 1}, now.Add(-time.Hour))
+	m.recordCleared(PR{URL: "https://github.com/org/a/pull/2", Repository: "org/a", Number: 2}, now.Add(-clearedHistoryWindow))
+	m.pruneCleared(now)
+	m.mu.Unlock()
+
+	if got := m.RecentlyCleared(); len(got) != 0 {
+		t.Errorf("RecentlyCleared() = %d entries, want none after %v", len(got), recentlyClearedWindow)
+	}
+	history := m.ClearedHistory()
+	if len(history) != 1 || history[0].PR.Number != 1 {
+		t.Errorf("ClearedHistory() = %+v, want only the PR cleared an hour ago", history)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Only macOS has login items. Elsewhere "Start at Login" registers the running binary,
+// with its flags, in the platform's autostart store: the HKCU Run key on Windows, an XDG
+// autostart entry on Linux, named after the profile so profiles register separately. The
+// entry is checked at startup and pointed at the running binary when an update has moved
+// it; the flags it was registered with are kept.
+
+// startupStore holds the command run at login.
+type startupStore interface {
+	// command returns the registered command line, or "" when there is none.
+	command() (string, error)
+	setCommand(cmd string) error
+	remove() error
+}
+
+// startupEntry is this binary's registration in a startupStore.
+type startupEntry struct {
+	store   startupStore
+	exe     string     // The running binary, quoted for the store
+	command string     // exe followed by the running flags, quoted for the store
+	mu      sync.Mutex // Serializes toggles and repairs
+}
+
+// splitStartupCommand splits a stored command line into its quoted executable and the
+// flags after it. Both stores double-quote a path with spaces and backslash-escape any
+// quote inside it.
+func splitStartupCommand(cmd string) (exe, flags string) {
+	end := strings.IndexByte(cmd, ' ')
+	if strings.HasPrefix(cmd, `"`) {
+		end = -1
+		for i := 1; i < len(cmd); i++ {
+			if cmd[i] == '\\' {
+				i++
+				continue
+			}
+			if cmd[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+	}
+	if end < 0 || end >= len(cmd) {
+		return cmd, ""
+	}
+	return cmd[:end], strings.TrimLeft(cmd[end:], " ")
+}
+
+// startupArgs returns the running binary's resolved path followed by its flags.
+func startupArgs() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return append([]string{exe}, os.Args[1:]...), nil
+}
+
+// enabled reports whether any command is registered, current or not.
+func (e *startupEntry) enabled() (bool, error) {
+	cmd, err := e.store.command()
+	return cmd != "", err
+}
+
+// set registers the current command, or removes the registration.
+func (e *startupEntry) set(enable bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if enable {
+		return e.store.setCommand(e.command)
+	}
+	return e.store.remove()
+}
+
+// verify points a registered command at the running binary, keeping its flags. It
+// reports whether it rewrote one; nothing is registered when the user hasn't turned
+// "Start at Login" on.
+func (e *startupEntry) verify() (repaired bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cmd, err := e.store.command()
+	if err != nil {
+		return false, err
+	}
+	if cmd == "" {
+		return false, nil
+	}
+	exe, flags := splitStartupCommand(cmd)
+	if exe == e.exe {
+		return false, nil
+	}
+	fixed := e.exe
+	if flags != "" {
+		fixed += " " + flags
+	}
+	if err := e.store.setCommand(fixed); err != nil {
+		return false, err
+	}
+	slog.Info("[STARTUP] Repaired startup entry", "was", cmd, "now", fixed)
+	return true, nil
+}
+
+// verifyStartup repairs a stale startup entry, telling the user if it can't.
+func (app *App) verifyStartup() {
+	if app.startup == nil {
+		return
+	}
+	if _, err := app.startup.verify(); err != nil {
+		slog.Error("[STARTUP] Failed to check startup entry", "error", err)
+		app.notifyStartupFailure(msg("startup.repair_failed"), err)
+	}
+}
+
+// notifyStartupFailure reports a startup entry change that failed.
+func (app *App) notifyStartupFailure(title string, err error) {
+	if nerr := app.notify(title, err.Error()); nerr != nil {
+		slog.Error("Failed to send notification", "error", nerr)
+	}
+}
+
+// addStartupItem adds the "Start at Login" toggle where a startup store is available.
+// A failed toggle leaves the entry as it was and says why in a notification.
+func (app *App) addStartupItem(ctx context.Context) {
+	if app.startup == nil {
+		return
+	}
+	on, err := app.startup.enabled()
+	if err != nil {
+		slog.Warn("[STARTUP] Failed to read startup entry", "error", err)
+	}
+	item := app.systrayInterface.AddSettingItem(SettingState{
+		ID:        "start_at_login",
+		Label:     msg("settings.start_at_login"),
+		Tooltip:   msg("settings.start_at_login.tooltip"),
+		Checkable: true,
+		Checked:   on,
+	})
+	item.Click(func() {
+		if err := app.startup.set(!on); err != nil {
+			slog.Error("[STARTUP] Failed to update startup entry", "enable", !on, "error", err)
+			title := msg("startup.enable_failed")
+			if on {
+				title = msg("startup.disable_failed")
+			}
+			app.notifyStartupFailure(title, err)
+		} else {
+			slog.Info("[SETTINGS] Start at Login toggled", "enabled", !on)
+		}
+		app.rebuildMenu(ctx)
+	})
+}
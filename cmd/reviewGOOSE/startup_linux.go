@@ -0,0 +1,147 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// xdgStartupStore is an XDG autostart entry, which desktops run at login.
+type xdgStartupStore struct {
+	path string // e.g. ~/.config/autostart/reviewGOOSE.desktop
+}
+
+func (s xdgStartupStore) command() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read autostart entry: %w", err)
+	}
+	var exec string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Exec":
+			exec = unescapeKeyValue(strings.TrimSpace(value))
+		case "Hidden":
+			// The desktop's own way of turning an entry off
+			if strings.TrimSpace(value) == "true" {
+				return "", nil
+			}
+		default:
+		}
+	}
+	return exec, nil
+}
+
+func (s xdgStartupStore) setCommand(cmd string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create autostart directory: %w", err)
+	}
+	entry := "[Desktop Entry]\n" +
+		"Type=Application\n" +
+		"Name=reviewGOOSE\n" +
+		"Comment=" + msg("settings.start_at_login.tooltip") + "\n" +
+		"Exec=" + keyValueEscaper.Replace(cmd) + "\n" +
+		"Terminal=false\n" +
+		"X-GNOME-Autostart-enabled=true\n"
+	if err := os.WriteFile(s.path, []byte(entry), 0o600); err != nil {
+		return fmt.Errorf("write autostart entry: %w", err)
+	}
+	return nil
+}
+
+func (s xdgStartupStore) remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove autostart entry: %w", err)
+	}
+	return nil
+}
+
+// keyValueEscaper escapes a desktop entry value for the key file.
+var keyValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// unescapeKeyValue reverses the key file's escapes, leaving any it doesn't know as is.
+func unescapeKeyValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case '\\':
+			b.WriteByte('\\')
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 's':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// desktopExec quotes a command line for a desktop entry's Exec key: arguments with
+// reserved characters are double-quoted with their specials escaped, and % is doubled, as
+// field codes would otherwise expand. The store escapes the result again for the key file.
+func desktopExec(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\><~|&;$*?#()`") {
+			quoted[i] = arg
+			continue
+		}
+		var b strings.Builder
+		b.WriteByte('"')
+		for _, r := range arg {
+			if strings.ContainsRune("\"`$\\", r) {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+		quoted[i] = b.String()
+	}
+	return strings.ReplaceAll(strings.Join(quoted, " "), "%", "%%")
+}
+
+// newStartupEntry returns the profile's XDG autostart entry, or nil if it can't be placed.
+func newStartupEntry(profile string) *startupEntry {
+	args, err := startupArgs()
+	if err != nil {
+		slog.Warn("[STARTUP] Start at Login unavailable", "error", err)
+		return nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		slog.Warn("[STARTUP] Start at Login unavailable", "error", err)
+		return nil
+	}
+	return &startupEntry{
+		store:   xdgStartupStore{path: filepath.Join(dir, "autostart", appDirName(profile)+".desktop")},
+		exe:     desktopExec(args[:1]),
+		command: desktopExec(args),
+	}
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDesktopExec(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"/usr/bin/reviewGOOSE"}, want: "/usr/bin/reviewGOOSE"},
+		{args: []string{"/home/me/My Apps/reviewGOOSE", "-repos", "acme/widgets"}, want: `"/home/me/My Apps/reviewGOOSE" -repos acme/widgets`},
+		{args: []string{"/opt/goose", `say "$HOME"`}, want: `/opt/goose "say \"\$HOME\""`},
+		{args: []string{"/opt/goose", "100%"}, want: "/opt/goose 100%%"},
+		{args: []string{"/opt/goose", ""}, want: `/opt/goose ""`},
+	}
+	for _, tt := range tests {
+		if got := desktopExec(tt.args); got != tt.want {
+			t.Errorf("desktopExec(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestXDGStartupStore(t *testing.T) {
+	store := xdgStartupStore{path: filepath.Join(t.TempDir(), "autostart", "reviewGOOSE.desktop")}
+	if cmd, err := store.command(); err != nil || cmd != "" {
+		t.Fatalf("command() = %q, %v before enabling, want none", cmd, err)
+	}
+	if err := store.remove(); err != nil {
+		t.Errorf("remove() without an entry = %v", err)
+	}
+
+	exec := desktopExec([]string{`/home/me/My "Apps"/reviewGOOSE`, "-repos", "acme/widgets"})
+	if err := store.setCommand(exec); err != nil {
+		t.Fatalf("setCommand() error = %v", err)
+	}
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Backslashes are escaped again for the key file
+	if !strings.HasPrefix(string(data), "[Desktop Entry]\n") || !strings.Contains(string(data), `Exec="/home/me/My \\"Apps\\"/reviewGOOSE" -repos`) {
+		t.Errorf("desktop entry =\n%s", data)
+	}
+	if cmd, err := store.command(); err != nil || cmd != exec {
+		t.Errorf("command() = %q, %v, want %q", cmd, err, exec)
+	}
+
+	// Turned off from the desktop's own settings
+	if err := os.WriteFile(store.path, append(data, "Hidden=true\n"...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if cmd, _ := store.command(); cmd != "" {
+		t.Errorf("command() = %q for a hidden entry, want none", cmd)
+	}
+
+	if err := store.remove(); err != nil {
+		t.Fatalf("remove() error = %v", err)
+	}
+	if _, err := os.Stat(store.path); !os.IsNotExist(err) {
+		t.Errorf("entry still present after remove(): %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !windows
+
+package main
+
+// newStartupEntry returns nil: macOS uses login items, and other platforms have no store.
+func newStartupEntry(string) *startupEntry {
+	return nil
+}
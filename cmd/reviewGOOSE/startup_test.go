@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeStartupStore is an in-memory startupStore whose writes fail while err is set.
+type fakeStartupStore struct {
+	err    error
+	cmd    string
+	writes int
+}
+
+func (s *fakeStartupStore) command() (string, error) { return s.cmd, nil }
+
+func (s *fakeStartupStore) setCommand(cmd string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.writes++
+	s.cmd = cmd
+	return nil
+}
+
+func (s *fakeStartupStore) remove() error {
+	if s.err != nil {
+		return s.err
+	}
+	s.writes++
+	s.cmd = ""
+	return nil
+}
+
+func TestStartupEntryVerify(t *testing.T) {
+	const exe = `"C:\Apps\reviewGOOSE.exe"`
+	tests := []struct {
+		name   string
+		stored string
+		want   string // The repaired command, or "" when the stored one is kept
+	}{
+		{name: "not registered", stored: ""},
+		{name: "current", stored: exe + " -repos acme/widgets"},
+		{name: "other flags", stored: exe + " -browser-auto-open"},
+		{name: "binary moved", stored: `"C:\Downloads\reviewGOOSE.exe" -repos acme/widgets`, want: exe + " -repos acme/widgets"},
+		{name: "moved without flags", stored: `C:\Downloads\reviewGOOSE.exe`, want: exe},
+		{name: "moved with a quote in the path", stored: `"/home/me/My \"Apps\"/reviewGOOSE" -repos "a b"`, want: exe + ` -repos "a b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStartupStore{cmd: tt.stored}
+			entry := &startupEntry{store: store, exe: exe, command: exe + " -repos acme/widgets"}
+			repaired, err := entry.verify()
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+			if repaired != (tt.want != "") {
+				t.Errorf("verify() repaired = %v, want %v", repaired, tt.want != "")
+			}
+			want := tt.stored
+			if tt.want != "" {
+				want = tt.want
+			}
+			if store.cmd != want {
+				t.Errorf("stored command = %q, want %q", store.cmd, want)
+			}
+		})
+	}
+}
+
+func TestStartupItemToggle(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	store := &fakeStartupStore{}
+	app.startup = &startupEntry{store: store, exe: "/opt/goose/reviewGOOSE", command: "/opt/goose/reviewGOOSE"}
+	ctx := context.Background()
+
+	app.rebuildMenu(ctx)
+	item, ok := mock.settingItems["start_at_login"]
+	if !ok {
+		t.Fatal("Start at Login setting missing")
+	}
+	if item.title != "Start at Login" {
+		t.Errorf("title = %q, want it unchecked", item.title)
+	}
+	item.clickHandler()
+	if store.cmd != "/opt/goose/reviewGOOSE" {
+		t.Errorf("stored command = %q after enabling", store.cmd)
+	}
+	if item := mock.settingItems["start_at_login"]; item.title != "✓ Start at Login" {
+		t.Errorf("title = %q, want it checked", item.title)
+	}
+
+	// A failure keeps the entry, and the checkmark, and says why
+	store.err = errors.New("access denied")
+	mock.settingItems["start_at_login"].clickHandler()
+	if store.cmd == "" {
+		t.Error("failed removal cleared the entry")
+	}
+	if item := mock.settingItems["start_at_login"]; item.title != "✓ Start at Login" {
+		t.Errorf("title = %q after a failed removal, want it still checked", item.title)
+	}
+	if len(notifier.notes) != 1 || !strings.Contains(notifier.notes[0], "access denied") {
+		t.Errorf("notifications = %q, want the failure", notifier.notes)
+	}
+
+	store.err = nil
+	mock.settingItems["start_at_login"].clickHandler()
+	if store.cmd != "" {
+		t.Errorf("stored command = %q after disabling, want none", store.cmd)
+	}
+}
+
+func TestVerifyStartupNotifiesOnFailure(t *testing.T) {
+	app, _ := newSettingsMenuTestApp(t)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+	store := &fakeStartupStore{cmd: "/old/reviewGOOSE", err: errors.New("read-only")}
+	app.startup = &startupEntry{store: store, exe: "/new/reviewGOOSE", command: "/new/reviewGOOSE"}
+
+	app.verifyStartup()
+	if len(notifier.notes) != 1 || !strings.Contains(notifier.notes[0], "read-only") {
+		t.Errorf("notifications = %q, want the failed repair", notifier.notes)
+	}
+	if store.cmd != "/old/reviewGOOSE" {
+		t.Errorf("stored command = %q, want it untouched", store.cmd)
+	}
+}
@@ -0,0 +1,88 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const runKeyPath = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+// runKeyStore is a value in this user's Run key, whose commands Windows starts at login.
+type runKeyStore struct {
+	name string // The value's name, e.g. reviewGOOSE
+}
+
+func (s runKeyStore) command() (string, error) {
+	k, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("open Run key: %w", err)
+	}
+	defer k.Close() //nolint:errcheck // read-only
+	cmd, _, err := k.GetStringValue(s.name)
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read Run value: %w", err)
+	}
+	return cmd, nil
+}
+
+func (s runKeyStore) setCommand(cmd string) error {
+	k, _, err := registry.CreateKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("open Run key: %w", err)
+	}
+	defer k.Close() //nolint:errcheck // the write below reports failures
+	if err := k.SetStringValue(s.name, cmd); err != nil {
+		return fmt.Errorf("write Run value: %w", err)
+	}
+	return nil
+}
+
+func (s runKeyStore) remove() error {
+	k, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open Run key: %w", err)
+	}
+	defer k.Close() //nolint:errcheck // the delete below reports failures
+	if err := k.DeleteValue(s.name); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return fmt.Errorf("delete Run value: %w", err)
+	}
+	return nil
+}
+
+// newStartupEntry returns the profile's Run key entry, or nil if the binary's path is unknown.
+func newStartupEntry(profile string) *startupEntry {
+	args, err := startupArgs()
+	if err != nil {
+		slog.Warn("[STARTUP] Start at Login unavailable", "error", err)
+		return nil
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = syscall.EscapeArg(arg)
+	}
+	// The path is always quoted, so one with spaces can't be read as another program
+	if !strings.HasPrefix(quoted[0], `"`) {
+		quoted[0] = `"` + quoted[0] + `"`
+	}
+	return &startupEntry{
+		store:   runKeyStore{name: appDirName(profile)},
+		exe:     quoted[0],
+		command: strings.Join(quoted, " "),
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
+)
+
+const (
+	// stateFileSchemaVersion is the layout of the -state-file document. Bump it when a
+	// field changes meaning or is removed; adding fields doesn't need a bump.
+	stateFileSchemaVersion = 1
+	// stateFileMinInterval throttles state file writes.
+	stateFileMinInterval = time.Second
+)
+
+// stateDocument is the JSON that -state-file writes for status bars such as waybar or polybar.
+type stateDocument struct {
+	UpdatedAt       time.Time `json:"updated_at"`
+	Icon            string    `json:"icon"` // goose, popper, smile, warn, or lock
+	Version         int       `json:"version"`
+	IncomingBlocked int       `json:"incoming_blocked"`
+	OutgoingBlocked int       `json:"outgoing_blocked"`
+	Total           int       `json:"total"` // incoming_blocked + outgoing_blocked
+}
+
+// stateIconName is the state file's name for a tray icon.
+func stateIconName(icon IconType) string {
+	switch icon {
+	case IconGoose, IconBoth:
+		return "goose"
+	case IconPopper, IconCockroach:
+		return "popper"
+	case IconWarning:
+		return "warn"
+	case IconLock:
+		return "lock"
+	default:
+		return "smile"
+	}
+}
+
+// stateFile mirrors the tray icon and blocked counts to a file. Writes are atomic and
+// throttled to one per interval; the latest state is written once the interval passes.
+type stateFile struct {
+	lastWrite time.Time
+	timer     *time.Timer
+	goFn      goFunc // Runs the throttled write; nil runs it untracked
+	pending   *stateDocument
+	path      string
+	last      PRCounts // Counts shown while the icon reports an error
+	interval  time.Duration
+	mu        sync.Mutex
+	closed    bool
+}
+
+func newStateFile(path string) *stateFile {
+	return &stateFile{path: path, interval: stateFileMinInterval}
+}
+
+// update records a tray icon change. Error icons keep the last known counts, so a bar
+// can still show them next to the warning.
+func (s *stateFile) update(icon IconType, counts PRCounts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if icon == IconWarning || icon == IconLock {
+		counts = s.last
+	} else {
+		s.last = counts
+	}
+	doc := stateDocument{
+		Version:         stateFileSchemaVersion,
+		UpdatedAt:       time.Now(),
+		Icon:            stateIconName(icon),
+		IncomingBlocked: counts.IncomingBlocked,
+		OutgoingBlocked: counts.OutgoingBlocked,
+		Total:           counts.IncomingBlocked + counts.OutgoingBlocked,
+	}
+
+	wait := s.interval - time.Since(s.lastWrite)
+	if wait <= 0 && s.timer == nil {
+		s.writeLocked(&doc)
+		return
+	}
+	s.pending = &doc
+	if s.timer == nil {
+		s.timer = time.AfterFunc(wait, func() {
+			if !s.goFn.start("state file", s.flushPending) {
+				// Shutting down: remove() deletes the file, so the held-back state is moot
+				s.mu.Lock()
+				s.timer = nil
+				s.mu.Unlock()
+			}
+		})
+	}
+}
+
+// flushPending writes the state held back by the throttle.
+func (s *stateFile) flushPending() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = nil
+	if s.closed || s.pending == nil {
+		return
+	}
+	doc := s.pending
+	s.pending = nil
+	s.writeLocked(doc)
+}
+
+// writeLocked replaces the file via a temporary file and rename. The caller holds s.mu.
+func (s *stateFile) writeLocked(doc *stateDocument) {
+	s.lastWrite = time.Now()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		slog.Warn("[STATEFILE] Failed to marshal state file", "error", err)
+		return
+	}
+	if err := appsettings.WriteAtomic(s.path, append(data, '\n'), 0o600); err != nil {
+		slog.Warn("[STATEFILE] Failed to write state file", "path", s.path, "error", err)
+	}
+}
+
+// remove deletes the file on clean shutdown; nothing is written afterwards.
+func (s *stateFile) remove() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Warn("[STATEFILE] Failed to remove state file", "path", s.path, "error", err)
+	}
+}
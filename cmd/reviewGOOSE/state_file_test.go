@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readStateFile(t *testing.T, path string) stateDocument {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading state file: %v", err)
+	}
+	var doc stateDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("state file isn't JSON: %v\n%s", err, data)
+	}
+	return doc
+}
+
+func newStateFileTestApp(t *testing.T) (*App, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "goose.json")
+	app := newFocusTestApp(time.Hour)
+	app.stateFile = newStateFile(path)
+	app.stateFile.interval = 0
+	return app, path
+}
+
+func TestStateFileTracksCounts(t *testing.T) {
+	app, path := newStateFileTestApp(t)
+	now := time.Now()
+
+	updates := []struct {
+		name     string
+		incoming []PR
+		outgoing []PR
+		icon     string
+	}{
+		{name: "nothing blocked", icon: "smile"},
+		{
+			name: "incoming blocked",
+			incoming: []PR{
+				{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: now},
+				{Repository: "acme/widgets", Number: 2, URL: "https://github.com/acme/widgets/pull/2", NeedsReview: true, UpdatedAt: now},
+			},
+			icon: "goose",
+		},
+		{
+			name:     "outgoing blocked",
+			outgoing: []PR{{Repository: "acme/gears", Number: 3, URL: "https://github.com/acme/gears/pull/3", IsBlocked: true, UpdatedAt: now}},
+			icon:     "popper",
+		},
+	}
+	for _, u := range updates {
+		app.mu.Lock()
+		app.incoming, app.outgoing = u.incoming, u.outgoing
+		app.mu.Unlock()
+		app.presentTrayState()
+
+		counts := app.countPRs()
+		doc := readStateFile(t, path)
+		if doc.Version != stateFileSchemaVersion || doc.Icon != u.icon ||
+			doc.IncomingBlocked != counts.IncomingBlocked || doc.OutgoingBlocked != counts.OutgoingBlocked ||
+			doc.Total != counts.IncomingBlocked+counts.OutgoingBlocked {
+			t.Errorf("%s: state file = %+v, counts = %+v, want icon %q", u.name, doc, counts, u.icon)
+		}
+	}
+}
+
+func TestStateFileFailureStates(t *testing.T) {
+	app, path := newStateFileTestApp(t)
+	app.incoming = []PR{{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: time.Now()}}
+	app.presentTrayState()
+
+	app.setTrayIcon(IconWarning, PRCounts{})
+	if doc := readStateFile(t, path); doc.Icon != "warn" || doc.IncomingBlocked != 1 {
+		t.Errorf("after a failure: %+v, want icon warn with the last counts", doc)
+	}
+	app.setTrayIcon(IconLock, PRCounts{})
+	if doc := readStateFile(t, path); doc.Icon != "lock" {
+		t.Errorf("after an auth error: icon = %q, want lock", doc.Icon)
+	}
+	app.presentTrayState()
+	if doc := readStateFile(t, path); doc.Icon != "goose" {
+		t.Errorf("after recovering: icon = %q, want goose", doc.Icon)
+	}
+}
+
+func TestStateFileThrottlesAndRemoves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goose.json")
+	s := newStateFile(path)
+	s.interval = 100 * time.Millisecond
+
+	s.update(IconGoose, PRCounts{IncomingBlocked: 1})
+	s.update(IconGoose, PRCounts{IncomingBlocked: 2})
+	s.update(IconGoose, PRCounts{IncomingBlocked: 3})
+	if doc := readStateFile(t, path); doc.IncomingBlocked != 1 {
+		t.Errorf("throttled write happened early: %+v", doc)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for readStateFile(t, path).IncomingBlocked != 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if doc := readStateFile(t, path); doc.IncomingBlocked != 3 {
+		t.Errorf("the latest state wasn't written after the interval: %+v", doc)
+	}
+
+	s.update(IconGoose, PRCounts{IncomingBlocked: 4}) // Held back, then dropped by remove
+	s.remove()
+	time.Sleep(150 * time.Millisecond)
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state file still present after shutdown: %v", err)
+	}
+}
+
+func TestStateFileWritesNothingOnceShutdownBegins(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.lifecycle = newLifecycle(func() {})
+	path := filepath.Join(t.TempDir(), "goose.json")
+	s := newStateFile(path)
+	s.goFn = app.goTracked
+	s.interval = 50 * time.Millisecond
+
+	s.update(IconGoose, PRCounts{IncomingBlocked: 1})
+	s.update(IconGoose, PRCounts{IncomingBlocked: 2}) // Held back past the shutdown
+	app.lifecycle.Shutdown(shutdownTimeout, func() {})
+	time.Sleep(150 * time.Millisecond)
+	if doc := readStateFile(t, path); doc.IncomingBlocked != 1 {
+		t.Errorf("throttled write ran after shutdown began: %+v", doc)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const (
+	storageFailureThreshold = 3               // Consecutive write failures before falling back to memory-only cache
+	storageProbeInterval    = 1 * time.Minute // How often to retry disk writes while in memory-only mode
+)
+
+// memCacheEntry is a Turn response held in memory while the disk cache is unwritable.
+type memCacheEntry struct {
+	cachedAt time.Time
+	data     *turn.CheckResponse
+}
+
+// storageHealth tracks whether persistent storage (cache, settings, logs) is writable.
+// Writes are best-effort: failures are recorded here instead of being logged on every
+// attempt, and after storageFailureThreshold consecutive cache write failures the Turn
+// cache falls back to memory until a periodic probe write succeeds again. Each kind
+// has its own failure streak, as the cache and settings live in different directories.
+type storageHealth struct {
+	lastErrorAt     time.Time
+	lastProbeAt     time.Time
+	settingsTriedAt time.Time // Last save of pending settings, to retry once per probe interval
+	lastError       error
+	memCache        map[string]memCacheEntry
+	failures        map[string]int // Consecutive failures by kind
+	lastErrorKind   string
+	mu              sync.Mutex
+	memoryOnly      bool
+	settingsPending bool
+}
+
+func newStorageHealth() *storageHealth {
+	return &storageHealth{
+		memCache: make(map[string]memCacheEntry),
+		failures: make(map[string]int),
+	}
+}
+
+// recordFailure records a failed write of the given kind (cache, settings, log).
+// Only the first failure of a kind's streak is logged at Warn level to avoid log spam.
+func (s *storageHealth) recordFailure(kind string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[kind]++
+	failures := s.failures[kind]
+	s.lastError = err
+	s.lastErrorKind = kind
+	s.lastErrorAt = time.Now()
+
+	if failures == 1 {
+		slog.Warn("[STORAGE] Write failed, further failures will be logged at debug level",
+			"kind", kind, "error", err)
+	} else {
+		slog.Debug("[STORAGE] Write failed", "kind", kind, "error", err, "consecutive", failures)
+	}
+
+	if kind == "settings" {
+		s.settingsPending = true
+		s.settingsTriedAt = s.lastErrorAt
+	}
+
+	if kind == "cache" && !s.memoryOnly && failures >= storageFailureThreshold {
+		s.memoryOnly = true
+		s.lastProbeAt = s.lastErrorAt
+		slog.Warn("[STORAGE] Falling back to in-memory cache",
+			"consecutive_failures", failures, "last_error", err)
+	}
+}
+
+// recordSuccess records a successful write of the given kind. After a cache write it
+// returns true if settings were left unsaved by an earlier failure and are due for
+// another try, which happens at most once per storageProbeInterval.
+func (s *storageHealth) recordSuccess(kind string) (flushSettings bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures[kind] > 0 || (kind == "cache" && s.memoryOnly) {
+		slog.Info("[STORAGE] Writes succeeding again",
+			"kind", kind, "previous_failures", s.failures[kind], "memory_only", s.memoryOnly)
+	}
+	delete(s.failures, kind)
+	if s.lastErrorKind == kind {
+		s.lastError = nil
+		s.lastErrorKind = ""
+	}
+
+	// Settings live in a different directory than the cache, so a successful
+	// settings save says nothing about whether the cache is writable again.
+	if kind == "settings" {
+		if s.settingsPending {
+			slog.Info("[STORAGE] Pending settings saved")
+		}
+		s.settingsPending = false
+		return false
+	}
+
+	if kind == "cache" {
+		s.memoryOnly = false
+		clear(s.memCache)
+	}
+	if !s.settingsPending || time.Since(s.settingsTriedAt) < storageProbeInterval {
+		return false
+	}
+	s.settingsTriedAt = time.Now()
+	return true
+}
+
+// shouldWriteCache reports whether a cache write should be attempted.
+// In memory-only mode, writes are skipped except for one probe per storageProbeInterval.
+func (s *storageHealth) shouldWriteCache() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.memoryOnly {
+		return true
+	}
+	if time.Since(s.lastProbeAt) < storageProbeInterval {
+		return false
+	}
+	s.lastProbeAt = time.Now()
+	slog.Debug("[STORAGE] Probing disk cache writability")
+	return true
+}
+
+// memoryOnlyMode reports whether the Turn cache is currently held in memory.
+func (s *storageHealth) memoryOnlyMode() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.memoryOnly
+}
+
+// cacheGet returns an in-memory cache entry if one exists and is still fresh.
+// Entries for PRs with incomplete tests expire after runningTestsCacheTTL, mirroring the disk cache.
+func (s *storageHealth) cacheGet(key string) (*turn.CheckResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.memCache[key]
+	if !ok {
+		return nil, false
+	}
+	ttl := cacheTTL
+	switch e.data.PullRequest.TestState {
+	case "running", "queued", "pending":
+		ttl = runningTestsCacheTTL
+	default:
+	}
+	if time.Since(e.cachedAt) >= ttl {
+		return nil, false
+	}
+	return e.data, true
+}
+
+// cachePut stores a Turn response in memory while the disk cache is unwritable.
+func (s *storageHealth) cachePut(key string, data *turn.CheckResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.memoryOnly {
+		return
+	}
+	s.memCache[key] = memCacheEntry{data: data, cachedAt: time.Now()}
+}
+
+// degraded reports whether the user should be warned that state isn't being persisted.
+func (s *storageHealth) degraded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.memoryOnly || s.settingsPending
+}
+
+// status returns a short description of the most recent storage error, if any.
+func (s *storageHealth) status() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastError == nil {
+		return ""
+	}
+	return s.lastErrorKind + ": " + s.lastError.Error()
+}
+
+// storageWarningTitle is the menu line shown while settings or cache writes are failing.
+func storageWarningTitle() string {
+	return msg("storage.warning")
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// readOnlyDir returns a directory that cannot be written to, and a function that makes it writable again.
+// Permission bits are ignored when running as root, so in that case the directory is placed
+// beneath a regular file, which fails writes with ENOTDIR for every user.
+func readOnlyDir(t *testing.T) (dir string, restore func()) {
+	t.Helper()
+	base := t.TempDir()
+
+	dir = filepath.Join(base, "ro")
+	if err := os.Mkdir(dir, 0o500); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	probe := filepath.Join(dir, "probe")
+	if err := os.WriteFile(probe, nil, 0o600); err != nil {
+		return dir, func() {
+			if err := os.Chmod(dir, 0o700); err != nil {
+				t.Fatalf("chmod: %v", err)
+			}
+		}
+	}
+
+	// Running as root: permission bits don't apply
+	blocker := filepath.Join(base, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	return filepath.Join(blocker, "ro"), func() {
+		if err := os.Remove(blocker); err != nil {
+			t.Fatalf("remove blocker: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Join(blocker, "ro"), 0o700); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+}
+
+func TestStorageFallbackToMemoryCache(t *testing.T) {
+	cacheDir, restore := readOnlyDir(t)
+
+	app := &App{
+		mu:           sync.RWMutex{},
+		cacheDir:     cacheDir,
+		storage:      newStorageHealth(),
+		stateManager: NewPRStateManager(time.Now()),
+		hiddenOrgs:   make(map[string]bool),
+		seenOrgs:     make(map[string]orgActivity),
+	}
+
+	cacheManager := prcache.NewManager(app.cacheDir)
+	updatedAt := time.Now().Add(-time.Hour)
+	data := &turn.CheckResponse{}
+	data.PullRequest.TestState = "passing"
+
+	for i := range storageFailureThreshold {
+		url := fmt.Sprintf("https://github.com/org/repo/pull/%d", i+1)
+		key := prcache.CacheKey(url, updatedAt)
+		app.saveToCache(cacheManager, cacheManager.CachePath(key), key, url, data, updatedAt)
+	}
+
+	if !app.storage.memoryOnlyMode() {
+		t.Fatalf("expected memory-only mode after %d failed writes", storageFailureThreshold)
+	}
+	if app.storage.status() == "" {
+		t.Error("expected last storage error to be recorded")
+	}
+
+	url := "https://github.com/org/repo/pull/42"
+	key := prcache.CacheKey(url, updatedAt)
+	app.saveToCache(cacheManager, cacheManager.CachePath(key), key, url, data, updatedAt)
+	if got, ok := app.storage.cacheGet(key); !ok || got != data {
+		t.Error("expected response to be served from the memory cache")
+	}
+
+	// Make the directory writable and force the next write to be a probe
+	restore()
+	app.storage.mu.Lock()
+	app.storage.lastProbeAt = time.Time{}
+	app.storage.mu.Unlock()
+
+	app.saveToCache(cacheManager, cacheManager.CachePath(key), key, url, data, updatedAt)
+	if app.storage.memoryOnlyMode() {
+		t.Error("expected disk cache to be used again after a successful write")
+	}
+	if app.storage.degraded() {
+		t.Error("expected storage to no longer be degraded")
+	}
+	if _, err := os.Stat(cacheManager.CachePath(key)); err != nil {
+		t.Errorf("expected cache file on disk: %v", err)
+	}
+}
+
+func TestStorageSettingsPendingAndFlush(t *testing.T) {
+	configDir, restoreConfig := readOnlyDir(t)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("APPDATA", configDir)
+
+	app := &App{
+		mu:                sync.RWMutex{},
+		cacheDir:          t.TempDir(),
+		storage:           newStorageHealth(),
+		stateManager:      NewPRStateManager(time.Now()),
+		hiddenOrgs:        map[string]bool{"secret-org": true},
+		seenOrgs:          make(map[string]orgActivity),
+		enableAudioCues:   true,
+		hideStaleIncoming: true,
+	}
+
+	app.saveSettings()
+
+	if !app.storage.degraded() {
+		t.Fatal("expected storage to be degraded after a failed settings save")
+	}
+	if !slices.Contains(app.generateMenuTitles(), storageWarningTitle()) {
+		t.Errorf("expected %q in menu titles", storageWarningTitle())
+	}
+	if app.storage.memoryOnlyMode() {
+		t.Error("settings failures should not switch the Turn cache to memory")
+	}
+
+	// Storage recovers; the next successful write past the probe interval flushes the
+	// pending settings
+	restoreConfig()
+	app.storage.mu.Lock()
+	app.storage.settingsTriedAt = time.Time{}
+	app.storage.mu.Unlock()
+	cacheManager := prcache.NewManager(app.cacheDir)
+	url := "https://github.com/org/repo/pull/1"
+	updatedAt := time.Now()
+	key := prcache.CacheKey(url, updatedAt)
+	app.saveToCache(cacheManager, cacheManager.CachePath(key), key, url, &turn.CheckResponse{}, updatedAt)
+
+	if app.storage.degraded() {
+		t.Error("expected pending settings to be flushed")
+	}
+	if slices.Contains(app.generateMenuTitles(), storageWarningTitle()) {
+		t.Errorf("did not expect %q in menu titles after recovery", storageWarningTitle())
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "reviewGOOSE", "settings.json")); err != nil {
+		t.Errorf("expected settings file after flush: %v", err)
+	}
+}
+
+func TestStorageSettingsFailureWarnsOnce(t *testing.T) {
+	configDir, _ := readOnlyDir(t)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("APPDATA", configDir)
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	app := &App{
+		mu:           sync.RWMutex{},
+		cacheDir:     t.TempDir(),
+		storage:      newStorageHealth(),
+		stateManager: NewPRStateManager(time.Now()),
+		hiddenOrgs:   make(map[string]bool),
+		seenOrgs:     make(map[string]orgActivity),
+	}
+	app.saveSettings()
+
+	// The cache is writable; its writes mustn't retry the settings or restart the streak
+	cacheManager := prcache.NewManager(app.cacheDir)
+	updatedAt := time.Now()
+	for i := range 5 {
+		url := fmt.Sprintf("https://github.com/org/repo/pull/%d", i+1)
+		key := prcache.CacheKey(url, updatedAt)
+		app.saveToCache(cacheManager, cacheManager.CachePath(key), key, url, &turn.CheckResponse{}, updatedAt)
+	}
+	app.saveSettings()
+
+	if got := strings.Count(logs.String(), "Write failed"); got != 1 {
+		t.Errorf("logged %d write failure warnings, want 1:\n%s", got, logs.String())
+	}
+	if !app.storage.degraded() || !strings.HasPrefix(app.storage.status(), "settings: ") {
+		t.Errorf("status = %q, want the settings failure still reported", app.storage.status())
+	}
+}
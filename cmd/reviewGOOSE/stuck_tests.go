@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// defaultStuckTestsThreshold is how long tests may report "running" before they count as stuck.
+const defaultStuckTestsThreshold = 2 * time.Hour
+
+// stuckDuration formats how long tests have been running, e.g. "6h".
+func stuckDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// TrackStuckTests records how long each PR has continuously reported running tests and
+// marks PRs past threshold as stuck, in place. Stuck outgoing PRs without a more specific
+// blocking action become blocked with actionInvestigateCI, so the usual unblocked -> blocked
+// transition sends exactly one notification. Any other test state resets the timer;
+// PRs without Turn data this cycle keep theirs.
+func (m *PRStateManager) TrackStuckTests(incoming, outgoing []PR, threshold time.Duration) {
+	if threshold <= 0 {
+		threshold = defaultStuckTestsThreshold
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	seen := make(map[string]bool, len(incoming)+len(outgoing))
+	track := func(prs []PR, isOutgoing bool) {
+		for i := range prs {
+			pr := &prs[i]
+			seen[pr.URL] = true
+			if pr.TurnDataAppliedAt.IsZero() {
+				continue
+			}
+			if pr.TestState != "running" {
+				if _, ok := m.runningSince[pr.URL]; ok {
+					slog.Debug("[STUCK] Tests no longer running, resetting timer", "url", pr.URL, "test_state", pr.TestState)
+					delete(m.runningSince, pr.URL)
+				}
+				continue
+			}
+
+			since, ok := m.runningSince[pr.URL]
+			if !ok {
+				m.runningSince[pr.URL] = now
+				continue
+			}
+			running := now.Sub(since)
+			if running < threshold {
+				continue
+			}
+
+			pr.TestsStuckFor = running
+			if !isOutgoing || pr.IsBlocked {
+				continue
+			}
+			slog.Info("[STUCK] Tests stuck on outgoing PR", "repo", pr.Repository, "number", pr.Number,
+				"running_for", running.Round(time.Minute), "threshold", threshold)
+			pr.IsBlocked = true
+			pr.ActionKind = actionInvestigateCI
+			pr.ActionReason = fmt.Sprintf("tests running for %s; check for a hung runner or workflows awaiting approval",
+				stuckDuration(running))
+		}
+	}
+	track(incoming, false)
+	track(outgoing, true)
+
+	for url := range m.runningSince {
+		if !seen[url] {
+			delete(m.runningSince, url)
+		}
+	}
+}
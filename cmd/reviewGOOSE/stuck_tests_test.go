@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const stuckTestURL = "https://github.com/org/repo/pull/7"
+
+// stuckTestPR returns an outgoing PR with Turn data reporting the given test state.
+func stuckTestPR(testState string) PR {
+	now := time.Now()
+	return PR{
+		Repository:        "org/repo",
+		Number:            7,
+		URL:               stuckTestURL,
+		TestState:         testState,
+		UpdatedAt:         now,
+		LastActivityAt:    now,
+		TurnDataAppliedAt: now,
+	}
+}
+
+func TestStuckDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		45 * time.Minute:             "45m",
+		2 * time.Hour:                "2h",
+		6*time.Hour + 59*time.Minute: "6h",
+		50 * time.Hour:               "2d",
+	}
+	for d, want := range tests {
+		if got := stuckDuration(d); got != want {
+			t.Errorf("stuckDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestTrackStuckTests(t *testing.T) {
+	clock := time.Now()
+	m := NewPRStateManager(clock.Add(-time.Hour))
+	m.now = func() time.Time { return clock }
+
+	// track runs one cycle at the current clock and returns the resulting PR
+	track := func(testState string) PR {
+		t.Helper()
+		outgoing := []PR{stuckTestPR(testState)}
+		m.TrackStuckTests(nil, outgoing, 2*time.Hour)
+		return outgoing[0]
+	}
+
+	if pr := track("running"); pr.TestsStuckFor != 0 || pr.IsBlocked {
+		t.Fatalf("first sighting should only start the timer: %+v", pr)
+	}
+
+	clock = clock.Add(90 * time.Minute)
+	if pr := track("running"); pr.TestsStuckFor != 0 || pr.IsBlocked {
+		t.Fatalf("under threshold should not be stuck: %+v", pr)
+	}
+
+	clock = clock.Add(4*time.Hour + 30*time.Minute)
+	pr := track("running")
+	if !pr.IsBlocked || pr.ActionKind != actionInvestigateCI || pr.TestsStuckFor != 6*time.Hour {
+		t.Fatalf("tests running for 6h should block the PR: %+v", pr)
+	}
+	if got := prAction(pr); got != "tests stuck (6h)" {
+		t.Errorf("prAction() = %q, want %q", got, "tests stuck (6h)")
+	}
+
+	// Flapping resets the timer
+	clock = clock.Add(time.Minute)
+	if pr := track("passing"); pr.TestsStuckFor != 0 || pr.IsBlocked {
+		t.Fatalf("passing tests should clear stuck state: %+v", pr)
+	}
+	clock = clock.Add(time.Minute)
+	track("running")
+	clock = clock.Add(time.Hour)
+	if pr := track("running"); pr.TestsStuckFor != 0 {
+		t.Errorf("timer should restart after tests stopped running, got %v", pr.TestsStuckFor)
+	}
+}
+
+func TestTrackStuckTestsKeepsTimerWithoutTurnData(t *testing.T) {
+	clock := time.Now()
+	m := NewPRStateManager(clock.Add(-time.Hour))
+	m.now = func() time.Time { return clock }
+
+	m.TrackStuckTests(nil, []PR{stuckTestPR("running")}, time.Hour)
+
+	// A cycle where Turn failed: no test state, but the timer survives
+	clock = clock.Add(30 * time.Minute)
+	noTurn := stuckTestPR("")
+	noTurn.TurnDataAppliedAt = time.Time{}
+	m.TrackStuckTests(nil, []PR{noTurn}, time.Hour)
+
+	clock = clock.Add(time.Hour)
+	outgoing := []PR{stuckTestPR("running")}
+	m.TrackStuckTests(nil, outgoing, time.Hour)
+	if outgoing[0].TestsStuckFor != 90*time.Minute {
+		t.Errorf("TestsStuckFor = %v, want 1h30m", outgoing[0].TestsStuckFor)
+	}
+
+	// PRs that leave the lists are forgotten
+	m.TrackStuckTests(nil, nil, time.Hour)
+	if len(m.runningSince) != 0 {
+		t.Errorf("runningSince not pruned: %v", m.runningSince)
+	}
+}
+
+func TestTrackStuckTestsOnlyBlocksOutgoing(t *testing.T) {
+	clock := time.Now()
+	m := NewPRStateManager(clock.Add(-time.Hour))
+	m.now = func() time.Time { return clock }
+
+	incoming := []PR{stuckTestPR("running")}
+	m.TrackStuckTests(incoming, nil, time.Hour)
+	clock = clock.Add(3 * time.Hour)
+	incoming = []PR{stuckTestPR("running")}
+	m.TrackStuckTests(incoming, nil, time.Hour)
+
+	if incoming[0].IsBlocked || incoming[0].ActionKind != "" {
+		t.Errorf("incoming PR should not be blocked by stuck tests: %+v", incoming[0])
+	}
+	if got := prAction(incoming[0]); got != "tests stuck (3h)" {
+		t.Errorf("prAction() = %q, want stuck label on incoming PR", got)
+	}
+
+	// A more specific blocking action is kept
+	clock = clock.Add(time.Hour)
+	outgoing := []PR{stuckTestPR("running")}
+	outgoing[0].IsBlocked = true
+	outgoing[0].ActionKind = "fix_conflict"
+	m.TrackStuckTests(incoming, outgoing, time.Hour)
+	m.TrackStuckTests(incoming, outgoing, time.Hour)
+	if outgoing[0].ActionKind != "fix_conflict" || prAction(outgoing[0]) != "fix conflict" {
+		t.Errorf("stuck tests overrode a blocking action: %+v", outgoing[0])
+	}
+}
+
+func TestStuckTestsNotifyOnce(t *testing.T) {
+	clock := time.Now()
+	m := NewPRStateManager(clock.Add(-time.Hour))
+	m.now = func() time.Time { return clock }
+
+	notified := 0
+	for range 5 {
+		outgoing := []PR{stuckTestPR("running")}
+		m.TrackStuckTests(nil, outgoing, 2*time.Hour)
+		notified += len(m.UpdatePRs(nil, outgoing, nil, false))
+		clock = clock.Add(time.Hour)
+	}
+	if notified != 1 {
+		t.Errorf("got %d notifications for stuck tests, want exactly 1", notified)
+	}
+}
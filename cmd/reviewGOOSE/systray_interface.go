@@ -2,6 +2,7 @@ package main
 
 import (
 	"log/slog"
+	"slices"
 	"sync"
 
 	"github.com/energye/systray"
@@ -14,22 +15,96 @@ type SystrayInterface interface {
 	AddSeparator()
 	SetTitle(title string)
 	SetIcon(iconBytes []byte)
+	SetTooltip(tooltip string)
 	SetOnClick(fn func(menu systray.IMenu))
 	Quit()
+	// AddSettingItem adds a static settings entry, rendering its checkmark from state.
+	AddSettingItem(state SettingState) MenuItem
+	// SettingsSnapshot returns the settings entries rendered since the last ResetMenu, in order.
+	SettingsSnapshot() []SettingState
+	// LiveHandlers counts the click handlers on items added since the last ResetMenu.
+	// It should stay flat across rebuilds of the same menu.
+	LiveHandlers() int
+}
+
+// SettingState is the rendered state of a static settings menu entry.
+type SettingState struct {
+	ID        string
+	Label     string
+	Tooltip   string
+	Checkable bool
+	Checked   bool
+	Disabled  bool
+}
+
+// Title returns the menu title, prefixed with a text checkmark when checked.
+// Text checkmarks are used on all platforms since native ones aren't supported everywhere.
+func (s SettingState) Title() string {
+	if s.Checked {
+		return "✓ " + s.Label
+	}
+	return s.Label
 }
 
 // RealSystray implements SystrayInterface using the actual systray library.
-type RealSystray struct{}
+//
+// systray.ResetMenu removes items from the native menu but never from the library's
+// id -> item table, so every generation's click closures would otherwise stay reachable.
+// RealSystray tracks the items of the current generation and releases their handlers on
+// reset; a click on an item from an older generation is ignored.
+type RealSystray struct {
+	settings   []SettingState
+	items      []*RealMenuItem // Items added since the last ResetMenu
+	generation uint64
+	mu         sync.Mutex
+}
 
-func (*RealSystray) ResetMenu() {
+func (r *RealSystray) ResetMenu() {
 	slog.Debug("[SYSTRAY] ResetMenu called")
+	r.mu.Lock()
+	r.settings = nil
+	stale := r.items
+	r.items = nil
+	r.generation++
+	r.mu.Unlock()
+	for _, item := range stale {
+		item.release()
+	}
+	slog.Debug("[SYSTRAY] Released handlers from previous menu", "items", len(stale))
 	systray.ResetMenu()
 }
 
-func (*RealSystray) AddMenuItem(title, tooltip string) MenuItem {
+func (r *RealSystray) AddMenuItem(title, tooltip string) MenuItem {
 	slog.Debug("[SYSTRAY] AddMenuItem called", "title", title)
-	item := systray.AddMenuItem(title, tooltip)
-	return &RealMenuItem{MenuItem: item}
+	return r.track(systray.AddMenuItem(title, tooltip))
+}
+
+// track wraps a new systray item as part of the current menu generation.
+func (r *RealSystray) track(item *systray.MenuItem) *RealMenuItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wrapped := &RealMenuItem{MenuItem: item, tray: r, generation: r.generation}
+	r.items = append(r.items, wrapped)
+	return wrapped
+}
+
+// current reports whether generation is the live menu generation.
+func (r *RealSystray) current(generation uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return generation == r.generation
+}
+
+func (r *RealSystray) LiveHandlers() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, item := range r.items {
+		if item.hasClick {
+			n++
+		}
+	}
+	return n
 }
 
 func (*RealSystray) AddSeparator() {
@@ -45,6 +120,10 @@ func (*RealSystray) SetIcon(iconBytes []byte) {
 	systray.SetIcon(iconBytes)
 }
 
+func (*RealSystray) SetTooltip(tooltip string) {
+	systray.SetTooltip(tooltip)
+}
+
 func (*RealSystray) SetOnClick(fn func(menu systray.IMenu)) {
 	systray.SetOnClick(fn)
 }
@@ -53,17 +132,51 @@ func (*RealSystray) Quit() {
 	systray.Quit()
 }
 
+func (r *RealSystray) AddSettingItem(state SettingState) MenuItem {
+	r.mu.Lock()
+	r.settings = append(r.settings, state)
+	r.mu.Unlock()
+	return r.AddMenuItem(state.Title(), state.Tooltip)
+}
+
+func (r *RealSystray) SettingsSnapshot() []SettingState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.settings)
+}
+
 // MockSystray implements SystrayInterface for testing.
 type MockSystray struct {
-	title     string
-	menuItems []string
-	mu        sync.Mutex
+	settingItems map[string]*MockMenuItem
+	title        string
+	tooltip      string
+	lastIcon     []byte
+	trayCalls    []trayCall // Icon and tooltip changes, in order
+	menuItems    []string
+	settings     []SettingState
+	items        []*MockMenuItem // Items added since the last ResetMenu
+	iconSets     int
+	quits        int
+	mu           sync.Mutex
+}
+
+// trayCall is an icon or tooltip change MockSystray recorded.
+type trayCall struct {
+	tooltip string
+	icon    []byte
 }
 
+// ResetMenu drops the menu and, like RealSystray, releases the old items' handlers.
 func (m *MockSystray) ResetMenu() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	for _, item := range m.items {
+		item.release()
+	}
 	m.menuItems = nil
+	m.settings = nil
+	m.settingItems = nil
+	m.items = nil
 }
 
 func (m *MockSystray) AddMenuItem(title, tooltip string) MenuItem {
@@ -71,10 +184,12 @@ func (m *MockSystray) AddMenuItem(title, tooltip string) MenuItem {
 	defer m.mu.Unlock()
 	m.menuItems = append(m.menuItems, title)
 	// Return a MockMenuItem that won't panic when methods are called
-	return &MockMenuItem{
+	item := &MockMenuItem{
 		title:   title,
 		tooltip: tooltip,
 	}
+	m.items = append(m.items, item)
+	return item
 }
 
 func (m *MockSystray) AddSeparator() {
@@ -89,14 +204,60 @@ func (m *MockSystray) SetTitle(title string) {
 	m.title = title
 }
 
-func (*MockSystray) SetIcon(_ []byte) {
-	// No-op for testing
+func (m *MockSystray) SetIcon(iconBytes []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastIcon = iconBytes
+	m.iconSets++
+	m.trayCalls = append(m.trayCalls, trayCall{icon: iconBytes})
+}
+
+func (m *MockSystray) SetTooltip(tooltip string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tooltip = tooltip
+	m.trayCalls = append(m.trayCalls, trayCall{tooltip: tooltip})
 }
 
 func (*MockSystray) SetOnClick(_ func(menu systray.IMenu)) {
 	// No-op for testing
 }
 
-func (*MockSystray) Quit() {
-	// No-op for testing
+func (m *MockSystray) Quit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quits++
+}
+
+func (m *MockSystray) AddSettingItem(state SettingState) MenuItem {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item := &MockMenuItem{
+		title:   state.Title(),
+		tooltip: state.Tooltip,
+	}
+	m.menuItems = append(m.menuItems, item.title)
+	m.items = append(m.items, item)
+	m.settings = append(m.settings, state)
+	if m.settingItems == nil {
+		m.settingItems = make(map[string]*MockMenuItem)
+	}
+	m.settingItems[state.ID] = item
+	return item
+}
+
+func (m *MockSystray) SettingsSnapshot() []SettingState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.settings)
+}
+
+func (m *MockSystray) LiveHandlers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, item := range m.items {
+		n += item.liveHandlers()
+	}
+	return n
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// Team mode ("team" in settings.json) is an observer view for a team lead: goose runs
+// each teammate's searches and the menu groups the PRs blocked on them by teammate.
+// Nothing in it is mine, so it never notifies or auto-opens.
+
+// minTeamUpdateInterval is the shortest update interval in team mode, whose API usage
+// grows with every teammate.
+const minTeamUpdateInterval = 2 * time.Minute
+
+// validTeam normalizes the configured teammates to unique valid GitHub logins,
+// skipping and logging anything else.
+func validTeam(logins []string) []string {
+	var valid []string
+	seen := make(map[string]bool)
+	for _, login := range logins {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		if err := validateGitHubUsername(login); err != nil || login == "" {
+			slog.Warn("[SETTINGS] Ignoring invalid teammate", "login", login, "error", err)
+			continue
+		}
+		if seen[strings.ToLower(login)] {
+			continue
+		}
+		seen[strings.ToLower(login)] = true
+		valid = append(valid, login)
+	}
+	return valid
+}
+
+// teamSearchQueries returns every teammate's searches, and whose each one is.
+func teamSearchQueries(team []string) (queries []string, member map[string]string) {
+	member = make(map[string]string)
+	for _, login := range team {
+		for _, q := range searchQueries(login, nil) {
+			queries = append(queries, q)
+			member[q] = login
+		}
+	}
+	return queries, member
+}
+
+// teamUpdateInterval stretches the update interval by the number of teammates, so a
+// team's searches use about as much API quota per hour as one user's.
+func teamUpdateInterval(base time.Duration, members int) time.Duration {
+	if members <= 0 {
+		return base
+	}
+	return max(base*time.Duration(members), minTeamUpdateInterval)
+}
+
+// teamMode reports whether goose shows a team's queues. Repo mode takes precedence.
+func (app *App) teamMode() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return len(app.team) > 0 && len(app.repos) == 0
+}
+
+// sharedQueue reports whether PRs are watched on others' behalf, in repo or team mode,
+// and the users whose next actions count as blocked. Nothing in a shared queue is mine.
+func (app *App) sharedQueue() (shared bool, users []string) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	switch {
+	case len(app.repos) > 0:
+		return true, app.repoModeUsers
+	case len(app.team) > 0:
+		return true, app.team
+	default:
+		return false, nil
+	}
+}
+
+// blockedOn lists the users with a next action, in users order.
+func blockedOn(next map[string]turn.Action, users []string) []string {
+	var blocked []string
+	for _, login := range users {
+		if _, ok := next[login]; ok {
+			blocked = append(blocked, login)
+		}
+	}
+	return blocked
+}
+
+// setTeamFailures records whose searches failed this cycle.
+func (app *App) setTeamFailures(failed map[string]bool) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if len(failed) > 0 || len(app.teamFailed) > 0 {
+		slog.Info("[GITHUB] Team searches failed", "teammates", len(failed))
+	}
+	app.teamFailed = failed
+}
+
+// teamQueue is one teammate's section of the team view.
+type teamQueue struct {
+	login  string
+	prs    []PR // PRs blocked on login
+	failed bool // login's searches failed this cycle
+}
+
+// teamQueues groups the blocked PRs in incoming, a prView's shown PRs, by the teammates
+// they wait on, in configured order. A PR waiting on several teammates appears under each.
+func (app *App) teamQueues(incoming []PR) []teamQueue {
+	app.mu.RLock()
+	team := app.team
+	failed := maps.Clone(app.teamFailed)
+	app.mu.RUnlock()
+
+	queues := make([]teamQueue, len(team))
+	index := make(map[string]int, len(team))
+	for i, login := range team {
+		queues[i] = teamQueue{login: login, failed: failed[login]}
+		index[login] = i
+	}
+	for _, pr := range app.sortSectionPRs(incoming, "Incoming") {
+		if !pr.NeedsReview && !pr.IsBlocked {
+			continue
+		}
+		for _, login := range pr.BlockedOn {
+			if i, ok := index[login]; ok {
+				queues[i].prs = append(queues[i].prs, pr)
+			}
+		}
+	}
+	return queues
+}
+
+// teamHeader is a teammate's menu line, e.g. "@alice — 3 blocked".
+func teamHeader(q teamQueue) string {
+	header := msg("team.member", q.login, len(q.prs))
+	if q.failed {
+		header += " " + msg("team.fetch_failed")
+	}
+	return header
+}
+
+// teamSectionTitles lists the team view entries for change detection.
+func (app *App) teamSectionTitles(incoming []PR) []string {
+	displayMode, labelWidth := app.menuLabelSettings()
+	titles := []string{msg("team.menu")}
+	for _, q := range app.teamQueues(incoming) {
+		titles = append(titles, teamHeader(q))
+		for i := range q.prs {
+			titles = append(titles, formatMenuLabel(q.prs[i], displayMode, labelWidth))
+		}
+	}
+	return titles
+}
+
+// addTeamSections adds the team view: a line per teammate, expandable to the PRs
+// waiting on them.
+func (app *App) addTeamSections(ctx context.Context, incoming []PR) {
+	header := app.systrayInterface.AddMenuItem(msg("team.menu"), msg("team.menu.tooltip"))
+	header.Disable()
+
+	displayMode, labelWidth := app.menuLabelSettings()
+	for _, q := range app.teamQueues(incoming) {
+		tooltip := ""
+		if q.failed {
+			tooltip = msg("team.fetch_failed.tooltip", q.login)
+		}
+		item := app.systrayInterface.AddMenuItem(teamHeader(q), tooltip)
+		if len(q.prs) == 0 {
+			item.Disable()
+			continue
+		}
+		for i := range q.prs {
+			pr := &q.prs[i]
+			url := prLink(pr)
+			item.AddSubMenuItem(formatMenuLabel(*pr, displayMode, labelWidth), formatMenuTooltip(*pr, displayMode, prAge(pr.UpdatedAt))).Click(func() {
+				if err := app.openBrowser(ctx, url, ""); err != nil {
+					slog.Error("failed to open url", "error", err)
+				}
+			})
+		}
+	}
+}
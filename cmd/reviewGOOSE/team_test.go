@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+func TestValidTeam(t *testing.T) {
+	got := validTeam([]string{" alice ", "@bob", "ALICE", "", "not a login", "-carol", "dave"})
+	want := []string{"alice", "bob", "dave"}
+	if !slices.Equal(got, want) {
+		t.Errorf("validTeam() = %q, want %q", got, want)
+	}
+}
+
+func TestTeamSearchQueries(t *testing.T) {
+	queries, member := teamSearchQueries([]string{"alice", "bob"})
+	if len(queries) != 4 {
+		t.Fatalf("queries = %q, want each teammate's two searches", queries)
+	}
+	for _, q := range queries {
+		login := member[q]
+		if login == "" || !strings.Contains(q, ":"+login+" ") {
+			t.Errorf("query %q attributed to %q", q, login)
+		}
+	}
+}
+
+func TestTeamUpdateInterval(t *testing.T) {
+	tests := []struct {
+		base    time.Duration
+		members int
+		want    time.Duration
+	}{
+		{base: time.Minute, members: 0, want: time.Minute},
+		{base: time.Minute, members: 1, want: 2 * time.Minute},
+		{base: 30 * time.Second, members: 3, want: 2 * time.Minute},
+		{base: time.Minute, members: 4, want: 4 * time.Minute},
+		{base: 5 * time.Minute, members: 3, want: 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := teamUpdateInterval(tt.base, tt.members); got != tt.want {
+			t.Errorf("teamUpdateInterval(%v, %d) = %v, want %v", tt.base, tt.members, got, tt.want)
+		}
+	}
+}
+
+// newTeamTestApp watches alice, bob, and carol. Both of alice's and carol's searches
+// find org/a#1, which waits on alice and carol; carol's also find org/b#2, which waits
+// on her alone. Bob's searches fail.
+func newTeamTestApp(t *testing.T, now time.Time) *App {
+	t.Helper()
+	pr := func(repo string, number int) fakePR {
+		return fakePR{repo: repo, number: number, title: fmt.Sprintf("PR %d in %s", number, repo), updated: now}
+	}
+	search := newFakeGitHub(t, func(r *http.Request) fakeSearch {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, ":bob "):
+			return fakeSearch{status: http.StatusUnprocessableEntity}
+		case strings.Contains(q, "involves:alice"):
+			return fakeSearch{prs: []fakePR{pr("org/a", 1)}}
+		case strings.Contains(q, "involves:carol"):
+			return fakeSearch{prs: []fakePR{pr("org/a", 1), pr("org/b", 2)}}
+		default:
+			return fakeSearch{}
+		}
+	})
+	turnClient := newFakeTurn(t, func(_ context.Context, req turn.CheckRequest) fakeVerdict {
+		review := map[string]any{"kind": "review", "critical": true, "since": now.Format(time.RFC3339)}
+		next := map[string]any{"carol": review}
+		if strings.Contains(req.URL, "org/a") {
+			next["alice"] = review
+		}
+		return fakeVerdict{next: next}
+	})
+	app := newFetchTestApp(t, search.URL, turnClient, "lead")
+	app.team = []string{"alice", "bob", "carol"}
+	return app
+}
+
+func TestTeamModeAggregation(t *testing.T) {
+	app := newTeamTestApp(t, time.Now())
+	app.updatePRs(context.Background())
+
+	if len(app.incoming) != 2 || len(app.outgoing) != 0 {
+		t.Fatalf("incoming=%d outgoing=%d, want the two PRs deduplicated, all incoming", len(app.incoming), len(app.outgoing))
+	}
+	if app.partialFetch != nil || app.lastFetchError != "" {
+		t.Errorf("bob's failed searches failed the cycle: partial=%v error=%q", app.partialFetch, app.lastFetchError)
+	}
+
+	queues := app.teamQueues(app.incoming)
+	var got []string
+	for _, q := range queues {
+		got = append(got, teamHeader(q))
+	}
+	want := []string{"@alice — 1 blocked", "@bob — 0 blocked (fetch failed)", "@carol — 2 blocked"}
+	if !slices.Equal(got, want) {
+		t.Errorf("team lines = %q, want %q", got, want)
+	}
+	if counts := app.countPRs(); counts.IncomingBlocked != 2 {
+		t.Errorf("counts = %+v, want the team's 2 blocked PRs", counts)
+	}
+	if titles := app.generateMenuTitles(); !slices.Contains(titles, "@carol — 2 blocked") || slices.Contains(titles, msg("menu.incoming_prs")) {
+		t.Errorf("menu titles %q, want the team view instead of Incoming", titles)
+	}
+	if got := app.baseTooltip(); got != "reviewGOOSE (Team of 3)" {
+		t.Errorf("tooltip = %q, want team mode", got)
+	}
+	if alerts := app.notifiablePRs(app.incoming); len(alerts) != 0 {
+		t.Errorf("team mode would notify for %d PRs, want none", len(alerts))
+	}
+}
+
+func TestTeamModeAllSearchesFail(t *testing.T) {
+	app := newTeamTestApp(t, time.Now())
+	app.team = []string{"bob"}
+	if _, _, err := app.fetchPRsInternal(context.Background()); err == nil {
+		t.Error("fetchPRsInternal() succeeded with every teammate's searches failing")
+	}
+}
+
+func TestRepoModeOverridesTeam(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.team = []string{"alice"}
+	if !app.teamMode() {
+		t.Error("teamMode() = false with a team set")
+	}
+	app.repos = []string{"org/a"}
+	if app.teamMode() {
+		t.Error("teamMode() = true in repo mode")
+	}
+}
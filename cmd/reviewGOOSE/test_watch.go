@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/appsettings"
+)
+
+const (
+	// testWatchFileName persists "Notify when tests finish" requests in the cache directory.
+	testWatchFileName = "test_watches.json"
+	// testWatchTTL is how long a watch waits for tests to finish before it is dropped.
+	testWatchTTL = 48 * time.Hour
+)
+
+// testWatch is a request to be notified once a PR's running tests finish.
+type testWatch struct {
+	WatchedAt  time.Time `json:"watched_at"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+}
+
+// testsInProgress reports whether a Turn test state means CI hasn't finished yet.
+func testsInProgress(state string) bool {
+	switch state {
+	case "running", "queued", "pending":
+		return true
+	default:
+		return false
+	}
+}
+
+// LoadTestWatches restores the watches saved at path, dropping expired ones, and saves
+// later changes there. A missing or unreadable file starts empty.
+func (m *PRStateManager) LoadTestWatches(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.testWatchPath = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("[WATCH] Failed to read test watches", "path", path, "error", err)
+		}
+		return
+	}
+	var watches map[string]testWatch
+	if err := json.Unmarshal(data, &watches); err != nil {
+		slog.Warn("[WATCH] Ignoring unreadable test watches", "path", path, "error", err)
+		return
+	}
+	now := m.now()
+	for url, w := range watches {
+		if now.Sub(w.WatchedAt) < testWatchTTL {
+			m.testWatches[url] = w
+		}
+	}
+	if len(m.testWatches) > 0 {
+		slog.Info("[WATCH] Restored test watches", "count", len(m.testWatches))
+	}
+}
+
+// WatchTests asks to be notified when pr's tests finish.
+func (m *PRStateManager) WatchTests(pr *PR) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.testWatches[pr.URL] = testWatch{WatchedAt: m.now(), Repository: pr.Repository, Number: pr.Number}
+	slog.Info("[WATCH] Watching tests", "repo", pr.Repository, "number", pr.Number, "test_state", pr.TestState)
+	m.saveTestWatchesLocked()
+}
+
+// UnwatchTests cancels the watch on a PR's tests, if any.
+func (m *PRStateManager) UnwatchTests(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.testWatches[url]; !ok {
+		return
+	}
+	delete(m.testWatches, url)
+	slog.Info("[WATCH] Stopped watching tests", "url", url)
+	m.saveTestWatchesLocked()
+}
+
+// WatchingTests reports whether a PR's tests are being watched.
+func (m *PRStateManager) WatchingTests(url string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.testWatches[url]
+	return ok
+}
+
+// FinishedTestWatches returns the watched PRs whose tests are no longer in progress,
+// clearing their watches. Watches past testWatchTTL are dropped, as are watches on PRs
+// missing from a complete fetch, which have closed. PRs without a known test state
+// this cycle keep waiting.
+func (m *PRStateManager) FinishedTestWatches(incoming, outgoing []PR, complete bool) []PR {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.testWatches) == 0 {
+		return nil
+	}
+	prs := make(map[string]*PR, len(incoming)+len(outgoing))
+	for _, list := range [][]PR{incoming, outgoing} {
+		for i := range list {
+			prs[list[i].URL] = &list[i]
+		}
+	}
+
+	now := m.now()
+	before := len(m.testWatches)
+	var finished []PR
+	for url, w := range maps.Clone(m.testWatches) {
+		pr, ok := prs[url]
+		switch {
+		case now.Sub(w.WatchedAt) >= testWatchTTL:
+			slog.Info("[WATCH] Test watch expired", "repo", w.Repository, "number", w.Number, "watched_for", now.Sub(w.WatchedAt).Round(time.Minute))
+		case !ok && complete:
+			slog.Info("[WATCH] Watched PR closed, cancelling test watch", "repo", w.Repository, "number", w.Number)
+		case !ok, pr.TurnDataAppliedAt.IsZero(), pr.TestState == "", testsInProgress(pr.TestState):
+			continue
+		default:
+			slog.Info("[WATCH] Watched tests finished", "repo", pr.Repository, "number", pr.Number, "test_state", pr.TestState)
+			finished = append(finished, *pr)
+		}
+		delete(m.testWatches, url)
+	}
+	if len(m.testWatches) != before {
+		m.saveTestWatchesLocked()
+	}
+	return finished
+}
+
+// saveTestWatchesLocked persists the watches, if LoadTestWatches set a path. The caller holds m.mu.
+func (m *PRStateManager) saveTestWatchesLocked() {
+	if m.testWatchPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(m.testWatches, "", "  ")
+	if err != nil {
+		slog.Warn("[WATCH] Failed to marshal test watches", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(m.testWatchPath), 0o700); err != nil {
+		slog.Warn("[WATCH] Failed to create test watch directory", "error", err)
+		return
+	}
+	if err := appsettings.WriteAtomic(m.testWatchPath, data, 0o600); err != nil {
+		slog.Warn("[WATCH] Failed to save test watches", "path", m.testWatchPath, "error", err)
+	}
+}
+
+// testOutcomeMessage is the notification body for a watched PR whose tests finished.
+func testOutcomeMessage(pr *PR) string {
+	switch pr.TestState {
+	case "passing":
+		return msg("notify.tests_passed", pr.Repository, pr.Number)
+	case "failing":
+		return msg("notify.tests_failed", pr.Repository, pr.Number)
+	default:
+		return msg("notify.tests_finished.other", pr.Repository, pr.Number, pr.TestState)
+	}
+}
+
+// notifyFinishedTests sends one notification per watched PR whose tests finished.
+func (app *App) notifyFinishedTests(ctx context.Context, incoming, outgoing []PR) {
+	app.mu.RLock()
+	complete := app.partialFetch == nil
+	app.mu.RUnlock()
+
+	finished := app.stateManager.FinishedTestWatches(incoming, outgoing, complete)
+	if len(finished) == 0 {
+		return
+	}
+	for i := range finished {
+		pr := finished[i]
+		app.goTracked("notification", func() {
+			e := notificationEvent{kind: notifyKindTests, prURL: pr.URL, title: msg("notify.tests_finished"), message: testOutcomeMessage(&pr)}
+			if err := app.notifyEvent(e); err != nil {
+				slog.Error("[NOTIFY] Failed to send test outcome notification", "url", pr.URL, "error", err)
+			}
+		})
+	}
+	// Drop the "✓ Watching tests" checkmark from their submenus
+	app.rebuildMenu(ctx)
+}
+
+// addTestWatchAction adds "Notify when tests finish" to an incoming PR's submenu while
+// its tests are running, and "✓ Watching tests" to cancel an active watch.
+func (app *App) addTestWatchAction(ctx context.Context, item MenuItem, pr *PR) {
+	watching := app.stateManager.WatchingTests(pr.URL)
+	if !watching && !testsInProgress(pr.TestState) {
+		return
+	}
+	text := msg("pr.watch_tests")
+	if watching {
+		text = "✓ " + msg("pr.watching_tests")
+	}
+	p := *pr
+	item.AddSubMenuItem(text, "").Click(func() {
+		if app.stateManager.WatchingTests(p.URL) {
+			app.stateManager.UnwatchTests(p.URL)
+		} else {
+			app.stateManager.WatchTests(&p)
+		}
+		app.rebuildMenu(ctx)
+	})
+}
+
+// FlushTestWatches writes the watches to disk, for shutdown.
+func (m *PRStateManager) FlushTestWatches() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saveTestWatchesLocked()
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func watchedPR(state string) PR {
+	return PR{
+		Repository: "acme/widgets", Number: 123, URL: "https://github.com/acme/widgets/pull/123",
+		TestState: state, TurnDataAppliedAt: time.Now(),
+	}
+}
+
+func TestTestWatchLifecycle(t *testing.T) {
+	m := NewPRStateManager(time.Now())
+	pr := watchedPR("running")
+	m.WatchTests(&pr)
+	if !m.WatchingTests(pr.URL) {
+		t.Fatal("WatchTests didn't record a watch")
+	}
+
+	// Still running, or no Turn data this cycle: keep waiting
+	if got := m.FinishedTestWatches([]PR{pr}, nil, true); len(got) != 0 {
+		t.Errorf("running tests reported finished: %+v", got)
+	}
+	unknown := watchedPR("passing")
+	unknown.TurnDataAppliedAt = time.Time{}
+	if got := m.FinishedTestWatches([]PR{unknown}, nil, true); len(got) != 0 {
+		t.Errorf("PR without Turn data reported finished: %+v", got)
+	}
+
+	done := watchedPR("passing")
+	got := m.FinishedTestWatches([]PR{done}, nil, true)
+	if len(got) != 1 || got[0].URL != pr.URL {
+		t.Fatalf("FinishedTestWatches = %+v, want the watched PR", got)
+	}
+	if m.WatchingTests(pr.URL) {
+		t.Error("the watch should clear after it fires")
+	}
+	if got := m.FinishedTestWatches([]PR{done}, nil, true); len(got) != 0 {
+		t.Errorf("a cleared watch fired again: %+v", got)
+	}
+}
+
+func TestTestWatchExpiresAndCancels(t *testing.T) {
+	now := time.Now()
+	m := NewPRStateManager(now)
+	m.now = func() time.Time { return now }
+	pr := watchedPR("running")
+	m.WatchTests(&pr)
+
+	// A partial fetch can't tell a closed PR from a failed search
+	if m.FinishedTestWatches(nil, nil, false); !m.WatchingTests(pr.URL) {
+		t.Error("a partial fetch cancelled the watch")
+	}
+	if m.FinishedTestWatches(nil, nil, true); m.WatchingTests(pr.URL) {
+		t.Error("a closed PR should cancel its watch")
+	}
+
+	m.WatchTests(&pr)
+	m.now = func() time.Time { return now.Add(testWatchTTL) }
+	done := watchedPR("passing")
+	if got := m.FinishedTestWatches([]PR{done}, nil, true); len(got) != 0 || m.WatchingTests(pr.URL) {
+		t.Errorf("expired watch: finished=%+v watching=%v, want it dropped silently", got, m.WatchingTests(pr.URL))
+	}
+}
+
+func TestTestWatchPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), testWatchFileName)
+	now := time.Now()
+	m := NewPRStateManager(now)
+	m.LoadTestWatches(path)
+	fresh, stale := watchedPR("running"), watchedPR("running")
+	stale.URL = "https://github.com/acme/widgets/pull/9"
+	m.WatchTests(&fresh)
+	m.now = func() time.Time { return now.Add(-testWatchTTL) }
+	m.WatchTests(&stale)
+
+	restarted := NewPRStateManager(now)
+	restarted.LoadTestWatches(path)
+	if !restarted.WatchingTests(fresh.URL) {
+		t.Error("watch didn't survive a restart")
+	}
+	if restarted.WatchingTests(stale.URL) {
+		t.Error("expired watch was restored")
+	}
+}
+
+func TestTestOutcomeMessages(t *testing.T) {
+	tests := []struct {
+		state string
+		want  string
+	}{
+		{state: "passing", want: "Tests passed on acme/widgets #123 — ready to review"},
+		{state: "failing", want: "Tests failed on acme/widgets #123"},
+		{state: "cancelled", want: "Tests finished on acme/widgets #123 (cancelled)"},
+	}
+	for _, tt := range tests {
+		pr := watchedPR(tt.state)
+		if got := testOutcomeMessage(&pr); got != tt.want {
+			t.Errorf("testOutcomeMessage(%q) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestTestWatchNotifiesOnce(t *testing.T) {
+	ctx := context.Background()
+	app := newFocusTestApp(time.Hour)
+	notifier := &messageNotifier{}
+	app.notifier = notifier
+
+	running := watchedPR("running")
+	item := &MockMenuItem{}
+	app.addTestWatchAction(ctx, item, &running)
+	prActionItem(t, item, "Notify when tests finish").clickHandler()
+
+	item = &MockMenuItem{}
+	app.addTestWatchAction(ctx, item, &running)
+	prActionItem(t, item, "✓ Watching tests")
+
+	failing := watchedPR("failing")
+	app.notifyFinishedTests(ctx, []PR{failing}, nil)
+	app.notifyFinishedTests(ctx, []PR{failing}, nil)
+	want := []string{"Tests Finished: Tests failed on acme/widgets #123"}
+	if notes := waitForNotes(notifier, 1); !slices.Equal(notes, want) {
+		t.Errorf("notifications = %q, want %q", notes, want)
+	}
+
+	item = &MockMenuItem{}
+	app.addTestWatchAction(ctx, item, &failing)
+	if len(item.subItems) != 0 {
+		t.Error("finished tests shouldn't offer a watch")
+	}
+}
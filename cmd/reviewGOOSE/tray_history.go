@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// By the time someone looks into "the icon showed a goose but nothing was blocked", the
+// state has moved on and the logs may have rotated. The last few tray icon changes are
+// kept in memory, with the counts that produced them, for the diagnostic report and the
+// Debug submenu.
+
+const (
+	// maxTrayChanges is how many tray icon changes are kept.
+	maxTrayChanges = 50
+	// shownTrayChanges is how many of them the Debug submenu lists.
+	shownTrayChanges = 5
+)
+
+// trayChange is what the tray started showing at a point in time.
+type trayChange struct {
+	at      time.Time
+	title   string
+	counts  PRCounts
+	icon    IconType
+	failing bool
+}
+
+// label renders the change compactly, e.g. "14:02 goose(2/0)".
+func (c trayChange) label() string {
+	return fmt.Sprintf("%s %s(%d/%d)", c.at.Format("15:04"), c.icon, c.counts.IncomingBlocked, c.counts.OutgoingBlocked)
+}
+
+// sameState reports whether c and o look the same in the tray.
+func (c trayChange) sameState(o trayChange) bool {
+	return c.icon == o.icon && c.title == o.title && c.failing == o.failing &&
+		c.counts.IncomingBlocked == o.counts.IncomingBlocked && c.counts.OutgoingBlocked == o.counts.OutgoingBlocked
+}
+
+// trayHistory is a bounded history of tray icon changes, oldest first. Its zero value
+// is ready to use.
+type trayHistory struct {
+	changes []trayChange
+	mu      sync.Mutex
+}
+
+// record notes what the tray shows now, unless it already showed the same, and reports
+// whether that was a change.
+func (h *trayHistory) record(c trayChange) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n := len(h.changes); n > 0 && h.changes[n-1].sameState(c) {
+		return false
+	}
+	h.changes = append(h.changes, c)
+	if len(h.changes) > maxTrayChanges {
+		h.changes = h.changes[len(h.changes)-maxTrayChanges:]
+	}
+	return true
+}
+
+// snapshot returns the recorded changes, oldest first.
+func (h *trayHistory) snapshot() []trayChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]trayChange, len(h.changes))
+	copy(out, h.changes)
+	return out
+}
+
+// recordTrayChange notes a presentation, and the counts it was made from, in the tray
+// history.
+func (app *App) recordTrayChange(at time.Time, p trayPresentation, counts PRCounts, failing bool) {
+	app.trayHistory.record(trayChange{at: at, title: p.title, counts: counts, icon: p.icon, failing: failing})
+}
+
+// trayChangeTitles returns the Debug submenu lines for the latest tray icon changes,
+// newest first, e.g. "14:02 goose(2/0) → 14:07 smile(0/0)", or nil before the first.
+func (app *App) trayChangeTitles() []string {
+	changes := app.trayHistory.snapshot()
+	if len(changes) < 2 {
+		return nil
+	}
+	var titles []string
+	for i := len(changes) - 1; i > 0 && len(titles) < shownTrayChanges; i-- {
+		titles = append(titles, changes[i-1].label()+" → "+changes[i].label())
+	}
+	return titles
+}
+
+// debugMenuTitles returns the Debug submenu lines: the effective update interval in
+// adaptive mode, then the latest tray icon changes.
+func (app *App) debugMenuTitles() []string {
+	var titles []string
+	if interval := app.updateIntervalTitle(); interval != "" {
+		titles = append(titles, interval)
+	}
+	return append(titles, app.trayChangeTitles()...)
+}
+
+// addDebugMenu adds the Debug submenu listing the effective update interval and the
+// latest tray icon changes.
+func (app *App) addDebugMenu() {
+	titles := app.debugMenuTitles()
+	if len(titles) == 0 {
+		return
+	}
+	debugMenu := app.systrayInterface.AddMenuItem(msg("debug.menu"), msg("debug.menu.tooltip"))
+	for _, title := range titles {
+		debugMenu.AddSubMenuItem(title, "").Disable()
+	}
+}
+
+// writeTrayChanges adds the tray icon changes to the diagnostic report, newest first.
+func writeTrayChanges(b *strings.Builder, changes []trayChange) {
+	fmt.Fprintf(b, "\ntray icon changes (%d):\n", len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		fmt.Fprintf(b, "  %s  %s title=%q incoming=%d/%d outgoing=%d/%d failing=%t\n",
+			c.at.Format(time.RFC3339), c.icon, c.title,
+			c.counts.IncomingBlocked, c.counts.IncomingTotal, c.counts.OutgoingBlocked, c.counts.OutgoingTotal, c.failing)
+	}
+}
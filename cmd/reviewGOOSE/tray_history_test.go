@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrayHistorySkipsRepeats(t *testing.T) {
+	var h trayHistory
+	at := time.Date(2026, 3, 2, 14, 2, 0, 0, time.UTC)
+	goose := trayChange{at: at, icon: IconGoose, title: "2", counts: PRCounts{IncomingTotal: 4, IncomingBlocked: 2}}
+	if !h.record(goose) {
+		t.Fatal("first state not recorded")
+	}
+	repeat := goose
+	repeat.at = at.Add(time.Minute)
+	repeat.counts.IncomingTotal = 5 // Unblocked PRs don't change what the tray shows
+	if h.record(repeat) {
+		t.Error("identical tray state recorded as a change")
+	}
+	smile := trayChange{at: at.Add(5 * time.Minute), icon: IconSmiling}
+	if !h.record(smile) {
+		t.Error("icon change not recorded")
+	}
+	failing := smile
+	failing.failing = true
+	if !h.record(failing) {
+		t.Error("failure state not recorded")
+	}
+
+	changes := h.snapshot()
+	if len(changes) != 3 || !changes[0].at.Equal(at) || changes[1].icon != IconSmiling {
+		t.Errorf("history = %+v, want goose, smile, failing", changes)
+	}
+}
+
+func TestTrayHistoryBounded(t *testing.T) {
+	var h trayHistory
+	start := time.Now()
+	for i := range maxTrayChanges * 3 {
+		h.record(trayChange{at: start.Add(time.Duration(i) * time.Minute), counts: PRCounts{IncomingBlocked: i}})
+	}
+	changes := h.snapshot()
+	if len(changes) != maxTrayChanges {
+		t.Fatalf("history holds %d changes, want %d", len(changes), maxTrayChanges)
+	}
+	if first, last := changes[0].counts.IncomingBlocked, changes[len(changes)-1].counts.IncomingBlocked; first != maxTrayChanges*2 || last != maxTrayChanges*3-1 {
+		t.Errorf("history spans %d..%d, want the newest %d", first, last, maxTrayChanges)
+	}
+}
+
+func TestTrayChangeTitles(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	if titles := app.trayChangeTitles(); titles != nil {
+		t.Errorf("titles before any change = %q, want none", titles)
+	}
+
+	start := time.Date(2026, 3, 2, 14, 2, 0, 0, time.Local)
+	for i := range 8 {
+		icon := IconGoose
+		blocked := 2
+		if i%2 == 1 {
+			icon, blocked = IconSmiling, 0
+		}
+		app.trayHistory.record(trayChange{at: start.Add(time.Duration(i*5) * time.Minute), icon: icon, counts: PRCounts{IncomingBlocked: blocked}})
+	}
+
+	titles := app.trayChangeTitles()
+	if len(titles) != shownTrayChanges {
+		t.Fatalf("titles = %q, want %d", titles, shownTrayChanges)
+	}
+	if want := "14:32 goose(2/0) → 14:37 smile(0/0)"; titles[0] != want {
+		t.Errorf("newest title = %q, want %q", titles[0], want)
+	}
+}
+
+func TestPresentTrayStateRecordsChanges(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.presentTrayState()
+	app.incoming = []PR{pinnablePR(1, true)}
+	app.presentTrayState()
+	app.presentTrayState()
+
+	changes := app.trayHistory.snapshot()
+	if len(changes) != 2 || changes[0].icon != IconSmiling || changes[1].icon != IconGoose || changes[1].counts.IncomingBlocked != 1 {
+		t.Fatalf("history = %+v, want smile then goose(1/0)", changes)
+	}
+	report := app.diagnosticReport(time.Now())
+	if !strings.Contains(report, "tray icon changes (2):") || !strings.Contains(report, "goose title=") {
+		t.Errorf("diagnostic report lacks the tray icon changes:\n%s", report)
+	}
+}
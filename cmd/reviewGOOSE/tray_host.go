@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// trayHostSource follows the desktop's tray host, the StatusNotifierWatcher that shows
+// tray items. When that process restarts (plasmashell crashing, waybar reloading) it
+// forgets every item, and the systray library never registers again on its own.
+type trayHostSource interface {
+	// subscribe delivers false when the host goes away and true when one takes its
+	// place, until ctx is done, then closes the channel.
+	subscribe(ctx context.Context) (<-chan bool, error)
+	// register announces the tray item to the current host.
+	register(ctx context.Context) error
+}
+
+// trayHostWatcher re-registers the tray item whenever a tray host comes back and has
+// the app redraw it.
+type trayHostWatcher struct {
+	source    trayHostSource
+	onReturn  func(ctx context.Context)
+	health    *healthMonitor // May be nil
+	lostAt    time.Time      // When the host went away; zero while it's up
+	startedAt time.Time
+}
+
+func newTrayHostWatcher(source trayHostSource, health *healthMonitor, onReturn func(ctx context.Context)) *trayHostWatcher {
+	return &trayHostWatcher{source: source, health: health, onReturn: onReturn, startedAt: time.Now()}
+}
+
+// run handles host changes until ctx is done, or returns right away if they can't be
+// watched.
+func (w *trayHostWatcher) run(ctx context.Context) {
+	changes, err := w.source.subscribe(ctx)
+	if err != nil {
+		slog.Warn("[TRAY] Can't watch for tray host restarts; the icon won't come back on its own", "error", err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case present, ok := <-changes:
+			if !ok {
+				return
+			}
+			if present {
+				w.hostReturned(ctx)
+			} else {
+				w.hostLost()
+			}
+		}
+	}
+}
+
+func (w *trayHostWatcher) hostLost() {
+	if !w.lostAt.IsZero() {
+		return
+	}
+	w.lostAt = time.Now()
+	slog.Warn("[TRAY] Tray host went away, waiting for it to come back")
+	if w.health != nil {
+		w.health.recordTrayHost(false, 0)
+	}
+}
+
+func (w *trayHostWatcher) hostReturned(ctx context.Context) {
+	if err := w.source.register(ctx); err != nil {
+		slog.Error("[TRAY] Failed to register with the new tray host", "error", err)
+		return
+	}
+	// A host that appears without one going away first was missing since startup
+	since := w.lostAt
+	if since.IsZero() {
+		since = w.startedAt
+	}
+	downtime := time.Since(since)
+	w.lostAt = time.Time{}
+	w.onReturn(ctx)
+	slog.Info("[TRAY] Recovered tray icon after the tray host restarted", "downtime", downtime.Round(time.Millisecond))
+	if w.health != nil {
+		w.health.recordTrayHost(true, downtime)
+	}
+}
+
+// restoreTray redraws the icon, title, and menu for a tray host that has just
+// registered the item.
+func (app *App) restoreTray(ctx context.Context) {
+	// The rebuild presents the tray state
+	app.rebuildMenu(ctx)
+}
@@ -0,0 +1,114 @@
+//go:build linux
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	trayWatcherName = "org.kde.StatusNotifierWatcher"
+	trayWatcherPath = "/StatusNotifierWatcher"
+)
+
+// sniTrayHost follows the StatusNotifierWatcher through the bus's NameOwnerChanged signal.
+type sniTrayHost struct {
+	conn *dbus.Conn
+}
+
+func newTrayHostSource() trayHostSource {
+	return &sniTrayHost{}
+}
+
+func (h *sniTrayHost) subscribe(ctx context.Context) (<-chan bool, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("connect to D-Bus session bus: %w", err)
+	}
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchSender("org.freedesktop.DBus"),
+		dbus.WithMatchInterface("org.freedesktop.DBus"),
+		dbus.WithMatchMember("NameOwnerChanged"),
+		dbus.WithMatchArg(0, trayWatcherName),
+	); err != nil {
+		closeTrayHostConn(conn)
+		return nil, fmt.Errorf("watch %s: %w", trayWatcherName, err)
+	}
+	h.conn = conn
+
+	signals := make(chan *dbus.Signal, 8)
+	conn.Signal(signals)
+	changes := make(chan bool)
+	go func() {
+		defer close(changes)
+		defer closeTrayHostConn(conn)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig, ok := <-signals:
+				if !ok {
+					return
+				}
+				for _, present := range watcherOwnerChanges(sig) {
+					select {
+					case changes <- present:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// register asks the watcher to show the item the systray library exports. The library
+// owns the well-known name below on its own connection, so the item is registered by
+// name rather than by this connection's path.
+func (h *sniTrayHost) register(ctx context.Context) error {
+	if h.conn == nil {
+		return errors.New("not subscribed")
+	}
+	item := fmt.Sprintf("org.kde.StatusNotifierItem-%d-1", os.Getpid())
+	call := h.conn.Object(trayWatcherName, trayWatcherPath).
+		CallWithContext(ctx, trayWatcherName+".RegisterStatusNotifierItem", 0, item)
+	if call.Err != nil {
+		return fmt.Errorf("register %s: %w", item, call.Err)
+	}
+	return nil
+}
+
+// watcherOwnerChanges turns a NameOwnerChanged signal for the watcher into host changes:
+// false when the owner left, true when a new one arrived. A direct handover is both.
+func watcherOwnerChanges(sig *dbus.Signal) []bool {
+	if sig == nil || len(sig.Body) < 3 {
+		return nil
+	}
+	name, _ := sig.Body[0].(string)
+	oldOwner, _ := sig.Body[1].(string)
+	newOwner, _ := sig.Body[2].(string)
+	if name != trayWatcherName {
+		return nil
+	}
+	var changes []bool
+	if oldOwner != "" {
+		changes = append(changes, false)
+	}
+	if newOwner != "" {
+		changes = append(changes, true)
+	}
+	return changes
+}
+
+func closeTrayHostConn(conn *dbus.Conn) {
+	if err := conn.Close(); err != nil {
+		slog.Debug("[TRAY] Failed to close D-Bus connection", "error", err)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build linux
+
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestWatcherOwnerChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		body []any
+		want []bool
+	}{
+		{name: "host exits", body: []any{trayWatcherName, ":1.42", ""}, want: []bool{false}},
+		{name: "host starts", body: []any{trayWatcherName, "", ":1.97"}, want: []bool{true}},
+		{name: "handover", body: []any{trayWatcherName, ":1.42", ":1.97"}, want: []bool{false, true}},
+		{name: "other name", body: []any{"org.freedesktop.Notifications", ":1.42", ""}},
+		{name: "short body", body: []any{trayWatcherName}},
+	}
+	for _, tt := range tests {
+		if got := watcherOwnerChanges(&dbus.Signal{Body: tt.body}); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: watcherOwnerChanges() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// newTrayHostSource returns nil: tray host restarts are only recovered from on Linux.
+func newTrayHostSource() trayHostSource {
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTrayHost is a trayHostSource fed by the test.
+type fakeTrayHost struct {
+	registerErr error
+	changes     chan bool
+	registered  int
+	mu          sync.Mutex
+}
+
+func (f *fakeTrayHost) subscribe(context.Context) (<-chan bool, error) {
+	if f.changes == nil {
+		return nil, errors.New("no session bus")
+	}
+	return f.changes, nil
+}
+
+func (f *fakeTrayHost) register(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.registered++
+	return f.registerErr
+}
+
+func (f *fakeTrayHost) setRegisterErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.registerErr = err
+}
+
+func TestTrayHostRestartRestoresTray(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	app.healthMonitor = newHealthMonitor()
+	mock := &MockSystray{}
+	app.systrayInterface = mock
+	app.incoming = []PR{{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", NeedsReview: true, UpdatedAt: time.Now()}}
+
+	host := &fakeTrayHost{changes: make(chan bool)}
+	restored := make(chan struct{}, 4)
+	watcher := newTrayHostWatcher(host, app.healthMonitor, func(ctx context.Context) {
+		app.restoreTray(ctx)
+		restored <- struct{}{}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watcher.run(ctx)
+		close(done)
+	}()
+
+	host.changes <- false
+	host.changes <- false // Repeated loss is one outage
+	host.setRegisterErr(errors.New("watcher not ready"))
+	host.changes <- true
+	host.setRegisterErr(nil)
+	host.changes <- true
+	select {
+	case <-restored:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tray not restored after the host came back")
+	}
+	cancel()
+	<-done
+
+	if len(restored) != 0 {
+		t.Errorf("restored %d extra times, want once: a failed registration mustn't redraw", len(restored))
+	}
+	if host.registered != 2 {
+		t.Errorf("registered %d times, want the failed attempt and the retry", host.registered)
+	}
+	m := app.healthMonitor.metrics()
+	if m["tray_host_lost"] != false || m["tray_recovered"] != int64(1) {
+		t.Errorf("health tray_host_lost=%v tray_recovered=%v, want the host back after one recovery", m["tray_host_lost"], m["tray_recovered"])
+	}
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if mock.iconSets == 0 {
+		t.Error("icon not redrawn after recovery")
+	}
+	if len(mock.menuItems) == 0 {
+		t.Error("menu not rebuilt after recovery")
+	}
+}
+
+func TestTrayHostLossRecordedInHealth(t *testing.T) {
+	health := newHealthMonitor()
+	host := &fakeTrayHost{changes: make(chan bool)}
+	watcher := newTrayHostWatcher(host, health, func(context.Context) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watcher.run(ctx)
+		close(done)
+	}()
+	host.changes <- false
+	cancel()
+	<-done
+	if health.metrics()["tray_host_lost"] != true {
+		t.Error("health doesn't report the missing tray host")
+	}
+}
+
+func TestTrayHostWatcherWithoutBus(t *testing.T) {
+	watcher := newTrayHostWatcher(&fakeTrayHost{}, nil, func(context.Context) {
+		t.Error("restored without a host change")
+	})
+	watcher.run(context.Background()) // Returns instead of blocking
+}
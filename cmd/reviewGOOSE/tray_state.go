@@ -0,0 +1,209 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The tray icon, title, and tooltip are only ever set together, by presentTrayState,
+// from one read of the auth and failure state, and a tray flash standing in for a
+// notification is drawn over it there. Anything that changes that state or the
+// counts presents again afterwards, so a settings toggle during a failed fetch can't
+// leave a warning icon under a happy tooltip, or the reverse.
+
+// trayPresentation is what the tray shows.
+type trayPresentation struct {
+	tooltip string
+	title   string
+	counts  PRCounts
+	icon    IconType
+}
+
+// trayHealth is the auth and fetch state the tray reflects, read under one lock.
+type trayHealth struct {
+	lastSuccess time.Time
+	fetchErr    error
+	incident    *githubIncident
+	authError   string
+	targetUser  string
+	failures    int
+	crashed     bool
+}
+
+// readTrayHealth reads the auth and fetch state.
+func (app *App) readTrayHealth() trayHealth {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return trayHealth{
+		lastSuccess: app.lastSuccessfulFetch,
+		fetchErr:    app.lastFetchErr,
+		incident:    app.githubIncident,
+		authError:   app.authError,
+		targetUser:  app.targetUser,
+		failures:    app.consecutiveFailures,
+		crashed:     app.crashed,
+	}
+}
+
+// recordFetchFailure notes a failed update, returning the consecutive failure count.
+func (app *App) recordFetchFailure(err error) int {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.consecutiveFailures++
+	app.lastFetchError = err.Error()
+	app.lastFetchErr = err
+	app.recentErrors = appendRecentError(app.recentErrors, time.Now(), err)
+	return app.consecutiveFailures
+}
+
+// recordFetchSuccess notes a successful update, returning how many failures it ended.
+func (app *App) recordFetchSuccess() int {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	previous := app.consecutiveFailures
+	app.lastSuccessfulFetch = time.Now()
+	app.consecutiveFailures = 0
+	app.lastFetchError = ""
+	app.lastFetchErr = nil
+	app.githubIncident = nil
+	return previous
+}
+
+// fetchErrorHint is the actionable line added to the tooltip for a failed update.
+func fetchErrorHint(err error) string {
+	if err == nil {
+		return ""
+	}
+	errMsg := err.Error()
+	switch {
+	case errors.Is(err, errUpdateTimedOut):
+		return "\n" + msg("tray.hint.timed_out")
+	case asSearchUnavailable(err) != nil:
+		return "\n" + msg("tray.hint.search_unavailable")
+	case strings.Contains(errMsg, "rate limited"):
+		return "\n" + msg("tray.hint.rate_limited")
+	case strings.Contains(errMsg, "authentication"):
+		return "\n" + msg("tray.hint.auth")
+	case strings.Contains(errMsg, "network"):
+		return "\n" + msg("tray.hint.network")
+	default:
+		return ""
+	}
+}
+
+// failureTray is the tray for an auth error, a crashed update loop, or failing updates.
+// ok is false when none applies.
+func failureTray(h trayHealth, now time.Time) (p trayPresentation, ok bool) {
+	switch {
+	case h.authError != "":
+		return trayPresentation{icon: IconLock, tooltip: msg("tray.tooltip.auth_error")}, true
+	case h.crashed:
+		return trayPresentation{icon: IconWarning, tooltip: msg("tray.tooltip.critical")}, true
+	case h.failures == 0:
+		return trayPresentation{}, false
+	}
+
+	// Progressive degradation based on failure count
+	var tooltip string
+	switch {
+	case h.incident != nil:
+		tooltip = msg("tray.tooltip.github_outage", h.incident.label())
+	case h.failures <= minorFailureThreshold:
+		tooltip = msg("tray.tooltip.failures", h.failures)
+	default:
+		tooltip = msg("tray.tooltip.connection_failures")
+	}
+	timeSinceSuccess := msg("tray.tooltip.never")
+	if !h.lastSuccess.IsZero() {
+		timeSinceSuccess = now.Sub(h.lastSuccess).Round(time.Minute).String()
+	}
+	userInfo := ""
+	if h.targetUser != "" {
+		userInfo = fmt.Sprintf(" - @%s", h.targetUser)
+	}
+	tooltip = msg("tray.tooltip.last_success", tooltip, userInfo, timeSinceSuccess) + fetchErrorHint(h.fetchErr)
+	return trayPresentation{icon: IconWarning, tooltip: tooltip}, true
+}
+
+// countsTray is the tray for the view's counts.
+func (app *App) countsTray(view *prView, counts PRCounts) trayPresentation {
+	countRepos := app.readSetting(&app.countRepos)
+	p := trayPresentation{counts: counts}
+
+	// On macOS, show counts with the icon
+	// On all other platforms (Linux, Windows, FreeBSD, etc), just show the icon
+	if runtime.GOOS == "darwin" {
+		p.title = trayTitle(counts, countRepos)
+	}
+	switch {
+	case counts.IncomingBlocked == 0 && counts.OutgoingBlocked == 0:
+		p.icon = IconSmiling
+	case counts.IncomingBlocked > 0 && counts.OutgoingBlocked > 0:
+		p.icon = IconBoth
+	case counts.IncomingBlocked > 0:
+		p.icon = IconGoose
+	case !slices.ContainsFunc(view.outgoing, func(pr PR) bool { return pr.IsBlocked && pr.ActionKind.outgoingIcon() != IconCockroach }):
+		// All outgoing blocked PRs are fix_tests only
+		p.icon = IconCockroach
+	default:
+		p.icon = IconPopper
+	}
+
+	p.tooltip = app.baseTooltip()
+	// The title counts repos, so keep the raw PR counts one hover away
+	if countRepos && (counts.IncomingBlocked > 0 || counts.OutgoingBlocked > 0) {
+		p.tooltip = msg("tray.tooltip.blocked", p.tooltip, counts.IncomingBlocked, counts.OutgoingBlocked)
+	}
+	if partial := app.partialFetchTitle(); partial != "" {
+		p.tooltip += "\n" + partial
+	}
+	return p
+}
+
+// presentTrayState sets the tray icon, title, and tooltip from the current state.
+func (app *App) presentTrayState() {
+	app.presentTrayStateFrom(app.snapshotPRs())
+}
+
+// presentTrayStateFrom sets the tray icon, title, and tooltip from the auth and failure
+// state, or when healthy from view's counts. Presentations are serialized, so the three
+// always come from the same state.
+func (app *App) presentTrayStateFrom(view *prView) {
+	app.trayPresent.Lock()
+	defer app.trayPresent.Unlock()
+
+	counts := view.counts()
+	if app.healthMonitor != nil {
+		app.healthMonitor.recordBlocked(counts.IncomingBlocked, counts.OutgoingBlocked)
+	}
+	now := time.Now()
+	p, failing := failureTray(app.readTrayHealth(), now)
+	if !failing {
+		p = app.countsTray(view, counts)
+		app.updateDockBadge(counts)
+	}
+	app.recordTrayChange(now, p, counts, failing)
+
+	slog.Info("[TRAY] Setting title and icon",
+		"os", runtime.GOOS,
+		"title", p.title,
+		"icon", p.icon,
+		"failing", failing,
+		"incoming_total", counts.IncomingTotal,
+		"incoming_blocked", counts.IncomingBlocked,
+		"outgoing_total", counts.OutgoingTotal,
+		"outgoing_blocked", counts.OutgoingBlocked)
+	tooltip := app.tooltipText(p.tooltip)
+	flashText, goose, flashing := app.trayFlash.current()
+	if flashing {
+		tooltip = flashText
+	}
+	app.systrayInterface.SetTitle(p.title)
+	app.setTrayIconFrame(p.icon, p.counts, flashing && goose)
+	app.systrayInterface.SetTooltip(tooltip)
+}
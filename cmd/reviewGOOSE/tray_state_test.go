@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failureTooltip reports whether a recorded tooltip describes failing updates.
+func failureTooltip(tooltip string) bool {
+	return strings.Contains(tooltip, "Last success")
+}
+
+func newTrayStateTestApp(t *testing.T) (*App, *MockSystray, []byte) {
+	t.Helper()
+	app := newFocusTestApp(time.Hour)
+	app.incoming = []PR{{Repository: "acme/widgets", Number: 1, URL: "https://github.com/acme/widgets/pull/1", NeedsReview: true, UpdatedAt: time.Now()}}
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("expected a MockSystray")
+	}
+	warning := themedIcon(IconWarning, PRCounts{}, app.tray.scheme)
+	if len(warning) == 0 || bytes.Equal(warning, themedIcon(IconGoose, PRCounts{IncomingBlocked: 1}, app.tray.scheme)) {
+		t.Fatal("the warning and goose icons should be distinct")
+	}
+	return app, mock, warning
+}
+
+func TestRebuildDuringFailureKeepsWarning(t *testing.T) {
+	app, mock, warning := newTrayStateTestApp(t)
+	app.recordFetchFailure(errors.New("network unreachable"))
+	app.presentTrayState()
+
+	// A settings toggle rebuilds the menu while the fetch is still failing
+	app.rebuildMenu(context.Background())
+	if !bytes.Equal(mock.lastIcon, warning) || !failureTooltip(mock.tooltip) {
+		t.Errorf("after a rebuild while failing: warning icon %v, tooltip %q", bytes.Equal(mock.lastIcon, warning), mock.tooltip)
+	}
+	if !strings.Contains(mock.tooltip, msg("tray.hint.network")) {
+		t.Errorf("tooltip %q lost the error hint", mock.tooltip)
+	}
+
+	app.recordFetchSuccess()
+	app.presentTrayState()
+	if bytes.Equal(mock.lastIcon, warning) || failureTooltip(mock.tooltip) {
+		t.Errorf("after recovering: warning icon %v, tooltip %q", bytes.Equal(mock.lastIcon, warning), mock.tooltip)
+	}
+}
+
+func TestTrayIconAndTooltipAgreeUnderConcurrency(t *testing.T) {
+	app, mock, warning := newTrayStateTestApp(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			app.recordFetchFailure(errors.New("network unreachable"))
+			app.presentTrayState()
+			app.recordFetchSuccess()
+			app.presentTrayState()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			app.presentTrayState() // What a settings toggle does
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 5 {
+			app.rebuildMenu(ctx)
+		}
+	}()
+	wg.Wait()
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	var icon []byte
+	tooltips := 0
+	for _, call := range mock.trayCalls {
+		if call.icon != nil {
+			icon = call.icon
+			continue
+		}
+		tooltips++
+		if bytes.Equal(icon, warning) != failureTooltip(call.tooltip) {
+			t.Fatalf("tooltip %q shown with the warning icon: %v", call.tooltip, bytes.Equal(icon, warning))
+		}
+	}
+	if tooltips < 300 {
+		t.Errorf("recorded %d tooltips, want one per presentation", tooltips)
+	}
+	if bytes.Equal(icon, warning) || failureTooltip(mock.tooltip) {
+		t.Errorf("the last update succeeded, but the tray shows tooltip %q", mock.tooltip)
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// turnBackfillDelay is how long after a full update cycle the backfill pass runs.
+	turnBackfillDelay = 20 * time.Second
+	// turnBackfillAttempts is the per-PR Turn API budget for each backfill pass.
+	turnBackfillAttempts = 2
+)
+
+// turnBackfill tracks PRs whose Turn lookups failed so a targeted pass can retry
+// them shortly after the cycle, rather than leaving them bare until the next one.
+type turnBackfill struct {
+	failures map[string]int // PR URL -> consecutive Turn failures
+	mu       sync.Mutex
+}
+
+func newTurnBackfill() *turnBackfill {
+	return &turnBackfill{failures: make(map[string]int)}
+}
+
+func (b *turnBackfill) recordFailure(url string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[url]++
+}
+
+func (b *turnBackfill) recordSuccess(url string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, url)
+}
+
+// tracked returns the PRs with failure records, for the janitor. It has no times of
+// its own, so the janitor's clocks decide.
+func (b *turnBackfill) tracked() map[string]time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	tracked := make(map[string]time.Time, len(b.failures))
+	for url := range b.failures {
+		tracked[url] = time.Time{}
+	}
+	return tracked
+}
+
+// forget drops the failure records of urls.
+func (b *turnBackfill) forget(urls []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, url := range urls {
+		delete(b.failures, url)
+	}
+}
+
+func (b *turnBackfill) failureCount(url string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures[url]
+}
+
+// backfillTarget is a PR whose Turn data is missing or stale after a failed lookup.
+type backfillTarget struct {
+	updatedAt time.Time
+	url       string
+	repo      string
+	number    int
+	isOwner   bool
+}
+
+// needsTurnBackfill reports whether a PR is missing Turn data, or has data older than its last update.
+func needsTurnBackfill(pr *PR) bool {
+	return pr.TurnDataAppliedAt.IsZero() || pr.TurnDataAppliedAt.Before(pr.UpdatedAt)
+}
+
+// scheduleTurnBackfill queues a backfill pass for the cycle that just completed.
+func (app *App) scheduleTurnBackfill(ctx context.Context) {
+	if app.turnBackfill == nil || app.turnClient == nil {
+		return
+	}
+	app.mu.RLock()
+	generation := app.updateGeneration
+	app.mu.RUnlock()
+
+	time.AfterFunc(turnBackfillDelay, func() {
+		// Refused once shutdown has begun, so a late timer can't outlive the wait
+		app.goTracked("turn backfill", func() { app.runTurnBackfill(ctx, generation) })
+	})
+}
+
+// backfillTargets returns PRs that failed Turn lookups and still lack current data.
+// Caller must hold app.mu.
+func (app *App) backfillTargets() []backfillTarget {
+	var targets []backfillTarget
+	add := func(prs []PR, isOwner bool) {
+		for i := range prs {
+			if !needsTurnBackfill(&prs[i]) || app.turnBackfill.failureCount(prs[i].URL) == 0 {
+				continue
+			}
+			if app.quarantine != nil && app.quarantine.shouldSkip(prs[i].URL) {
+				continue
+			}
+			targets = append(targets, backfillTarget{
+				url:       prs[i].URL,
+				repo:      prs[i].Repository,
+				number:    prs[i].Number,
+				updatedAt: prs[i].UpdatedAt,
+				isOwner:   isOwner,
+			})
+		}
+	}
+	add(app.incoming, false)
+	add(app.outgoing, true)
+	return targets
+}
+
+// runTurnBackfill retries Turn lookups for PRs the given cycle couldn't enrich and
+// patches them in place. It does nothing if a newer full cycle has started.
+func (app *App) runTurnBackfill(ctx context.Context, generation uint64) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	app.mu.RLock()
+	current := app.updateGeneration
+	user, actionUsers := app.lookupUsersLocked()
+	var targets []backfillTarget
+	if current == generation {
+		targets = app.backfillTargets()
+	}
+	app.mu.RUnlock()
+
+	if current != generation {
+		slog.Debug("[BACKFILL] Skipping, a new update cycle has started", "generation", generation, "current", current)
+		return
+	}
+	if len(targets) == 0 || user == "" {
+		return
+	}
+
+	slog.Info("[BACKFILL] Retrying Turn lookups for PRs missing data", "count", len(targets))
+	start := time.Now()
+	backfillCtx, cancel := context.WithTimeout(ctx, app.updateCycleTimeout())
+	defer cancel()
+
+	results := make(chan prResult, len(targets))
+	sem := make(chan struct{}, maxConcurrentTurnAPICalls)
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-backfillCtx.Done():
+				return
+			}
+
+			results <- app.lookupTarget(backfillCtx, target, user, actionUsers, turnBackfillAttempts)
+		})
+	}
+	wg.Wait()
+	close(results)
+
+	var fetched []prResult
+	failed := 0
+	for result := range results {
+		if result.err != nil {
+			failed++
+			if isPermanentPRError(result.err) {
+				// Lost access; the quarantine takes over and retrying here won't help
+				app.turnBackfill.recordSuccess(result.url)
+				app.quarantinePR(result.url, result.err)
+				continue
+			}
+			app.turnBackfill.recordFailure(result.url)
+			slog.Warn("[BACKFILL] Turn lookup still failing", "url", result.url,
+				"failures", app.turnBackfill.failureCount(result.url), "error", result.err)
+			continue
+		}
+		if result.turnData == nil || result.turnData.Analysis.NextAction == nil {
+			continue
+		}
+		app.turnBackfill.recordSuccess(result.url)
+		if app.quarantine != nil {
+			app.quarantine.recordSuccess(result.url)
+		}
+		fetched = append(fetched, result)
+	}
+
+	// Patch PRs in place, unless a full cycle replaced them while we were fetching
+	patched := 0
+	app.mu.Lock()
+	if app.updateGeneration == generation {
+		patched = app.patchTurnResults(fetched, user)
+	}
+	stale := app.updateGeneration != generation
+	app.mu.Unlock()
+
+	if stale {
+		slog.Debug("[BACKFILL] Discarding results, a new update cycle has started", "generation", generation)
+		return
+	}
+	slog.Info("[BACKFILL] Backfill completed",
+		"duration", time.Since(start).Round(time.Millisecond), "patched", patched, "failed", failed)
+	if patched > 0 {
+		app.updateMenu(ctx)
+	}
+}
+
+// lookupUsersLocked returns whose queue Turn lookups are for, and in repo and team mode
+// the users whose actions count as blocked. Caller must hold app.mu.
+func (app *App) lookupUsersLocked() (user string, actionUsers []string) {
+	user = app.targetUser
+	if user == "" {
+		user = app.login()
+	}
+	switch {
+	case len(app.repos) > 0:
+		actionUsers = app.repoModeUsers
+	case len(app.team) > 0:
+		actionUsers = app.team
+	default:
+	}
+	return user, actionUsers
+}
+
+// lookupTarget looks up one PR's Turn data, and the GitHub details its blocked state
+// depends on, outside an update cycle, allowing it attempts Turn API calls.
+func (app *App) lookupTarget(ctx context.Context, target backfillTarget, user string, actionUsers []string, attempts uint) prResult {
+	data, decision, err := app.turnDataAttempts(ctx, target.url, target.updatedAt, attempts)
+	var actionUser string
+	var blocked []string
+	actor := user
+	if err == nil && data != nil {
+		if actionUser = repoModeActor(data.Analysis.NextAction, actionUsers); actionUser != "" {
+			actor = actionUser
+		}
+		blocked = blockedOn(data.Analysis.NextAction, actionUsers)
+	}
+	awaitingApproval := false
+	if err == nil && data != nil && !target.isOwner {
+		if _, hasAction := data.Analysis.NextAction[actor]; !hasAction {
+			awaitingApproval = app.workflowsAwaitingApproval(ctx, target.repo, target.url, data)
+		}
+	}
+	var requestedBy reviewRequest
+	var details pullDetails
+	var question pendingQuestion
+	if err == nil {
+		requestedBy, _ = app.blockedReviewRequester(ctx, data, target.isOwner, target.repo, target.number, target.url, target.updatedAt, actor)
+		details = app.blockedPullDetails(ctx, data, target.repo, target.number, target.url, target.updatedAt, actor)
+		question = app.blockedQuestion(ctx, data, target.isOwner, target.repo, target.number, target.url, target.updatedAt, actor)
+	}
+	return prResult{
+		url:              target.url,
+		turnData:         data,
+		err:              err,
+		isOwner:          target.isOwner,
+		decision:         decision,
+		actionUser:       actionUser,
+		blockedOn:        blocked,
+		requestedBy:      requestedBy,
+		pullDetails:      details,
+		question:         question,
+		awaitingApproval: awaitingApproval,
+	}
+}
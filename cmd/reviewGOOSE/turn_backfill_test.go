@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+// newFlakyTurnServer returns a Turn server that fails the first failFirst calls and succeeds afterwards.
+func newFlakyTurnServer(t *testing.T, failFirst int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failFirst {
+			// 408 isn't retried inside turnclient, so each failure is one Turn call
+			http.Error(w, "timeout", http.StatusRequestTimeout)
+			return
+		}
+		resp := map[string]any{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"pull_request": map[string]any{
+				"state":         "open",
+				"test_state":    "passing",
+				"check_summary": map[string]any{},
+			},
+			"analysis": map[string]any{
+				"workflow_state": "PUBLISHED_WAITING_FOR_REVIEW",
+				"next_action":    map[string]any{"reviewer": map[string]any{"kind": "review", "reason": "needs review", "critical": true}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Failed to encode Turn response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newBackfillTestApp(t *testing.T, serverURL string) *App {
+	t.Helper()
+	turnClient, err := turn.NewClient(serverURL)
+	if err != nil {
+		t.Fatalf("Failed to create turn client: %v", err)
+	}
+	turnClient.SetAuthToken("test-token")
+
+	app := newFocusTestApp(time.Hour)
+	app.turnClient = turnClient
+	app.turnBackfill = newTurnBackfill()
+	app.currentUser = &github.User{Login: github.String("reviewer")}
+	app.cacheDir = t.TempDir()
+	app.noCache = true
+	app.updateGeneration = 1
+	app.incoming = []PR{
+		{Repository: "org/repo", Number: 1, URL: "https://github.com/org/repo/pull/1", UpdatedAt: time.Now()},
+		// Already enriched this cycle; must not be refetched
+		{
+			Repository: "org/repo", Number: 2, URL: "https://github.com/org/repo/pull/2",
+			UpdatedAt: time.Now().Add(-time.Hour), TurnDataAppliedAt: time.Now(),
+		},
+	}
+	app.turnBackfill.recordFailure("https://github.com/org/repo/pull/1")
+	return app
+}
+
+func TestTurnBackfillPatchesPRAfterRetry(t *testing.T) {
+	server, calls := newFlakyTurnServer(t, 1)
+	app := newBackfillTestApp(t, server.URL)
+
+	app.runTurnBackfill(t.Context(), 1)
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Turn called %d times, want 2 (one failure, one success)", got)
+	}
+	pr := app.incoming[0]
+	if !pr.NeedsReview || !pr.IsBlocked || pr.ActionKind != "review" || pr.TurnDataAppliedAt.IsZero() {
+		t.Errorf("PR not patched in place: %+v", pr)
+	}
+	if got := app.turnBackfill.failureCount(pr.URL); got != 0 {
+		t.Errorf("failure count = %d after success, want 0", got)
+	}
+	if len(app.lastMenuTitles) == 0 {
+		t.Error("expected menu update after a successful backfill")
+	}
+}
+
+func TestTurnBackfillRespectsBudget(t *testing.T) {
+	server, calls := newFlakyTurnServer(t, 100)
+	app := newBackfillTestApp(t, server.URL)
+
+	app.runTurnBackfill(t.Context(), 1)
+
+	if got := calls.Load(); got != turnBackfillAttempts {
+		t.Errorf("Turn called %d times, want budget of %d", got, turnBackfillAttempts)
+	}
+	if app.incoming[0].NeedsReview || !app.incoming[0].TurnDataAppliedAt.IsZero() {
+		t.Error("PR patched despite every attempt failing")
+	}
+	if got := app.turnBackfill.failureCount(app.incoming[0].URL); got != 2 {
+		t.Errorf("failure count = %d, want 2 (cycle plus backfill)", got)
+	}
+}
+
+func TestTurnBackfillSkipsWhenNewCycleStarted(t *testing.T) {
+	server, calls := newFlakyTurnServer(t, 0)
+	app := newBackfillTestApp(t, server.URL)
+	app.updateGeneration = 2
+
+	app.runTurnBackfill(t.Context(), 1)
+
+	if got := calls.Load(); got != 0 {
+		t.Errorf("Turn called %d times for a superseded cycle, want 0", got)
+	}
+	if app.incoming[0].NeedsReview {
+		t.Error("superseded backfill patched PR")
+	}
+}
+
+func TestNeedsTurnBackfill(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		pr   PR
+		want bool
+	}{
+		{name: "missing data", pr: PR{UpdatedAt: now}, want: true},
+		{name: "data older than update", pr: PR{UpdatedAt: now, TurnDataAppliedAt: now.Add(-time.Minute)}, want: true},
+		{name: "current data", pr: PR{UpdatedAt: now.Add(-time.Minute), TurnDataAppliedAt: now}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsTurnBackfill(&tt.pr); got != tt.want {
+				t.Errorf("needsTurnBackfill() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
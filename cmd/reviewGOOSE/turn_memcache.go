@@ -0,0 +1,105 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// turnMemoryEntries bounds the in-memory Turn cache: room for every PR a cycle processes.
+const turnMemoryEntries = maxPRsToProcess
+
+// turnMemoryEntry is a Turn response for one version of a PR.
+type turnMemoryEntry struct {
+	updatedAt time.Time
+	cachedAt  time.Time // When the response was fetched, so the disk cache's TTL still applies
+	data      *turn.CheckResponse
+	url       string
+}
+
+// turnMemory is an LRU of Turn responses consulted before the disk cache, so unchanged
+// PRs don't re-read their cache files every cycle. It holds one version per PR URL.
+type turnMemory struct {
+	entries map[string]*list.Element // By PR URL
+	order   *list.List               // Front is most recently used
+	max     int
+	mu      sync.Mutex
+}
+
+func newTurnMemory(maxEntries int) *turnMemory {
+	return &turnMemory{entries: make(map[string]*list.Element), order: list.New(), max: maxEntries}
+}
+
+// get returns the cached response for url at updatedAt while it is within cacheTTL.
+// An entry for an older version of the PR is dropped.
+func (c *turnMemory) get(url string, updatedAt time.Time) (*turn.CheckResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	e, ok := el.Value.(*turnMemoryEntry)
+	if !ok || !e.updatedAt.Equal(updatedAt) || time.Since(e.cachedAt) >= cacheTTL {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.data, true
+}
+
+// put caches data for url at updatedAt, replacing any other version of the PR.
+// Responses with incomplete tests are left to the disk cache, which knows when to
+// bypass them.
+func (c *turnMemory) put(url string, updatedAt, cachedAt time.Time, data *turn.CheckResponse) {
+	if data == nil {
+		return
+	}
+	switch data.PullRequest.TestState {
+	case "running", "queued", "pending":
+		c.invalidate(url)
+		return
+	default:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &turnMemoryEntry{url: url, updatedAt: updatedAt, cachedAt: cachedAt, data: data}
+	if el, ok := c.entries[url]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[url] = c.order.PushFront(e)
+	for c.order.Len() > c.max {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops the cached response for url, e.g. after a real-time event for it.
+func (c *turnMemory) invalidate(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[url]; ok {
+		c.remove(el)
+	}
+}
+
+// remove drops el. The caller holds c.mu.
+func (c *turnMemory) remove(el *list.Element) {
+	if e, ok := el.Value.(*turnMemoryEntry); ok {
+		delete(c.entries, e.url)
+	}
+	c.order.Remove(el)
+}
+
+// len returns the number of cached responses.
+func (c *turnMemory) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// countingFS is a prcache.FS that counts reads and writes on top of the os package.
+type countingFS struct {
+	reads  atomic.Int32
+	writes atomic.Int32
+}
+
+func (f *countingFS) ReadFile(name string) ([]byte, error) {
+	f.reads.Add(1)
+	return os.ReadFile(name)
+}
+
+func (f *countingFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f.writes.Add(1)
+	return os.WriteFile(name, data, perm)
+}
+
+func (*countingFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (*countingFS) Remove(name string) error { return os.Remove(name) }
+
+func newTurnMemoryTestApp(t *testing.T) (*App, *countingFS, *atomic.Int32) {
+	t.Helper()
+	server, calls := newFlakyTurnServer(t, 0)
+	app := newBackfillTestApp(t, server.URL)
+	app.noCache = false
+	app.turnMemory = newTurnMemory(turnMemoryEntries)
+	fsys := &countingFS{}
+	app.cacheFS = fsys
+	return app, fsys, calls
+}
+
+func TestTurnMemoryWarmHitSkipsDisk(t *testing.T) {
+	ctx := context.Background()
+	app, fsys, calls := newTurnMemoryTestApp(t)
+	const url = "https://github.com/acme/widgets/pull/7"
+	updatedAt := time.Now().Add(-10 * time.Minute)
+
+	if _, decision, err := app.turnData(ctx, url, updatedAt); err != nil || decision != cacheBypassFreshUpdate {
+		t.Fatalf("first lookup: decision=%q err=%v, want a fresh fetch", decision, err)
+	}
+	reads, writes := fsys.reads.Load(), fsys.writes.Load()
+
+	for range 3 {
+		data, decision, err := app.turnData(ctx, url, updatedAt)
+		if err != nil || data == nil {
+			t.Fatalf("warm lookup failed: %v", err)
+		}
+		if decision != cacheHitMemory || !decision.hit() {
+			t.Errorf("decision = %q, want %q", decision, cacheHitMemory)
+		}
+	}
+	if fsys.reads.Load() != reads || fsys.writes.Load() != writes {
+		t.Errorf("warm in-memory hits touched the disk cache: reads %d->%d, writes %d->%d",
+			reads, fsys.reads.Load(), writes, fsys.writes.Load())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Turn calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestTurnMemoryPopulatedFromDisk(t *testing.T) {
+	ctx := context.Background()
+	app, fsys, calls := newTurnMemoryTestApp(t)
+	const url = "https://github.com/acme/widgets/pull/7"
+	updatedAt := time.Now().Add(-10 * time.Minute)
+	seedTurnCache(t, app, url, updatedAt, "passing", time.Minute)
+
+	if _, decision, _ := app.turnData(ctx, url, updatedAt); decision != cacheHit {
+		t.Fatalf("first lookup decision = %q, want a disk hit", decision)
+	}
+	reads := fsys.reads.Load()
+	if _, decision, _ := app.turnData(ctx, url, updatedAt); decision != cacheHitMemory {
+		t.Errorf("second lookup decision = %q, want %q", decision, cacheHitMemory)
+	}
+	if fsys.reads.Load() != reads || calls.Load() != 0 {
+		t.Errorf("second lookup read the disk (%d reads) or called Turn (%d calls)", fsys.reads.Load()-reads, calls.Load())
+	}
+}
+
+func TestTurnMemoryInvalidation(t *testing.T) {
+	ctx := context.Background()
+	app, fsys, calls := newTurnMemoryTestApp(t)
+	const url = "https://github.com/acme/widgets/pull/7"
+	updatedAt := time.Now().Add(-10 * time.Minute)
+
+	if _, _, err := app.turnData(ctx, url, updatedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	// A newer UpdatedAt is a new version of the PR
+	newer := updatedAt.Add(time.Minute)
+	if _, decision, _ := app.turnData(ctx, url, newer); decision.hit() {
+		t.Errorf("decision = %q after UpdatedAt changed, want a fetch", decision)
+	}
+	if _, ok := app.turnMemory.get(url, updatedAt); ok {
+		t.Error("the older version should have been replaced")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Turn calls = %d, want 2", calls.Load())
+	}
+
+	// A sprinkler event drops the entry, so its lookup falls back to the disk cache
+	reads := fsys.reads.Load()
+	(&sprinklerMonitor{app: app}).checkAndNotify(ctx, prEvent{url: url, timestamp: newer})
+	if fsys.reads.Load() == reads {
+		t.Error("sprinkler event was answered from memory; it should invalidate the entry")
+	}
+}
+
+func TestTurnMemoryMismatchedEntry(t *testing.T) {
+	ctx := context.Background()
+	app, _, calls := newTurnMemoryTestApp(t)
+	app.healthMonitor = newHealthMonitor()
+	const url = "https://github.com/acme/widgets/pull/7"
+	updatedAt := time.Now().Add(-10 * time.Minute)
+	stale := &turn.CheckResponse{}
+	stale.PullRequest.UpdatedAt = updatedAt.Add(-24 * time.Hour)
+	stale.PullRequest.TestState = "passing"
+	app.turnMemory.put(url, updatedAt, time.Now(), stale)
+
+	if _, decision, _ := app.turnData(ctx, url, updatedAt); decision != cacheMissMismatch {
+		t.Fatalf("decision = %q, want %q", decision, cacheMissMismatch)
+	}
+	// The refetched response replaces the stale one
+	if _, decision, _ := app.turnData(ctx, url, updatedAt); decision != cacheHitMemory {
+		t.Errorf("decision after refetch = %q, want %q", decision, cacheHitMemory)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Turn calls = %d, want 1", calls.Load())
+	}
+	if got := app.healthMonitor.metrics()["cache_mismatches"]; got != int64(1) {
+		t.Errorf("cache_mismatches = %v, want 1", got)
+	}
+}
+
+func TestTurnMemoryBypassedByNoCache(t *testing.T) {
+	app, fsys, calls := newTurnMemoryTestApp(t)
+	app.noCache = true
+	const url = "https://github.com/acme/widgets/pull/7"
+	updatedAt := time.Now().Add(-10 * time.Minute)
+
+	for range 2 {
+		if _, decision, _ := app.turnData(context.Background(), url, updatedAt); decision != cacheBypassNoCache {
+			t.Errorf("decision = %q, want %q", decision, cacheBypassNoCache)
+		}
+	}
+	if calls.Load() != 2 || app.turnMemory.len() != 0 || fsys.reads.Load() != 0 {
+		t.Errorf("-no-cache: calls=%d entries=%d reads=%d, want 2 Turn calls and no caching",
+			calls.Load(), app.turnMemory.len(), fsys.reads.Load())
+	}
+}
+
+func TestTurnMemoryLRU(t *testing.T) {
+	c := newTurnMemory(2)
+	now := time.Now()
+	data := &turn.CheckResponse{}
+	data.PullRequest.TestState = "passing"
+	url := func(n int) string { return fmt.Sprintf("https://github.com/acme/widgets/pull/%d", n) }
+
+	c.put(url(1), now, now, data)
+	c.put(url(2), now, now, data)
+	c.get(url(1), now) // 1 is now most recently used
+	c.put(url(3), now, now, data)
+	if _, ok := c.get(url(2), now); ok {
+		t.Error("least recently used entry should be evicted")
+	}
+	if _, ok := c.get(url(1), now); !ok {
+		t.Error("recently used entry was evicted")
+	}
+
+	running := &turn.CheckResponse{}
+	running.PullRequest.TestState = "running"
+	c.put(url(1), now, now, running)
+	if _, ok := c.get(url(1), now); ok {
+		t.Error("responses with running tests should be left to the disk cache")
+	}
+	if _, ok := c.get(url(3), now.Add(-time.Hour)); ok {
+		t.Error("lookups for another UpdatedAt should miss")
+	}
+	c.put(url(4), now, now.Add(-cacheTTL), data)
+	if _, ok := c.get(url(4), now); ok {
+		t.Error("entries older than cacheTTL should expire")
+	}
+}
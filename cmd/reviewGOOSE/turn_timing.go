@@ -0,0 +1,67 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// slowTurnCallsReported is how many of a cycle's slowest Turn calls are logged and kept for diagnostics.
+const slowTurnCallsReported = 5
+
+// turnTiming is one PR's Turn lookup within an update cycle.
+type turnTiming struct {
+	url      string
+	decision cacheDecision
+	elapsed  time.Duration
+	failed   bool
+}
+
+// String renders the timing for the summary log line and diagnostic report.
+func (t turnTiming) String() string {
+	s := fmt.Sprintf("%s %s (%s)", t.elapsed.Round(time.Millisecond), t.url, t.decision)
+	if t.failed {
+		s += " failed"
+	}
+	return s
+}
+
+// slowestTurnCalls returns up to n timings, slowest first.
+func slowestTurnCalls(timings []turnTiming, n int) []turnTiming {
+	sorted := slices.Clone(timings)
+	slices.SortStableFunc(sorted, func(a, b turnTiming) int {
+		return cmp.Compare(b.elapsed, a.elapsed)
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// recordTurnTimings logs the cycle's slowest Turn calls at Debug and keeps them for the diagnostic report.
+func (app *App) recordTurnTimings(timings []turnTiming) {
+	if len(timings) == 0 {
+		return
+	}
+	slowest := slowestTurnCalls(timings, slowTurnCallsReported)
+
+	decisions := make(map[cacheDecision]int)
+	for _, t := range timings {
+		decisions[t.decision]++
+	}
+	lines := make([]string, len(slowest))
+	for i, t := range slowest {
+		lines[i] = t.String()
+	}
+	slog.Debug("[TURN] Slowest calls this cycle",
+		"calls", len(timings),
+		"decisions", decisions,
+		"slowest", strings.Join(lines, "; "))
+
+	app.mu.Lock()
+	app.slowTurnCalls = slowest
+	app.mu.Unlock()
+}
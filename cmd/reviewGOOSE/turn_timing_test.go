@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
+)
+
+// seedTurnCache writes a cache entry for url as if it had been cached age ago.
+func seedTurnCache(t *testing.T, app *App, url string, updatedAt time.Time, testState string, age time.Duration) {
+	t.Helper()
+	seedTurnCacheEntry(t, app, url, updatedAt, map[string]any{"state": "open", "test_state": testState}, age)
+}
+
+// seedTurnCacheEntry writes a cache file for url at updatedAt holding pullRequest.
+func seedTurnCacheEntry(t *testing.T, app *App, url string, updatedAt time.Time, pullRequest map[string]any, age time.Duration) {
+	t.Helper()
+	m := prcache.NewManager(app.cacheDir)
+	entry := prcache.Entry[any]{
+		Data: map[string]any{
+			"pull_request": pullRequest,
+			"analysis":     map[string]any{"next_action": map[string]any{}},
+		},
+		CachedAt:  time.Now().Add(-age),
+		UpdatedAt: updatedAt,
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.CachePath(prcache.CacheKey(url, updatedAt)), b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTurnDataCacheDecisions(t *testing.T) {
+	const url = "https://github.com/acme/widgets/pull/7"
+	tests := []struct {
+		name      string
+		noCache   bool
+		testState string        // Seeded entry's test state; empty seeds nothing
+		age       time.Duration // Seeded entry's age
+		want      cacheDecision
+		wantCalls int32
+	}{
+		{name: "hit", testState: "passing", age: time.Minute, want: cacheHit, wantCalls: 0},
+		{name: "expired", testState: "passing", age: cacheTTL + time.Hour, want: cacheMissExpired, wantCalls: 1},
+		{name: "running tests", testState: "running", age: time.Minute, want: cacheMissRunningTests, wantCalls: 1},
+		{name: "no cache flag", noCache: true, testState: "passing", age: time.Minute, want: cacheBypassNoCache, wantCalls: 1},
+		{name: "fresh update", want: cacheBypassFreshUpdate, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newFlakyTurnServer(t, 0)
+			app := newBackfillTestApp(t, server.URL)
+			app.noCache = tt.noCache
+
+			updatedAt := time.Now().Add(-10 * time.Minute)
+			if tt.testState != "" {
+				seedTurnCache(t, app, url, updatedAt, tt.testState, tt.age)
+			}
+
+			data, decision, err := app.turnData(context.Background(), url, updatedAt)
+			if err != nil {
+				t.Fatalf("turnData failed: %v", err)
+			}
+			if data == nil {
+				t.Fatal("turnData returned no data")
+			}
+			if decision != tt.want {
+				t.Errorf("decision = %q, want %q", decision, tt.want)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("Turn calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTurnDataRefetchesMismatchedCache(t *testing.T) {
+	const url = "https://github.com/acme/widgets/pull/7"
+	tests := []struct {
+		name       string
+		drift      time.Duration // Cached updated_at minus GitHub's
+		want       cacheDecision
+		mismatches int64
+	}{
+		{name: "turn ahead", drift: 26 * time.Hour, want: cacheMissMismatch, mismatches: 1},
+		{name: "turn behind", drift: -26 * time.Hour, want: cacheMissMismatch, mismatches: 1},
+		{name: "within tolerance", drift: turnUpdatedAtTolerance / 2, want: cacheHit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newFlakyTurnServer(t, 0)
+			app := newBackfillTestApp(t, server.URL)
+			app.noCache = false
+			app.healthMonitor = newHealthMonitor()
+
+			updatedAt := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+			seedTurnCacheEntry(t, app, url, updatedAt, map[string]any{
+				"state": "open", "test_state": "running", "updated_at": updatedAt.Add(tt.drift).Format(time.RFC3339),
+			}, 2*time.Hour)
+
+			if _, decision, err := app.turnData(context.Background(), url, updatedAt); err != nil || decision != tt.want {
+				t.Fatalf("decision = %q (err %v), want %q", decision, err, tt.want)
+			}
+			wantCalls := int32(0)
+			if tt.want == cacheMissMismatch {
+				wantCalls = 1
+			}
+			if got := calls.Load(); got != wantCalls {
+				t.Errorf("Turn calls = %d, want %d", got, wantCalls)
+			}
+			if got := app.healthMonitor.metrics()["cache_mismatches"]; got != tt.mismatches {
+				t.Errorf("cache_mismatches = %v, want %d", got, tt.mismatches)
+			}
+		})
+	}
+}
+
+func TestSlowestTurnCalls(t *testing.T) {
+	var timings []turnTiming
+	for i, ms := range []int{30, 500, 10, 900, 70, 200, 40} {
+		timings = append(timings, turnTiming{
+			url:      "https://github.com/acme/widgets/pull/" + strconv.Itoa(i),
+			decision: cacheBypassFreshUpdate,
+			elapsed:  time.Duration(ms) * time.Millisecond,
+		})
+	}
+
+	got := slowestTurnCalls(timings, slowTurnCallsReported)
+	want := []time.Duration{900, 500, 200, 70, 40}
+	if len(got) != len(want) {
+		t.Fatalf("slowestTurnCalls returned %d, want %d", len(got), len(want))
+	}
+	for i, ms := range want {
+		if got[i].elapsed != ms*time.Millisecond {
+			t.Errorf("slowest[%d] = %v, want %v", i, got[i].elapsed, ms*time.Millisecond)
+		}
+	}
+	if timings[0].elapsed != 30*time.Millisecond {
+		t.Error("slowestTurnCalls must not reorder its input")
+	}
+}
+
+func TestSlowTurnCallsInDiagnosticReport(t *testing.T) {
+	app := &App{}
+	app.recordTurnTimings([]turnTiming{
+		{url: "https://github.com/acme/widgets/pull/1", decision: cacheHit, elapsed: time.Millisecond},
+		{url: "https://github.com/acme/widgets/pull/2", decision: cacheMissRunningTests, elapsed: 12 * time.Second, failed: true},
+	})
+
+	report := app.diagnosticReport(time.Now())
+	for _, want := range []string{
+		"slowest Turn calls last cycle (2):",
+		"12s https://github.com/acme/widgets/pull/2 (miss-running-tests) failed",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("diagnostic report missing %q:\n%s", want, report)
+		}
+	}
+}
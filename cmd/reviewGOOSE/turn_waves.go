@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+const (
+	// turnPriorityWindow is how recently a PR must have been updated to be enriched in
+	// the first wave of the first load.
+	turnPriorityWindow = 7 * 24 * time.Hour
+	// defaultFirstTurnWaveBudget is how long the first menu waits for the first wave.
+	defaultFirstTurnWaveBudget = 5 * time.Second
+)
+
+// turnWave is the second wave of the first load's Turn lookups: PRs unlikely to be
+// blocked, enriched in the background after the first menu is shown.
+type turnWave struct {
+	leftover   <-chan prResult    // First-wave lookups still running when its budget ran out; nil if none
+	cancel     context.CancelFunc // Stops the leftover lookups
+	user       string
+	issues     []*github.Issue
+	generation uint64 // The update cycle the wave belongs to
+}
+
+// splitTurnWaves splits a first load's Turn lookups into the PRs most likely to matter,
+// those updated within turnPriorityWindow or whose cached Turn data had a next action
+// (hadAction, by URL), and the rest. Order is kept within each wave.
+func splitTurnWaves(issues []*github.Issue, now time.Time, hadAction map[string]bool) (first, second []*github.Issue) {
+	for _, issue := range issues {
+		if now.Sub(issue.GetUpdatedAt().Time) < turnPriorityWindow || hadAction[issue.GetHTMLURL()] {
+			first = append(first, issue)
+		} else {
+			second = append(second, issue)
+		}
+	}
+	return first, second
+}
+
+// cachedActions returns the URLs of PRs whose cached Turn data for their current
+// revision, however old, had a next action for one of users.
+func (app *App) cachedActions(issues []*github.Issue, users []string) map[string]bool {
+	if app.noCache {
+		return nil
+	}
+	cacheManager := app.turnCacheManager()
+	found := make(map[string]bool)
+	for _, issue := range issues {
+		url := issue.GetHTMLURL()
+		updatedAt := issue.GetUpdatedAt().Time
+		path := cacheManager.CachePath(prcache.CacheKey(url, updatedAt))
+		result, err := cacheManager.Get(path, updatedAt, time.Duration(math.MaxInt64), 0, nil)
+		if err != nil || !result.Hit || result.Entry == nil {
+			continue
+		}
+		b, err := json.Marshal(result.Entry.Data)
+		if err != nil {
+			continue
+		}
+		var data turn.CheckResponse
+		if err := json.Unmarshal(b, &data); err != nil {
+			continue
+		}
+		for _, user := range users {
+			if _, ok := data.Analysis.NextAction[user]; ok {
+				found[url] = true
+				break
+			}
+		}
+	}
+	return found
+}
+
+// fetchTurnDataInWaves is fetchTurnDataSync for the first load. Likely-blocked PRs are
+// enriched first, and the first menu waits at most firstTurnWaveBudget for them; the
+// rest are left to the second wave, which startTurnWave runs in the background.
+func (app *App) fetchTurnDataInWaves(ctx context.Context, issues []*github.Issue, user string, incoming *[]PR, outgoing *[]PR) {
+	actors := []string{user}
+	if shared, users := app.sharedQueue(); shared && len(users) > 0 {
+		actors = users
+	}
+	first, second := splitTurnWaves(issues, time.Now(), app.cachedActions(issues, actors))
+	if len(second) == 0 {
+		app.fetchTurnDataSync(ctx, issues, user, incoming, outgoing)
+		return
+	}
+	slog.Info("[TURN] First load, enriching likely-blocked PRs first", "first_wave", len(first), "second_wave", len(second))
+
+	// The lookups outlive this update cycle, so ones still running when the budget runs
+	// out are finished by the second wave rather than abandoned
+	budget := app.firstTurnWaveBudget
+	if budget <= 0 {
+		budget = defaultFirstTurnWaveBudget
+	}
+	turnStart := time.Now()
+	waveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), app.updateCycleTimeout())
+	lookups := app.startTurnLookups(waveCtx, first, user)
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+	results, done := collectTurnResults(lookups, timer.C)
+	applyTurnResults(app.recordTurnResults(results, user, turnStart), user, *incoming, *outgoing)
+	if done {
+		lookups = nil
+	} else {
+		slog.Info("[TURN] First wave over budget, showing the menu without waiting", "budget", budget, "applied", len(results))
+	}
+
+	app.mu.Lock()
+	if app.turnWave != nil {
+		app.turnWave.cancel()
+	}
+	app.turnWave = &turnWave{
+		leftover:   lookups,
+		cancel:     cancel,
+		user:       user,
+		issues:     second,
+		generation: app.updateGeneration,
+	}
+	app.mu.Unlock()
+}
+
+// turnWavePending reports whether a first load's second wave hasn't been applied yet.
+func (app *App) turnWavePending() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.turnWave != nil
+}
+
+// startTurnWave runs the pending second wave, if any, in the background.
+func (app *App) startTurnWave(ctx context.Context) {
+	app.mu.RLock()
+	wave := app.turnWave
+	app.mu.RUnlock()
+	if wave == nil {
+		return
+	}
+	app.goTracked("turn second wave", func() {
+		app.runTurnWave(ctx, wave)
+	})
+}
+
+// runTurnWave finishes the first wave's leftover lookups and enriches the second wave,
+// then patches the PRs in place and processes notifications for them. Results are
+// dropped if a newer update cycle replaced the PRs in the meantime.
+func (app *App) runTurnWave(ctx context.Context, wave *turnWave) {
+	defer wave.cancel()
+	stop := context.AfterFunc(ctx, wave.cancel)
+	defer stop()
+
+	start := time.Now()
+	var results []prResult
+	if wave.leftover != nil {
+		results, _ = collectTurnResults(wave.leftover, nil)
+	}
+	waveCtx, cancel := context.WithTimeout(ctx, app.updateCycleTimeout())
+	defer cancel()
+	more, _ := collectTurnResults(app.startTurnLookups(waveCtx, wave.issues, wave.user), nil)
+	results = append(results, more...)
+	usable := app.recordTurnResults(results, wave.user, start)
+
+	// Patch and notify under updateMutex, like an update cycle, so the two don't interleave
+	app.updateMutex.Lock()
+	defer app.updateMutex.Unlock()
+	patched := 0
+	app.mu.Lock()
+	stale := app.updateGeneration != wave.generation
+	if !stale {
+		patched = app.patchTurnResults(usable, wave.user)
+	}
+	if app.turnWave == wave {
+		app.turnWave = nil
+	}
+	app.mu.Unlock()
+
+	if stale {
+		slog.Debug("[TURN] Discarding second wave, a new update cycle has started", "generation", wave.generation)
+		return
+	}
+	slog.Info("[TURN] Second wave completed",
+		"duration", time.Since(start).Round(time.Millisecond), "prs", len(wave.issues), "patched", patched)
+	if ctx.Err() != nil {
+		return
+	}
+	app.updateMenu(ctx)
+	// Notifications for these PRs only now have their Turn data to go on
+	app.processNotifications(ctx)
+}
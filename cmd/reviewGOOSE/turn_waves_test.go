@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/goose/pkg/prcache"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func waveIssue(number int, updatedAt time.Time) *github.Issue {
+	url := fmt.Sprintf("https://github.com/org/repo/pull/%d", number)
+	return &github.Issue{
+		Number:    &number,
+		HTMLURL:   &url,
+		UpdatedAt: &github.Timestamp{Time: updatedAt},
+	}
+}
+
+func TestSplitTurnWaves(t *testing.T) {
+	now := time.Now()
+	issues := []*github.Issue{
+		waveIssue(1, now.Add(-time.Hour)),
+		waveIssue(2, now.Add(-30*24*time.Hour)),
+		waveIssue(3, now.Add(-6*24*time.Hour)),
+		waveIssue(4, now.Add(-8*24*time.Hour)),
+		waveIssue(5, now.Add(-90*24*time.Hour)),
+	}
+	hadAction := map[string]bool{"https://github.com/org/repo/pull/4": true}
+
+	first, second := splitTurnWaves(issues, now, hadAction)
+	numbers := func(issues []*github.Issue) []int {
+		var n []int
+		for _, issue := range issues {
+			n = append(n, issue.GetNumber())
+		}
+		return n
+	}
+	if got := numbers(first); !slices.Equal(got, []int{1, 3, 4}) {
+		t.Errorf("first wave = %v, want the recently updated PRs and the one with a cached action", got)
+	}
+	if got := numbers(second); !slices.Equal(got, []int{2, 5}) {
+		t.Errorf("second wave = %v, want the rest in order", got)
+	}
+
+	if first, second := splitTurnWaves(issues[:1], now, nil); len(first) != 1 || len(second) != 0 {
+		t.Errorf("a recent PR alone: first=%d second=%d, want no second wave", len(first), len(second))
+	}
+}
+
+func TestCachedActions(t *testing.T) {
+	app := &App{cacheDir: t.TempDir()}
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	blocked, idle, uncached := waveIssue(1, old), waveIssue(2, old), waveIssue(3, old)
+
+	cache := app.turnCacheManager()
+	put := func(issue *github.Issue, next map[string]turn.Action) {
+		data := &turn.CheckResponse{Analysis: turn.Analysis{NextAction: next}}
+		path := cache.CachePath(prcache.CacheKey(issue.GetHTMLURL(), issue.GetUpdatedAt().Time))
+		if err := cache.Put(path, data, issue.GetUpdatedAt().Time); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put(blocked, map[string]turn.Action{"me": {Kind: "review"}})
+	put(idle, map[string]turn.Action{"someone": {Kind: "review"}})
+
+	got := app.cachedActions([]*github.Issue{blocked, idle, uncached}, []string{"me"})
+	if !got[blocked.GetHTMLURL()] || got[idle.GetHTMLURL()] || got[uncached.GetHTMLURL()] {
+		t.Errorf("cachedActions() = %v, want only the PR whose cached data waited on me", got)
+	}
+
+	app.noCache = true
+	if got := app.cachedActions([]*github.Issue{blocked}, []string{"me"}); len(got) != 0 {
+		t.Errorf("cachedActions() with -no-cache = %v, want none", got)
+	}
+}
+
+// newTurnWaveTestApp serves a recently updated PR and a month-old one, both waiting on
+// testuser. Turn lookups for URLs in gates block until the gate is closed.
+func newTurnWaveTestApp(t *testing.T, now time.Time, gates map[string]chan struct{}) *App {
+	t.Helper()
+	search := newFakeGitHub(t, func(*http.Request) fakeSearch {
+		return fakeSearch{prs: []fakePR{
+			{repo: "org/repo", number: 1, updated: now},
+			{repo: "org/repo", number: 2, updated: now.Add(-30 * 24 * time.Hour)},
+		}}
+	})
+	turnClient := newFakeTurn(t, func(ctx context.Context, req turn.CheckRequest) fakeVerdict {
+		if gate, ok := gates[req.URL]; ok {
+			select {
+			case <-gate:
+			case <-ctx.Done():
+			}
+		}
+		return fakeVerdict{next: map[string]any{"testuser": map[string]any{"kind": "review", "critical": true}}}
+	})
+	app := newFetchTestApp(t, search.URL, turnClient, "testuser")
+	app.firstTurnWaveBudget = 200 * time.Millisecond
+	return app
+}
+
+func waitForTurnWave(t *testing.T, app *App) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for app.turnWavePending() {
+		if time.Now().After(deadline) {
+			t.Fatal("second Turn wave never finished")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The wave holds updateMutex until its notifications are processed
+	app.updateMutex.Lock()
+	app.updateMutex.Unlock() //nolint:staticcheck // Waiting for the holder, not guarding anything
+}
+
+func blockedURLs(app *App) []string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	var urls []string
+	for i := range app.incoming {
+		if app.incoming[i].NeedsReview {
+			urls = append(urls, app.incoming[i].URL)
+		}
+	}
+	slices.Sort(urls)
+	return urls
+}
+
+func TestFirstLoadTurnWaves(t *testing.T) {
+	const recent, old = "https://github.com/org/repo/pull/1", "https://github.com/org/repo/pull/2"
+	tests := []struct {
+		name      string
+		gated     string
+		firstMenu []string
+	}{
+		{name: "second wave after the first menu", gated: old, firstMenu: []string{recent}},
+		{name: "first wave over budget", gated: recent, firstMenu: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gate := make(chan struct{})
+			app := newTurnWaveTestApp(t, time.Now(), map[string]chan struct{}{tt.gated: gate})
+			notifier, ok := app.notifier.(*messageNotifier)
+			if !ok {
+				t.Fatal("expected a messageNotifier")
+			}
+
+			start := time.Now()
+			app.updatePRsWithWait(context.Background())
+			if elapsed := time.Since(start); elapsed > 3*time.Second {
+				t.Errorf("first menu took %v, want it not to wait for the gated lookup", elapsed)
+			}
+			if got := blockedURLs(app); !slices.Equal(got, tt.firstMenu) {
+				t.Errorf("blocked in the first menu = %v, want %v", got, tt.firstMenu)
+			}
+			if !app.turnWavePending() {
+				t.Fatal("second wave should be pending after the first menu")
+			}
+			if app.hasPerformedInitialDiscovery {
+				t.Error("initial discovery ended before the second wave was applied")
+			}
+
+			close(gate)
+			waitForTurnWave(t, app)
+			if got := blockedURLs(app); !slices.Equal(got, []string{recent, old}) {
+				t.Errorf("blocked after the second wave = %v, want both", got)
+			}
+			if _, ok := app.stateManager.PRState(old); !ok {
+				t.Error("state manager doesn't track the PR from the second wave")
+			}
+			if !app.hasPerformedInitialDiscovery {
+				t.Error("initial discovery should end once the second wave is applied")
+			}
+			notifier.mu.Lock()
+			notes := slices.Clone(notifier.notes)
+			notifier.mu.Unlock()
+			if len(notes) != 0 {
+				t.Errorf("PRs already blocked at startup notified: %q", notes)
+			}
+		})
+	}
+}
+
+func TestLaterLoadsSkipTurnWaves(t *testing.T) {
+	gate := make(chan struct{})
+	app := newTurnWaveTestApp(t, time.Now(), map[string]chan struct{}{"https://github.com/org/repo/pull/2": gate})
+	app.initialLoadComplete = true
+	close(gate)
+
+	app.updatePRs(context.Background())
+	if app.turnWavePending() {
+		t.Error("only the first load should defer Turn lookups")
+	}
+	if got := blockedURLs(app); len(got) != 2 || !strings.HasSuffix(got[1], "/2") {
+		t.Errorf("blocked = %v, want both PRs enriched in one pass", got)
+	}
+}
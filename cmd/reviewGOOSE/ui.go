@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"maps"
 	"runtime"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,208 +20,86 @@ var _ *systray.MenuItem = nil
 
 // openURL safely opens a URL in the default browser using safebrowse package.
 // The gooseParam parameter specifies what value to use for the ?goose= query parameter.
-// If empty, defaults to "1" for menu clicks.
+// If empty, the URL is opened unchanged.
 func openURL(ctx context.Context, rawURL string, gooseParam string) error {
-	// Set default goose parameter
-	if gooseParam == "" {
-		gooseParam = "1"
+	finalURL, err := gooseURL(rawURL, gooseParam)
+	if err != nil {
+		return err
 	}
+	return safebrowse.OpenWithParams(ctx, finalURL, nil)
+}
 
-	// Use safebrowse package to validate and open the URL with parameters
-	return safebrowse.OpenWithParams(ctx, rawURL, map[string]string{
-		"goose": gooseParam,
-	})
+// gooseURL validates rawURL and returns it as the browser opens it, with ?goose= set
+// to gooseParam unless that's empty. A comment anchor, as on a pending question's
+// link, is kept after the query.
+func gooseURL(rawURL, gooseParam string) (string, error) {
+	base, anchor, anchored := strings.Cut(rawURL, "#")
+	var params map[string]string
+	if gooseParam != "" {
+		params = map[string]string{"goose": gooseParam}
+	}
+	finalURL, err := safebrowse.WithParams(base, params)
+	if err != nil || !anchored {
+		return finalURL, err
+	}
+	return safebrowse.WithAnchor(finalURL, anchor)
 }
 
 // PRCounts represents PR count information.
 type PRCounts struct {
-	IncomingTotal   int
-	IncomingBlocked int
-	OutgoingTotal   int
-	OutgoingBlocked int
+	IncomingTotal        int
+	IncomingBlocked      int
+	IncomingBlockedRepos int // Distinct repositories with blocked incoming PRs
+	OutgoingTotal        int
+	OutgoingBlocked      int
+	OutgoingBlockedRepos int // Distinct repositories with blocked outgoing PRs
 }
 
 // countPRs counts the number of PRs that need review/are blocked.
 func (app *App) countPRs() PRCounts {
-	app.mu.RLock()
-	defer app.mu.RUnlock()
-
-	var incomingCount, incomingBlocked, outgoingCount, outgoingBlocked int
-
-	// Pre-calculate stale threshold to avoid repeated time calculations
-	now := time.Now()
-	staleThreshold := now.Add(-stalePRThreshold)
-
-	slog.Info("[MENU] Counting incoming PRs", "total_incoming", len(app.incoming))
-	filteredIncoming := 0
-	for i := range app.incoming {
-		// Check if org is hidden
-		org := extractOrgFromRepo(app.incoming[i].Repository)
-		if org != "" && app.hiddenOrgs[org] {
-			filteredIncoming++
-			continue
-		}
-
-		if !app.hideStaleIncoming || app.incoming[i].UpdatedAt.After(staleThreshold) {
-			incomingCount++
-			if app.incoming[i].NeedsReview {
-				incomingBlocked++
-			}
-		} else {
-			filteredIncoming++
-		}
-	}
-	slog.Info("[MENU] Incoming PR count results",
-		"total_before_filter", len(app.incoming),
-		"total_after_filter", incomingCount,
-		"filtered_out", filteredIncoming,
-		"blocked_count", incomingBlocked)
-
-	slog.Info("[MENU] Counting outgoing PRs",
-		"total_outgoing", len(app.outgoing),
-		"hideStaleIncoming", app.hideStaleIncoming,
-		"staleThreshold", staleThreshold.Format(time.RFC3339))
-	for i := range app.outgoing {
-		pr := app.outgoing[i]
-		// Check if org is hidden
-		org := extractOrgFromRepo(pr.Repository)
-		hiddenByOrg := org != "" && app.hiddenOrgs[org]
-		isStale := pr.UpdatedAt.Before(staleThreshold)
-
-		// Log every PR with its filtering status
-		slog.Info("[MENU] Processing outgoing PR",
-			"repo", pr.Repository,
-			"number", pr.Number,
-			"org", org,
-			"hidden_org", hiddenByOrg,
-			"updated_at", pr.UpdatedAt.Format(time.RFC3339),
-			"is_stale", isStale,
-			"hideStale_enabled", app.hideStaleIncoming,
-			"blocked", pr.IsBlocked,
-			"url", pr.URL)
-
-		if hiddenByOrg {
-			slog.Info("[MENU] ❌ Filtering out outgoing PR (hidden org)",
-				"repo", pr.Repository, "number", pr.Number,
-				"org", org, "url", pr.URL)
-			continue
-		}
+	return app.snapshotPRs().counts()
+}
 
-		if !app.hideStaleIncoming || !isStale {
-			outgoingCount++
-			if pr.IsBlocked {
-				outgoingBlocked++
-			}
-			slog.Info("[MENU] ✅ Including outgoing PR in count",
-				"repo", pr.Repository, "number", pr.Number,
-				"blocked", pr.IsBlocked, "url", pr.URL)
-		} else {
-			slog.Info("[MENU] ❌ Filtering out outgoing PR (stale)",
-				"repo", pr.Repository, "number", pr.Number,
-				"updated_at", pr.UpdatedAt.Format(time.RFC3339),
-				"url", pr.URL)
-		}
+// trayTitle returns the macOS tray title for the given counts: blocked PRs, or the
+// number of repositories containing them when countRepos is set.
+func trayTitle(counts PRCounts, countRepos bool) string {
+	incoming, outgoing := counts.IncomingBlocked, counts.OutgoingBlocked
+	if countRepos {
+		incoming, outgoing = counts.IncomingBlockedRepos, counts.OutgoingBlockedRepos
 	}
-	slog.Info("[MENU] Outgoing PR count results",
-		"total_before_filter", len(app.outgoing),
-		"total_after_filter", outgoingCount,
-		"blocked_count", outgoingBlocked)
-	return PRCounts{
-		IncomingTotal:   incomingCount,
-		IncomingBlocked: incomingBlocked,
-		OutgoingTotal:   outgoingCount,
-		OutgoingBlocked: outgoingBlocked,
+	switch {
+	case counts.IncomingBlocked == 0 && counts.OutgoingBlocked == 0:
+		return ""
+	case counts.IncomingBlocked > 0 && counts.OutgoingBlocked > 0:
+		return fmt.Sprintf("%d / %d", incoming, outgoing)
+	case counts.IncomingBlocked > 0:
+		return strconv.Itoa(incoming)
+	default:
+		return strconv.Itoa(outgoing)
 	}
 }
 
-// setTrayTitle updates the system tray title and icon based on PR counts.
-func (app *App) setTrayTitle() {
-	counts := app.countPRs()
-
-	// Check if all outgoing blocked PRs are fix_tests only
-	allOutgoingAreFixTests := false
-	if counts.OutgoingBlocked > 0 && counts.IncomingBlocked == 0 {
-		app.mu.RLock()
-		allFixTests := true
-		for i := range app.outgoing {
-			if app.outgoing[i].IsBlocked && app.outgoing[i].ActionKind != "fix_tests" {
-				allFixTests = false
-				break
-			}
-		}
-		app.mu.RUnlock()
-		allOutgoingAreFixTests = allFixTests
+// sectionHeader returns the menu header for a PR section.
+// sectionTitle is the internal section key, "Incoming" or "Outgoing".
+func sectionHeader(sectionTitle string, blocked, blockedRepos int, countRepos bool) string {
+	name := msg("section.incoming")
+	if sectionTitle == "Outgoing" {
+		name = msg("section.outgoing")
 	}
-
-	// Set title and icon based on PR state
-	var title string
-	var iconType IconType
-
-	// On macOS, show counts with the icon
-	// On all other platforms (Linux, Windows, FreeBSD, etc), just show the icon
-	if runtime.GOOS == "darwin" {
-		// macOS: show counts alongside icon
-		switch {
-		case counts.IncomingBlocked == 0 && counts.OutgoingBlocked == 0:
-			title = ""
-			iconType = IconSmiling
-		case counts.IncomingBlocked > 0 && counts.OutgoingBlocked > 0:
-			title = fmt.Sprintf("%d / %d", counts.IncomingBlocked, counts.OutgoingBlocked)
-			iconType = IconBoth
-		case counts.IncomingBlocked > 0:
-			title = strconv.Itoa(counts.IncomingBlocked)
-			iconType = IconGoose
-		default:
-			title = strconv.Itoa(counts.OutgoingBlocked)
-			if allOutgoingAreFixTests {
-				iconType = IconCockroach
-			} else {
-				iconType = IconPopper
-			}
-		}
-	} else {
-		// All other platforms: icon only, no text
-		title = ""
-		switch {
-		case counts.IncomingBlocked == 0 && counts.OutgoingBlocked == 0:
-			iconType = IconSmiling
-		case counts.IncomingBlocked > 0 && counts.OutgoingBlocked > 0:
-			iconType = IconBoth
-		case counts.IncomingBlocked > 0:
-			iconType = IconGoose
-		default:
-			if allOutgoingAreFixTests {
-				iconType = IconCockroach
-			} else {
-				iconType = IconPopper
-			}
-		}
+	switch {
+	case !countRepos || blocked == 0:
+		return msg("section.blocked", name, blocked)
+	case blockedRepos == 1:
+		return msg("section.blocked_repo", name, blocked, blockedRepos)
+	default:
+		return msg("section.blocked_repos", name, blocked, blockedRepos)
 	}
-
-	// Log title change with detailed counts
-	slog.Info("[TRAY] Setting title and icon",
-		"os", runtime.GOOS,
-		"title", title,
-		"icon", iconType,
-		"incoming_total", counts.IncomingTotal,
-		"incoming_blocked", counts.IncomingBlocked,
-		"outgoing_total", counts.OutgoingTotal,
-		"outgoing_blocked", counts.OutgoingBlocked)
-	app.systrayInterface.SetTitle(title)
-	app.setTrayIcon(iconType, counts)
-
-	// Update tooltip to match current state
-	tooltip := "reviewGOOSE"
-	if app.targetUser != "" {
-		tooltip = fmt.Sprintf("reviewGOOSE (@%s)", app.targetUser)
-	}
-	systray.SetTooltip(tooltip)
 }
 
 // addPRSection adds a section of PRs to the menu.
 //
 //nolint:maintidx,gocognit // Function complexity is inherent to PR menu building logic
-func (app *App) addPRSection(ctx context.Context, prs []PR, sectionTitle string, blockedCount int) {
+func (app *App) addPRSection(ctx context.Context, prs []PR, sectionTitle string, blockedCount, blockedRepos int) {
 	slog.Debug("[MENU] addPRSection called",
 		"section", sectionTitle,
 		"pr_count", len(prs),
@@ -231,173 +108,39 @@ func (app *App) addPRSection(ctx context.Context, prs []PR, sectionTitle string,
 		slog.Debug("[MENU] No PRs to add in section", "section", sectionTitle)
 		return
 	}
-
 	// Add header
-	headerText := fmt.Sprintf("%s — %d blocked on you", sectionTitle, blockedCount)
+	headerText := sectionHeader(sectionTitle, blockedCount, blockedRepos, app.readSetting(&app.countRepos))
 	// Create section header
-	header := app.systrayInterface.AddMenuItem(headerText, "")
+	header := app.systrayInterface.AddMenuItem(headerText+app.sectionChangedSuffix(sectionTitle), "")
 	header.Disable()
+	app.trackSectionHeader(sectionTitle, header, headerText)
+	if sectionTitle == "Incoming" {
+		app.addOpenAllBlocked(ctx)
+		app.addReviewSession(ctx, blockedCount)
+		app.addSnoozeIncoming(ctx, blockedCount)
+	}
 
-	// Sort PRs with blocked ones first, humans before bots - inline for simplicity
-	sortedPRs := make([]PR, len(prs))
-	copy(sortedPRs, prs)
-	sort.SliceStable(sortedPRs, func(i, j int) bool {
-		// First priority: blocked status
-		if sortedPRs[i].NeedsReview != sortedPRs[j].NeedsReview {
-			return sortedPRs[i].NeedsReview // true (blocked) comes before false
-		}
-		if sortedPRs[i].IsBlocked != sortedPRs[j].IsBlocked {
-			return sortedPRs[i].IsBlocked // true (blocked) comes before false
-		}
-		// Second priority: human PRs before bot PRs
-		if sortedPRs[i].AuthorBot != sortedPRs[j].AuthorBot {
-			return !sortedPRs[i].AuthorBot // false (human) comes before true (bot)
-		}
-		// Third priority: more recent PRs first
-		return sortedPRs[i].UpdatedAt.After(sortedPRs[j].UpdatedAt)
-	})
+	// Sort PRs with blocked ones first, humans before bots, grouping bot PRs if asked
+	rows := app.sectionRows(prs, sectionTitle)
 
-	// Get hidden orgs with proper locking
-	app.mu.RLock()
-	hiddenOrgs := make(map[string]bool)
-	maps.Copy(hiddenOrgs, app.hiddenOrgs)
-	hideStale := app.hideStaleIncoming
-	app.mu.RUnlock()
+	displayMode, labelWidth := app.menuLabelSettings()
+	highlight := app.highlightWindow()
 
 	// Add PR items in sorted order
-	added := 0
-	for i := range sortedPRs {
-		pr := &sortedPRs[i]
-
-		// Skip PRs from hidden orgs
-		org := extractOrgFromRepo(pr.Repository)
-		if org != "" && hiddenOrgs[org] {
-			slog.Debug("[MENU] Skipping PR in addPRSection (hidden org)",
-				"section", sectionTitle,
-				"repo", pr.Repository,
-				"number", pr.Number,
-				"org", org)
-			continue
-		}
-
-		// Skip stale PRs if configured
-		if hideStale && pr.UpdatedAt.Before(time.Now().Add(-stalePRThreshold)) {
-			slog.Debug("[MENU] Skipping PR in addPRSection (stale)",
-				"section", sectionTitle,
-				"repo", pr.Repository,
-				"number", pr.Number,
-				"updated_at", pr.UpdatedAt)
+	blockedRows := 0
+	for i := range rows {
+		row := &rows[i]
+		blockedRows += row.blocked(sectionTitle)
+		if row.group != nil {
+			app.addBotGroup(ctx, row, sectionTitle, displayMode, labelWidth, highlight)
 			continue
 		}
+		pr := &row.pr
 
-		title := fmt.Sprintf("%s #%d", pr.Repository, pr.Number)
-
-		// Add action code if present, or test state as fallback
-		if pr.ActionKind != "" {
-			// Replace underscores with spaces for better readability
-			actionDisplay := strings.ReplaceAll(pr.ActionKind, "_", " ")
-			title = fmt.Sprintf("%s — %s", title, actionDisplay)
-		} else if pr.TestState == "running" {
-			// Show "tests running" as a fallback when no specific action is available
-			title = fmt.Sprintf("%s — tests running...", title)
-		}
-
-		// Add bullet point or emoji based on PR status
-		switch {
-		case pr.NeedsReview || pr.IsBlocked:
-			// Get the blocked time from state manager
-			prState, hasState := app.stateManager.PRState(pr.URL)
-
-			// Show emoji for PRs blocked within the last 5 minutes
-			// (but only for real state transitions, not initial discoveries)
-			if hasState && !prState.FirstBlockedAt.IsZero() &&
-				time.Since(prState.FirstBlockedAt) < blockedPRIconDuration &&
-				!prState.IsInitialDiscovery {
-				elapsed := time.Since(prState.FirstBlockedAt)
-				// Use cockroach for fix_tests, party popper for other outgoing PRs, goose for incoming PRs
-				if sectionTitle == "Outgoing" {
-					if pr.ActionKind == "fix_tests" {
-						title = fmt.Sprintf("🪳 %s", title)
-						slog.Info("[MENU] Adding cockroach to outgoing PR with broken tests",
-							"repo", pr.Repository,
-							"number", pr.Number,
-							"url", pr.URL,
-							"firstBlockedAt", prState.FirstBlockedAt.Format(time.RFC3339),
-							"blocked_ago", elapsed.Round(time.Second),
-							"remaining", (blockedPRIconDuration - elapsed).Round(time.Second))
-					} else {
-						title = fmt.Sprintf("🎉 %s", title)
-						slog.Info("[MENU] Adding party popper to outgoing PR",
-							"repo", pr.Repository,
-							"number", pr.Number,
-							"url", pr.URL,
-							"firstBlockedAt", prState.FirstBlockedAt.Format(time.RFC3339),
-							"blocked_ago", elapsed.Round(time.Second),
-							"remaining", (blockedPRIconDuration - elapsed).Round(time.Second))
-					}
-				} else {
-					title = fmt.Sprintf("🪿 %s", title)
-					slog.Debug("[MENU] Adding goose to incoming PR",
-						"url", pr.URL,
-						"blocked_ago", elapsed,
-						"remaining", blockedPRIconDuration-elapsed)
-				}
-			} else {
-				// Use smaller dot for bot PRs, block icon for humans
-				if pr.AuthorBot {
-					title = fmt.Sprintf("· %s", title)
-				} else {
-					title = fmt.Sprintf("■ %s", title)
-				}
-				// Log when we transition from emoji to block icon
-				if hasState && !prState.FirstBlockedAt.IsZero() {
-					elapsed := time.Since(prState.FirstBlockedAt)
-					if sectionTitle == "Outgoing" {
-						slog.Debug("[MENU] Removing party popper from outgoing PR",
-							"url", pr.URL,
-							"blocked_ago", elapsed,
-							"duration", blockedPRIconDuration)
-					} else {
-						slog.Debug("[MENU] Removing goose from incoming PR",
-							"url", pr.URL,
-							"blocked_ago", elapsed,
-							"duration", blockedPRIconDuration)
-					}
-				}
-			}
-		case pr.ActionKind != "":
-			// PR has an action but isn't blocked - add bullet to indicate it could use input
-			title = fmt.Sprintf("• %s", title)
-		case pr.WorkflowState == string(turn.StateNewlyPublished) && time.Since(pr.UpdatedAt) < time.Minute:
-			// Use gem emoji for newly published PRs updated within the last minute
-			title = fmt.Sprintf("💎 %s", title)
-		default:
-			// No prefix needed
-		}
-
-		// Format age for tooltip
-		dur := time.Since(pr.UpdatedAt)
-		var age string
-		switch {
-		case dur < time.Hour:
-			age = fmt.Sprintf("%dm", int(dur.Minutes()))
-		case dur < 24*time.Hour:
-			age = fmt.Sprintf("%dh", int(dur.Hours()))
-		case dur < 30*24*time.Hour:
-			age = fmt.Sprintf("%dd", int(dur.Hours()/24))
-		case dur < 365*24*time.Hour:
-			age = fmt.Sprintf("%dmo", int(dur.Hours()/(24*30)))
-		default:
-			age = pr.UpdatedAt.Format("2006")
-		}
-		tooltip := fmt.Sprintf("%s (%s)", pr.Title, age)
-		// Add action reason for blocked PRs
-		if (pr.NeedsReview || pr.IsBlocked) && pr.ActionReason != "" {
-			tooltip = fmt.Sprintf("%s - %s", tooltip, pr.ActionReason)
-		}
+		title := app.prRowTitle(pr, sectionTitle, displayMode, labelWidth, highlight)
+		tooltip := formatMenuTooltip(*pr, displayMode, prAge(pr.UpdatedAt))
 
 		// Create PR menu item
-		added++
 		slog.Debug("[MENU] Adding PR to menu",
 			"section", sectionTitle,
 			"title", title,
@@ -406,19 +149,73 @@ func (app *App) addPRSection(ctx context.Context, prs []PR, sectionTitle string,
 			"url", pr.URL,
 			"blocked", pr.NeedsReview || pr.IsBlocked)
 		item := app.systrayInterface.AddMenuItem(title, tooltip)
-
-		// Capture URL for closure (Go 1.22+ doesn't require this, but kept for clarity)
-		url := pr.URL
-		item.Click(func() {
-			if err := openURL(ctx, url, ""); err != nil {
-				slog.Error("failed to open url", "error", err)
-			}
-		})
+		app.setupPRItem(ctx, item, pr, sectionTitle)
 	}
 	slog.Info("[MENU] Added PR section",
 		"section", sectionTitle,
-		"items_added", added,
-		"filtered_out", len(sortedPRs)-added)
+		"items_added", len(prs),
+		"rows", len(rows),
+		"blocked", blockedRows)
+	checkSectionCount(sectionTitle, blockedCount, blockedRows)
+}
+
+// setupPRItem makes a PR's menu item open the PR, and adds its per-PR actions.
+func (app *App) setupPRItem(ctx context.Context, item MenuItem, pr *PR, sectionTitle string) {
+	// Capture URL for closure (Go 1.22+ doesn't require this, but kept for clarity)
+	url := prLink(pr)
+	item.Click(func() {
+		if err := app.openBrowser(ctx, url, ""); err != nil {
+			slog.Error("failed to open url", "error", err)
+			return
+		}
+		app.resumeAutoOpen(ctx)
+	})
+	app.addPRActions(ctx, item, pr, url)
+	addExplainAction(item, pr)
+	app.addDismissAction(ctx, item, pr)
+	app.addPinAction(ctx, item, pr)
+	app.addHidePRAction(ctx, item, pr)
+	if sectionTitle == "Incoming" {
+		app.addTestWatchAction(ctx, item, pr)
+	}
+}
+
+// prRowTitle is a PR's menu label with the bullet or emoji for its status.
+func (app *App) prRowTitle(pr *PR, sectionTitle string, displayMode DisplayMode, labelWidth int, highlight HighlightWindow) string {
+	title := formatMenuLabel(*pr, displayMode, labelWidth) + pendingReviewSuffix(pr) + commentBurstSuffix(app.commentBurst(pr, sectionTitle))
+	switch {
+	case pr.NeedsReview || pr.IsBlocked:
+		return fmt.Sprintf("%s %s", app.blockedPrefix(pr, sectionTitle, highlight), title)
+	case pr.Dismissed:
+		return fmt.Sprintf("– %s", title)
+	case pr.Snoozed:
+		return fmt.Sprintf("💤 %s", title)
+	case pr.ActionKind != actionNone:
+		// PR has an action but isn't blocked - add bullet to indicate it could use input
+		return fmt.Sprintf("• %s", title)
+	case pr.WorkflowState == string(turn.StateNewlyPublished) && time.Since(pr.UpdatedAt) < time.Minute:
+		// Use gem emoji for newly published PRs updated within the last minute
+		return fmt.Sprintf("💎 %s", title)
+	default:
+		return title
+	}
+}
+
+// prAge formats how long ago a PR was updated for its tooltip, e.g. "5m" or "3d".
+func prAge(updatedAt time.Time) string {
+	dur := time.Since(updatedAt)
+	switch {
+	case dur < time.Hour:
+		return fmt.Sprintf("%dm", int(dur.Minutes()))
+	case dur < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(dur.Hours()))
+	case dur < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(dur.Hours()/24))
+	case dur < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo", int(dur.Hours()/(24*30)))
+	default:
+		return updatedAt.Format("2006")
+	}
 }
 
 // generateMenuTitles generates the list of menu item titles that would be shown
@@ -429,165 +226,125 @@ func (app *App) generateMenuTitles() []string {
 	// Check for auth error first
 	if app.authError != "" {
 		titles = append(titles,
-			"⚠️ Authentication Error",
+			msg("auth.title"),
 			app.authError,
-			"To fix this issue:",
-			"1. Install GitHub CLI: brew install gh",
-			"2. Run: gh auth login",
-			"3. Or set GITHUB_TOKEN environment variable",
-			"Quit")
+			msg("auth.fix"),
+			msg("auth.step_install"),
+			msg("auth.step_login"),
+			msg("auth.step_token"),
+			msg("menu.quit"))
 		return titles
 	}
 
-	app.mu.RLock()
-	incoming := make([]PR, len(app.incoming))
-	copy(incoming, app.incoming)
-	outgoing := make([]PR, len(app.outgoing))
-	copy(outgoing, app.outgoing)
-	hiddenOrgs := make(map[string]bool)
-	maps.Copy(hiddenOrgs, app.hiddenOrgs)
-	hideStale := app.hideStaleIncoming
-	app.mu.RUnlock()
+	view := app.snapshotPRs()
+	incoming, outgoing := view.incoming, view.outgoing
+	focusRepo := view.focusRepo
+
+	if app.storage != nil && app.storage.degraded() {
+		titles = append(titles, storageWarningTitle())
+	}
+	if title := app.partialFetchTitle(); title != "" {
+		titles = append(titles, title)
+	}
+	if title := app.searchCappedTitle(); title != "" {
+		titles = append(titles, title)
+	}
+	if title := app.autoOpenPausedTitle(); title != "" {
+		titles = append(titles, title)
+	}
+	if title := app.settingsResetTitle(); title != "" {
+		titles = append(titles, title)
+	}
+	if title := app.archWarningTitle(); title != "" {
+		titles = append(titles, title)
+	}
+
+	if focusRepo != "" {
+		titles = append(titles, focusBannerTitle(focusRepo))
+	}
 
 	// Add common menu items
-	titles = append(titles, "Web Dashboard")
+	titles = append(titles, msg("menu.web_dashboard"))
+	titles = append(titles, app.pinnedTitles(view)...)
 
 	// Generate PR section titles
 	if len(incoming) == 0 && len(outgoing) == 0 {
-		titles = append(titles, "No pull requests")
+		if empty := app.emptyStateTitles(view); empty != nil {
+			titles = append(titles, empty...)
+		} else {
+			titles = append(titles, msg("menu.no_prs"))
+		}
 	} else {
 		// Add incoming PR titles
-		if len(incoming) > 0 {
-			titles = append(titles, "📥 Incoming PRs")
-			titles = append(titles, app.generatePRSectionTitles(incoming, "Incoming", hiddenOrgs, hideStale)...)
+		switch {
+		case len(incoming) > 0 && app.teamMode():
+			titles = append(titles, app.teamSectionTitles(incoming)...)
+		case len(incoming) > 0:
+			titles = append(titles, msg("menu.incoming_prs"))
+			titles = append(titles, app.sessionTitles(view.counts().IncomingBlocked)...)
+			titles = append(titles, app.snoozeTitles(view.counts().IncomingBlocked)...)
+			titles = append(titles, app.generatePRSectionTitles(incoming, "Incoming")...)
+			titles = append(titles, app.weekAheadTitles(view)...)
+		default:
 		}
 
 		// Add outgoing PR titles
 		if len(outgoing) > 0 {
-			titles = append(titles, "📤 Outgoing PRs")
-			titles = append(titles, app.generatePRSectionTitles(outgoing, "Outgoing", hiddenOrgs, hideStale)...)
+			titles = append(titles, msg("menu.outgoing_prs"))
+			titles = append(titles, app.generatePRSectionTitles(outgoing, "Outgoing")...)
 		}
 	}
 
-	// Add settings menu items
+	titles = append(titles, app.recentlyClearedTitles()...)
+	titles = append(titles, app.dismissedTitles()...)
+	titles = append(titles, app.filteredTitles()...)
+	titles = append(titles, app.notificationHistoryTitles()...)
+
+	// Add settings menu items, including checkmarks so a setting changed from
+	// any path triggers a rebuild
+	titles = append(titles,
+		msg("menu.settings"),
+		msg("focus.menu"),
+		msg("orgs.menu"))
+	titles = append(titles, app.hiddenPRsTitles()...)
 	titles = append(titles,
-		"⚙️ Settings",
-		"Hide Stale Incoming PRs",
-		"Honks enabled",
-		"Auto-open in Browser",
-		"Hidden Organizations",
-		"Quit")
+		msg("display.menu"),
+		msg("sort.menu"),
+		msg("highlight.menu"),
+		msg("url_param.menu"),
+		msg("language.menu"))
+	titles = append(titles, app.statsTitles()...)
+	if debug := app.debugMenuTitles(); len(debug) > 0 {
+		titles = append(titles, msg("debug.menu"))
+		titles = append(titles, debug...)
+	}
+	for _, setting := range app.settingItems() {
+		titles = append(titles, setting.state().Title())
+	}
 
 	return titles
 }
 
-// generatePRSectionTitles generates the titles for a specific PR section.
-func (app *App) generatePRSectionTitles(prs []PR, sectionTitle string, hiddenOrgs map[string]bool, hideStale bool) []string {
+// generatePRSectionTitles generates the titles for a section's shown PRs, as taken from
+// a prView.
+func (app *App) generatePRSectionTitles(prs []PR, sectionTitle string) []string {
 	var titles []string
+	displayMode, labelWidth := app.menuLabelSettings()
+	highlight := app.highlightWindow()
 
-	// Sort PRs: humans before bots, then by UpdatedAt (most recent first)
-	sortedPRs := make([]PR, len(prs))
-	copy(sortedPRs, prs)
-	sort.Slice(sortedPRs, func(i, j int) bool {
-		if sortedPRs[i].AuthorBot != sortedPRs[j].AuthorBot {
-			return !sortedPRs[i].AuthorBot // false (human) comes before true (bot)
-		}
-		return sortedPRs[i].UpdatedAt.After(sortedPRs[j].UpdatedAt)
-	})
-
-	for i := range sortedPRs {
-		pr := &sortedPRs[i]
+	// Sort PRs the same way addPRSection does, so the titles follow menu order
+	rows := app.sectionRows(prs, sectionTitle)
 
-		// Apply filters (same logic as in addPRSection)
-		org := extractOrgFromRepo(pr.Repository)
-		if org != "" && hiddenOrgs[org] {
+	for i := range rows {
+		row := &rows[i]
+		if row.group == nil {
+			titles = append(titles, app.prRowTitle(&row.pr, sectionTitle, displayMode, labelWidth, highlight))
 			continue
 		}
-
-		if hideStale && pr.UpdatedAt.Before(time.Now().Add(-stalePRThreshold)) {
-			continue
-		}
-
-		title := fmt.Sprintf("%s #%d", pr.Repository, pr.Number)
-
-		// Add action code if present
-		if pr.ActionKind != "" {
-			title = fmt.Sprintf("%s — %s", title, pr.ActionKind)
-		} else if pr.TestState == "running" {
-			// Show "tests running" as a fallback when no specific action is available
-			title = fmt.Sprintf("%s — tests running...", title)
-		}
-
-		// Add bullet point or emoji for blocked PRs (same logic as in addPRSection)
-		switch {
-		case pr.NeedsReview || pr.IsBlocked:
-			prState, hasState := app.stateManager.PRState(pr.URL)
-
-			if hasState && !prState.FirstBlockedAt.IsZero() &&
-				time.Since(prState.FirstBlockedAt) < blockedPRIconDuration &&
-				!prState.IsInitialDiscovery {
-				elapsed := time.Since(prState.FirstBlockedAt)
-				if sectionTitle == "Outgoing" {
-					if pr.ActionKind == "fix_tests" {
-						title = fmt.Sprintf("🪳 %s", title)
-						slog.Info("[MENU] Adding cockroach to outgoing PR with broken tests in generateMenuTitles",
-							"repo", pr.Repository,
-							"number", pr.Number,
-							"url", pr.URL,
-							"firstBlockedAt", prState.FirstBlockedAt.Format(time.RFC3339),
-							"blocked_ago", elapsed.Round(time.Second),
-							"remaining", (blockedPRIconDuration - elapsed).Round(time.Second))
-					} else {
-						title = fmt.Sprintf("🎉 %s", title)
-						slog.Info("[MENU] Adding party popper to outgoing PR in generateMenuTitles",
-							"repo", pr.Repository,
-							"number", pr.Number,
-							"url", pr.URL,
-							"firstBlockedAt", prState.FirstBlockedAt.Format(time.RFC3339),
-							"blocked_ago", elapsed.Round(time.Second),
-							"remaining", (blockedPRIconDuration - elapsed).Round(time.Second))
-					}
-				} else {
-					title = fmt.Sprintf("🪿 %s", title)
-					slog.Debug("[MENU] Adding goose to incoming PR in generateMenuTitles",
-						"url", pr.URL,
-						"blocked_ago", elapsed,
-						"remaining", blockedPRIconDuration-elapsed)
-				}
-			} else {
-				// Use smaller dot for bot PRs, block icon for humans
-				if pr.AuthorBot {
-					title = fmt.Sprintf("· %s", title)
-				} else {
-					title = fmt.Sprintf("■ %s", title)
-				}
-				// Log when we use block icon instead of emoji
-				if hasState && !prState.FirstBlockedAt.IsZero() {
-					elapsed := time.Since(prState.FirstBlockedAt)
-					if sectionTitle == "Outgoing" {
-						slog.Debug("[MENU] Using block icon instead of party popper in generateMenuTitles",
-							"url", pr.URL,
-							"blocked_ago", elapsed.Round(time.Second),
-							"icon_duration", blockedPRIconDuration)
-					}
-				} else if !hasState {
-					slog.Debug("[MENU] No state found for blocked PR, using block icon",
-						"url", pr.URL,
-						"repo", pr.Repository,
-						"number", pr.Number)
-				}
-			}
-		case pr.ActionKind != "":
-			// PR has an action but isn't blocked - add bullet to indicate it could use input
-			title = fmt.Sprintf("• %s", title)
-		case pr.WorkflowState == string(turn.StateNewlyPublished) && time.Since(pr.UpdatedAt) < time.Minute:
-			// Use gem emoji for newly published PRs updated within the last minute
-			title = fmt.Sprintf("💎 %s", title)
-		default:
-			// No prefix needed
+		titles = append(titles, app.botGroupTitle(row, sectionTitle, highlight))
+		for j := range row.group {
+			titles = append(titles, app.prRowTitle(&row.group[j], sectionTitle, displayMode, labelWidth, highlight))
 		}
-
-		titles = append(titles, title)
 	}
 
 	return titles
@@ -604,6 +361,9 @@ func (app *App) rebuildMenu(ctx context.Context) {
 
 	// Clear all existing menu items
 	app.systrayInterface.ResetMenu()
+	app.mu.Lock()
+	clear(app.sectionHeaders)
+	app.mu.Unlock()
 	slog.Info("[MENU] Called ResetMenu")
 
 	// On Linux, add a small delay to ensure DBus properly processes the reset
@@ -622,15 +382,15 @@ func (app *App) rebuildMenu(ctx context.Context) {
 	// Show auth error if present
 	if authError != "" {
 		// Show authentication error message
-		errorTitle := app.systrayInterface.AddMenuItem("⚠️ Authentication Error", "")
+		errorTitle := app.systrayInterface.AddMenuItem(msg("auth.title"), "")
 		errorTitle.Disable()
 
 		app.systrayInterface.AddSeparator()
 
 		// Add error details
-		errorMsg := app.systrayInterface.AddMenuItem(authError, "Click to see setup instructions")
+		errorMsg := app.systrayInterface.AddMenuItem(authError, msg("auth.tooltip"))
 		errorMsg.Click(func() {
-			if err := openURL(ctx, "https://cli.github.com/manual/gh_auth_login", ""); err != nil {
+			if err := app.openBrowser(ctx, "https://cli.github.com/manual/gh_auth_login", ""); err != nil {
 				slog.Error("failed to open setup instructions", "error", err)
 			}
 		})
@@ -638,41 +398,46 @@ func (app *App) rebuildMenu(ctx context.Context) {
 		app.systrayInterface.AddSeparator()
 
 		// Add setup instructions
-		setupInstr := app.systrayInterface.AddMenuItem("To fix this issue:", "")
+		setupInstr := app.systrayInterface.AddMenuItem(msg("auth.fix"), "")
 		setupInstr.Disable()
 
-		option1 := app.systrayInterface.AddMenuItem("1. Install GitHub CLI: brew install gh", "")
+		option1 := app.systrayInterface.AddMenuItem(msg("auth.step_install"), "")
 		option1.Disable()
 
-		option2 := app.systrayInterface.AddMenuItem("2. Run: gh auth login", "")
+		option2 := app.systrayInterface.AddMenuItem(msg("auth.step_login"), "")
 		option2.Disable()
 
-		option3 := app.systrayInterface.AddMenuItem("3. Or set GITHUB_TOKEN environment variable", "")
+		option3 := app.systrayInterface.AddMenuItem(msg("auth.step_token"), "")
 		option3.Disable()
 
 		app.systrayInterface.AddSeparator()
 
 		// Add quit option
-		quitItem := app.systrayInterface.AddMenuItem("Quit", "")
+		quitItem := app.systrayInterface.AddMenuItem(msg("menu.quit"), "")
 		quitItem.Click(func() {
 			app.systrayInterface.Quit()
 		})
 
+		app.presentTrayState()
 		return
 	}
 
-	// Show connection error if we have consecutive failures
-	if failureCount > 0 && lastFetchError != "" {
+	// Show connection error if we have consecutive failures. A search GitHub refuses
+	// isn't one; the partial fetch notice below explains it.
+	if failureCount > 0 && lastFetchError != "" && !app.searchRefused() {
+		incident := app.activeGitHubIncident()
 		var errorMsg string
 		switch {
+		case incident != nil:
+			errorMsg = msg("error.github_outage", incident.label())
 		case failureCount == 1:
-			errorMsg = "⚠️ Connection Error"
+			errorMsg = msg("error.connection")
 		case failureCount <= 3:
-			errorMsg = fmt.Sprintf("⚠️ Connection Issues (%d failures)", failureCount)
+			errorMsg = msg("error.connection_issues", failureCount)
 		case failureCount <= 10:
-			errorMsg = "❌ Multiple Connection Failures"
+			errorMsg = msg("error.multiple_failures")
 		default:
-			errorMsg = "💀 Service Degraded"
+			errorMsg = msg("error.degraded")
 		}
 
 		errorTitle := app.systrayInterface.AddMenuItem(errorMsg, "")
@@ -691,20 +456,21 @@ func (app *App) rebuildMenu(ctx context.Context) {
 			}
 		}
 
-		errorType := "Connection failed"
+		errorType := msg("error.kind.connection_failed")
 		for _, e := range []struct{ match, errType string }{
-			{"timeout", "Request timeout"},
-			{"context deadline", "Request timeout (context deadline)"},
-			{"rate limit", "Rate limit exceeded"},
-			{"401", "Authentication failed"},
-			{"unauthorized", "Authentication failed"},
-			{"403", "Access forbidden"},
-			{"forbidden", "Access forbidden"},
-			{"404", "Resource not found"},
-			{"connection refused", "Connection refused"},
-			{"no such host", "DNS resolution failed"},
-			{"TLS", "TLS/Certificate error"},
-			{"x509", "TLS/Certificate error"},
+			{"update timed out", msg("error.kind.update_timed_out")},
+			{"timeout", msg("error.kind.request_timeout")},
+			{"context deadline", msg("error.kind.context_deadline")},
+			{"rate limit", msg("error.kind.rate_limit")},
+			{"401", msg("error.kind.auth")},
+			{"unauthorized", msg("error.kind.auth")},
+			{"403", msg("error.kind.forbidden")},
+			{"forbidden", msg("error.kind.forbidden")},
+			{"404", msg("error.kind.not_found")},
+			{"connection refused", msg("error.kind.refused")},
+			{"no such host", msg("error.kind.dns")},
+			{"TLS", msg("error.kind.tls")},
+			{"x509", msg("error.kind.tls")},
 		} {
 			if strings.Contains(lastFetchError, e.match) {
 				errorType = e.errType
@@ -713,55 +479,84 @@ func (app *App) rebuildMenu(ctx context.Context) {
 		}
 
 		// Show technical details
-		techDetails := app.systrayInterface.AddMenuItem(fmt.Sprintf("Host: %s", hostname), "")
+		techDetails := app.systrayInterface.AddMenuItem(msg("error.host", hostname), "")
 		techDetails.Disable()
 
-		errorTypeItem := app.systrayInterface.AddMenuItem(fmt.Sprintf("Error: %s", errorType), "")
+		errorTypeItem := app.systrayInterface.AddMenuItem(msg("error.kind", errorType), "")
 		errorTypeItem.Disable()
 
-		// Show truncated raw error for debugging (max 80 chars)
-		rawError := lastFetchError
-		if len(rawError) > 80 {
-			rawError = rawError[:77] + "..."
+		// The raw error, wrapped so the host or error code at its end stays visible
+		for _, line := range wrapRunes(msg("error.details", lastFetchError), errorDetailWidth, errorDetailLines) {
+			app.systrayInterface.AddMenuItem(line, lastFetchError).Disable()
 		}
-		rawErrorItem := app.systrayInterface.AddMenuItem(fmt.Sprintf("Details: %s", rawError), "Click to copy full error")
-		rawErrorItem.Click(func() {
-			// Would need clipboard support to implement copy
-			slog.Info("Full error", "error", lastFetchError)
+		app.systrayInterface.AddMenuItem(msg("error.copy"), msg("error.details.tooltip")).Click(func() {
+			app.copyToClipboard(ctx, lastFetchError)
 		})
 
+		app.addCircuitItems(ctx)
+
+		// During a GitHub incident there's nothing to diagnose locally
+		if failureCount >= majorFailureThreshold && incident == nil {
+			app.addEscalationItems(ctx)
+		}
+
 		app.systrayInterface.AddSeparator()
 	}
 
-	// Update tray title
-	app.setTrayTitle()
+	// Show a single warning line instead of logging every failed write
+	if app.storage != nil && app.storage.degraded() {
+		storageItem := app.systrayInterface.AddMenuItem(storageWarningTitle(), app.storage.status())
+		storageItem.Disable()
+		app.systrayInterface.AddSeparator()
+	}
+	app.addPartialFetchNotice(ctx)
+	app.addSearchCappedNotice(ctx)
+	app.addAutoOpenPausedNotice(ctx)
+	app.addSettingsResetNotice(ctx)
+	app.addArchWarning(ctx)
+
+	// The tray title, the section headers, and their rows all come from one view
+	view := app.snapshotPRs()
+	app.presentTrayStateFrom(view)
+
+	// Focus mode banner at the very top so it's easy to exit
+	if focusRepo := app.focusedRepo(); focusRepo != "" {
+		app.addFocusBanner(ctx, focusRepo)
+	}
 
 	// Dashboard at the top
 	// Add Web Dashboard link
-	dashboardItem := app.systrayInterface.AddMenuItem("Web Dashboard", "")
+	dashboardItem := app.systrayInterface.AddMenuItem(msg("menu.web_dashboard"), "")
 	dashboardItem.Click(func() {
-		if err := openURL(ctx, "https://my.reviewGOOSE.dev/", ""); err != nil {
+		if err := app.openBrowser(ctx, app.dashboardURL(), ""); err != nil {
 			slog.Error("failed to open dashboard", "error", err)
 		}
 	})
 
 	app.systrayInterface.AddSeparator()
 
+	// Pinned PRs repeat above the sections; they're counted there, not here
+	app.addPinnedSection(ctx, view)
+
 	// Get PR counts
-	counts := app.countPRs()
+	counts := view.counts()
 
 	// Handle "No pull requests" case
 	if counts.IncomingTotal == 0 && counts.OutgoingTotal == 0 {
 		// No PRs to display
-		noPRs := app.systrayInterface.AddMenuItem("No pull requests", "")
-		noPRs.Disable()
+		if !app.addEmptyState(view) {
+			noPRs := app.systrayInterface.AddMenuItem(msg("menu.no_prs"), "")
+			noPRs.Disable()
+		}
 	} else {
-		// Incoming section
+		// Incoming section, grouped by teammate in team mode
 		if counts.IncomingTotal > 0 {
-			app.mu.RLock()
-			incoming := app.incoming
-			app.mu.RUnlock()
-			app.addPRSection(ctx, incoming, "Incoming", counts.IncomingBlocked)
+			if app.teamMode() {
+				app.addTeamSections(ctx, view.incoming)
+			} else {
+				app.addPRSection(ctx, view.incoming, "Incoming", counts.IncomingBlocked, counts.IncomingBlockedRepos)
+				app.addWeekAhead(ctx, view)
+			}
 		}
 
 		app.systrayInterface.AddSeparator()
@@ -771,16 +566,18 @@ func (app *App) rebuildMenu(ctx context.Context) {
 			"total_count", counts.OutgoingTotal,
 			"blocked_count", counts.OutgoingBlocked)
 		if counts.OutgoingTotal > 0 {
-			app.mu.RLock()
-			outgoing := app.outgoing
-			app.mu.RUnlock()
-			slog.Debug("[MENU] Outgoing PRs to add", "count", len(outgoing))
-			app.addPRSection(ctx, outgoing, "Outgoing", counts.OutgoingBlocked)
+			slog.Debug("[MENU] Outgoing PRs to add", "count", len(view.outgoing))
+			app.addPRSection(ctx, view.outgoing, "Outgoing", counts.OutgoingBlocked, counts.OutgoingBlockedRepos)
 		} else {
 			slog.Info("[MENU] No outgoing PRs to display after filtering")
 		}
 	}
 
+	app.addRecentlyCleared(ctx)
+	app.addDismissedPRs(ctx)
+	app.addFilteredPRs(ctx)
+	app.addNotificationHistory(ctx)
+
 	// Add static items at the end
 	app.addStaticMenuItems(ctx)
 
@@ -793,156 +590,278 @@ func (app *App) addStaticMenuItems(ctx context.Context) {
 
 	app.systrayInterface.AddSeparator()
 
-	// Hide orgs submenu
-	// Add 'Hide orgs' submenu
-	hideOrgsMenu := app.systrayInterface.AddMenuItem("Hide orgs", "Select organizations to hide PRs from")
+	// Focus on a single repository (transient)
+	app.addFocusMenu(ctx)
 
-	// Get combined list of seen orgs and hidden orgs
-	app.mu.RLock()
-	orgSet := make(map[string]bool)
-	// Add all seen orgs
-	for org := range app.seenOrgs {
-		orgSet[org] = true
-	}
-	// Add all hidden orgs (in case they're not in seenOrgs yet)
-	for org := range app.hiddenOrgs {
-		orgSet[org] = true
-	}
-	// Convert to sorted slice
-	orgs := make([]string, 0, len(orgSet))
-	for org := range orgSet {
-		orgs = append(orgs, org)
-	}
-	hiddenOrgs := make(map[string]bool)
-	maps.Copy(hiddenOrgs, app.hiddenOrgs)
-	app.mu.RUnlock()
+	// Organizations submenu with a notification policy per org
+	app.addOrgsMenu(ctx)
 
-	sort.Strings(orgs)
+	// PRs hidden forever, with unhide actions
+	app.addHiddenPRsMenu(ctx)
 
-	if len(orgs) == 0 {
-		noOrgsItem := hideOrgsMenu.AddSubMenuItem("No organizations found", "")
-		noOrgsItem.Disable()
-	} else {
-		// Add checkbox items for each org
-		for _, org := range orgs {
-			orgName := org // Capture for closure
-			// Add text checkmark for all platforms
-			var orgText string
-			if hiddenOrgs[orgName] {
-				orgText = "✓ " + orgName
-			} else {
-				orgText = orgName
-			}
-			orgItem := hideOrgsMenu.AddSubMenuItem(orgText, "")
+	// How PRs are labelled in the menu
+	app.addDisplayModeMenu(ctx)
 
-			orgItem.Click(func() {
-				app.mu.Lock()
-				if app.hiddenOrgs[orgName] {
-					delete(app.hiddenOrgs, orgName)
-					slog.Info("[SETTINGS] Unhiding org", "org", orgName)
-				} else {
-					app.hiddenOrgs[orgName] = true
-					slog.Info("[SETTINGS] Hiding org", "org", orgName)
-				}
-				app.mu.Unlock()
+	// How blocked incoming PRs are ordered
+	app.addIncomingSortMenu(ctx)
 
-				// Save settings
-				app.saveSettings()
+	// How long newly blocked PRs keep their emoji
+	app.addHighlightMenu(ctx)
 
-				// Rebuild menu to update checkmarks
-				app.rebuildMenu(ctx)
-			})
-		}
-	}
+	// What goose appends to the links it opens
+	app.addURLParamMenu(ctx)
 
-	// Hide stale PRs
-	// Add 'Hide stale PRs' option with text checkmark for all platforms
-	var hideStaleText string
-	if app.hideStaleIncoming {
-		hideStaleText = "✓ Hide stale PRs (>90 days)"
-	} else {
-		hideStaleText = "Hide stale PRs (>90 days)"
-	}
-	hideStaleItem := app.systrayInterface.AddMenuItem(hideStaleText, "")
-	hideStaleItem.Click(func() {
-		app.mu.Lock()
-		app.hideStaleIncoming = !app.hideStaleIncoming
-		hideStale := app.hideStaleIncoming
-		app.mu.Unlock()
+	app.addLanguageMenu(ctx)
 
-		// Save settings to disk
-		app.saveSettings()
+	// Yesterday's reviews and today's queue, as Markdown for a standup
+	app.addStandupItem(ctx)
 
-		slog.Info("[SETTINGS] Hide stale PRs toggled", "enabled", hideStale)
+	app.addStatsMenu()
 
-		// Rebuild menu to update checkmarks
-		app.rebuildMenu(ctx)
-	})
+	// The latest tray icon changes, for "the icon was wrong" reports
+	app.addDebugMenu()
+
+	// Title and label filters, edited in settings.json
+	app.addFiltersMenu()
 
-	// Add login item option (macOS only)
+	// Add login item option (macOS login items, or the Windows and Linux startup store)
 	addLoginItemUI(ctx, app)
 
-	// Audio cues
-	// Add 'Audio cues' option with text checkmark for all platforms
-	app.mu.RLock()
-	var audioText string
-	if app.enableAudioCues {
-		audioText = "✓ Honks enabled"
-	} else {
-		audioText = "Honks enabled"
-	}
-	app.mu.RUnlock()
-	audioItem := app.systrayInterface.AddMenuItem(audioText, "Play sounds for notifications")
-	audioItem.Click(func() {
-		app.mu.Lock()
-		app.enableAudioCues = !app.enableAudioCues
-		enabled := app.enableAudioCues
-		app.mu.Unlock()
+	for _, setting := range app.settingItems() {
+		item := setting // Capture for closure
+		state := item.state()
+		menuItem := app.systrayInterface.AddSettingItem(state)
+		if state.Disabled {
+			menuItem.Disable()
+			continue
+		}
+		menuItem.Click(func() {
+			item.OnToggle()
+			if item.Checked == nil {
+				return
+			}
 
-		slog.Info("[SETTINGS] Audio cues toggled", "enabled", enabled)
+			slog.Info("[SETTINGS] Setting toggled", "setting", item.ID, "enabled", item.Checked())
 
-		// Save settings to disk
-		app.saveSettings()
+			// Save settings to disk
+			app.saveSettings()
 
-		// Rebuild menu to update checkmarks
-		app.rebuildMenu(ctx)
-	})
+			if item.Refetch {
+				app.goTracked("update", func() { app.updatePRs(ctx) })
+			}
 
-	// Auto-open blocked PRs in browser
-	// Add 'Auto-open PRs' option with text checkmark for all platforms
-	app.mu.RLock()
-	var autoText string
-	if app.enableAutoBrowser {
-		autoText = "✓ Auto-open incoming PRs"
-	} else {
-		autoText = "Auto-open incoming PRs"
+			// Rebuild menu to update checkmarks
+			app.rebuildMenu(ctx)
+		})
 	}
-	app.mu.RUnlock()
-	autoOpenItem := app.systrayInterface.AddMenuItem(autoText, "Automatically open newly blocked PRs in browser (rate limited)")
-	autoOpenItem.Click(func() {
-		app.mu.Lock()
-		app.enableAutoBrowser = !app.enableAutoBrowser
-		enabled := app.enableAutoBrowser
-		// Reset rate limiter when toggling the feature
-		if !enabled {
-			app.browserRateLimiter.Reset()
-		}
-		app.mu.Unlock()
+}
 
-		slog.Info("[SETTINGS] Auto-open blocked PRs toggled", "enabled", enabled)
+// SettingItem declares a static menu entry. Toggles provide Checked, which is the
+// single source of truth for their checkmark; entries without Checked are plain actions.
+type SettingItem struct {
+	Checked  func() bool
+	OnToggle func()
+	ID       string
+	Label    string
+	Tooltip  string
+	Refetch  bool // Run an update after toggling: the setting changes which PRs are filtered
+	Disabled bool // Shown grayed out because the platform can't honor it; Tooltip says why
+}
 
-		// Save settings to disk
-		app.saveSettings()
+// state renders the item's current label and checkmark.
+func (s SettingItem) state() SettingState {
+	st := SettingState{ID: s.ID, Label: s.Label, Tooltip: s.Tooltip, Disabled: s.Disabled}
+	if s.Checked != nil {
+		st.Checkable = true
+		st.Checked = s.Checked()
+	}
+	return st
+}
 
-		// Rebuild menu to update checkmarks
-		app.rebuildMenu(ctx)
-	})
+// settingItems returns the static settings entries in menu order.
+// Callers must not hold app.mu, as Checked and OnToggle acquire it.
+func (app *App) settingItems() []SettingItem {
+	app.mu.RLock()
+	digestLabel := msg("settings.digest", snoozeEndLabel(digestAt(time.Now(), app.digestClock)))
+	if app.digestWeekdaysOnly {
+		digestLabel = msg("settings.digest.weekdays", snoozeEndLabel(digestAt(time.Now(), app.digestClock)))
+	}
+	autoOpenLabel := msg("settings.auto_open")
+	if !app.autoOpenSchedule.always() {
+		autoOpenLabel = msg("settings.auto_open.schedule", app.autoOpenSchedule.label())
+	}
+	app.mu.RUnlock()
+	items := []SettingItem{
+		{
+			ID:      "hide_stale",
+			Label:   msg("settings.hide_stale"),
+			Checked: func() bool { return app.readSetting(&app.hideStaleIncoming) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.hideStaleIncoming = !app.hideStaleIncoming
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:       "show_incoming",
+			Label:    msg("settings.show_incoming"),
+			Checked:  func() bool { return !app.readSetting(&app.hideIncoming) },
+			OnToggle: func() { app.toggleSection(&app.hideIncoming, &app.hideOutgoing, msg("section.incoming")) },
+		},
+		{
+			ID:       "show_outgoing",
+			Label:    msg("settings.show_outgoing"),
+			Checked:  func() bool { return !app.readSetting(&app.hideOutgoing) },
+			OnToggle: func() { app.toggleSection(&app.hideOutgoing, &app.hideIncoming, msg("section.outgoing")) },
+		},
+		{
+			ID:      "honks",
+			Label:   msg("settings.honks"),
+			Tooltip: msg("settings.honks.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.enableAudioCues) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.enableAudioCues = !app.enableAudioCues
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "auto_open",
+			Label:   autoOpenLabel,
+			Tooltip: msg("settings.auto_open.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.enableAutoBrowser) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.enableAutoBrowser = !app.enableAutoBrowser
+				// Reset rate limiter when toggling the feature
+				if !app.enableAutoBrowser {
+					app.browserRateLimiter.Reset()
+				}
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "refresh_animation",
+			Label:   msg("settings.refresh_animation"),
+			Tooltip: msg("settings.refresh_animation.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.enableRefreshAnimation) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.enableRefreshAnimation = !app.enableRefreshAnimation
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "count_repos",
+			Label:   msg("settings.count_repos"),
+			Tooltip: msg("settings.count_repos.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.countRepos) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.countRepos = !app.countRepos
+				app.mu.Unlock()
+				app.presentTrayState()
+			},
+		},
+		{
+			ID:      "group_bot_prs",
+			Label:   msg("settings.group_bot_prs"),
+			Tooltip: msg("settings.group_bot_prs.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.groupBotPRs) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.groupBotPRs = !app.groupBotPRs
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "drafts_block",
+			Label:   msg("settings.drafts_block"),
+			Tooltip: msg("settings.drafts_block.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.draftsBlock) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.draftsBlock = !app.draftsBlock
+				app.mu.Unlock()
+				app.presentTrayState()
+			},
+		},
+		{
+			ID:      "hide_non_default_base",
+			Label:   msg("settings.hide_non_default_base"),
+			Tooltip: msg("settings.hide_non_default_base.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.hideNonDefaultBase) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.hideNonDefaultBase = !app.hideNonDefaultBase
+				app.mu.Unlock()
+			},
+			Refetch: true,
+		},
+		{
+			ID:      "response_times",
+			Label:   msg("settings.response_times"),
+			Tooltip: msg("settings.response_times.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.trackResponseTimes) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.trackResponseTimes = !app.trackResponseTimes
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "daily_digest",
+			Label:   digestLabel,
+			Tooltip: msg("settings.digest.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.digestEnabled) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.digestEnabled = !app.digestEnabled
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "comment_bursts",
+			Label:   msg("settings.comment_bursts"),
+			Tooltip: msg("settings.comment_bursts.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.commentBurstNotify) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.commentBurstNotify = !app.commentBurstNotify
+				app.mu.Unlock()
+			},
+		},
+		{
+			ID:      "adaptive_interval",
+			Label:   msg("settings.adaptive_interval"),
+			Tooltip: msg("settings.adaptive_interval.tooltip"),
+			Checked: func() bool { return app.readSetting(&app.adaptiveInterval) },
+			OnToggle: func() {
+				app.mu.Lock()
+				app.adaptiveInterval = !app.adaptiveInterval
+				app.mu.Unlock()
+				app.updateActivity("adaptive interval toggled")
+			},
+		},
+		{
+			ID:    "quit",
+			Label: msg("menu.quit"),
+			OnToggle: func() {
+				slog.Info("Quit requested by user")
+				app.systrayInterface.Quit()
+			},
+		},
+	}
+	if dock, ok := app.dockBadgeSetting(); ok {
+		at := slices.IndexFunc(items, func(s SettingItem) bool { return s.ID == "count_repos" })
+		items = slices.Insert(items, at+1, dock)
+	}
+	return items
+}
 
-	// Quit
-	// Add 'Quit' option
-	quitItem := app.systrayInterface.AddMenuItem("Quit", "")
-	quitItem.Click(func() {
-		slog.Info("Quit requested by user")
-		app.systrayInterface.Quit()
-	})
+// readSetting reads a boolean setting under the read lock.
+func (app *App) readSetting(field *bool) bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return *field
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// GitHub answers 410 Gone for deleted resources and 451 Unavailable For Legal Reasons
+// for repositories taken down (e.g. by a DMCA notice). Neither changes on retry, so a
+// PR that gets one is dropped from the lists, caches, and state at once and never
+// looked up again, and a search that gets one is reported as such rather than as a
+// connection problem.
+
+// goneStatus returns 410 or 451 when err carries that status from GitHub, directly or
+// through Turn, and 0 otherwise.
+func goneStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		switch code := ghErr.Response.StatusCode; code {
+		case http.StatusGone, http.StatusUnavailableForLegalReasons:
+			return code
+		default:
+			return 0
+		}
+	}
+	// turnclient reports non-200 responses as "api request failed with status N: ..."
+	msg := err.Error()
+	for _, code := range []int{http.StatusGone, http.StatusUnavailableForLegalReasons} {
+		if strings.Contains(msg, "status "+strconv.Itoa(code)) {
+			return code
+		}
+	}
+	return 0
+}
+
+// quarantinePR records a permanent failure for a PR. One that is gone is also removed
+// from the lists and the in-memory Turn cache right away.
+func (app *App) quarantinePR(url string, err error) {
+	if app.quarantine == nil {
+		return
+	}
+	app.quarantine.recordFailure(url, err)
+	if goneStatus(err) == 0 {
+		return
+	}
+	same := func(pr PR) bool { return pr.URL == url }
+	app.mu.Lock()
+	app.incoming = slices.DeleteFunc(slices.Clone(app.incoming), same)
+	app.outgoing = slices.DeleteFunc(slices.Clone(app.outgoing), same)
+	delete(app.eventPRs, url)
+	app.mu.Unlock()
+	if app.turnMemory != nil {
+		app.turnMemory.invalidate(url)
+	}
+}
+
+// SearchUnavailableError reports a GitHub search answered with 410 or 451.
+type SearchUnavailableError struct {
+	Err        error
+	Query      string
+	StatusCode int
+}
+
+func (e *SearchUnavailableError) Error() string {
+	return fmt.Sprintf("github search unavailable (%d %s): %v", e.StatusCode, http.StatusText(e.StatusCode), e.Err)
+}
+
+func (e *SearchUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// asSearchUnavailable returns the SearchUnavailableError in err, including one inside a
+// PartialError, or nil.
+func asSearchUnavailable(err error) *SearchUnavailableError {
+	var unavailable *SearchUnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable
+	}
+	return nil
+}
+
+// searchRefused reports whether GitHub answered the last update's searches with 410 or 451.
+func (app *App) searchRefused() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.searchUnavailable != nil
+}
+
+// setSearchUnavailable records the outcome of an update: nil once no search is refused.
+func (app *App) setSearchUnavailable(unavailable *SearchUnavailableError) {
+	app.mu.Lock()
+	was := app.searchUnavailable != nil
+	app.searchUnavailable = unavailable
+	app.mu.Unlock()
+	if unavailable == nil && was {
+		slog.Info("[GITHUB] Searches are answered again")
+	}
+}
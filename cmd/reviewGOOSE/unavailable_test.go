@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func TestGoneStatus(t *testing.T) {
+	ghErr := func(code int) error {
+		return &github.ErrorResponse{Response: &http.Response{StatusCode: code}}
+	}
+	tests := []struct {
+		err  error
+		name string
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "github 410", err: ghErr(http.StatusGone), want: http.StatusGone},
+		{name: "github 451 wrapped", err: fmt.Errorf("fetch: %w", ghErr(http.StatusUnavailableForLegalReasons)), want: http.StatusUnavailableForLegalReasons},
+		{name: "github 404", err: ghErr(http.StatusNotFound), want: 0},
+		{name: "turn 451", err: errors.New("api request failed with status 451: unavailable"), want: http.StatusUnavailableForLegalReasons},
+		{name: "turn 410", err: errors.New("api request failed with status 410: gone"), want: http.StatusGone},
+		{name: "turn 500", err: errors.New("api request failed with status 500: oops"), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goneStatus(tt.err); got != tt.want {
+				t.Errorf("goneStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+			if tt.want != 0 && !isPermanentPRError(tt.err) {
+				t.Errorf("isPermanentPRError(%v) = false for a gone PR", tt.err)
+			}
+		})
+	}
+}
+
+func TestQuarantineGonePRIsPermanent(t *testing.T) {
+	now := time.Now()
+	q := newPRQuarantine()
+	q.now = func() time.Time { return now }
+
+	const url = "https://github.com/org/takedown/pull/1"
+	if !q.recordFailure(url, errors.New("api request failed with status 451: unavailable")) {
+		t.Fatal("a 451 should quarantine the PR on the first failure")
+	}
+	// A stray success, e.g. from a stale cache, doesn't bring it back
+	q.recordSuccess(url)
+	now = now.Add(30 * 24 * time.Hour)
+	if !q.isQuarantined(url) || !q.shouldSkip(url) {
+		t.Error("a gone PR should stay quarantined and never be re-checked")
+	}
+}
+
+func TestGonePRDroppedWhileOthersRefresh(t *testing.T) {
+	const (
+		healthyURL = "https://github.com/org/public/pull/1"
+		goneURL    = "https://github.com/org/takedown/pull/2"
+	)
+	var mu sync.Mutex
+	requests := map[string]int{}
+	turnClient := newFakeTurn(t, func(_ context.Context, req turn.CheckRequest) fakeVerdict {
+		mu.Lock()
+		requests[req.URL]++
+		mu.Unlock()
+		if req.URL == goneURL {
+			return fakeVerdict{status: http.StatusUnavailableForLegalReasons}
+		}
+		return fakeVerdict{next: map[string]any{"testuser": map[string]any{"kind": "review", "reason": "needs review"}}}
+	})
+
+	login := "testuser"
+	app := newFocusTestApp(time.Hour)
+	app.turnClient = turnClient
+	app.currentUser = &github.User{Login: &login}
+	app.cacheDir = t.TempDir()
+	app.noCache = true
+	app.quarantine = newPRQuarantine()
+	app.turnMemory = newTurnMemory(turnMemoryEntries)
+
+	issue := func(url string) *github.Issue {
+		return &github.Issue{
+			HTMLURL:          github.String(url),
+			User:             &github.User{Login: github.String("author")},
+			UpdatedAt:        &github.Timestamp{Time: time.Now()},
+			PullRequestLinks: &github.PullRequestLinks{},
+		}
+	}
+	issues := []*github.Issue{issue(healthyURL), issue(goneURL)}
+	prs := func() []PR {
+		return []PR{
+			{URL: healthyURL, Repository: "org/public", Number: 1},
+			{URL: goneURL, Repository: "org/takedown", Number: 2},
+		}
+	}
+	app.incoming = prs()
+
+	for range 2 {
+		incoming := prs()
+		var outgoing []PR
+		app.fetchTurnDataSync(context.Background(), issues, login, &incoming, &outgoing)
+		i := slices.IndexFunc(incoming, func(pr PR) bool { return pr.URL == healthyURL })
+		if i < 0 || !incoming[i].NeedsReview {
+			t.Fatalf("healthy PR lost its Turn data: %+v", incoming)
+		}
+	}
+
+	if !app.quarantine.isQuarantined(goneURL) {
+		t.Error("expected the 451 PR to be quarantined")
+	}
+	if app.quarantine.isQuarantined(healthyURL) {
+		t.Error("the healthy PR should not be quarantined")
+	}
+	if slices.ContainsFunc(app.incoming, func(pr PR) bool { return pr.URL == goneURL }) {
+		t.Error("expected the 451 PR to be dropped from the incoming list right away")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// The Turn client may retry once internally, but the second cycle skips the PR
+	if requests[goneURL] > 2 {
+		t.Errorf("Turn server got %d requests for the gone PR, want it looked up only once", requests[goneURL])
+	}
+	if requests[healthyURL] == 0 {
+		t.Error("the healthy PR was never looked up")
+	}
+}
+
+func TestSearchUnavailableIsNotAConnectionFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	server := newPartialSearchServer(t, now)
+	app := newPartialFetchTestApp(t, server.URL)
+	app.githubCircuit = newCircuitBreaker("github", 1, time.Hour)
+
+	server.mu.Lock()
+	server.fail, server.failStatus = "review:none", http.StatusUnavailableForLegalReasons
+	server.mu.Unlock()
+	app.updatePRs(ctx)
+
+	if app.searchUnavailable == nil || app.searchUnavailable.StatusCode != http.StatusUnavailableForLegalReasons {
+		t.Fatalf("searchUnavailable = %+v, want the 451 search", app.searchUnavailable)
+	}
+	if !strings.Contains(app.searchUnavailable.Query, "review:none") {
+		t.Errorf("unavailable query = %q, want the review:none search", app.searchUnavailable.Query)
+	}
+	if st := app.githubCircuit.status(); st.state != circuitClosed {
+		t.Errorf("circuit state = %s, a refused search shouldn't trip it", st.state)
+	}
+	if app.consecutiveFailures != 0 {
+		t.Errorf("consecutiveFailures = %d, want 0", app.consecutiveFailures)
+	}
+	want := "⛔ GitHub refuses one of your searches (HTTP 451)"
+	if got := app.partialFetchTitle(); got != want {
+		t.Errorf("partialFetchTitle() = %q, want %q", got, want)
+	}
+	if !slices.ContainsFunc(app.incoming, func(pr PR) bool { return pr.URL == involvesPR }) {
+		t.Error("the search that succeeded should still list its PR")
+	}
+
+	server.setFail("")
+	app.updatePRs(ctx)
+	if app.searchRefused() || app.partialFetchTitle() != "" {
+		t.Error("the notice should clear once the search is answered again")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// TestUpdateCycleTimesOutOnHungTurnServer verifies that a Turn server that never
+// responds can't wedge updateMutex: the cycle is abandoned and the next one runs.
+func TestUpdateCycleTimesOutOnHungTurnServer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	githubServer := newFakeGitHub(t, func(*http.Request) fakeSearch {
+		return fakeSearch{prs: []fakePR{{repo: "test/repo", number: 1, title: "Hung PR", updated: now}}}
+	})
+
+	var hang atomic.Bool
+	hang.Store(true)
+	turnClient := newFakeTurn(t, func(ctx context.Context, _ turn.CheckRequest) fakeVerdict {
+		if hang.Load() {
+			// Never respond, until the client gives up
+			<-ctx.Done()
+		}
+		return fakeVerdict{next: map[string]any{"testuser": reviewAction()}}
+	})
+
+	app := newFetchTestApp(t, githubServer.URL, turnClient, "testuser")
+	app.noCache = true
+	app.updateInterval = 100 * time.Millisecond
+
+	start := time.Now()
+	app.updatePRs(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("update cycle took %v, expected it to be abandoned after %v", elapsed, app.updateCycleTimeout())
+	}
+
+	app.mu.RLock()
+	failures := app.consecutiveFailures
+	lastErr := app.lastFetchError
+	app.mu.RUnlock()
+	if failures != 1 {
+		t.Errorf("consecutiveFailures = %d, want 1", failures)
+	}
+	if !strings.Contains(lastErr, errUpdateTimedOut.Error()) {
+		t.Errorf("lastFetchError = %q, want it to mention %q", lastErr, errUpdateTimedOut)
+	}
+
+	// The mutex must have been released so the next cycle can run
+	hang.Store(false)
+	app.updatePRs(ctx)
+
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.consecutiveFailures != 0 {
+		t.Errorf("consecutiveFailures after recovery = %d, want 0 (last error: %s)", app.consecutiveFailures, app.lastFetchError)
+	}
+	if len(app.incoming) != 1 || !app.incoming[0].NeedsReview {
+		t.Errorf("expected one incoming PR needing review after recovery, got %+v", app.incoming)
+	}
+}
+
+func TestFetchPRsWithDeadlineParentCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	app := &App{
+		mu:             sync.RWMutex{},
+		updateInterval: time.Minute,
+	}
+	if _, _, err := app.fetchPRsWithDeadline(ctx); errors.Is(err, errUpdateTimedOut) {
+		t.Errorf("cancelled parent context should not be reported as a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+// URLParam controls which query parameter goose appends to the links it opens.
+// Deep links like /checks are part of the path, so they work in every mode.
+type URLParam string
+
+const (
+	// URLParamAction appends ?goose= with the PR's action, or 1 for plain clicks.
+	URLParamAction URLParam = "action"
+	// URLParamGooseOnly appends ?goose=1 to every link.
+	URLParamGooseOnly URLParam = "goose_only"
+	// URLParamOff opens links unchanged.
+	URLParamOff URLParam = "off"
+)
+
+// urlParams lists the URL parameter modes in menu order.
+var urlParams = []URLParam{URLParamAction, URLParamGooseOnly, URLParamOff}
+
+// label returns the human-readable name shown in the menu.
+func (p URLParam) label() string {
+	switch p {
+	case URLParamGooseOnly:
+		return msg("url_param.goose_only")
+	case URLParamOff:
+		return msg("url_param.off")
+	default:
+		return msg("url_param.action")
+	}
+}
+
+// valid reports whether p is a known URL parameter mode.
+func (p URLParam) valid() bool {
+	switch p {
+	case URLParamAction, URLParamGooseOnly, URLParamOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// value returns the ?goose= value to append for an open with gooseParam, or "" to
+// leave the link unchanged.
+func (p URLParam) value(gooseParam string) string {
+	switch p {
+	case URLParamOff:
+		return ""
+	case URLParamGooseOnly:
+		return "1"
+	default:
+		if gooseParam == "" {
+			return "1"
+		}
+		return gooseParam
+	}
+}
+
+// urlParam returns the configured URL parameter mode.
+func (app *App) urlParam() URLParam {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if !app.openedURLParam.valid() {
+		return URLParamAction
+	}
+	return app.openedURLParam
+}
+
+// addURLParamMenu adds the "Add to opened links" submenu.
+func (app *App) addURLParamMenu(ctx context.Context) {
+	paramMenu := app.systrayInterface.AddMenuItem(msg("url_param.menu"), msg("url_param.menu.tooltip"))
+
+	current := app.urlParam()
+	for _, p := range urlParams {
+		mode := p // Capture for closure
+		text := mode.label()
+		if mode == current {
+			text = "✓ " + text
+		}
+		paramMenu.AddSubMenuItem(text, "").Click(func() {
+			app.mu.Lock()
+			app.openedURLParam = mode
+			app.mu.Unlock()
+
+			slog.Info("[SETTINGS] Opened link parameter changed", "mode", mode)
+
+			app.saveSettings()
+			app.rebuildMenu(ctx)
+		})
+	}
+}
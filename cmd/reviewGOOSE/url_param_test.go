@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// finalURLBrowser records the URLs the system browser would be handed.
+type finalURLBrowser struct {
+	urls []string
+	mu   sync.Mutex
+}
+
+func (b *finalURLBrowser) Open(_ context.Context, rawURL, gooseParam string) error {
+	finalURL, err := gooseURL(rawURL, gooseParam)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.urls = append(b.urls, finalURL)
+	return nil
+}
+
+func TestOpenedURLParam(t *testing.T) {
+	const pr = "https://github.com/acme/widgets/pull/1"
+	tests := []struct {
+		mode   URLParam
+		rawURL string
+		param  string
+		want   string
+	}{
+		{mode: "", rawURL: pr, param: "review", want: pr + "?goose=review"},
+		{mode: URLParamAction, rawURL: pr, want: pr + "?goose=1"},
+		{mode: URLParamAction, rawURL: pr, param: "review", want: pr + "?goose=review"},
+		{mode: URLParamAction, rawURL: pr + "/checks", param: string(actionApproveWorkflows), want: pr + "/checks?goose=approve_workflows"},
+		{mode: URLParamAction, rawURL: pr + "/files?w=1", param: "review", want: pr + "/files?goose=review&w=1"},
+		{mode: URLParamGooseOnly, rawURL: pr, param: "review", want: pr + "?goose=1"},
+		{mode: URLParamGooseOnly, rawURL: pr + "/files?w=1", want: pr + "/files?goose=1&w=1"},
+		{mode: URLParamOff, rawURL: pr, param: "review", want: pr},
+		{mode: URLParamOff, rawURL: pr + "/checks", param: string(actionApproveWorkflows), want: pr + "/checks"},
+		{mode: URLParamOff, rawURL: pr + "/files?w=1", want: pr + "/files?w=1"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+" "+tt.rawURL+" "+tt.param, func(t *testing.T) {
+			app := newFocusTestApp(0)
+			browser := &finalURLBrowser{}
+			app.browser = browser
+			app.openedURLParam = tt.mode
+			if err := app.openBrowser(context.Background(), tt.rawURL, tt.param); err != nil {
+				t.Fatal(err)
+			}
+			if len(browser.urls) != 1 || browser.urls[0] != tt.want {
+				t.Errorf("opened %q, want %q", browser.urls, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenedURLParamPersists(t *testing.T) {
+	app, mock := newSettingsMenuTestApp(t)
+	if got := app.urlParam(); got != URLParamAction {
+		t.Fatalf("default mode = %q, want %q", got, URLParamAction)
+	}
+
+	app.rebuildMenu(context.Background())
+	var menu *MockMenuItem
+	for _, item := range mock.items {
+		if item.title == msg("url_param.menu") {
+			menu = item
+		}
+	}
+	if menu == nil || len(menu.subItems) != len(urlParams) {
+		t.Fatalf("opened link submenu = %+v, want one entry per mode", menu)
+	}
+	if title := menu.subItems[0].(*MockMenuItem).title; title != "✓ "+URLParamAction.label() {
+		t.Errorf("first entry = %q, want the default checked", title)
+	}
+	menu.subItems[2].(*MockMenuItem).clickHandler()
+
+	restarted := &App{mu: sync.RWMutex{}, systrayInterface: &MockSystray{}}
+	restarted.loadSettings()
+	if got := restarted.urlParam(); got != URLParamOff {
+		t.Errorf("mode after restarting = %q, want %q", got, URLParamOff)
+	}
+}
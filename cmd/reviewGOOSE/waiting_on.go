@@ -0,0 +1,76 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+// pendingUser is someone other than me that Turn lists as owing an action on my PR.
+type pendingUser struct {
+	since    time.Time
+	login    string
+	kind     string
+	critical bool
+}
+
+// isBotLogin reports whether a login looks like an automation account.
+func isBotLogin(login string) bool {
+	l := strings.ToLower(login)
+	return strings.HasSuffix(l, "[bot]") || strings.HasSuffix(l, "-bot")
+}
+
+// pendingUsers returns the humans other than me with a next action, blocking ones first,
+// then whoever has been waited on longest. Bots are left out; nudging them doesn't help.
+func pendingUsers(next map[string]turn.Action, me string) []pendingUser {
+	var users []pendingUser
+	for login, action := range next {
+		if strings.EqualFold(login, me) || isBotLogin(login) {
+			continue
+		}
+		users = append(users, pendingUser{login: login, kind: string(action.Kind), since: action.Since, critical: action.Critical})
+	}
+	slices.SortFunc(users, func(a, b pendingUser) int {
+		if a.critical != b.critical {
+			if a.critical {
+				return -1
+			}
+			return 1
+		}
+		if c := a.since.Compare(b.since); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.login, b.login)
+	})
+	return users
+}
+
+// waitingOnLabel is the short menu suffix for a PR waiting on others, or "" if it isn't.
+func waitingOnLabel(pr PR) string {
+	switch {
+	case pr.WaitingOnCount > 1:
+		return msg("waiting.many", pr.WaitingOnCount)
+	case pr.WaitingOn != "":
+		return msg("waiting.one.short", pr.WaitingOn)
+	default:
+		return ""
+	}
+}
+
+// waitingOnDetail is the tooltip line naming who a PR waits on and for how long.
+func waitingOnDetail(pr PR, now time.Time) string {
+	if pr.WaitingOnCount > 1 {
+		return msg("waiting.many", pr.WaitingOnCount)
+	}
+	if pr.WaitingOn == "" {
+		return ""
+	}
+	verb := strings.ReplaceAll(pr.WaitingOnKind, "_", " ")
+	if pr.WaitingSince.IsZero() {
+		return msg("waiting.one", pr.WaitingOn, verb)
+	}
+	return msg("waiting.one.since", pr.WaitingOn, verb, stuckDuration(now.Sub(pr.WaitingSince)))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+)
+
+const waitingOnTestURL = "https://github.com/acme/widgets/pull/9"
+
+// waitingTurnData returns Turn data with the given next actions.
+func waitingTurnData(next map[string]turn.Action) *turn.CheckResponse {
+	data := &turn.CheckResponse{}
+	data.Analysis.NextAction = next
+	return data
+}
+
+func TestWaitingOnOwnPR(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		next      map[string]turn.Action
+		wantLogin string
+		wantCount int
+		wantLabel string
+	}{
+		{
+			name:      "single reviewer",
+			next:      map[string]turn.Action{"bob": {Kind: turn.ActionReview, Since: now.Add(-48 * time.Hour)}},
+			wantLogin: "bob",
+			wantCount: 1,
+			wantLabel: "acme/widgets #9 — waiting on @bob",
+		},
+		{
+			name: "multiple reviewers",
+			next: map[string]turn.Action{
+				"carol": {Kind: turn.ActionReview, Since: now.Add(-time.Hour)},
+				"bob":   {Kind: turn.ActionReview, Since: now.Add(-48 * time.Hour)},
+				"dave":  {Kind: turn.ActionApprove, Since: now.Add(-2 * time.Hour)},
+				"me":    {Kind: turn.ActionRespond},
+			},
+			wantLogin: "bob", // Waited on longest
+			wantCount: 3,
+			wantLabel: "acme/widgets #9 — waiting on 3 reviewers",
+		},
+		{
+			name:      "bots only",
+			next:      map[string]turn.Action{"renovate[bot]": {Kind: turn.ActionReview}, "ci-bot": {Kind: turn.ActionMerge}},
+			wantLabel: "acme/widgets #9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outgoing := []PR{{Repository: "acme/widgets", Number: 9, URL: waitingOnTestURL}}
+			result := &prResult{url: waitingOnTestURL, turnData: waitingTurnData(tt.next), isOwner: true}
+			applyTurnResult(&outgoing[0], result, "me", now)
+
+			pr := outgoing[0]
+			if pr.WaitingOn != tt.wantLogin || pr.WaitingOnCount != tt.wantCount {
+				t.Errorf("WaitingOn = %q (%d), want %q (%d)", pr.WaitingOn, pr.WaitingOnCount, tt.wantLogin, tt.wantCount)
+			}
+			if pr.NeedsReview {
+				// "me" has an action in the multiple case; clear it to check the non-blocked label.
+				pr.NeedsReview, pr.IsBlocked, pr.ActionKind = false, false, ""
+			}
+			if got := formatMenuLabel(pr, DisplayRepoNumber, 0); got != tt.wantLabel {
+				t.Errorf("label = %q, want %q", got, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestWaitingOnTooltip(t *testing.T) {
+	pr := PR{
+		Repository:     "acme/widgets",
+		Number:         9,
+		Title:          "Add retries",
+		WaitingOn:      "bob",
+		WaitingOnKind:  "review",
+		WaitingOnCount: 1,
+		WaitingSince:   time.Now().Add(-49 * time.Hour),
+	}
+	if got := formatMenuTooltip(pr, DisplayRepoNumber, "3d"); !strings.HasSuffix(got, " - waiting on @bob to review, 2d") {
+		t.Errorf("tooltip = %q, want the waiting-on detail", got)
+	}
+
+	pr.WaitingOnCount = 3
+	if got := formatMenuTooltip(pr, DisplayRepoNumber, "3d"); !strings.HasSuffix(got, " - waiting on 3 reviewers") {
+		t.Errorf("tooltip = %q, want the reviewer count", got)
+	}
+}
+
+func TestWaitingOnOnlyForOwnPRs(t *testing.T) {
+	incoming := []PR{{Repository: "acme/widgets", Number: 9, URL: waitingOnTestURL}}
+	next := map[string]turn.Action{"bob": {Kind: turn.ActionReview}}
+	applyTurnResult(&incoming[0], &prResult{url: waitingOnTestURL, turnData: waitingTurnData(next)}, "me", time.Now())
+	if incoming[0].WaitingOn != "" || incoming[0].WaitingOnCount != 0 {
+		t.Errorf("incoming PR should not track who else it waits on: %+v", incoming[0])
+	}
+}
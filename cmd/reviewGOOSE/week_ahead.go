@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// The "This week" submenu projects how the blocked incoming queue will age: for each
+// of the next seven days, the PRs whose wait crosses the review SLA (review_sla, 48h by
+// default) that day, plus the ones already past it. A PR's wait starts when Turn says my
+// action became due, else when it was first seen blocked.
+
+// defaultReviewSLA is how long a PR may wait on my review unless settings say otherwise.
+const defaultReviewSLA = 48 * time.Hour
+
+// weekAheadDays is how many days, today included, the projection covers.
+const weekAheadDays = 7
+
+// slaOverdue is the projection bucket for PRs already past the SLA.
+const slaOverdue = -1
+
+// slaStart returns when the wait on pr started, or the zero time if that's unknown.
+func slaStart(pr *PR) time.Time {
+	if !pr.ActionSince.IsZero() {
+		return pr.ActionSince
+	}
+	return pr.FirstBlockedAt
+}
+
+// calendarDaysBetween counts the midnights between a and b in a's location, so a DST
+// change doesn't shift a deadline into the wrong day.
+func calendarDaysBetween(a, b time.Time) int {
+	b = b.In(a.Location())
+	da := time.Date(a.Year(), a.Month(), a.Day(), 12, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 12, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// projectSLA buckets prs by the day, counted from now's date, their wait crosses sla:
+// 0 for later today, 1 for tomorrow, up to weekAheadDays-1, and slaOverdue for PRs
+// already past it. PRs crossing later, or without a known start, are left out. Each
+// bucket is ordered by deadline.
+func projectSLA(prs []PR, sla time.Duration, now time.Time) map[int][]PR {
+	buckets := make(map[int][]PR)
+	for i := range prs {
+		start := slaStart(&prs[i])
+		if start.IsZero() {
+			continue
+		}
+		deadline := start.Add(sla)
+		day := slaOverdue
+		if deadline.After(now) {
+			day = calendarDaysBetween(now, deadline)
+		}
+		if day >= weekAheadDays {
+			continue
+		}
+		buckets[day] = append(buckets[day], prs[i])
+	}
+	for _, bucket := range buckets {
+		slices.SortStableFunc(bucket, func(a, b PR) int {
+			return slaStart(&a).Compare(slaStart(&b))
+		})
+	}
+	return buckets
+}
+
+// formatSLA renders an SLA the way review_sla is written, e.g. "48h".
+func formatSLA(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return strconv.Itoa(int(d.Hours())) + "h"
+	}
+	return formatResponseTime(d)
+}
+
+// weekdayName is the translated name of a weekday.
+func weekdayName(d time.Weekday) string {
+	switch d {
+	case time.Monday:
+		return msg("weekday.monday")
+	case time.Tuesday:
+		return msg("weekday.tuesday")
+	case time.Wednesday:
+		return msg("weekday.wednesday")
+	case time.Thursday:
+		return msg("weekday.thursday")
+	case time.Friday:
+		return msg("weekday.friday")
+	case time.Saturday:
+		return msg("weekday.saturday")
+	default:
+		return msg("weekday.sunday")
+	}
+}
+
+// weekAheadLine is the summary line for one projection bucket, e.g.
+// "Today: 3 PRs cross the 48h SLA" or "Tomorrow: 1 more".
+func weekAheadLine(day, count int, sla time.Duration, now time.Time) string {
+	switch day {
+	case slaOverdue:
+		if count == 1 {
+			return msg("week.overdue.one", formatSLA(sla))
+		}
+		return msg("week.overdue", count, formatSLA(sla))
+	case 0:
+		if count == 1 {
+			return msg("week.today.one", formatSLA(sla))
+		}
+		return msg("week.today", count, formatSLA(sla))
+	case 1:
+		return msg("week.tomorrow", count)
+	default:
+		return msg("week.day", weekdayName(now.AddDate(0, 0, day).Weekday()), count)
+	}
+}
+
+// slaThreshold returns the configured review SLA.
+func (app *App) slaThreshold() time.Duration {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.reviewSLA <= 0 {
+		return defaultReviewSLA
+	}
+	return app.reviewSLA
+}
+
+// weekAhead projects the view's blocked incoming PRs, filling in when each was first
+// seen blocked from the state manager.
+func (app *App) weekAhead(view *prView) map[int][]PR {
+	sla := app.slaThreshold()
+	var blocked []PR
+	for i := range view.incoming {
+		pr := view.incoming[i]
+		if !pr.NeedsReview {
+			continue
+		}
+		if pr.FirstBlockedAt.IsZero() && app.stateManager != nil {
+			if state, ok := app.stateManager.PRState(pr.URL); ok {
+				pr.FirstBlockedAt = state.FirstBlockedAt
+			}
+		}
+		blocked = append(blocked, pr)
+	}
+	return projectSLA(blocked, sla, view.at)
+}
+
+// weekAheadLines returns the buckets' days in menu order with their summary lines.
+func (app *App) weekAheadLines(view *prView, buckets map[int][]PR) (days []int, lines []string) {
+	sla := app.slaThreshold()
+	for day := slaOverdue; day < weekAheadDays; day++ {
+		if len(buckets[day]) == 0 {
+			continue
+		}
+		days = append(days, day)
+		lines = append(lines, weekAheadLine(day, len(buckets[day]), sla, view.at))
+	}
+	return days, lines
+}
+
+// weekAheadTitles returns the "This week" submenu's titles for change detection, or
+// nil when nothing crosses the SLA this week.
+func (app *App) weekAheadTitles(view *prView) []string {
+	buckets := app.weekAhead(view)
+	days, lines := app.weekAheadLines(view, buckets)
+	if len(days) == 0 {
+		return nil
+	}
+	displayMode, labelWidth := app.menuLabelSettings()
+	titles := []string{msg("week.menu")}
+	for i, day := range days {
+		titles = append(titles, lines[i])
+		for _, pr := range buckets[day] {
+			titles = append(titles, formatMenuLabel(pr, displayMode, labelWidth))
+		}
+	}
+	return titles
+}
+
+// addWeekAhead adds the "This week" submenu, each day expanding to its PRs.
+func (app *App) addWeekAhead(ctx context.Context, view *prView) {
+	buckets := app.weekAhead(view)
+	days, lines := app.weekAheadLines(view, buckets)
+	if len(days) == 0 {
+		return
+	}
+	displayMode, labelWidth := app.menuLabelSettings()
+	weekMenu := app.systrayInterface.AddMenuItem(msg("week.menu"), msg("week.menu.tooltip"))
+	for i, day := range days {
+		dayItem := weekMenu.AddSubMenuItem(lines[i], "")
+		for _, pr := range buckets[day] {
+			url := prLink(&pr)
+			dayItem.AddSubMenuItem(formatMenuLabel(pr, displayMode, labelWidth),
+				formatMenuTooltip(pr, displayMode, prAge(pr.UpdatedAt))).Click(func() {
+				if err := app.openBrowser(ctx, url, ""); err != nil {
+					slog.Error("failed to open url", "error", err)
+					return
+				}
+				app.resumeAutoOpen(ctx)
+			})
+		}
+	}
+}
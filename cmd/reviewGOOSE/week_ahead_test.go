@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func slaTestPR(n int, since time.Time) PR {
+	return PR{
+		Repository: "org/repo", Number: n, URL: "https://github.com/org/repo/pull/" + strconv.Itoa(n),
+		NeedsReview: true, ActionKind: "review", ActionSince: since, UpdatedAt: since,
+	}
+}
+
+// bucketNumbers maps each bucket to its PR numbers, in order.
+func bucketNumbers(buckets map[int][]PR) map[int][]int {
+	out := make(map[int][]int, len(buckets))
+	for day, prs := range buckets {
+		for i := range prs {
+			out[day] = append(out[day], prs[i].Number)
+		}
+	}
+	return out
+}
+
+func TestProjectSLA(t *testing.T) {
+	loc := time.FixedZone("test", -5*3600)
+	now := time.Date(2026, 3, 10, 23, 30, 0, 0, loc) // A Tuesday, half an hour before midnight
+	const sla = 48 * time.Hour
+	// deadlineAt returns a start whose SLA deadline falls at t
+	deadlineAt := func(t time.Time) time.Time { return t.Add(-sla) }
+	midnight := time.Date(2026, 3, 11, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		prs  []PR
+		want map[int][]int
+	}{
+		{name: "empty queue", prs: nil, want: map[int][]int{}},
+		{
+			name: "unknown start is skipped",
+			prs:  []PR{{Number: 1, NeedsReview: true}},
+			want: map[int][]int{},
+		},
+		{
+			name: "already overdue",
+			prs:  []PR{slaTestPR(1, deadlineAt(now.Add(-time.Hour))), slaTestPR(2, deadlineAt(now.Add(-72*time.Hour)))},
+			want: map[int][]int{slaOverdue: {2, 1}},
+		},
+		{
+			name: "deadline exactly now is overdue",
+			prs:  []PR{slaTestPR(1, deadlineAt(now))},
+			want: map[int][]int{slaOverdue: {1}},
+		},
+		{
+			name: "last second of today",
+			prs:  []PR{slaTestPR(1, deadlineAt(midnight.Add(-time.Second)))},
+			want: map[int][]int{0: {1}},
+		},
+		{
+			name: "midnight is tomorrow",
+			prs:  []PR{slaTestPR(1, deadlineAt(midnight))},
+			want: map[int][]int{1: {1}},
+		},
+		{
+			name: "last day of the week",
+			prs:  []PR{slaTestPR(1, deadlineAt(midnight.AddDate(0, 0, 6).Add(-time.Second)))},
+			want: map[int][]int{6: {1}},
+		},
+		{
+			name: "past the week is left out",
+			prs:  []PR{slaTestPR(1, deadlineAt(midnight.AddDate(0, 0, 6)))},
+			want: map[int][]int{},
+		},
+		{
+			name: "first seen blocked when Turn has no action time",
+			prs:  []PR{{Number: 1, NeedsReview: true, FirstBlockedAt: deadlineAt(now.Add(10 * time.Minute))}},
+			want: map[int][]int{0: {1}},
+		},
+		{
+			name: "Turn's action time wins",
+			prs: []PR{{
+				Number: 1, NeedsReview: true,
+				ActionSince: deadlineAt(midnight.Add(time.Hour)), FirstBlockedAt: deadlineAt(now.Add(-time.Hour)),
+			}},
+			want: map[int][]int{1: {1}},
+		},
+		{
+			name: "mixed queue ordered by deadline",
+			prs: []PR{
+				slaTestPR(3, deadlineAt(midnight.Add(5*time.Hour))),
+				slaTestPR(1, deadlineAt(now.Add(-time.Minute))),
+				slaTestPR(4, deadlineAt(midnight.Add(2*time.Hour))),
+				slaTestPR(2, deadlineAt(now.Add(time.Minute))),
+			},
+			want: map[int][]int{slaOverdue: {1}, 0: {2}, 1: {4, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bucketNumbers(projectSLA(tt.prs, sla, now))
+			if len(got) != len(tt.want) {
+				t.Fatalf("projectSLA() = %v, want %v", got, tt.want)
+			}
+			for day, want := range tt.want {
+				if !slices.Equal(got[day], want) {
+					t.Errorf("projectSLA() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectSLAAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	// Clocks fall back at 2:00 on Sunday, November 1, 2026, making that day 25 hours long
+	now := time.Date(2026, 10, 31, 22, 0, 0, 0, loc)
+	tests := []struct {
+		deadline time.Time
+		want     int
+	}{
+		{deadline: time.Date(2026, 11, 1, 23, 59, 0, 0, loc), want: 1},
+		{deadline: time.Date(2026, 11, 2, 0, 0, 0, 0, loc), want: 2},
+		{deadline: time.Date(2026, 11, 2, 0, 30, 0, 0, loc), want: 2},
+	}
+	for _, tt := range tests {
+		got := bucketNumbers(projectSLA([]PR{slaTestPR(1, tt.deadline.Add(-time.Hour))}, time.Hour, now))
+		if !slices.Equal(got[tt.want], []int{1}) {
+			t.Errorf("deadline %v: buckets = %v, want day %d", tt.deadline, got, tt.want)
+		}
+	}
+}
+
+func TestWeekAheadLine(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) // Tuesday
+	tests := []struct {
+		want  string
+		day   int
+		count int
+	}{
+		{day: slaOverdue, count: 2, want: "Overdue: 2 PRs past the 48h SLA"},
+		{day: slaOverdue, count: 1, want: "Overdue: 1 PR past the 48h SLA"},
+		{day: 0, count: 3, want: "Today: 3 PRs cross the 48h SLA"},
+		{day: 0, count: 1, want: "Today: 1 PR crosses the 48h SLA"},
+		{day: 1, count: 1, want: "Tomorrow: 1 more"},
+		{day: 2, count: 4, want: "Thursday: 4 more"},
+		{day: 6, count: 1, want: "Monday: 1 more"},
+	}
+	for _, tt := range tests {
+		if got := weekAheadLine(tt.day, tt.count, 48*time.Hour, now); got != tt.want {
+			t.Errorf("weekAheadLine(%d, %d) = %q, want %q", tt.day, tt.count, got, tt.want)
+		}
+	}
+	if got := formatSLA(90 * time.Minute); got != "1h30m" {
+		t.Errorf("formatSLA(90m) = %q, want 1h30m", got)
+	}
+}
+
+func TestWeekAheadMenu(t *testing.T) {
+	app := newFocusTestApp(time.Hour)
+	browser := &countingBrowser{}
+	app.browser = browser
+	app.reviewSLA = 24 * time.Hour
+	now := time.Now()
+	overdue := slaTestPR(1, now.Add(-30*time.Hour))
+	notBlocked := slaTestPR(2, now.Add(-30*time.Hour))
+	notBlocked.NeedsReview = false
+	app.incoming = []PR{overdue, notBlocked}
+
+	titles := app.generateMenuTitles()
+	if !slices.Contains(titles, "📅 This week") || !slices.Contains(titles, "Overdue: 1 PR past the 24h SLA") {
+		t.Fatalf("menu titles missing the week ahead lines: %v", titles)
+	}
+
+	app.rebuildMenu(context.Background())
+	mock, ok := app.systrayInterface.(*MockSystray)
+	if !ok {
+		t.Fatal("expected a MockSystray")
+	}
+	week := topMenuItem(mock, "📅 This week")
+	if week == nil || len(week.subItems) != 1 {
+		t.Fatalf("week submenu = %+v, want one day line", week)
+	}
+	day, ok := week.subItems[0].(*MockMenuItem)
+	if !ok {
+		t.Fatal("expected a MockMenuItem")
+	}
+	if day.title != "Overdue: 1 PR past the 24h SLA" || len(day.subItems) != 1 {
+		t.Fatalf("day line = %q with %d PRs, want the overdue PR only", day.title, len(day.subItems))
+	}
+	pr, ok := day.subItems[0].(*MockMenuItem)
+	if !ok {
+		t.Fatal("expected a MockMenuItem")
+	}
+	pr.clickHandler()
+	if got := waitForOpens(browser, 1); got != 1 || browser.urls[0] != overdue.URL {
+		t.Errorf("clicking the PR opened %v, want %s", browser.urls, overdue.URL)
+	}
+
+	// Nothing blocked, no submenu
+	app.incoming = []PR{notBlocked}
+	if slices.Contains(app.generateMenuTitles(), "📅 This week") {
+		t.Error("the week ahead submenu should be hidden without blocked PRs")
+	}
+}
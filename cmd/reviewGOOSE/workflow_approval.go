@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+const (
+	workflowApprovalReason = "workflow runs are waiting for maintainer approval"
+	// workflowStuckThreshold is how long checks must sit pending before we ask the Actions API.
+	workflowStuckThreshold = 10 * time.Minute
+	// workflowApprovalCacheTTL bounds how often the Actions API is queried for the same commit.
+	workflowApprovalCacheTTL = 15 * time.Minute
+)
+
+// awaitingApprovalMarkers are substrings of check descriptions GitHub uses for runs
+// that need a maintainer to approve them.
+var awaitingApprovalMarkers = []string{"action_required", "action required", "awaiting approval", "waiting for approval"}
+
+// approvalSignal is the outcome of inspecting a check summary for workflow approval.
+type approvalSignal int
+
+const (
+	approvalNone     approvalSignal = iota // Nothing suggests runs are awaiting approval
+	approvalPossible                       // Checks look stuck; confirm with the Actions API
+	approvalDefinite                       // A check explicitly reports it needs approval
+)
+
+// detectWorkflowApproval inspects Turn data for a PR where Turn reported no action for
+// the user. It is cheap: no network calls are made here.
+func detectWorkflowApproval(data *turn.CheckResponse, now time.Time) approvalSignal {
+	if data == nil {
+		return approvalNone
+	}
+	pr := data.PullRequest
+	if pr.State != "open" || pr.Draft || pr.Merged {
+		return approvalNone
+	}
+
+	summary := pr.CheckSummary
+	if summary != nil {
+		for _, checks := range []map[string]string{summary.Pending, summary.Neutral} {
+			for name, desc := range checks {
+				if hasApprovalMarker(name) || hasApprovalMarker(desc) {
+					return approvalDefinite
+				}
+			}
+		}
+	}
+
+	// Tests appear stuck: nothing has passed or failed, and the PR has been quiet a while
+	switch pr.TestState {
+	case "", "pending", "queued":
+	default:
+		return approvalNone
+	}
+	if summary != nil && (len(summary.Success) > 0 || len(summary.Failing) > 0) {
+		return approvalNone
+	}
+	if pr.HeadSHA == "" || now.Sub(pr.UpdatedAt) < workflowStuckThreshold {
+		return approvalNone
+	}
+	return approvalPossible
+}
+
+func hasApprovalMarker(s string) bool {
+	s = strings.ToLower(s)
+	for _, marker := range awaitingApprovalMarkers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowApprovalEntry caches the Actions API answer for one head commit.
+type workflowApprovalEntry struct {
+	checkedAt time.Time
+	headSHA   string
+	awaiting  bool
+}
+
+// workflowApprovalCache remembers Actions API lookups so each commit is checked at most
+// once per workflowApprovalCacheTTL.
+type workflowApprovalCache struct {
+	entries map[string]workflowApprovalEntry
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func newWorkflowApprovalCache() *workflowApprovalCache {
+	return &workflowApprovalCache{
+		entries: make(map[string]workflowApprovalEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *workflowApprovalCache) get(url, headSHA string) (awaiting, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[url]
+	if !found || entry.headSHA != headSHA || c.now().Sub(entry.checkedAt) > workflowApprovalCacheTTL {
+		return false, false
+	}
+	return entry.awaiting, true
+}
+
+func (c *workflowApprovalCache) put(url, headSHA string, awaiting bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[url] = workflowApprovalEntry{checkedAt: c.now(), headSHA: headSHA, awaiting: awaiting}
+}
+
+// workflowsAwaitingApproval reports whether an incoming PR has workflow runs waiting for
+// maintainer approval. The Actions API is only consulted when the checks look stuck.
+func (app *App) workflowsAwaitingApproval(ctx context.Context, repo, url string, data *turn.CheckResponse) bool {
+	switch detectWorkflowApproval(data, time.Now()) {
+	case approvalDefinite:
+		return true
+	case approvalNone:
+		return false
+	default:
+	}
+
+	if app.client == nil || app.workflowApprovals == nil {
+		return false
+	}
+	sha := data.PullRequest.HeadSHA
+	if awaiting, ok := app.workflowApprovals.get(url, sha); ok {
+		return awaiting
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return false
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, turnAPITimeout)
+	defer cancel()
+	runs, _, err := app.client.Actions.ListRepositoryWorkflowRuns(apiCtx, owner, name, &github.ListWorkflowRunsOptions{
+		Status:      "action_required",
+		HeadSHA:     sha,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		// Anything but an abandoned cycle (e.g. no Actions access) is cached
+		if cacheableFailure(err) {
+			app.workflowApprovals.put(url, sha, false)
+		}
+		if goneStatus(err) != 0 {
+			app.quarantinePR(url, err)
+		}
+		slog.Debug("[WORKFLOWS] Actions API lookup failed", "url", url, "error", err)
+		return false
+	}
+
+	awaiting := runs.GetTotalCount() > 0
+	app.workflowApprovals.put(url, sha, awaiting)
+	if awaiting {
+		slog.Info("[WORKFLOWS] Workflow runs awaiting approval", "url", url, "runs", runs.GetTotalCount())
+	}
+	return awaiting
+}
+
+// prLink returns the page to open for a PR: the checks tab when workflows need
+// approval, the question to answer when I owe a response, the PR itself otherwise.
+func prLink(pr *PR) string {
+	if pr.ActionKind == actionApproveWorkflows {
+		return pr.URL + "/checks"
+	}
+	if pr.ActionKind == actionRespond && pr.QuestionURL != "" {
+		return pr.QuestionURL
+	}
+	return pr.URL
+}
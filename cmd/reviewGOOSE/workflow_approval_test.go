@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/turnclient/pkg/turn"
+	"github.com/google/go-github/v57/github"
+)
+
+func workflowTurnData(testState string, summary *prx.CheckSummary, quietFor time.Duration) *turn.CheckResponse {
+	return &turn.CheckResponse{
+		PullRequest: prx.PullRequest{
+			State:        "open",
+			TestState:    testState,
+			HeadSHA:      "abc123",
+			UpdatedAt:    time.Now().Add(-quietFor),
+			CheckSummary: summary,
+		},
+	}
+}
+
+func TestDetectWorkflowApproval(t *testing.T) {
+	now := time.Now()
+	stuck := 2 * workflowStuckThreshold
+
+	tests := []struct {
+		data *turn.CheckResponse
+		name string
+		want approvalSignal
+	}{
+		{name: "nil data", data: nil, want: approvalNone},
+		{
+			name: "pending check reports action required",
+			data: workflowTurnData("pending", &prx.CheckSummary{Pending: map[string]string{"CI / test": "action_required"}}, time.Minute),
+			want: approvalDefinite,
+		},
+		{
+			name: "neutral check awaiting approval",
+			data: workflowTurnData("", &prx.CheckSummary{Neutral: map[string]string{"build": "Awaiting approval from a maintainer"}}, time.Minute),
+			want: approvalDefinite,
+		},
+		{
+			name: "no checks at all for a while",
+			data: workflowTurnData("", &prx.CheckSummary{}, stuck),
+			want: approvalPossible,
+		},
+		{
+			name: "queued checks for a while",
+			data: workflowTurnData("queued", &prx.CheckSummary{Pending: map[string]string{"CI / test": "Queued"}}, stuck),
+			want: approvalPossible,
+		},
+		{
+			name: "recently pushed checks are not stuck yet",
+			data: workflowTurnData("pending", &prx.CheckSummary{Pending: map[string]string{"CI / test": "Queued"}}, time.Minute),
+			want: approvalNone,
+		},
+		{
+			name: "some checks already ran",
+			data: workflowTurnData("pending", &prx.CheckSummary{
+				Success: map[string]string{"lint": "ok"},
+				Pending: map[string]string{"CI / test": "Queued"},
+			}, stuck),
+			want: approvalNone,
+		},
+		{
+			name: "passing tests",
+			data: workflowTurnData("passing", &prx.CheckSummary{Success: map[string]string{"CI / test": "ok"}}, stuck),
+			want: approvalNone,
+		},
+		{
+			name: "missing check summary treated as no checks",
+			data: workflowTurnData("", nil, stuck),
+			want: approvalPossible,
+		},
+		{
+			name: "missing head sha cannot be confirmed",
+			data: func() *turn.CheckResponse {
+				d := workflowTurnData("", &prx.CheckSummary{}, stuck)
+				d.PullRequest.HeadSHA = ""
+				return d
+			}(),
+			want: approvalNone,
+		},
+		{
+			name: "draft PR",
+			data: func() *turn.CheckResponse {
+				d := workflowTurnData("", &prx.CheckSummary{Pending: map[string]string{"CI": "action_required"}}, stuck)
+				d.PullRequest.Draft = true
+				return d
+			}(),
+			want: approvalNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectWorkflowApproval(tt.data, now); got != tt.want {
+				t.Errorf("detectWorkflowApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowsAwaitingApprovalCachesActionsLookup(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/org/repo/actions/runs" || r.URL.Query().Get("status") != "action_required" ||
+			r.URL.Query().Get("head_sha") == "" {
+			t.Errorf("unexpected Actions request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{"total_count": 2, "workflow_runs": []any{}}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	app := &App{
+		mu:                sync.RWMutex{},
+		client:            newETagTestClient(t, server.URL),
+		workflowApprovals: newWorkflowApprovalCache(),
+	}
+	ctx := context.Background()
+	url := "https://github.com/org/repo/pull/1"
+	data := workflowTurnData("", &prx.CheckSummary{}, 2*workflowStuckThreshold)
+
+	for range 3 {
+		if !app.workflowsAwaitingApproval(ctx, "org/repo", url, data) {
+			t.Fatal("expected workflow runs awaiting approval")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Actions API called %d times, want 1 (cached)", got)
+	}
+
+	// A new push invalidates the cached answer
+	data.PullRequest.HeadSHA = "def456"
+	app.workflowsAwaitingApproval(ctx, "org/repo", url, data)
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Actions API called %d times after new push, want 2", got)
+	}
+
+	// Definite signals never need the API
+	definite := workflowTurnData("pending", &prx.CheckSummary{Pending: map[string]string{"CI": "action_required"}}, time.Minute)
+	if !app.workflowsAwaitingApproval(ctx, "org/repo", "https://github.com/org/repo/pull/2", definite) {
+		t.Error("expected definite signal to be reported")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Error("Actions API called for a definite signal")
+	}
+}
+
+func TestApproveWorkflowsRenderedAsBlocked(t *testing.T) {
+	turnServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := map[string]any{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"pull_request": map[string]any{
+				"state":         "open",
+				"test_state":    "pending",
+				"head_sha":      "abc123",
+				"check_summary": map[string]any{"pending": map[string]string{"CI / test": "action_required"}},
+			},
+			"analysis": map[string]any{
+				"workflow_state": "PUBLISHED_WAITING_FOR_TESTS",
+				"next_action":    map[string]any{"contributor": map[string]any{"kind": "tests_pending", "reason": "waiting"}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Failed to encode Turn response: %v", err)
+		}
+	}))
+	defer turnServer.Close()
+
+	turnClient, err := turn.NewClient(turnServer.URL)
+	if err != nil {
+		t.Fatalf("Failed to create turn client: %v", err)
+	}
+	turnClient.SetAuthToken("test-token")
+
+	app := &App{
+		mu:          sync.RWMutex{},
+		turnClient:  turnClient,
+		currentUser: &github.User{Login: github.String("maintainer")},
+		cacheDir:    t.TempDir(),
+		noCache:     true,
+	}
+
+	url := "https://github.com/org/repo/pull/1"
+	repoURL := "https://api.github.com/repos/org/repo"
+	issue := &github.Issue{
+		HTMLURL:          &url,
+		RepositoryURL:    &repoURL,
+		User:             &github.User{Login: github.String("contributor")},
+		UpdatedAt:        &github.Timestamp{Time: time.Now()},
+		PullRequestLinks: &github.PullRequestLinks{},
+	}
+	incoming := []PR{{URL: url, Repository: "org/repo", Number: 1}}
+	var outgoing []PR
+	app.fetchTurnDataSync(context.Background(), []*github.Issue{issue}, "maintainer", &incoming, &outgoing)
+
+	pr := incoming[0]
+	if pr.ActionKind != actionApproveWorkflows || !pr.IsBlocked || !pr.NeedsReview {
+		t.Fatalf("expected approve_workflows blocking action, got kind=%q blocked=%v", pr.ActionKind, pr.IsBlocked)
+	}
+	if pr.ActionReason != workflowApprovalReason {
+		t.Errorf("ActionReason = %q", pr.ActionReason)
+	}
+	if got := prLink(&pr); got != url+"/checks" {
+		t.Errorf("prLink() = %q, want the checks tab", got)
+	}
+	if got := formatMenuLabel(pr, DisplayRepoNumber, 0); got != "org/repo #1 — approve workflows" {
+		t.Errorf("formatMenuLabel() = %q", got)
+	}
+}
@@ -3,6 +3,7 @@ module github.com/codeGROOVE-dev/goose
 go 1.25.4
 
 require (
+	github.com/codeGROOVE-dev/prx v0.0.0-20260116145942-52ee64398c48
 	github.com/codeGROOVE-dev/retry v1.3.1
 	github.com/codeGROOVE-dev/sprinkler v0.0.0-20260117025717-3985b18e658a
 	github.com/codeGROOVE-dev/turnclient v0.0.0-20260116165138-9bd9013c5156
@@ -12,6 +13,7 @@ require (
 	github.com/google/go-github/v57 v57.0.0
 	golang.org/x/image v0.36.0
 	golang.org/x/oauth2 v0.35.0
+	golang.org/x/sys v0.41.0
 )
 
 require (
@@ -19,7 +21,6 @@ require (
 	github.com/codeGROOVE-dev/fido v1.10.0 // indirect
 	github.com/codeGROOVE-dev/fido/pkg/store/compress v1.10.0 // indirect
 	github.com/codeGROOVE-dev/fido/pkg/store/localfs v1.10.0 // indirect
-	github.com/codeGROOVE-dev/prx v0.0.0-20260116145942-52ee64398c48 // indirect
 	github.com/esiqveland/notify v0.13.3 // indirect
 	github.com/go-ole/go-ole v1.3.0 // indirect
 	github.com/google/go-querystring v1.2.0 // indirect
@@ -31,6 +32,5 @@ require (
 	github.com/sergeymakinen/go-ico v1.0.0 // indirect
 	github.com/tadvi/systray v0.0.0-20190226123456-11a2b8fa57af // indirect
 	golang.org/x/net v0.51.0 // indirect
-	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/text v0.34.0 // indirect
 )
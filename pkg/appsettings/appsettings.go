@@ -6,8 +6,27 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 )
 
+// CorruptError reports a settings file that could not be parsed. The file has been
+// moved aside to BackupPath so the next Save starts fresh without losing it.
+type CorruptError struct {
+	Err        error
+	BackupPath string // Empty if the backup itself failed
+}
+
+func (e *CorruptError) Error() string {
+	if e.BackupPath == "" {
+		return fmt.Sprintf("parse settings: %v", e.Err)
+	}
+	return fmt.Sprintf("parse settings (backed up to %s): %v", e.BackupPath, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error { return e.Err }
+
 // Manager handles loading and saving settings to disk.
 type Manager struct {
 	appName string
@@ -28,7 +47,8 @@ func (m *Manager) Path() (string, error) {
 }
 
 // Load loads settings from disk into the provided struct.
-// Returns false if the file doesn't exist (not an error).
+// Returns false if the file doesn't exist (not an error). A file that can't be parsed,
+// such as one truncated by a crash, is moved aside and reported as a *CorruptError.
 func (m *Manager) Load(settings any) (bool, error) {
 	path, err := m.Path()
 	if err != nil {
@@ -44,13 +64,18 @@ func (m *Manager) Load(settings any) (bool, error) {
 	}
 
 	if err := json.Unmarshal(data, settings); err != nil {
-		return false, fmt.Errorf("parse settings: %w", err)
+		backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+		if renameErr := os.Rename(path, backup); renameErr != nil {
+			backup = ""
+		}
+		return false, &CorruptError{Err: err, BackupPath: backup}
 	}
 
 	return true, nil
 }
 
-// Save saves settings to disk.
+// Save saves settings to disk atomically. Keys in the existing file that the settings
+// struct doesn't know about, such as ones written by a newer version, are kept.
 func (m *Manager) Save(settings any) error {
 	path, err := m.Path()
 	if err != nil {
@@ -63,14 +88,100 @@ func (m *Manager) Save(settings any) error {
 		return fmt.Errorf("create settings directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(settings, "", "  ")
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("marshal settings: %w", err)
+	}
+	merged := preserveUnknownKeys(path, data, jsonKeys(settings))
+	data, err = json.MarshalIndent(merged, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal settings: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0o600); err != nil {
+	if err := WriteAtomic(path, data, 0o600); err != nil {
 		return fmt.Errorf("write settings file: %w", err)
 	}
 
 	return nil
 }
+
+// preserveUnknownKeys merges data over the existing file at path, keeping keys that
+// aren't in known. Known keys absent from data (e.g. omitempty fields) are dropped.
+// An unreadable existing file contributes nothing.
+func preserveUnknownKeys(path string, data []byte, known map[string]bool) map[string]json.RawMessage {
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil || merged == nil {
+		merged = make(map[string]json.RawMessage)
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return merged
+	}
+	var old map[string]json.RawMessage
+	if json.Unmarshal(existing, &old) != nil {
+		return merged
+	}
+	for k, v := range old {
+		if _, ok := merged[k]; !ok && !known[k] {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// jsonKeys returns the JSON object keys a struct (or pointer to one) can produce.
+func jsonKeys(v any) map[string]bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	keys := make(map[string]bool)
+	if t == nil || t.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		keys[name] = true
+	}
+	return keys
+}
+
+// WriteAtomic writes data to path via a synced temp file in the same directory and a
+// rename, so a crash mid-write leaves either the old file or the new one, never a partial one.
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) //nolint:errcheck // Already renamed on success
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // Write error takes precedence
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // Sync error takes precedence
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package appsettings
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -289,3 +291,142 @@ func TestLoad_EmptyFile(t *testing.T) {
 		t.Error("Load() returned found=true for empty file")
 	}
 }
+
+func TestLoad_TruncatedFileIsBackedUp(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("APPDATA", tmpDir)
+
+	m := NewManager("testapp")
+	if err := m.Save(&testSettings{Name: "before crash", Tags: map[string]bool{"go": true}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	path, err := m.Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+
+	// Simulate a crash partway through an in-place write.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	truncated := data[:len(data)/2]
+	if err := os.WriteFile(path, truncated, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var settings testSettings
+	found, err := m.Load(&settings)
+	var corrupt *CorruptError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("Load() error = %v, want *CorruptError", err)
+	}
+	if found {
+		t.Error("Load() returned found=true for a truncated file")
+	}
+	backup, err := os.ReadFile(corrupt.BackupPath)
+	if err != nil {
+		t.Fatalf("backup not readable: %v", err)
+	}
+	if string(backup) != string(truncated) {
+		t.Errorf("backup = %q, want the truncated contents", backup)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("corrupt file should be moved aside, stat error = %v", err)
+	}
+
+	// The next save starts fresh and loads cleanly.
+	if err := m.Save(&testSettings{Name: "after"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if found, err := m.Load(&settings); err != nil || !found || settings.Name != "after" {
+		t.Errorf("Load() after recovery = %v, %v, %+v", found, err, settings)
+	}
+}
+
+func TestSave_LeavesNoTempFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("APPDATA", tmpDir)
+
+	m := NewManager("testapp")
+	for i := range 3 {
+		if err := m.Save(&testSettings{Count: i}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	path, err := m.Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "settings.json" {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("settings dir = %v, want only settings.json", names)
+	}
+}
+
+func TestSave_PreservesUnknownKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("APPDATA", tmpDir)
+
+	m := NewManager("testapp")
+	path, err := m.Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	// Written by a newer version that knows about "future".
+	newer := `{"name":"newer","count":9,"future":{"mode":"fancy"},"tags":{"go":true}}`
+	if err := os.WriteFile(path, []byte(newer), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type olderSettings struct {
+		Tags map[string]bool `json:"tags,omitempty"`
+		Name string          `json:"name"`
+	}
+	var loaded olderSettings
+	if _, err := m.Load(&loaded); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	loaded.Name = "older"
+	loaded.Tags = nil // An omitted known key is cleared, not resurrected
+	if err := m.Save(&loaded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got["name"]) != `"older"` {
+		t.Errorf("name = %s, want the older binary's value", got["name"])
+	}
+	if string(got["count"]) != "9" {
+		t.Errorf("count = %s, want the unknown key kept", got["count"])
+	}
+	if !strings.Contains(string(got["future"]), `"fancy"`) {
+		t.Errorf("future = %s, want the unknown key kept", got["future"])
+	}
+	if _, ok := got["tags"]; ok {
+		t.Errorf("tags = %s, want the cleared known key dropped", got["tags"])
+	}
+}
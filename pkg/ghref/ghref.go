@@ -0,0 +1,121 @@
+// Package ghref parses and validates GitHub logins, repository paths, and pull request URLs.
+package ghref
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxLoginLen is GitHub's maximum login length, including an EMU "_shortcode" suffix.
+	MaxLoginLen = 39
+	// MaxRepoNameLen is GitHub's maximum repository name length.
+	MaxRepoNameLen = 100
+)
+
+var (
+	// loginRegex matches GitHub logins. Regular accounts use alphanumerics and hyphens;
+	// Enterprise Managed Users add dots and an underscore before the enterprise shortcode
+	// (e.g. "jane.doe_acmecorp"). Logins start and end with an alphanumeric.
+	loginRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+	// repoNameRegex matches GitHub repository names.
+	repoNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// IsLogin reports whether s is a syntactically valid GitHub user or organization login.
+func IsLogin(s string) bool {
+	return len(s) <= MaxLoginLen && loginRegex.MatchString(s) && !strings.Contains(s, "..")
+}
+
+// IsRepoName reports whether s is a valid repository name (without its owner).
+func IsRepoName(s string) bool {
+	if s == "" || len(s) > MaxRepoNameLen || s == "." || s == ".." {
+		return false
+	}
+	return repoNameRegex.MatchString(s)
+}
+
+// SplitRepo splits an "owner/name" repository path, ignoring surrounding slashes and a
+// trailing ".git". It returns false unless both halves are valid.
+func SplitRepo(repo string) (owner, name string, ok bool) {
+	owner, name, found := strings.Cut(strings.Trim(repo, "/"), "/")
+	name = strings.TrimSuffix(name, ".git")
+	if !found || !IsLogin(owner) || !IsRepoName(name) {
+		return "", "", false
+	}
+	return owner, name, true
+}
+
+// Org returns the owner of a repository path like "org/repo". A path without a slash is
+// returned as-is (minus any ".git"), and one starting with "/" yields "".
+func Org(repo string) string {
+	owner, _, found := strings.Cut(repo, "/")
+	if !found {
+		return strings.TrimSuffix(repo, ".git")
+	}
+	return owner
+}
+
+// PR identifies a pull request.
+type PR struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// Repository returns the PR's "owner/repo" path.
+func (p PR) Repository() string {
+	return p.Owner + "/" + p.Repo
+}
+
+// URL returns the PR's canonical https://github.com/{owner}/{repo}/pull/{number} URL.
+func (p PR) URL() string {
+	return "https://github.com/" + p.Repository() + "/pull/" + strconv.Itoa(p.Number)
+}
+
+// ParsePRURL parses https://github.com/{owner}/{repo}/pull/{number}. Query strings,
+// fragments, a ".git" repo suffix, and trailing segments such as /files are ignored.
+func ParsePRURL(rawURL string) (PR, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return PR{}, fmt.Errorf("parse url: %w", err)
+	}
+	if !strings.EqualFold(u.Host, "github.com") {
+		return PR{}, fmt.Errorf("not a github.com URL: %q", u.Host)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return PR{}, errors.New("must match format: /{owner}/{repo}/pull/{number}")
+	}
+	owner, repo, ok := SplitRepo(parts[0] + "/" + parts[1])
+	if !ok {
+		return PR{}, fmt.Errorf("invalid repository: %q", parts[0]+"/"+parts[1])
+	}
+	n, err := ParseNumber(parts[3])
+	if err != nil {
+		return PR{}, err
+	}
+	return PR{Owner: owner, Repo: repo, Number: n}, nil
+}
+
+// ParseNumber parses a PR number: digits only, starting with 1-9.
+func ParseNumber(s string) (int, error) {
+	if s == "" || s[0] < '1' || s[0] > '9' {
+		return 0, errors.New("PR number must start with 1-9")
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, errors.New("PR number must be digits only")
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("PR number out of range: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,209 @@
+package ghref
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsLogin(t *testing.T) {
+	tests := []struct {
+		login string
+		want  bool
+	}{
+		// Regular accounts
+		{"a", true},
+		{"octocat", true},
+		{"user-name", true},
+		{"user--name", true},
+		{"User123", true},
+		{strings.Repeat("a", MaxLoginLen), true},
+
+		// Enterprise Managed Users
+		{"jane_acmecorp", true},
+		{"jane.doe_acmecorp", true},
+		{"j.d-smith_acme", true},
+		{"JANE.DOE_ACME", true},
+
+		// Invalid
+		{"", false},
+		{strings.Repeat("a", MaxLoginLen+1), false},
+		{"-user", false},
+		{"user-", false},
+		{"_user", false},
+		{"user_", false},
+		{".user", false},
+		{"user.", false},
+		{"user..name", false},
+		{"..", false},
+		{"user name", false},
+		{"user@name", false},
+		{"user/name", false},
+		{"user;rm -rf", false},
+		{"user$(id)", false},
+		{"user`id`", false},
+		{"user\nname", false},
+		{"user%2Fname", false},
+		{"usér", false},
+	}
+	for _, tt := range tests {
+		if got := IsLogin(tt.login); got != tt.want {
+			t.Errorf("IsLogin(%q) = %v, want %v", tt.login, got, tt.want)
+		}
+	}
+}
+
+func TestIsRepoName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"repo", true},
+		{"repo.name", true},
+		{"repo_name", true},
+		{"repo-name", true},
+		{".github", true},
+		{"socket.io", true},
+		{strings.Repeat("r", MaxRepoNameLen), true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{strings.Repeat("r", MaxRepoNameLen+1), false},
+		{"repo/name", false},
+		{"repo name", false},
+		{"repo;ls", false},
+		{"repo?x=1", false},
+		{"repo#x", false},
+	}
+	for _, tt := range tests {
+		if got := IsRepoName(tt.name); got != tt.want {
+			t.Errorf("IsRepoName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitRepo(t *testing.T) {
+	tests := []struct {
+		repo      string
+		wantOwner string
+		wantName  string
+		wantOK    bool
+	}{
+		{"acme/widgets", "acme", "widgets", true},
+		{"acme/widgets.io", "acme", "widgets.io", true},
+		{"acme/widgets.git", "acme", "widgets", true},
+		{"acme/widgets.io.git", "acme", "widgets.io", true},
+		{"/acme/widgets/", "acme", "widgets", true},
+		{"jane.doe_acmecorp/dotfiles", "jane.doe_acmecorp", "dotfiles", true},
+		{"acme", "", "", false},
+		{"acme/", "", "", false},
+		{"/widgets", "", "", false},
+		{"acme/.git", "", "", false},
+		{"acme/widgets/extra", "", "", false},
+		{"-acme/widgets", "", "", false},
+		{"acme/wid gets", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		owner, name, ok := SplitRepo(tt.repo)
+		if owner != tt.wantOwner || name != tt.wantName || ok != tt.wantOK {
+			t.Errorf("SplitRepo(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.repo, owner, name, ok, tt.wantOwner, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+func TestOrg(t *testing.T) {
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{"acme/widgets", "acme"},
+		{"acme/widgets.io", "acme"},
+		{"acme/widgets.git", "acme"},
+		{"jane.doe_acmecorp/dotfiles", "jane.doe_acmecorp"},
+		{"org/repo/subpath", "org"},
+		{"justarepo", "justarepo"},
+		{"justarepo.git", "justarepo"},
+		{"/repo", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Org(tt.repo); got != tt.want {
+			t.Errorf("Org(%q) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
+
+func TestParsePRURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    PR
+		wantErr bool
+	}{
+		{url: "https://github.com/acme/widgets/pull/123", want: PR{Owner: "acme", Repo: "widgets", Number: 123}},
+		{url: "https://github.com/acme/widgets.io/pull/7", want: PR{Owner: "acme", Repo: "widgets.io", Number: 7}},
+		{url: "https://github.com/acme/widgets.git/pull/7", want: PR{Owner: "acme", Repo: "widgets", Number: 7}},
+		{url: "https://github.com/jane.doe_acmecorp/dotfiles/pull/1", want: PR{Owner: "jane.doe_acmecorp", Repo: "dotfiles", Number: 1}},
+		{url: "https://github.com/acme/widgets/pull/123?goose=review", want: PR{Owner: "acme", Repo: "widgets", Number: 123}},
+		{url: "https://github.com/acme/widgets/pull/123#issuecomment-42", want: PR{Owner: "acme", Repo: "widgets", Number: 123}},
+		{url: "https://github.com/acme/widgets/pull/123/files?w=1#diff", want: PR{Owner: "acme", Repo: "widgets", Number: 123}},
+		{url: "https://github.com/acme/widgets/pull/123/", want: PR{Owner: "acme", Repo: "widgets", Number: 123}},
+		{url: "https://GitHub.com/acme/widgets/pull/5", want: PR{Owner: "acme", Repo: "widgets", Number: 5}},
+
+		{url: "", wantErr: true},
+		{url: "https://gitlab.com/acme/widgets/pull/1", wantErr: true},
+		{url: "https://github.com.evil.com/acme/widgets/pull/1", wantErr: true},
+		{url: "https://github.com/acme/widgets", wantErr: true},
+		{url: "https://github.com/acme/widgets/issues/1", wantErr: true},
+		{url: "https://github.com/acme/pull/1", wantErr: true},
+		{url: "https://github.com/acme/widgets/pull/", wantErr: true},
+		{url: "https://github.com/acme/widgets/pull/0", wantErr: true},
+		{url: "https://github.com/acme/widgets/pull/012", wantErr: true},
+		{url: "https://github.com/acme/widgets/pull/12a", wantErr: true},
+		{url: "https://github.com/acme/widgets/pull/99999999999999999999", wantErr: true},
+		{url: "https://github.com/-acme/widgets/pull/1", wantErr: true},
+		{url: "https://github.com/acme/../pull/1", wantErr: true},
+		{url: "https://github.com/acme;ls/widgets/pull/1", wantErr: true},
+		{url: "://github.com/acme/widgets/pull/1", wantErr: true},
+		// Malformed event URLs seen in sprinkler logs
+		{url: "github.com/acme/widgets/pull/1", wantErr: true},
+		{url: " https://github.com/acme/widgets/pull/1", wantErr: true},
+		{url: "https://github.com//widgets/pull/1", wantErr: true},
+		{url: "https://github.com/acme/widgets/pulls/1", wantErr: true},
+		{url: "https://api.github.com/repos/acme/widgets/pulls/1", wantErr: true},
+		{url: "https://github.example.com/acme/widgets/pull/1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePRURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePRURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePRURL(%q) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestPRURL(t *testing.T) {
+	for _, raw := range []string{
+		"https://github.com/Acme/widgets/pull/12",
+		"https://github.com/Acme/widgets/pull/12/",
+		"https://GitHub.com/Acme/widgets/pull/12?notification_referrer_id=x",
+		"https://github.com/Acme/widgets.git/pull/12/files#diff",
+	} {
+		pr, err := ParsePRURL(raw)
+		if err != nil {
+			t.Fatalf("ParsePRURL(%q): %v", raw, err)
+		}
+		if got := pr.URL(); got != "https://github.com/Acme/widgets/pull/12" {
+			t.Errorf("ParsePRURL(%q).URL() = %q", raw, got)
+		}
+	}
+}
+
+func TestPRRepository(t *testing.T) {
+	if got := (PR{Owner: "acme", Repo: "widgets.io", Number: 1}).Repository(); got != "acme/widgets.io" {
+		t.Errorf("Repository() = %q, want %q", got, "acme/widgets.io")
+	}
+}
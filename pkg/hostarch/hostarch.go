@@ -0,0 +1,116 @@
+// Package hostarch detects a build running on a host of another architecture, such as
+// the amd64 build translated by Rosetta on Apple Silicon.
+package hostarch
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// probeTimeout bounds each sysctl or uname call.
+const probeTimeout = 2 * time.Second
+
+// Probes are the system queries Detect relies on, injectable for tests.
+type Probes struct {
+	// Sysctl returns the value of a sysctl name (darwin).
+	Sysctl func(ctx context.Context, name string) (string, error)
+	// Uname returns the machine hardware name, as printed by "uname -m".
+	Uname func(ctx context.Context) (string, error)
+	// GOOS and GOARCH describe the running build.
+	GOOS   string
+	GOARCH string
+}
+
+// Result describes the running build and the host it runs on.
+type Result struct {
+	BuildArch  string // GOARCH of the running build
+	HostArch   string // Native architecture of the host in GOARCH terms; "" if unknown
+	Translated bool   // The process runs under Rosetta translation
+}
+
+// Mismatch reports whether the build doesn't match the host's native architecture.
+func (r Result) Mismatch() bool {
+	return r.Translated || (r.HostArch != "" && r.HostArch != r.BuildArch)
+}
+
+// RosettaOnAppleSilicon reports whether this is the Intel build on an Apple Silicon Mac.
+func (r Result) RosettaOnAppleSilicon() bool {
+	return r.Translated && r.BuildArch == "amd64"
+}
+
+// NativeProbes queries the running system.
+func NativeProbes() Probes {
+	return Probes{
+		Sysctl: func(ctx context.Context, name string) (string, error) {
+			return run(ctx, "sysctl", "-n", name)
+		},
+		Uname: func(ctx context.Context) (string, error) {
+			return run(ctx, "uname", "-m")
+		},
+		GOOS:   runtime.GOOS,
+		GOARCH: runtime.GOARCH,
+	}
+}
+
+// run returns a command's trimmed output.
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// Detect finds the host's native architecture and whether the build is translated. A
+// failed probe leaves its answer unknown rather than guessing a mismatch.
+func Detect(ctx context.Context, p Probes) Result {
+	r := Result{BuildArch: p.GOARCH}
+	switch p.GOOS {
+	case "darwin":
+		// Under Rosetta, uname reports x86_64, so ask the kernel instead
+		if p.Sysctl == nil {
+			return r
+		}
+		translated, terr := p.Sysctl(ctx, "sysctl.proc_translated")
+		r.Translated = terr == nil && translated == "1"
+		arm64, aerr := p.Sysctl(ctx, "hw.optional.arm64")
+		switch {
+		case r.Translated || (aerr == nil && arm64 == "1"):
+			r.HostArch = "arm64"
+		case aerr == nil || terr == nil:
+			// The kernel answered, and Intel Macs lack hw.optional.arm64
+			r.HostArch = "amd64"
+		default:
+		}
+	case "windows":
+		// No uname; the host stays unknown
+	default:
+		if p.Uname == nil {
+			return r
+		}
+		if v, err := p.Uname(ctx); err == nil {
+			r.HostArch = goarch(v)
+		}
+	}
+	return r
+}
+
+// goarch maps a "uname -m" machine name to its GOARCH, or "" if it isn't one goose
+// ships.
+func goarch(machine string) string {
+	switch strings.ToLower(machine) {
+	case "x86_64", "amd64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	case "i386", "i486", "i586", "i686":
+		return "386"
+	default:
+		if strings.HasPrefix(machine, "armv") {
+			return "arm"
+		}
+		return ""
+	}
+}
@@ -0,0 +1,115 @@
+package hostarch
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errNoSuchOID = errors.New("unknown oid")
+
+// fakeProbes answers sysctl names from values and uname with machine; names missing
+// from values fail like an unknown sysctl OID.
+func fakeProbes(goos, goarch string, values map[string]string, machine string) Probes {
+	return Probes{
+		GOOS:   goos,
+		GOARCH: goarch,
+		Sysctl: func(_ context.Context, name string) (string, error) {
+			v, ok := values[name]
+			if !ok {
+				return "", errNoSuchOID
+			}
+			return v, nil
+		},
+		Uname: func(context.Context) (string, error) {
+			if machine == "" {
+				return "", errNoSuchOID
+			}
+			return machine, nil
+		},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		probes   Probes
+		want     Result
+		mismatch bool
+		rosetta  bool
+	}{
+		{
+			name:     "Intel build under Rosetta",
+			probes:   fakeProbes("darwin", "amd64", map[string]string{"sysctl.proc_translated": "1", "hw.optional.arm64": "1"}, "x86_64"),
+			want:     Result{BuildArch: "amd64", HostArch: "arm64", Translated: true},
+			mismatch: true,
+			rosetta:  true,
+		},
+		{
+			name:   "arm64 build on Apple Silicon",
+			probes: fakeProbes("darwin", "arm64", map[string]string{"sysctl.proc_translated": "0", "hw.optional.arm64": "1"}, "arm64"),
+			want:   Result{BuildArch: "arm64", HostArch: "arm64"},
+		},
+		{
+			name:   "Intel build on an Intel Mac",
+			probes: fakeProbes("darwin", "amd64", map[string]string{"sysctl.proc_translated": "0"}, "x86_64"),
+			want:   Result{BuildArch: "amd64", HostArch: "amd64"},
+		},
+		{
+			name:   "no sysctl answers",
+			probes: fakeProbes("darwin", "amd64", nil, "x86_64"),
+			want:   Result{BuildArch: "amd64"},
+		},
+		{
+			name:     "amd64 build under emulation on arm64 Linux",
+			probes:   fakeProbes("linux", "amd64", nil, "aarch64"),
+			want:     Result{BuildArch: "amd64", HostArch: "arm64"},
+			mismatch: true,
+		},
+		{
+			name:   "native Linux",
+			probes: fakeProbes("linux", "amd64", nil, "x86_64"),
+			want:   Result{BuildArch: "amd64", HostArch: "amd64"},
+		},
+		{
+			name:   "unknown machine",
+			probes: fakeProbes("freebsd", "amd64", nil, "riscv64"),
+			want:   Result{BuildArch: "amd64"},
+		},
+		{
+			name:   "uname fails",
+			probes: fakeProbes("linux", "arm64", nil, ""),
+			want:   Result{BuildArch: "arm64"},
+		},
+		{
+			name:   "windows",
+			probes: fakeProbes("windows", "amd64", nil, "aarch64"),
+			want:   Result{BuildArch: "amd64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(context.Background(), tt.probes)
+			if got != tt.want {
+				t.Fatalf("Detect() = %+v, want %+v", got, tt.want)
+			}
+			if got.Mismatch() != tt.mismatch {
+				t.Errorf("Mismatch() = %v, want %v", got.Mismatch(), tt.mismatch)
+			}
+			if got.RosettaOnAppleSilicon() != tt.rosetta {
+				t.Errorf("RosettaOnAppleSilicon() = %v, want %v", got.RosettaOnAppleSilicon(), tt.rosetta)
+			}
+		})
+	}
+}
+
+func TestGoarch(t *testing.T) {
+	for machine, want := range map[string]string{
+		"x86_64": "amd64", "AMD64": "amd64", "aarch64": "arm64", "arm64": "arm64",
+		"i686": "386", "armv7l": "arm", "riscv64": "", "": "",
+	} {
+		if got := goarch(machine); got != want {
+			t.Errorf("goarch(%q) = %q, want %q", machine, got, want)
+		}
+	}
+}
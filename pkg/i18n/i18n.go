@@ -0,0 +1,151 @@
+// Package i18n provides a minimal message catalog with per-key fallback.
+//
+// Catalogs are JSON files named <locale>.json mapping message IDs to text.
+// Text may contain indexed placeholders ({0}, {1}, ...) so translations can reorder arguments.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Catalog holds messages for each locale.
+type Catalog struct {
+	messages map[string]map[string]string // locale -> message ID -> text
+	fallback string
+}
+
+// Load reads every <locale>.json file in dir. fallback names the locale used for
+// message IDs a locale doesn't translate, and must be present.
+func Load(fsys fs.FS, dir, fallback string) (*Catalog, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list catalogs: %w", err)
+	}
+
+	c := &Catalog{messages: make(map[string]map[string]string), fallback: fallback}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		c.messages[strings.TrimSuffix(path.Base(file), ".json")] = messages
+	}
+	if _, ok := c.messages[fallback]; !ok {
+		return nil, fmt.Errorf("fallback locale %q not found in %s", fallback, dir)
+	}
+	return c, nil
+}
+
+// Locales returns the available locales, sorted.
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Messages returns the IDs and text defined by a locale, without fallbacks.
+func (c *Catalog) Messages(locale string) map[string]string {
+	return c.messages[locale]
+}
+
+// Translate returns the message for id in locale with placeholders filled from args.
+// Missing translations fall back to the fallback locale, then to the ID itself.
+func (c *Catalog) Translate(locale, id string, args ...any) string {
+	text, ok := c.messages[locale][id]
+	if !ok || text == "" {
+		text, ok = c.messages[c.fallback][id]
+	}
+	if !ok {
+		text = id
+	}
+	return Format(text, args...)
+}
+
+// Match returns the catalog locale for a POSIX locale such as "de_DE.UTF-8",
+// or "" if none matches.
+func (c *Catalog) Match(posix string) string {
+	tag := posix
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" || tag == "c" || tag == "posix" {
+		return ""
+	}
+	if _, ok := c.messages[tag]; ok {
+		return tag
+	}
+	lang, _, _ := strings.Cut(tag, "-")
+	if _, ok := c.messages[lang]; ok {
+		return lang
+	}
+	return ""
+}
+
+// Format replaces indexed placeholders ({0}, {1}, ...) with args.
+// Placeholders without a matching argument are left as is.
+func Format(text string, args ...any) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		n, err := strconv.Atoi(text[start+1 : end])
+		if err != nil || n < 0 || n >= len(args) {
+			b.WriteString(text[:end+1])
+		} else {
+			b.WriteString(text[:start])
+			fmt.Fprint(&b, args[n])
+		}
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// Placeholders returns the placeholder indexes used in text, sorted and deduplicated.
+func Placeholders(text string) []int {
+	seen := make(map[int]bool)
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		if n, err := strconv.Atoi(text[start+1 : start+end]); err == nil && n >= 0 {
+			seen[n] = true
+		}
+		text = text[start+end+1:]
+	}
+	indexes := make([]int, 0, len(seen))
+	for n := range seen {
+		indexes = append(indexes, n)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"greeting": "Hello, {0}!", "count": "{0} of {1}", "only_en": "English only"}`)},
+		"locales/de.json": {Data: []byte(`{"greeting": "Hallo, {0}!", "count": "{1} davon {0}", "only_en": ""}`)},
+		"locales/ja.json": {Data: []byte(`{"greeting": "{0}さん、こんにちは"}`)},
+	}
+	c, err := Load(fsys, "locales", "en")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return c
+}
+
+func TestLoad(t *testing.T) {
+	c := testCatalog(t)
+	if got := c.Locales(); !slices.Equal(got, []string{"de", "en", "ja"}) {
+		t.Errorf("Locales() = %v", got)
+	}
+
+	if _, err := Load(fstest.MapFS{"locales/de.json": {Data: []byte(`{}`)}}, "locales", "en"); err == nil {
+		t.Error("Load() without the fallback locale = nil error")
+	}
+	if _, err := Load(fstest.MapFS{"locales/en.json": {Data: []byte(`{`)}}, "locales", "en"); err == nil {
+		t.Error("Load() with malformed JSON = nil error")
+	}
+}
+
+func TestTranslateFallback(t *testing.T) {
+	c := testCatalog(t)
+	tests := []struct {
+		locale string
+		id     string
+		want   string
+	}{
+		{"de", "greeting", "Hallo, Ana!"},
+		{"ja", "greeting", "Anaさん、こんにちは"},
+		{"ja", "only_en", "English only"},  // missing key falls back per-key
+		{"de", "only_en", "English only"},  // empty translation falls back
+		{"fr", "greeting", "Hello, Ana!"},  // unknown locale
+		{"de", "missing_id", "missing_id"}, // unknown ID
+		{"", "count", "{0} of {1}"},        // no args leaves placeholders
+	}
+	for _, tt := range tests {
+		var got string
+		if tt.id == "count" {
+			got = c.Translate(tt.locale, tt.id)
+		} else {
+			got = c.Translate(tt.locale, tt.id, "Ana")
+		}
+		if got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestFormatIndexedPlaceholders(t *testing.T) {
+	c := testCatalog(t)
+	if got := c.Translate("en", "count", 3, 7); got != "3 of 7" {
+		t.Errorf("en count = %q", got)
+	}
+	// Translations may reorder arguments
+	if got := c.Translate("de", "count", 3, 7); got != "7 davon 3" {
+		t.Errorf("de count = %q", got)
+	}
+
+	tests := []struct {
+		text string
+		want string
+		args []any
+	}{
+		{"{0} {0}", "a a", []any{"a"}},
+		{"{1} missing", "{1} missing", []any{"a"}},
+		{"{name} stays", "{name} stays", []any{"a"}},
+		{"unclosed {0", "unclosed {0", []any{"a"}},
+		{"braces in args {0}", "braces in args {1}", []any{"{1}"}},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.text, tt.args...); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	c := testCatalog(t)
+	tests := map[string]string{
+		"de_DE.UTF-8": "de",
+		"ja_JP":       "ja",
+		"en_US.UTF-8": "en",
+		"de":          "de",
+		"fr_FR.UTF-8": "",
+		"C":           "",
+		"POSIX":       "",
+		"":            "",
+	}
+	for posix, want := range tests {
+		if got := c.Match(posix); got != want {
+			t.Errorf("Match(%q) = %q, want %q", posix, got, want)
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	if got := Placeholders("{1} and {0} and {1}, not {x}"); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("Placeholders() = %v", got)
+	}
+	if got := Placeholders("none"); len(got) != 0 {
+		t.Errorf("Placeholders(none) = %v", got)
+	}
+}
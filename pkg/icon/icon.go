@@ -88,6 +88,50 @@ func Scale(iconData []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Outline redraws the edge of an icon's opaque area in edge, so the icon keeps its
+// shape against a panel of similar color. Transparent pixels are left alone.
+func Outline(iconData []byte, edge color.RGBA) ([]byte, error) {
+	src, err := png.Decode(bytes.NewReader(iconData))
+	if err != nil {
+		return nil, fmt.Errorf("decode png: %w", err)
+	}
+
+	b := src.Bounds()
+	width := max(1, b.Dx()/24) // 2px at the standard size
+	opaque := func(x, y int) bool {
+		if !(image.Point{x, y}.In(b)) {
+			return false
+		}
+		_, _, _, a := src.At(x, y).RGBA()
+		return a >= 0x8000
+	}
+
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, src, b.Min, draw.Src)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !opaque(x, y) {
+				continue
+			}
+		edge:
+			for dy := -width; dy <= width; dy++ {
+				for dx := -width; dx <= width; dx++ {
+					if dx*dx+dy*dy <= width*width && !opaque(x+dx, y+dy) {
+						dst.Set(x, y, edge)
+						break edge
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 // drawCircle renders a large filled circle with bold centered text.
 func drawCircle(img *image.RGBA, fill color.RGBA, text string) {
 	radius := float64(Size) / 2
@@ -2,6 +2,7 @@ package icon
 
 import (
 	"bytes"
+	"image/color"
 	"image/png"
 	"testing"
 )
@@ -159,3 +160,33 @@ func TestCacheOverflow(t *testing.T) {
 		t.Errorf("expected old entries to be cleared after overflow, but found %d", found)
 	}
 }
+
+func TestOutline(t *testing.T) {
+	original, err := Badge(3, 0)
+	if err != nil {
+		t.Fatalf("Badge() failed: %v", err)
+	}
+	edge := color.RGBA{10, 20, 30, 255}
+	outlined, err := Outline(original, edge)
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(outlined))
+	if err != nil {
+		t.Fatalf("invalid PNG after outlining: %v", err)
+	}
+	if got := color.RGBAModel.Convert(img.At(Size/2, 0)); got != edge {
+		t.Errorf("top of the circle = %v, want the edge color %v", got, edge)
+	}
+	if got := color.RGBAModel.Convert(img.At(Size/2, Size/2)); got == edge {
+		t.Error("the middle of the badge shouldn't be redrawn")
+	}
+	if _, _, _, a := img.At(0, 0).RGBA(); a != 0 {
+		t.Error("the transparent corner should stay transparent")
+	}
+
+	if _, err := Outline([]byte("not a png"), edge); err == nil {
+		t.Error("Outline() should fail with invalid PNG data")
+	}
+}
@@ -0,0 +1,258 @@
+package logging
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxFileSize caps a single log file before it rolls over to the next number.
+	DefaultMaxFileSize = 20 << 20
+	// DefaultMaxAge is how many days of logs are kept.
+	DefaultMaxAge = 7
+	// DefaultMaxTotalSize caps the disk space used by all log files together.
+	DefaultMaxTotalSize = 200 << 20
+
+	dayLayout = "2006-01-02"
+)
+
+// RotateOptions configures a RotatingWriter. Zero values use the defaults.
+type RotateOptions struct {
+	Now          func() time.Time // For tests; defaults to time.Now
+	Prefix       string           // File name prefix; defaults to "goose"
+	MaxFileSize  int64            // Bytes per file before rolling over
+	MaxTotalSize int64            // Bytes across all log files
+	MaxAge       int              // Days of logs to keep, counting today
+}
+
+// RotatingWriter writes logs to daily files named <prefix>-YYYY-MM-DD.log in a directory.
+// A file reaching MaxFileSize rolls over to <prefix>-YYYY-MM-DD.1.log, .2.log, and so on,
+// and old files are pruned to MaxAge days and MaxTotalSize bytes when the writer opens
+// and each time the day changes. It is safe for concurrent use.
+type RotatingWriter struct {
+	now     func() time.Time
+	file    *os.File
+	dir     string
+	prefix  string
+	day     string
+	opts    RotateOptions
+	written int64
+	seq     int
+	mu      sync.Mutex
+}
+
+// NewRotatingWriter opens today's newest log file in dir, creating dir if needed, and
+// prunes old files.
+func NewRotatingWriter(dir string, opts RotateOptions) (*RotatingWriter, error) {
+	opts.MaxFileSize = cmp.Or(opts.MaxFileSize, DefaultMaxFileSize)
+	opts.MaxTotalSize = cmp.Or(opts.MaxTotalSize, DefaultMaxTotalSize)
+	opts.MaxAge = cmp.Or(opts.MaxAge, DefaultMaxAge)
+	w := &RotatingWriter{
+		dir:    dir,
+		prefix: cmp.Or(opts.Prefix, "goose"),
+		now:    opts.Now,
+		opts:   opts,
+	}
+	if w.now == nil {
+		w.now = time.Now
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.openDay(w.now().Format(dayLayout)); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Path returns the file currently being written.
+func (w *RotatingWriter) Path() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.path(w.day, w.seq)
+}
+
+// Write appends p to the current file, first moving to a new file if the day changed or
+// p would push the file past MaxFileSize. A single record is never split across files.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if day := w.now().Format(dayLayout); day != w.day {
+		if err := w.openDay(day); err != nil {
+			return 0, err
+		}
+	} else if w.written > 0 && w.written+int64(len(p)) > w.opts.MaxFileSize {
+		if err := w.open(w.day, w.seq+1); err != nil {
+			return 0, err
+		}
+		w.prune()
+	}
+	if w.file == nil {
+		return 0, errors.New("log file is closed")
+	}
+	n, err := w.file.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotatingWriter) path(day string, seq int) string {
+	if seq == 0 {
+		return filepath.Join(w.dir, fmt.Sprintf("%s-%s.log", w.prefix, day))
+	}
+	return filepath.Join(w.dir, fmt.Sprintf("%s-%s.%d.log", w.prefix, day, seq))
+}
+
+// openDay switches to the newest file for day, resuming one left by an earlier run, and
+// prunes old files. Caller must hold w.mu.
+func (w *RotatingWriter) openDay(day string) error {
+	seq := 0
+	for _, f := range w.logFiles() {
+		if f.day == day {
+			seq = max(seq, f.seq)
+		}
+	}
+	if err := w.open(day, seq); err != nil {
+		return err
+	}
+	if w.written >= w.opts.MaxFileSize {
+		if err := w.open(day, seq+1); err != nil {
+			return err
+		}
+	}
+	w.prune()
+	return nil
+}
+
+// open closes the current file and opens day's file number seq for appending. Caller
+// must hold w.mu.
+func (w *RotatingWriter) open(day string, seq int) error {
+	path := w.path(day, seq)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	var size int64
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+	if w.file != nil {
+		_ = w.file.Close() //nolint:errcheck // Nothing useful to do if closing the old file fails
+	}
+	w.file, w.day, w.seq, w.written = f, day, seq, size
+	return nil
+}
+
+// logFile is a log file found in the directory.
+type logFile struct {
+	path string
+	day  string
+	size int64
+	seq  int
+}
+
+// logFiles lists this writer's log files, newest first. Caller must hold w.mu.
+func (w *RotatingWriter) logFiles() []logFile {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil
+	}
+	var files []logFile
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		day, seq, ok := w.parseName(e.Name())
+		if !ok {
+			continue
+		}
+		var size int64
+		if info, err := e.Info(); err == nil {
+			size = info.Size()
+		} else if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		files = append(files, logFile{path: filepath.Join(w.dir, e.Name()), day: day, seq: seq, size: size})
+	}
+	slices.SortFunc(files, func(a, b logFile) int {
+		return cmp.Or(strings.Compare(b.day, a.day), cmp.Compare(b.seq, a.seq))
+	})
+	return files
+}
+
+// parseName recognizes <prefix>-YYYY-MM-DD.log and <prefix>-YYYY-MM-DD.N.log.
+func (w *RotatingWriter) parseName(name string) (day string, seq int, ok bool) {
+	rest, found := strings.CutPrefix(name, w.prefix+"-")
+	if !found {
+		return "", 0, false
+	}
+	rest, found = strings.CutSuffix(rest, ".log")
+	if !found {
+		return "", 0, false
+	}
+	day, num, numbered := strings.Cut(rest, ".")
+	if _, err := time.Parse(dayLayout, day); err != nil {
+		return "", 0, false
+	}
+	if numbered {
+		n, err := strconv.Atoi(num)
+		if err != nil || n <= 0 {
+			return "", 0, false
+		}
+		seq = n
+	}
+	return day, seq, true
+}
+
+// prune deletes files older than MaxAge days, then the oldest files until the rest fit
+// in MaxTotalSize. The current file is never deleted. Caller must hold w.mu.
+func (w *RotatingWriter) prune() {
+	today, err := time.Parse(dayLayout, w.day)
+	if err != nil {
+		return
+	}
+	oldest := today.AddDate(0, 0, 1-w.opts.MaxAge).Format(dayLayout)
+	current := w.path(w.day, w.seq)
+
+	var total int64
+	full := false
+	for _, f := range w.logFiles() {
+		if f.path == current {
+			total += f.size
+			continue
+		}
+		// Once a file doesn't fit, every older one goes too
+		full = full || f.day < oldest || total+f.size > w.opts.MaxTotalSize
+		if !full {
+			total += f.size
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			// Logging from inside the log writer would deadlock; report on stderr instead
+			fmt.Fprintf(os.Stderr, "failed to remove old log file %s: %v\n", f.path, err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package logging
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClock is a settable clock for the writer.
+type testClock struct {
+	t  time.Time
+	mu sync.Mutex
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestWriter(t *testing.T, dir string, opts RotateOptions) (*RotatingWriter, *testClock) {
+	t.Helper()
+	clock := &testClock{t: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)}
+	opts.Now = clock.now
+	w, err := NewRotatingWriter(dir, opts)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+	})
+	return w, clock
+}
+
+func logNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func writeFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRotatingWriterRollsOverAtSize(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := newTestWriter(t, dir, RotateOptions{MaxFileSize: 10})
+
+	for _, line := range []string{"12345\n", "678\n", "abc\n", "0123456789abcdef\n", "z\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write(%q) error = %v", line, err)
+		}
+	}
+
+	// Exactly 10 bytes fit; the 11th starts a new file, and an oversized record gets its own
+	want := map[string]string{
+		"goose-2026-03-10.log":   "12345\n678\n",
+		"goose-2026-03-10.1.log": "abc\n",
+		"goose-2026-03-10.2.log": "0123456789abcdef\n",
+		"goose-2026-03-10.3.log": "z\n",
+	}
+	if got := logNames(t, dir); len(got) != len(want) {
+		t.Fatalf("files = %v, want %d", got, len(want))
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s = %q, want %q", name, data, content)
+		}
+	}
+	if got := filepath.Base(w.Path()); got != "goose-2026-03-10.3.log" {
+		t.Errorf("Path() = %s, want the newest file", got)
+	}
+}
+
+func TestRotatingWriterResumesNewestFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "goose-2026-03-10.log"), 10)
+	writeFile(t, filepath.Join(dir, "goose-2026-03-10.1.log"), 4)
+
+	w, _ := newTestWriter(t, dir, RotateOptions{MaxFileSize: 10})
+	if got := filepath.Base(w.Path()); got != "goose-2026-03-10.1.log" {
+		t.Errorf("Path() = %s, want the newest file from the earlier run", got)
+	}
+	if _, err := w.Write([]byte("1234567\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := filepath.Base(w.Path()); got != "goose-2026-03-10.2.log" {
+		t.Errorf("Path() = %s, want a rollover once the resumed file is full", got)
+	}
+}
+
+func TestRotatingWriterNewDay(t *testing.T) {
+	dir := t.TempDir()
+	w, clock := newTestWriter(t, dir, RotateOptions{})
+	if _, err := w.Write([]byte("today\n")); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(24 * time.Hour)
+	if _, err := w.Write([]byte("tomorrow\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"goose-2026-03-10.log", "goose-2026-03-11.log"}
+	if got := logNames(t, dir); !slices.Equal(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestRotatingWriterRetention(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  RotateOptions
+		files map[string]int
+		want  []string
+	}{
+		{
+			name: "older than max age",
+			opts: RotateOptions{MaxAge: 3},
+			files: map[string]int{
+				"goose-2026-03-07.log": 1, "goose-2026-03-08.log": 1, "goose-2026-03-08.1.log": 1,
+				"goose-2026-03-09.log": 1,
+			},
+			want: []string{"goose-2026-03-08.1.log", "goose-2026-03-08.log", "goose-2026-03-09.log", "goose-2026-03-10.log"},
+		},
+		{
+			name: "over the total size, oldest go first",
+			opts: RotateOptions{MaxTotalSize: 200},
+			files: map[string]int{
+				"goose-2026-03-10.log": 50, "goose-2026-03-09.1.log": 100, "goose-2026-03-09.log": 100,
+				"goose-2026-03-08.log": 1,
+			},
+			want: []string{"goose-2026-03-09.1.log", "goose-2026-03-10.log"},
+		},
+		{
+			name:  "other files are left alone",
+			opts:  RotateOptions{MaxAge: 1, MaxTotalSize: 1},
+			files: map[string]int{"goose-2026-01-01.log": 10, "notes.txt": 10, "goose-latest.log": 10, "other-2026-01-01.log": 10},
+			want:  []string{"goose-2026-03-10.log", "goose-latest.log", "notes.txt", "other-2026-01-01.log"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, size := range tt.files {
+				writeFile(t, filepath.Join(dir, name), size)
+			}
+			newTestWriter(t, dir, tt.opts)
+			if got := logNames(t, dir); !slices.Equal(got, tt.want) {
+				t.Errorf("files after startup = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotatingWriterPrunesDaily(t *testing.T) {
+	dir := t.TempDir()
+	w, clock := newTestWriter(t, dir, RotateOptions{MaxAge: 2})
+	for range 3 {
+		if _, err := w.Write([]byte("line\n")); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(24 * time.Hour)
+	}
+	if _, err := w.Write([]byte("line\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"goose-2026-03-12.log", "goose-2026-03-13.log"}
+	if got := logNames(t, dir); !slices.Equal(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestRotatingWriterConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := newTestWriter(t, dir, RotateOptions{MaxFileSize: 1024, MaxTotalSize: 1 << 20})
+
+	const writers, lines = 8, 200
+	var wg sync.WaitGroup
+	for g := range writers {
+		wg.Go(func() {
+			for i := range lines {
+				if _, err := fmt.Fprintf(w, "writer=%d line=%03d %s\n", g, i, strings.Repeat("-", 20)); err != nil {
+					t.Errorf("Write() error = %v", err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for _, name := range logNames(t, dir) {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 1024 {
+			t.Errorf("%s is %d bytes, over the 1024 byte cap", name, info.Size())
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			var g, i int
+			var pad string
+			if n, err := fmt.Sscanf(line, "writer=%d line=%d %s", &g, &i, &pad); n != 3 || err != nil || len(pad) != 20 {
+				t.Errorf("corrupt line %q in %s", line, name)
+				continue
+			}
+			seen[line] = true
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(seen) != writers*lines {
+		t.Errorf("found %d distinct lines, want %d", len(seen), writers*lines)
+	}
+}
@@ -20,14 +20,47 @@ type Entry[T any] struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// FS is the filesystem a Manager reads and writes cache entries through.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Remove(name string) error
+}
+
+// osFS is the FS backed by the os package.
+type osFS struct{}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
 // Manager handles caching of PR metadata with TTL and invalidation logic.
 type Manager struct {
+	fs       FS
 	cacheDir string
 }
 
 // NewManager creates a new cache manager.
 func NewManager(cacheDir string) *Manager {
-	return &Manager{cacheDir: cacheDir}
+	return &Manager{cacheDir: cacheDir, fs: osFS{}}
+}
+
+// NewManagerFS creates a cache manager that reads and writes entries through fsys.
+func NewManagerFS(cacheDir string, fsys FS) *Manager {
+	return &Manager{cacheDir: cacheDir, fs: fsys}
 }
 
 // CacheKey generates a cache key from a URL and timestamp.
@@ -47,11 +80,12 @@ type CacheResult struct {
 	Entry        *Entry[any]
 	Hit          bool // True if cache entry was found and valid
 	ShouldBypass bool // True if cache should be bypassed (e.g., for running tests)
+	Expired      bool // True if an entry for this PR version exists but is older than the TTL
 }
 
 // Get retrieves cached data if valid according to TTL rules.
-func (*Manager) Get(path string, updatedAt time.Time, ttl time.Duration, bypassTTL time.Duration, stateCheck func(any) bool) (*CacheResult, error) {
-	b, err := os.ReadFile(path)
+func (m *Manager) Get(path string, updatedAt time.Time, ttl time.Duration, bypassTTL time.Duration, stateCheck func(any) bool) (*CacheResult, error) {
+	b, err := m.fs.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &CacheResult{}, nil
@@ -62,7 +96,7 @@ func (*Manager) Get(path string, updatedAt time.Time, ttl time.Duration, bypassT
 	var e Entry[any]
 	if err := json.Unmarshal(b, &e); err != nil {
 		// Corrupted cache file - try to remove it
-		if removeErr := os.Remove(path); removeErr != nil {
+		if removeErr := m.fs.Remove(path); removeErr != nil {
 			slog.Debug("Failed to remove corrupted cache file", "path", path, "error", removeErr)
 		}
 		return nil, fmt.Errorf("unmarshal cache: %w", err)
@@ -83,14 +117,14 @@ func (*Manager) Get(path string, updatedAt time.Time, ttl time.Duration, bypassT
 
 	// Check TTL
 	if age >= ttl {
-		return &CacheResult{}, nil
+		return &CacheResult{Expired: true}, nil
 	}
 
 	return &CacheResult{Entry: &e, Hit: true}, nil
 }
 
 // Put stores data in the cache.
-func (*Manager) Put(path string, data any, updatedAt time.Time) error {
+func (m *Manager) Put(path string, data any, updatedAt time.Time) error {
 	e := Entry[any]{
 		Data:      data,
 		CachedAt:  time.Now(),
@@ -102,11 +136,11 @@ func (*Manager) Put(path string, data any, updatedAt time.Time) error {
 		return fmt.Errorf("marshal cache data: %w", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+	if err := m.fs.MkdirAll(filepath.Dir(path), 0o700); err != nil {
 		return fmt.Errorf("create cache directory: %w", err)
 	}
 
-	if err := os.WriteFile(path, b, 0o600); err != nil {
+	if err := m.fs.WriteFile(path, b, 0o600); err != nil {
 		return fmt.Errorf("write cache file: %w", err)
 	}
 
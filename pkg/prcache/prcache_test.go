@@ -178,6 +178,9 @@ func TestGet_CacheMiss_PRUpdated(t *testing.T) {
 	if result.Hit {
 		t.Error("Should not have cache hit when PR was updated")
 	}
+	if result.Expired {
+		t.Error("An entry for an older PR version is stale, not expired")
+	}
 }
 
 func TestGet_CacheMiss_TTLExpired(t *testing.T) {
@@ -210,6 +213,9 @@ func TestGet_CacheMiss_TTLExpired(t *testing.T) {
 	if result.ShouldBypass {
 		t.Error("Should not bypass without state check")
 	}
+	if !result.Expired {
+		t.Error("Expected Expired when the entry is older than the TTL")
+	}
 }
 
 func TestGet_Bypass_WithStateCheck(t *testing.T) {
@@ -8,13 +8,20 @@ import (
 )
 
 // BrowserRateLimiter manages rate limiting for automatically opening browser windows.
+// It also pauses auto-open for a while when recent auto-opened PRs were ignored.
 type BrowserRateLimiter struct {
+	pausedUntil      time.Time // Zero unless auto-open is paused after ignored opens
+	now              func() time.Time
 	openedPRs        map[string]bool
 	openedLastMinute []time.Time
 	openedToday      []time.Time
+	autoOpens        []autoOpen // Recent auto-opens, oldest first
 	startupDelay     time.Duration
+	actedWindow      time.Duration
+	ignoredCooldown  time.Duration
 	maxPerMinute     int
 	maxPerDay        int
+	ignoredOpens     int
 	mu               sync.Mutex
 }
 
@@ -29,6 +36,10 @@ func NewBrowserRateLimiter(startupDelay time.Duration, maxPerMinute, maxPerDay i
 		maxPerMinute:     maxPerMinute,
 		maxPerDay:        maxPerDay,
 		openedPRs:        make(map[string]bool),
+		now:              time.Now,
+		actedWindow:      DefaultActedWindow,
+		ignoredCooldown:  DefaultIgnoredCooldown,
+		ignoredOpens:     DefaultIgnoredOpens,
 	}
 }
 
@@ -55,7 +66,14 @@ func (b *BrowserRateLimiter) CanOpen(startTime time.Time, prURL string) bool {
 		return false
 	}
 
-	now := time.Now()
+	now := b.now()
+
+	// Check whether recent opens were ignored
+	if b.checkPause(now) {
+		slog.Info("[BROWSER] Skipping auto-open: paused after ignored opens",
+			"until", b.pausedUntil.Format(time.Kitchen))
+		return false
+	}
 
 	// Clean old entries
 	b.cleanOldEntries(now)
@@ -78,21 +96,43 @@ func (b *BrowserRateLimiter) CanOpen(startTime time.Time, prURL string) bool {
 	return true
 }
 
-// RecordOpen records that a browser window was opened.
+// RecordOpen records that a browser window was auto-opened.
 func (b *BrowserRateLimiter) RecordOpen(prURL string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	now := time.Now()
+	now := b.now()
 	b.openedLastMinute = append(b.openedLastMinute, now)
 	b.openedToday = append(b.openedToday, now)
 	b.openedPRs[prURL] = true
+	b.recordAutoOpen(prURL, now)
 
 	slog.Info("[BROWSER] Recorded browser open",
 		"url", prURL, "minuteCount", len(b.openedLastMinute), "minuteMax", b.maxPerMinute,
 		"todayCount", len(b.openedToday), "todayMax", b.maxPerDay)
 }
 
+// AllowManualOpen reports whether the per-minute and per-day limits leave room for a
+// window the user asked for, recording the open when they do. Unlike CanOpen it ignores
+// the startup delay and whether the PR was opened before.
+func (b *BrowserRateLimiter) AllowManualOpen(prURL string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.cleanOldEntries(now)
+	if len(b.openedLastMinute) >= b.maxPerMinute || len(b.openedToday) >= b.maxPerDay {
+		slog.Info("[BROWSER] Rate limit reached for manual open",
+			"url", prURL, "minuteCount", len(b.openedLastMinute), "minuteMax", b.maxPerMinute,
+			"todayCount", len(b.openedToday), "todayMax", b.maxPerDay)
+		return false
+	}
+	b.openedLastMinute = append(b.openedLastMinute, now)
+	b.openedToday = append(b.openedToday, now)
+	b.openedPRs[prURL] = true
+	return true
+}
+
 // cleanOldEntries removes entries outside the time windows.
 func (b *BrowserRateLimiter) cleanOldEntries(now time.Time) {
 	// Clean entries older than 1 minute
@@ -116,11 +156,13 @@ func (b *BrowserRateLimiter) cleanOldEntries(now time.Time) {
 	b.openedToday = newToday
 }
 
-// Reset clears the opened PRs tracking - useful when toggling the feature.
+// Reset clears the opened PRs tracking and any pause - useful when toggling the feature.
 func (b *BrowserRateLimiter) Reset() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	previousCount := len(b.openedPRs)
 	clear(b.openedPRs)
+	b.autoOpens = nil
+	b.pausedUntil = time.Time{}
 	slog.Info("[BROWSER] Rate limiter reset", "clearedPRs", previousCount)
 }
@@ -140,6 +140,23 @@ func TestBrowserRateLimiter_RecordOpen(t *testing.T) {
 	limiter.mu.Unlock()
 }
 
+func TestBrowserRateLimiter_AllowManualOpen(t *testing.T) {
+	limiter := NewBrowserRateLimiter(time.Hour, 2, 100)
+	prURL := "https://github.com/owner/repo/pull/1"
+	limiter.RecordOpen(prURL)
+
+	// Manual opens skip the startup delay and may reopen a PR
+	if !limiter.AllowManualOpen(prURL) {
+		t.Error("AllowManualOpen should allow reopening within the budget")
+	}
+	if limiter.AllowManualOpen("https://github.com/owner/repo/pull/2") {
+		t.Error("AllowManualOpen should refuse once the per-minute limit is reached")
+	}
+	if limiter.CanOpen(time.Now().Add(-2*time.Hour), "https://github.com/owner/repo/pull/3") {
+		t.Error("manual opens should consume the auto-open budget")
+	}
+}
+
 func TestBrowserRateLimiter_CleanOldEntries(t *testing.T) {
 	limiter := NewBrowserRateLimiter(1*time.Minute, 10, 100)
 
@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultIgnoredOpens is how many auto-opens in a row must go unacted on before
+	// auto-open pauses.
+	DefaultIgnoredOpens = 5
+	// DefaultActedWindow is how soon after an auto-open the user must clear the PR for
+	// the open to count as acted on.
+	DefaultActedWindow = time.Hour
+	// DefaultIgnoredCooldown is how long auto-open pauses after ignored opens.
+	DefaultIgnoredCooldown = 2 * time.Hour
+
+	// autoOpenHistory bounds the auto-opens kept for the effectiveness ratio.
+	autoOpenHistory = 20
+)
+
+// openOutcome is what became of an auto-opened PR.
+type openOutcome int
+
+const (
+	outcomePending  openOutcome = iota // Opened less than actedWindow ago and not cleared yet
+	outcomeActed                       // Cleared by the user within actedWindow
+	outcomeIgnored                     // Still not cleared after actedWindow
+	outcomeExcluded                    // Cleared by someone else; says nothing about the user
+)
+
+// autoOpen is one PR auto-open and, once known, when and by whom the PR was cleared.
+type autoOpen struct {
+	openedAt  time.Time
+	clearedAt time.Time
+	url       string
+	byMe      bool
+}
+
+func (o autoOpen) outcome(now time.Time, window time.Duration) openOutcome {
+	switch {
+	case !o.clearedAt.IsZero() && o.clearedAt.Sub(o.openedAt) <= window:
+		if o.byMe {
+			return outcomeActed
+		}
+		return outcomeExcluded
+	case now.Sub(o.openedAt) >= window:
+		return outcomeIgnored
+	default:
+		return outcomePending
+	}
+}
+
+// RecordCleared notes that a PR left the blocked state, by the user's own action or
+// someone else's. It settles any auto-open of the PR that hasn't been settled yet.
+func (b *BrowserRateLimiter) RecordCleared(prURL string, byMe bool, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := range b.autoOpens {
+		o := &b.autoOpens[i]
+		if o.url != prURL || !o.clearedAt.IsZero() || at.Before(o.openedAt) {
+			continue
+		}
+		o.clearedAt = at
+		o.byMe = byMe
+		slog.Debug("[BROWSER] Auto-opened PR cleared", "url", prURL, "by_me", byMe, "after", at.Sub(o.openedAt).Round(time.Second))
+	}
+}
+
+// AutoOpenEffectiveness returns the share of settled auto-opens the user acted on, and
+// how many settled auto-opens that share is based on.
+func (b *BrowserRateLimiter) AutoOpenEffectiveness() (ratio float64, samples int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.effectiveness(b.now())
+}
+
+// AutoOpenPaused reports whether auto-open is paused because recent opens were ignored,
+// and when it resumes.
+func (b *BrowserRateLimiter) AutoOpenPaused() (until time.Time, paused bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.checkPause(b.now()) {
+		return b.pausedUntil, true
+	}
+	return time.Time{}, false
+}
+
+// ResumeAutoOpen ends a pause early, as when the user opens a PR from the menu, and
+// reports whether auto-open was paused.
+func (b *BrowserRateLimiter) ResumeAutoOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pausedUntil.IsZero() {
+		return false
+	}
+	b.pausedUntil = time.Time{}
+	slog.Info("[BROWSER] Auto-open resumed")
+	return true
+}
+
+// effectiveness computes AutoOpenEffectiveness. Caller must hold b.mu.
+func (b *BrowserRateLimiter) effectiveness(now time.Time) (ratio float64, samples int) {
+	acted := 0
+	for _, o := range b.autoOpens {
+		switch o.outcome(now, b.actedWindow) {
+		case outcomeActed:
+			acted++
+			samples++
+		case outcomeIgnored:
+			samples++
+		default:
+		}
+	}
+	if samples == 0 {
+		return 0, 0
+	}
+	return float64(acted) / float64(samples), samples
+}
+
+// checkPause reports whether auto-open is paused at now, starting a pause when the last
+// ignoredOpens auto-opens (not counting PRs someone else cleared) were all ignored. With
+// fewer samples, or one still pending, it never pauses. Caller must hold b.mu.
+func (b *BrowserRateLimiter) checkPause(now time.Time) bool {
+	if !b.pausedUntil.IsZero() {
+		if now.Before(b.pausedUntil) {
+			return true
+		}
+		b.pausedUntil = time.Time{}
+		slog.Info("[BROWSER] Auto-open cooldown over, resuming")
+	}
+	if b.ignoredOpens <= 0 {
+		return false
+	}
+
+	seen := 0
+	for i := len(b.autoOpens) - 1; i >= 0 && seen < b.ignoredOpens; i-- {
+		switch b.autoOpens[i].outcome(now, b.actedWindow) {
+		case outcomeExcluded:
+			continue
+		case outcomeIgnored:
+			seen++
+		default:
+			return false
+		}
+	}
+	if seen < b.ignoredOpens {
+		return false
+	}
+
+	ratio, samples := b.effectiveness(now)
+	b.pausedUntil = now.Add(b.ignoredCooldown)
+	// Start over, so the same ignored opens don't pause again once the cooldown ends
+	b.autoOpens = nil
+	slog.Warn("[BROWSER] Auto-open paused, recent opens weren't acted on",
+		"ignored", seen, "effectiveness", ratio, "samples", samples, "until", b.pausedUntil.Format(time.Kitchen))
+	return true
+}
+
+// recordAutoOpen adds an auto-open to the history. Caller must hold b.mu.
+func (b *BrowserRateLimiter) recordAutoOpen(prURL string, now time.Time) {
+	b.autoOpens = append(b.autoOpens, autoOpen{url: prURL, openedAt: now})
+	if len(b.autoOpens) > autoOpenHistory {
+		b.autoOpens = b.autoOpens[len(b.autoOpens)-autoOpenHistory:]
+	}
+}
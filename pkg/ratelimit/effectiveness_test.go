@@ -0,0 +1,234 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable clock for the limiter.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newEffectivenessLimiter() (*BrowserRateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	limiter := NewBrowserRateLimiter(0, 100, 1000)
+	limiter.now = clock.now
+	return limiter, clock
+}
+
+func prURL(n int) string {
+	return fmt.Sprintf("https://github.com/org/repo/pull/%d", n)
+}
+
+// step is one event in a synthetic auto-open history.
+type step struct {
+	open    int           // Auto-open this PR
+	cleared int           // This PR was cleared...
+	byMe    bool          // ...by the user
+	wait    time.Duration // Then let this much time pass
+}
+
+func replay(limiter *BrowserRateLimiter, clock *fakeClock, steps []step) {
+	for _, s := range steps {
+		if s.open != 0 {
+			limiter.RecordOpen(prURL(s.open))
+		}
+		if s.cleared != 0 {
+			limiter.RecordCleared(prURL(s.cleared), s.byMe, clock.now())
+		}
+		clock.advance(s.wait)
+	}
+}
+
+func TestAutoOpenPauseHeuristic(t *testing.T) {
+	const gap = 10 * time.Minute
+	ignored := func(n int) []step {
+		var steps []step
+		for i := 1; i <= n; i++ {
+			steps = append(steps, step{open: i, wait: gap})
+		}
+		return steps
+	}
+	tests := []struct {
+		name       string
+		steps      []step
+		wantPaused bool
+	}{
+		{
+			name:  "no history",
+			steps: nil,
+		},
+		{
+			name:  "fewer than five samples, all ignored",
+			steps: append(ignored(4), step{wait: 3 * time.Hour}),
+		},
+		{
+			name:       "five ignored",
+			steps:      append(ignored(5), step{wait: time.Hour}),
+			wantPaused: true,
+		},
+		{
+			name:  "five opened but the newest is still within the hour",
+			steps: append(ignored(5), step{wait: time.Hour - 5*gap}),
+		},
+		{
+			name: "one of the last five acted on",
+			steps: []step{
+				{open: 1, wait: gap}, {open: 2, wait: gap}, {open: 3, wait: gap},
+				{cleared: 2, byMe: true}, {open: 4, wait: gap}, {open: 5, wait: gap},
+				{wait: 2 * time.Hour},
+			},
+		},
+		{
+			name: "acted on too late still counts as ignored",
+			steps: append(ignored(5),
+				step{wait: 2 * time.Hour},
+				step{cleared: 1, byMe: true}),
+			wantPaused: true,
+		},
+		{
+			name: "older acted-on opens don't save the last five",
+			steps: append([]step{{open: 100}, {cleared: 100, byMe: true, wait: gap}},
+				append(ignored(5), step{wait: time.Hour})...),
+			wantPaused: true,
+		},
+		{
+			name: "PRs someone else cleared don't count",
+			steps: append(append(ignored(4),
+				step{open: 50}, step{cleared: 50, byMe: false, wait: gap}),
+				step{wait: 2 * time.Hour}),
+		},
+		{
+			name: "someone else's clears are skipped, not counted as acted",
+			steps: append(append(ignored(5),
+				step{open: 50}, step{cleared: 50, byMe: false, wait: gap}),
+				step{wait: 2 * time.Hour}),
+			wantPaused: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, clock := newEffectivenessLimiter()
+			replay(limiter, clock, tt.steps)
+			if _, paused := limiter.AutoOpenPaused(); paused != tt.wantPaused {
+				t.Errorf("AutoOpenPaused() = %v, want %v", paused, tt.wantPaused)
+			}
+			if got := limiter.CanOpen(time.Time{}, prURL(999)); got == tt.wantPaused {
+				t.Errorf("CanOpen() = %v while paused=%v", got, tt.wantPaused)
+			}
+		})
+	}
+}
+
+func TestAutoOpenPauseCooldown(t *testing.T) {
+	limiter, clock := newEffectivenessLimiter()
+	for i := 1; i <= DefaultIgnoredOpens; i++ {
+		limiter.RecordOpen(prURL(i))
+		clock.advance(time.Minute)
+	}
+	clock.advance(time.Hour)
+
+	until, paused := limiter.AutoOpenPaused()
+	if !paused || !until.Equal(clock.now().Add(DefaultIgnoredCooldown)) {
+		t.Fatalf("AutoOpenPaused() = %v, %v, want paused for %v", until, paused, DefaultIgnoredCooldown)
+	}
+
+	clock.advance(DefaultIgnoredCooldown - time.Minute)
+	if _, paused := limiter.AutoOpenPaused(); !paused {
+		t.Error("resumed before the cooldown ended")
+	}
+	clock.advance(time.Minute)
+	if _, paused := limiter.AutoOpenPaused(); paused {
+		t.Error("still paused after the cooldown")
+	}
+	// The ignored opens that caused the pause don't cause another one
+	clock.advance(time.Hour)
+	if _, paused := limiter.AutoOpenPaused(); paused {
+		t.Error("paused again on the same history")
+	}
+	if !limiter.CanOpen(time.Time{}, prURL(100)) {
+		t.Error("CanOpen() = false after the cooldown")
+	}
+}
+
+func TestResumeAutoOpen(t *testing.T) {
+	limiter, clock := newEffectivenessLimiter()
+	if limiter.ResumeAutoOpen() {
+		t.Error("ResumeAutoOpen() = true while not paused")
+	}
+	for i := 1; i <= DefaultIgnoredOpens; i++ {
+		limiter.RecordOpen(prURL(i))
+	}
+	clock.advance(2 * time.Hour)
+	if _, paused := limiter.AutoOpenPaused(); !paused {
+		t.Fatal("expected a pause")
+	}
+
+	if !limiter.ResumeAutoOpen() {
+		t.Error("ResumeAutoOpen() = false while paused")
+	}
+	if _, paused := limiter.AutoOpenPaused(); paused {
+		t.Error("still paused after ResumeAutoOpen")
+	}
+
+	// Reset, as when auto-open is toggled, also ends a pause
+	for i := 10; i < 10+DefaultIgnoredOpens; i++ {
+		limiter.RecordOpen(prURL(i))
+	}
+	clock.advance(2 * time.Hour)
+	if _, paused := limiter.AutoOpenPaused(); !paused {
+		t.Fatal("expected a second pause")
+	}
+	limiter.Reset()
+	if _, paused := limiter.AutoOpenPaused(); paused {
+		t.Error("still paused after Reset")
+	}
+}
+
+func TestAutoOpenEffectiveness(t *testing.T) {
+	limiter, clock := newEffectivenessLimiter()
+	if ratio, samples := limiter.AutoOpenEffectiveness(); ratio != 0 || samples != 0 {
+		t.Errorf("empty history: %v over %d samples, want none", ratio, samples)
+	}
+
+	replay(limiter, clock, []step{
+		{open: 1}, {open: 2}, {open: 3}, {open: 4}, {open: 5},
+		{cleared: 1, byMe: true, wait: 10 * time.Minute},
+		{cleared: 2, byMe: false, wait: 10 * time.Minute},
+		{cleared: 3, byMe: true, wait: 2 * time.Hour},
+		{open: 6}, // Pending: neither acted on nor ignored yet
+	})
+	ratio, samples := limiter.AutoOpenEffectiveness()
+	if samples != 4 || ratio != 0.5 {
+		t.Errorf("AutoOpenEffectiveness() = %v over %d samples, want 0.5 over 4", ratio, samples)
+	}
+}
+
+func TestRecordClearedIgnoresEarlierClears(t *testing.T) {
+	limiter, clock := newEffectivenessLimiter()
+	before := clock.now()
+	clock.advance(time.Minute)
+	limiter.RecordOpen(prURL(1))
+	// A clear from before the open, e.g. an earlier block episode, doesn't settle it
+	limiter.RecordCleared(prURL(1), true, before)
+	clock.advance(2 * time.Hour)
+	if ratio, samples := limiter.AutoOpenEffectiveness(); samples != 1 || ratio != 0 {
+		t.Errorf("AutoOpenEffectiveness() = %v over %d samples, want the open ignored", ratio, samples)
+	}
+}
+
+func TestAutoOpenHistoryBounded(t *testing.T) {
+	limiter, _ := newEffectivenessLimiter()
+	for i := range 3 * autoOpenHistory {
+		limiter.RecordOpen(prURL(i))
+	}
+	if len(limiter.autoOpens) != autoOpenHistory {
+		t.Errorf("history has %d entries, want %d", len(limiter.autoOpens), autoOpenHistory)
+	}
+}
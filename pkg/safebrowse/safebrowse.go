@@ -10,10 +10,23 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
+
+	"github.com/codeGROOVE-dev/goose/pkg/ghref"
 )
 
 const maxURLLength = 2048
 
+// allowedHosts lists the hosts ValidateAllowedHost accepts. Self-hosted deployments add theirs with AllowHost.
+var (
+	allowedHostsMu sync.RWMutex
+	allowedHosts   = map[string]bool{
+		"github.com":           true,
+		"my.reviewgoose.dev":   true,
+		"www.githubstatus.com": true,
+	}
+)
+
 // Open validates and opens a URL in the system browser.
 func Open(ctx context.Context, rawURL string) error {
 	if err := validate(rawURL, false); err != nil {
@@ -24,27 +37,54 @@ func Open(ctx context.Context, rawURL string) error {
 
 // OpenWithParams validates and opens a URL with query parameters.
 func OpenWithParams(ctx context.Context, rawURL string, params map[string]string) error {
-	if err := validate(rawURL, false); err != nil {
+	finalURL, err := WithParams(rawURL, params)
+	if err != nil {
 		return err
 	}
+	return openBrowser(ctx, finalURL)
+}
+
+// WithParams validates a URL and returns it with query parameters set. A query the URL
+// already carries must follow the same rules as params; without params, the URL is
+// returned unchanged.
+func WithParams(rawURL string, params map[string]string) (string, error) {
+	if err := validate(rawURL, true); err != nil {
+		return "", err
+	}
 
 	u, err := url.Parse(rawURL)
 	if err != nil {
-		return fmt.Errorf("parse url: %w", err)
+		return "", fmt.Errorf("parse url: %w", err)
 	}
 
-	// Validate parameters before encoding
+	// Validate the existing query and the parameters before encoding
+	q, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", fmt.Errorf("parse query: %w", err)
+	}
+	for key, values := range q {
+		if err := validateParamString(key); err != nil {
+			return "", fmt.Errorf("invalid query key %q: %w", key, err)
+		}
+		for _, value := range values {
+			if err := validateParamString(value); err != nil {
+				return "", fmt.Errorf("invalid query value %q: %w", value, err)
+			}
+		}
+	}
 	for key, value := range params {
 		if err := validateParamString(key); err != nil {
-			return fmt.Errorf("invalid parameter key %q: %w", key, err)
+			return "", fmt.Errorf("invalid parameter key %q: %w", key, err)
 		}
 		if err := validateParamString(value); err != nil {
-			return fmt.Errorf("invalid parameter value %q: %w", value, err)
+			return "", fmt.Errorf("invalid parameter value %q: %w", value, err)
 		}
 	}
+	if len(params) == 0 {
+		return rawURL, nil
+	}
 
 	// Build query string
-	q := u.Query()
 	for key, value := range params {
 		q.Set(key, value)
 	}
@@ -53,14 +93,54 @@ func OpenWithParams(ctx context.Context, rawURL string, params map[string]string
 	// Validate the final URL after encoding to catch any encoding issues
 	finalURL := u.String()
 	if strings.Contains(finalURL, "%") {
-		return errors.New("URL encoding produced unsafe characters")
+		return "", errors.New("URL encoding produced unsafe characters")
 	}
 
 	if err := validate(finalURL, true); err != nil {
-		return err
+		return "", err
 	}
 
-	return openBrowser(ctx, finalURL)
+	return finalURL, nil
+}
+
+// WithAnchor validates a URL like WithParams and returns it with a comment anchor
+// appended, e.g. "#discussion_r123". URLs are otherwise never allowed fragments, so only
+// GitHub's comment anchors are: a known prefix followed by a numeric ID.
+func WithAnchor(rawURL, anchor string) (string, error) {
+	if err := validate(rawURL, true); err != nil {
+		return "", err
+	}
+	if err := validateAnchor(anchor); err != nil {
+		return "", fmt.Errorf("invalid anchor %q: %w", anchor, err)
+	}
+	finalURL := rawURL + "#" + anchor
+	if len(finalURL) > maxURLLength {
+		return "", fmt.Errorf("URL exceeds maximum length of %d", maxURLLength)
+	}
+	return finalURL, nil
+}
+
+// anchorPrefixes are the GitHub comment anchors WithAnchor accepts.
+var anchorPrefixes = []string{"discussion_r", "issuecomment-", "pullrequestreview-"}
+
+// validateAnchor checks that an anchor is one of anchorPrefixes followed by digits.
+func validateAnchor(anchor string) error {
+	for _, prefix := range anchorPrefixes {
+		id, ok := strings.CutPrefix(anchor, prefix)
+		if !ok {
+			continue
+		}
+		if id == "" || len(id) > 20 {
+			return errors.New("missing or oversized comment ID")
+		}
+		for _, r := range id {
+			if r < '0' || r > '9' {
+				return fmt.Errorf("contains invalid character %q", r)
+			}
+		}
+		return nil
+	}
+	return errors.New("not a comment anchor")
 }
 
 // ValidateURL performs strict security validation on a URL.
@@ -89,14 +169,15 @@ func ValidateGitHubPRURL(rawURL string) error {
 		return errors.New("must match format: /{owner}/{repo}/pull/{number}")
 	}
 
-	// Validate PR number (must start with 1-9)
-	if parts[3] == "" || parts[3][0] < '1' || parts[3][0] > '9' {
-		return errors.New("PR number must start with 1-9")
+	// Owner and repo follow the same rules the app uses to parse PR URLs
+	if !ghref.IsLogin(parts[0]) {
+		return fmt.Errorf("invalid owner: %q", parts[0])
 	}
-	for _, c := range parts[3] {
-		if c < '0' || c > '9' {
-			return errors.New("PR number must be digits only")
-		}
+	if !ghref.IsRepoName(parts[1]) {
+		return fmt.Errorf("invalid repository name: %q", parts[1])
+	}
+	if _, err := ghref.ParseNumber(parts[3]); err != nil {
+		return err
 	}
 
 	// If query params exist, only allow ?goose= (no other params or & characters)
@@ -109,6 +190,39 @@ func ValidateGitHubPRURL(rawURL string) error {
 	return nil
 }
 
+// AllowHost adds a host to the allowlist used by ValidateAllowedHost.
+func AllowHost(host string) error {
+	host = strings.ToLower(host)
+	if err := validateSafeChars(host); err != nil {
+		return fmt.Errorf("invalid host: %w", err)
+	}
+	if host == "" || strings.Contains(host, "/") || strings.Contains(host, "..") {
+		return fmt.Errorf("invalid host %q", host)
+	}
+	allowedHostsMu.Lock()
+	allowedHosts[host] = true
+	allowedHostsMu.Unlock()
+	return nil
+}
+
+// ValidateAllowedHost validates a URL like ValidateURL and requires its host to be on the allowlist.
+func ValidateAllowedHost(rawURL string) error {
+	if err := validate(rawURL, false); err != nil {
+		return err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+	allowedHostsMu.RLock()
+	ok := allowedHosts[strings.ToLower(u.Host)]
+	allowedHostsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("host %q not allowed", u.Host)
+	}
+	return nil
+}
+
 // validate performs the core validation logic.
 func validate(rawURL string, allowParams bool) error {
 	if rawURL == "" {
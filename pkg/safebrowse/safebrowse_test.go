@@ -657,6 +657,21 @@ func TestValidateGitHubPRURL_EdgeCases(t *testing.T) {
 			url:     "https://github.com/owner/repo_name/pull/123",
 			wantErr: false,
 		},
+		{
+			name:    "valid with EMU owner",
+			url:     "https://github.com/jane.doe_acmecorp/dotfiles/pull/4",
+			wantErr: false,
+		},
+		{
+			name:    "owner ending with hyphen",
+			url:     "https://github.com/owner-/repo/pull/1",
+			wantErr: true,
+		},
+		{
+			name:    "repo is dot-dot",
+			url:     "https://github.com/owner/../pull/1",
+			wantErr: true,
+		},
 		{
 			name:    "missing pull segment",
 			url:     "https://github.com/owner/repo/123",
@@ -726,3 +741,87 @@ func TestOpenWithParams_MultipleValidParams(t *testing.T) {
 		t.Errorf("OpenWithParams with valid params should not fail validation: %v", err)
 	}
 }
+
+func TestValidateAllowedHost(t *testing.T) {
+	if err := ValidateAllowedHost("https://github.com/owner/repo/pull/1"); err != nil {
+		t.Errorf("github.com rejected: %v", err)
+	}
+	if err := ValidateAllowedHost("https://dash.example.com/pr/owner/repo/1"); err == nil {
+		t.Error("unlisted host accepted")
+	}
+
+	if err := AllowHost("Dash.Example.com"); err != nil {
+		t.Fatalf("AllowHost() error = %v", err)
+	}
+	if err := ValidateAllowedHost("https://dash.example.com/pr/owner/repo/1"); err != nil {
+		t.Errorf("host rejected after AllowHost: %v", err)
+	}
+	if err := ValidateAllowedHost("https://evil.example.com/pr/owner/repo/1"); err == nil {
+		t.Error("sibling host accepted")
+	}
+
+	for _, host := range []string{"", "example.com:8443", "example.com/path", "exa mple.com", "..", "user@example.com"} {
+		if err := AllowHost(host); err == nil {
+			t.Errorf("AllowHost(%q) = nil, want error", host)
+		}
+	}
+}
+
+func TestWithParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		params  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "adds a parameter", rawURL: "https://github.com/owner/repo/pull/123", params: map[string]string{"goose": "1"}, want: "https://github.com/owner/repo/pull/123?goose=1"},
+		{name: "keeps an existing query", rawURL: "https://github.com/owner/repo/pull/123/files?w=1", params: map[string]string{"goose": "review"}, want: "https://github.com/owner/repo/pull/123/files?goose=review&w=1"},
+		{name: "replaces an existing value", rawURL: "https://github.com/owner/repo/pull/123?goose=1", params: map[string]string{"goose": "review"}, want: "https://github.com/owner/repo/pull/123?goose=review"},
+		{name: "no parameters leave the URL alone", rawURL: "https://github.com/owner/repo/pull/123/checks?w=1&tab=x", want: "https://github.com/owner/repo/pull/123/checks?w=1&tab=x"},
+		{name: "unsafe existing query", rawURL: "https://github.com/owner/repo/pull/123?q=a;b", wantErr: true},
+		{name: "empty existing value", rawURL: "https://github.com/owner/repo/pull/123?q=", wantErr: true},
+		{name: "unsafe parameter", rawURL: "https://github.com/owner/repo/pull/123", params: map[string]string{"goose": "a b"}, wantErr: true},
+		{name: "fragment", rawURL: "https://github.com/owner/repo/pull/123#top", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithParams(tt.rawURL, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WithParams() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithAnchor(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		anchor  string
+		want    string
+		wantErr bool
+	}{
+		{name: "review comment", rawURL: "https://github.com/owner/repo/pull/123", anchor: "discussion_r456", want: "https://github.com/owner/repo/pull/123#discussion_r456"},
+		{name: "keeps the query", rawURL: "https://github.com/owner/repo/pull/123?goose=respond", anchor: "issuecomment-7", want: "https://github.com/owner/repo/pull/123?goose=respond#issuecomment-7"},
+		{name: "arbitrary anchor", rawURL: "https://github.com/owner/repo/pull/123", anchor: "top", wantErr: true},
+		{name: "missing ID", rawURL: "https://github.com/owner/repo/pull/123", anchor: "discussion_r", wantErr: true},
+		{name: "non-numeric ID", rawURL: "https://github.com/owner/repo/pull/123", anchor: "discussion_r1\"><script>", wantErr: true},
+		{name: "existing fragment", rawURL: "https://github.com/owner/repo/pull/123#top", anchor: "discussion_r1", wantErr: true},
+		{name: "unsafe URL", rawURL: "http://github.com/owner/repo/pull/123", anchor: "discussion_r1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithAnchor(tt.rawURL, tt.anchor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithAnchor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WithAnchor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}